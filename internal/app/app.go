@@ -8,13 +8,20 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	_ "github.com/taskflow/docs" // 导入Swagger文档
+	appHandlers "github.com/taskflow/internal/application/handlers"
 	appUserService "github.com/taskflow/internal/application/service"
+	domainAggregate "github.com/taskflow/internal/domain/aggregate"
+	authRepository "github.com/taskflow/internal/domain/auth/repository"
 	"github.com/taskflow/internal/domain/auth/service"
 	"github.com/taskflow/internal/domain/auth/valueobject"
 	domainService "github.com/taskflow/internal/domain/service"
+	domainValueObject "github.com/taskflow/internal/domain/valueobject"
 	"github.com/taskflow/internal/infrastructure/config"
+	infraEvents "github.com/taskflow/internal/infrastructure/events"
 	"github.com/taskflow/internal/infrastructure/messaging/memory"
+	"github.com/taskflow/internal/infrastructure/persistence/cache"
 	"github.com/taskflow/internal/infrastructure/persistence/mysql"
 	"github.com/taskflow/internal/infrastructure/security"
 	"github.com/taskflow/internal/infrastructure/validation"
@@ -28,10 +35,16 @@ import (
 type App struct {
 	config         *config.Config
 	db             *gorm.DB
+	cache          cache.Interface
 	httpServer     *httpServer.Server
 	transactionMgr service.TransactionManager
 	jwtService     service.JWTService
 	userAppService *appUserService.UserAppService
+	eventBus       *memory.InMemoryEventBus
+	webhooks       *appHandlers.WebhookDispatcher
+	retention      *appUserService.RetentionAppService // 未启用数据保留策略时为 nil
+	reminders      *appUserService.ReminderAppService
+	stopJobs       context.CancelFunc
 }
 
 // NewApp 创建新的应用程序实例
@@ -96,21 +109,27 @@ func NewApp(configPath string) (*App, error) {
 		Issuer:             cfg.App.Name,
 	})
 
+	// 6.1. 创建缓存，Redis故障时由熔断器降级为直接查库
+	var projectCache cache.Interface
+	if cfg.Redis.Enabled {
+		projectCache = newRedisCache(&cfg.Redis)
+	}
+
 	// 7. 创建仓储层
 	userRepo := mysql.NewUserRepository(db)
 	taskRepo := mysql.NewTaskRepository(db)
-	projectRepo := mysql.NewProjectRepository(db, nil)
+	projectRepo := mysql.NewProjectRepository(db, projectCache)
 	departmentRepo := mysql.NewDepartmentRepository(db)
 
 	// 7.1. 创建用户验证器和密码哈希器
 	userValidator := validation.NewUserValidator()
 	passwordHasher := security.NewPasswordHasher()
 
-	pubStore := memory.NewInMemoryEventStore(100)
+	pubStore := mysql.NewEventStore(db)
 	// 7.2. 创建事件发布器
 
 	userEventPublisher := memory.NewInMemoryEventBus(memory.EventBusConfig{BufferSize: cfg.EventBusStore.BufferSize,
-		MaxRetries: cfg.EventBusStore.BufferSize,
+		MaxRetries: cfg.EventBusStore.MaxRetries,
 		RetryDelay: time.Duration(cfg.EventBusStore.RetryDelay * int(time.Millisecond)),
 	}, pubStore)
 
@@ -133,16 +152,154 @@ func NewApp(configPath string) (*App, error) {
 		passwordHasher,
 	)
 
-	// 9. 创建HTTP服务器
-	httpSrv := httpServer.NewServer(cfg, jwtService, userAppService)
+	// 7.4. 创建功能开关服务，按环境和项目开启新功能
+	featureFlagAppService := appUserService.NewFeatureFlagAppService(featureFlagPolicy(cfg))
+
+	// 8. 创建项目服务
+	projectDomainService := domainService.NewProjectDomainService(projectRepo, userRepo)
+	projectAppService := appUserService.NewProjectAppService(
+		projectDomainService,
+		transactionMgr,
+		projectRepo,
+		domainValueObject.NewUUIDGenerator(),
+	).WithEventBus(userEventPublisher).WithTaskRepository(taskRepo).
+		WithFeatureFlags(featureFlagAppService).
+		WithTextLimits(domainValueObject.TextLimits{
+			NameMaxLength:        cfg.Project.NameMaxLength,
+			DescriptionMaxLength: cfg.Project.DescriptionMaxLength,
+		})
+
+	// 9. 创建任务服务
+	taskDomainService := domainService.NewTaskDomainService(taskRepo, userRepo, projectRepo)
+	taskAppService := appUserService.NewTaskAppService(
+		taskDomainService,
+		transactionMgr,
+		taskRepo,
+		domainAggregate.NewTaskFactory(validation.NewTaskValidator(), domainValueObject.NewUUIDGenerator()).
+			WithMaxParticipants(cfg.Task.MaxParticipants).
+			WithTextLimits(domainValueObject.TextLimits{
+				NameMaxLength:        cfg.Task.TitleMaxLength,
+				DescriptionMaxLength: cfg.Task.DescriptionMaxLength,
+			}),
+	).WithEventBus(userEventPublisher).
+		WithFileRepository(mysql.NewFileRepository(db)).
+		WithEventStore(pubStore).
+		WithStaleApprovedAge(time.Duration(cfg.Task.StaleApprovedHours) * time.Hour).
+		WithEstimateOverrunPercent(float64(cfg.Task.EstimateOverrunPercent))
+
+	// 10. 创建权限服务
+	permissionRepo := mysql.NewPermissionRepository(db)
+	roleRepo := mysql.NewRoleRepository(db)
+	policyRepo := mysql.NewPolicyRepository(db)
+	permissionDomainService := service.NewPermissionDomainService(
+		permissionRepo,
+		roleRepo,
+		policyRepo,
+		mysql.NewUserRoleRepository(db),
+		authRepository.NewRBACAbacEvaluator(permissionRepo, roleRepo, policyRepo),
+		transactionMgr,
+	)
+	permissionAppService := appUserService.NewPermissionAppService(permissionDomainService, transactionMgr)
+	currentUserAppService := appUserService.NewCurrentUserAppService(userRepo, projectRepo, permissionDomainService)
+	eventAppService := appUserService.NewEventAppService(pubStore)
+
+	// 10.1. 创建webhook订阅服务，并将事件投递处理器订阅到事件总线
+	webhookRepo := mysql.NewWebhookRepository(db)
+	webhookDispatcher := appHandlers.NewWebhookDispatcher(webhookRepo, appHandlers.WebhookDispatcherConfig{
+		MaxAttempts:    cfg.Webhook.MaxAttempts,
+		InitialBackoff: time.Duration(cfg.Webhook.RetryBackoffMs) * time.Millisecond,
+		Timeout:        time.Duration(cfg.Webhook.TimeoutSeconds) * time.Second,
+		MaxFailures:    cfg.Webhook.MaxFailures,
+		Workers:        cfg.Webhook.Workers,
+		QueueSize:      cfg.Webhook.QueueSize,
+	})
+	webhookAppService := appUserService.NewWebhookAppService(webhookRepo).WithRedeliverer(webhookDispatcher)
+	for _, eventType := range webhookDispatcher.EventTypes() {
+		if err := userEventPublisher.Subscribe(eventType, webhookDispatcher); err != nil {
+			return nil, fmt.Errorf("failed to subscribe webhook dispatcher: %w", err)
+		}
+	}
+
+	// 10.2. 创建全局搜索服务
+	searchAppService := appUserService.NewSearchAppService(taskRepo, projectRepo)
+
+	// 10.3. 创建审批查询服务
+	approvalAppService := appUserService.NewApprovalAppService(taskRepo, userRepo)
+
+	// 10.4. 创建站内通知服务，并将通知处理器订阅到事件总线
+	notificationRepo := mysql.NewNotificationRepository(db)
+	notificationAppService := appUserService.NewNotificationAppService(notificationRepo)
+	inAppNotifier := appHandlers.NewInAppNotifier(notificationRepo, userRepo, taskRepo)
+	for _, eventType := range inAppNotifier.EventTypes() {
+		if err := userEventPublisher.Subscribe(eventType, inAppNotifier); err != nil {
+			return nil, fmt.Errorf("failed to subscribe in-app notifier: %w", err)
+		}
+	}
+	// 邮件通知按接收人的通知偏好和静默时段过滤
+	emailNotifier := appHandlers.NewNotificationHandler(&infraEvents.MockEmailService{}, &infraEvents.MockSMSService{}).
+		WithUserRepository(userRepo)
+	for _, eventType := range emailNotifier.EventTypes() {
+		if err := userEventPublisher.Subscribe(eventType, emailNotifier); err != nil {
+			return nil, fmt.Errorf("failed to subscribe email notifier: %w", err)
+		}
+	}
+
+	// 10.5. 任务变化时同步项目的任务计数
+	projectStatisticsSyncer := appHandlers.NewProjectStatisticsSyncer(projectRepo, taskRepo)
+	for _, eventType := range projectStatisticsSyncer.EventTypes() {
+		if err := userEventPublisher.Subscribe(eventType, projectStatisticsSyncer); err != nil {
+			return nil, fmt.Errorf("failed to subscribe project statistics syncer: %w", err)
+		}
+	}
+	// 成员关系或角色变化时立即清除项目和权限缓存
+	if projectCache != nil {
+		permissionCacheHandler := appHandlers.NewPermissionCacheHandler(projectCache)
+		for _, eventType := range permissionCacheHandler.EventTypes() {
+			if err := userEventPublisher.Subscribe(eventType, permissionCacheHandler); err != nil {
+				return nil, fmt.Errorf("failed to subscribe permission cache handler: %w", err)
+			}
+		}
+	}
+
+	// 10.6. 创建软删除数据保留服务，启用后在后台定期清理
+	var retentionAppService *appUserService.RetentionAppService
+	if cfg.Retention.Enabled {
+		retentionAppService = appUserService.NewRetentionAppService(
+			mysql.NewRetentionRepository(db),
+			transactionMgr,
+			time.Duration(cfg.Retention.Days)*24*time.Hour,
+		).WithDryRun(cfg.Retention.DryRun)
+	}
+
+	// 10.7. 创建截止日期提醒服务，启用后在后台定期检查
+	reminderAppService := appUserService.NewReminderAppService(
+		taskRepo,
+		mysql.NewReminderRepository(db),
+		userEventPublisher,
+		reminderPolicy(cfg.Reminder),
+	).WithFeatureFlags(featureFlagAppService).WithUserRepository(userRepo)
+
+	// 10.8. 创建用户API密钥服务，认证中间件据此接受 X-Api-Key 请求头
+	apiKeyAppService := appUserService.NewAPIKeyAppService(mysql.NewAPIKeyRepository(db), userRepo)
+
+	// 10.9. 创建管理员模拟用户服务，模拟期间的请求记录在操作日志中
+	impersonationAppService := appUserService.NewImpersonationAppService(transactionMgr, userRepo, mysql.NewOperationLogRepository(db), jwtService)
+
+	// 11. 创建HTTP服务器
+	httpSrv := httpServer.NewServer(cfg, jwtService, userAppService, projectAppService, taskAppService, currentUserAppService, eventAppService, webhookAppService, searchAppService, approvalAppService, notificationAppService, reminderAppService, apiKeyAppService, impersonationAppService, permissionAppService, featureFlagAppService)
 
 	app := &App{
 		config:         cfg,
 		db:             db,
+		cache:          projectCache,
 		httpServer:     httpSrv,
 		transactionMgr: transactionMgr,
 		jwtService:     jwtService,
 		userAppService: userAppService,
+		eventBus:       userEventPublisher,
+		webhooks:       webhookDispatcher,
+		retention:      retentionAppService,
+		reminders:      reminderAppService,
 	}
 
 	return app, nil
@@ -152,6 +309,29 @@ func NewApp(configPath string) (*App, error) {
 func (a *App) Run() error {
 	logger.Info("Starting TaskFlow application...")
 
+	// 启动事件总线，事件处理器（如webhook投递）在后台执行
+	if err := a.eventBus.Start(); err != nil {
+		return fmt.Errorf("failed to start event bus: %w", err)
+	}
+
+	// 启动后台定时任务
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	a.stopJobs = stopJobs
+	if a.retention != nil {
+		interval := time.Duration(a.config.Retention.IntervalHours) * time.Hour
+		if interval <= 0 {
+			interval = 24 * time.Hour
+		}
+		go a.retention.RunSchedule(jobsCtx, interval)
+	}
+	if a.config.Reminder.Enabled {
+		interval := time.Duration(a.config.Reminder.IntervalMinutes) * time.Minute
+		if interval <= 0 {
+			interval = 5 * time.Minute
+		}
+		go a.reminders.RunSchedule(jobsCtx, interval)
+	}
+
 	// 启动HTTP服务器
 	go func() {
 		if err := a.httpServer.Start(); err != nil {
@@ -180,15 +360,101 @@ func (a *App) gracefulShutdown() error {
 		logger.Error("HTTP server shutdown error", zap.Error(err))
 	}
 
+	// 停止后台定时任务
+	if a.stopJobs != nil {
+		a.stopJobs()
+	}
+
+	// 停止事件总线，处理完已发布的事件后再关闭数据库
+	if err := a.eventBus.Stop(); err != nil {
+		logger.Error("Event bus shutdown error", zap.Error(err))
+	}
+	// 等待已排队的webhook投递完成
+	a.webhooks.Close()
+
 	// 关闭数据库连接
 	if err := a.closeDatabase(); err != nil {
 		logger.Error("Database shutdown error", zap.Error(err))
 	}
 
+	// 关闭缓存连接
+	if a.cache != nil {
+		if err := a.cache.Close(); err != nil {
+			logger.Error("Cache shutdown error", zap.Error(err))
+		}
+	}
+
 	logger.Info("Application shutdown complete")
 	return nil
 }
 
+// newRedisCache 创建带熔断的Redis缓存，连接失败不影响启动
+func newRedisCache(cfg *config.RedisConfig) cache.Interface {
+	client := redis.NewClient(&redis.Options{
+		Addr:         cfg.GetAddr(),
+		Password:     cfg.Password,
+		DB:           cfg.Database,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+	})
+	breaker := cache.NewCircuitBreakerCache(cache.NewRedisCache(client), cache.CircuitBreakerConfig{
+		FailureThreshold: cfg.BreakerFailureThreshold,
+		CoolDown:         time.Duration(cfg.BreakerCooldownSeconds) * time.Second,
+		OperationTimeout: time.Duration(cfg.OperationTimeoutMs) * time.Millisecond,
+		MaxRetries:       cfg.MaxRetries,
+	})
+	if err := breaker.Ping(context.Background()); err != nil {
+		logger.Warn("Redis unavailable, cache calls will be skipped until it recovers",
+			zap.String("addr", cfg.GetAddr()), zap.Error(err))
+	}
+	return breaker
+}
+
+// reminderPolicy 将提醒配置转换为提醒策略，未配置默认梯度时使用 T-24h、T-1h、到期
+func reminderPolicy(cfg config.ReminderConfig) domainValueObject.ReminderPolicy {
+	ladder := func(minutes []int) domainValueObject.ReminderLadder {
+		result := make(domainValueObject.ReminderLadder, len(minutes))
+		for i, m := range minutes {
+			result[i] = time.Duration(m) * time.Minute
+		}
+		return result
+	}
+
+	policy := domainValueObject.ReminderPolicy{
+		ByPriority: make(map[domainValueObject.TaskPriority]domainValueObject.ReminderLadder, len(cfg.Priorities)),
+		ByProject:  make(map[domainValueObject.ProjectID]domainValueObject.ReminderLadder, len(cfg.Projects)),
+	}
+	if len(cfg.LadderMinutes) > 0 {
+		policy.Default = ladder(cfg.LadderMinutes)
+	}
+	for priority, minutes := range cfg.Priorities {
+		policy.ByPriority[domainValueObject.TaskPriority(priority)] = ladder(minutes)
+	}
+	for projectID, minutes := range cfg.Projects {
+		policy.ByProject[domainValueObject.ProjectID(projectID)] = ladder(minutes)
+	}
+	return policy
+}
+
+// featureFlagPolicy 将配置转换为当前环境（app.mode）下的功能开关策略
+func featureFlagPolicy(cfg *config.Config) domainValueObject.FeatureFlagPolicy {
+	policy := domainValueObject.FeatureFlagPolicy{
+		Environment: cfg.App.Mode,
+		Rules:       make(map[domainValueObject.FeatureFlag]domainValueObject.FeatureFlagRule, len(cfg.Features)),
+	}
+	for name, flag := range cfg.Features {
+		rule := domainValueObject.FeatureFlagRule{
+			Environments: flag.Environments,
+			Projects:     make(map[domainValueObject.ProjectID]bool, len(flag.Projects)),
+		}
+		for projectID, enabled := range flag.Projects {
+			rule.Projects[domainValueObject.ProjectID(projectID)] = enabled
+		}
+		policy.Rules[domainValueObject.FeatureFlag(name)] = rule
+	}
+	return policy
+}
+
 // closeDatabase 关闭数据库连接
 func (a *App) closeDatabase() error {
 	if a.db != nil {
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// CreateAPIKeyRequest 创建API密钥请求，scopes 形如 tasks:read、projects:write，write 包含同一资源的 read
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,required,max=50"`
+}
+
+// APIKeyResponse API密钥响应，不包含明文密钥和哈希
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse 创建API密钥响应，key 为明文密钥，只在创建时返回一次
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyPrincipal API密钥认证通过后的调用身份
+type APIKeyPrincipal struct {
+	KeyID  string
+	UserID string
+	Email  string
+	Roles  []string
+	Scopes []string
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/taskflow/internal/domain/valueobject"
+
+// 审批类型，与审批记录的 approval_type 取值一致
+const (
+	ApprovalKindTaskCreation     = string(valueobject.SubmittedApprovalTaskCreation)     // 任务创建审批
+	ApprovalKindTaskCompletion   = string(valueobject.SubmittedApprovalTaskCompletion)   // 工作提交审核
+	ApprovalKindExtensionRequest = string(valueobject.SubmittedApprovalExtensionRequest) // 延期申请审批
+)
+
+// ApprovalStepReview 待审批事项当前所处的步骤
+const ApprovalStepReview = "review"
+
+// MyApprovalsRequest 我提交的审批查询请求
+type MyApprovalsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Type     string `form:"type" binding:"omitempty,oneof=task_creation task_completion extension_request"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+
+	// 请求者由处理器根据认证上下文填充，不从查询参数绑定
+	RequesterID string `form:"-" json:"-"`
+}
+
+// MyApprovalItem 我提交的审批项，task_id 为审批关联的任务
+type MyApprovalItem struct {
+	valueobject.ApprovalSummary
+	TaskID string `json:"task_id"`
+}
+
+// MyApprovalsResponse 我提交的审批列表，按提交时间倒序
+type MyApprovalsResponse struct {
+	Approvals  []MyApprovalItem               `json:"approvals"`
+	Pagination valueobject.PaginationResponse `json:"pagination"`
+}
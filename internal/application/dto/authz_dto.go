@@ -0,0 +1,40 @@
+package dto
+
+// ExplainPermissionRequest 权限决策解释请求，不执行任何操作
+type ExplainPermissionRequest struct {
+	UserID      string                 `json:"user_id" binding:"required"`
+	Resource    string                 `json:"resource" binding:"required"`
+	Action      string                 `json:"action" binding:"required"`
+	ResourceCtx map[string]interface{} `json:"resource_context"`
+}
+
+// PermissionExplanation 权限决策及评估过程
+type PermissionExplanation struct {
+	UserID          string                   `json:"user_id"`
+	Resource        string                   `json:"resource"`
+	Action          string                   `json:"action"`
+	Allowed         bool                     `json:"allowed"`
+	Effect          string                   `json:"effect"`
+	Reason          string                   `json:"reason"`
+	DeterminingRule string                   `json:"determining_rule,omitempty"` // policy:<策略ID> 或 role:<角色ID>:permission:<权限ID>，为空表示没有规则匹配，默认拒绝
+	Roles           []string                 `json:"roles"`
+	RolePermissions []RolePermissionMatchDTO `json:"role_permissions"`
+	Policies        []PolicyEvaluationDTO    `json:"policies"`
+	DecidingPolicy  *PolicyEvaluationDTO     `json:"deciding_policy,omitempty"` // 决定结果的策略，由角色权限决定时为空
+}
+
+// RolePermissionMatchDTO 匹配资源和操作的角色权限
+type RolePermissionMatchDTO struct {
+	RoleID       string `json:"role_id"`
+	PermissionID string `json:"permission_id"`
+}
+
+// PolicyEvaluationDTO 策略评估结果
+type PolicyEvaluationDTO struct {
+	PolicyID string `json:"policy_id"`
+	Name     string `json:"name"`
+	Effect   string `json:"effect"`
+	Priority int    `json:"priority"`
+	Active   bool   `json:"active"`
+	Matched  bool   `json:"matched"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ListEventsRequest 领域事件查询请求，type 可重复传入多个
+// 翻页时把上一页响应中的 next_since 和 next_after_id 作为 since 和 after_id 传入
+type ListEventsRequest struct {
+	Types       []string   `form:"type"`
+	AggregateID string     `form:"aggregate_id"`
+	Since       *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00" binding:"required_with=AfterID"`
+	AfterID     string     `form:"after_id"`
+	Limit       int        `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+// EventResponse 领域事件信封及反序列化后的事件数据
+type EventResponse struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateID   string          `json:"aggregate_id"`
+	AggregateType string          `json:"aggregate_type"`
+	Version       int             `json:"version"`
+	ActorID       string          `json:"actor_id,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// ListEventsResponse 领域事件查询响应，按发生时间升序；还有更多数据时返回下一页游标
+type ListEventsResponse struct {
+	Events      []EventResponse `json:"events"`
+	NextSince   *time.Time      `json:"next_since,omitempty"`
+	NextAfterID string          `json:"next_after_id,omitempty"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// FeatureFlagResponse 功能开关在当前环境下的取值
+type FeatureFlagResponse struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Source  string `json:"source"` // project、environment 或 default（未配置时生产环境关闭、其他环境开启）
+}
+
+// FeatureFlagListResponse 功能开关列表，指定项目时包含按项目覆盖的取值
+type FeatureFlagListResponse struct {
+	Environment string                `json:"environment"`
+	ProjectID   string                `json:"project_id,omitempty"`
+	Flags       []FeatureFlagResponse `json:"flags"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// ImpersonateRequest 管理员模拟用户请求，reason 记录在操作日志中
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+
+	// 以下字段由处理器根据路径和认证上下文填充
+	TargetUserID   string `json:"-"`
+	ImpersonatorID string `json:"-"`
+	IPAddress      string `json:"-"`
+	UserAgent      string `json:"-"`
+}
+
+// ImpersonationResponse 模拟登录令牌，只有访问令牌，过期后需要重新发起模拟
+type ImpersonationResponse struct {
+	AccessToken    string    `json:"access_token"`
+	TokenType      string    `json:"token_type"`
+	ExpiresIn      int64     `json:"expires_in"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Impersonating  bool      `json:"impersonating"` // 始终为 true，便于客户端显示模拟状态
+	UserID         string    `json:"user_id"`
+	ImpersonatorID string    `json:"impersonator_id"`
+}
+
+// ImpersonatedAction 模拟登录期间的一次请求，由认证中间件在请求结束后记录
+type ImpersonatedAction struct {
+	UserID         string
+	ImpersonatorID string
+	Operation      string // 请求方法和路由，如 PUT /api/v1/tasks/:id
+	ResourceType   string
+	ResourceID     string
+	IPAddress      string
+	UserAgent      string
+	ResponseStatus int
+}
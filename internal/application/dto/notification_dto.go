@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// ListNotificationsRequest 站内通知查询请求
+type ListNotificationsRequest struct {
+	Unread   bool `form:"unread"` // 为 true 时只返回未读通知
+	Page     int  `form:"page" binding:"omitempty,min=1"`
+	PageSize int  `form:"page_size" binding:"omitempty,min=1,max=100"`
+
+	// 接收人由处理器根据认证上下文填充，不从查询参数绑定
+	UserID string `form:"-" json:"-"`
+}
+
+// NotificationResponse 站内通知
+type NotificationResponse struct {
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body"`
+	ResourceType string     `json:"resource_type,omitempty"`
+	ResourceID   string     `json:"resource_id,omitempty"`
+	Read         bool       `json:"read"`
+	ReadAt       *time.Time `json:"read_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// NotificationListResponse 站内通知列表，按创建时间倒序，unread_count 为全部未读数
+type NotificationListResponse struct {
+	Notifications []NotificationResponse         `json:"notifications"`
+	UnreadCount   int                            `json:"unread_count"`
+	Pagination    valueobject.PaginationResponse `json:"pagination"`
+}
+
+// MarkAllNotificationsReadResponse 全部标记已读的结果
+type MarkAllNotificationsReadResponse struct {
+	Marked      int `json:"marked"`
+	UnreadCount int `json:"unread_count"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// 全局搜索结果类型
+const (
+	SearchResultTypeTask    = "task"
+	SearchResultTypeProject = "project"
+)
+
+// SearchRequest 全局搜索请求，按标题匹配任务、按名称匹配项目
+type SearchRequest struct {
+	Query string `form:"q" binding:"required,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+
+	// 请求者信息由处理器根据认证上下文填充，不从查询参数绑定
+	RequesterID      string `form:"-" json:"-"`
+	RequesterIsAdmin bool   `form:"-" json:"-"`
+}
+
+// SearchResult 全局搜索结果项，score 越大越相关
+type SearchResult struct {
+	Type        string    `json:"type"`
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status"`
+	ProjectID   string    `json:"project_id,omitempty"`
+	Score       int       `json:"score"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SearchResponse 全局搜索响应，结果按相关度降序，同等相关度时最近更新的在前
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+	Total   int            `json:"total"`
+}
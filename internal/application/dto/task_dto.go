@@ -2,6 +2,8 @@ package dto
 
 import (
 	"time"
+
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/domain/valueobject"
 )
 
@@ -16,69 +18,109 @@ type CreateTaskRequest struct {
 	ResponsibleID string    `json:"responsible_id" validate:"required"`
 	DueDate       *time.Time `json:"due_date"`
 	EstimatedHours int      `json:"estimated_hours"`
+	OpenContribution bool   `json:"open_contribution"`
+	Reviewers        []string   `json:"reviewers"`     // 工作审核人，为空时单人审核
+	ReviewQuorum     int        `json:"review_quorum"` // 工作通过所需的审核通过人数
 }
 
 // CreateTaskResponse 创建任务响应
 type CreateTaskResponse struct {
-	ID            string    `json:"id"`
-	Title         string    `json:"title"`
-	Description   *string   `json:"description"`
-	TaskType      string    `json:"task_type"`
-	Priority      string    `json:"priority"`
-	Status        string    `json:"status"`
-	ProjectID     string    `json:"project_id"`
-	CreatorID     string    `json:"creator_id"`
-	ResponsibleID string    `json:"responsible_id"`
-	DueDate       *time.Time `json:"due_date"`
-	EstimatedHours int      `json:"estimated_hours"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    *string    `json:"description,omitempty"`
+	TaskType       string     `json:"task_type"`
+	Priority       string     `json:"priority"`
+	Status         string     `json:"status"`
+	ProjectID      string     `json:"project_id"`
+	CreatorID      string     `json:"creator_id"`
+	ResponsibleID  string     `json:"responsible_id"`
+	DueDate        *time.Time `json:"due_date,omitempty"`
+	EstimatedHours int        `json:"estimated_hours"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
-// UpdateTaskRequest 更新任务请求
+// CloneTaskRequest 复制任务请求
+// ProjectID 为空时复制到源任务所在项目；DueDate 为空时沿用源任务的截止时间，已过期则按源任务原工期顺延
+type CloneTaskRequest struct {
+	ProjectID           string     `json:"project_id"`
+	DueDate             *time.Time `json:"due_date"`
+	IncludeParticipants bool       `json:"include_participants"`
+	TaskID              string     `json:"-"`
+	CallerID            string     `json:"-"`
+	IsAdmin             bool       `json:"-"`
+}
+
+// UpdateTaskRequest 更新任务请求，指针字段为空表示保持不变；ID 和 UpdatedBy 由处理器根据路径和认证上下文填充
 type UpdateTaskRequest struct {
-	ID            string     `json:"id"`
-	Title         *string    `json:"title"`
-	Description   *string    `json:"description"`
-	Priority      *string    `json:"priority"`
-	DueDate       *time.Time `json:"due_date"`
-	EstimatedHours *int      `json:"estimated_hours"`
+	ID             string     `json:"-"`
+	Title          *string    `json:"title"`
+	Description    *string    `json:"description"`
+	Priority       *string    `json:"priority" binding:"omitempty,oneof=low medium high critical"`
+	StartDate      *time.Time `json:"start_date"`
+	DueDate        *time.Time `json:"due_date"`
+	EstimatedHours *int       `json:"estimated_hours" binding:"omitempty,min=0"`
+	UpdatedBy      string     `json:"-"`
 }
 
 // UpdateTaskResponse 更新任务响应
 type UpdateTaskResponse struct {
-	ID            string    `json:"id"`
-	Title         string    `json:"title"`
-	Description   *string   `json:"description"`
-	TaskType      string    `json:"task_type"`
-	Priority      string    `json:"priority"`
-	Status        string    `json:"status"`
-	ProjectID     string    `json:"project_id"`
-	CreatorID     string    `json:"creator_id"`
-	ResponsibleID string    `json:"responsible_id"`
-	DueDate       *time.Time `json:"due_date"`
-	EstimatedHours int      `json:"estimated_hours"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Description    *string    `json:"description,omitempty"`
+	TaskType       string     `json:"task_type"`
+	Priority       string     `json:"priority"`
+	Status         string     `json:"status"`
+	ProjectID      string     `json:"project_id"`
+	CreatorID      string     `json:"creator_id"`
+	ResponsibleID  string     `json:"responsible_id"`
+	StartDate      *time.Time `json:"start_date,omitempty"`
+	DueDate        *time.Time `json:"due_date,omitempty"`
+	EstimatedHours int        `json:"estimated_hours"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
 // TaskResponse 任务响应
+// 可选字段（指针）为空时省略，集合字段始终输出数组
 type TaskResponse struct {
-	ID            string                `json:"id"`
-	Title         string                `json:"title"`
-	Description   *string               `json:"description"`
-	TaskType      string                `json:"task_type"`
-	Priority      string                `json:"priority"`
-	Status        string                `json:"status"`
-	ProjectID     string                `json:"project_id"`
-	CreatorID     string                `json:"creator_id"`
-	ResponsibleID string                `json:"responsible_id"`
-	DueDate       *time.Time            `json:"due_date"`
-	EstimatedHours int                  `json:"estimated_hours"`
-	ActualHours   float64               `json:"actual_hours"`
-	Participants  []TaskParticipantDTO  `json:"participants"`
-	CreatedAt     time.Time             `json:"created_at"`
-	UpdatedAt     time.Time             `json:"updated_at"`
+	ID                string               `json:"id"`
+	Title             string               `json:"title"`
+	Description       *string              `json:"description,omitempty"`
+	TaskType          string               `json:"task_type"`
+	Priority          string               `json:"priority"`
+	Status            string               `json:"status"`
+	ProjectID         string               `json:"project_id"`
+	CreatorID         string               `json:"creator_id"`
+	ResponsibleID     string               `json:"responsible_id"`
+	CoResponsibleIDs  []string             `json:"co_responsible_ids,omitempty"` // 共同负责人，负责人为牵头人
+	DueDate           *time.Time           `json:"due_date,omitempty"`
+	ApprovedAt        *time.Time           `json:"approved_at,omitempty"`
+	StatusChangedAt   time.Time            `json:"status_changed_at"`
+	TimeInStatusHours float64              `json:"time_in_status_hours"`
+	EstimatedHours    int                  `json:"estimated_hours"`
+	ActualHours       float64              `json:"actual_hours"`
+	Participants      []TaskParticipantDTO `json:"participants"`
+	Tags              []string             `json:"tags"`
+	RecurrenceRule    *RecurrenceRuleDTO   `json:"recurrence_rule,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	UpdatedBy         string               `json:"updated_by,omitempty"`
+}
+
+// Localize 按用户时区渲染响应中的时间
+func (r *TaskResponse) Localize(loc *time.Location) {
+	r.DueDate = shared.InLocation(r.DueDate, loc)
+	r.ApprovedAt = shared.InLocation(r.ApprovedAt, loc)
+	r.StatusChangedAt = r.StatusChangedAt.In(loc)
+	r.CreatedAt = r.CreatedAt.In(loc)
+	r.UpdatedAt = r.UpdatedAt.In(loc)
+	for i := range r.Participants {
+		r.Participants[i].AddedAt = r.Participants[i].AddedAt.In(loc)
+	}
+	if r.RecurrenceRule != nil {
+		r.RecurrenceRule.EndDate = shared.InLocation(r.RecurrenceRule.EndDate, loc)
+	}
 }
 
 // TaskParticipantDTO 任务参与者DTO
@@ -89,28 +131,179 @@ type TaskParticipantDTO struct {
 	AddedBy string    `json:"added_by"`
 }
 
+// RecurrenceRuleDTO 重复规则DTO
+type RecurrenceRuleDTO struct {
+	Frequency     string     `json:"frequency" binding:"required,oneof=daily weekly monthly yearly"`
+	IntervalValue int        `json:"interval_value" binding:"required,min=1,max=1000"`
+	EndDate       *time.Time `json:"end_date,omitempty"`
+	MaxExecutions *int       `json:"max_executions,omitempty" binding:"omitempty,min=1"`
+}
+
+// ChangeTaskTypeRequest 变更任务类型请求，变更为重复任务时必须提供重复规则
+type ChangeTaskTypeRequest struct {
+	TaskType       string             `json:"task_type" binding:"required,oneof=regular recurring template urgent"`
+	RecurrenceRule *RecurrenceRuleDTO `json:"recurrence_rule"`
+	TaskID         string             `json:"-"`
+	ChangedBy      string             `json:"-"`
+}
+
+// PreviewRecurrenceRequest 预览重复任务执行时间请求，只计算不保存
+type PreviewRecurrenceRequest struct {
+	RecurrenceRule *RecurrenceRuleDTO `json:"recurrence_rule"`                        // 为空时使用任务当前的重复规则
+	AnchorDate     *time.Time         `json:"anchor_date"`                            // 为空时使用任务的开始日期或截止日期
+	Count          int                `json:"count" binding:"omitempty,min=1,max=50"` // 预览次数，默认5次
+	TaskID         string             `json:"-"`
+	ViewerID       string             `json:"-"`
+	IsAdmin        bool               `json:"-"`
+}
+
+// RecurrencePreviewResponse 重复任务执行时间预览，结束日期或最大执行次数截止时少于请求次数
+type RecurrencePreviewResponse struct {
+	AnchorDate time.Time   `json:"anchor_date"`
+	Executions []time.Time `json:"executions"`
+}
+
+// Localize 按用户时区渲染响应中的时间
+func (r *RecurrencePreviewResponse) Localize(loc *time.Location) {
+	r.AnchorDate = r.AnchorDate.In(loc)
+	for i := range r.Executions {
+		r.Executions[i] = r.Executions[i].In(loc)
+	}
+}
+
+// RequestExtensionRequest 申请延期请求
+type RequestExtensionRequest struct {
+	TaskID      string    `json:"-"`
+	RequesterID string    `json:"-"`
+	NewDueDate  time.Time `json:"new_due_date" binding:"required"`
+	Reason      string    `json:"reason" binding:"required"`
+}
+
+// ApproveExtensionRequest 批准延期申请请求，字段均由处理器根据路径和认证上下文填充
+type ApproveExtensionRequest struct {
+	TaskID      string `json:"-"`
+	ExtensionID string `json:"-"`
+	ApproverID  string `json:"-"`
+}
+
+// RejectExtensionRequest 拒绝延期申请请求，审批意见可选，其余字段由处理器根据路径和认证上下文填充
+type RejectExtensionRequest struct {
+	TaskID      string `json:"-"`
+	ExtensionID string `json:"-"`
+	RejectorID  string `json:"-"`
+	Comment     string `json:"comment" binding:"max=500"`
+}
+
+// ExtensionRequestResponse 延期申请响应
+type ExtensionRequestResponse struct {
+	ID               string     `json:"id"`
+	TaskID           string     `json:"task_id"`
+	RequesterID      string     `json:"requester_id"`
+	OriginalDueDate  time.Time  `json:"original_due_date"`
+	RequestedDueDate time.Time  `json:"requested_due_date"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"`
+	RequestedAt      time.Time  `json:"requested_at"`
+	ReviewerID       *string    `json:"reviewer_id,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	ReviewComment    *string    `json:"review_comment,omitempty"`
+}
+
+// Localize 按用户时区渲染响应中的时间
+func (r *ExtensionRequestResponse) Localize(loc *time.Location) {
+	r.OriginalDueDate = r.OriginalDueDate.In(loc)
+	r.RequestedDueDate = r.RequestedDueDate.In(loc)
+	r.RequestedAt = r.RequestedAt.In(loc)
+	r.ReviewedAt = shared.InLocation(r.ReviewedAt, loc)
+}
+
+// WorkSubmissionResponse 工作提交记录响应
+type WorkSubmissionResponse struct {
+	ID            string     `json:"id"`
+	TaskID        string     `json:"task_id"`
+	AuthorID      string     `json:"author_id"`
+	Content       string     `json:"content"`
+	Attachments   []string   `json:"attachments"`
+	ReviewStatus  string     `json:"review_status"`
+	SubmittedAt   time.Time  `json:"submitted_at"`
+	ReviewerID    *string    `json:"reviewer_id,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	ReviewComment *string    `json:"review_comment,omitempty"`
+}
+
+// Localize 按用户时区渲染响应中的时间
+func (r *WorkSubmissionResponse) Localize(loc *time.Location) {
+	r.SubmittedAt = r.SubmittedAt.In(loc)
+	r.ReviewedAt = shared.InLocation(r.ReviewedAt, loc)
+}
+
+// TaskBundleResponse 任务详情包：任务及参与者、工作提交、延期申请和事件历史，审核意见随提交和延期申请返回
+// 调用者无权查看的部分省略；事件历史按发生时间升序，超出上限时只返回最新的部分并标记截断，更早的事件可通过事件查询接口按 aggregate_id 翻页获取
+type TaskBundleResponse struct {
+	Task             TaskResponse               `json:"task"`
+	WorkSubmissions  []WorkSubmissionResponse   `json:"work_submissions"`
+	Extensions       []ExtensionRequestResponse `json:"extensions"`
+	History          []EventResponse            `json:"history,omitempty"`
+	HistoryTruncated bool                       `json:"history_truncated,omitempty"`
+}
+
+// WorkReviewItem 待当前用户审核的工作提交
+type WorkReviewItem struct {
+	Task       TaskResponse           `json:"task"`
+	Submission WorkSubmissionResponse `json:"submission"`
+}
+
+// ExtensionDecisionItem 待当前用户审批的延期申请
+type ExtensionDecisionItem struct {
+	Task      TaskResponse             `json:"task"`
+	Extension ExtensionRequestResponse `json:"extension"`
+}
+
+// ActionItemsRequest 待处理事项请求，按任务分页；UserID 由处理器根据认证上下文填充
+type ActionItemsRequest struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	UserID   string `form:"-"`
+}
+
+// ActionItemsResponse 当前用户需要处理的事项，按处理类型分组
+// Total 为本页的事项数，TotalTasks 为需要处理的任务总数，用于计算页数
+type ActionItemsResponse struct {
+	InProgress         []TaskResponse          `json:"in_progress"`         // 负责的进行中任务，按截止时间升序
+	WorkReviews        []WorkReviewItem        `json:"work_reviews"`        // 待审核的工作提交，按提交时间升序
+	ExtensionDecisions []ExtensionDecisionItem `json:"extension_decisions"` // 待审批的延期申请，按申请时间升序
+	Total              int                     `json:"total"`
+	TotalTasks         int                     `json:"total_tasks"`
+	Page               int                     `json:"page"`
+	PageSize           int                     `json:"page_size"`
+}
+
 // TaskSearchCriteria 任务搜索条件
 type TaskSearchCriteria struct {
-	Title         *string                      `json:"title"`
-	Description   *string                      `json:"description"`
-	TaskType      *valueobject.TaskType        `json:"task_type"`
-	Priority      *valueobject.TaskPriority    `json:"priority"`
-	Status        *valueobject.TaskStatus      `json:"status"`
-	ProjectID     *valueobject.ProjectID       `json:"project_id"`
-	CreatorID     *valueobject.UserID          `json:"creator_id"`
-	ResponsibleID *valueobject.UserID          `json:"responsible_id"`
-	ParticipantID *valueobject.UserID          `json:"participant_id"`
-	StartDate     *time.Time                   `json:"start_date"`
-	DueDate       *time.Time                   `json:"due_date"`
-	CreatedAfter  *time.Time                   `json:"created_after"`
-	CreatedBefore *time.Time                   `json:"created_before"`
-}
-
-// ListTasksRequest 任务列表请求
+	Title         *string                   `json:"title" form:"title"`
+	Description   *string                   `json:"description" form:"description"`
+	TaskType      *valueobject.TaskType     `json:"task_type" form:"task_type"`
+	Priority      *valueobject.TaskPriority `json:"priority" form:"priority"`
+	Status        *valueobject.TaskStatus   `json:"status" form:"status"`
+	ProjectID     *valueobject.ProjectID    `json:"project_id" form:"project_id"`
+	CreatorID     *valueobject.UserID       `json:"creator_id" form:"creator_id"`
+	ResponsibleID *valueobject.UserID       `json:"responsible_id" form:"responsible_id"`
+	ParticipantID *valueobject.UserID       `json:"participant_id" form:"participant_id"`
+	StartDate     *time.Time                `json:"start_date" form:"start_date"`
+	DueDate       *time.Time                `json:"due_date" form:"due_date"`
+	CreatedAfter  *time.Time                `json:"created_after" form:"created_after"`
+	CreatedBefore *time.Time                `json:"created_before" form:"created_before"`
+}
+
+// ListTasksRequest 任务列表请求，page 从1开始，page_size 未指定时使用默认值
 type ListTasksRequest struct {
 	Criteria TaskSearchCriteria `json:"criteria"`
-	Page     int                `json:"page"`
-	PageSize int                `json:"page_size"`
+	Page     int                `json:"page" form:"page" binding:"omitempty,min=1"`
+	PageSize int                `json:"page_size" form:"page_size" binding:"omitempty,min=1,max=100"`
+
+	// 请求者信息由处理器根据认证上下文填充，非管理员只能看到自己创建、负责或参与的任务
+	RequesterID      string `form:"-" json:"-"`
+	RequesterIsAdmin bool   `form:"-" json:"-"`
 }
 
 // ListTasksResponse 任务列表响应
@@ -122,11 +315,12 @@ type ListTasksResponse struct {
 	TotalPages int            `json:"total_pages"`
 }
 
-// AssignTaskRequest 分配任务请求
+// AssignTaskRequest 分配任务请求，指定共同负责人时 responsible_id 为牵头人；不指定时只有一名负责人
 type AssignTaskRequest struct {
-	TaskID        string `json:"task_id"`
-	ResponsibleID string `json:"responsible_id" validate:"required"`
-	AssignedBy    string `json:"assigned_by" validate:"required"`
+	TaskID           string   `json:"task_id"`
+	ResponsibleID    string   `json:"responsible_id" validate:"required"`
+	CoResponsibleIDs []string `json:"co_responsible_ids"`
+	AssignedBy       string   `json:"assigned_by" validate:"required"`
 }
 
 // UpdateTaskStatusRequest 更新任务状态请求
@@ -137,7 +331,7 @@ type UpdateTaskStatusRequest struct {
 	Comment   string `json:"comment"`
 }
 
-// AddTaskParticipantRequest 添加任务参与者请求
+// AddTaskParticipantRequest 添加任务参与者请求，role 为空时默认为执行者
 type AddTaskParticipantRequest struct {
 	TaskID        string `json:"task_id"`
 	ParticipantID string `json:"participant_id" validate:"required"`
@@ -145,6 +339,35 @@ type AddTaskParticipantRequest struct {
 	AddedBy       string `json:"added_by" validate:"required"`
 }
 
+// AddTaskParticipantBody HTTP添加任务参与者请求体，任务ID和添加人取自路由与当前登录用户
+type AddTaskParticipantBody struct {
+	ParticipantID string `json:"participant_id" binding:"required"`
+	Role          string `json:"role" binding:"omitempty,oneof=executor reviewer observer assistant"`
+}
+
+// AddTaskParticipantsRequest 批量添加任务参与者请求
+type AddTaskParticipantsRequest struct {
+	TaskID         string   `json:"task_id"`
+	ParticipantIDs []string `json:"participant_ids" validate:"required,min=1"`
+	AddedBy        string   `json:"added_by" validate:"required"`
+}
+
+// SubmitWorkRequest 提交工作请求
+type SubmitWorkRequest struct {
+	TaskID      string   `json:"task_id"`
+	SubmitterID string   `json:"submitter_id" validate:"required"`
+	WorkContent string   `json:"work_content" validate:"required"`
+	Attachments []string `json:"attachments"`
+	HoursSpent  float64  `json:"hours_spent" validate:"omitempty,gt=0"` // 本次投入的工时，累加到任务实际工时
+}
+
+// SubmitWorkBody HTTP提交工作请求体，任务ID和提交人取自路由与当前登录用户
+type SubmitWorkBody struct {
+	WorkContent string   `json:"work_content" binding:"required"`
+	Attachments []string `json:"attachments"`
+	HoursSpent  float64  `json:"hours_spent" binding:"omitempty,gt=0"` // 本次投入的工时，累加到任务实际工时
+}
+
 // RemoveTaskParticipantRequest 移除任务参与者请求
 type RemoveTaskParticipantRequest struct {
 	TaskID        string `json:"task_id"`
@@ -152,6 +375,153 @@ type RemoveTaskParticipantRequest struct {
 	RemovedBy     string `json:"removed_by" validate:"required"`
 }
 
+// DeleteTaskRequest 删除单个任务请求；处于流转中的任务需要指定 force，TaskID 和 DeletedBy 由处理器根据路径和认证上下文填充
+type DeleteTaskRequest struct {
+	Force     bool   `form:"force"`
+	TaskID    string `form:"-"`
+	DeletedBy string `form:"-"`
+}
+
+// BulkDeleteTasksRequest 批量删除任务请求
+// ConfirmationToken 需与服务端根据任务ID列表计算的确认令牌一致
+type BulkDeleteTasksRequest struct {
+	TaskIDs           []string `json:"task_ids" binding:"required,min=1,max=100"`
+	ConfirmationToken string   `json:"confirmation_token"`
+	Force             bool     `json:"force"`
+	DeletedBy         string   `json:"-"`
+}
+
+// 批量删除单个任务的处理结果
+const (
+	BulkDeleteStatusDeleted   = "deleted"
+	BulkDeleteStatusNotFound  = "not_found"
+	BulkDeleteStatusForbidden = "forbidden"
+)
+
+// BulkDeleteTaskResult 单个任务的删除结果
+type BulkDeleteTaskResult struct {
+	TaskID string `json:"task_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkDeleteTasksResponse 批量删除任务响应
+type BulkDeleteTasksResponse struct {
+	Results []BulkDeleteTaskResult `json:"results"`
+	Deleted int                    `json:"deleted"`
+}
+
+// BulkTagTasksRequest 批量添加和移除任务标签请求，同一标签不能同时添加和移除
+type BulkTagTasksRequest struct {
+	TaskIDs    []string `json:"task_ids" binding:"required,min=1,max=100"`
+	AddTags    []string `json:"add_tags" binding:"max=20"`
+	RemoveTags []string `json:"remove_tags" binding:"max=20"`
+	// TaggedBy 和 RequesterIsAdmin 由处理器根据认证上下文填充
+	TaggedBy         string `json:"-"`
+	RequesterIsAdmin bool   `json:"-"`
+}
+
+// 批量打标签单个任务的处理结果
+const (
+	BulkTagStatusUpdated   = "updated"
+	BulkTagStatusUnchanged = "unchanged"
+	BulkTagStatusNotFound  = "not_found"
+	BulkTagStatusForbidden = "forbidden"
+)
+
+// BulkTagTaskResult 单个任务的打标签结果，Tags 为处理后的全部标签
+type BulkTagTaskResult struct {
+	TaskID string   `json:"task_id"`
+	Status string   `json:"status"`
+	Tags   []string `json:"tags,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// BulkTagTasksResponse 批量打标签响应
+type BulkTagTasksResponse struct {
+	Results []BulkTagTaskResult `json:"results"`
+	Updated int                 `json:"updated"`
+}
+
+// StaleApprovedTasksRequest 已审批未开始任务报表请求，未指定时长时使用配置的默认值
+type StaleApprovedTasksRequest struct {
+	ProjectID   string `form:"project_id"`
+	MinAgeHours int    `form:"min_age_hours" binding:"omitempty,min=1,max=8760"`
+}
+
+// StaleApprovedTask 已审批未开始的任务及其等待时长
+type StaleApprovedTask struct {
+	TaskResponse
+	WaitingHours float64 `json:"waiting_hours"`
+}
+
+// StaleApprovedTasksResponse 已审批未开始任务报表响应，按审批时间升序
+type StaleApprovedTasksResponse struct {
+	Tasks          []StaleApprovedTask `json:"tasks"`
+	Total          int                 `json:"total"`
+	ApprovedBefore time.Time           `json:"approved_before"`
+}
+
+// TaskStatusDurationsRequest 项目任务状态停留时长报表请求
+type TaskStatusDurationsRequest struct {
+	ProjectID string `form:"project_id" binding:"required"`
+}
+
+// TaskStatusDuration 处于某一状态的任务数量及其在该状态的停留时长
+type TaskStatusDuration struct {
+	Status       string  `json:"status"`
+	TaskCount    int     `json:"task_count"`
+	AverageHours float64 `json:"average_hours"`
+	MaxHours     float64 `json:"max_hours"`
+}
+
+// TaskStatusDurationsResponse 项目任务状态停留时长报表响应，按状态名排序
+type TaskStatusDurationsResponse struct {
+	ProjectID string               `json:"project_id"`
+	Statuses  []TaskStatusDuration `json:"statuses"`
+	AsOf      time.Time            `json:"as_of"`
+}
+
+// EstimateVarianceRequest 预估与实际工时偏差报表请求，未指定超支阈值时使用配置的默认值
+type EstimateVarianceRequest struct {
+	ProjectID        string  `form:"project_id"`
+	ResponsibleID    string  `form:"responsible_id"`
+	OverrunThreshold float64 `form:"overrun_threshold_percent" binding:"omitempty,gt=0,max=1000"`
+}
+
+// TaskEstimateVariance 任务的工时偏差，实际工时超出预估的比例大于阈值时 overrun 为 true
+type TaskEstimateVariance struct {
+	TaskID          string  `json:"task_id"`
+	Title           string  `json:"title"`
+	ProjectID       string  `json:"project_id"`
+	ResponsibleID   string  `json:"responsible_id"`
+	EstimatedHours  int     `json:"estimated_hours"`
+	ActualHours     float64 `json:"actual_hours"`
+	VarianceHours   float64 `json:"variance_hours"`
+	VariancePercent float64 `json:"variance_percent"`
+	Overrun         bool    `json:"overrun"`
+}
+
+// EstimateVarianceGroup 按项目或负责人汇总的工时偏差，偏差百分比基于汇总后的工时计算
+type EstimateVarianceGroup struct {
+	ID              string  `json:"id"`
+	TaskCount       int     `json:"task_count"`
+	OverrunCount    int     `json:"overrun_count"`
+	EstimatedHours  float64 `json:"estimated_hours"`
+	ActualHours     float64 `json:"actual_hours"`
+	VarianceHours   float64 `json:"variance_hours"`
+	VariancePercent float64 `json:"variance_percent"`
+}
+
+// EstimateVarianceResponse 预估与实际工时偏差报表响应，任务按偏差百分比降序，汇总按ID排序
+type EstimateVarianceResponse struct {
+	OverrunThreshold float64                 `json:"overrun_threshold_percent"`
+	Tasks            []TaskEstimateVariance  `json:"tasks"`
+	OverrunCount     int                     `json:"overrun_count"`
+	ByProject        []EstimateVarianceGroup `json:"by_project"`
+	ByResponsible    []EstimateVarianceGroup `json:"by_responsible"`
+}
+
 // TaskStatisticsResponse 任务统计响应
 type TaskStatisticsResponse struct {
 	TotalTasks      int                        `json:"total_tasks"`
@@ -174,3 +544,13 @@ type ProjectTaskStatisticsResponse struct {
 	CompletionRate  float64                    `json:"completion_rate"`
 	AverageHours    float64                    `json:"average_hours"`
 }
+
+// BatchProjectTaskStatisticsRequest 批量获取项目任务统计请求，一次最多100个项目
+type BatchProjectTaskStatisticsRequest struct {
+	ProjectIDs []string `json:"project_ids" binding:"required,min=1,max=100,dive,required"`
+}
+
+// BatchProjectTaskStatisticsResponse 批量项目任务统计响应，键为项目ID
+type BatchProjectTaskStatisticsResponse struct {
+	Statistics map[string]ProjectTaskStatisticsResponse `json:"statistics"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/shared"
+)
+
+func TestTaskResponse_LocalizeForShanghaiUser(t *testing.T) {
+	dueDate := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	resp := &TaskResponse{
+		ID:        "task-1",
+		DueDate:   &dueDate,
+		CreatedAt: time.Date(2024, 2, 29, 20, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 2, 29, 20, 0, 0, 0, time.UTC),
+	}
+
+	ctx := shared.WithTimezone(context.Background(), "Asia/Shanghai")
+	resp.Localize(shared.LocationFromContext(ctx))
+
+	require.NotNil(t, resp.DueDate)
+	assert.Equal(t, "2024-03-01T18:30:00+08:00", resp.DueDate.Format(time.RFC3339))
+	assert.Equal(t, "2024-03-01T04:00:00+08:00", resp.CreatedAt.Format(time.RFC3339))
+	assert.True(t, resp.DueDate.Equal(dueDate), "渲染不应改变时间点")
+	assert.Equal(t, "2024-03-01T10:30:00Z", dueDate.Format(time.RFC3339), "原始UTC值不应被修改")
+}
+
+func TestTaskResponse_LocalizeDefaultsToUTC(t *testing.T) {
+	dueDate := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	resp := &TaskResponse{DueDate: &dueDate}
+
+	resp.Localize(shared.LocationFromContext(context.Background()))
+
+	assert.Equal(t, "2024-03-01T10:30:00Z", resp.DueDate.Format(time.RFC3339))
+}
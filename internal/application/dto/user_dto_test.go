@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+const (
+	testPasswordHash    = "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA"
+	testTwoFactorSecret = "JBSWY3DPEHPK3PXP"
+	testBackupCode      = "backup-7f3a9c"
+)
+
+func newUserDataWithSecrets() *valueobject.UserData {
+	position := "engineer"
+	return &valueobject.UserData{
+		ID:           "user-1",
+		Username:     "alice",
+		Email:        "alice@example.com",
+		PasswordHash: testPasswordHash,
+		FullName:     "Alice",
+		Status:       "active",
+		Role:         valueobject.RoleEmployee,
+		Position:     &position,
+		JoinDate:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		SecuritySettings: &valueobject.UserSecuritySettings{
+			TwoFactorEnabled:   true,
+			TwoFactorSecret:    testTwoFactorSecret,
+			BackupCodes:        []string{testBackupCode},
+			LastPasswordChange: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			LoginAttempts:      2,
+			SessionTimeout:     30,
+		},
+	}
+}
+
+// assertNoSecrets 序列化结果中不能出现密钥字段名或密钥值
+func assertNoSecrets(t *testing.T, v interface{}) {
+	t.Helper()
+	body, err := json.Marshal(v)
+	require.NoError(t, err)
+	for _, secret := range []string{"password_hash", "two_factor_secret", "backup_codes", "salt",
+		testPasswordHash, testTwoFactorSecret, testBackupCode} {
+		assert.NotContains(t, string(body), secret)
+	}
+}
+
+func TestUserDetailResponse_ExposesOnlySafeSecurityFlags(t *testing.T) {
+	resp := valueobject.UserDetailResponse{
+		ID:               "user-1",
+		Username:         "alice",
+		Roles:            []string{valueobject.RoleEmployee},
+		SecuritySettings: &valueobject.UserSecurityStatus{TwoFactorEnabled: true},
+	}
+
+	assertNoSecrets(t, resp)
+	assertNoSecrets(t, valueobject.AuthenticationResponse{User: resp, AccessToken: "token"})
+
+	body, err := json.Marshal(resp)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"two_factor_enabled":true`)
+}
+
+func TestUserSourceStructs_DoNotSerializeSecrets(t *testing.T) {
+	data := newUserDataWithSecrets()
+
+	assertNoSecrets(t, data)
+	assertNoSecrets(t, valueobject.UserCredentials{PasswordHash: testPasswordHash, Salt: "salt"})
+}
+
+// 响应类型中不能有保存密钥的字段，新增字段时防止误把安全设置原样嵌入响应
+func TestUserResponseTypes_HaveNoSecretFields(t *testing.T) {
+	forbidden := map[string]bool{"PasswordHash": true, "Salt": true, "TwoFactorSecret": true, "BackupCodes": true}
+	for _, v := range []interface{}{
+		UserResponse{}, AuthenticationResponse{},
+		valueobject.UserDetailResponse{}, valueobject.AuthenticationResponse{},
+	} {
+		for _, name := range fieldNames(reflect.TypeOf(v), map[reflect.Type]bool{}) {
+			assert.False(t, forbidden[name], "%T exposes %s", v, name)
+		}
+	}
+}
+
+func fieldNames(t reflect.Type, seen map[reflect.Type]bool) []string {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		names = append(names, f.Name)
+		names = append(names, fieldNames(f.Type, seen)...)
+	}
+	return names
+}
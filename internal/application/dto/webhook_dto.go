@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// CreateWebhookRequest 创建webhook订阅请求，event_types 为空时订阅全部事件
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=500"`
+	Secret     string   `json:"secret" binding:"required,min=16,max=255"`
+	EventTypes []string `json:"event_types" binding:"omitempty,dive,required,max=100"`
+}
+
+// UpdateWebhookRequest 更新webhook订阅请求
+// secret 为空时保留原密钥；active 为空时不改变启用状态，重新启用会清零失败计数
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=500"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types" binding:"omitempty,dive,required,max=100"`
+	Active     *bool    `json:"active"`
+}
+
+// WebhookResponse webhook订阅响应，不返回签名密钥
+type WebhookResponse struct {
+	ID           string     `json:"id"`
+	URL          string     `json:"url"`
+	EventTypes   []string   `json:"event_types"`
+	Active       bool       `json:"active"`
+	FailureCount int        `json:"failure_count"`
+	CreatedBy    string     `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"`
+}
+
+// ListWebhookDeliveriesRequest webhook投递记录查询请求
+type ListWebhookDeliveriesRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// WebhookDeliveryResponse webhook投递记录响应，id 为单次尝试ID，delivery_id 为订阅方收到的去重ID
+type WebhookDeliveryResponse struct {
+	ID          string    `json:"id"`
+	DeliveryID  string    `json:"delivery_id"`
+	EventID     string    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	Attempt     int       `json:"attempt"`
+	Redelivery  bool      `json:"redelivery"`
+	StatusCode  int       `json:"status_code"`
+	Success     bool      `json:"success"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
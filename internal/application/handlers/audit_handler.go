@@ -94,6 +94,7 @@ func (h *AuditHandler) EventTypes() []string {
 		"ParticipantAdded",
 		"ParticipantRemoved",
 		"WorkSubmitted",
+		"HoursLogged",
 		"WorkReviewed",
 		"TaskCompletionSubmitted",
 		"TaskCompleted",
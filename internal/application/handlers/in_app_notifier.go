@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// NotificationResourceTask 通知关联的资源类型：任务
+const NotificationResourceTask = "task"
+
+// InAppNotificationEventTypes 会写入站内通知的领域事件类型
+var InAppNotificationEventTypes = []string{
+	"TaskCreated", "TaskAssigned", "WorkSubmitted", "WorkReviewed",
+	"TaskCompletionSubmitted", "TaskCompleted", "TaskRejected",
+	"ExtensionRequested", "ExtensionApproved", "ExtensionRejected",
+	"TaskDueDateChanged", "TaskDueReminder",
+}
+
+// InAppNotifier 将领域事件转换为站内通知
+// 每个事件为每个接收人写入一条通知，关闭站内通知的用户和触发事件的用户本人不会收到
+// 静默时段只约束邮件等打扰性渠道，站内通知照常写入收件箱，不会丢失
+type InAppNotifier struct {
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepository
+	taskRepo         repository.TaskRepository
+}
+
+// NewInAppNotifier 创建站内通知处理器
+func NewInAppNotifier(notificationRepo repository.NotificationRepository, userRepo repository.UserRepository, taskRepo repository.TaskRepository) *InAppNotifier {
+	return &InAppNotifier{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		taskRepo:         taskRepo,
+	}
+}
+
+// inAppMessage 待写入的通知内容
+type inAppMessage struct {
+	taskID     string
+	title      string
+	body       string
+	recipients []string
+}
+
+// Handle 为事件的接收人写入站内通知
+func (n *InAppNotifier) Handle(domainEvent event.DomainEvent) error {
+	ctx := context.Background()
+
+	msg, err := n.buildMessage(ctx, domainEvent)
+	if err != nil {
+		return err
+	}
+	if msg == nil {
+		return nil
+	}
+
+	actorID := ""
+	if actor, ok := domainEvent.(event.ActorEvent); ok {
+		actorID = actor.ActorID()
+	}
+
+	seen := make(map[string]bool, len(msg.recipients))
+	var notifications []aggregate.Notification
+	for _, userID := range msg.recipients {
+		if userID == "" || userID == actorID || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		user, err := n.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to load notification recipient: %w", err)
+		}
+		if !user.InAppNotifications {
+			continue
+		}
+
+		notifications = append(notifications, aggregate.Notification{
+			ID:           uuid.New().String(),
+			UserID:       user.ID,
+			Type:         domainEvent.EventType(),
+			Title:        msg.title,
+			Body:         msg.body,
+			ResourceType: NotificationResourceTask,
+			ResourceID:   msg.taskID,
+			CreatedAt:    domainEvent.OccurredAt(),
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	if err := n.notificationRepo.Create(ctx, notifications); err != nil {
+		return err
+	}
+	logger.Debug("In-app notifications created",
+		zap.String("event_type", domainEvent.EventType()),
+		zap.String("event_id", domainEvent.EventID()),
+		zap.Int("count", len(notifications)))
+	return nil
+}
+
+// buildMessage 根据事件类型确定通知内容和接收人，不支持的事件返回nil
+func (n *InAppNotifier) buildMessage(ctx context.Context, domainEvent event.DomainEvent) (*inAppMessage, error) {
+	switch e := domainEvent.(type) {
+	case *event.TaskCreatedEvent:
+		return &inAppMessage{
+			taskID:     e.TaskID,
+			title:      "新任务创建",
+			body:       fmt.Sprintf("任务「%s」已创建，您是负责人", e.Title),
+			recipients: []string{e.ResponsibleID},
+		}, nil
+	case *event.TaskAssignedEvent:
+		return n.taskMessage(ctx, e.TaskID, "任务分配通知", "您被分配了任务「%s」", e.Assignees()...)
+	case *event.WorkSubmittedEvent:
+		task, err := n.findTask(ctx, e.TaskID)
+		if err != nil || task == nil {
+			return nil, err
+		}
+		return newTaskMessage(task, "工作提交通知", "任务「%s」有新的工作提交，请进行审核", responsibleRecipients(task)...), nil
+	case *event.WorkReviewedEvent:
+		if e.Approved {
+			return n.taskMessage(ctx, e.TaskID, "工作审核通过", "您在任务「%s」中提交的工作已通过审核", e.ParticipantID)
+		}
+		return n.taskMessage(ctx, e.TaskID, "工作需要修改", "您在任务「%s」中提交的工作需要修改", e.ParticipantID)
+	case *event.TaskCompletionSubmittedEvent:
+		task, err := n.findTask(ctx, e.TaskID)
+		if err != nil || task == nil {
+			return nil, err
+		}
+		return newTaskMessage(task, "任务完成提交通知", "任务「%s」已提交完成，等待最终审批", string(task.CreatorID)), nil
+	case *event.TaskCompletedEvent:
+		task, err := n.findTask(ctx, e.TaskID)
+		if err != nil || task == nil {
+			return nil, err
+		}
+		return newTaskMessage(task, "任务完成通知", "任务「%s」已完成", append(responsibleRecipients(task), string(task.CreatorID))...), nil
+	case *event.TaskRejectedEvent:
+		task, err := n.findTask(ctx, e.TaskID)
+		if err != nil || task == nil {
+			return nil, err
+		}
+		return newTaskMessage(task, "任务返工通知", "任务「%s」需要返工", responsibleRecipients(task)...), nil
+	case *event.ExtensionRequestedEvent:
+		task, err := n.findTask(ctx, e.TaskID)
+		if err != nil || task == nil {
+			return nil, err
+		}
+		return newTaskMessage(task, "延期申请通知", "任务「%s」有新的延期申请，请审批", string(task.CreatorID)), nil
+	case *event.ExtensionApprovedEvent:
+		return n.extensionMessage(ctx, e.TaskID, e.RequestID, "延期申请已批准", "您对任务「%s」的延期申请已批准")
+	case *event.ExtensionRejectedEvent:
+		return n.extensionMessage(ctx, e.TaskID, e.RequestID, "延期申请已拒绝", "您对任务「%s」的延期申请已被拒绝")
+	case *event.TaskDueDateChangedEvent:
+		task, err := n.findTask(ctx, e.TaskID)
+		if err != nil || task == nil {
+			return nil, err
+		}
+		recipients := append(responsibleRecipients(task), string(task.CreatorID))
+		for _, p := range task.Participants {
+			recipients = append(recipients, string(p.UserID))
+		}
+		msg := newTaskMessage(task, "截止日期变更通知", "任务「%s」的截止日期已变更", recipients...)
+		msg.body += fmt.Sprintf("：%s → %s", formatDueDate(e.OldDueDate), formatDueDate(e.NewDueDate))
+		return msg, nil
+	case *event.TaskDueReminderEvent:
+		if e.OffsetMinutes <= 0 {
+			return n.taskMessage(ctx, e.TaskID, "任务到期提醒", "任务「%s」已到截止时间", e.UserID)
+		}
+		msg, err := n.taskMessage(ctx, e.TaskID, "任务即将到期", "任务「%s」即将到期", e.UserID)
+		if msg != nil {
+			msg.body += fmt.Sprintf("，截止时间：%s", e.DueDate.Format("2006-01-02 15:04"))
+		}
+		return msg, err
+	default:
+		return nil, nil
+	}
+}
+
+// formatDueDate 格式化通知中的截止日期
+func formatDueDate(dueDate *time.Time) string {
+	if dueDate == nil {
+		return "无"
+	}
+	return dueDate.Format("2006-01-02")
+}
+
+// taskMessage 加载任务后以任务标题构造通知
+func (n *InAppNotifier) taskMessage(ctx context.Context, taskID, title, bodyFormat string, recipients ...string) (*inAppMessage, error) {
+	task, err := n.findTask(ctx, taskID)
+	if err != nil || task == nil {
+		return nil, err
+	}
+	return newTaskMessage(task, title, bodyFormat, recipients...), nil
+}
+
+// extensionMessage 通知延期申请的申请人
+func (n *InAppNotifier) extensionMessage(ctx context.Context, taskID, requestID, title, bodyFormat string) (*inAppMessage, error) {
+	task, err := n.findTask(ctx, taskID)
+	if err != nil || task == nil {
+		return nil, err
+	}
+	for _, ext := range task.Extensions {
+		if string(ext.ID) == requestID {
+			return newTaskMessage(task, title, bodyFormat, string(ext.RequesterID)), nil
+		}
+	}
+	return nil, nil
+}
+
+// findTask 查找事件关联的任务，任务已删除时返回nil
+func (n *InAppNotifier) findTask(ctx context.Context, taskID string) (*aggregate.TaskAggregate, error) {
+	task, err := n.taskRepo.FindByID(ctx, valueobject.TaskID(taskID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load task for notification: %w", err)
+	}
+	return task, nil
+}
+
+// responsibleRecipients 任务的负责人和全部共同负责人
+func responsibleRecipients(task *aggregate.TaskAggregate) []string {
+	responsibles := task.Responsibles()
+	recipients := make([]string, len(responsibles))
+	for i, id := range responsibles {
+		recipients[i] = string(id)
+	}
+	return recipients
+}
+
+func newTaskMessage(task *aggregate.TaskAggregate, title, bodyFormat string, recipients ...string) *inAppMessage {
+	return &inAppMessage{
+		taskID:     string(task.ID),
+		title:      title,
+		body:       fmt.Sprintf(bodyFormat, task.Title),
+		recipients: recipients,
+	}
+}
+
+// CanHandle 判断是否能处理该事件
+func (n *InAppNotifier) CanHandle(eventType string) bool {
+	for _, t := range InAppNotificationEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypes 返回支持的事件类型
+func (n *InAppNotifier) EventTypes() []string {
+	return InAppNotificationEventTypes
+}
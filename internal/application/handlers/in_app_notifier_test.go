@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// newTestNotifier 创建负责人 alice、创建人 bob、参与者 dave 的任务，carol 关闭了站内通知
+func newTestNotifier(t *testing.T) (*InAppNotifier, *testutil.MemoryNotificationRepository) {
+	setupLogger(t)
+	task := aggregate.TaskAggregate{
+		ID:            "task-1",
+		Title:         "Quarterly report",
+		Status:        valueobject.TaskStatusInProgress,
+		CreatorID:     "bob",
+		ResponsibleID: "alice",
+		Participants: []valueobject.TaskParticipant{
+			{UserID: "dave", Role: valueobject.ParticipantRoleExecutor},
+		},
+		Extensions: []valueobject.ExtensionRequest{
+			{ID: "ext-1", TaskID: "task-1", RequesterID: "carol", Status: valueobject.ExtensionStatusPending},
+		},
+	}
+	carol := aggregate.NewUser("carol", "carol", "carol@example.com", "Carol", "hash", valueobject.UserRoleEmployee)
+	carol.SetInAppNotifications(false)
+
+	notificationRepo := testutil.NewMemoryNotificationRepository()
+	userRepo := testutil.NewMemoryUserRepository(
+		aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+		aggregate.NewUser("bob", "bob", "bob@example.com", "Bob", "hash", valueobject.UserRoleManager),
+		carol,
+		aggregate.NewUser("dave", "dave", "dave@example.com", "Dave", "hash", valueobject.UserRoleEmployee),
+	)
+	return NewInAppNotifier(notificationRepo, userRepo, testutil.NewMemoryTaskRepository(task)), notificationRepo
+}
+
+func TestInAppNotifier_WritesOneNotificationPerRecipient(t *testing.T) {
+	notifier, repo := newTestNotifier(t)
+	ctx := context.Background()
+
+	require.NoError(t, notifier.Handle(event.NewTaskCompletedEvent("task-1", "system")))
+
+	for _, userID := range []valueobject.UserID{"alice", "bob"} {
+		notifications, total, err := repo.FindByUser(ctx, userID, true, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, total, userID)
+		assert.Equal(t, "TaskCompleted", notifications[0].Type)
+		assert.Equal(t, NotificationResourceTask, notifications[0].ResourceType)
+		assert.Equal(t, "task-1", notifications[0].ResourceID)
+		assert.Contains(t, notifications[0].Body, "Quarterly report")
+		assert.False(t, notifications[0].Read)
+	}
+}
+
+func TestInAppNotifier_SkipsActorAndDisabledUsers(t *testing.T) {
+	notifier, repo := newTestNotifier(t)
+	ctx := context.Background()
+
+	// 负责人自己完成任务时只通知创建人
+	require.NoError(t, notifier.Handle(event.NewTaskCompletedEvent("task-1", "alice")))
+	count, err := repo.CountUnread(ctx, "alice")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	count, err = repo.CountUnread(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// carol 关闭了站内通知
+	require.NoError(t, notifier.Handle(event.NewExtensionApprovedEvent("task-1", "ext-1", "bob", time.Now())))
+	count, err = repo.CountUnread(ctx, "carol")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestInAppNotifier_KeepsInboxRecordDuringQuietHours(t *testing.T) {
+	setupLogger(t)
+	alice := aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee)
+	require.NoError(t, alice.SetTimezone("Asia/Shanghai"))
+	// 静默时段覆盖当前时间前后一小时
+	local := time.Now().In(shared.LoadLocation(alice.Timezone))
+	settings := alice.NotificationSettings
+	settings.QuietHoursStart, settings.QuietHoursEnd = local.Add(-time.Hour).Format("15:04"), local.Add(time.Hour).Format("15:04")
+	require.NoError(t, alice.UpdateNotificationSettings(settings))
+	require.True(t, alice.NotificationSettings.InQuietHours(time.Now(), shared.LoadLocation(alice.Timezone)))
+
+	repo := testutil.NewMemoryNotificationRepository()
+	notifier := NewInAppNotifier(repo, testutil.NewMemoryUserRepository(alice), testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", Title: "Quarterly report", Status: valueobject.TaskStatusInProgress, ResponsibleID: "alice",
+	}))
+	reminder := event.NewTaskDueReminderEvent("task-1", "alice", time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC), time.Hour)
+
+	// 静默时段只约束打扰性渠道，站内通知仍写入收件箱
+	require.NoError(t, notifier.Handle(reminder))
+	count, err := repo.CountUnread(context.Background(), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestInAppNotifier_IgnoresDeletedTask(t *testing.T) {
+	notifier, repo := newTestNotifier(t)
+
+	require.NoError(t, notifier.Handle(event.NewTaskRejectedEvent("task-missing", "bob", "redo")))
+
+	count, err := repo.CountUnread(context.Background(), "alice")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestInAppNotifier_DueDateChangedNotifiesParticipants(t *testing.T) {
+	notifier, repo := newTestNotifier(t)
+	ctx := context.Background()
+	oldDueDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	newDueDate := time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, notifier.Handle(event.NewTaskDueDateChangedEvent("task-1", &oldDueDate, &newDueDate, "bob")))
+
+	for _, userID := range []valueobject.UserID{"alice", "dave"} {
+		notifications, total, err := repo.FindByUser(ctx, userID, true, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, total, userID)
+		assert.Equal(t, "TaskDueDateChanged", notifications[0].Type)
+		assert.Contains(t, notifications[0].Body, "2024-05-01 → 2024-05-08")
+	}
+	// 修改截止日期的创建人本人不收到通知
+	count, err := repo.CountUnread(ctx, "bob")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestInAppNotifier_ExtensionApprovalThroughTaskServiceNotifiesDueDateChange(t *testing.T) {
+	setupLogger(t)
+	ctx := context.Background()
+	oldDueDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	newDueDate := time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)
+	taskRepo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID:            "task-1",
+		Title:         "Quarterly report",
+		Status:        valueobject.TaskStatusInProgress,
+		CreatorID:     "bob",
+		ResponsibleID: "alice",
+		DueDate:       &oldDueDate,
+		Participants: []valueobject.TaskParticipant{
+			{UserID: "dave", Role: valueobject.ParticipantRoleExecutor},
+		},
+	})
+	notificationRepo := testutil.NewMemoryNotificationRepository()
+	notifier := NewInAppNotifier(notificationRepo, testutil.NewMemoryUserRepository(
+		aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+		aggregate.NewUser("bob", "bob", "bob@example.com", "Bob", "hash", valueobject.UserRoleManager),
+		aggregate.NewUser("dave", "dave", "dave@example.com", "Dave", "hash", valueobject.UserRoleEmployee),
+	), taskRepo)
+	bus := &syncEventBus{}
+	for _, eventType := range notifier.EventTypes() {
+		require.NoError(t, bus.Subscribe(eventType, notifier))
+	}
+	svc := service.NewTaskAppService(nil, passthroughTransactionManager{}, taskRepo, nil).WithEventBus(bus)
+
+	requested, err := svc.RequestExtension(ctx, dto.RequestExtensionRequest{
+		TaskID: "task-1", RequesterID: "alice", NewDueDate: newDueDate, Reason: "waiting on finance",
+	})
+	require.NoError(t, err)
+	approved, err := svc.ApproveExtension(ctx, dto.ApproveExtensionRequest{
+		TaskID: "task-1", ExtensionID: requested.ID, ApproverID: "bob",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "approved", approved.Status)
+
+	stored, err := taskRepo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored.DueDate)
+	assert.True(t, stored.DueDate.Equal(newDueDate))
+	notifications, _, err := notificationRepo.FindByUser(ctx, "dave", true, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "TaskDueDateChanged", notifications[0].Type)
+	assert.Contains(t, notifications[0].Body, "2024-05-01 → 2024-05-08")
+	notifications, _, err = notificationRepo.FindByUser(ctx, "alice", true, 0, 0)
+	require.NoError(t, err)
+	types := make([]string, len(notifications))
+	for i, n := range notifications {
+		types[i] = n.Type
+	}
+	assert.ElementsMatch(t, []string{"ExtensionApproved", "TaskDueDateChanged"}, types)
+}
+
+func TestInAppNotifier_AssignmentNotifiesEveryCoResponsible(t *testing.T) {
+	notifier, repo := newTestNotifier(t)
+	ctx := context.Background()
+	task := aggregate.TaskAggregate{ID: "task-1", ResponsibleID: "alice"}
+	require.NoError(t, task.AssignResponsibles("alice", []valueobject.UserID{"dave", "carol"}, "bob"))
+	require.Len(t, task.Events, 1)
+
+	require.NoError(t, notifier.Handle(task.Events[0]))
+
+	for _, userID := range []valueobject.UserID{"alice", "dave"} {
+		notifications, total, err := repo.FindByUser(ctx, userID, true, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, total, userID)
+		assert.Equal(t, "TaskAssigned", notifications[0].Type)
+	}
+	// carol 关闭了站内通知，分配人 bob 本人不收到通知
+	for _, userID := range []valueobject.UserID{"carol", "bob"} {
+		count, err := repo.CountUnread(ctx, userID)
+		require.NoError(t, err)
+		assert.Zero(t, count, userID)
+	}
+}
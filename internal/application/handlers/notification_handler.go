@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -13,6 +18,8 @@ import (
 type FixedNotificationHandler struct {
 	emailService EmailService
 	smsService   SMSService
+	userRepo     repository.UserRepository
+	now          func() time.Time
 }
 
 // EmailService 邮件服务接口
@@ -30,9 +37,43 @@ func NewNotificationHandler(emailService EmailService, smsService SMSService) *F
 	return &FixedNotificationHandler{
 		emailService: emailService,
 		smsService:   smsService,
+		now:          time.Now,
 	}
 }
 
+// WithUserRepository 设置用户仓储，发送前按接收人的通知偏好过滤并使用其邮箱地址
+func (h *FixedNotificationHandler) WithUserRepository(userRepo repository.UserRepository) *FixedNotificationHandler {
+	h.userRepo = userRepo
+	return h
+}
+
+// sendEmail 向用户发送邮件，用户关闭邮件通知或处于静默时段时跳过
+// 未设置用户仓储或用户不存在时发送到默认地址
+func (h *FixedNotificationHandler) sendEmail(userID, subject, body string) error {
+	to := userID + "@company.com"
+	if h.userRepo != nil {
+		user, err := h.userRepo.FindByID(context.Background(), userID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("failed to load notification recipient: %w", err)
+		}
+		if err == nil {
+			settings := user.NotificationSettings
+			if !settings.EmailEnabled {
+				logger.Debug("Email notification disabled by user", zap.String("user_id", userID))
+				return nil
+			}
+			if settings.InQuietHours(h.now(), shared.LoadLocation(user.Timezone)) {
+				logger.Debug("Email notification skipped in quiet hours", zap.String("user_id", userID))
+				return nil
+			}
+			if user.Email != "" {
+				to = user.Email
+			}
+		}
+	}
+	return h.emailService.SendEmail(to, subject, body)
+}
+
 // Handle 处理事件 - 使用反射和类型安全的方法
 func (h *FixedNotificationHandler) Handle(domainEvent event.DomainEvent) error {
 	eventType := domainEvent.EventType()
@@ -122,7 +163,7 @@ func (h *FixedNotificationHandler) handleTaskCreatedSafe(domainEvent event.Domai
 		data.Title, data.ResponsibleID, data.DueDate.Format("2006-01-02"))
 
 	// 通知负责人
-	if err := h.emailService.SendEmail(data.ResponsibleID+"@company.com", subject, body); err != nil {
+	if err := h.sendEmail(data.ResponsibleID, subject, body); err != nil {
 		logger.Error("Failed to send email for TaskCreated", zap.Error(err))
 		return err
 	}
@@ -144,15 +185,18 @@ func (h *FixedNotificationHandler) handleTaskAssignedSafe(domainEvent event.Doma
 	subject := "任务分配通知"
 	body := fmt.Sprintf("您被分配了新任务，任务ID：%s", data.TaskID)
 
-	// 通知新的执行者
-	if err := h.emailService.SendEmail(data.ExecutorID+"@company.com", subject, body); err != nil {
-		logger.Error("Failed to send email for TaskAssigned", zap.Error(err))
-		return err
+	// 通知牵头负责人和全部共同负责人
+	for _, userID := range data.Assignees() {
+		if err := h.sendEmail(userID, subject, body); err != nil {
+			logger.Error("Failed to send email for TaskAssigned", zap.String("user_id", userID), zap.Error(err))
+			return err
+		}
 	}
 
 	logger.Info("Task assigned notification sent",
 		zap.String("task_id", data.TaskID),
-		zap.String("executor_id", data.ExecutorID))
+		zap.String("executor_id", data.ExecutorID),
+		zap.Strings("co_responsible_ids", data.CoResponsibleIDs))
 	return nil
 }
 
@@ -194,7 +238,7 @@ func (h *FixedNotificationHandler) handleWorkReviewedSafe(domainEvent event.Doma
 	body := fmt.Sprintf("您的工作成果审批结果：%s。评论：%s", status, data.Comment)
 
 	// 通知参与人员
-	if err := h.emailService.SendEmail(data.ParticipantID+"@company.com", subject, body); err != nil {
+	if err := h.sendEmail(data.ParticipantID, subject, body); err != nil {
 		logger.Error("Failed to send email for WorkReviewed", zap.Error(err))
 		return err
 	}
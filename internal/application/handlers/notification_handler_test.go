@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// recordingEmailService 记录发送的邮件
+type recordingEmailService struct {
+	sent []string
+}
+
+func (s *recordingEmailService) SendEmail(to, subject, body string) error {
+	s.sent = append(s.sent, to)
+	return nil
+}
+
+func TestNotificationHandler_HonorsDisabledEmailChannel(t *testing.T) {
+	setupLogger(t)
+	ctx := context.Background()
+	users := testutil.NewMemoryUserRepository(
+		aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+	)
+	emails := &recordingEmailService{}
+	handler := NewNotificationHandler(emails, nil).WithUserRepository(users)
+	reviewed := event.NewWorkReviewedEvent("task-1", "alice", "bob", true, "")
+
+	require.NoError(t, handler.Handle(reviewed))
+	assert.Equal(t, []string{"alice@example.com"}, emails.sent, "email goes to the user's address while enabled")
+
+	disabled := false
+	_, err := service.NewCurrentUserAppService(users, nil, nil).UpdateNotificationPreferences(ctx, "alice",
+		&service.UpdateNotificationPreferencesRequest{EmailEnabled: &disabled})
+	require.NoError(t, err)
+
+	require.NoError(t, handler.Handle(reviewed))
+	assert.Len(t, emails.sent, 1, "no email after the user disabled the email channel")
+}
+
+func TestNotificationHandler_SkipsEmailInQuietHours(t *testing.T) {
+	setupLogger(t)
+	alice := aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee)
+	require.NoError(t, alice.SetTimezone("Asia/Shanghai"))
+	settings := alice.NotificationSettings
+	settings.QuietHoursStart, settings.QuietHoursEnd = "22:00", "07:00"
+	require.NoError(t, alice.UpdateNotificationSettings(settings))
+
+	emails := &recordingEmailService{}
+	handler := NewNotificationHandler(emails, nil).WithUserRepository(testutil.NewMemoryUserRepository(alice))
+	reviewed := event.NewWorkReviewedEvent("task-1", "alice", "bob", true, "")
+
+	// 北京时间 23:30 处于静默时段
+	handler.now = func() time.Time { return time.Date(2024, 6, 10, 15, 30, 0, 0, time.UTC) }
+	require.NoError(t, handler.Handle(reviewed))
+	assert.Empty(t, emails.sent)
+
+	// 北京时间 09:00 正常发送
+	handler.now = func() time.Time { return time.Date(2024, 6, 10, 1, 0, 0, 0, time.UTC) }
+	require.NoError(t, handler.Handle(reviewed))
+	assert.Equal(t, []string{"alice@example.com"}, emails.sent)
+}
+
+func TestNotificationHandler_AssignmentEmailsEveryCoResponsible(t *testing.T) {
+	setupLogger(t)
+	emails := &recordingEmailService{}
+	handler := NewNotificationHandler(emails, nil).WithUserRepository(testutil.NewMemoryUserRepository(
+		aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+		aggregate.NewUser("dave", "dave", "dave@example.com", "Dave", "hash", valueobject.UserRoleEmployee),
+	))
+	assigned := event.NewTaskAssignedEvent("task-1", "project-1", "alice", "bob", nil)
+	assigned.CoResponsibleIDs = []string{"dave"}
+
+	require.NoError(t, handler.Handle(assigned))
+
+	assert.Equal(t, []string{"alice@example.com", "dave@example.com"}, emails.sent)
+}
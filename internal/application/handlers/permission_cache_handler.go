@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CacheInvalidator 缓存失效接口
+type CacheInvalidator interface {
+	Del(ctx context.Context, keys ...string) error
+}
+
+// PermissionCacheHandler 权限缓存失效处理器
+// 项目成员关系或角色变化时，立即清除相关的授权/成员缓存，避免等待TTL过期
+type PermissionCacheHandler struct {
+	cache CacheInvalidator
+}
+
+// NewPermissionCacheHandler 创建权限缓存失效处理器
+func NewPermissionCacheHandler(cache CacheInvalidator) *PermissionCacheHandler {
+	return &PermissionCacheHandler{
+		cache: cache,
+	}
+}
+
+// Handle 处理事件
+func (h *PermissionCacheHandler) Handle(domainEvent event.DomainEvent) error {
+	if h.cache == nil {
+		return nil
+	}
+
+	var projectID valueobject.ProjectID
+	var userID valueobject.UserID
+
+	switch e := domainEvent.(type) {
+	case *event.ProjectMemberRoleUpdatedEvent:
+		projectID, userID = e.ProjectID, e.UserID
+	case *event.ProjectMemberAddedEvent:
+		projectID, userID = e.ProjectID, e.UserID
+	case *event.ProjectMemberRemovedEvent:
+		projectID, userID = e.ProjectID, e.UserID
+	default:
+		return fmt.Errorf("unsupported event type: %s", domainEvent.EventType())
+	}
+
+	keys := []string{
+		string(valueobject.BuildProjectCacheKey(projectID)),
+		string(valueobject.BuildProjectMemberRoleCacheKey(projectID, userID)),
+		string(valueobject.BuildUserPermissionsCacheKey(userID)),
+	}
+
+	if err := h.cache.Del(context.Background(), keys...); err != nil {
+		logger.Error("Failed to invalidate permission cache",
+			zap.String("project_id", string(projectID)),
+			zap.String("user_id", string(userID)),
+			zap.Error(err))
+		return err
+	}
+
+	logger.Debug("Permission cache invalidated",
+		zap.String("event_type", domainEvent.EventType()),
+		zap.String("project_id", string(projectID)),
+		zap.String("user_id", string(userID)))
+	return nil
+}
+
+// CanHandle 检查是否可以处理指定事件类型
+func (h *PermissionCacheHandler) CanHandle(eventType string) bool {
+	for _, t := range h.EventTypes() {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypes 返回支持的事件类型
+func (h *PermissionCacheHandler) EventTypes() []string {
+	return []string{
+		"project.member_role_updated",
+		"project.member_added",
+		"project.member_removed",
+	}
+}
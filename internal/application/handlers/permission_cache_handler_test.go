@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+)
+
+// setupLogger 初始化测试用的logger
+func setupLogger(t *testing.T) {
+	err := logger.InitLogger(&logger.Config{
+		Level:  "info",
+		Format: "console",
+		Output: "console",
+	})
+	if err != nil {
+		t.Fatalf("Failed to init logger: %v", err)
+	}
+}
+
+// memoryCache 内存缓存，仅用于测试
+type memoryCache struct {
+	items map[string]aggregate.Project
+}
+
+func (c *memoryCache) Del(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+	return nil
+}
+
+// cachedProjectRepository 先读缓存再读存储的项目仓储，模拟生产环境的旁路缓存
+type cachedProjectRepository struct {
+	repository.ProjectRepository
+	cache *memoryCache
+	store map[valueobject.ProjectID]aggregate.Project
+}
+
+func (r *cachedProjectRepository) FindByID(ctx context.Context, id valueobject.ProjectID) (*aggregate.Project, error) {
+	key := string(valueobject.BuildProjectCacheKey(id))
+	if proj, ok := r.cache.items[key]; ok {
+		return &proj, nil
+	}
+
+	proj, ok := r.store[id]
+	if !ok {
+		return nil, fmt.Errorf("project not found: %s", id)
+	}
+	r.cache.items[key] = proj
+	return &proj, nil
+}
+
+func TestPermissionCacheHandler_RoleUpdateTakesEffectImmediately(t *testing.T) {
+	setupLogger(t)
+	ctx := context.Background()
+	memberID := valueobject.UserID("user-1")
+
+	project := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, project.AddMember(memberID, valueobject.ProjectRoleMember, project.OwnerID))
+
+	cache := &memoryCache{items: map[string]aggregate.Project{}}
+	repo := &cachedProjectRepository{
+		cache: cache,
+		store: map[valueobject.ProjectID]aggregate.Project{project.ID: *project},
+	}
+	domainService := service.NewProjectDomainService(repo, nil)
+
+	// 首次权限检查后项目被缓存
+	role, err := domainService.GetUserProjectRole(ctx, project.ID, memberID)
+	require.NoError(t, err)
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ProjectRoleMember, *role)
+
+	// 持久化新角色，此时缓存中仍是旧角色
+	updated := *project
+	updated.Members = append([]valueobject.ProjectMember(nil), project.Members...)
+	require.NoError(t, updated.UpdateMemberRole(memberID, valueobject.ProjectRoleDeveloper, project.OwnerID))
+	repo.store[project.ID] = updated
+
+	// 处理角色变更事件
+	handler := NewPermissionCacheHandler(cache)
+	roleEvent := event.NewProjectMemberRoleUpdatedEvent(project.ID, memberID,
+		valueobject.ProjectRoleMember, valueobject.ProjectRoleDeveloper, project.OwnerID)
+	require.True(t, handler.CanHandle(roleEvent.EventType()))
+	require.NoError(t, handler.Handle(roleEvent))
+
+	// 再次检查应立即反映新角色
+	role, err = domainService.GetUserProjectRole(ctx, project.ID, memberID)
+	require.NoError(t, err)
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ProjectRoleDeveloper, *role)
+}
+
+func TestPermissionCacheHandler_NilCacheIsNoop(t *testing.T) {
+	handler := NewPermissionCacheHandler(nil)
+	roleEvent := event.NewProjectMemberRoleUpdatedEvent("project-1", "user-1",
+		valueobject.ProjectRoleMember, valueobject.ProjectRoleTester, "owner-1")
+
+	assert.NoError(t, handler.Handle(roleEvent))
+}
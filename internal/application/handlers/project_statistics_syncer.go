@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ProjectStatisticsEventTypes 会改变项目任务计数的领域事件类型
+var ProjectStatisticsEventTypes = []string{
+	"TaskCreated", "TaskDeleted", "TaskStatusChanged", "TaskCompleted",
+}
+
+// ProjectStatisticsSyncer 任务变化时同步项目的任务总数和已完成数
+// 每次都按任务数据重新统计，而不是在旧计数上增减，并发事件和重复投递都会收敛到正确的计数
+type ProjectStatisticsSyncer struct {
+	projectRepo repository.ProjectRepository
+	taskRepo    repository.TaskRepository
+}
+
+// NewProjectStatisticsSyncer 创建项目任务统计同步处理器
+func NewProjectStatisticsSyncer(projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository) *ProjectStatisticsSyncer {
+	return &ProjectStatisticsSyncer{
+		projectRepo: projectRepo,
+		taskRepo:    taskRepo,
+	}
+}
+
+// Handle 重新统计事件所属项目的任务计数
+func (s *ProjectStatisticsSyncer) Handle(domainEvent event.DomainEvent) error {
+	ctx := context.Background()
+
+	projectID, err := s.projectOf(ctx, domainEvent)
+	if err != nil {
+		return err
+	}
+	if projectID == "" {
+		return nil
+	}
+
+	if err := s.projectRepo.SyncTaskStatistics(ctx, projectID); err != nil {
+		return err
+	}
+	logger.Debug("Project task statistics synced",
+		zap.String("event_type", domainEvent.EventType()),
+		zap.String("project_id", string(projectID)))
+	return nil
+}
+
+// projectOf 获取事件所属的项目，事件不带项目ID时通过任务查找，任务不存在时返回空
+func (s *ProjectStatisticsSyncer) projectOf(ctx context.Context, domainEvent event.DomainEvent) (valueobject.ProjectID, error) {
+	switch e := domainEvent.(type) {
+	case *event.TaskCreatedEvent:
+		return valueobject.ProjectID(e.ProjectID), nil
+	case *event.TaskDeletedEvent:
+		return valueobject.ProjectID(e.ProjectID), nil
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(domainEvent.AggregateID()))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to load task for project statistics: %w", err)
+	}
+	return task.ProjectID, nil
+}
+
+// CanHandle 判断是否能处理该事件
+func (s *ProjectStatisticsSyncer) CanHandle(eventType string) bool {
+	for _, t := range ProjectStatisticsEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypes 返回支持的事件类型
+func (s *ProjectStatisticsSyncer) EventTypes() []string {
+	return ProjectStatisticsEventTypes
+}
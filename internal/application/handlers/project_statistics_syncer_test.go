@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	domainService "github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/internal/testutil"
+)
+
+// passthroughTransactionManager 直接执行回调的事务管理器
+type passthroughTransactionManager struct{}
+
+func (passthroughTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTransactionManager) WithTransactionResult(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return fn(ctx)
+}
+
+// syncEventBus 在发布时同步调用已订阅的处理器
+type syncEventBus struct {
+	handlers map[string][]event.EventHandler
+}
+
+func (b *syncEventBus) Publish(e event.DomainEvent) error {
+	for _, h := range b.handlers[e.EventType()] {
+		if err := h.Handle(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *syncEventBus) Subscribe(eventType string, handler event.EventHandler) error {
+	if b.handlers == nil {
+		b.handlers = make(map[string][]event.EventHandler)
+	}
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+	return nil
+}
+
+func (b *syncEventBus) Unsubscribe(eventType string, handler event.EventHandler) error { return nil }
+
+func TestProjectStatisticsSyncer_ConcurrentCompletionsConverge(t *testing.T) {
+	setupLogger(t)
+	ctx := context.Background()
+
+	const completions = 20
+	due := time.Now().Add(24 * time.Hour)
+	var tasks []aggregate.TaskAggregate
+	for i := 0; i <= completions; i++ {
+		tasks = append(tasks, *aggregate.NewTask(valueobject.TaskID(fmt.Sprintf("task-%d", i)), "Task", "",
+			valueobject.TaskTypeRegular, valueobject.TaskPriorityMedium, "project-1", "creator-1", "alice", &due))
+	}
+	taskRepo := testutil.NewMemoryTaskRepository(tasks...)
+	project := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	projectRepo := testutil.NewMemoryProjectRepository(*project).WithTaskRepository(taskRepo)
+	syncer := NewProjectStatisticsSyncer(projectRepo, taskRepo)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, completions)
+	for i := 1; i <= completions; i++ {
+		wg.Add(1)
+		go func(id valueobject.TaskID) {
+			defer wg.Done()
+			task, err := taskRepo.FindByID(ctx, id)
+			if err != nil {
+				errs <- err
+				return
+			}
+			task.Status = valueobject.TaskStatusCompleted
+			if err := taskRepo.Update(ctx, *task); err != nil {
+				errs <- err
+				return
+			}
+			errs <- syncer.Handle(event.NewTaskCompletedEvent(string(id), "alice"))
+		}(valueobject.TaskID(fmt.Sprintf("task-%d", i)))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	stored, err := projectRepo.FindByID(ctx, "project-1")
+	require.NoError(t, err)
+	assert.Equal(t, completions+1, stored.TaskCount)
+	assert.Equal(t, completions, stored.CompletedTasks)
+}
+
+func TestProjectStatisticsSyncer_IgnoresMissingTask(t *testing.T) {
+	setupLogger(t)
+	project := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	taskRepo := testutil.NewMemoryTaskRepository()
+	syncer := NewProjectStatisticsSyncer(testutil.NewMemoryProjectRepository(*project).WithTaskRepository(taskRepo), taskRepo)
+
+	assert.NoError(t, syncer.Handle(event.NewTaskCompletedEvent("missing", "alice")))
+	assert.True(t, syncer.CanHandle("TaskStatusChanged"))
+	assert.False(t, syncer.CanHandle("ProjectCreated"))
+}
+
+func TestProjectStatisticsSyncer_FollowsTaskServiceThroughBus(t *testing.T) {
+	setupLogger(t)
+	ctx := context.Background()
+
+	taskRepo := testutil.NewMemoryTaskRepository()
+	project := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	project.Status = valueobject.ProjectStatusActive
+	projectRepo := testutil.NewMemoryProjectRepository(*project).WithTaskRepository(taskRepo)
+	users := testutil.NewMemoryUserRepository(
+		aggregate.NewUser("owner-1", "owner-1", "owner-1@example.com", "Owner", "hash", valueobject.UserRoleManager),
+		aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+	)
+
+	bus := &syncEventBus{}
+	syncer := NewProjectStatisticsSyncer(projectRepo, taskRepo)
+	for _, eventType := range syncer.EventTypes() {
+		require.NoError(t, bus.Subscribe(eventType, syncer))
+	}
+	svc := service.NewTaskAppService(
+		domainService.NewTaskDomainService(taskRepo, users, projectRepo),
+		passthroughTransactionManager{},
+		taskRepo,
+		aggregate.NewTaskFactory(validation.NewTaskValidator(), nil),
+	).WithEventBus(bus)
+
+	due := time.Now().Add(48 * time.Hour)
+	created, err := svc.CreateTask(ctx, dto.CreateTaskRequest{
+		Title:         "Ship it",
+		TaskType:      string(valueobject.TaskTypeRegular),
+		Priority:      string(valueobject.TaskPriorityMedium),
+		ProjectID:     "project-1",
+		CreatorID:     "owner-1",
+		ResponsibleID: "alice",
+		DueDate:       &due,
+	})
+	require.NoError(t, err)
+
+	stored, err := projectRepo.FindByID(ctx, "project-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.TaskCount, "creating a task recounts the project")
+	assert.Equal(t, 0, stored.CompletedTasks)
+
+	for _, step := range []struct {
+		status valueobject.TaskStatus
+		by     string
+	}{
+		{valueobject.TaskStatusPendingApproval, "owner-1"},
+		{valueobject.TaskStatusApproved, "owner-1"},
+		{valueobject.TaskStatusInProgress, "alice"},
+		{valueobject.TaskStatusCompleted, "alice"},
+	} {
+		require.NoError(t, svc.UpdateTaskStatus(ctx, dto.UpdateTaskStatusRequest{
+			TaskID: created.ID, Status: string(step.status), UpdatedBy: step.by,
+		}), step.status)
+	}
+
+	stored, err = projectRepo.FindByID(ctx, "project-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.TaskCount)
+	assert.Equal(t, 1, stored.CompletedTasks, "completing the task recounts the project")
+}
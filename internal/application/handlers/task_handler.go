@@ -109,7 +109,8 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.taskService.DeleteTask(r.Context(), valueobject.TaskID(taskID))
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	err := h.taskService.DeleteTask(r.Context(), dto.DeleteTaskRequest{TaskID: taskID, Force: force})
 	if err != nil {
 		h.logger.Error("Failed to delete task", zap.String("taskID", taskID), zap.Error(err))
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete task", err)
@@ -266,7 +267,7 @@ func (h *TaskHandler) AddTaskParticipant(w http.ResponseWriter, r *http.Request)
 	}
 
 	req.TaskID = taskID
-	err := h.taskService.AddTaskParticipant(r.Context(), req)
+	_, err := h.taskService.AddTaskParticipant(r.Context(), req)
 	if err != nil {
 		h.logger.Error("Failed to add task participant", zap.String("taskID", taskID), zap.Error(err))
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to add task participant", err)
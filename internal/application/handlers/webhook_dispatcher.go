@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// webhook请求头
+const (
+	WebhookSignatureHeader  = "X-Taskflow-Signature"  // sha256=<hex(HMAC-SHA256(secret, body))>
+	WebhookEventHeader      = "X-Taskflow-Event"      // 事件类型
+	WebhookEventIDHeader    = "X-Taskflow-Event-ID"   // 事件ID
+	WebhookDeliveryHeader   = "X-Taskflow-Delivery"   // 投递ID，同一事件的重试和重投保持不变，订阅方据此去重
+	WebhookRedeliveryHeader = "X-Taskflow-Redelivery" // 管理员手动重投时为 true
+)
+
+// WebhookEventTypes webhook可订阅的领域事件类型
+var WebhookEventTypes = []string{
+	"TaskCreated", "TaskAssigned", "TaskStatusChanged", "TaskPriorityChanged", "TaskWorkflowChanged", "TaskTypeChanged", "TaskDueDateChanged", "TaskDueReminder",
+	"TaskCompleted", "TaskRejected", "TaskDeleted",
+	"ParticipantAdded", "ParticipantRemoved", "WorkSubmitted", "WorkReviewed", "TaskCompletionSubmitted",
+	"ExtensionRequested", "ExtensionApproved", "ExtensionRejected",
+	"NextExecutionPrepared", "AllParticipantsCompleted", "HoursLogged",
+	"project.created", "project.updated", "project.deleted", "project.status_changed",
+	"project.manager_assigned", "project.parent_changed", "project.sub_project_created",
+	"project.member_added", "project.member_removed", "project.member_role_updated",
+	"user.created", "user.role_changed", "user.deactivated", "user.department_transferred",
+}
+
+// WebhookDispatcherConfig webhook投递配置
+type WebhookDispatcherConfig struct {
+	MaxAttempts    int           // 单个事件的最大投递次数，默认3
+	InitialBackoff time.Duration // 首次重试前的等待时间，之后每次翻倍，默认1秒
+	Timeout        time.Duration // 单次请求超时，默认10秒
+	MaxFailures    int           // 连续多少个事件投递失败后停用webhook，默认 aggregate.DefaultWebhookMaxFailures
+	Workers        int           // 并发投递的工作协程数，默认4
+	QueueSize      int           // 等待投递的队列长度，队列满时丢弃新的投递，默认1000
+}
+
+// WebhookDispatcher 将领域事件投递到订阅的webhook
+// 投递由后台工作协程执行，事件处理立即返回，不阻塞事件总线；失败时指数退避重试并记录每次尝试
+type WebhookDispatcher struct {
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+	config      WebhookDispatcherConfig
+
+	jobs    chan webhookJob
+	pending sync.WaitGroup
+	mu      sync.RWMutex
+	closed  bool
+}
+
+// webhookJob 单个webhook的一次事件投递
+type webhookJob struct {
+	webhook aggregate.Webhook
+	event   event.DomainEvent
+	body    []byte
+}
+
+// NewWebhookDispatcher 创建webhook投递处理器，未配置的参数使用默认值
+func NewWebhookDispatcher(webhookRepo repository.WebhookRepository, config WebhookDispatcherConfig) *WebhookDispatcher {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 3
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.MaxFailures <= 0 {
+		config.MaxFailures = aggregate.DefaultWebhookMaxFailures
+	}
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1000
+	}
+
+	d := &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		client:      &http.Client{Timeout: config.Timeout},
+		config:      config,
+		jobs:        make(chan webhookJob, config.QueueSize),
+	}
+	for i := 0; i < config.Workers; i++ {
+		go d.work()
+	}
+	return d
+}
+
+// work 依次执行队列中的投递，直到队列关闭
+func (d *WebhookDispatcher) work() {
+	for job := range d.jobs {
+		d.deliver(context.Background(), job.webhook, job.event, job.body)
+		d.pending.Done()
+	}
+}
+
+// Wait 等待已排队的投递全部完成
+func (d *WebhookDispatcher) Wait() {
+	d.pending.Wait()
+}
+
+// Close 停止接收新的投递，并等待已排队的投递完成
+func (d *WebhookDispatcher) Close() {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	close(d.jobs)
+	d.mu.Unlock()
+
+	d.pending.Wait()
+}
+
+// Handle 将事件加入所有匹配webhook的投递队列后立即返回
+// 投递失败只记录到投递记录和失败计数中，不返回错误，避免事件总线重试时重复投递给已成功的订阅方
+func (d *WebhookDispatcher) Handle(domainEvent event.DomainEvent) error {
+	ctx := context.Background()
+
+	webhooks, err := d.webhookRepo.FindActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhooks: %w", err)
+	}
+
+	var matched []aggregate.Webhook
+	for _, w := range webhooks {
+		if w.Matches(domainEvent.EventType()) {
+			matched = append(matched, w)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	// 与事件查询接口使用相同的信封格式，时间统一为UTC
+	envelope, err := service.NewEventResponse(domainEvent, time.UTC)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return fmt.Errorf("webhook dispatcher is closed")
+	}
+	for _, w := range matched {
+		d.pending.Add(1)
+		select {
+		case d.jobs <- webhookJob{webhook: w, event: domainEvent, body: body}:
+		default:
+			d.pending.Done()
+			logger.Error("Webhook delivery queue is full, dropping delivery",
+				zap.String("webhook_id", w.ID),
+				zap.String("event_id", domainEvent.EventID()),
+				zap.Int("queue_size", d.config.QueueSize))
+		}
+	}
+	return nil
+}
+
+// deliver 带重试地投递到单个webhook，并更新连续失败计数
+// 所有重试使用同一个投递ID和请求体
+func (d *WebhookDispatcher) deliver(ctx context.Context, w aggregate.Webhook, domainEvent event.DomainEvent, body []byte) {
+	deliveryID := uuid.New().String()
+	success := false
+	for attempt := 1; attempt <= d.config.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(d.config.InitialBackoff << (attempt - 2))
+		}
+
+		delivery := d.send(ctx, w, aggregate.WebhookDelivery{
+			DeliveryID: deliveryID,
+			WebhookID:  w.ID,
+			EventID:    domainEvent.EventID(),
+			EventType:  domainEvent.EventType(),
+			Attempt:    attempt,
+			Payload:    body,
+		})
+		if err := d.webhookRepo.SaveDelivery(ctx, delivery); err != nil {
+			logger.Error("Failed to save webhook delivery",
+				zap.String("webhook_id", w.ID),
+				zap.String("event_id", domainEvent.EventID()),
+				zap.Error(err))
+		}
+
+		if delivery.Success {
+			success = true
+			break
+		}
+		logger.Warn("Webhook delivery failed",
+			zap.String("webhook_id", w.ID),
+			zap.String("event_id", domainEvent.EventID()),
+			zap.Int("attempt", attempt),
+			zap.Int("status_code", delivery.StatusCode),
+			zap.String("error", delivery.Error))
+	}
+
+	d.recordResult(ctx, w.ID, success)
+}
+
+// Redeliver 以原投递ID重新发送一次原始请求体，记录为重投
+// 手动重投不计入连续失败次数，成功时清零失败计数
+func (d *WebhookDispatcher) Redeliver(ctx context.Context, w aggregate.Webhook, original aggregate.WebhookDelivery) (aggregate.WebhookDelivery, error) {
+	delivery := d.send(ctx, w, aggregate.WebhookDelivery{
+		DeliveryID: original.DeliveryID,
+		WebhookID:  w.ID,
+		EventID:    original.EventID,
+		EventType:  original.EventType,
+		Attempt:    original.Attempt + 1,
+		Redelivery: true,
+		Payload:    original.Payload,
+	})
+	if err := d.webhookRepo.SaveDelivery(ctx, delivery); err != nil {
+		return delivery, err
+	}
+	if delivery.Success {
+		d.recordResult(ctx, w.ID, true)
+	}
+	return delivery, nil
+}
+
+// send 发送一次签名的POST请求，返回补全了响应结果的投递记录
+func (d *WebhookDispatcher) send(ctx context.Context, w aggregate.Webhook, delivery aggregate.WebhookDelivery) aggregate.WebhookDelivery {
+	start := time.Now()
+	delivery.ID = uuid.New().String()
+	delivery.DeliveredAt = start
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookEventIDHeader, delivery.EventID)
+	req.Header.Set(WebhookDeliveryHeader, delivery.DeliveryID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(w.Secret, delivery.Payload))
+	if delivery.Redelivery {
+		req.Header.Set(WebhookRedeliveryHeader, "true")
+	}
+
+	resp, err := d.client.Do(req)
+	delivery.Duration = time.Since(start)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	delivery.StatusCode = resp.StatusCode
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+	return delivery
+}
+
+// recordResult 重新加载webhook后更新失败计数，避免覆盖投递期间的管理操作
+func (d *WebhookDispatcher) recordResult(ctx context.Context, webhookID string, success bool) {
+	w, err := d.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		logger.Error("Failed to reload webhook", zap.String("webhook_id", webhookID), zap.Error(err))
+		return
+	}
+	if success && w.FailureCount == 0 {
+		return
+	}
+
+	if disabled := w.RecordDeliveryResult(success, d.config.MaxFailures); disabled {
+		logger.Warn("Webhook disabled after repeated delivery failures",
+			zap.String("webhook_id", w.ID),
+			zap.String("url", w.URL),
+			zap.Int("failure_count", w.FailureCount))
+	}
+	if err := d.webhookRepo.Update(ctx, *w); err != nil {
+		logger.Error("Failed to update webhook failure count", zap.String("webhook_id", w.ID), zap.Error(err))
+	}
+}
+
+// SignWebhookPayload 计算请求体签名，订阅方用相同密钥计算后比对 X-Taskflow-Signature
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CanHandle 判断是否能处理该事件
+func (d *WebhookDispatcher) CanHandle(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypes 返回支持的事件类型
+func (d *WebhookDispatcher) EventTypes() []string {
+	return WebhookEventTypes
+}
@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/testutil"
+)
+
+// webhookReceiver 记录收到的webhook请求，依次返回预设的状态码
+type webhookReceiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := http.StatusOK
+	if n := len(r.requests); n < len(r.statuses) {
+		status = r.statuses[n]
+	}
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(status)
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+// newTestWebhook 创建订阅指定事件类型的webhook
+func newTestWebhook(t *testing.T, id, url string, eventTypes ...string) aggregate.Webhook {
+	t.Helper()
+	webhook, err := aggregate.NewWebhook(id, url, "webhook-test-secret", eventTypes, "admin-1")
+	require.NoError(t, err)
+	return *webhook
+}
+
+// newTestDispatcher 创建重试间隔很短的投递处理器
+func newTestDispatcher(repo *testutil.MemoryWebhookRepository, maxFailures int) *WebhookDispatcher {
+	return NewWebhookDispatcher(repo, WebhookDispatcherConfig{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Timeout:        time.Second,
+		MaxFailures:    maxFailures,
+	})
+}
+
+// handleAndWait 投递事件并等待后台投递完成
+func handleAndWait(t *testing.T, d *WebhookDispatcher, e event.DomainEvent) {
+	t.Helper()
+	require.NoError(t, d.Handle(e))
+	d.Wait()
+}
+
+func TestWebhookDispatcher_MatchingEventSendsSignedPost(t *testing.T) {
+	setupLogger(t)
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := testutil.NewMemoryWebhookRepository(newTestWebhook(t, "webhook-1", server.URL, "TaskCompleted"))
+	e := event.NewTaskCompletedEvent("task-1", "user-1")
+
+	handleAndWait(t, newTestDispatcher(repo, 0), e)
+
+	require.Equal(t, 1, receiver.count())
+	req, body := receiver.requests[0], receiver.bodies[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "TaskCompleted", req.Header.Get(WebhookEventHeader))
+	assert.Equal(t, e.EventID(), req.Header.Get(WebhookEventIDHeader))
+	assert.NotEmpty(t, req.Header.Get(WebhookDeliveryHeader))
+	assert.Empty(t, req.Header.Get(WebhookRedeliveryHeader))
+	assert.Equal(t, SignWebhookPayload("webhook-test-secret", body), req.Header.Get(WebhookSignatureHeader))
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, e.EventID(), payload["id"])
+	assert.Equal(t, "TaskCompleted", payload["type"])
+	assert.Equal(t, "task-1", payload["aggregate_id"])
+
+	deliveries, err := repo.FindDeliveries(context.Background(), "webhook-1", 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.True(t, deliveries[0].Success)
+	assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+	assert.Equal(t, 1, deliveries[0].Attempt)
+	assert.Equal(t, req.Header.Get(WebhookDeliveryHeader), deliveries[0].DeliveryID)
+	assert.Equal(t, body, deliveries[0].Payload)
+}
+
+func TestWebhookDispatcher_NonMatchingEventIsNotSent(t *testing.T) {
+	setupLogger(t)
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	inactive := newTestWebhook(t, "webhook-2", server.URL)
+	inactive.Deactivate()
+	repo := testutil.NewMemoryWebhookRepository(
+		newTestWebhook(t, "webhook-1", server.URL, "TaskCompleted"),
+		inactive,
+	)
+
+	handleAndWait(t, newTestDispatcher(repo, 0), event.NewTaskCreatedEvent(
+		"task-1", "Task", "project-1", "user-1", "user-2", "single", "medium", time.Now()))
+
+	assert.Zero(t, receiver.count())
+	for _, id := range []string{"webhook-1", "webhook-2"} {
+		deliveries, err := repo.FindDeliveries(context.Background(), id, 0)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	}
+}
+
+func TestWebhookDispatcher_RetriesAndRecordsEachAttempt(t *testing.T) {
+	setupLogger(t)
+	receiver := &webhookReceiver{statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := testutil.NewMemoryWebhookRepository(newTestWebhook(t, "webhook-1", server.URL))
+
+	handleAndWait(t, newTestDispatcher(repo, 0), event.NewTaskCompletedEvent("task-1", "user-1"))
+
+	assert.Equal(t, 3, receiver.count())
+	deliveries, err := repo.FindDeliveries(context.Background(), "webhook-1", 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	successes := 0
+	for _, d := range deliveries {
+		if d.Success {
+			successes++
+			assert.Equal(t, 3, d.Attempt)
+		}
+	}
+	assert.Equal(t, 1, successes)
+
+	webhook, err := repo.FindByID(context.Background(), "webhook-1")
+	require.NoError(t, err)
+	assert.True(t, webhook.Active)
+	assert.Zero(t, webhook.FailureCount)
+}
+
+func TestWebhookDispatcher_DisablesWebhookAfterRepeatedFailures(t *testing.T) {
+	setupLogger(t)
+	receiver := &webhookReceiver{statuses: make([]int, 6)}
+	for i := range receiver.statuses {
+		receiver.statuses[i] = http.StatusInternalServerError
+	}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := testutil.NewMemoryWebhookRepository(newTestWebhook(t, "webhook-1", server.URL))
+	dispatcher := newTestDispatcher(repo, 2)
+	ctx := context.Background()
+
+	// 第一个事件重试耗尽后计一次失败，webhook仍启用
+	handleAndWait(t, dispatcher, event.NewTaskCompletedEvent("task-1", "user-1"))
+	webhook, err := repo.FindByID(ctx, "webhook-1")
+	require.NoError(t, err)
+	assert.True(t, webhook.Active)
+	assert.Equal(t, 1, webhook.FailureCount)
+
+	// 连续第二个事件失败后自动停用
+	handleAndWait(t, dispatcher, event.NewTaskCompletedEvent("task-2", "user-1"))
+	webhook, err = repo.FindByID(ctx, "webhook-1")
+	require.NoError(t, err)
+	assert.False(t, webhook.Active)
+	assert.NotNil(t, webhook.DisabledAt)
+	assert.Equal(t, 6, receiver.count())
+
+	// 停用后不再投递
+	handleAndWait(t, dispatcher, event.NewTaskCompletedEvent("task-3", "user-1"))
+	assert.Equal(t, 6, receiver.count())
+}
+
+func TestWebhookDispatcher_RetryReusesDeliveryID(t *testing.T) {
+	setupLogger(t)
+	receiver := &webhookReceiver{statuses: []int{http.StatusBadGateway, http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	repo := testutil.NewMemoryWebhookRepository(newTestWebhook(t, "webhook-1", server.URL))
+	e := event.NewTaskCompletedEvent("task-1", "user-1")
+
+	handleAndWait(t, newTestDispatcher(repo, 0), e)
+
+	require.Equal(t, 2, receiver.count())
+	deliveryID := receiver.requests[0].Header.Get(WebhookDeliveryHeader)
+	require.NotEmpty(t, deliveryID)
+	assert.Equal(t, deliveryID, receiver.requests[1].Header.Get(WebhookDeliveryHeader))
+	assert.Equal(t, receiver.bodies[0], receiver.bodies[1])
+	for _, req := range receiver.requests {
+		assert.Equal(t, e.EventID(), req.Header.Get(WebhookEventIDHeader))
+	}
+
+	// 两次尝试的记录ID不同，但共用投递ID
+	attempts, err := repo.FindDeliveryAttempts(context.Background(), deliveryID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.NotEqual(t, attempts[0].ID, attempts[1].ID)
+	assert.Equal(t, 1, attempts[0].Attempt)
+	assert.Equal(t, 2, attempts[1].Attempt)
+}
+
+func TestWebhookDispatcher_RedeliverReusesDeliveryIDAndMarksRedelivery(t *testing.T) {
+	setupLogger(t)
+	receiver := &webhookReceiver{statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	webhook := newTestWebhook(t, "webhook-1", server.URL)
+	repo := testutil.NewMemoryWebhookRepository(webhook)
+	dispatcher := newTestDispatcher(repo, 0)
+	e := event.NewTaskCompletedEvent("task-1", "user-1")
+	ctx := context.Background()
+
+	handleAndWait(t, dispatcher, e)
+	require.Equal(t, 3, receiver.count())
+	deliveryID := receiver.requests[0].Header.Get(WebhookDeliveryHeader)
+	attempts, err := repo.FindDeliveryAttempts(ctx, deliveryID)
+	require.NoError(t, err)
+	last := attempts[len(attempts)-1]
+
+	delivery, err := dispatcher.Redeliver(ctx, webhook, last)
+	require.NoError(t, err)
+
+	require.Equal(t, 4, receiver.count())
+	req := receiver.requests[3]
+	assert.Equal(t, deliveryID, req.Header.Get(WebhookDeliveryHeader))
+	assert.Equal(t, e.EventID(), req.Header.Get(WebhookEventIDHeader))
+	assert.Equal(t, "true", req.Header.Get(WebhookRedeliveryHeader))
+	assert.Equal(t, receiver.bodies[0], receiver.bodies[3])
+	assert.Equal(t, SignWebhookPayload("webhook-test-secret", receiver.bodies[3]), req.Header.Get(WebhookSignatureHeader))
+
+	assert.True(t, delivery.Success)
+	assert.True(t, delivery.Redelivery)
+	assert.Equal(t, deliveryID, delivery.DeliveryID)
+	assert.Equal(t, e.EventID(), delivery.EventID)
+	assert.Equal(t, 4, delivery.Attempt)
+
+	// 重投成功后清零失败计数
+	stored, err := repo.FindByID(ctx, "webhook-1")
+	require.NoError(t, err)
+	assert.Zero(t, stored.FailureCount)
+	attempts, err = repo.FindDeliveryAttempts(ctx, deliveryID)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 4)
+}
+
+func TestWebhookDispatcher_SlowEndpointDoesNotBlockHandle(t *testing.T) {
+	setupLogger(t)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	repo := testutil.NewMemoryWebhookRepository(newTestWebhook(t, "webhook-1", server.URL))
+	dispatcher := NewWebhookDispatcher(repo, WebhookDispatcherConfig{MaxAttempts: 1, Workers: 1, QueueSize: 1})
+
+	// 投递卡住时事件处理仍立即返回，队列满后丢弃新的投递
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, dispatcher.Handle(event.NewTaskCompletedEvent(fmt.Sprintf("task-%d", i), "user-1")))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Handle blocked on a stalled webhook endpoint")
+	}
+
+	close(release)
+	dispatcher.Close()
+	assert.Error(t, dispatcher.Handle(event.NewTaskCompletedEvent("task-late", "user-1")), "closed dispatcher rejects new events")
+}
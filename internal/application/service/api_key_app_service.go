@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// apiKeyLastUsedInterval 最近使用时间的记录间隔，避免每个请求都写库
+const apiKeyLastUsedInterval = time.Minute
+
+// APIKeyAppService 用户API密钥管理和认证应用服务
+type APIKeyAppService struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+	now        func() time.Time
+}
+
+// NewAPIKeyAppService 创建用户API密钥应用服务
+func NewAPIKeyAppService(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository) *APIKeyAppService {
+	return &APIKeyAppService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		now:        time.Now,
+	}
+}
+
+// CreateAPIKey 为用户创建API密钥，明文密钥只在响应中返回这一次
+func (s *APIKeyAppService) CreateAPIKey(ctx context.Context, userID string, req dto.CreateAPIKeyRequest) (*dto.CreatedAPIKeyResponse, error) {
+	key, raw, err := aggregate.NewAPIKey(uuid.New().String(), valueobject.UserID(userID), req.Name, req.Scopes)
+	if err != nil {
+		return nil, event.NewDomainErrorWithCause(event.ErrInvalidInput, "API密钥参数无效", err)
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, *key); err != nil {
+		return nil, fmt.Errorf("保存API密钥失败: %w", err)
+	}
+	return &dto.CreatedAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key, shared.LocationFromContext(ctx)),
+		Key:            raw,
+	}, nil
+}
+
+// ListAPIKeys 获取用户的全部API密钥，包含已吊销的密钥
+func (s *APIKeyAppService) ListAPIKeys(ctx context.Context, userID string) ([]dto.APIKeyResponse, error) {
+	keys, err := s.apiKeyRepo.FindByUser(ctx, valueobject.UserID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("查询API密钥失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	responses := make([]dto.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = toAPIKeyResponse(&keys[i], loc)
+	}
+	return responses, nil
+}
+
+// RevokeAPIKey 吊销API密钥，只有密钥所属用户或管理员可以吊销；重复吊销不报错
+func (s *APIKeyAppService) RevokeAPIKey(ctx context.Context, userID, id string, isAdmin bool) (*dto.APIKeyResponse, error) {
+	key, err := s.apiKeyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("查找API密钥失败: %w", err)
+	}
+	if key.UserID != valueobject.UserID(userID) && !isAdmin {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, "only the owner can revoke an api key")
+	}
+
+	if !key.IsRevoked() {
+		key.Revoke()
+		if err := s.apiKeyRepo.Update(ctx, *key); err != nil {
+			return nil, fmt.Errorf("吊销API密钥失败: %w", err)
+		}
+	}
+	response := toAPIKeyResponse(key, shared.LocationFromContext(ctx))
+	return &response, nil
+}
+
+// Authenticate 按明文密钥的哈希查找密钥并校验作用域，返回密钥所属用户的身份
+// 密钥不存在或已吊销时返回 ErrTokenInvalid，作用域不足或 requiredScope 为空时返回 ErrPermissionDenied，用户已停用时返回 ErrUserInactive
+func (s *APIKeyAppService) Authenticate(ctx context.Context, rawKey, requiredScope string) (*dto.APIKeyPrincipal, error) {
+	key, err := s.apiKeyRepo.FindByHash(ctx, aggregate.HashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, event.NewDomainError(event.ErrTokenInvalid, "invalid api key")
+		}
+		return nil, fmt.Errorf("查找API密钥失败: %w", err)
+	}
+	if key.IsRevoked() {
+		return nil, event.NewDomainError(event.ErrTokenInvalid, "api key has been revoked")
+	}
+	if !key.HasScope(requiredScope) {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, fmt.Sprintf("api key lacks the %q scope", requiredScope))
+	}
+
+	user, err := s.userRepo.FindByID(ctx, string(key.UserID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, event.NewDomainError(event.ErrTokenInvalid, "api key owner no longer exists")
+		}
+		return nil, fmt.Errorf("查找API密钥所属用户失败: %w", err)
+	}
+	if !user.IsActive() {
+		return nil, event.NewDomainError(event.ErrUserInactive, "api key owner is not active")
+	}
+
+	s.touch(ctx, key)
+	return &dto.APIKeyPrincipal{
+		KeyID:  key.ID,
+		UserID: string(user.ID),
+		Email:  user.Email,
+		Roles:  []string{string(user.Role)},
+		Scopes: key.Scopes,
+	}, nil
+}
+
+// touch 记录密钥最近使用时间，距上次记录不足 apiKeyLastUsedInterval 时跳过；失败只记录日志
+func (s *APIKeyAppService) touch(ctx context.Context, key *aggregate.APIKey) {
+	now := s.now()
+	if key.LastUsedAt != nil && now.Sub(*key.LastUsedAt) < apiKeyLastUsedInterval {
+		return
+	}
+	key.MarkUsed(now)
+	if err := s.apiKeyRepo.Update(ctx, *key); err != nil {
+		logger.Warn("Failed to record api key usage", zap.String("api_key_id", key.ID), zap.Error(err))
+	}
+}
+
+// toAPIKeyResponse 转换为API密钥响应，不包含明文密钥和哈希
+func toAPIKeyResponse(k *aggregate.APIKey, loc *time.Location) dto.APIKeyResponse {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	response := dto.APIKeyResponse{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    scopes,
+		CreatedAt: k.CreatedAt.In(loc),
+	}
+	if k.LastUsedAt != nil {
+		lastUsedAt := k.LastUsedAt.In(loc)
+		response.LastUsedAt = &lastUsedAt
+	}
+	if k.RevokedAt != nil {
+		revokedAt := k.RevokedAt.In(loc)
+		response.RevokedAt = &revokedAt
+	}
+	return response
+}
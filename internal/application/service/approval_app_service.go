@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// DefaultApprovalPageSize 未指定 page_size 时每页返回的审批数
+const DefaultApprovalPageSize = 20
+
+// ApprovalAppService 审批查询应用服务
+// 审批由任务创建审批、工作提交审核和延期申请组成，均从任务数据中汇总
+type ApprovalAppService struct {
+	taskRepo repository.TaskRepository
+	userRepo repository.UserRepository
+}
+
+// NewApprovalAppService 创建审批查询应用服务
+func NewApprovalAppService(taskRepo repository.TaskRepository, userRepo repository.UserRepository) *ApprovalAppService {
+	return &ApprovalAppService{taskRepo: taskRepo, userRepo: userRepo}
+}
+
+// ListMyApprovals 分页查询请求者提交的审批及其状态（只读操作，不需要事务）
+func (s *ApprovalAppService) ListMyApprovals(ctx context.Context, req dto.MyApprovalsRequest) (*dto.MyApprovalsResponse, error) {
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultApprovalPageSize
+	}
+
+	user, err := s.userRepo.FindByID(ctx, req.RequesterID)
+	if err != nil {
+		return nil, fmt.Errorf("查找请求者失败: %w", err)
+	}
+	requester := valueobject.UserSummary{
+		ID:       string(user.ID),
+		Username: user.Username,
+		Email:    user.Email,
+		FullName: user.FullName,
+		Status:   string(user.Status),
+		Role:     string(user.Role),
+		JoinDate: user.CreatedAt,
+	}
+
+	records, total, err := s.taskRepo.FindSubmittedApprovals(ctx, valueobject.SubmittedApprovalCriteria{
+		RequesterID: user.ID,
+		Kind:        valueobject.SubmittedApprovalKind(req.Type),
+		Status:      valueobject.ApprovalStatus(req.Status),
+		Limit:       pageSize,
+		Offset:      (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询提交的审批失败: %w", err)
+	}
+	tasks, err := s.findTasks(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	approvals := make([]dto.MyApprovalItem, 0, len(records))
+	for _, record := range records {
+		task, ok := tasks[record.TaskID]
+		if !ok {
+			continue
+		}
+		item := newMyApprovalItem(record, task, requester)
+		item.SubmittedAt = item.SubmittedAt.In(loc)
+		item.DueDate = shared.InLocation(item.DueDate, loc)
+		item.Requester.JoinDate = item.Requester.JoinDate.In(loc)
+		approvals = append(approvals, item)
+	}
+
+	return &dto.MyApprovalsResponse{
+		Approvals:  approvals,
+		Pagination: valueobject.NewPaginationResponse(page, pageSize, int64(total)),
+	}, nil
+}
+
+// findTasks 批量查找当前页审批关联的任务
+func (s *ApprovalAppService) findTasks(ctx context.Context, records []valueobject.SubmittedApproval) (map[valueobject.TaskID]*aggregate.TaskAggregate, error) {
+	result := make(map[valueobject.TaskID]*aggregate.TaskAggregate, len(records))
+	if len(records) == 0 {
+		return result, nil
+	}
+	ids := make([]valueobject.TaskID, len(records))
+	for i, record := range records {
+		ids[i] = record.TaskID
+	}
+	tasks, err := s.taskRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("查询审批关联任务失败: %w", err)
+	}
+	for i := range tasks {
+		result[tasks[i].ID] = &tasks[i]
+	}
+	return result, nil
+}
+
+// newMyApprovalItem 以关联任务的标题、优先级和截止日期构造审批项，待审批时标记当前步骤
+func newMyApprovalItem(record valueobject.SubmittedApproval, task *aggregate.TaskAggregate, requester valueobject.UserSummary) dto.MyApprovalItem {
+	item := dto.MyApprovalItem{
+		ApprovalSummary: valueobject.ApprovalSummary{
+			ID:          record.ID,
+			Type:        string(record.Kind),
+			Title:       task.Title,
+			Priority:    string(task.Priority),
+			Status:      string(record.Status),
+			Requester:   requester,
+			SubmittedAt: record.SubmittedAt,
+			DueDate:     task.DueDate,
+		},
+		TaskID: string(task.ID),
+	}
+	if record.Status == valueobject.ApprovalStatusPending {
+		step := dto.ApprovalStepReview
+		item.CurrentStep = &step
+	}
+	return item
+}
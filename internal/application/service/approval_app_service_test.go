@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// criteriaRecordingTaskRepository 记录审批查询下推到仓储的条件
+type criteriaRecordingTaskRepository struct {
+	*testutil.MemoryTaskRepository
+	criteria valueobject.SubmittedApprovalCriteria
+}
+
+func (r *criteriaRecordingTaskRepository) FindSubmittedApprovals(ctx context.Context, criteria valueobject.SubmittedApprovalCriteria) ([]valueobject.SubmittedApproval, int, error) {
+	r.criteria = criteria
+	return r.MemoryTaskRepository.FindSubmittedApprovals(ctx, criteria)
+}
+
+// newApprovalFixture 准备 alice 和 bob 各自提交的任务审批、工作提交和延期申请
+func newApprovalFixture() *ApprovalAppService {
+	svc, _ := newRecordingApprovalFixture()
+	return svc
+}
+
+// newRecordingApprovalFixture 与 newApprovalFixture 相同，同时返回记录查询条件的任务仓储
+func newRecordingApprovalFixture() (*ApprovalAppService, *criteriaRecordingTaskRepository) {
+	base := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	newTask := func(id valueobject.TaskID, creator valueobject.UserID, status valueobject.TaskStatus, created time.Time) aggregate.TaskAggregate {
+		dueDate := base.Add(30 * 24 * time.Hour)
+		return aggregate.TaskAggregate{
+			ID:            id,
+			Title:         "Task " + string(id),
+			TaskType:      valueobject.TaskTypeRegular,
+			Priority:      valueobject.TaskPriorityHigh,
+			Status:        status,
+			ProjectID:     "project-1",
+			CreatorID:     creator,
+			ResponsibleID: creator,
+			DueDate:       &dueDate,
+			CreatedAt:     created,
+			UpdatedAt:     created,
+		}
+	}
+
+	alicePending := newTask("task-alice-pending", "alice", valueobject.TaskStatusPendingApproval, base)
+	aliceDraft := newTask("task-alice-draft", "alice", valueobject.TaskStatusDraft, base.Add(time.Hour))
+	aliceRejected := newTask("task-alice-rejected", "alice", valueobject.TaskStatusRejected, base.Add(2*time.Hour))
+	aliceRejected.WorkSubmissions = []valueobject.WorkSubmission{
+		{ID: "ws-alice", TaskID: aliceRejected.ID, SubmitterID: "alice", Status: valueobject.WorkSubmissionStatusApproved, SubmittedAt: base.Add(3 * time.Hour)},
+	}
+	aliceRejected.Extensions = []valueobject.ExtensionRequest{
+		{ID: "ext-alice", TaskID: aliceRejected.ID, RequesterID: "alice", Status: valueobject.ExtensionStatusPending, RequestedAt: base.Add(4 * time.Hour)},
+	}
+
+	bobApproved := newTask("task-bob", "bob", valueobject.TaskStatusInProgress, base)
+	approvedAt := base.Add(time.Hour)
+	bobApproved.ApprovedAt = &approvedAt
+	bobApproved.WorkSubmissions = []valueobject.WorkSubmission{
+		{ID: "ws-bob", TaskID: bobApproved.ID, SubmitterID: "bob", Status: valueobject.WorkSubmissionStatusPending, SubmittedAt: base.Add(5 * time.Hour)},
+	}
+	bobApproved.Extensions = []valueobject.ExtensionRequest{
+		{ID: "ext-bob", TaskID: bobApproved.ID, RequesterID: "bob", Status: valueobject.ExtensionStatusPending, RequestedAt: base.Add(6 * time.Hour)},
+	}
+
+	taskRepo := &criteriaRecordingTaskRepository{MemoryTaskRepository: testutil.NewMemoryTaskRepository(alicePending, aliceDraft, aliceRejected, bobApproved)}
+	userRepo := testutil.NewMemoryUserRepository(
+		aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+		aggregate.NewUser("bob", "bob", "bob@example.com", "Bob", "hash", valueobject.UserRoleEmployee),
+	)
+	return NewApprovalAppService(taskRepo, userRepo), taskRepo
+}
+
+func approvalIDs(resp *dto.MyApprovalsResponse) []string {
+	ids := make([]string, len(resp.Approvals))
+	for i, approval := range resp.Approvals {
+		ids[i] = approval.ID
+	}
+	return ids
+}
+
+func TestListMyApprovals_ReturnsOnlyCallersRequests(t *testing.T) {
+	svc := newApprovalFixture()
+
+	resp, err := svc.ListMyApprovals(context.Background(), dto.MyApprovalsRequest{RequesterID: "alice"})
+	require.NoError(t, err)
+
+	// 按提交时间倒序，草稿任务未进入审批不计入
+	assert.Equal(t, []string{"ext-alice", "ws-alice", "task-alice-rejected", "task-alice-pending"}, approvalIDs(resp))
+	assert.Equal(t, int64(4), resp.Pagination.Total)
+	for _, approval := range resp.Approvals {
+		assert.Equal(t, "alice", approval.Requester.ID)
+	}
+
+	ext := resp.Approvals[0]
+	assert.Equal(t, dto.ApprovalKindExtensionRequest, ext.Type)
+	assert.Equal(t, "task-alice-rejected", ext.TaskID)
+	assert.Equal(t, "Task task-alice-rejected", ext.Title)
+	assert.Equal(t, string(valueobject.TaskPriorityHigh), ext.Priority)
+	require.NotNil(t, ext.CurrentStep)
+	assert.Equal(t, dto.ApprovalStepReview, *ext.CurrentStep)
+	require.NotNil(t, ext.DueDate)
+
+	assert.Nil(t, resp.Approvals[1].CurrentStep, "decided approvals have no current step")
+}
+
+func TestListMyApprovals_FiltersByStatusAndType(t *testing.T) {
+	svc := newApprovalFixture()
+	ctx := context.Background()
+
+	pending, err := svc.ListMyApprovals(ctx, dto.MyApprovalsRequest{RequesterID: "alice", Status: "pending"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ext-alice", "task-alice-pending"}, approvalIDs(pending))
+
+	rejected, err := svc.ListMyApprovals(ctx, dto.MyApprovalsRequest{RequesterID: "alice", Status: "rejected"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task-alice-rejected"}, approvalIDs(rejected))
+
+	pendingTasks, err := svc.ListMyApprovals(ctx, dto.MyApprovalsRequest{
+		RequesterID: "alice",
+		Status:      "pending",
+		Type:        dto.ApprovalKindTaskCreation,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task-alice-pending"}, approvalIDs(pendingTasks))
+
+	bobApproved, err := svc.ListMyApprovals(ctx, dto.MyApprovalsRequest{RequesterID: "bob", Status: "approved"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task-bob"}, approvalIDs(bobApproved))
+}
+
+func TestListMyApprovals_Paginates(t *testing.T) {
+	svc, repo := newRecordingApprovalFixture()
+	ctx := context.Background()
+
+	second, err := svc.ListMyApprovals(ctx, dto.MyApprovalsRequest{RequesterID: "alice", Status: "pending", Type: "task_creation", Page: 2, PageSize: 3})
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.SubmittedApprovalCriteria{
+		RequesterID: "alice", Kind: valueobject.SubmittedApprovalTaskCreation, Status: valueobject.ApprovalStatusPending, Limit: 3, Offset: 3,
+	}, repo.criteria, "filters and the page window are pushed into the repository")
+	assert.Empty(t, second.Approvals)
+
+	second, err = svc.ListMyApprovals(ctx, dto.MyApprovalsRequest{RequesterID: "alice", Page: 2, PageSize: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"task-alice-pending"}, approvalIDs(second))
+	assert.Equal(t, valueobject.NewPaginationResponse(2, 3, 4), second.Pagination)
+
+	beyond, err := svc.ListMyApprovals(ctx, dto.MyApprovalsRequest{RequesterID: "alice", Page: 5, PageSize: 3})
+	require.NoError(t, err)
+	assert.NotNil(t, beyond.Approvals)
+	assert.Empty(t, beyond.Approvals)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	authService "github.com/taskflow/internal/domain/auth/service"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// CurrentUserAppService 当前登录用户应用服务
+// 只读写调用者自身的数据，用户ID由认证上下文提供
+type CurrentUserAppService struct {
+	userRepo          repository.UserRepository
+	projectRepo       repository.ProjectRepository
+	permissionService authService.PermissionDomainService
+}
+
+// NewCurrentUserAppService 创建当前用户应用服务
+func NewCurrentUserAppService(
+	userRepo repository.UserRepository,
+	projectRepo repository.ProjectRepository,
+	permissionService authService.PermissionDomainService,
+) *CurrentUserAppService {
+	return &CurrentUserAppService{
+		userRepo:          userRepo,
+		projectRepo:       projectRepo,
+		permissionService: permissionService,
+	}
+}
+
+// CurrentUserPermission 当前用户的有效权限
+type CurrentUserPermission struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// CurrentUserResponse 当前用户资料、角色、有效权限和统计信息
+type CurrentUserResponse struct {
+	valueobject.UserDetailResponse
+	Permissions []CurrentUserPermission `json:"permissions"`
+}
+
+// GetCurrentUser 获取当前用户的资料、角色、有效权限和可访问项目数（只读操作，不需要事务）
+func (s *CurrentUserAppService) GetCurrentUser(ctx context.Context, userID string) (*CurrentUserResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取当前用户失败: %w", err)
+	}
+
+	roles, err := s.permissionService.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取用户角色失败: %w", err)
+	}
+	roleNames := []string{string(user.Role)}
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+	}
+
+	permissions, err := s.permissionService.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取用户权限失败: %w", err)
+	}
+	permissionResponses := make([]CurrentUserPermission, len(permissions))
+	for i, p := range permissions {
+		permissionResponses[i] = CurrentUserPermission{
+			ID:       string(p.ID),
+			Name:     p.Name,
+			Resource: string(p.Resource),
+			Action:   string(p.Action),
+		}
+	}
+
+	// 只需要总数，不加载项目列表
+	_, totalProjects, err := s.projectRepo.FindUserAccessibleProjects(ctx, user.ID, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("统计可访问项目失败: %w", err)
+	}
+
+	return &CurrentUserResponse{
+		UserDetailResponse: valueobject.UserDetailResponse{
+			ID:         string(user.ID),
+			Username:   user.Username,
+			Email:      user.Email,
+			FullName:   user.FullName,
+			Status:     string(user.Status),
+			Roles:      uniqueStrings(roleNames),
+			JoinDate:   user.CreatedAt,
+			Statistics: &valueobject.UserStatistics{TotalProjects: totalProjects},
+			CreatedAt:  user.CreatedAt,
+			UpdatedAt:  user.UpdatedAt,
+		},
+		Permissions: permissionResponses,
+	}, nil
+}
+
+// NotificationPreferencesResponse 当前用户的通知偏好设置，静默时段按 Timezone 解释
+type NotificationPreferencesResponse struct {
+	valueobject.NotificationSettings
+	Timezone string `json:"timezone"`
+}
+
+// UpdateNotificationPreferencesRequest 批量更新通知偏好，未提供的字段保持不变
+// 静默时段的开始和结束同时传空字符串表示取消静默时段
+type UpdateNotificationPreferencesRequest struct {
+	EmailEnabled    *bool   `json:"email_enabled,omitempty"`
+	SMSEnabled      *bool   `json:"sms_enabled,omitempty"`
+	PushEnabled     *bool   `json:"push_enabled,omitempty"`
+	ReminderHours   *int    `json:"reminder_hours,omitempty"`
+	EscalationHours *int    `json:"escalation_hours,omitempty"`
+	QuietHoursStart *string `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   *string `json:"quiet_hours_end,omitempty"`
+	Timezone        *string `json:"timezone,omitempty"`
+}
+
+// GetNotificationPreferences 获取当前用户的通知偏好设置
+func (s *CurrentUserAppService) GetNotificationPreferences(ctx context.Context, userID string) (*NotificationPreferencesResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取当前用户失败: %w", err)
+	}
+	return newNotificationPreferencesResponse(user), nil
+}
+
+// UpdateNotificationPreferences 批量更新当前用户的通知偏好设置
+// 小时数为负、静默时段格式错误或时区无效时返回 ErrInvalidInput，不做任何修改
+func (s *CurrentUserAppService) UpdateNotificationPreferences(ctx context.Context, userID string, req *UpdateNotificationPreferencesRequest) (*NotificationPreferencesResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("获取当前用户失败: %w", err)
+	}
+
+	settings := user.NotificationSettings
+	if req.EmailEnabled != nil {
+		settings.EmailEnabled = *req.EmailEnabled
+	}
+	if req.SMSEnabled != nil {
+		settings.SMSEnabled = *req.SMSEnabled
+	}
+	if req.PushEnabled != nil {
+		settings.PushEnabled = *req.PushEnabled
+	}
+	if req.ReminderHours != nil {
+		settings.ReminderHours = *req.ReminderHours
+	}
+	if req.EscalationHours != nil {
+		settings.EscalationHours = *req.EscalationHours
+	}
+	if req.QuietHoursStart != nil {
+		settings.QuietHoursStart = *req.QuietHoursStart
+	}
+	if req.QuietHoursEnd != nil {
+		settings.QuietHoursEnd = *req.QuietHoursEnd
+	}
+
+	if req.Timezone != nil {
+		if err := user.SetTimezone(*req.Timezone); err != nil {
+			return nil, event.NewDomainErrorWithCause(event.ErrInvalidInput, err.Error(), err)
+		}
+	}
+	if err := user.UpdateNotificationSettings(settings); err != nil {
+		return nil, event.NewDomainErrorWithCause(event.ErrInvalidInput, err.Error(), err)
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("保存通知偏好失败: %w", err)
+	}
+	return newNotificationPreferencesResponse(user), nil
+}
+
+func newNotificationPreferencesResponse(user *aggregate.User) *NotificationPreferencesResponse {
+	return &NotificationPreferencesResponse{
+		NotificationSettings: user.NotificationSettings,
+		Timezone:             user.Timezone,
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	authAggregate "github.com/taskflow/internal/domain/auth/aggregate"
+	authRepository "github.com/taskflow/internal/domain/auth/repository"
+	authService "github.com/taskflow/internal/domain/auth/service"
+	authValueObject "github.com/taskflow/internal/domain/auth/valueobject"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// newCurrentUserFixture 创建测试环境：user-1 拥有 manager 角色（task-update、task-read），并参与一个项目
+func newCurrentUserFixture(t *testing.T) *CurrentUserAppService {
+	t.Helper()
+
+	permissions := testutil.NewMemoryPermissionRepository(
+		authAggregate.NewPermission("task-update", "Task Update", authValueObject.ResourceTypeTask, authValueObject.ActionTypeUpdate, "Update tasks"),
+		authAggregate.NewPermission("task-read", "Task Read", authValueObject.ResourceTypeTask, authValueObject.ActionTypeRead, "Read tasks"),
+		authAggregate.NewPermission("project-delete", "Project Delete", authValueObject.ResourceTypeProject, authValueObject.ActionTypeDelete, "Delete projects"),
+	)
+	manager := authAggregate.NewRole("manager", "manager", "Manager", "Manager role", false)
+	require.NoError(t, manager.AddPermission("task-update"))
+	require.NoError(t, manager.AddPermission("task-read"))
+	admin := authAggregate.NewRole("admin", "admin", "Admin", "Admin role", false)
+	require.NoError(t, admin.AddPermission("project-delete"))
+	roles := testutil.NewMemoryRoleRepository(permissions, manager, admin)
+	userRoles := testutil.NewMemoryUserRoleRepository(roles)
+	policies := testutil.NewMemoryPolicyRepository()
+	evaluator := authRepository.NewRBACAbacEvaluator(permissions, roles, policies)
+	permissionService := authService.NewPermissionDomainService(permissions, roles, policies, userRoles, evaluator, nil)
+
+	ctx := context.Background()
+	require.NoError(t, userRoles.AssignRole(ctx, "user-1", "manager"))
+	require.NoError(t, userRoles.AssignRole(ctx, "user-2", "admin"))
+
+	joined := aggregate.NewProject("p-joined", "Joined", "", valueobject.ProjectTypeMaster, "owner-2")
+	require.NoError(t, joined.AddMember("user-1", valueobject.ProjectRoleMember, "owner-2"))
+	other := aggregate.NewProject("p-other", "Other", "", valueobject.ProjectTypeMaster, "user-2")
+
+	users := testutil.NewMemoryUserRepository(
+		aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+		aggregate.NewUser("user-2", "bob", "bob@example.com", "Bob", "hash", valueobject.UserRoleAdmin),
+	)
+	return NewCurrentUserAppService(users, testutil.NewMemoryProjectRepository(*joined, *other), permissionService)
+}
+
+func TestGetCurrentUser_ReturnsRolesAndRolePermissions(t *testing.T) {
+	svc := newCurrentUserFixture(t)
+
+	resp, err := svc.GetCurrentUser(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", resp.ID)
+	assert.Equal(t, "alice@example.com", resp.Email)
+	assert.ElementsMatch(t, []string{"employee", "manager"}, resp.Roles)
+
+	granted := make([]string, len(resp.Permissions))
+	for i, p := range resp.Permissions {
+		granted[i] = p.ID
+	}
+	assert.Subset(t, granted, []string{"task-update", "task-read"}, "permissions granted by the caller's roles must be included")
+	assert.NotContains(t, granted, "project-delete", "other users' role permissions must not leak")
+	require.NotNil(t, resp.Statistics)
+	assert.Equal(t, 1, resp.Statistics.TotalProjects)
+}
+
+func TestGetCurrentUser_UnknownUser(t *testing.T) {
+	svc := newCurrentUserFixture(t)
+
+	_, err := svc.GetCurrentUser(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestUpdateNotificationPreferences_UpdatesOnlyGivenFields(t *testing.T) {
+	svc := newCurrentUserFixture(t)
+	ctx := context.Background()
+	disabled, reminderHours := false, 6
+	quietStart, quietEnd, timezone := "22:00", "07:00", "Asia/Shanghai"
+
+	resp, err := svc.UpdateNotificationPreferences(ctx, "user-1", &UpdateNotificationPreferencesRequest{
+		EmailEnabled:    &disabled,
+		ReminderHours:   &reminderHours,
+		QuietHoursStart: &quietStart,
+		QuietHoursEnd:   &quietEnd,
+		Timezone:        &timezone,
+	})
+	require.NoError(t, err)
+
+	stored, err := svc.GetNotificationPreferences(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, resp, stored)
+	assert.False(t, stored.EmailEnabled)
+	assert.True(t, stored.PushEnabled, "fields not in the request keep their value")
+	assert.Equal(t, 6, stored.ReminderHours)
+	assert.Equal(t, valueobject.DefaultNotificationSettings().EscalationHours, stored.EscalationHours)
+	assert.Equal(t, "22:00", stored.QuietHoursStart)
+	assert.Equal(t, "Asia/Shanghai", stored.Timezone)
+}
+
+func TestUpdateNotificationPreferences_RejectsInvalidInput(t *testing.T) {
+	svc := newCurrentUserFixture(t)
+	ctx := context.Background()
+	negative, badTime, badZone := -1, "25:00", "Mars/Olympus"
+	disabled := false
+
+	for name, req := range map[string]*UpdateNotificationPreferencesRequest{
+		"negative hours":     {EmailEnabled: &disabled, EscalationHours: &negative},
+		"invalid quiet time": {EmailEnabled: &disabled, QuietHoursStart: &badTime, QuietHoursEnd: &badTime},
+		"invalid timezone":   {EmailEnabled: &disabled, Timezone: &badZone},
+	} {
+		_, err := svc.UpdateNotificationPreferences(ctx, "user-1", req)
+
+		var domainErr *event.DomainError
+		require.ErrorAs(t, err, &domainErr, name)
+		assert.Equal(t, event.ErrInvalidInput, domainErr.Type, name)
+	}
+
+	stored, err := svc.GetNotificationPreferences(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.DefaultNotificationSettings(), stored.NotificationSettings, "rejected updates leave settings unchanged")
+	assert.Empty(t, stored.Timezone)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/shared"
+)
+
+// DefaultEventPageSize 未指定 limit 时每页返回的事件数
+const DefaultEventPageSize = 100
+
+// EventAppService 领域事件查询应用服务，供外部系统按类型和时间窗口拉取事件
+type EventAppService struct {
+	eventStore event.EventQueryStore
+}
+
+// NewEventAppService 创建领域事件查询应用服务
+func NewEventAppService(eventStore event.EventQueryStore) *EventAppService {
+	return &EventAppService{eventStore: eventStore}
+}
+
+// ListEvents 按类型、聚合和时间窗口分页查询事件（只读操作，不需要事务）
+func (s *EventAppService) ListEvents(ctx context.Context, req dto.ListEventsRequest) (*dto.ListEventsResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultEventPageSize
+	}
+
+	query := event.EventQuery{Types: req.Types, AggregateID: req.AggregateID, AfterID: req.AfterID, Limit: limit}
+	if req.Since != nil {
+		query.Since = *req.Since
+	}
+
+	events, err := s.eventStore.FindEvents(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("查询领域事件失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	response := &dto.ListEventsResponse{Events: make([]dto.EventResponse, len(events))}
+	for i, e := range events {
+		item, err := NewEventResponse(e, loc)
+		if err != nil {
+			return nil, err
+		}
+		response.Events[i] = item
+	}
+
+	// 返回满一页时可能还有更多数据，以最后一条事件作为下一页游标
+	if len(events) == limit {
+		last := response.Events[len(response.Events)-1]
+		response.NextSince = &last.OccurredAt
+		response.NextAfterID = last.ID
+	}
+	return response, nil
+}
+
+// NewEventResponse 转换为事件响应，事件数据为空时序列化事件本身，时间按 loc 渲染
+func NewEventResponse(e event.DomainEvent, loc *time.Location) (dto.EventResponse, error) {
+	data := e.EventData()
+	if data == nil {
+		data = e
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return dto.EventResponse{}, fmt.Errorf("序列化事件 %s 失败: %w", e.EventID(), err)
+	}
+
+	response := dto.EventResponse{
+		ID:            e.EventID(),
+		Type:          e.EventType(),
+		AggregateID:   e.AggregateID(),
+		AggregateType: e.AggregateType(),
+		Version:       e.Version(),
+		OccurredAt:    e.OccurredAt().In(loc),
+		Payload:       payload,
+	}
+	if actor, ok := e.(event.ActorEvent); ok {
+		response.ActorID = actor.ActorID()
+	}
+	return response, nil
+}
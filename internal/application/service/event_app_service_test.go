@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/infrastructure/messaging/memory"
+)
+
+var eventsBase = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// newEventFixture 事件存储：evt-1/evt-3 为任务完成，evt-2 为任务拒绝，evt-3 与 evt-4 同一时刻发生
+func newEventFixture(t *testing.T) *EventAppService {
+	t.Helper()
+	store := memory.NewInMemoryEventStore(0)
+
+	completed1 := event.NewTaskCompletedEvent("task-1", "user-1")
+	completed1.ID, completed1.Timestamp = "evt-1", eventsBase
+	rejected := event.NewTaskRejectedEvent("task-2", "user-2", "incomplete")
+	rejected.ID, rejected.Timestamp = "evt-2", eventsBase.Add(time.Hour)
+	completed2 := event.NewTaskCompletedEvent("task-3", "user-1")
+	completed2.ID, completed2.Timestamp = "evt-3", eventsBase.Add(2*time.Hour)
+	completed3 := event.NewTaskCompletedEvent("task-4", "user-3")
+	completed3.ID, completed3.Timestamp = "evt-4", eventsBase.Add(2*time.Hour)
+
+	for _, e := range []event.DomainEvent{completed3, rejected, completed1, completed2} {
+		require.NoError(t, store.Save(e))
+	}
+	return NewEventAppService(store)
+}
+
+func eventIDs(resp *dto.ListEventsResponse) []string {
+	ids := make([]string, len(resp.Events))
+	for i, e := range resp.Events {
+		ids[i] = e.ID
+	}
+	return ids
+}
+
+func TestListEvents_FiltersByTypeInOccurrenceOrder(t *testing.T) {
+	svc := newEventFixture(t)
+
+	resp, err := svc.ListEvents(context.Background(), dto.ListEventsRequest{Types: []string{"TaskCompleted"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-1", "evt-3", "evt-4"}, eventIDs(resp))
+	assert.Equal(t, "user-1", resp.Events[0].ActorID)
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Events[0].Payload, &payload))
+	assert.Equal(t, "task-1", payload["task_id"])
+	assert.Nil(t, resp.NextSince, "a partial page has no next cursor")
+
+	both, err := svc.ListEvents(context.Background(), dto.ListEventsRequest{Types: []string{"TaskCompleted", "TaskRejected"}})
+	require.NoError(t, err)
+	assert.Len(t, both.Events, 4)
+}
+
+func TestListEvents_SinceIsInclusive(t *testing.T) {
+	svc := newEventFixture(t)
+	since := eventsBase.Add(time.Hour)
+
+	resp, err := svc.ListEvents(context.Background(), dto.ListEventsRequest{Since: &since})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-2", "evt-3", "evt-4"}, eventIDs(resp))
+}
+
+func TestListEvents_PagesThroughEventsWithSameTimestamp(t *testing.T) {
+	svc := newEventFixture(t)
+
+	first, err := svc.ListEvents(context.Background(), dto.ListEventsRequest{Types: []string{"TaskCompleted"}, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-1", "evt-3"}, eventIDs(first))
+	require.NotNil(t, first.NextSince)
+	assert.Equal(t, "evt-3", first.NextAfterID)
+
+	second, err := svc.ListEvents(context.Background(), dto.ListEventsRequest{
+		Types: []string{"TaskCompleted"}, Since: first.NextSince, AfterID: first.NextAfterID, Limit: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-4"}, eventIDs(second), "events sharing the cursor timestamp must not be skipped or repeated")
+}
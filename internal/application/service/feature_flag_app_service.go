@@ -0,0 +1,46 @@
+package service
+
+import (
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// FeatureFlagChecker 功能开关查询接口，服务未设置开关时视为全部开启
+type FeatureFlagChecker interface {
+	IsEnabled(flag valueobject.FeatureFlag, fc valueobject.FeatureFlagContext) bool
+}
+
+// featureEnabled 未设置功能开关时保持原有行为
+func featureEnabled(flags FeatureFlagChecker, flag valueobject.FeatureFlag, fc valueobject.FeatureFlagContext) bool {
+	return flags == nil || flags.IsEnabled(flag, fc)
+}
+
+// FeatureFlagAppService 基于配置的功能开关服务，按环境和项目开启或关闭新功能，无需重新部署代码
+type FeatureFlagAppService struct {
+	policy valueobject.FeatureFlagPolicy
+}
+
+// NewFeatureFlagAppService 创建功能开关服务
+func NewFeatureFlagAppService(policy valueobject.FeatureFlagPolicy) *FeatureFlagAppService {
+	return &FeatureFlagAppService{policy: policy}
+}
+
+// IsEnabled 判断功能开关在上下文中是否开启
+func (s *FeatureFlagAppService) IsEnabled(flag valueobject.FeatureFlag, fc valueobject.FeatureFlagContext) bool {
+	return s.policy.IsEnabled(flag, fc)
+}
+
+// ListFlags 列出全部已定义的功能开关在当前环境下的取值，projectID 非空时应用该项目的覆盖配置
+func (s *FeatureFlagAppService) ListFlags(projectID string) *dto.FeatureFlagListResponse {
+	fc := valueobject.FeatureFlagContext{ProjectID: valueobject.ProjectID(projectID)}
+	response := &dto.FeatureFlagListResponse{
+		Environment: s.policy.Environment,
+		ProjectID:   projectID,
+		Flags:       make([]dto.FeatureFlagResponse, len(valueobject.KnownFeatureFlags)),
+	}
+	for i, flag := range valueobject.KnownFeatureFlags {
+		enabled, source := s.policy.Resolve(flag, fc)
+		response.Flags[i] = dto.FeatureFlagResponse{Name: string(flag), Enabled: enabled, Source: string(source)}
+	}
+	return response
+}
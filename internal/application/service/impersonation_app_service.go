@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	authService "github.com/taskflow/internal/domain/auth/service"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+const (
+	// ImpersonationTokenTTL 模拟登录令牌的有效期
+	ImpersonationTokenTTL = 15 * time.Minute
+
+	// impersonationRateWindow 和 impersonationRateLimit 每个管理员在窗口内最多发起的模拟次数
+	impersonationRateWindow = time.Hour
+	impersonationRateLimit  = 10
+)
+
+// ImpersonationAppService 管理员模拟用户应用服务，用于客服以用户视角复现问题
+// 发起模拟和模拟期间的每个请求都记录在操作日志中
+type ImpersonationAppService struct {
+	transactionMgr   authService.TransactionManager
+	userRepo         repository.UserRepository
+	operationLogRepo repository.OperationLogRepository
+	jwtService       authService.JWTService
+	now              func() time.Time
+}
+
+// NewImpersonationAppService 创建模拟用户应用服务
+func NewImpersonationAppService(
+	transactionMgr authService.TransactionManager,
+	userRepo repository.UserRepository,
+	operationLogRepo repository.OperationLogRepository,
+	jwtService authService.JWTService,
+) *ImpersonationAppService {
+	return &ImpersonationAppService{
+		transactionMgr:   transactionMgr,
+		userRepo:         userRepo,
+		operationLogRepo: operationLogRepo,
+		jwtService:       jwtService,
+		now:              time.Now,
+	}
+}
+
+// Impersonate 为管理员签发模拟目标用户的短期令牌（需要事务）
+// 发起人不是管理员或目标用户权限不低于发起人时返回 ErrPermissionDenied，模拟自己时返回 ErrInvalidInput，
+// 目标用户未激活时返回 ErrUserInactive，超过频率限制时返回 ErrRateLimited。
+// 事务中先锁定管理员行，同一管理员的并发请求依次完成计数和审计记录写入，不会超过频率限制
+func (s *ImpersonationAppService) Impersonate(ctx context.Context, req dto.ImpersonateRequest) (*dto.ImpersonationResponse, error) {
+	if req.TargetUserID == req.ImpersonatorID {
+		return nil, event.NewDomainError(event.ErrInvalidInput, "cannot impersonate yourself")
+	}
+
+	var response *dto.ImpersonationResponse
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		admin, err := s.userRepo.FindByIDForUpdate(ctx, req.ImpersonatorID)
+		if err != nil {
+			return fmt.Errorf("获取管理员失败: %w", err)
+		}
+		if admin.Role.Level() < valueobject.UserRoleAdmin.Level() {
+			return event.NewDomainError(event.ErrPermissionDenied, "only admins can impersonate users")
+		}
+
+		target, err := s.userRepo.FindByID(ctx, req.TargetUserID)
+		if err != nil {
+			return fmt.Errorf("获取被模拟用户失败: %w", err)
+		}
+		if target.Role.Level() >= admin.Role.Level() {
+			return event.NewDomainError(event.ErrPermissionDenied, "cannot impersonate a user with equal or higher privileges")
+		}
+		if !target.IsActive() {
+			return event.NewDomainError(event.ErrUserInactive, "cannot impersonate an inactive user")
+		}
+
+		now := s.now()
+		count, err := s.operationLogRepo.CountByUserSince(ctx, admin.ID, aggregate.OperationImpersonate, now.Add(-impersonationRateWindow))
+		if err != nil {
+			return fmt.Errorf("统计模拟次数失败: %w", err)
+		}
+		if count >= impersonationRateLimit {
+			return event.NewDomainError(event.ErrRateLimited,
+				fmt.Sprintf("at most %d impersonations per %s", impersonationRateLimit, impersonationRateWindow))
+		}
+
+		tokens, err := s.jwtService.GenerateImpersonationToken(string(target.ID), target.Email,
+			[]string{string(target.Role)}, string(admin.ID), ImpersonationTokenTTL)
+		if err != nil {
+			return fmt.Errorf("生成模拟令牌失败: %w", err)
+		}
+
+		// 审计记录写入成功后才返回令牌
+		if err := s.operationLogRepo.Create(ctx, aggregate.OperationLog{
+			ID:           uuid.New().String(),
+			UserID:       admin.ID,
+			Operation:    aggregate.OperationImpersonate,
+			ResourceType: "users",
+			ResourceID:   string(target.ID),
+			IPAddress:    req.IPAddress,
+			UserAgent:    req.UserAgent,
+			RequestData: map[string]interface{}{
+				"reason":     req.Reason,
+				"expires_at": tokens.ExpiresAt.UTC().Format(time.RFC3339),
+			},
+			CreatedAt: now,
+		}); err != nil {
+			return fmt.Errorf("记录模拟登录失败: %w", err)
+		}
+
+		response = &dto.ImpersonationResponse{
+			AccessToken:    tokens.AccessToken,
+			TokenType:      tokens.TokenType,
+			ExpiresIn:      tokens.ExpiresIn,
+			ExpiresAt:      tokens.ExpiresAt,
+			Impersonating:  true,
+			UserID:         string(target.ID),
+			ImpersonatorID: string(admin.ID),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// RecordImpersonatedAction 记录模拟登录期间的一次请求，操作人为被模拟用户，同时记录实际操作的管理员
+func (s *ImpersonationAppService) RecordImpersonatedAction(ctx context.Context, action dto.ImpersonatedAction) error {
+	impersonatorID := valueobject.UserID(action.ImpersonatorID)
+	if err := s.operationLogRepo.Create(ctx, aggregate.OperationLog{
+		ID:             uuid.New().String(),
+		UserID:         valueobject.UserID(action.UserID),
+		ImpersonatorID: &impersonatorID,
+		Operation:      action.Operation,
+		ResourceType:   action.ResourceType,
+		ResourceID:     action.ResourceID,
+		IPAddress:      action.IPAddress,
+		UserAgent:      action.UserAgent,
+		ResponseStatus: action.ResponseStatus,
+		CreatedAt:      s.now(),
+	}); err != nil {
+		return fmt.Errorf("记录模拟操作失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	authValueobject "github.com/taskflow/internal/domain/auth/valueobject"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// recordingJWTService 记录模拟令牌参数的JWT服务
+type recordingJWTService struct {
+	impersonatorID string
+	ttl            time.Duration
+}
+
+func (j *recordingJWTService) GenerateTokens(userID, email string, roles []string) (*authValueobject.TokenPair, error) {
+	return &authValueobject.TokenPair{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer"}, nil
+}
+
+func (j *recordingJWTService) GenerateImpersonationToken(userID, email string, roles []string, impersonatorID string, ttl time.Duration) (*authValueobject.TokenPair, error) {
+	j.impersonatorID = impersonatorID
+	j.ttl = ttl
+	return &authValueobject.TokenPair{
+		AccessToken: "impersonation-" + userID,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Seconds()),
+		ExpiresAt:   time.Now().Add(ttl),
+	}, nil
+}
+
+func (j *recordingJWTService) ValidateToken(tokenString string) (*authValueobject.Claims, error) {
+	return nil, nil
+}
+
+func (j *recordingJWTService) RefreshToken(refreshToken string) (*authValueobject.TokenPair, error) {
+	return nil, nil
+}
+
+func (j *recordingJWTService) RevokeToken(tokenString string) error {
+	return nil
+}
+
+func newImpersonationFixture() (*ImpersonationAppService, *testutil.MemoryOperationLogRepository, *recordingJWTService) {
+	inactive := aggregate.NewUser("inactive-1", "ivan", "ivan@example.com", "Ivan", "hash", valueobject.UserRoleEmployee)
+	inactive.Deactivate()
+	users := testutil.NewMemoryUserRepository(
+		aggregate.NewUser("admin-1", "root", "root@example.com", "Root", "hash", valueobject.UserRoleAdmin),
+		aggregate.NewUser("admin-2", "ops", "ops@example.com", "Ops", "hash", valueobject.UserRoleAdmin),
+		aggregate.NewUser("super-1", "boss", "boss@example.com", "Boss", "hash", valueobject.UserRoleSuperAdmin),
+		aggregate.NewUser("manager-1", "mia", "mia@example.com", "Mia", "hash", valueobject.UserRoleManager),
+		aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+		inactive,
+	)
+	logs := testutil.NewMemoryOperationLogRepository()
+	jwt := &recordingJWTService{}
+	return NewImpersonationAppService(passthroughTransactionManager{}, users, logs, jwt), logs, jwt
+}
+
+func TestImpersonate_IssuesShortLivedTokenAndAudits(t *testing.T) {
+	svc, logs, jwt := newImpersonationFixture()
+
+	resp, err := svc.Impersonate(context.Background(), dto.ImpersonateRequest{
+		TargetUserID:   "user-1",
+		ImpersonatorID: "admin-1",
+		Reason:         "ticket 42",
+		IPAddress:      "10.0.0.1",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, resp.Impersonating)
+	assert.Equal(t, "user-1", resp.UserID)
+	assert.Equal(t, "admin-1", resp.ImpersonatorID)
+	assert.Equal(t, "admin-1", jwt.impersonatorID)
+	assert.Equal(t, ImpersonationTokenTTL, jwt.ttl)
+	assert.Equal(t, int64(ImpersonationTokenTTL.Seconds()), resp.ExpiresIn)
+
+	recorded := logs.All()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, valueobject.UserID("admin-1"), recorded[0].UserID)
+	assert.Equal(t, aggregate.OperationImpersonate, recorded[0].Operation)
+	assert.Equal(t, "user-1", recorded[0].ResourceID)
+	assert.Equal(t, "ticket 42", recorded[0].RequestData["reason"])
+	assert.Equal(t, "10.0.0.1", recorded[0].IPAddress)
+}
+
+func TestImpersonate_RequiresAdminAndLessPrivilegedActiveTarget(t *testing.T) {
+	svc, logs, _ := newImpersonationFixture()
+	ctx := context.Background()
+
+	cases := []struct {
+		impersonator, target string
+		errType              event.DomainErrorType
+	}{
+		{"manager-1", "user-1", event.ErrPermissionDenied},
+		{"admin-1", "admin-2", event.ErrPermissionDenied},
+		{"admin-1", "super-1", event.ErrPermissionDenied},
+		{"admin-1", "admin-1", event.ErrInvalidInput},
+		{"admin-1", "inactive-1", event.ErrUserInactive},
+	}
+	for _, tc := range cases {
+		_, err := svc.Impersonate(ctx, dto.ImpersonateRequest{TargetUserID: tc.target, ImpersonatorID: tc.impersonator})
+		assert.True(t, event.IsErrorType(err, tc.errType), "%s -> %s: %v", tc.impersonator, tc.target, err)
+	}
+	assert.Empty(t, logs.All(), "rejected attempts must not issue tokens")
+
+	// 超级管理员可以模拟管理员
+	_, err := svc.Impersonate(ctx, dto.ImpersonateRequest{TargetUserID: "admin-1", ImpersonatorID: "super-1"})
+	require.NoError(t, err)
+}
+
+func TestImpersonate_RateLimitedPerAdmin(t *testing.T) {
+	svc, _, _ := newImpersonationFixture()
+	ctx := context.Background()
+	req := dto.ImpersonateRequest{TargetUserID: "user-1", ImpersonatorID: "admin-1"}
+
+	for i := 0; i < impersonationRateLimit; i++ {
+		_, err := svc.Impersonate(ctx, req)
+		require.NoError(t, err)
+	}
+	_, err := svc.Impersonate(ctx, req)
+	assert.True(t, event.IsErrorType(err, event.ErrRateLimited), "got %v", err)
+
+	// 窗口过去后可以再次发起
+	svc.now = func() time.Time { return time.Now().Add(impersonationRateWindow + time.Minute) }
+	_, err = svc.Impersonate(ctx, req)
+	require.NoError(t, err)
+}
+
+// serialTransactionManager 依次执行事务，模拟事务中锁定管理员行后同一管理员的请求串行执行
+type serialTransactionManager struct {
+	mu sync.Mutex
+}
+
+func (m *serialTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fn(ctx)
+}
+
+func (m *serialTransactionManager) WithTransactionResult(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fn(ctx)
+}
+
+func TestImpersonate_ConcurrentRequestsStayWithinRateLimit(t *testing.T) {
+	svc, logs, _ := newImpersonationFixture()
+	svc.transactionMgr = &serialTransactionManager{}
+	req := dto.ImpersonateRequest{TargetUserID: "user-1", ImpersonatorID: "admin-1"}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		limited   int
+	)
+	for i := 0; i < 2*impersonationRateLimit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.Impersonate(context.Background(), req)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case event.IsErrorType(err, event.ErrRateLimited):
+				limited++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, impersonationRateLimit, succeeded)
+	assert.Equal(t, impersonationRateLimit, limited)
+	assert.Len(t, logs.All(), impersonationRateLimit, "one audit record per issued token")
+}
+
+func TestRecordImpersonatedAction_RecordsRealAdmin(t *testing.T) {
+	svc, logs, _ := newImpersonationFixture()
+
+	require.NoError(t, svc.RecordImpersonatedAction(context.Background(), dto.ImpersonatedAction{
+		UserID:         "user-1",
+		ImpersonatorID: "admin-1",
+		Operation:      "PUT /api/v1/tasks/:id",
+		ResourceType:   "tasks",
+		ResourceID:     "task-1",
+		ResponseStatus: 200,
+	}))
+
+	recorded := logs.All()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, valueobject.UserID("user-1"), recorded[0].UserID)
+	require.True(t, recorded[0].IsImpersonated())
+	assert.Equal(t, valueobject.UserID("admin-1"), *recorded[0].ImpersonatorID)
+	assert.Equal(t, 200, recorded[0].ResponseStatus)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// DefaultNotificationPageSize 未指定 page_size 时每页返回的通知数
+const DefaultNotificationPageSize = 20
+
+// NotificationAppService 站内通知应用服务，通知由事件处理器写入，用户只能查看和标记自己的通知
+type NotificationAppService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewNotificationAppService 创建站内通知应用服务
+func NewNotificationAppService(notificationRepo repository.NotificationRepository) *NotificationAppService {
+	return &NotificationAppService{notificationRepo: notificationRepo}
+}
+
+// ListNotifications 分页查询用户的通知，同时返回未读总数
+func (s *NotificationAppService) ListNotifications(ctx context.Context, req dto.ListNotificationsRequest) (*dto.NotificationListResponse, error) {
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultNotificationPageSize
+	}
+
+	userID := valueobject.UserID(req.UserID)
+	notifications, total, err := s.notificationRepo.FindByUser(ctx, userID, req.Unread, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("查询通知失败: %w", err)
+	}
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("统计未读通知失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	items := make([]dto.NotificationResponse, len(notifications))
+	for i := range notifications {
+		items[i] = toNotificationResponse(&notifications[i], loc)
+	}
+
+	return &dto.NotificationListResponse{
+		Notifications: items,
+		UnreadCount:   unread,
+		Pagination:    valueobject.NewPaginationResponse(page, pageSize, int64(total)),
+	}, nil
+}
+
+// MarkNotificationRead 标记一条通知为已读，通知不属于该用户时按不存在处理
+func (s *NotificationAppService) MarkNotificationRead(ctx context.Context, userID, id string) (*dto.NotificationResponse, error) {
+	notification, err := s.notificationRepo.MarkRead(ctx, valueobject.UserID(userID), id)
+	if err != nil {
+		return nil, fmt.Errorf("标记通知已读失败: %w", err)
+	}
+	response := toNotificationResponse(notification, shared.LocationFromContext(ctx))
+	return &response, nil
+}
+
+// MarkAllNotificationsRead 标记用户的全部未读通知为已读
+func (s *NotificationAppService) MarkAllNotificationsRead(ctx context.Context, userID string) (*dto.MarkAllNotificationsReadResponse, error) {
+	marked, err := s.notificationRepo.MarkAllRead(ctx, valueobject.UserID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("标记全部通知已读失败: %w", err)
+	}
+	unread, err := s.notificationRepo.CountUnread(ctx, valueobject.UserID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("统计未读通知失败: %w", err)
+	}
+	return &dto.MarkAllNotificationsReadResponse{Marked: marked, UnreadCount: unread}, nil
+}
+
+func toNotificationResponse(n *aggregate.Notification, loc *time.Location) dto.NotificationResponse {
+	return dto.NotificationResponse{
+		ID:           n.ID,
+		Type:         n.Type,
+		Title:        n.Title,
+		Body:         n.Body,
+		ResourceType: n.ResourceType,
+		ResourceID:   n.ResourceID,
+		Read:         n.Read,
+		ReadAt:       shared.InLocation(n.ReadAt, loc),
+		CreatedAt:    n.CreatedAt.In(loc),
+	}
+}
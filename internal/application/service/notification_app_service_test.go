@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/testutil"
+)
+
+// newNotificationFixture alice 有三条通知（其中一条已读），bob 有一条未读通知
+func newNotificationFixture() *NotificationAppService {
+	base := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	readAt := base.Add(time.Minute)
+	repo := testutil.NewMemoryNotificationRepository(
+		aggregate.Notification{ID: "n-1", UserID: "alice", Type: "TaskCreated", Title: "t1", CreatedAt: base},
+		aggregate.Notification{ID: "n-2", UserID: "alice", Type: "TaskAssigned", Title: "t2", CreatedAt: base.Add(time.Hour), Read: true, ReadAt: &readAt},
+		aggregate.Notification{ID: "n-3", UserID: "alice", Type: "TaskRejected", Title: "t3", CreatedAt: base.Add(2 * time.Hour)},
+		aggregate.Notification{ID: "n-4", UserID: "bob", Type: "TaskCreated", Title: "t4", CreatedAt: base},
+	)
+	return NewNotificationAppService(repo)
+}
+
+func notificationIDs(resp *dto.NotificationListResponse) []string {
+	ids := make([]string, len(resp.Notifications))
+	for i, n := range resp.Notifications {
+		ids[i] = n.ID
+	}
+	return ids
+}
+
+func TestListNotifications_UnreadFilter(t *testing.T) {
+	svc := newNotificationFixture()
+	ctx := context.Background()
+
+	all, err := svc.ListNotifications(ctx, dto.ListNotificationsRequest{UserID: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"n-3", "n-2", "n-1"}, notificationIDs(all))
+	assert.Equal(t, 2, all.UnreadCount)
+
+	unread, err := svc.ListNotifications(ctx, dto.ListNotificationsRequest{UserID: "alice", Unread: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"n-3", "n-1"}, notificationIDs(unread))
+	assert.Equal(t, int64(2), unread.Pagination.Total)
+}
+
+func TestMarkNotificationRead_DecreasesUnreadCount(t *testing.T) {
+	svc := newNotificationFixture()
+	ctx := context.Background()
+
+	resp, err := svc.MarkNotificationRead(ctx, "alice", "n-3")
+	require.NoError(t, err)
+	assert.True(t, resp.Read)
+	require.NotNil(t, resp.ReadAt)
+
+	list, err := svc.ListNotifications(ctx, dto.ListNotificationsRequest{UserID: "alice", Unread: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"n-1"}, notificationIDs(list))
+	assert.Equal(t, 1, list.UnreadCount)
+
+	// 不能标记其他用户的通知
+	_, err = svc.MarkNotificationRead(ctx, "alice", "n-4")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestMarkAllNotificationsRead(t *testing.T) {
+	svc := newNotificationFixture()
+	ctx := context.Background()
+
+	resp, err := svc.MarkAllNotificationsRead(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Marked)
+	assert.Zero(t, resp.UnreadCount)
+
+	bob, err := svc.ListNotifications(ctx, dto.ListNotificationsRequest{UserID: "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, bob.UnreadCount)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/taskflow/internal/application/dto"
 	"github.com/taskflow/internal/domain/auth/aggregate"
 	"github.com/taskflow/internal/domain/auth/service"
 	"github.com/taskflow/internal/domain/auth/valueobject"
@@ -58,6 +59,56 @@ func (s *PermissionAppService) CanUserPerformAction(
 	return allowed, nil
 }
 
+// ExplainPermission 评估用户能否执行操作并解释决策：匹配的角色权限、参与评估的策略及决定结果的规则
+func (s *PermissionAppService) ExplainPermission(ctx context.Context, req dto.ExplainPermissionRequest) (*dto.PermissionExplanation, error) {
+	result, err := s.domainService.ExplainUserAction(ctx, req.UserID,
+		valueobject.ResourceType(req.Resource), valueobject.ActionType(req.Action), req.ResourceCtx)
+	if err != nil {
+		return nil, fmt.Errorf("permission explanation failed: %w", err)
+	}
+
+	explanation := &dto.PermissionExplanation{
+		UserID:          req.UserID,
+		Resource:        req.Resource,
+		Action:          req.Action,
+		Allowed:         result.Allowed,
+		Effect:          string(result.Effect),
+		Reason:          result.Reason,
+		DeterminingRule: result.MatchedRule,
+		Roles:           []string{},
+		RolePermissions: []dto.RolePermissionMatchDTO{},
+		Policies:        []dto.PolicyEvaluationDTO{},
+	}
+	if result.Trace == nil {
+		return explanation, nil
+	}
+	for _, roleID := range result.Trace.Roles {
+		explanation.Roles = append(explanation.Roles, string(roleID))
+	}
+	for _, match := range result.Trace.RolePermissions {
+		explanation.RolePermissions = append(explanation.RolePermissions, dto.RolePermissionMatchDTO{
+			RoleID:       string(match.RoleID),
+			PermissionID: string(match.PermissionID),
+		})
+	}
+	for _, policy := range result.Trace.Policies {
+		evaluation := dto.PolicyEvaluationDTO{
+			PolicyID: string(policy.PolicyID),
+			Name:     policy.Name,
+			Effect:   string(policy.Effect),
+			Priority: policy.Priority,
+			Active:   policy.Active,
+			Matched:  policy.Matched,
+		}
+		explanation.Policies = append(explanation.Policies, evaluation)
+		if result.MatchedRule == "policy:"+evaluation.PolicyID {
+			explanation.DecidingPolicy = &evaluation
+		}
+	}
+
+	return explanation, nil
+}
+
 // GetUserPermissions 获取用户的所有权限
 func (s *PermissionAppService) GetUserPermissions(ctx context.Context, userID string) ([]aggregate.Permission, error) {
 	domainPermissions, err := s.domainService.GetUserPermissions(ctx, userID)
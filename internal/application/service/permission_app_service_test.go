@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	authAggregate "github.com/taskflow/internal/domain/auth/aggregate"
+	authRepository "github.com/taskflow/internal/domain/auth/repository"
+	authService "github.com/taskflow/internal/domain/auth/service"
+	authValueObject "github.com/taskflow/internal/domain/auth/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+func TestExplainPermission_DenyPolicyNamesDecidingPolicy(t *testing.T) {
+	permissions := testutil.NewMemoryPermissionRepository(
+		authAggregate.NewPermission("task-update", "Task Update", authValueObject.ResourceTypeTask, authValueObject.ActionTypeUpdate, "Update tasks"),
+	)
+	editor := authAggregate.NewRole("editor", "editor", "Editor", "Editor role", false)
+	require.NoError(t, editor.AddPermission("task-update"))
+	roles := testutil.NewMemoryRoleRepository(permissions, editor)
+	userRoles := testutil.NewMemoryUserRoleRepository(roles)
+	policies := testutil.NewMemoryPolicyRepository(authAggregate.NewPolicy(
+		"freeze-archived", "Freeze Archived", "", authValueObject.ResourceTypeTask, authValueObject.ActionTypeUpdate,
+		authValueObject.PolicyEffectDeny, authValueObject.PolicyConditions{"resource.status": "archived"}, 300,
+	))
+	evaluator := authRepository.NewRBACAbacEvaluator(permissions, roles, policies)
+	svc := NewPermissionAppService(authService.NewPermissionDomainService(permissions, roles, policies, userRoles, evaluator, nil), nil)
+	ctx := context.Background()
+	require.NoError(t, userRoles.AssignRole(ctx, "user-1", "editor"))
+
+	denied, err := svc.ExplainPermission(ctx, dto.ExplainPermissionRequest{
+		UserID: "user-1", Resource: "task", Action: "update",
+		ResourceCtx: map[string]interface{}{"resource.status": "archived"},
+	})
+	require.NoError(t, err)
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, "deny", denied.Effect)
+	assert.Equal(t, "policy:freeze-archived", denied.DeterminingRule)
+	require.NotNil(t, denied.DecidingPolicy)
+	assert.Equal(t, "freeze-archived", denied.DecidingPolicy.PolicyID)
+	assert.Equal(t, 300, denied.DecidingPolicy.Priority)
+	assert.Equal(t, []dto.RolePermissionMatchDTO{{RoleID: "editor", PermissionID: "task-update"}}, denied.RolePermissions)
+
+	// 策略条件不匹配时由角色权限决定
+	allowed, err := svc.ExplainPermission(ctx, dto.ExplainPermissionRequest{
+		UserID: "user-1", Resource: "task", Action: "update",
+		ResourceCtx: map[string]interface{}{"resource.status": "active"},
+	})
+	require.NoError(t, err)
+	assert.True(t, allowed.Allowed)
+	assert.Equal(t, "role:editor:permission:task-update", allowed.DeterminingRule)
+	assert.Nil(t, allowed.DecidingPolicy)
+	require.Len(t, allowed.Policies, 1)
+	assert.False(t, allowed.Policies[0].Matched)
+}
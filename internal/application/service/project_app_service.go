@@ -3,13 +3,18 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/taskflow/internal/domain/aggregate"
 	authService "github.com/taskflow/internal/domain/auth/service"
+	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/repository"
 	"github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
 )
 
 // ProjectAppService 项目应用服务
@@ -17,39 +22,90 @@ type ProjectAppService struct {
 	projectDomainService service.ProjectDomainService
 	transactionMgr       authService.TransactionManager
 	projectRepo          repository.ProjectRepository
+	idGenerator          valueobject.IDGenerator
+	eventBus             event.EventBus
+	taskRepo             repository.TaskRepository
+	textLimits           valueobject.TextLimits
+	featureFlags         FeatureFlagChecker
 }
 
-// NewProjectAppService 创建项目应用服务
+// projectSaveKind 项目保存方式，决定保存后发布哪些事件
+type projectSaveKind int
+
+const (
+	projectCreated projectSaveKind = iota + 1 // 新建项目，发布包括 ProjectCreated 在内的全部事件
+	projectUpdated                            // 更新已有项目，不发布 ProjectCreated
+)
+
+// NewProjectAppService 创建项目应用服务，未指定ID生成器时使用UUID
 func NewProjectAppService(
 	projectDomainService service.ProjectDomainService,
 	transactionMgr authService.TransactionManager,
 	projectRepo repository.ProjectRepository,
+	idGenerator valueobject.IDGenerator,
 ) *ProjectAppService {
+	if idGenerator == nil {
+		idGenerator = valueobject.NewUUIDGenerator()
+	}
 	return &ProjectAppService{
 		projectDomainService: projectDomainService,
 		transactionMgr:       transactionMgr,
 		projectRepo:          projectRepo,
+		idGenerator:          idGenerator,
 	}
 }
 
+// WithEventBus 设置事件总线，用于在事务提交后发布项目事件
+func (s *ProjectAppService) WithEventBus(bus event.EventBus) *ProjectAppService {
+	s.eventBus = bus
+	return s
+}
+
+// WithTextLimits 设置更新项目时名称和描述的长度上限，非正数时使用默认值
+func (s *ProjectAppService) WithTextLimits(limits valueobject.TextLimits) *ProjectAppService {
+	s.textLimits = limits
+	return s
+}
+
+// WithTaskRepository 设置任务仓储，用于项目暂停/恢复时级联更新任务
+func (s *ProjectAppService) WithTaskRepository(repo repository.TaskRepository) *ProjectAppService {
+	s.taskRepo = repo
+	return s
+}
+
+// WithFeatureFlags 设置功能开关，级联暂停任务关闭时项目状态变更不再级联到任务
+func (s *ProjectAppService) WithFeatureFlags(flags FeatureFlagChecker) *ProjectAppService {
+	s.featureFlags = flags
+	return s
+}
+
 // CreateProject 创建项目（需要事务）
 func (s *ProjectAppService) CreateProject(ctx context.Context, req *CreateProjectRequest) (*ProjectResponse, error) {
+	var events []event.DomainEvent
 	result, err := s.transactionMgr.WithTransactionResult(ctx, func(ctx context.Context) (interface{}, error) {
-		// 1. 创建项目聚合
+		// 1. 确定项目ID：未指定时由服务端生成，指定时不能与已有项目冲突
+		projectID := valueobject.ProjectID(req.ID)
+		if projectID == "" {
+			projectID = s.idGenerator.GenerateProjectID()
+		} else if existing, err := s.projectRepo.FindByID(ctx, projectID); err == nil && existing != nil {
+			return nil, event.NewDomainError(event.ErrProjectExists, fmt.Sprintf("project id already taken: %s", projectID))
+		}
+
+		// 2. 创建项目聚合
 		project := aggregate.NewProject(
-			valueobject.ProjectID(req.ID),
+			projectID,
 			req.Name,
 			req.Description,
 			valueobject.ProjectType(req.ProjectType),
 			valueobject.UserID(req.OwnerID),
 		)
 
-		// 2. 保存项目
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		// 3. 保存项目
+		if err := s.saveProject(ctx, project, projectCreated, &events); err != nil {
 			return nil, fmt.Errorf("保存项目失败: %w", err)
 		}
 
-		// 3. 返回结果
+		// 4. 返回结果
 		return &ProjectResponse{
 			ID:          string(project.ID),
 			Name:        project.Name,
@@ -67,6 +123,7 @@ func (s *ProjectAppService) CreateProject(ctx context.Context, req *CreateProjec
 	if err != nil {
 		return nil, err
 	}
+	s.publishEvents(events)
 
 	if projectResponse, ok := result.(*ProjectResponse); ok {
 		return projectResponse, nil
@@ -75,67 +132,95 @@ func (s *ProjectAppService) CreateProject(ctx context.Context, req *CreateProjec
 	return nil, fmt.Errorf("unexpected result type")
 }
 
-// GetProject 获取项目（不需要事务）
-func (s *ProjectAppService) GetProject(ctx context.Context, id string) (*ProjectResponse, error) {
-	project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(id))
+// GetProject 获取项目（不需要事务），仅项目所有者、管理者、成员和管理员可以查看
+func (s *ProjectAppService) GetProject(ctx context.Context, id, viewerID string, isAdmin bool) (*ProjectResponse, error) {
+	project, err := s.findAccessibleProject(ctx, id, viewerID, isAdmin)
 	if err != nil {
-		return nil, fmt.Errorf("获取项目失败: %w", err)
+		return nil, err
 	}
 
-	var managerID *string
-	if project.ManagerID != nil {
-		managerIDStr := string(*project.ManagerID)
-		managerID = &managerIDStr
-	}
+	response := s.buildProjectResponse(*project)
+	response.Localize(shared.LocationFromContext(ctx))
 
-	return &ProjectResponse{
-		ID:          string(project.ID),
-		Name:        project.Name,
-		Description: project.Description,
-		ProjectType: string(project.ProjectType),
-		Status:      string(project.Status),
-		OwnerID:     string(project.OwnerID),
-		ManagerID:   managerID,
-		StartDate:   project.StartDate,
-		EndDate:     project.EndDate,
-		CreatedAt:   project.CreatedAt,
-		UpdatedAt:   project.UpdatedAt,
-	}, nil
+	return response, nil
 }
 
-// UpdateProject 更新项目（需要事务）
+// UpdateProject 更新项目（需要事务），仅项目所有者、管理者和管理员可以修改
 func (s *ProjectAppService) UpdateProject(ctx context.Context, req *UpdateProjectRequest) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找项目
 		project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(req.ID))
 		if err != nil {
 			return fmt.Errorf("项目不存在: %w", err)
 		}
+		if !req.RequesterIsAdmin && !project.CanUserManage(valueobject.UserID(req.UpdatedBy)) {
+			return event.NewDomainError(event.ErrPermissionDenied, "only the project owner or manager can update the project")
+		}
+
+		// 2. 子项目名称在同一父项目下必须唯一
+		if project.ParentID != nil {
+			if err := s.projectDomainService.ValidateSiblingNameUnique(ctx, *project.ParentID, req.Name, project.ID); err != nil {
+				return fmt.Errorf("项目名称校验失败: %w", err)
+			}
+		}
 
-		// 2. 更新项目信息
-		if err := project.UpdateBasicInfo(req.Name, req.Description); err != nil {
+		// 3. 更新项目信息
+		project.SetTextLimits(s.textLimits)
+		if err := project.UpdateBasicInfo(req.Name, req.Description, valueobject.UserID(req.UpdatedBy)); err != nil {
 			return fmt.Errorf("更新项目信息失败: %w", err)
 		}
 
-		// 3. 保存更新
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		// 4. 更新起止日期，未提供的日期保持不变
+		if req.StartDate != nil || req.EndDate != nil {
+			startDate := project.StartDate
+			if req.StartDate != nil {
+				startDate = *req.StartDate
+			}
+			endDate := project.EndDate
+			if req.EndDate != nil {
+				endDate = req.EndDate
+			}
+			if err := project.UpdateSchedule(startDate, endDate); err != nil {
+				return fmt.Errorf("更新项目日期失败: %w", err)
+			}
+		}
+
+		// 5. 保存更新
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
 			return fmt.Errorf("保存项目失败: %w", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
 }
 
 // AssignManager 分配项目管理者（需要事务）
 func (s *ProjectAppService) AssignManager(ctx context.Context, projectID, managerID, assignedBy string) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
-		// 1. 查找项目
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 领域服务验证：管理者会被自动加入成员，需检查用户存在且有效
+		if err := s.projectDomainService.ValidateManagerAssignment(
+			ctx,
+			valueobject.ProjectID(projectID),
+			valueobject.UserID(managerID),
+		); err != nil {
+			return fmt.Errorf("管理者分配验证失败: %w", err)
+		}
+
+		// 2. 查找项目
 		project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(projectID))
 		if err != nil {
 			return fmt.Errorf("项目不存在: %w", err)
 		}
 
-		// 2. 分配管理者
+		// 3. 分配管理者
 		if err := project.AssignManager(
 			valueobject.UserID(managerID),
 			valueobject.UserID(assignedBy),
@@ -143,18 +228,25 @@ func (s *ProjectAppService) AssignManager(ctx context.Context, projectID, manage
 			return fmt.Errorf("分配管理者失败: %w", err)
 		}
 
-		// 3. 保存更新
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		// 4. 保存更新
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
 			return fmt.Errorf("保存项目失败: %w", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
 }
 
 // AddMember 添加项目成员（需要事务）
 func (s *ProjectAppService) AddMember(ctx context.Context, projectID, userID, addedBy string, role string) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 领域服务验证
 		if err := s.projectDomainService.ValidateMemberAddition(
 			ctx,
@@ -181,17 +273,30 @@ func (s *ProjectAppService) AddMember(ctx context.Context, projectID, userID, ad
 		}
 
 		// 4. 保存更新
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
 			return fmt.Errorf("保存项目失败: %w", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
 }
 
 // RemoveMember 移除项目成员（需要事务）
-func (s *ProjectAppService) RemoveMember(ctx context.Context, projectID, userID, removedBy string) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+// 成员仍负责项目中未结束的任务时拒绝移除；reassignTo 不为空时先把这些任务转交给该成员再移除
+// 成员担任共同负责人的任务由 reassignTo 接替，未指定时直接移除该共同负责人
+func (s *ProjectAppService) RemoveMember(ctx context.Context, projectID, userID, removedBy, reassignTo string) error {
+	if reassignTo != "" && s.taskRepo == nil {
+		return fmt.Errorf("未配置任务仓储，无法转交任务")
+	}
+
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找项目
 		project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(projectID))
 		if err != nil {
@@ -206,18 +311,163 @@ func (s *ProjectAppService) RemoveMember(ctx context.Context, projectID, userID,
 			return fmt.Errorf("移除成员失败: %w", err)
 		}
 
-		// 3. 保存更新
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		// 3. 处理成员负责的未结束任务，与成员移除在同一事务中提交
+		if s.taskRepo != nil {
+			if err := s.reassignActiveTasks(ctx, project, valueobject.UserID(userID), valueobject.UserID(reassignTo), valueobject.UserID(removedBy), &events); err != nil {
+				return err
+			}
+		}
+
+		// 4. 保存更新
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
 			return fmt.Errorf("保存项目失败: %w", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
+}
+
+// reassignActiveTasks 成员负责项目中未结束的任务时，未指定接收人则拒绝移除，否则把任务转交给接收人
+// 成员只是共同负责人的任务有接收人时由接收人接替，否则直接移除该共同负责人
+func (s *ProjectAppService) reassignActiveTasks(
+	ctx context.Context,
+	project *aggregate.Project,
+	memberID, reassignTo, operatorID valueobject.UserID,
+	pending *[]event.DomainEvent,
+) error {
+	tasks, err := s.taskRepo.FindByResponsible(ctx, memberID)
+	if err != nil {
+		return fmt.Errorf("查询成员负责的任务失败: %w", err)
+	}
+
+	active := make([]aggregate.TaskAggregate, 0, len(tasks))
+	leading := 0
+	for _, task := range tasks {
+		if task.ProjectID != project.ID ||
+			task.Status == valueobject.TaskStatusCompleted || task.Status == valueobject.TaskStatusCancelled {
+			continue
+		}
+		active = append(active, task)
+		if task.ResponsibleID == memberID {
+			leading++
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	if reassignTo == "" && leading > 0 {
+		return event.NewDomainError(event.ErrBusinessRule,
+			fmt.Sprintf("member is responsible for %d active task(s) in this project, reassign them before removing the member", leading))
+	}
+	// 接收人必须是移除后仍在项目中的成员
+	if reassignTo != "" && (reassignTo == memberID || project.GetMemberRole(reassignTo) == nil) {
+		return event.NewDomainError(event.ErrInvalidInput, fmt.Sprintf("reassign target is not a project member: %s", reassignTo))
+	}
+
+	for i := range active {
+		task := &active[i]
+		leadID := task.ResponsibleID
+		coResponsibles := make([]valueobject.UserID, 0, len(task.CoResponsibleIDs))
+		for _, id := range task.CoResponsibleIDs {
+			switch {
+			case id != memberID:
+				coResponsibles = append(coResponsibles, id)
+			case reassignTo != "":
+				coResponsibles = append(coResponsibles, reassignTo)
+			}
+		}
+		if leadID == memberID {
+			leadID = reassignTo
+		}
+		if err := task.AssignResponsibles(leadID, coResponsibles, operatorID); err != nil {
+			return fmt.Errorf("转交任务 %s 失败: %w", task.ID, err)
+		}
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return fmt.Errorf("保存任务 %s 失败: %w", task.ID, err)
+		}
+		*pending = append(*pending, task.GetEvents()...)
+	}
+	return nil
+}
+
+// SwapParticipants 在项目的全部任务中以 toUser 替换参与者 fromUser，保留原参与角色（需要事务）
+// 只有项目所有者、管理者或全局管理员可以操作，toUser 必须是项目成员；已结束的任务和 toUser 已是参与者的任务跳过
+func (s *ProjectAppService) SwapParticipants(ctx context.Context, projectID, operatorID string, req SwapParticipantsRequest) (*SwapParticipantsResponse, error) {
+	if s.taskRepo == nil {
+		return nil, fmt.Errorf("未配置任务仓储，无法替换参与者")
+	}
+
+	fromID := valueobject.UserID(req.FromUser)
+	toID := valueobject.UserID(req.ToUser)
+	if fromID == toID {
+		return nil, event.NewDomainError(event.ErrInvalidInput, "from_user and to_user must be different")
+	}
+
+	response := &SwapParticipantsResponse{SwappedTaskIDs: []string{}, SkippedTaskIDs: []string{}}
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 查找项目并校验权限
+		project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(projectID))
+		if err != nil {
+			return fmt.Errorf("项目不存在: %w", err)
+		}
+		if !req.RequesterIsAdmin {
+			if role := project.GetMemberRole(valueobject.UserID(operatorID)); role == nil || *role != valueobject.ProjectRoleManager {
+				return event.NewDomainError(event.ErrPermissionDenied, "only the project owner or manager can swap participants")
+			}
+		}
+		if project.GetMemberRole(toID) == nil {
+			return event.NewDomainError(event.ErrInvalidInput, fmt.Sprintf("to_user is not a project member: %s", toID))
+		}
+
+		// 2. 查找 fromUser 参与的项目任务
+		tasks, err := s.taskRepo.FindByParticipant(ctx, fromID)
+		if err != nil {
+			return fmt.Errorf("查询参与的任务失败: %w", err)
+		}
+
+		// 3. 逐个替换并保存
+		for i := range tasks {
+			task := &tasks[i]
+			if task.ProjectID != project.ID {
+				continue
+			}
+			// 已结束的任务保留原参与者，不改写历史
+			if task.Status == valueobject.TaskStatusCompleted || task.Status == valueobject.TaskStatusCancelled {
+				response.SkippedTaskIDs = append(response.SkippedTaskIDs, string(task.ID))
+				continue
+			}
+			if !task.SwapParticipant(fromID, toID, valueobject.UserID(operatorID)) {
+				response.SkippedTaskIDs = append(response.SkippedTaskIDs, string(task.ID))
+				continue
+			}
+			if err := s.taskRepo.Update(ctx, *task); err != nil {
+				return fmt.Errorf("保存任务 %s 失败: %w", task.ID, err)
+			}
+			response.SwappedTaskIDs = append(response.SwappedTaskIDs, string(task.ID))
+			events = append(events, task.GetEvents()...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishEvents(events)
+	return response, nil
 }
 
 // UpdateMemberRole 更新成员角色（需要事务）
 func (s *ProjectAppService) UpdateMemberRole(ctx context.Context, projectID, userID, updatedBy string, newRole string) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找项目
 		project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(projectID))
 		if err != nil {
@@ -234,17 +484,33 @@ func (s *ProjectAppService) UpdateMemberRole(ctx context.Context, projectID, use
 		}
 
 		// 3. 保存更新
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
 			return fmt.Errorf("保存项目失败: %w", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
 }
 
 // ChangeStatus 更改项目状态（需要事务）
-func (s *ProjectAppService) ChangeStatus(ctx context.Context, projectID, userID string, newStatus string, reason string) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+// cascadeTasks 为 true 时，暂停项目会同时暂停进行中的任务，恢复项目只恢复随项目暂停的任务；返回级联更新的任务数
+func (s *ProjectAppService) ChangeStatus(ctx context.Context, projectID, userID string, newStatus string, reason string, cascadeTasks bool) (int, error) {
+	if cascadeTasks && !featureEnabled(s.featureFlags, valueobject.FeatureCascadePause, valueobject.FeatureFlagContext{ProjectID: valueobject.ProjectID(projectID)}) {
+		cascadeTasks = false
+	}
+	if cascadeTasks && s.taskRepo == nil {
+		return 0, fmt.Errorf("未配置任务仓储，无法级联更新任务")
+	}
+
+	var events []event.DomainEvent
+	cascaded := 0
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 验证状态更改权限
 		canChange, err := s.projectDomainService.CanChangeProjectStatus(
 			ctx,
@@ -264,11 +530,17 @@ func (s *ProjectAppService) ChangeStatus(ctx context.Context, projectID, userID
 		if err != nil {
 			return fmt.Errorf("项目不存在: %w", err)
 		}
+		oldStatus := project.Status
 
 		// 3. 根据状态执行相应操作
 		switch valueobject.ProjectStatus(newStatus) {
 		case valueobject.ProjectStatusActive:
-			if err := project.Activate(valueobject.UserID(userID)); err != nil {
+			// 从暂停恢复时保留原开始日期
+			if oldStatus == valueobject.ProjectStatusPaused {
+				if err := project.Resume(valueobject.UserID(userID)); err != nil {
+					return fmt.Errorf("恢复项目失败: %w", err)
+				}
+			} else if err := project.Activate(valueobject.UserID(userID)); err != nil {
 				return fmt.Errorf("激活项目失败: %w", err)
 			}
 		case valueobject.ProjectStatusPaused:
@@ -292,16 +564,77 @@ func (s *ProjectAppService) ChangeStatus(ctx context.Context, projectID, userID
 		}
 
 		// 4. 保存更新
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
 			return fmt.Errorf("保存项目失败: %w", err)
 		}
 
+		// 5. 级联暂停/恢复任务，与项目状态在同一事务中提交
+		if cascadeTasks {
+			n, err := s.cascadeTaskStatus(ctx, project.ID, oldStatus, project.Status, valueobject.UserID(userID), reason, &events)
+			if err != nil {
+				return err
+			}
+			cascaded = n
+		}
+
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.publishEvents(events)
+	return cascaded, nil
+}
+
+// cascadeTaskStatus 项目暂停时暂停进行中的任务，项目从暂停恢复时只恢复随项目暂停的任务
+func (s *ProjectAppService) cascadeTaskStatus(
+	ctx context.Context,
+	projectID valueobject.ProjectID,
+	oldStatus, newStatus valueobject.ProjectStatus,
+	operatorID valueobject.UserID,
+	reason string,
+	pending *[]event.DomainEvent,
+) (int, error) {
+	pausing := newStatus == valueobject.ProjectStatusPaused
+	resuming := oldStatus == valueobject.ProjectStatusPaused && newStatus == valueobject.ProjectStatusActive
+	if !pausing && !resuming {
+		return 0, nil
+	}
+
+	tasks, err := s.taskRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("查询项目任务失败: %w", err)
+	}
+
+	count := 0
+	for i := range tasks {
+		task := &tasks[i]
+		switch {
+		case pausing && task.Status == valueobject.TaskStatusInProgress:
+			if err := task.PauseWithProject(operatorID, reason); err != nil {
+				return 0, fmt.Errorf("暂停任务 %s 失败: %w", task.ID, err)
+			}
+		case resuming && task.Status == valueobject.TaskStatusPaused && task.PausedByProject:
+			if err := task.Resume(operatorID); err != nil {
+				return 0, fmt.Errorf("恢复任务 %s 失败: %w", task.ID, err)
+			}
+		default:
+			continue
+		}
+
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return 0, fmt.Errorf("保存任务 %s 失败: %w", task.ID, err)
+		}
+		*pending = append(*pending, task.GetEvents()...)
+		count++
+	}
+	return count, nil
 }
 
 // CreateSubProject 创建子项目（需要事务）
 func (s *ProjectAppService) CreateSubProject(ctx context.Context, parentID, name, description, createdBy string) (*ProjectResponse, error) {
+	var events []event.DomainEvent
 	result, err := s.transactionMgr.WithTransactionResult(ctx, func(ctx context.Context) (interface{}, error) {
 		// 1. 验证是否可以创建子项目
 		canCreate, err := s.projectDomainService.CanCreateSubProject(
@@ -315,6 +648,9 @@ func (s *ProjectAppService) CreateSubProject(ctx context.Context, parentID, name
 		if !canCreate {
 			return nil, fmt.Errorf("用户无权限创建子项目")
 		}
+		if err := s.projectDomainService.ValidateSiblingNameUnique(ctx, valueobject.ProjectID(parentID), name, ""); err != nil {
+			return nil, fmt.Errorf("子项目名称校验失败: %w", err)
+		}
 
 		// 2. 查找父项目
 		parentProject, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(parentID))
@@ -323,9 +659,9 @@ func (s *ProjectAppService) CreateSubProject(ctx context.Context, parentID, name
 		}
 
 		// 3. 创建子项目
-		subProjectID := generateProjectID()
+		subProjectID := s.idGenerator.GenerateProjectID()
 		subProject, err := parentProject.CreateSubProject(
-			valueobject.ProjectID(subProjectID),
+			subProjectID,
 			name,
 			description,
 			valueobject.UserID(createdBy),
@@ -335,12 +671,12 @@ func (s *ProjectAppService) CreateSubProject(ctx context.Context, parentID, name
 		}
 
 		// 4. 保存父项目和子项目
-		if err := s.projectRepo.Save(ctx, *parentProject); err != nil {
+		if err := s.saveProject(ctx, parentProject, projectUpdated, &events); err != nil {
 			return nil, fmt.Errorf("保存父项目失败: %w", err)
 		}
 
 		if concreteSubProject, ok := subProject.(*aggregate.Project); ok {
-			if err := s.projectRepo.Save(ctx, *concreteSubProject); err != nil {
+			if err := s.saveProject(ctx, concreteSubProject, projectCreated, &events); err != nil {
 				return nil, fmt.Errorf("保存子项目失败: %w", err)
 			}
 
@@ -354,6 +690,7 @@ func (s *ProjectAppService) CreateSubProject(ctx context.Context, parentID, name
 	if err != nil {
 		return nil, err
 	}
+	s.publishEvents(events)
 
 	if projectResponse, ok := result.(*ProjectResponse); ok {
 		return projectResponse, nil
@@ -362,6 +699,88 @@ func (s *ProjectAppService) CreateSubProject(ctx context.Context, parentID, name
 	return nil, fmt.Errorf("unexpected result type")
 }
 
+// ReparentProject 将项目移动到新的父项目下（需要事务）
+// 同时更新项目的父项目以及新旧父项目的子项目列表；非管理员需要能管理项目，并能在新父项目下创建子项目
+func (s *ProjectAppService) ReparentProject(ctx context.Context, projectID, newParentID, changedBy string, isAdmin bool) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 验证不会形成自引用或循环
+		if err := s.projectDomainService.ValidateProjectReparent(
+			ctx,
+			valueobject.ProjectID(projectID),
+			valueobject.ProjectID(newParentID),
+		); err != nil {
+			return fmt.Errorf("项目层级验证失败: %w", err)
+		}
+
+		// 2. 查找项目和新父项目
+		project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(projectID))
+		if err != nil {
+			return fmt.Errorf("项目不存在: %w", err)
+		}
+		newParent, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(newParentID))
+		if err != nil {
+			return fmt.Errorf("父项目不存在: %w", err)
+		}
+		oldParentID := project.ParentID
+
+		// 3. 校验权限：需要能管理项目，并能在新父项目下创建子项目
+		if !isAdmin {
+			canManage, err := s.projectDomainService.CanUserManageProject(ctx, project.ID, valueobject.UserID(changedBy))
+			if err != nil {
+				return fmt.Errorf("项目权限校验失败: %w", err)
+			}
+			if !canManage {
+				return event.NewDomainError(event.ErrPermissionDenied, "only the project owner or manager can move the project")
+			}
+			canCreate, err := s.projectDomainService.CanCreateSubProject(ctx, newParent.ID, valueobject.UserID(changedBy))
+			if err != nil {
+				return fmt.Errorf("父项目校验失败: %w", err)
+			}
+			if !canCreate {
+				return event.NewDomainError(event.ErrPermissionDenied, "only the owner or manager of the new parent can move projects under it")
+			}
+		}
+		if err := s.projectDomainService.ValidateSiblingNameUnique(ctx, newParent.ID, project.Name, project.ID); err != nil {
+			return fmt.Errorf("项目名称校验失败: %w", err)
+		}
+
+		// 4. 变更父项目
+		if err := project.ChangeParent(newParent.ID, valueobject.UserID(changedBy)); err != nil {
+			return fmt.Errorf("变更父项目失败: %w", err)
+		}
+
+		// 5. 更新新旧父项目的子项目列表
+		if oldParentID != nil && *oldParentID != newParent.ID {
+			oldParent, err := s.projectRepo.FindByID(ctx, *oldParentID)
+			if err != nil {
+				return fmt.Errorf("原父项目不存在: %w", err)
+			}
+			oldParent.DetachChild(project.ID)
+			if err := s.saveProject(ctx, oldParent, projectUpdated, &events); err != nil {
+				return fmt.Errorf("保存原父项目失败: %w", err)
+			}
+		}
+		newParent.AttachChild(project.ID)
+		if err := s.saveProject(ctx, newParent, projectUpdated, &events); err != nil {
+			return fmt.Errorf("保存父项目失败: %w", err)
+		}
+
+		// 6. 保存项目
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
+			return fmt.Errorf("保存项目失败: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
+}
+
 // ListProjects 获取项目列表（不需要事务）
 func (s *ProjectAppService) ListProjects(ctx context.Context, req *ProjectListRequest) (*ProjectListResponse, error) {
 	// 构建查询条件
@@ -399,6 +818,12 @@ func (s *ProjectAppService) ListProjects(ctx context.Context, req *ProjectListRe
 		criteria.Name = &req.Search
 	}
 
+	// 非管理员只能看到自己拥有、管理或参与的项目
+	if !req.RequesterIsAdmin {
+		requesterID := valueobject.UserID(req.RequesterID)
+		criteria.AccessibleBy = &requesterID
+	}
+
 	// 设置排序
 	if req.SortBy != "" {
 		criteria.OrderBy = req.SortBy
@@ -417,6 +842,7 @@ func (s *ProjectAppService) ListProjects(ctx context.Context, req *ProjectListRe
 	projectResponses := make([]ProjectResponse, len(projects))
 	for i, project := range projects {
 		projectResponses[i] = *s.buildProjectResponse(project)
+		projectResponses[i].Localize(shared.LocationFromContext(ctx))
 	}
 
 	totalPages := (total + req.PageSize - 1) / req.PageSize
@@ -430,8 +856,133 @@ func (s *ProjectAppService) ListProjects(ctx context.Context, req *ProjectListRe
 	}, nil
 }
 
-// GetProjectHierarchy 获取项目层级结构（不需要事务）
-func (s *ProjectAppService) GetProjectHierarchy(ctx context.Context, projectID string) (*ProjectHierarchyResponse, error) {
+// ListProjectsByDateRange 获取时间窗口内处于进行期的项目（不需要事务）
+func (s *ProjectAppService) ListProjectsByDateRange(ctx context.Context, req *ProjectDateRangeRequest) ([]ProjectResponse, error) {
+	if req.To.Before(req.From) {
+		return nil, fmt.Errorf("结束时间不能早于开始时间")
+	}
+
+	projects, err := s.projectRepo.FindByDateRange(ctx, req.From, req.To)
+	if err != nil {
+		return nil, fmt.Errorf("查询项目失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	responses := make([]ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		// 非管理员只能看到自己可访问的项目
+		if !req.RequesterIsAdmin && !project.CanUserAccess(valueobject.UserID(req.RequesterID)) {
+			continue
+		}
+		response := s.buildProjectResponse(project)
+		response.Localize(loc)
+		responses = append(responses, *response)
+	}
+
+	return responses, nil
+}
+
+// ListAtRiskProjects 分页获取有逾期任务的项目及逾期任务数（不需要事务）
+func (s *ProjectAppService) ListAtRiskProjects(ctx context.Context, req *AtRiskProjectListRequest) (*AtRiskProjectListResponse, error) {
+	summaries, total, err := s.projectRepo.FindProjectsWithOverdueTasks(ctx, time.Now(), req.PageSize, (req.Page-1)*req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("查询有逾期任务的项目失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	projects := make([]AtRiskProjectResponse, len(summaries))
+	for i, summary := range summaries {
+		response := s.buildProjectResponse(summary.Project)
+		response.Localize(loc)
+		projects[i] = AtRiskProjectResponse{ProjectResponse: *response, OverdueTasks: summary.OverdueTasks}
+	}
+
+	return &AtRiskProjectListResponse{
+		Projects:   projects,
+		Total:      total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalPages: (total + req.PageSize - 1) / req.PageSize,
+	}, nil
+}
+
+// SuggestAssignees 按当前负载推荐项目负责人（不需要事务）
+// 高优先级和紧急任务优先推荐没有逾期任务的成员，其他任务优先推荐未结束任务最少的成员
+func (s *ProjectAppService) SuggestAssignees(ctx context.Context, req *AssignmentSuggestionRequest) (*AssignmentSuggestionResponse, error) {
+	if s.taskRepo == nil {
+		return nil, fmt.Errorf("未配置任务仓储，无法统计成员负载")
+	}
+
+	project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(req.ProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("项目不存在: %w", err)
+	}
+	if !req.RequesterIsAdmin && !project.CanUserAccess(valueobject.UserID(req.RequesterID)) {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, "no access to this project")
+	}
+
+	// 所有者、管理者也可能出现在成员列表中，按首次出现去重
+	seen := make(map[valueobject.UserID]bool)
+	var candidates []valueobject.UserID
+	for _, id := range project.GetMemberIDs() {
+		userID := valueobject.UserID(id)
+		if !seen[userID] {
+			seen[userID] = true
+			candidates = append(candidates, userID)
+		}
+	}
+
+	workloads, err := s.taskRepo.CountWorkloadByResponsible(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("统计成员负载失败: %w", err)
+	}
+
+	suggestions := make([]AssigneeSuggestion, 0, len(candidates))
+	for _, userID := range candidates {
+		workload := workloads[userID]
+		suggestion := AssigneeSuggestion{
+			UserID:       string(userID),
+			OpenTasks:    workload.OpenTasks,
+			OverdueTasks: workload.OverdueTasks,
+		}
+		if role := project.GetMemberRole(userID); role != nil {
+			suggestion.Role = string(*role)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	urgent := valueobject.TaskPriority(req.Priority).IsUrgent()
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		primaryA, primaryB, secondaryA, secondaryB := a.OpenTasks, b.OpenTasks, a.OverdueTasks, b.OverdueTasks
+		if urgent {
+			primaryA, primaryB, secondaryA, secondaryB = a.OverdueTasks, b.OverdueTasks, a.OpenTasks, b.OpenTasks
+		}
+		if primaryA != primaryB {
+			return primaryA < primaryB
+		}
+		if secondaryA != secondaryB {
+			return secondaryA < secondaryB
+		}
+		return a.UserID < b.UserID
+	})
+	if req.Limit > 0 && len(suggestions) > req.Limit {
+		suggestions = suggestions[:req.Limit]
+	}
+
+	return &AssignmentSuggestionResponse{
+		ProjectID:   string(project.ID),
+		Priority:    req.Priority,
+		Suggestions: suggestions,
+	}, nil
+}
+
+// GetProjectHierarchy 获取项目层级结构（不需要事务），需要能访问该项目
+func (s *ProjectAppService) GetProjectHierarchy(ctx context.Context, projectID, viewerID string, isAdmin bool) (*ProjectHierarchyResponse, error) {
+	if _, err := s.findAccessibleProject(ctx, projectID, viewerID, isAdmin); err != nil {
+		return nil, err
+	}
+
 	hierarchy, err := s.projectDomainService.GetProjectHierarchy(ctx, valueobject.ProjectID(projectID))
 	if err != nil {
 		return nil, fmt.Errorf("获取项目层级失败: %w", err)
@@ -440,6 +991,7 @@ func (s *ProjectAppService) GetProjectHierarchy(ctx context.Context, projectID s
 	response := &ProjectHierarchyResponse{
 		Project:       s.buildProjectResponse(*hierarchy.Project),
 		Depth:         hierarchy.Depth,
+		Children:      make([]ProjectResponse, len(hierarchy.Children)),
 		TotalProjects: hierarchy.TotalProjects,
 	}
 
@@ -447,11 +999,38 @@ func (s *ProjectAppService) GetProjectHierarchy(ctx context.Context, projectID s
 		response.Parent = s.buildProjectResponse(*hierarchy.Parent)
 	}
 
-	if len(hierarchy.Children) > 0 {
-		response.Children = make([]ProjectResponse, len(hierarchy.Children))
-		for i, child := range hierarchy.Children {
-			response.Children[i] = *s.buildProjectResponse(child)
-		}
+	for i, child := range hierarchy.Children {
+		response.Children[i] = *s.buildProjectResponse(child)
+	}
+
+	return response, nil
+}
+
+// GetProjectDescendants 获取项目的全部后代项目（不需要事务），需要能访问该项目
+func (s *ProjectAppService) GetProjectDescendants(ctx context.Context, projectID string, maxDepth int, viewerID string, isAdmin bool) (*ProjectDescendantsResponse, error) {
+	if _, err := s.findAccessibleProject(ctx, projectID, viewerID, isAdmin); err != nil {
+		return nil, err
+	}
+	if maxDepth <= 0 || maxDepth > service.MaxProjectDescendantDepth {
+		maxDepth = service.MaxProjectDescendantDepth
+	}
+
+	descendants, err := s.projectDomainService.GetProjectDescendants(ctx, valueobject.ProjectID(projectID), maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("获取后代项目失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	response := &ProjectDescendantsResponse{
+		ProjectID:   projectID,
+		Descendants: make([]ProjectDescendantResponse, len(descendants)),
+		Total:       len(descendants),
+		MaxDepth:    maxDepth,
+	}
+	for i, descendant := range descendants {
+		project := s.buildProjectResponse(descendant.Project)
+		project.Localize(loc)
+		response.Descendants[i] = ProjectDescendantResponse{ProjectResponse: *project, Depth: descendant.Depth}
 	}
 
 	return response, nil
@@ -459,7 +1038,8 @@ func (s *ProjectAppService) GetProjectHierarchy(ctx context.Context, projectID s
 
 // DeleteProject 删除项目（需要事务）
 func (s *ProjectAppService) DeleteProject(ctx context.Context, projectID, deletedBy string) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找项目
 		project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(projectID))
 		if err != nil {
@@ -472,17 +1052,73 @@ func (s *ProjectAppService) DeleteProject(ctx context.Context, projectID, delete
 		}
 
 		// 3. 保存更新
-		if err := s.projectRepo.Save(ctx, *project); err != nil {
+		if err := s.saveProject(ctx, project, projectUpdated, &events); err != nil {
 			return fmt.Errorf("保存项目失败: %w", err)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
 }
 
 // 辅助方法
 
-// buildProjectResponse 构建项目响应
+// findAccessibleProject 查找项目并校验查看权限，非管理员需要是项目所有者、管理者或成员
+func (s *ProjectAppService) findAccessibleProject(ctx context.Context, projectID, viewerID string, isAdmin bool) (*aggregate.Project, error) {
+	project, err := s.projectRepo.FindByID(ctx, valueobject.ProjectID(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("获取项目失败: %w", err)
+	}
+	if !isAdmin && !project.CanUserAccess(valueobject.UserID(viewerID)) {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, "no access to this project")
+	}
+	return project, nil
+}
+
+// saveProject 新建或更新项目，保存成功后将待发布的事件追加到 pending
+// 仅新建时保留 ProjectCreated 事件，更新已有项目不会重复发布创建事件
+func (s *ProjectAppService) saveProject(ctx context.Context, project *aggregate.Project, kind projectSaveKind, pending *[]event.DomainEvent) error {
+	if kind == projectCreated {
+		if err := s.projectRepo.Create(ctx, *project); err != nil {
+			return err
+		}
+		*pending = append(*pending, project.Events...)
+		return nil
+	}
+
+	if err := s.projectRepo.Update(ctx, *project); err != nil {
+		return err
+	}
+	for _, e := range project.Events {
+		if _, ok := e.(*event.ProjectCreatedEvent); ok {
+			continue
+		}
+		*pending = append(*pending, e)
+	}
+	return nil
+}
+
+// publishEvents 发布事件，发布失败只记录日志
+func (s *ProjectAppService) publishEvents(events []event.DomainEvent) {
+	if s.eventBus == nil {
+		return
+	}
+	for _, e := range events {
+		if err := s.eventBus.Publish(e); err != nil {
+			logger.Warn("Failed to publish project event",
+				zap.String("event_type", e.EventType()),
+				zap.String("project_id", e.AggregateID()),
+				zap.Error(err))
+		}
+	}
+}
+
+// buildProjectResponse 构建项目响应，成员和子项目为空（数据库或缓存恢复时为 nil）时均返回空数组，未指定管理者时省略
 func (s *ProjectAppService) buildProjectResponse(project aggregate.Project) *ProjectResponse {
 	// 转换成员列表
 	members := make([]ProjectMemberResponse, len(project.Members))
@@ -509,6 +1145,8 @@ func (s *ProjectAppService) buildProjectResponse(project aggregate.Project) *Pro
 		EndDate:     project.EndDate,
 		CreatedAt:   project.CreatedAt,
 		UpdatedAt:   project.UpdatedAt,
+		CreatedBy:   string(project.CreatedBy),
+		UpdatedBy:   string(project.UpdatedBy),
 	}
 
 	// 设置管理者ID
@@ -532,9 +1170,3 @@ func (s *ProjectAppService) buildProjectResponse(project aggregate.Project) *Pro
 
 	return response
 }
-
-// generateProjectID 生成项目ID
-func generateProjectID() string {
-	// 这里可以使用UUID或其他ID生成策略
-	return "proj_" + fmt.Sprintf("%d", time.Now().UnixNano())
-}
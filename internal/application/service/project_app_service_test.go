@@ -0,0 +1,884 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	domainService "github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// searchableProjectRepository 支持按访问权限过滤搜索的内存项目仓储
+type searchableProjectRepository struct {
+	repository.ProjectRepository
+	projects []aggregate.Project
+}
+
+func (r *searchableProjectRepository) SearchProjects(ctx context.Context, criteria aggregate.ProjectSearchCriteria) ([]aggregate.Project, int, error) {
+	result := make([]aggregate.Project, 0)
+	for _, p := range r.projects {
+		if criteria.AccessibleBy != nil && !p.CanUserAccess(*criteria.AccessibleBy) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result, len(result), nil
+}
+
+func newAccessFilterFixture(t *testing.T) *ProjectAppService {
+	owned := aggregate.NewProject("p-owned", "Owned", "", valueobject.ProjectTypeMaster, "user-1")
+	joined := aggregate.NewProject("p-joined", "Joined", "", valueobject.ProjectTypeMaster, "owner-2")
+	require.NoError(t, joined.AddMember("user-1", valueobject.ProjectRoleMember, "owner-2"))
+	other := aggregate.NewProject("p-other", "Other", "", valueobject.ProjectTypeMaster, "owner-3")
+
+	repo := &searchableProjectRepository{
+		projects: []aggregate.Project{*owned, *joined, *other},
+	}
+	return NewProjectAppService(nil, nil, repo, nil)
+}
+
+func projectIDs(resp *ProjectListResponse) []string {
+	ids := make([]string, len(resp.Projects))
+	for i, p := range resp.Projects {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestListProjects_PlainUserSeesOnlyAccessibleProjects(t *testing.T) {
+	svc := newAccessFilterFixture(t)
+
+	resp, err := svc.ListProjects(context.Background(), &ProjectListRequest{
+		Page:        1,
+		PageSize:    20,
+		RequesterID: "user-1",
+	})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"p-owned", "p-joined"}, projectIDs(resp))
+	assert.Equal(t, 2, resp.Total)
+}
+
+func TestListProjects_AdminSeesAllProjects(t *testing.T) {
+	svc := newAccessFilterFixture(t)
+
+	resp, err := svc.ListProjects(context.Background(), &ProjectListRequest{
+		Page:             1,
+		PageSize:         20,
+		RequesterID:      "admin-1",
+		RequesterIsAdmin: true,
+	})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"p-owned", "p-joined", "p-other"}, projectIDs(resp))
+}
+
+// memoryProjectRepository 内存项目仓储
+type memoryProjectRepository struct {
+	repository.ProjectRepository
+	projects map[valueobject.ProjectID]aggregate.Project
+}
+
+func (r *memoryProjectRepository) FindByID(ctx context.Context, id valueobject.ProjectID) (*aggregate.Project, error) {
+	project, ok := r.projects[id]
+	if !ok {
+		return nil, fmt.Errorf("project not found: %s", id)
+	}
+	return &project, nil
+}
+
+func (r *memoryProjectRepository) Create(ctx context.Context, project aggregate.Project) error {
+	if _, ok := r.projects[project.ID]; ok {
+		return fmt.Errorf("project %s: %w", project.ID, repository.ErrAlreadyExists)
+	}
+	r.projects[project.ID] = project
+	return nil
+}
+
+func TestCreateProject_RejectsExistingID(t *testing.T) {
+	existing := aggregate.NewProject("p-1", "Existing", "", valueobject.ProjectTypeMaster, "owner-1")
+	repo := &memoryProjectRepository{
+		projects: map[valueobject.ProjectID]aggregate.Project{existing.ID: *existing},
+	}
+	svc := NewProjectAppService(nil, passthroughTransactionManager{}, repo, nil)
+
+	_, err := svc.CreateProject(context.Background(), &CreateProjectRequest{
+		ID:          "p-1",
+		Name:        "Intruder",
+		ProjectType: string(valueobject.ProjectTypeMaster),
+		OwnerID:     "owner-2",
+	})
+
+	require.Error(t, err)
+	assert.True(t, event.IsErrorType(err, event.ErrProjectExists))
+	assert.Equal(t, "Existing", repo.projects["p-1"].Name, "existing project must not be overwritten")
+	assert.Equal(t, valueobject.UserID("owner-1"), repo.projects["p-1"].OwnerID)
+}
+
+func TestCreateProject_GeneratesIDWhenEmpty(t *testing.T) {
+	repo := &memoryProjectRepository{projects: map[valueobject.ProjectID]aggregate.Project{}}
+	svc := NewProjectAppService(nil, passthroughTransactionManager{}, repo, nil)
+
+	resp, err := svc.CreateProject(context.Background(), &CreateProjectRequest{
+		Name:        "New",
+		ProjectType: string(valueobject.ProjectTypeMaster),
+		OwnerID:     "owner-1",
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.ID)
+	assert.Contains(t, repo.projects, valueobject.ProjectID(resp.ID))
+}
+
+// newProjectServiceForTest 以种子数据创建内存项目仓储和任务仓储，装配接入记录事件总线的项目应用服务
+func newProjectServiceForTest(t *testing.T, projects []aggregate.Project, tasks []aggregate.TaskAggregate) (*ProjectAppService, *testutil.MemoryProjectRepository, *testutil.MemoryTaskRepository, *recordingEventBus) {
+	t.Helper()
+
+	projectRepo := testutil.NewMemoryProjectRepository(projects...)
+	taskRepo := testutil.NewMemoryTaskRepository(tasks...)
+	bus := &recordingEventBus{}
+	svc := NewProjectAppService(domainService.NewProjectDomainService(projectRepo, nil), passthroughTransactionManager{}, projectRepo, nil).
+		WithTaskRepository(taskRepo).
+		WithEventBus(bus)
+	return svc, projectRepo, taskRepo, bus
+}
+
+// newReparentFixture 创建层级：root-a → child → grandchild，以及独立的 root-b
+func newReparentFixture(t *testing.T) (*ProjectAppService, *testutil.MemoryProjectRepository, *recordingEventBus) {
+	t.Helper()
+
+	rootA := aggregate.NewProject("root-a", "Root A", "", valueobject.ProjectTypeMaster, "owner-1")
+	rootB := aggregate.NewProject("root-b", "Root B", "", valueobject.ProjectTypeMaster, "owner-1")
+	rootB.Status = valueobject.ProjectStatusActive
+	child := aggregate.NewProject("child", "Child", "", valueobject.ProjectTypeSub, "owner-1")
+	grandchild := aggregate.NewProject("grandchild", "Grandchild", "", valueobject.ProjectTypeSub, "owner-1")
+	child.ParentID = &rootA.ID
+	rootA.Children = []valueobject.ProjectID{child.ID}
+	grandchild.ParentID = &child.ID
+	child.Children = []valueobject.ProjectID{grandchild.ID}
+
+	svc, repo, _, bus := newProjectServiceForTest(t, []aggregate.Project{*rootA, *rootB, *child, *grandchild}, nil)
+	return svc, repo, bus
+}
+
+func TestReparentProject_MovesProjectBetweenParents(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, bus := newReparentFixture(t)
+
+	require.NoError(t, svc.ReparentProject(ctx, "child", "root-b", "owner-1", false))
+	assert.Contains(t, publishedTypes(bus), "project.parent_changed")
+
+	child, err := repo.FindByID(ctx, "child")
+	require.NoError(t, err)
+	require.NotNil(t, child.ParentID)
+	assert.Equal(t, valueobject.ProjectID("root-b"), *child.ParentID)
+	rootA, err := repo.FindByID(ctx, "root-a")
+	require.NoError(t, err)
+	assert.Empty(t, rootA.Children)
+	rootB, err := repo.FindByID(ctx, "root-b")
+	require.NoError(t, err)
+	assert.Equal(t, []valueobject.ProjectID{"child"}, rootB.Children)
+}
+
+func TestReparentProject_RequiresManageRights(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newReparentFixture(t)
+	rootC := aggregate.NewProject("root-c", "Root C", "", valueobject.ProjectTypeMaster, "outsider")
+	rootC.Status = valueobject.ProjectStatusActive
+	require.NoError(t, repo.Create(ctx, *rootC))
+
+	var domainErr *event.DomainError
+	// 不能管理项目的用户不能移动项目，即使能管理新父项目
+	err := svc.ReparentProject(ctx, "child", "root-c", "outsider", false)
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	// 项目所有者不能把项目移动到自己无权管理的父项目下
+	err = svc.ReparentProject(ctx, "child", "root-c", "owner-1", false)
+	require.Error(t, err)
+	child, _ := repo.FindByID(ctx, "child")
+	assert.Equal(t, valueobject.ProjectID("root-a"), *child.ParentID)
+
+	// 管理员不受限制
+	require.NoError(t, svc.ReparentProject(ctx, "child", "root-c", "admin-1", true))
+	child, _ = repo.FindByID(ctx, "child")
+	assert.Equal(t, valueobject.ProjectID("root-c"), *child.ParentID)
+}
+
+func TestReparentProject_RejectsSelfParent(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newReparentFixture(t)
+
+	err := svc.ReparentProject(ctx, "child", "child", "owner-1", false)
+
+	require.Error(t, err)
+	child, _ := repo.FindByID(ctx, "child")
+	assert.Equal(t, valueobject.ProjectID("root-a"), *child.ParentID)
+}
+
+func TestReparentProject_RejectsCycle(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newReparentFixture(t)
+
+	// root-a → child → grandchild，把 root-a 挂到 grandchild 下会形成环
+	err := svc.ReparentProject(ctx, "root-a", "grandchild", "owner-1", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cycle")
+
+	// 直接子项目同样不能成为父项目（A→B→A）
+	err = svc.ReparentProject(ctx, "child", "grandchild", "owner-1", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cycle")
+
+	rootA, _ := repo.FindByID(ctx, "root-a")
+	assert.Nil(t, rootA.ParentID)
+	grandchild, _ := repo.FindByID(ctx, "grandchild")
+	assert.Equal(t, []valueobject.ProjectID(nil), grandchild.Children)
+}
+
+// newSiblingNameFixture 创建活跃主项目 master，子项目 alpha 和已删除的子项目 beta
+func newSiblingNameFixture(t *testing.T) (*ProjectAppService, *testutil.MemoryProjectRepository) {
+	t.Helper()
+
+	master := aggregate.NewProject("master", "Master", "", valueobject.ProjectTypeMaster, "owner-1")
+	master.Status = valueobject.ProjectStatusActive
+	alpha := aggregate.NewProject("alpha", "Alpha", "", valueobject.ProjectTypeSub, "owner-1")
+	alpha.ParentID = &master.ID
+	beta := aggregate.NewProject("beta", "Beta", "", valueobject.ProjectTypeSub, "owner-1")
+	beta.ParentID = &master.ID
+	deletedAt := time.Now()
+	beta.DeletedAt = &deletedAt
+	master.Children = []valueobject.ProjectID{alpha.ID, beta.ID}
+
+	svc, repo, _, _ := newProjectServiceForTest(t, []aggregate.Project{*master, *alpha, *beta}, nil)
+	return svc, repo
+}
+
+// assertProjectNameConflict 断言错误链中包含项目名称冲突错误
+func assertProjectNameConflict(t *testing.T, err error) {
+	t.Helper()
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrProjectNameConflict, domainErr.Type)
+}
+
+func TestCreateSubProject_RejectsDuplicateSiblingName(t *testing.T) {
+	svc, _ := newSiblingNameFixture(t)
+
+	_, err := svc.CreateSubProject(context.Background(), "master", " alpha ", "", "owner-1")
+
+	assertProjectNameConflict(t, err)
+}
+
+func TestCreateSubProject_ReusesDeletedSiblingName(t *testing.T) {
+	svc, _ := newSiblingNameFixture(t)
+
+	resp, err := svc.CreateSubProject(context.Background(), "master", "Beta", "", "owner-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Beta", resp.Name)
+}
+
+func TestUpdateProject_RejectsDuplicateSiblingName(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newSiblingNameFixture(t)
+	gamma := aggregate.NewProject("gamma", "Gamma", "", valueobject.ProjectTypeSub, "owner-1")
+	master := valueobject.ProjectID("master")
+	gamma.ParentID = &master
+	require.NoError(t, repo.Create(ctx, *gamma))
+
+	err := svc.UpdateProject(ctx, &UpdateProjectRequest{ID: "gamma", UpdatedBy: "owner-1", Name: "Alpha"})
+	assertProjectNameConflict(t, err)
+
+	// 保留自身名称或使用已删除项目的名称都允许
+	require.NoError(t, svc.UpdateProject(ctx, &UpdateProjectRequest{ID: "alpha", UpdatedBy: "owner-1", Name: "Alpha", Description: "updated"}))
+	require.NoError(t, svc.UpdateProject(ctx, &UpdateProjectRequest{ID: "gamma", UpdatedBy: "owner-1", Name: "Beta"}))
+	updated, err := repo.FindByID(ctx, "gamma")
+	require.NoError(t, err)
+	assert.Equal(t, "Beta", updated.Name)
+}
+
+func TestUpdateProject_ValidatesDateRange(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newSiblingNameFixture(t)
+	start := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, 0, -1)
+	end := start.AddDate(0, 1, 0)
+
+	err := svc.UpdateProject(ctx, &UpdateProjectRequest{ID: "alpha", UpdatedBy: "owner-1", Name: "Alpha", StartDate: &start, EndDate: &before})
+	require.ErrorIs(t, err, aggregate.ErrProjectEndBeforeStart)
+
+	require.NoError(t, svc.UpdateProject(ctx, &UpdateProjectRequest{ID: "alpha", UpdatedBy: "owner-1", Name: "Alpha", StartDate: &start, EndDate: &end}))
+	updated, err := repo.FindByID(ctx, "alpha")
+	require.NoError(t, err)
+	assert.True(t, updated.StartDate.Equal(start))
+	require.NotNil(t, updated.EndDate)
+	assert.True(t, updated.EndDate.Equal(end))
+
+	// 只修改开始日期时与已保存的结束日期比较
+	late := end.AddDate(0, 0, 1)
+	err = svc.UpdateProject(ctx, &UpdateProjectRequest{ID: "alpha", UpdatedBy: "owner-1", Name: "Alpha", StartDate: &late})
+	require.ErrorIs(t, err, aggregate.ErrProjectEndBeforeStart)
+}
+
+// publishedTypes 按发布顺序返回事件类型
+func publishedTypes(bus *recordingEventBus) []string {
+	types := make([]string, len(bus.published))
+	for i, e := range bus.published {
+		types[i] = e.EventType()
+	}
+	return types
+}
+
+func TestProjectSave_FirstSaveCreatesAndLaterSaveUpdates(t *testing.T) {
+	svc, _, _, bus := newProjectServiceForTest(t, nil, nil)
+	ctx := context.Background()
+
+	resp, err := svc.CreateProject(ctx, &CreateProjectRequest{
+		Name:        "New",
+		ProjectType: string(valueobject.ProjectTypeMaster),
+		OwnerID:     "owner-1",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, publishedTypes(bus), "project.created")
+
+	bus.published = nil
+	require.NoError(t, svc.UpdateProject(ctx, &UpdateProjectRequest{ID: resp.ID, Name: "Renamed", UpdatedBy: "owner-1"}))
+	assert.NotContains(t, publishedTypes(bus), "project.created")
+	assert.Contains(t, publishedTypes(bus), "project.updated")
+}
+
+func TestProjectSave_UpdateDropsPendingCreatedEvent(t *testing.T) {
+	svc, _, _, _ := newProjectServiceForTest(t, nil, nil)
+	ctx := context.Background()
+	project := aggregate.NewProject("p-1", "New", "", valueobject.ProjectTypeMaster, "owner-1")
+
+	var created []event.DomainEvent
+	require.NoError(t, svc.saveProject(ctx, project, projectCreated, &created))
+	require.NotEmpty(t, created)
+	_, ok := created[0].(*event.ProjectCreatedEvent)
+	assert.True(t, ok)
+
+	// 同一聚合再次保存时仍携带创建事件，按更新处理不应再次发布
+	require.NoError(t, project.UpdateBasicInfo("Renamed", "", project.OwnerID))
+	var updated []event.DomainEvent
+	require.NoError(t, svc.saveProject(ctx, project, projectUpdated, &updated))
+	for _, e := range updated {
+		assert.NotEqual(t, "project.created", e.EventType())
+	}
+	assert.NotEmpty(t, updated)
+
+	// 已存在的项目不能再按新建保存
+	err := svc.saveProject(ctx, project, projectCreated, &created)
+	assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+}
+
+// newCascadeFixture 创建进行中的项目 p-1，包含进行中、手动暂停、草稿任务，以及其他项目的进行中任务
+func newCascadeFixture(t *testing.T) (*ProjectAppService, *testutil.MemoryTaskRepository, *recordingEventBus) {
+	t.Helper()
+
+	project := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	project.Status = valueobject.ProjectStatusActive
+	other := aggregate.NewProject("p-2", "Other", "", valueobject.ProjectTypeMaster, "owner-1")
+	other.Status = valueobject.ProjectStatusActive
+
+	svc, _, taskRepo, bus := newProjectServiceForTest(t, []aggregate.Project{*project, *other}, []aggregate.TaskAggregate{
+		{ID: "running-1", ProjectID: "p-1", Status: valueobject.TaskStatusInProgress},
+		{ID: "running-2", ProjectID: "p-1", Status: valueobject.TaskStatusInProgress},
+		{ID: "manual-paused", ProjectID: "p-1", Status: valueobject.TaskStatusPaused},
+		{ID: "draft", ProjectID: "p-1", Status: valueobject.TaskStatusDraft},
+		{ID: "other-running", ProjectID: "p-2", Status: valueobject.TaskStatusInProgress},
+	})
+	return svc, taskRepo, bus
+}
+
+func taskStatus(t *testing.T, repo *testutil.MemoryTaskRepository, id valueobject.TaskID) valueobject.TaskStatus {
+	t.Helper()
+	task, err := repo.FindByID(context.Background(), id)
+	require.NoError(t, err)
+	return task.Status
+}
+
+func TestChangeStatus_CascadePausesInProgressTasks(t *testing.T) {
+	svc, taskRepo, bus := newCascadeFixture(t)
+	ctx := context.Background()
+
+	n, err := svc.ChangeStatus(ctx, "p-1", "owner-1", string(valueobject.ProjectStatusPaused), "budget review", true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	for _, id := range []valueobject.TaskID{"running-1", "running-2"} {
+		task, err := taskRepo.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, valueobject.TaskStatusPaused, task.Status)
+		assert.True(t, task.PausedByProject)
+	}
+	assert.Equal(t, valueobject.TaskStatusDraft, taskStatus(t, taskRepo, "draft"))
+	assert.Equal(t, valueobject.TaskStatusInProgress, taskStatus(t, taskRepo, "other-running"))
+
+	manual, err := taskRepo.FindByID(ctx, "manual-paused")
+	require.NoError(t, err)
+	assert.False(t, manual.PausedByProject)
+
+	// 每个被级联暂停的任务各发布一条状态变更事件
+	var taskEvents []string
+	for _, e := range bus.published {
+		if changed, ok := e.(*event.TaskStatusChangedEvent); ok {
+			assert.Equal(t, string(valueobject.TaskStatusPaused), changed.NewStatus)
+			taskEvents = append(taskEvents, changed.TaskID)
+		}
+	}
+	assert.ElementsMatch(t, []string{"running-1", "running-2"}, taskEvents)
+}
+
+func TestChangeStatus_CascadeResumeRestoresOnlyProjectPausedTasks(t *testing.T) {
+	svc, taskRepo, _ := newCascadeFixture(t)
+	ctx := context.Background()
+
+	_, err := svc.ChangeStatus(ctx, "p-1", "owner-1", string(valueobject.ProjectStatusPaused), "", true)
+	require.NoError(t, err)
+
+	n, err := svc.ChangeStatus(ctx, "p-1", "owner-1", string(valueobject.ProjectStatusActive), "", true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	for _, id := range []valueobject.TaskID{"running-1", "running-2"} {
+		task, err := taskRepo.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, valueobject.TaskStatusInProgress, task.Status)
+		assert.False(t, task.PausedByProject)
+	}
+	assert.Equal(t, valueobject.TaskStatusPaused, taskStatus(t, taskRepo, "manual-paused"), "manually paused task must stay paused")
+	assert.Equal(t, valueobject.TaskStatusDraft, taskStatus(t, taskRepo, "draft"))
+}
+
+func TestChangeStatus_ResumeKeepsOriginalStartDate(t *testing.T) {
+	startDate := time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)
+	project := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	project.Status = valueobject.ProjectStatusPaused
+	project.StartDate = startDate
+	svc, projectRepo, _, _ := newProjectServiceForTest(t, []aggregate.Project{*project}, nil)
+
+	_, err := svc.ChangeStatus(context.Background(), "p-1", "owner-1", string(valueobject.ProjectStatusActive), "", false)
+	require.NoError(t, err)
+
+	stored, err := projectRepo.FindByID(context.Background(), "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.ProjectStatusActive, stored.Status)
+	assert.True(t, startDate.Equal(stored.StartDate), "resume must not reset the start date, got %v", stored.StartDate)
+}
+
+func TestChangeStatus_WithoutCascadeLeavesTasksUntouched(t *testing.T) {
+	svc, taskRepo, _ := newCascadeFixture(t)
+
+	n, err := svc.ChangeStatus(context.Background(), "p-1", "owner-1", string(valueobject.ProjectStatusPaused), "", false)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, valueobject.TaskStatusInProgress, taskStatus(t, taskRepo, "running-1"))
+}
+
+func TestChangeStatus_CascadeGatedByFeatureFlag(t *testing.T) {
+	policy := valueobject.FeatureFlagPolicy{
+		Environment: valueobject.ProductionEnvironment,
+		Rules: map[valueobject.FeatureFlag]valueobject.FeatureFlagRule{
+			valueobject.FeatureCascadePause: {Projects: map[valueobject.ProjectID]bool{"p-2": true}},
+		},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		svc, taskRepo, _ := newCascadeFixture(t)
+		svc.WithFeatureFlags(NewFeatureFlagAppService(policy))
+
+		n, err := svc.ChangeStatus(context.Background(), "p-1", "owner-1", string(valueobject.ProjectStatusPaused), "", true)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, valueobject.TaskStatusInProgress, taskStatus(t, taskRepo, "running-1"))
+	})
+
+	t.Run("enabled for project", func(t *testing.T) {
+		svc, taskRepo, _ := newCascadeFixture(t)
+		svc.WithFeatureFlags(NewFeatureFlagAppService(policy))
+
+		n, err := svc.ChangeStatus(context.Background(), "p-2", "owner-1", string(valueobject.ProjectStatusPaused), "", true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, valueobject.TaskStatusPaused, taskStatus(t, taskRepo, "other-running"))
+	})
+}
+
+// updateCountingProjectRepository 记录 Update 调用次数，用于验证校验失败时不会写库
+type updateCountingProjectRepository struct {
+	*testutil.MemoryProjectRepository
+	updates int
+}
+
+func (r *updateCountingProjectRepository) Update(ctx context.Context, project aggregate.Project) error {
+	r.updates++
+	return r.MemoryProjectRepository.Update(ctx, project)
+}
+
+func newAssignManagerFixture(t *testing.T, users ...*aggregate.User) (*ProjectAppService, *updateCountingProjectRepository, *recordingEventBus) {
+	t.Helper()
+
+	project := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	project.Status = valueobject.ProjectStatusActive
+	repo := &updateCountingProjectRepository{MemoryProjectRepository: testutil.NewMemoryProjectRepository(*project)}
+	userRepo := testutil.NewMemoryUserRepository(users...)
+	bus := &recordingEventBus{}
+	svc := NewProjectAppService(domainService.NewProjectDomainService(repo, userRepo), passthroughTransactionManager{}, repo, nil).
+		WithEventBus(bus)
+	return svc, repo, bus
+}
+
+func TestAssignManager_RejectsNonexistentUserBeforePersistence(t *testing.T) {
+	svc, repo, bus := newAssignManagerFixture(t)
+	ctx := context.Background()
+
+	err := svc.AssignManager(ctx, "p-1", "ghost", "owner-1")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Zero(t, repo.updates, "project must not be saved when the manager does not exist")
+	assert.Empty(t, bus.published)
+
+	stored, err := repo.FindByID(ctx, "p-1")
+	require.NoError(t, err)
+	assert.Nil(t, stored.ManagerID)
+	assert.Empty(t, stored.Members)
+}
+
+func TestAssignManager_RejectsInactiveUser(t *testing.T) {
+	inactive := aggregate.NewUser("user-2", "user2", "user2@example.com", "User Two", "hash", valueobject.UserRoleEmployee)
+	inactive.Deactivate()
+	svc, repo, _ := newAssignManagerFixture(t, inactive)
+
+	err := svc.AssignManager(context.Background(), "p-1", "user-2", "owner-1")
+
+	require.Error(t, err)
+	assert.Zero(t, repo.updates)
+}
+
+func TestAssignManager_AddsExistingUserAsManagerMember(t *testing.T) {
+	user := aggregate.NewUser("user-1", "user1", "user1@example.com", "User One", "hash", valueobject.UserRoleEmployee)
+	svc, repo, _ := newAssignManagerFixture(t, user)
+	ctx := context.Background()
+
+	require.NoError(t, svc.AssignManager(ctx, "p-1", "user-1", "owner-1"))
+
+	stored, err := repo.FindByID(ctx, "p-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored.ManagerID)
+	assert.Equal(t, valueobject.UserID("user-1"), *stored.ManagerID)
+	require.Len(t, stored.Members, 1)
+	assert.Equal(t, valueobject.ProjectRoleManager, stored.Members[0].Role)
+}
+
+func TestBuildProjectResponse_JSONShape(t *testing.T) {
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	project := aggregate.Project{
+		ID:          "project-1",
+		Name:        "Snapshot",
+		ProjectType: valueobject.ProjectTypeMaster,
+		Status:      valueobject.ProjectStatusActive,
+		OwnerID:     "owner-1",
+		StartDate:   created,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}
+
+	body, err := json.Marshal((&ProjectAppService{}).buildProjectResponse(project))
+	require.NoError(t, err)
+
+	// 空的可选字段省略，成员和子项目始终为数组
+	assert.JSONEq(t, `{
+		"id": "project-1",
+		"name": "Snapshot",
+		"description": "",
+		"project_type": "master",
+		"status": "active",
+		"owner_id": "owner-1",
+		"members": [],
+		"children": [],
+		"start_date": "2024-03-01T08:00:00Z",
+		"created_at": "2024-03-01T08:00:00Z",
+		"updated_at": "2024-03-01T08:00:00Z",
+		"statistics": {
+			"total_tasks": 0,
+			"completed_tasks": 0,
+			"pending_tasks": 0,
+			"total_members": 1,
+			"progress_percentage": 0
+		}
+	}`, string(body))
+}
+
+// newMemberRemovalFixture 项目 p-1 有成员 alice、bob；alice 负责 p-1 中进行中和已完成的任务，以及 p-2 中进行中的任务
+func newMemberRemovalFixture(t *testing.T) (*ProjectAppService, *testutil.MemoryProjectRepository, *testutil.MemoryTaskRepository) {
+	t.Helper()
+
+	project := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	project.Status = valueobject.ProjectStatusActive
+	require.NoError(t, project.AddMember("alice", valueobject.ProjectRoleMember, "owner-1"))
+	require.NoError(t, project.AddMember("bob", valueobject.ProjectRoleMember, "owner-1"))
+
+	svc, projectRepo, taskRepo, _ := newProjectServiceForTest(t, []aggregate.Project{*project}, []aggregate.TaskAggregate{
+		{ID: "active-1", ProjectID: "p-1", ResponsibleID: "alice", Status: valueobject.TaskStatusInProgress},
+		{ID: "done-1", ProjectID: "p-1", ResponsibleID: "alice", Status: valueobject.TaskStatusCompleted},
+		{ID: "other-project", ProjectID: "p-2", ResponsibleID: "alice", Status: valueobject.TaskStatusInProgress},
+	})
+	return svc, projectRepo, taskRepo
+}
+
+func TestRemoveMember_BlockedWhileResponsibleForActiveTasks(t *testing.T) {
+	svc, projectRepo, _ := newMemberRemovalFixture(t)
+	ctx := context.Background()
+
+	err := svc.RemoveMember(ctx, "p-1", "alice", "owner-1", "")
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrBusinessRule, domainErr.Type)
+	assert.Contains(t, err.Error(), "1 active task")
+
+	project, err := projectRepo.FindByID(ctx, "p-1")
+	require.NoError(t, err)
+	assert.NotNil(t, project.GetMemberRole("alice"), "member must not be removed")
+
+	// 接收人必须是项目成员
+	err = svc.RemoveMember(ctx, "p-1", "alice", "owner-1", "outsider")
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrInvalidInput, domainErr.Type)
+}
+
+func TestRemoveMember_AllowedOnceTasksReassignedOrCompleted(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("completed", func(t *testing.T) {
+		svc, projectRepo, taskRepo := newMemberRemovalFixture(t)
+		task, err := taskRepo.FindByID(ctx, "active-1")
+		require.NoError(t, err)
+		task.Status = valueobject.TaskStatusCompleted
+		require.NoError(t, taskRepo.Update(ctx, *task))
+
+		require.NoError(t, svc.RemoveMember(ctx, "p-1", "alice", "owner-1", ""))
+		project, err := projectRepo.FindByID(ctx, "p-1")
+		require.NoError(t, err)
+		assert.Nil(t, project.GetMemberRole("alice"))
+	})
+
+	t.Run("reassigned", func(t *testing.T) {
+		svc, projectRepo, taskRepo := newMemberRemovalFixture(t)
+
+		require.NoError(t, svc.RemoveMember(ctx, "p-1", "alice", "owner-1", "bob"))
+		project, err := projectRepo.FindByID(ctx, "p-1")
+		require.NoError(t, err)
+		assert.Nil(t, project.GetMemberRole("alice"))
+
+		reassigned, err := taskRepo.FindByID(ctx, "active-1")
+		require.NoError(t, err)
+		assert.Equal(t, valueobject.UserID("bob"), reassigned.ResponsibleID)
+		// 已完成任务和其他项目的任务不转交
+		done, err := taskRepo.FindByID(ctx, "done-1")
+		require.NoError(t, err)
+		assert.Equal(t, valueobject.UserID("alice"), done.ResponsibleID)
+		other, err := taskRepo.FindByID(ctx, "other-project")
+		require.NoError(t, err)
+		assert.Equal(t, valueobject.UserID("alice"), other.ResponsibleID)
+	})
+}
+
+func TestRemoveMember_ReplacesOrStripsCoResponsible(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*ProjectAppService, *testutil.MemoryTaskRepository) {
+		svc, _, taskRepo := newMemberRemovalFixture(t)
+		require.NoError(t, taskRepo.Create(ctx, aggregate.TaskAggregate{ID: "co-1", ProjectID: "p-1", ResponsibleID: "owner-1",
+			CoResponsibleIDs: []valueobject.UserID{"alice"}, Status: valueobject.TaskStatusInProgress}))
+		return svc, taskRepo
+	}
+
+	t.Run("replaced", func(t *testing.T) {
+		svc, taskRepo := setup(t)
+		require.NoError(t, svc.RemoveMember(ctx, "p-1", "alice", "owner-1", "bob"))
+
+		task, err := taskRepo.FindByID(ctx, "co-1")
+		require.NoError(t, err)
+		assert.Equal(t, valueobject.UserID("owner-1"), task.ResponsibleID)
+		assert.Equal(t, []valueobject.UserID{"bob"}, task.CoResponsibleIDs)
+	})
+
+	t.Run("stripped", func(t *testing.T) {
+		svc, taskRepo := setup(t)
+		active, err := taskRepo.FindByID(ctx, "active-1")
+		require.NoError(t, err)
+		active.Status = valueobject.TaskStatusCompleted
+		require.NoError(t, taskRepo.Update(ctx, *active))
+
+		// 只担任共同负责人时无需指定接收人
+		require.NoError(t, svc.RemoveMember(ctx, "p-1", "alice", "owner-1", ""))
+		task, err := taskRepo.FindByID(ctx, "co-1")
+		require.NoError(t, err)
+		assert.Equal(t, valueobject.UserID("owner-1"), task.ResponsibleID)
+		assert.Empty(t, task.CoResponsibleIDs)
+	})
+}
+
+// newParticipantSwapFixture 项目 p-1 有成员 alice、bob；alice 以审核者参与 t-1，以执行者参与 t-2，
+// 与 bob 共同参与 t-3，还参与其他项目的 t-4 以及本项目已完成的 t-5
+func newParticipantSwapFixture(t *testing.T) (*ProjectAppService, *testutil.MemoryTaskRepository, *recordingEventBus) {
+	t.Helper()
+
+	project := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, project.AddMember("alice", valueobject.ProjectRoleMember, "owner-1"))
+	require.NoError(t, project.AddMember("bob", valueobject.ProjectRoleMember, "owner-1"))
+
+	withParticipants := func(id valueobject.TaskID, projectID valueobject.ProjectID, participants ...valueobject.TaskParticipant) aggregate.TaskAggregate {
+		return aggregate.TaskAggregate{ID: id, ProjectID: projectID, ResponsibleID: "owner-1", Status: valueobject.TaskStatusInProgress, Participants: participants}
+	}
+	done := withParticipants("t-5", "p-1", valueobject.TaskParticipant{UserID: "alice", Role: valueobject.ParticipantRoleExecutor})
+	done.Status = valueobject.TaskStatusCompleted
+	svc, _, taskRepo, bus := newProjectServiceForTest(t, []aggregate.Project{*project}, []aggregate.TaskAggregate{
+		withParticipants("t-1", "p-1", valueobject.TaskParticipant{UserID: "alice", Role: valueobject.ParticipantRoleReviewer}),
+		withParticipants("t-2", "p-1", valueobject.TaskParticipant{UserID: "alice", Role: valueobject.ParticipantRoleExecutor}),
+		withParticipants("t-3", "p-1",
+			valueobject.TaskParticipant{UserID: "alice", Role: valueobject.ParticipantRoleExecutor},
+			valueobject.TaskParticipant{UserID: "bob", Role: valueobject.ParticipantRoleObserver}),
+		withParticipants("t-4", "p-2", valueobject.TaskParticipant{UserID: "alice", Role: valueobject.ParticipantRoleExecutor}),
+		done,
+	})
+	return svc, taskRepo, bus
+}
+
+func TestSwapParticipants_ReplacesPreservingRoleAndSkipsExisting(t *testing.T) {
+	svc, taskRepo, bus := newParticipantSwapFixture(t)
+	ctx := context.Background()
+
+	resp, err := svc.SwapParticipants(ctx, "p-1", "owner-1", SwapParticipantsRequest{FromUser: "alice", ToUser: "bob"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"t-1", "t-2"}, resp.SwappedTaskIDs)
+	assert.ElementsMatch(t, []string{"t-3", "t-5"}, resp.SkippedTaskIDs)
+
+	t1, err := taskRepo.FindByID(ctx, "t-1")
+	require.NoError(t, err)
+	assert.False(t, t1.IsParticipant("alice"))
+	require.NotNil(t, t1.GetParticipantRole("bob"))
+	assert.Equal(t, valueobject.ParticipantRoleReviewer, *t1.GetParticipantRole("bob"))
+
+	// 跳过的任务和其他项目的任务保持不变
+	t3, err := taskRepo.FindByID(ctx, "t-3")
+	require.NoError(t, err)
+	assert.True(t, t3.IsParticipant("alice"))
+	assert.Equal(t, valueobject.ParticipantRoleObserver, *t3.GetParticipantRole("bob"))
+	t4, err := taskRepo.FindByID(ctx, "t-4")
+	require.NoError(t, err)
+	assert.True(t, t4.IsParticipant("alice"))
+	t5, err := taskRepo.FindByID(ctx, "t-5")
+	require.NoError(t, err)
+	assert.True(t, t5.IsParticipant("alice"), "completed tasks keep their participants")
+
+	// 每个替换的任务发布一对移除和添加事件
+	assert.Equal(t, []string{"ParticipantRemoved", "ParticipantAdded", "ParticipantRemoved", "ParticipantAdded"}, publishedTypes(bus))
+	removed, ok := bus.published[0].(*event.ParticipantRemovedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "alice", removed.ParticipantID)
+	added, ok := bus.published[1].(*event.ParticipantAddedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "bob", added.ParticipantID)
+	assert.Equal(t, removed.AggregateID(), added.AggregateID())
+}
+
+func TestSwapParticipants_RequiresManagerAndMemberTarget(t *testing.T) {
+	svc, taskRepo, bus := newParticipantSwapFixture(t)
+	ctx := context.Background()
+
+	var domainErr *event.DomainError
+	_, err := svc.SwapParticipants(ctx, "p-1", "bob", SwapParticipantsRequest{FromUser: "alice", ToUser: "bob"})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	_, err = svc.SwapParticipants(ctx, "p-1", "owner-1", SwapParticipantsRequest{FromUser: "alice", ToUser: "outsider"})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrInvalidInput, domainErr.Type)
+
+	task, err := taskRepo.FindByID(ctx, "t-1")
+	require.NoError(t, err)
+	assert.True(t, task.IsParticipant("alice"))
+	assert.Empty(t, bus.published)
+}
+
+func TestSwapParticipants_AllowsGlobalAdminOutsideProject(t *testing.T) {
+	svc, _, _ := newParticipantSwapFixture(t)
+
+	resp, err := svc.SwapParticipants(context.Background(), "p-1", "admin-1",
+		SwapParticipantsRequest{FromUser: "alice", ToUser: "bob", RequesterIsAdmin: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"t-1", "t-2"}, resp.SwappedTaskIDs)
+}
+
+// newSuggestionFixture 项目 p-1 的成员 alice 负责 3 个未结束任务，bob 负责 1 个逾期任务和 1 个已完成任务，carol 没有任务
+func newSuggestionFixture(t *testing.T) *ProjectAppService {
+	t.Helper()
+
+	project := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	for _, userID := range []valueobject.UserID{"alice", "bob", "carol"} {
+		require.NoError(t, project.AddMember(userID, valueobject.ProjectRoleDeveloper, "owner-1"))
+	}
+
+	past := time.Now().Add(-48 * time.Hour)
+	svc, _, _, _ := newProjectServiceForTest(t, []aggregate.Project{*project}, []aggregate.TaskAggregate{
+		{ID: "a-1", ProjectID: "p-1", ResponsibleID: "alice", Status: valueobject.TaskStatusInProgress},
+		{ID: "a-2", ProjectID: "p-1", ResponsibleID: "alice", Status: valueobject.TaskStatusApproved},
+		{ID: "a-3", ProjectID: "p-other", ResponsibleID: "alice", Status: valueobject.TaskStatusDraft},
+		{ID: "b-1", ProjectID: "p-1", ResponsibleID: "bob", Status: valueobject.TaskStatusInProgress, DueDate: &past},
+		{ID: "b-2", ProjectID: "p-1", ResponsibleID: "bob", Status: valueobject.TaskStatusCompleted},
+		{ID: "o-1", ProjectID: "p-1", ResponsibleID: "owner-1", Status: valueobject.TaskStatusInProgress},
+		{ID: "o-2", ProjectID: "p-1", ResponsibleID: "owner-1", Status: valueobject.TaskStatusInProgress},
+		{ID: "o-3", ProjectID: "p-1", ResponsibleID: "owner-1", Status: valueobject.TaskStatusInProgress},
+		{ID: "o-4", ProjectID: "p-1", ResponsibleID: "owner-1", Status: valueobject.TaskStatusInProgress},
+	})
+	return svc
+}
+
+func suggestedUserIDs(resp *AssignmentSuggestionResponse) []string {
+	ids := make([]string, len(resp.Suggestions))
+	for i, s := range resp.Suggestions {
+		ids[i] = s.UserID
+	}
+	return ids
+}
+
+func TestSuggestAssignees_RanksLeastLoadedFirst(t *testing.T) {
+	svc := newSuggestionFixture(t)
+	ctx := context.Background()
+
+	resp, err := svc.SuggestAssignees(ctx, &AssignmentSuggestionRequest{ProjectID: "p-1", RequesterID: "owner-1", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol", "bob", "alice", "owner-1"}, suggestedUserIDs(resp))
+	assert.Equal(t, AssigneeSuggestion{UserID: "alice", Role: "developer", OpenTasks: 3}, resp.Suggestions[2])
+	assert.Equal(t, 1, resp.Suggestions[1].OverdueTasks)
+
+	// 紧急任务优先推荐没有逾期任务的成员
+	urgent, err := svc.SuggestAssignees(ctx, &AssignmentSuggestionRequest{ProjectID: "p-1", RequesterID: "owner-1", Priority: "critical", Limit: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol", "alice", "owner-1"}, suggestedUserIDs(urgent))
+}
+
+func TestSuggestAssignees_RequiresProjectAccess(t *testing.T) {
+	svc := newSuggestionFixture(t)
+
+	_, err := svc.SuggestAssignees(context.Background(), &AssignmentSuggestionRequest{ProjectID: "p-1", RequesterID: "outsider", Limit: 3})
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	resp, err := svc.SuggestAssignees(context.Background(), &AssignmentSuggestionRequest{ProjectID: "p-1", RequesterID: "outsider", RequesterIsAdmin: true, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"carol"}, suggestedUserIDs(resp))
+}
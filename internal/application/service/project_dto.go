@@ -3,24 +3,31 @@ package service
 import (
 	"time"
 
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/domain/valueobject"
 )
 
 // CreateProjectRequest 创建项目请求
 type CreateProjectRequest struct {
-	ID          string `json:"id" binding:"required"`
+	ID          string `json:"id"` // 可选，为空时由服务端生成
 	Name        string `json:"name" binding:"required,min=1,max=100"`
 	Description string `json:"description" binding:"max=500"`
 	ProjectType string `json:"project_type" binding:"required,oneof=master sub"`
-	OwnerID     string `json:"owner_id" binding:"required"`
+	OwnerID     string `json:"-"` // 项目所有者，由处理器填充为当前用户
 	ParentID    string `json:"parent_id,omitempty"`
 }
 
 // UpdateProjectRequest 更新项目请求
 type UpdateProjectRequest struct {
-	ID          string `json:"id" binding:"required"`
-	Name        string `json:"name" binding:"required,min=1,max=100"`
-	Description string `json:"description" binding:"max=500"`
+	ID          string     `json:"id" binding:"required"`
+	Name        string     `json:"name" binding:"required"` // 长度上限由配置的项目文本限制校验
+	Description string     `json:"description"`
+	StartDate   *time.Time `json:"start_date,omitempty"` // 为空时不修改
+	EndDate     *time.Time `json:"end_date,omitempty"`   // 为空时不修改，不能早于开始日期
+	UpdatedBy   string     `json:"-"`                    // 当前操作用户，由处理器填充
+
+	// RequesterIsAdmin 由处理器根据认证上下文填充，全局管理员无需是项目所有者或管理者
+	RequesterIsAdmin bool `json:"-"`
 }
 
 // ProjectResponse 项目响应
@@ -39,9 +46,22 @@ type ProjectResponse struct {
 	EndDate     *time.Time                    `json:"end_date,omitempty"`
 	CreatedAt   time.Time                     `json:"created_at"`
 	UpdatedAt   time.Time                     `json:"updated_at"`
+	CreatedBy   string                        `json:"created_by,omitempty"`
+	UpdatedBy   string                        `json:"updated_by,omitempty"`
 	Statistics  *ProjectStatisticsResponse    `json:"statistics,omitempty"`
 }
 
+// Localize 按用户时区渲染响应中的时间
+func (r *ProjectResponse) Localize(loc *time.Location) {
+	r.StartDate = r.StartDate.In(loc)
+	r.EndDate = shared.InLocation(r.EndDate, loc)
+	r.CreatedAt = r.CreatedAt.In(loc)
+	r.UpdatedAt = r.UpdatedAt.In(loc)
+	for i := range r.Members {
+		r.Members[i].JoinedAt = r.Members[i].JoinedAt.In(loc)
+	}
+}
+
 // ProjectMemberResponse 项目成员响应
 type ProjectMemberResponse struct {
 	UserID   string    `json:"user_id"`
@@ -70,15 +90,34 @@ type UpdateMemberRoleRequest struct {
 	Role string `json:"role" binding:"required,oneof=member developer tester"`
 }
 
+// SwapParticipantsRequest 批量替换任务参与者请求
+type SwapParticipantsRequest struct {
+	FromUser string `json:"from_user" binding:"required"`
+	ToUser   string `json:"to_user" binding:"required,nefield=FromUser"`
+
+	// RequesterIsAdmin 由处理器根据认证上下文填充，全局管理员无需是项目成员
+	RequesterIsAdmin bool `json:"-"`
+}
+
+// SwapParticipantsResponse 批量替换任务参与者响应
+// skipped_task_ids 为 to_user 已是参与者或任务已完成、已取消而未替换的任务
+type SwapParticipantsResponse struct {
+	SwappedTaskIDs []string `json:"swapped_task_ids"`
+	SkippedTaskIDs []string `json:"skipped_task_ids"`
+}
+
 // AssignManagerRequest 分配管理者请求
 type AssignManagerRequest struct {
 	ManagerID string `json:"manager_id" binding:"required"`
 }
 
 // ChangeStatusRequest 更改状态请求
+// cascade_tasks 为 true 时，暂停项目同时暂停进行中的任务，恢复项目时只恢复随项目暂停的任务
+// 功能开关 cascade_pause 对该项目关闭时忽略 cascade_tasks
 type ChangeStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=draft active paused completed cancelled"`
-	Reason string `json:"reason,omitempty"`
+	Status       string `json:"status" binding:"required,oneof=draft active paused completed cancelled"`
+	Reason       string `json:"reason,omitempty"`
+	CascadeTasks bool   `json:"cascade_tasks"`
 }
 
 // ProjectListRequest 项目列表请求
@@ -92,6 +131,19 @@ type ProjectListRequest struct {
 	Search     string `form:"search,omitempty"`
 	SortBy     string `form:"sort_by,default=created_at" binding:"omitempty,oneof=name created_at updated_at status"`
 	SortOrder  string `form:"sort_order,default=desc" binding:"omitempty,oneof=asc desc"`
+
+	// 请求者信息由处理器根据认证上下文填充，不从查询参数绑定
+	RequesterID      string `form:"-" json:"-"`
+	RequesterIsAdmin bool   `form:"-" json:"-"`
+}
+
+// ProjectDateRangeRequest 按时间窗口查询项目请求
+type ProjectDateRangeRequest struct {
+	From time.Time
+	To   time.Time
+
+	RequesterID      string
+	RequesterIsAdmin bool
 }
 
 // ProjectListResponse 项目列表响应
@@ -103,6 +155,27 @@ type ProjectListResponse struct {
 	TotalPages int               `json:"total_pages"`
 }
 
+// AtRiskProjectListRequest 有逾期任务的项目列表请求
+type AtRiskProjectListRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=20" binding:"min=1,max=100"`
+}
+
+// AtRiskProjectResponse 有逾期任务的项目
+type AtRiskProjectResponse struct {
+	ProjectResponse
+	OverdueTasks int `json:"overdue_tasks"`
+}
+
+// AtRiskProjectListResponse 有逾期任务的项目列表响应，按逾期任务数降序
+type AtRiskProjectListResponse struct {
+	Projects   []AtRiskProjectResponse `json:"projects"`
+	Total      int                     `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
+}
+
 // CreateSubProjectRequest 创建子项目请求
 type CreateSubProjectRequest struct {
 	Name        string `json:"name" binding:"required,min=1,max=100"`
@@ -118,6 +191,50 @@ type ProjectHierarchyResponse struct {
 	TotalProjects int                `json:"total_projects"`
 }
 
+// ProjectDescendantsRequest 项目子树查询参数，max_depth 为空时使用默认上限
+type ProjectDescendantsRequest struct {
+	MaxDepth int `form:"max_depth" binding:"omitempty,min=1,max=10"`
+}
+
+// ProjectDescendantResponse 后代项目响应
+type ProjectDescendantResponse struct {
+	ProjectResponse
+	Depth int `json:"depth"`
+}
+
+// ProjectDescendantsResponse 项目子树响应
+type ProjectDescendantsResponse struct {
+	ProjectID   string                      `json:"project_id"`
+	Descendants []ProjectDescendantResponse `json:"descendants"`
+	Total       int                         `json:"total"`
+	MaxDepth    int                         `json:"max_depth"`
+}
+
+// AssignmentSuggestionRequest 负责人推荐请求，priority 为待分配任务的优先级
+type AssignmentSuggestionRequest struct {
+	Priority string `form:"priority" binding:"omitempty,oneof=low medium high critical"`
+	Limit    int    `form:"limit,default=3" binding:"min=1,max=20"`
+
+	ProjectID        string `form:"-" json:"-"`
+	RequesterID      string `form:"-" json:"-"`
+	RequesterIsAdmin bool   `form:"-" json:"-"`
+}
+
+// AssigneeSuggestion 推荐的负责人及其当前负载（统计全部项目）
+type AssigneeSuggestion struct {
+	UserID       string `json:"user_id"`
+	Role         string `json:"role"`
+	OpenTasks    int    `json:"open_tasks"`
+	OverdueTasks int    `json:"overdue_tasks"`
+}
+
+// AssignmentSuggestionResponse 负责人推荐响应，按负载从低到高排序，仅供参考
+type AssignmentSuggestionResponse struct {
+	ProjectID   string               `json:"project_id"`
+	Priority    string               `json:"priority,omitempty"`
+	Suggestions []AssigneeSuggestion `json:"suggestions"`
+}
+
 // 转换函数
 
 // ToProjectMemberResponse 转换项目成员响应
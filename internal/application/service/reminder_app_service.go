@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ReminderAppService 截止日期提醒服务，按提醒梯度为任务负责人和参与者发布提醒事件
+type ReminderAppService struct {
+	taskRepo     repository.TaskRepository
+	reminderRepo repository.ReminderRepository
+	eventBus     event.EventBus
+	policy       valueobject.ReminderPolicy
+	featureFlags FeatureFlagChecker
+	userRepo     repository.UserRepository
+	now          func() time.Time
+}
+
+// NewReminderAppService 创建截止日期提醒服务
+func NewReminderAppService(taskRepo repository.TaskRepository, reminderRepo repository.ReminderRepository, eventBus event.EventBus, policy valueobject.ReminderPolicy) *ReminderAppService {
+	return &ReminderAppService{
+		taskRepo:     taskRepo,
+		reminderRepo: reminderRepo,
+		eventBus:     eventBus,
+		policy:       policy,
+		now:          time.Now,
+	}
+}
+
+// WithFeatureFlags 设置功能开关，截止日期提醒关闭的项目不再发送提醒
+func (s *ReminderAppService) WithFeatureFlags(flags FeatureFlagChecker) *ReminderAppService {
+	s.featureFlags = flags
+	return s
+}
+
+// WithUserRepository 设置用户仓储，接收人处于静默时段时推迟提醒，待静默时段结束后的检查再发送
+func (s *ReminderAppService) WithUserRepository(userRepo repository.UserRepository) *ReminderAppService {
+	s.userRepo = userRepo
+	return s
+}
+
+// SendDueReminders 为到达提醒梯度的任务发布提醒，每个任务、用户和梯度只提醒一次，返回发布的提醒数
+// 已确认提醒的用户不再收到该任务的提醒
+func (s *ReminderAppService) SendDueReminders(ctx context.Context) (int, error) {
+	now := s.now()
+	// 只查询可能有梯度到期的任务：截止时间早于 now-补发时长的任务所有梯度都已超过补发时长，
+	// 截止时间晚于 now+最大提前量的任务还未到第一级提醒
+	tasks, err := s.taskRepo.FindTasksDueBetween(ctx, now.Add(-valueobject.ReminderCatchUp), now.Add(s.policy.MaxOffset()))
+	if err != nil {
+		return 0, fmt.Errorf("查询待提醒任务失败: %w", err)
+	}
+
+	sent := 0
+	for i := range tasks {
+		task := &tasks[i]
+		if !featureEnabled(s.featureFlags, valueobject.FeatureDeadlineReminders, valueobject.FeatureFlagContext{ProjectID: task.ProjectID}) {
+			continue
+		}
+		rung, ok := s.policy.LadderFor(task.ProjectID, task.Priority).DueRung(*task.DueDate, now)
+		if !ok {
+			continue
+		}
+		for _, userID := range reminderRecipients(task) {
+			published, err := s.remind(ctx, task, userID, rung, now)
+			if err != nil {
+				return sent, err
+			}
+			if published {
+				sent++
+			}
+		}
+	}
+	return sent, nil
+}
+
+// remind 为单个用户发布提醒，用户已确认或该梯度已提醒过时跳过
+// 用户处于静默时段时不记录提醒，之后的检查仍会发送
+func (s *ReminderAppService) remind(ctx context.Context, task *aggregate.TaskAggregate, userID valueobject.UserID, rung time.Duration, now time.Time) (bool, error) {
+	acknowledged, err := s.reminderRepo.IsAcknowledged(ctx, task.ID, userID)
+	if err != nil {
+		return false, fmt.Errorf("查询提醒确认状态失败: %w", err)
+	}
+	if acknowledged {
+		return false, nil
+	}
+
+	quiet, err := s.inQuietHours(ctx, userID, now)
+	if err != nil {
+		return false, err
+	}
+	if quiet {
+		return false, nil
+	}
+
+	recorded, err := s.reminderRepo.RecordSent(ctx, valueobject.TaskReminder{
+		TaskID:  task.ID,
+		UserID:  userID,
+		DueDate: *task.DueDate,
+		Offset:  rung,
+		SentAt:  now,
+	})
+	if err != nil {
+		return false, fmt.Errorf("记录截止日期提醒失败: %w", err)
+	}
+	if !recorded {
+		return false, nil
+	}
+
+	if s.eventBus != nil {
+		e := event.NewTaskDueReminderEvent(string(task.ID), string(userID), *task.DueDate, rung)
+		if err := s.eventBus.Publish(e); err != nil {
+			logger.Warn("Failed to publish task due reminder",
+				zap.String("task_id", string(task.ID)),
+				zap.String("user_id", string(userID)),
+				zap.Error(err))
+		}
+	}
+	return true, nil
+}
+
+// inQuietHours 判断接收人是否处于静默时段，未设置用户仓储或用户不存在时返回 false
+func (s *ReminderAppService) inQuietHours(ctx context.Context, userID valueobject.UserID, now time.Time) (bool, error) {
+	if s.userRepo == nil {
+		return false, nil
+	}
+	user, err := s.userRepo.FindByID(ctx, string(userID))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("查询提醒接收人失败: %w", err)
+	}
+	return user.NotificationSettings.InQuietHours(now, shared.LoadLocation(user.Timezone)), nil
+}
+
+// reminderRecipients 提醒接收人：负责人、共同负责人和全部参与者，按首次出现去重
+func reminderRecipients(task *aggregate.TaskAggregate) []valueobject.UserID {
+	seen := make(map[valueobject.UserID]bool)
+	var recipients []valueobject.UserID
+	add := func(userID valueobject.UserID) {
+		if userID != "" && !seen[userID] {
+			seen[userID] = true
+			recipients = append(recipients, userID)
+		}
+	}
+	for _, userID := range task.Responsibles() {
+		add(userID)
+	}
+	for _, p := range task.Participants {
+		add(p.UserID)
+	}
+	return recipients
+}
+
+// Acknowledge 确认任务的截止日期提醒，之后不再向该用户发送该任务的提醒
+// 只有提醒接收人（负责人或参与者）可以确认
+func (s *ReminderAppService) Acknowledge(ctx context.Context, taskID, userID string) error {
+	task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(taskID))
+	if err != nil {
+		return fmt.Errorf("任务不存在: %w", err)
+	}
+
+	isRecipient := false
+	for _, recipient := range reminderRecipients(task) {
+		if recipient == valueobject.UserID(userID) {
+			isRecipient = true
+			break
+		}
+	}
+	if !isRecipient {
+		return event.NewDomainError(event.ErrPermissionDenied, "only the responsible user or participants can acknowledge reminders")
+	}
+
+	if err := s.reminderRepo.Acknowledge(ctx, task.ID, valueobject.UserID(userID), s.now()); err != nil {
+		return fmt.Errorf("确认截止日期提醒失败: %w", err)
+	}
+	return nil
+}
+
+// RunSchedule 启动后立即检查一次，之后按间隔定期检查，直到 ctx 取消；单次失败只记录日志
+func (s *ReminderAppService) RunSchedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := s.SendDueReminders(ctx); err != nil {
+			logger.Error("Task due reminders failed", zap.Error(err))
+		} else if count > 0 {
+			logger.Info("Task due reminders sent", zap.Int("count", count))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
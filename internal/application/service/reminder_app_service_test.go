@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// rangeRecordingTaskRepository 记录提醒检查查询的截止时间范围
+type rangeRecordingTaskRepository struct {
+	*testutil.MemoryTaskRepository
+	from, to time.Time
+}
+
+func (r *rangeRecordingTaskRepository) FindTasksDueBetween(ctx context.Context, from, to time.Time) ([]aggregate.TaskAggregate, error) {
+	r.from, r.to = from, to
+	return r.MemoryTaskRepository.FindTasksDueBetween(ctx, from, to)
+}
+
+// reminderFixture 截止时间为 due 的任务，负责人 alice、参与者 bob，使用默认提醒梯度
+type reminderFixture struct {
+	svc      *ReminderAppService
+	bus      *recordingEventBus
+	reminder *testutil.MemoryReminderRepository
+	due      time.Time
+	now      time.Time
+}
+
+func newReminderFixture(t *testing.T) *reminderFixture {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+
+	due := time.Date(2024, 6, 10, 18, 0, 0, 0, time.UTC)
+	task := aggregate.NewTask("task-1", "Quarterly report", "", valueobject.TaskTypeRegular,
+		valueobject.TaskPriorityMedium, "project-1", "creator-1", "alice", &due)
+	task.Status = valueobject.TaskStatusInProgress
+	task.Participants = []valueobject.TaskParticipant{{UserID: "bob", Role: valueobject.ParticipantRoleExecutor}}
+
+	f := &reminderFixture{
+		bus:      &recordingEventBus{},
+		reminder: testutil.NewMemoryReminderRepository(),
+		due:      due,
+	}
+	f.svc = NewReminderAppService(testutil.NewMemoryTaskRepository(*task), f.reminder, f.bus, valueobject.ReminderPolicy{})
+	f.svc.now = func() time.Time { return f.now }
+	return f
+}
+
+// runAt 在指定时间执行一次提醒检查，返回本次发布的提醒
+func (f *reminderFixture) runAt(t *testing.T, now time.Time) []*event.TaskDueReminderEvent {
+	t.Helper()
+	f.now = now
+	before := len(f.bus.published)
+	count, err := f.svc.SendDueReminders(context.Background())
+	require.NoError(t, err)
+
+	var reminders []*event.TaskDueReminderEvent
+	for _, e := range f.bus.published[before:] {
+		reminder, ok := e.(*event.TaskDueReminderEvent)
+		require.True(t, ok, "unexpected event %T", e)
+		reminders = append(reminders, reminder)
+	}
+	require.Len(t, reminders, count)
+	return reminders
+}
+
+func TestReminderAppService_EachRungFiresOnce(t *testing.T) {
+	f := newReminderFixture(t)
+
+	assert.Empty(t, f.runAt(t, f.due.Add(-25*time.Hour)), "before the first rung")
+
+	for _, rung := range []struct {
+		at      time.Duration
+		minutes int
+	}{
+		{-24 * time.Hour, 24 * 60},
+		{-time.Hour, 60},
+		{0, 0},
+	} {
+		reminders := f.runAt(t, f.due.Add(rung.at))
+		require.Len(t, reminders, 2, "rung %d", rung.minutes)
+		for _, r := range reminders {
+			assert.Equal(t, rung.minutes, r.OffsetMinutes)
+			assert.True(t, r.DueDate.Equal(f.due))
+		}
+		assert.ElementsMatch(t, []string{"alice", "bob"}, []string{reminders[0].UserID, reminders[1].UserID})
+
+		// 同一梯度再次检查不重复提醒
+		assert.Empty(t, f.runAt(t, f.due.Add(rung.at+time.Minute)), "rung %d repeated", rung.minutes)
+	}
+	assert.Len(t, f.reminder.Sent(), 6)
+}
+
+func TestReminderAppService_SkipsMissedRungsAndStaleTasks(t *testing.T) {
+	f := newReminderFixture(t)
+
+	// 错过 T-24h 时只发送最近的 T-1h 提醒
+	reminders := f.runAt(t, f.due.Add(-30*time.Minute))
+	require.Len(t, reminders, 2)
+	assert.Equal(t, 60, reminders[0].OffsetMinutes)
+
+	// 到期超过补发时长后不再提醒
+	assert.Empty(t, f.runAt(t, f.due.Add(valueobject.ReminderCatchUp+time.Minute)))
+}
+
+func TestReminderAppService_QueriesOnlyTasksWithinReminderWindow(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	longOverdue := now.AddDate(-2, 0, 0)
+	dueSoon := now.Add(30 * time.Minute)
+	stale := aggregate.NewTask("task-stale", "Stale", "", valueobject.TaskTypeRegular,
+		valueobject.TaskPriorityMedium, "project-1", "creator-1", "alice", &longOverdue)
+	stale.Status = valueobject.TaskStatusInProgress
+	soon := aggregate.NewTask("task-soon", "Soon", "", valueobject.TaskTypeRegular,
+		valueobject.TaskPriorityMedium, "project-1", "creator-1", "alice", &dueSoon)
+	soon.Status = valueobject.TaskStatusInProgress
+	repo := &rangeRecordingTaskRepository{MemoryTaskRepository: testutil.NewMemoryTaskRepository(*stale, *soon)}
+	bus := &recordingEventBus{}
+	svc := NewReminderAppService(repo, testutil.NewMemoryReminderRepository(), bus, valueobject.ReminderPolicy{})
+	svc.now = func() time.Time { return now }
+
+	sent, err := svc.SendDueReminders(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, now.Add(-valueobject.ReminderCatchUp), repo.from)
+	assert.Equal(t, now.Add(24*time.Hour), repo.to)
+	reminder, ok := bus.published[0].(*event.TaskDueReminderEvent)
+	require.True(t, ok)
+	assert.Equal(t, "task-soon", reminder.TaskID)
+}
+
+func TestReminderAppService_GatedByFeatureFlag(t *testing.T) {
+	f := newReminderFixture(t)
+	rule := valueobject.FeatureFlagRule{Environments: map[string]bool{"staging": false}}
+	f.svc.WithFeatureFlags(NewFeatureFlagAppService(valueobject.FeatureFlagPolicy{
+		Environment: "staging",
+		Rules:       map[valueobject.FeatureFlag]valueobject.FeatureFlagRule{valueobject.FeatureDeadlineReminders: rule},
+	}))
+
+	assert.Empty(t, f.runAt(t, f.due.Add(-24*time.Hour)), "disabled flag must skip reminders")
+	assert.Empty(t, f.reminder.Sent())
+
+	// 为项目开启后恢复发送
+	rule.Projects = map[valueobject.ProjectID]bool{"project-1": true}
+	f.svc.WithFeatureFlags(NewFeatureFlagAppService(valueobject.FeatureFlagPolicy{
+		Environment: "staging",
+		Rules:       map[valueobject.FeatureFlag]valueobject.FeatureFlagRule{valueobject.FeatureDeadlineReminders: rule},
+	}))
+	assert.Len(t, f.runAt(t, f.due.Add(-24*time.Hour)), 2)
+}
+
+func TestReminderAppService_DefersRemindersDuringQuietHours(t *testing.T) {
+	f := newReminderFixture(t)
+	alice := aggregate.NewUser("alice", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee)
+	settings := alice.NotificationSettings
+	settings.QuietHoursStart, settings.QuietHoursEnd = "16:30", "17:30"
+	require.NoError(t, alice.UpdateNotificationSettings(settings))
+	f.svc.WithUserRepository(testutil.NewMemoryUserRepository(alice))
+
+	// T-1h 时 alice 处于静默时段，只提醒 bob
+	reminders := f.runAt(t, f.due.Add(-time.Hour))
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "bob", reminders[0].UserID)
+
+	// 静默时段结束后补发 alice 的 T-1h 提醒
+	reminders = f.runAt(t, f.due.Add(-29*time.Minute))
+	require.Len(t, reminders, 1)
+	assert.Equal(t, "alice", reminders[0].UserID)
+	assert.Equal(t, 60, reminders[0].OffsetMinutes)
+}
+
+func TestReminderAppService_AcknowledgeStopsSubsequentReminders(t *testing.T) {
+	f := newReminderFixture(t)
+	require.Len(t, f.runAt(t, f.due.Add(-24*time.Hour)), 2)
+
+	require.NoError(t, f.svc.Acknowledge(context.Background(), "task-1", "alice"))
+
+	for _, at := range []time.Duration{-time.Hour, 0} {
+		reminders := f.runAt(t, f.due.Add(at))
+		require.Len(t, reminders, 1)
+		assert.Equal(t, "bob", reminders[0].UserID, "only the user who did not acknowledge is reminded")
+	}
+}
+
+func TestReminderAppService_AcknowledgeRequiresRecipient(t *testing.T) {
+	f := newReminderFixture(t)
+
+	err := f.svc.Acknowledge(context.Background(), "task-1", "mallory")
+
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authService "github.com/taskflow/internal/domain/auth/service"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultRetentionPeriod 软删除数据默认保留时长
+const DefaultRetentionPeriod = 90 * 24 * time.Hour
+
+// RetentionAppService 软删除数据保留策略，物理删除超过保留期的软删除数据
+type RetentionAppService struct {
+	retentionRepo  repository.RetentionRepository
+	transactionMgr authService.TransactionManager
+	retention      time.Duration
+	dryRun         bool
+	now            func() time.Time
+}
+
+// NewRetentionAppService 创建软删除数据保留服务，保留时长非正数时使用默认值
+func NewRetentionAppService(retentionRepo repository.RetentionRepository, transactionMgr authService.TransactionManager, retention time.Duration) *RetentionAppService {
+	if retention <= 0 {
+		retention = DefaultRetentionPeriod
+	}
+	return &RetentionAppService{
+		retentionRepo:  retentionRepo,
+		transactionMgr: transactionMgr,
+		retention:      retention,
+		now:            time.Now,
+	}
+}
+
+// WithDryRun 设置为只统计不删除，用于上线前评估清理范围
+func (s *RetentionAppService) WithDryRun(dryRun bool) *RetentionAppService {
+	s.dryRun = dryRun
+	return s
+}
+
+// PurgeExpired 清理 deleted_at 早于保留期的数据及其依赖行（需要事务）
+func (s *RetentionAppService) PurgeExpired(ctx context.Context) (repository.PurgeCounts, error) {
+	cutoff := s.now().Add(-s.retention)
+
+	var counts repository.PurgeCounts
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		counts, err = s.retentionRepo.PurgeSoftDeleted(ctx, cutoff, s.dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("清理过期软删除数据失败: %w", err)
+	}
+
+	message := "Purged soft-deleted data past retention"
+	if s.dryRun {
+		message = "Dry run: soft-deleted data past retention would be purged"
+	}
+	logger.Info(message,
+		zap.Time("cutoff", cutoff),
+		zap.Int64("total", counts.Total()),
+		zap.Any("counts", counts))
+	return counts, nil
+}
+
+// RunSchedule 启动后立即清理一次，之后按间隔定期清理，直到 ctx 取消；单次失败只记录日志
+func (s *RetentionAppService) RunSchedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PurgeExpired(ctx); err != nil {
+			logger.Error("Retention purge failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/pkg/logger"
+)
+
+// recordingRetentionRepository 记录清理参数的保留策略仓储
+type recordingRetentionRepository struct {
+	before time.Time
+	dryRun bool
+	counts repository.PurgeCounts
+	err    error
+}
+
+func (r *recordingRetentionRepository) PurgeSoftDeleted(ctx context.Context, before time.Time, dryRun bool) (repository.PurgeCounts, error) {
+	r.before = before
+	r.dryRun = dryRun
+	return r.counts, r.err
+}
+
+func newTestRetentionService(t *testing.T, repo repository.RetentionRepository, retention time.Duration) (*RetentionAppService, time.Time) {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewRetentionAppService(repo, passthroughTransactionManager{}, retention)
+	svc.now = func() time.Time { return now }
+	return svc, now
+}
+
+func TestRetentionAppService_PurgeExpired_UsesRetentionCutoff(t *testing.T) {
+	repo := &recordingRetentionRepository{counts: repository.PurgeCounts{"tasks": 2, "task_participants": 3}}
+	svc, now := newTestRetentionService(t, repo, 30*24*time.Hour)
+
+	counts, err := svc.PurgeExpired(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-30*24*time.Hour), repo.before)
+	assert.False(t, repo.dryRun)
+	assert.Equal(t, int64(5), counts.Total())
+}
+
+func TestRetentionAppService_PurgeExpired_DefaultsAndDryRun(t *testing.T) {
+	repo := &recordingRetentionRepository{counts: repository.PurgeCounts{}}
+	svc, now := newTestRetentionService(t, repo, 0)
+	svc.WithDryRun(true)
+
+	_, err := svc.PurgeExpired(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-DefaultRetentionPeriod), repo.before)
+	assert.True(t, repo.dryRun)
+}
+
+func TestRetentionAppService_PurgeExpired_WrapsError(t *testing.T) {
+	cause := errors.New("db down")
+	svc, _ := newTestRetentionService(t, &recordingRetentionRepository{err: cause}, time.Hour)
+
+	_, err := svc.PurgeExpired(context.Background())
+
+	assert.ErrorIs(t, err, cause)
+}
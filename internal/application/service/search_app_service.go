@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// DefaultSearchLimitPerType 未指定 limit 时每种类型最多返回的结果数
+const DefaultSearchLimitPerType = 10
+
+// 搜索相关度：完全匹配 > 前缀匹配 > 包含匹配
+const (
+	searchScoreContains = 1
+	searchScorePrefix   = 2
+	searchScoreExact    = 3
+)
+
+// SearchAppService 全局搜索应用服务，同时搜索用户可访问的任务和项目
+type SearchAppService struct {
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+}
+
+// NewSearchAppService 创建全局搜索应用服务
+func NewSearchAppService(taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository) *SearchAppService {
+	return &SearchAppService{taskRepo: taskRepo, projectRepo: projectRepo}
+}
+
+// Search 按关键字搜索任务标题和项目名称（只读操作，不需要事务）
+// 关键字去掉首尾空白、合并连续空白后不足 valueobject.MinSearchTermLength 个字符时拒绝
+// 非管理员只能搜到自己可访问的任务和项目，每种类型最多返回 limit 条
+func (s *SearchAppService) Search(ctx context.Context, req dto.SearchRequest) (*dto.SearchResponse, error) {
+	query, ok := valueobject.NormalizeSearchTerm(req.Query)
+	if !ok {
+		return nil, event.NewDomainError(event.ErrInvalidInput,
+			fmt.Sprintf("搜索关键字至少需要%d个字符", valueobject.MinSearchTermLength))
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimitPerType
+	}
+
+	var accessibleBy *valueobject.UserID
+	if !req.RequesterIsAdmin {
+		requesterID := valueobject.UserID(req.RequesterID)
+		accessibleBy = &requesterID
+	}
+
+	tasks, _, err := s.taskRepo.SearchTasks(ctx, valueobject.TaskSearchCriteria{
+		Title:        &query,
+		AccessibleBy: accessibleBy,
+		Limit:        limit,
+		OrderBy:      "updated_at",
+		OrderDir:     "desc",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("搜索任务失败: %w", err)
+	}
+	projects, _, err := s.projectRepo.SearchProjects(ctx, aggregate.ProjectSearchCriteria{
+		Name:         &query,
+		AccessibleBy: accessibleBy,
+		Limit:        limit,
+		OrderBy:      "updated_at",
+		OrderDir:     "DESC",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("搜索项目失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	results := make([]dto.SearchResult, 0, len(tasks)+len(projects))
+	for _, task := range tasks {
+		result := dto.SearchResult{
+			Type:      dto.SearchResultTypeTask,
+			ID:        string(task.ID),
+			Title:     task.Title,
+			Status:    string(task.Status),
+			ProjectID: string(task.ProjectID),
+			Score:     searchScore(task.Title, query),
+			UpdatedAt: task.UpdatedAt.In(loc),
+		}
+		if task.Description != nil {
+			result.Description = *task.Description
+		}
+		results = append(results, result)
+	}
+	for _, project := range projects {
+		results = append(results, dto.SearchResult{
+			Type:        dto.SearchResultTypeProject,
+			ID:          string(project.ID),
+			Title:       project.Name,
+			Description: project.Description,
+			Status:      string(project.Status),
+			Score:       searchScore(project.Name, query),
+			UpdatedAt:   project.UpdatedAt.In(loc),
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].UpdatedAt.After(results[j].UpdatedAt)
+	})
+
+	return &dto.SearchResponse{Query: query, Results: results, Total: len(results)}, nil
+}
+
+// searchScore 计算标题与关键字的相关度，忽略大小写
+func searchScore(title, query string) int {
+	title, query = strings.ToLower(title), strings.ToLower(query)
+	switch {
+	case title == query:
+		return searchScoreExact
+	case strings.HasPrefix(title, query):
+		return searchScorePrefix
+	default:
+		return searchScoreContains
+	}
+}
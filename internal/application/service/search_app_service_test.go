@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// newSearchTestService 创建包含用户 user-1 可访问和不可访问数据的搜索服务
+func newSearchTestService() *SearchAppService {
+	now := time.Now()
+	ownTask := approvedTask("task-own", "project-1", time.Hour)
+	ownTask.Title = "Apollo launch checklist"
+	ownTask.ResponsibleID = "user-1"
+	ownTask.UpdatedAt = now
+	hiddenTask := approvedTask("task-hidden", "project-2", time.Hour)
+	hiddenTask.Title = "Apollo budget"
+	hiddenTask.UpdatedAt = now
+
+	ownProject := aggregate.NewProject("project-1", "Apollo", "", valueobject.ProjectTypeMaster, "user-1")
+	ownProject.UpdatedAt = now.Add(-time.Hour)
+	hiddenProject := aggregate.NewProject("project-2", "Apollo Secret", "", valueobject.ProjectTypeMaster, "owner-2")
+
+	return NewSearchAppService(
+		testutil.NewMemoryTaskRepository(ownTask, hiddenTask),
+		testutil.NewMemoryProjectRepository(*ownProject, *hiddenProject),
+	)
+}
+
+func TestSearch_ReturnsMatchingTasksAndProjects(t *testing.T) {
+	svc := newSearchTestService()
+
+	resp, err := svc.Search(context.Background(), dto.SearchRequest{Query: "Apollo", RequesterID: "user-1"})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, dto.SearchResultTypeProject, resp.Results[0].Type, "exact name match ranks first")
+	assert.Equal(t, "project-1", resp.Results[0].ID)
+	assert.Equal(t, dto.SearchResultTypeTask, resp.Results[1].Type)
+	assert.Equal(t, "task-own", resp.Results[1].ID)
+	assert.Equal(t, "project-1", resp.Results[1].ProjectID)
+	assert.Greater(t, resp.Results[0].Score, resp.Results[1].Score)
+}
+
+func TestSearch_ExcludesInaccessibleResults(t *testing.T) {
+	svc := newSearchTestService()
+
+	resp, err := svc.Search(context.Background(), dto.SearchRequest{Query: "Apollo", RequesterID: "user-1"})
+	require.NoError(t, err)
+	for _, result := range resp.Results {
+		assert.NotContains(t, []string{"task-hidden", "project-2"}, result.ID)
+	}
+
+	admin, err := svc.Search(context.Background(), dto.SearchRequest{Query: "Apollo", RequesterID: "admin-1", RequesterIsAdmin: true})
+	require.NoError(t, err)
+	assert.Len(t, admin.Results, 4, "admins search everything")
+
+	outsider, err := svc.Search(context.Background(), dto.SearchRequest{Query: "Apollo", RequesterID: "user-9"})
+	require.NoError(t, err)
+	assert.Empty(t, outsider.Results)
+}
+
+func TestSearch_CapsResultsPerType(t *testing.T) {
+	tasks := make([]aggregate.TaskAggregate, 0, 3)
+	for _, id := range []valueobject.TaskID{"task-1", "task-2", "task-3"} {
+		task := approvedTask(id, "project-1", time.Hour)
+		task.Title = "Report " + string(id)
+		tasks = append(tasks, task)
+	}
+	project := aggregate.NewProject("project-1", "Reporting", "", valueobject.ProjectTypeMaster, "creator-1")
+	svc := NewSearchAppService(testutil.NewMemoryTaskRepository(tasks...), testutil.NewMemoryProjectRepository(*project))
+
+	resp, err := svc.Search(context.Background(), dto.SearchRequest{Query: "Report", Limit: 2, RequesterID: "creator-1"})
+
+	require.NoError(t, err)
+	counts := map[string]int{}
+	for _, result := range resp.Results {
+		counts[result.Type]++
+	}
+	assert.Equal(t, map[string]int{dto.SearchResultTypeTask: 2, dto.SearchResultTypeProject: 1}, counts)
+}
+
+func TestSearch_RejectsTooShortQueryAndNormalizesTerm(t *testing.T) {
+	svc := newSearchTestService()
+
+	for _, query := range []string{"", "   ", " A "} {
+		_, err := svc.Search(context.Background(), dto.SearchRequest{Query: query, RequesterID: "user-1"})
+
+		var domainErr *event.DomainError
+		require.ErrorAs(t, err, &domainErr, "query %q", query)
+		assert.Equal(t, event.ErrInvalidInput, domainErr.Type)
+	}
+
+	resp, err := svc.Search(context.Background(), dto.SearchRequest{Query: "  Apollo   launch ", RequesterID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Apollo launch", resp.Query)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, "task-own", resp.Results[0].ID)
+}
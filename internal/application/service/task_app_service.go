@@ -2,25 +2,64 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/taskflow/internal/application/dto"
 	"github.com/taskflow/internal/domain/aggregate"
 	authService "github.com/taskflow/internal/domain/auth/service"
+	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/repository"
 	"github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
 )
 
+// ErrBulkDeleteNotConfirmed 批量删除缺少或携带了错误的确认令牌
+var ErrBulkDeleteNotConfirmed = errors.New("批量删除需要确认令牌")
+
 // TaskAppService 任务应用服务
 type TaskAppService struct {
 	taskDomainService service.TaskDomainService
 	transactionMgr    authService.TransactionManager
 	taskRepo          repository.TaskRepository
 	taskFactory       *aggregate.TaskFactory
+	eventBus          event.EventBus
+	fileRepo          repository.FileRepository
+	eventStore        event.EventQueryStore
+	staleApprovedAge  time.Duration
+	overrunPercent    float64
 }
 
+// TaskBundleHistoryLimit 任务详情包中事件历史的最大条数
+const TaskBundleHistoryLimit = 200
+
+// DefaultTaskPageSize 未指定 page_size 时每页返回的任务数
+const DefaultTaskPageSize = 20
+
+// MaxTaskPageSize 任务列表每页最多返回的任务数
+const MaxTaskPageSize = 100
+
+// DefaultRecurrencePreviewCount 未指定预览次数时返回的重复执行时间个数
+const DefaultRecurrencePreviewCount = 5
+
+// DefaultStaleApprovedAge 审批通过后超过该时长仍未开始的任务视为停滞
+const DefaultStaleApprovedAge = 72 * time.Hour
+
+// DefaultEstimateOverrunPercent 实际工时超出预估工时的比例大于该百分比时视为超支
+const DefaultEstimateOverrunPercent = 20.0
+
+// DefaultCloneLeadTime 源任务截止时间已过且无法推算原工期时，副本截止时间距复制时刻的时长
+const DefaultCloneLeadTime = 7 * 24 * time.Hour
+
 // NewTaskAppService 创建任务应用服务
 func NewTaskAppService(
 	taskDomainService service.TaskDomainService,
@@ -33,13 +72,57 @@ func NewTaskAppService(
 		transactionMgr:    transactionMgr,
 		taskRepo:          taskRepo,
 		taskFactory:       taskFactory,
+		staleApprovedAge:  DefaultStaleApprovedAge,
+		overrunPercent:    DefaultEstimateOverrunPercent,
 	}
 }
 
-// CreateTask 创建任务（需要事务）
+// WithEventBus 设置事件总线，用于在事务提交后发布任务事件
+func (s *TaskAppService) WithEventBus(bus event.EventBus) *TaskAppService {
+	s.eventBus = bus
+	return s
+}
+
+// WithFileRepository 设置文件仓储，用于校验提交工作时引用的附件
+func (s *TaskAppService) WithFileRepository(fileRepo repository.FileRepository) *TaskAppService {
+	s.fileRepo = fileRepo
+	return s
+}
+
+// WithEventStore 设置事件查询存储，用于在任务详情包中返回事件历史
+func (s *TaskAppService) WithEventStore(store event.EventQueryStore) *TaskAppService {
+	s.eventStore = store
+	return s
+}
+
+// WithStaleApprovedAge 设置已审批未开始报表的默认时长，非正数时使用默认值
+func (s *TaskAppService) WithStaleApprovedAge(age time.Duration) *TaskAppService {
+	if age <= 0 {
+		age = DefaultStaleApprovedAge
+	}
+	s.staleApprovedAge = age
+	return s
+}
+
+// WithEstimateOverrunPercent 设置工时偏差报表的默认超支阈值（百分比），非正数时使用默认值
+func (s *TaskAppService) WithEstimateOverrunPercent(percent float64) *TaskAppService {
+	if percent <= 0 {
+		percent = DefaultEstimateOverrunPercent
+	}
+	s.overrunPercent = percent
+	return s
+}
+
+// CreateTask 创建任务，事务提交后发布创建事件（需要事务）
 func (s *TaskAppService) CreateTask(ctx context.Context, req dto.CreateTaskRequest) (*dto.CreateTaskResponse, error) {
+	var events []event.DomainEvent
 	result, err := s.transactionMgr.WithTransactionResult(ctx, func(ctx context.Context) (interface{}, error) {
-		// 1. 创建任务聚合
+		// 1. 校验目标项目允许创建任务
+		if err := s.taskDomainService.ValidateTaskCreation(ctx, valueobject.ProjectID(req.ProjectID), valueobject.UserID(req.CreatorID)); err != nil {
+			return nil, fmt.Errorf("任务创建校验失败: %w", err)
+		}
+
+		// 2. 创建任务聚合
 		task, err := s.taskFactory.CreateTask(
 			valueobject.TaskID(""), // Generate ID in factory
 			req.Title,
@@ -55,12 +138,24 @@ func (s *TaskAppService) CreateTask(ctx context.Context, req dto.CreateTaskReque
 			return nil, fmt.Errorf("创建任务失败: %w", err)
 		}
 
-		// 2. 保存任务
-		if err := s.taskRepo.Save(ctx, *task); err != nil {
+		task.SetOpenContribution(req.OpenContribution)
+		if len(req.Reviewers) > 0 {
+			reviewers := make([]valueobject.UserID, len(req.Reviewers))
+			for i, id := range req.Reviewers {
+				reviewers[i] = valueobject.UserID(id)
+			}
+			if err := task.SetReviewers(reviewers, req.ReviewQuorum, valueobject.UserID(req.CreatorID)); err != nil {
+				return nil, fmt.Errorf("设置审核人失败: %w", err)
+			}
+		}
+
+		// 3. 保存任务
+		if err := s.taskRepo.Create(ctx, *task); err != nil {
 			return nil, fmt.Errorf("保存任务失败: %w", err)
 		}
+		events = append(events, task.GetEvents()...)
 
-		// 3. 返回结果
+		// 4. 返回结果
 		return &dto.CreateTaskResponse{
 			ID:            string((*task).ID),
 			Title:         (*task).Title,
@@ -80,6 +175,7 @@ func (s *TaskAppService) CreateTask(ctx context.Context, req dto.CreateTaskReque
 	if err != nil {
 		return nil, err
 	}
+	s.publishEvents(events)
 
 	if taskResponse, ok := result.(*dto.CreateTaskResponse); ok {
 		return taskResponse, nil
@@ -88,6 +184,112 @@ func (s *TaskAppService) CreateTask(ctx context.Context, req dto.CreateTaskReque
 	return nil, fmt.Errorf("unexpected result type")
 }
 
+// CloneTask 复制任务（需要事务）
+// 新任务以调用者为创建人并处于草稿状态，复制标题、描述、类型、优先级和预估工时，
+// 可选复制参与者；不复制状态历史、实际工时和完成情况。
+// 未指定截止时间时沿用源任务的截止时间，若其已过则按源任务原工期从当前时间顺延
+func (s *TaskAppService) CloneTask(ctx context.Context, req dto.CloneTaskRequest) (*dto.CreateTaskResponse, error) {
+	var events []event.DomainEvent
+	var response *dto.CreateTaskResponse
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 查找源任务
+		source, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
+		if err != nil {
+			return fmt.Errorf("任务不存在: %w", err)
+		}
+		callerID := valueobject.UserID(req.CallerID)
+		if !req.IsAdmin && !source.CanUserView(callerID) {
+			return event.NewDomainError(event.ErrPermissionDenied, "无权查看源任务")
+		}
+
+		// 2. 校验目标项目允许创建任务
+		projectID := source.ProjectID
+		if req.ProjectID != "" {
+			projectID = valueobject.ProjectID(req.ProjectID)
+		}
+		if err := s.taskDomainService.ValidateTaskCreation(ctx, projectID, callerID); err != nil {
+			return fmt.Errorf("任务创建校验失败: %w", err)
+		}
+
+		// 3. 创建新任务聚合
+		dueDate := cloneDueDate(source, time.Now())
+		if req.DueDate != nil {
+			dueDate = req.DueDate
+		}
+		clone, err := s.taskFactory.CreateTask(
+			valueobject.TaskID(""),
+			source.Title,
+			s.stringPtrToString(source.Description),
+			source.TaskType,
+			source.Priority,
+			projectID,
+			callerID,
+			source.ResponsibleID,
+			dueDate,
+		)
+		if err != nil {
+			return event.NewDomainErrorWithCause(event.ErrInvalidInput, "复制任务失败", err)
+		}
+		if err := clone.SetEstimatedHours(source.EstimatedHours, callerID); err != nil {
+			return fmt.Errorf("设置预估工时失败: %w", err)
+		}
+		clone.SetOpenContribution(source.OpenContribution)
+
+		// 4. 按原角色复制参与者
+		if req.IncludeParticipants {
+			for _, p := range source.Participants {
+				if err := clone.AddParticipantWithRole(p.UserID, p.Role, callerID); err != nil {
+					return fmt.Errorf("复制参与者失败: %w", err)
+				}
+			}
+		}
+
+		// 5. 保存新任务
+		if err := s.taskRepo.Create(ctx, *clone); err != nil {
+			return fmt.Errorf("保存任务失败: %w", err)
+		}
+		events = append(events, clone.GetEvents()...)
+
+		response = &dto.CreateTaskResponse{
+			ID:             string(clone.ID),
+			Title:          clone.Title,
+			Description:    clone.Description,
+			TaskType:       string(clone.TaskType),
+			Priority:       string(clone.Priority),
+			Status:         string(clone.Status),
+			ProjectID:      string(clone.ProjectID),
+			CreatorID:      string(clone.CreatorID),
+			ResponsibleID:  string(clone.ResponsibleID),
+			DueDate:        clone.DueDate,
+			EstimatedHours: clone.EstimatedHours,
+			CreatedAt:      clone.CreatedAt,
+			UpdatedAt:      clone.UpdatedAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishEvents(events)
+	return response, nil
+}
+
+// cloneDueDate 计算副本的默认截止时间
+// 源任务截止时间未过时直接沿用；已过时从 now 起按源任务创建到截止的时长顺延，
+// 源任务缺少创建时间或工期不为正时使用 DefaultCloneLeadTime
+func cloneDueDate(source *aggregate.TaskAggregate, now time.Time) *time.Time {
+	if source.DueDate == nil || source.DueDate.After(now) {
+		return source.DueDate
+	}
+	leadTime := DefaultCloneLeadTime
+	if !source.CreatedAt.IsZero() && source.DueDate.After(source.CreatedAt) {
+		leadTime = source.DueDate.Sub(source.CreatedAt)
+	}
+	shifted := now.Add(leadTime)
+	return &shifted
+}
+
 // GetTask 获取任务（不需要事务）
 func (s *TaskAppService) GetTask(ctx context.Context, id string) (*dto.TaskResponse, error) {
 	task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(id))
@@ -95,81 +297,734 @@ func (s *TaskAppService) GetTask(ctx context.Context, id string) (*dto.TaskRespo
 		return nil, fmt.Errorf("获取任务失败: %w", err)
 	}
 
-	return &dto.TaskResponse{
-		ID:            string(task.ID),
-		Title:         task.Title,
-		Description:   task.Description,
-		TaskType:      string(task.TaskType),
-		Priority:      string(task.Priority),
-		Status:        string(task.Status),
-		ProjectID:     string(task.ProjectID),
-		CreatorID:     string(task.CreatorID),
-		ResponsibleID: string(task.ResponsibleID),
-		DueDate:       task.DueDate,
-		CreatedAt:     task.CreatedAt,
-		UpdatedAt:     task.UpdatedAt,
+	response := buildTaskResponse(task, time.Now(), shared.LocationFromContext(ctx))
+	return &response, nil
+}
+
+// GetStaleApprovedTasks 获取审批通过超过指定时长仍未开始的任务（不需要事务）
+func (s *TaskAppService) GetStaleApprovedTasks(ctx context.Context, req dto.StaleApprovedTasksRequest) (*dto.StaleApprovedTasksResponse, error) {
+	age := s.staleApprovedAge
+	if req.MinAgeHours > 0 {
+		age = time.Duration(req.MinAgeHours) * time.Hour
+	}
+	now := time.Now()
+	approvedBefore := now.Add(-age)
+
+	var projectID *valueobject.ProjectID
+	if req.ProjectID != "" {
+		id := valueobject.ProjectID(req.ProjectID)
+		projectID = &id
+	}
+
+	tasks, err := s.taskRepo.FindApprovedNotStarted(ctx, approvedBefore, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("查询已审批未开始任务失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	result := make([]dto.StaleApprovedTask, len(tasks))
+	for i, task := range tasks {
+		result[i] = dto.StaleApprovedTask{
+			TaskResponse: buildTaskResponse(&tasks[i], now, loc),
+			WaitingHours: now.Sub(*task.ApprovedAt).Hours(),
+		}
+	}
+
+	return &dto.StaleApprovedTasksResponse{
+		Tasks:          result,
+		Total:          len(result),
+		ApprovedBefore: approvedBefore.In(loc),
 	}, nil
 }
 
-// UpdateTask 更新任务（需要事务）
-func (s *TaskAppService) UpdateTask(ctx context.Context, req dto.UpdateTaskRequest) (*dto.UpdateTaskResponse, error) {
-	result, err := s.transactionMgr.WithTransactionResult(ctx, func(ctx context.Context) (interface{}, error) {
-		// 1. 查找任务
-		task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.ID))
+// GetTaskStatusDurations 统计项目内各状态的任务数量及其在当前状态的平均、最长停留时长（不需要事务）
+func (s *TaskAppService) GetTaskStatusDurations(ctx context.Context, req dto.TaskStatusDurationsRequest) (*dto.TaskStatusDurationsResponse, error) {
+	tasks, err := s.taskRepo.FindByProject(ctx, valueobject.ProjectID(req.ProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("查询项目任务失败: %w", err)
+	}
+
+	now := time.Now()
+	byStatus := make(map[string]*dto.TaskStatusDuration)
+	totals := make(map[string]time.Duration)
+	for _, task := range tasks {
+		status := string(task.Status)
+		item, ok := byStatus[status]
+		if !ok {
+			item = &dto.TaskStatusDuration{Status: status}
+			byStatus[status] = item
+		}
+		inStatus := task.TimeInStatus(now)
+		item.TaskCount++
+		totals[status] += inStatus
+		if hours := inStatus.Hours(); hours > item.MaxHours {
+			item.MaxHours = hours
+		}
+	}
+
+	statuses := make([]dto.TaskStatusDuration, 0, len(byStatus))
+	for status, item := range byStatus {
+		item.AverageHours = totals[status].Hours() / float64(item.TaskCount)
+		statuses = append(statuses, *item)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Status < statuses[j].Status })
+
+	return &dto.TaskStatusDurationsResponse{
+		ProjectID: req.ProjectID,
+		Statuses:  statuses,
+		AsOf:      now.In(shared.LocationFromContext(ctx)),
+	}, nil
+}
+
+// GetEstimateVariance 统计同时记录了预估和实际工时的任务的工时偏差，并按项目和负责人汇总（不需要事务）
+func (s *TaskAppService) GetEstimateVariance(ctx context.Context, req dto.EstimateVarianceRequest) (*dto.EstimateVarianceResponse, error) {
+	threshold := s.overrunPercent
+	if req.OverrunThreshold > 0 {
+		threshold = req.OverrunThreshold
+	}
+
+	var projectID *valueobject.ProjectID
+	if req.ProjectID != "" {
+		id := valueobject.ProjectID(req.ProjectID)
+		projectID = &id
+	}
+	var responsibleID *valueobject.UserID
+	if req.ResponsibleID != "" {
+		id := valueobject.UserID(req.ResponsibleID)
+		responsibleID = &id
+	}
+
+	tasks, err := s.taskRepo.FindWithEstimateAndActual(ctx, projectID, responsibleID)
+	if err != nil {
+		return nil, fmt.Errorf("查询任务工时失败: %w", err)
+	}
+
+	response := &dto.EstimateVarianceResponse{
+		OverrunThreshold: threshold,
+		Tasks:            make([]dto.TaskEstimateVariance, 0, len(tasks)),
+	}
+	byProject := make(map[string]*dto.EstimateVarianceGroup)
+	byResponsible := make(map[string]*dto.EstimateVarianceGroup)
+	for i := range tasks {
+		task := &tasks[i]
+		hours, percent, ok := task.EstimateVariance()
+		if !ok {
+			continue
+		}
+		item := dto.TaskEstimateVariance{
+			TaskID:          string(task.ID),
+			Title:           task.Title,
+			ProjectID:       string(task.ProjectID),
+			ResponsibleID:   string(task.ResponsibleID),
+			EstimatedHours:  task.EstimatedHours,
+			ActualHours:     task.ActualHours,
+			VarianceHours:   hours,
+			VariancePercent: percent,
+			Overrun:         percent > threshold,
+		}
+		if item.Overrun {
+			response.OverrunCount++
+		}
+		response.Tasks = append(response.Tasks, item)
+		addEstimateVariance(byProject, item.ProjectID, item)
+		addEstimateVariance(byResponsible, item.ResponsibleID, item)
+	}
+
+	sort.SliceStable(response.Tasks, func(i, j int) bool {
+		return response.Tasks[i].VariancePercent > response.Tasks[j].VariancePercent
+	})
+	response.ByProject = estimateVarianceGroups(byProject)
+	response.ByResponsible = estimateVarianceGroups(byResponsible)
+	return response, nil
+}
+
+// addEstimateVariance 将任务工时累加到对应分组
+func addEstimateVariance(groups map[string]*dto.EstimateVarianceGroup, id string, item dto.TaskEstimateVariance) {
+	group, ok := groups[id]
+	if !ok {
+		group = &dto.EstimateVarianceGroup{ID: id}
+		groups[id] = group
+	}
+	group.TaskCount++
+	if item.Overrun {
+		group.OverrunCount++
+	}
+	group.EstimatedHours += float64(item.EstimatedHours)
+	group.ActualHours += item.ActualHours
+}
+
+// estimateVarianceGroups 计算分组的偏差并按ID排序
+func estimateVarianceGroups(groups map[string]*dto.EstimateVarianceGroup) []dto.EstimateVarianceGroup {
+	result := make([]dto.EstimateVarianceGroup, 0, len(groups))
+	for _, group := range groups {
+		group.VarianceHours = group.ActualHours - group.EstimatedHours
+		group.VariancePercent = group.VarianceHours / group.EstimatedHours * 100
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// RequestExtension 申请延期（需要事务）
+// 同一任务已有待审批的延期申请时拒绝；先锁定任务行再检查，并发申请串行执行，只有一个能成功
+// 事务提交后发布延期申请事件
+func (s *TaskAppService) RequestExtension(ctx context.Context, req dto.RequestExtensionRequest) (*dto.ExtensionRequestResponse, error) {
+	var events []event.DomainEvent
+	var response *dto.ExtensionRequestResponse
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 锁定并查找任务
+		task, err := s.taskRepo.FindByIDForUpdate(ctx, valueobject.TaskID(req.TaskID))
 		if err != nil {
-			return nil, fmt.Errorf("任务不存在: %w", err)
+			return fmt.Errorf("任务不存在: %w", err)
+		}
+
+		// 2. 申请延期
+		if _, err := task.RequestExtension(valueobject.UserID(req.RequesterID), req.NewDueDate, req.Reason); err != nil {
+			return fmt.Errorf("申请延期失败: %w", err)
+		}
+
+		// 3. 保存更新
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return fmt.Errorf("保存任务失败: %w", err)
 		}
+		events = append(events, task.GetEvents()...)
 
-		// 2. 更新任务信息
-		title := task.Title
-		if req.Title != nil {
-			title = *req.Title
+		resp := buildExtensionResponse(*task.GetPendingExtension(), shared.LocationFromContext(ctx))
+		response = &resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishEvents(events)
+	return response, nil
+}
+
+// ApproveExtension 批准延期申请（需要事务），任务截止日期改为申请的日期
+// 事务提交后发布延期批准和截止日期变更事件
+func (s *TaskAppService) ApproveExtension(ctx context.Context, req dto.ApproveExtensionRequest) (*dto.ExtensionRequestResponse, error) {
+	return s.resolveExtension(ctx, req.TaskID, req.ExtensionID, func(task *aggregate.TaskAggregate, extensionID valueobject.ExtensionRequestID) error {
+		if err := task.ApproveExtension(extensionID, valueobject.UserID(req.ApproverID)); err != nil {
+			return fmt.Errorf("批准延期失败: %w", err)
 		}
-		description := s.stringPtrToString(task.Description)
-		if req.Description != nil {
-			description = *req.Description
+		return nil
+	})
+}
+
+// RejectExtension 拒绝延期申请（需要事务），任务截止日期保持不变
+// 事务提交后发布延期拒绝事件
+func (s *TaskAppService) RejectExtension(ctx context.Context, req dto.RejectExtensionRequest) (*dto.ExtensionRequestResponse, error) {
+	return s.resolveExtension(ctx, req.TaskID, req.ExtensionID, func(task *aggregate.TaskAggregate, extensionID valueobject.ExtensionRequestID) error {
+		if err := task.RejectExtension(extensionID, valueobject.UserID(req.RejectorID), req.Comment); err != nil {
+			return fmt.Errorf("拒绝延期失败: %w", err)
 		}
-		if err := task.UpdateBasicInfo(title, description); err != nil {
-			return nil, fmt.Errorf("更新任务信息失败: %w", err)
+		return nil
+	})
+}
+
+// resolveExtension 在锁定任务行的事务内审批延期申请，事务提交后再发布聚合产生的事件
+func (s *TaskAppService) resolveExtension(ctx context.Context, taskID, extensionID string, decide func(*aggregate.TaskAggregate, valueobject.ExtensionRequestID) error) (*dto.ExtensionRequestResponse, error) {
+	var events []event.DomainEvent
+	var response *dto.ExtensionRequestResponse
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 查找并锁定任务，避免并发审批同一申请
+		task, err := s.taskRepo.FindByIDForUpdate(ctx, valueobject.TaskID(taskID))
+		if err != nil {
+			return fmt.Errorf("任务不存在: %w", err)
+		}
+
+		// 2. 审批延期
+		id := valueobject.ExtensionRequestID(extensionID)
+		if err := decide(task, id); err != nil {
+			return err
 		}
 
 		// 3. 保存更新
-		if err := s.taskRepo.Save(ctx, *task); err != nil {
-			return nil, fmt.Errorf("保存任务失败: %w", err)
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return fmt.Errorf("保存任务失败: %w", err)
+		}
+		events = append(events, task.GetEvents()...)
+
+		for _, ext := range task.Extensions {
+			if ext.ID == id {
+				resp := buildExtensionResponse(ext, shared.LocationFromContext(ctx))
+				response = &resp
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishEvents(events)
+	return response, nil
+}
+
+// GetTaskExtensions 获取任务的延期申请历史（不需要事务），仅任务可见用户和管理员可以查看
+func (s *TaskAppService) GetTaskExtensions(ctx context.Context, taskID, viewerID string, isAdmin bool) ([]dto.ExtensionRequestResponse, error) {
+	task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("任务不存在: %w", err)
+	}
+	if !isAdmin && !task.CanUserView(valueobject.UserID(viewerID)) {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, "无权查看该任务的延期申请")
+	}
+
+	extensions, err := s.taskRepo.FindExtensionsByTask(ctx, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取延期申请失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	responses := make([]dto.ExtensionRequestResponse, len(extensions))
+	for i, ext := range extensions {
+		responses[i] = buildExtensionResponse(ext, loc)
+	}
+
+	return responses, nil
+}
+
+// buildTaskResponse 转换为任务响应，参与者始终输出数组
+func buildTaskResponse(task *aggregate.TaskAggregate, now time.Time, loc *time.Location) dto.TaskResponse {
+	participants := make([]dto.TaskParticipantDTO, len(task.Participants))
+	for i, p := range task.Participants {
+		participants[i] = dto.TaskParticipantDTO{
+			UserID:  string(p.UserID),
+			Role:    string(p.Role),
+			AddedAt: p.AddedAt,
+			AddedBy: string(p.AddedBy),
+		}
+	}
+
+	response := dto.TaskResponse{
+		ID:                string(task.ID),
+		Title:             task.Title,
+		Description:       task.Description,
+		TaskType:          string(task.TaskType),
+		Priority:          string(task.Priority),
+		Status:            string(task.Status),
+		ProjectID:         string(task.ProjectID),
+		CreatorID:         string(task.CreatorID),
+		ResponsibleID:     string(task.ResponsibleID),
+		DueDate:           task.DueDate,
+		ApprovedAt:        task.ApprovedAt,
+		StatusChangedAt:   task.StatusChangedAt,
+		TimeInStatusHours: task.TimeInStatus(now).Hours(),
+		EstimatedHours:    task.EstimatedHours,
+		ActualHours:       task.ActualHours,
+		Participants:      participants,
+		Tags:              append([]string{}, task.Tags...),
+		CreatedAt:         task.CreatedAt,
+		UpdatedAt:         task.UpdatedAt,
+		UpdatedBy:         string(task.UpdatedBy),
+	}
+	for _, id := range task.CoResponsibleIDs {
+		response.CoResponsibleIDs = append(response.CoResponsibleIDs, string(id))
+	}
+	if rule := task.RecurrenceRule; rule != nil {
+		response.RecurrenceRule = &dto.RecurrenceRuleDTO{
+			Frequency:     string(rule.Frequency),
+			IntervalValue: rule.IntervalValue,
+			EndDate:       rule.EndDate,
+			MaxExecutions: rule.MaxExecutions,
+		}
+	}
+	response.Localize(loc)
+	return response
+}
+
+// buildExtensionResponse 构建延期申请响应
+func buildExtensionResponse(ext valueobject.ExtensionRequest, loc *time.Location) dto.ExtensionRequestResponse {
+	response := dto.ExtensionRequestResponse{
+		ID:               string(ext.ID),
+		TaskID:           string(ext.TaskID),
+		RequesterID:      string(ext.RequesterID),
+		OriginalDueDate:  ext.OriginalDueDate,
+		RequestedDueDate: ext.RequestedDueDate,
+		Reason:           ext.Reason,
+		Status:           string(ext.Status),
+		RequestedAt:      ext.RequestedAt,
+		ReviewedAt:       ext.ReviewedAt,
+		ReviewComment:    ext.ReviewComment,
+	}
+	if ext.ReviewerID != nil {
+		reviewerID := string(*ext.ReviewerID)
+		response.ReviewerID = &reviewerID
+	}
+	response.Localize(loc)
+	return response
+}
+
+// GetWorkSubmissions 获取任务的工作提交记录，按提交时间倒序（不需要事务）
+// 仅任务可见用户（创建者、负责人、参与者）和管理员可以查看
+func (s *TaskAppService) GetWorkSubmissions(ctx context.Context, taskID, viewerID string, isAdmin bool) ([]dto.WorkSubmissionResponse, error) {
+	task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("任务不存在: %w", err)
+	}
+	if !isAdmin && !task.CanUserView(valueobject.UserID(viewerID)) {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, "无权查看该任务的工作提交")
+	}
+
+	submissions, err := s.taskRepo.FindWorkSubmissionsByTask(ctx, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("获取工作提交失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	responses := make([]dto.WorkSubmissionResponse, len(submissions))
+	for i, submission := range submissions {
+		responses[i] = buildWorkSubmissionResponse(submission, loc)
+	}
+	return responses, nil
+}
+
+// GetTaskBundle 获取任务详情包，并行加载工作提交、延期申请和事件历史（不需要事务）
+// 仅任务可见用户和管理员可以获取；事件历史仅管理员可见，最多返回最新的 TaskBundleHistoryLimit 条
+func (s *TaskAppService) GetTaskBundle(ctx context.Context, taskID, viewerID string, isAdmin bool) (*dto.TaskBundleResponse, error) {
+	task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("任务不存在: %w", err)
+	}
+	if !isAdmin && !task.CanUserView(valueobject.UserID(viewerID)) {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, "无权查看该任务")
+	}
+
+	var (
+		wg                                   sync.WaitGroup
+		submissions                          []valueobject.WorkSubmission
+		extensions                           []valueobject.ExtensionRequest
+		history                              []event.DomainEvent
+		submissionErr, extensionErr, histErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		submissions, submissionErr = s.taskRepo.FindWorkSubmissionsByTask(ctx, task.ID)
+	}()
+	go func() {
+		defer wg.Done()
+		extensions, extensionErr = s.taskRepo.FindExtensionsByTask(ctx, task.ID)
+	}()
+	if isAdmin && s.eventStore != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 倒序取最新的事件，多查一条用于判断是否截断
+			history, histErr = s.eventStore.FindEvents(ctx, event.EventQuery{
+				AggregateID: string(task.ID),
+				Limit:       TaskBundleHistoryLimit + 1,
+				Descending:  true,
+			})
+		}()
+	}
+	wg.Wait()
+
+	if submissionErr != nil {
+		return nil, fmt.Errorf("获取工作提交失败: %w", submissionErr)
+	}
+	if extensionErr != nil {
+		return nil, fmt.Errorf("获取延期申请失败: %w", extensionErr)
+	}
+	if histErr != nil {
+		return nil, fmt.Errorf("获取任务事件历史失败: %w", histErr)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	bundle := &dto.TaskBundleResponse{
+		Task:            buildTaskResponse(task, time.Now(), loc),
+		WorkSubmissions: make([]dto.WorkSubmissionResponse, len(submissions)),
+		Extensions:      make([]dto.ExtensionRequestResponse, len(extensions)),
+	}
+	for i, submission := range submissions {
+		bundle.WorkSubmissions[i] = buildWorkSubmissionResponse(submission, loc)
+	}
+	for i, ext := range extensions {
+		bundle.Extensions[i] = buildExtensionResponse(ext, loc)
+	}
+	if len(history) > TaskBundleHistoryLimit {
+		history, bundle.HistoryTruncated = history[:TaskBundleHistoryLimit], true
+	}
+	// 按发生时间升序返回
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	for _, e := range history {
+		item, err := NewEventResponse(e, loc)
+		if err != nil {
+			return nil, err
+		}
+		bundle.History = append(bundle.History, item)
+	}
+	return bundle, nil
+}
+
+// GetActionItems 获取用户需要处理的事项：负责的进行中任务、待其审核的工作提交和待其审批的延期申请（不需要事务）
+// 按任务截止时间分页，每页最多 MaxTaskPageSize 个任务
+func (s *TaskAppService) GetActionItems(ctx context.Context, req dto.ActionItemsRequest) (*dto.ActionItemsResponse, error) {
+	page, pageSize := normalizeTaskPage(req.Page, req.PageSize)
+	uid := valueobject.UserID(req.UserID)
+	tasks, totalTasks, err := s.taskRepo.FindActionRequired(ctx, uid, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("查询待处理任务失败: %w", err)
+	}
+
+	now := time.Now()
+	loc := shared.LocationFromContext(ctx)
+	response := &dto.ActionItemsResponse{
+		InProgress:         []dto.TaskResponse{},
+		WorkReviews:        []dto.WorkReviewItem{},
+		ExtensionDecisions: []dto.ExtensionDecisionItem{},
+		TotalTasks:         totalTasks,
+		Page:               page,
+		PageSize:           pageSize,
+	}
+	for i := range tasks {
+		task := &tasks[i]
+		if task.IsResponsible(uid) && task.Status == valueobject.TaskStatusInProgress {
+			response.InProgress = append(response.InProgress, buildTaskResponse(task, now, loc))
+		}
+		for _, submission := range task.SubmissionsAwaitingReview(uid) {
+			response.WorkReviews = append(response.WorkReviews, dto.WorkReviewItem{
+				Task:       buildTaskResponse(task, now, loc),
+				Submission: buildWorkSubmissionResponse(submission, loc),
+			})
+		}
+		if ext := task.GetPendingExtension(); ext != nil && task.CanUserApprove(uid) {
+			response.ExtensionDecisions = append(response.ExtensionDecisions, dto.ExtensionDecisionItem{
+				Task:      buildTaskResponse(task, now, loc),
+				Extension: buildExtensionResponse(*ext, loc),
+			})
+		}
+	}
+
+	sort.SliceStable(response.WorkReviews, func(i, j int) bool {
+		return response.WorkReviews[i].Submission.SubmittedAt.Before(response.WorkReviews[j].Submission.SubmittedAt)
+	})
+	sort.SliceStable(response.ExtensionDecisions, func(i, j int) bool {
+		return response.ExtensionDecisions[i].Extension.RequestedAt.Before(response.ExtensionDecisions[j].Extension.RequestedAt)
+	})
+	response.Total = len(response.InProgress) + len(response.WorkReviews) + len(response.ExtensionDecisions)
+	return response, nil
+}
+
+// buildWorkSubmissionResponse 构建工作提交响应
+func buildWorkSubmissionResponse(submission valueobject.WorkSubmission, loc *time.Location) dto.WorkSubmissionResponse {
+	attachments := submission.Attachments
+	if attachments == nil {
+		attachments = []string{}
+	}
+	response := dto.WorkSubmissionResponse{
+		ID:            string(submission.ID),
+		TaskID:        string(submission.TaskID),
+		AuthorID:      string(submission.SubmitterID),
+		Content:       submission.Content,
+		Attachments:   attachments,
+		ReviewStatus:  string(submission.Status),
+		SubmittedAt:   submission.SubmittedAt,
+		ReviewedAt:    submission.ReviewedAt,
+		ReviewComment: submission.ReviewComment,
+	}
+	if submission.ReviewerID != nil {
+		reviewerID := string(*submission.ReviewerID)
+		response.ReviewerID = &reviewerID
+	}
+	response.Localize(loc)
+	return response
+}
+
+// UpdateTask 更新任务基本信息、优先级、预估工时和日期（需要事务），未提供的字段保持不变
+// 仅任务创建者和负责人可以更新；日期变更经由 UpdateSchedule 校验，事务提交后发布优先级和截止日期变更事件
+func (s *TaskAppService) UpdateTask(ctx context.Context, req dto.UpdateTaskRequest) (*dto.UpdateTaskResponse, error) {
+	var events []event.DomainEvent
+	var response *dto.UpdateTaskResponse
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 查找任务并校验权限
+		task, err := s.findTaskWithLimits(ctx, valueobject.TaskID(req.ID))
+		if err != nil {
+			return fmt.Errorf("任务不存在: %w", err)
+		}
+		updatedBy := valueobject.UserID(req.UpdatedBy)
+		if !task.CanUserModify(updatedBy) {
+			return event.NewDomainError(event.ErrPermissionDenied, "无权更新该任务")
+		}
+
+		// 2. 更新基本信息
+		if req.Title != nil || req.Description != nil {
+			title := task.Title
+			if req.Title != nil {
+				title = *req.Title
+			}
+			description := s.stringPtrToString(task.Description)
+			if req.Description != nil {
+				description = *req.Description
+			}
+			if err := task.UpdateBasicInfo(title, description); err != nil {
+				return fmt.Errorf("更新任务信息失败: %w", err)
+			}
+		}
+
+		// 3. 更新优先级和预估工时
+		if req.Priority != nil && valueobject.TaskPriority(*req.Priority) != task.Priority {
+			if err := task.ChangePriority(valueobject.TaskPriority(*req.Priority), updatedBy); err != nil {
+				return fmt.Errorf("变更优先级失败: %w", err)
+			}
 		}
+		if req.EstimatedHours != nil {
+			if err := task.SetEstimatedHours(*req.EstimatedHours, updatedBy); err != nil {
+				return fmt.Errorf("设置预估工时失败: %w", err)
+			}
+		}
+
+		// 4. 更新日期
+		if req.StartDate != nil || req.DueDate != nil {
+			startDate, dueDate := task.StartDate, task.DueDate
+			if req.StartDate != nil {
+				startDate = req.StartDate
+			}
+			if req.DueDate != nil {
+				dueDate = req.DueDate
+			}
+			if err := task.UpdateSchedule(startDate, dueDate, updatedBy); err != nil {
+				return fmt.Errorf("更新任务日期失败: %w", err)
+			}
+		}
+
+		// 5. 保存更新
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return fmt.Errorf("保存任务失败: %w", err)
+		}
+		events = append(events, task.GetEvents()...)
 
-		// 4. 返回更新后的任务
-		return &dto.UpdateTaskResponse{
-			ID:            string(task.ID),
-			Title:         task.Title,
-			Description:   task.Description,
-			TaskType:      string(task.TaskType),
-			Priority:      string(task.Priority),
-			Status:        string(task.Status),
-			ProjectID:     string(task.ProjectID),
-			CreatorID:     string(task.CreatorID),
-			ResponsibleID: string(task.ResponsibleID),
-			DueDate:       task.DueDate,
+		response = &dto.UpdateTaskResponse{
+			ID:             string(task.ID),
+			Title:          task.Title,
+			Description:    task.Description,
+			TaskType:       string(task.TaskType),
+			Priority:       string(task.Priority),
+			Status:         string(task.Status),
+			ProjectID:      string(task.ProjectID),
+			CreatorID:      string(task.CreatorID),
+			ResponsibleID:  string(task.ResponsibleID),
+			StartDate:      task.StartDate,
+			DueDate:        task.DueDate,
 			EstimatedHours: task.EstimatedHours,
-			CreatedAt:     task.CreatedAt,
-			UpdatedAt:     task.UpdatedAt,
-		}, nil
+			CreatedAt:      task.CreatedAt,
+			UpdatedAt:      task.UpdatedAt,
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
+	s.publishEvents(events)
+	return response, nil
+}
+
+// ChangeTaskType 变更任务类型（需要事务）
+// 变更为重复任务时需提供重复规则，已完成或已取消的任务不能变更为重复任务
+func (s *TaskAppService) ChangeTaskType(ctx context.Context, req dto.ChangeTaskTypeRequest) (*dto.TaskResponse, error) {
+	var rule *valueobject.RecurrenceRule
+	if req.RecurrenceRule != nil {
+		rule = &valueobject.RecurrenceRule{
+			Frequency:     valueobject.RecurrenceFrequency(req.RecurrenceRule.Frequency),
+			IntervalValue: req.RecurrenceRule.IntervalValue,
+			EndDate:       req.RecurrenceRule.EndDate,
+			MaxExecutions: req.RecurrenceRule.MaxExecutions,
+		}
+	}
+
+	var events []event.DomainEvent
+	var task *aggregate.TaskAggregate
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 查找任务
+		var err error
+		task, err = s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
+		if err != nil {
+			return fmt.Errorf("任务不存在: %w", err)
+		}
+
+		// 2. 变更任务类型
+		if err := task.ChangeType(valueobject.TaskType(req.TaskType), rule, valueobject.UserID(req.ChangedBy)); err != nil {
+			return fmt.Errorf("变更任务类型失败: %w", err)
+		}
+
+		// 3. 保存更新
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return fmt.Errorf("保存任务失败: %w", err)
+		}
+		events = append(events, task.GetEvents()...)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if updateResponse, ok := result.(*dto.UpdateTaskResponse); ok {
-		return updateResponse, nil
+	s.publishEvents(events)
+	response := buildTaskResponse(task, time.Now(), shared.LocationFromContext(ctx))
+	return &response, nil
+}
+
+// PreviewRecurrence 预览重复任务接下来的执行时间，不修改任务（不需要事务）
+// 请求未提供重复规则或锚点时使用任务当前的规则和锚点；仅任务可见用户和管理员可以预览
+func (s *TaskAppService) PreviewRecurrence(ctx context.Context, req dto.PreviewRecurrenceRequest) (*dto.RecurrencePreviewResponse, error) {
+	task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
+	if err != nil {
+		return nil, fmt.Errorf("任务不存在: %w", err)
+	}
+	if !req.IsAdmin && !task.CanUserView(valueobject.UserID(req.ViewerID)) {
+		return nil, event.NewDomainError(event.ErrPermissionDenied, "无权查看该任务")
 	}
 
-	return nil, fmt.Errorf("unexpected result type")
+	rule := task.RecurrenceRule
+	if req.RecurrenceRule != nil {
+		rule = &valueobject.RecurrenceRule{
+			Frequency:     valueobject.RecurrenceFrequency(req.RecurrenceRule.Frequency),
+			IntervalValue: req.RecurrenceRule.IntervalValue,
+			EndDate:       req.RecurrenceRule.EndDate,
+			MaxExecutions: req.RecurrenceRule.MaxExecutions,
+		}
+	}
+	if rule == nil {
+		return nil, aggregate.ErrRecurrenceRuleRequired
+	}
+	if !rule.IsValid() {
+		return nil, aggregate.ErrInvalidRecurrenceRule
+	}
+
+	anchor, ok := task.RecurrenceAnchor()
+	if req.AnchorDate != nil {
+		anchor, ok = *req.AnchorDate, true
+	}
+	if !ok {
+		return nil, aggregate.ErrRecurrenceNeedsAnchor
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = DefaultRecurrencePreviewCount
+	}
+	response := &dto.RecurrencePreviewResponse{
+		AnchorDate: anchor,
+		Executions: rule.Occurrences(anchor, time.Now(), count),
+	}
+	response.Localize(shared.LocationFromContext(ctx))
+	return response, nil
 }
 
-// AssignTask 分配任务（需要事务）
+// AssignTask 分配任务负责人和共同负责人，事务提交后发布分配事件（需要事务）
 func (s *TaskAppService) AssignTask(ctx context.Context, req dto.AssignTaskRequest) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+	coResponsibles := make([]valueobject.UserID, len(req.CoResponsibleIDs))
+	for i, id := range req.CoResponsibleIDs {
+		coResponsibles[i] = valueobject.UserID(id)
+	}
+
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找任务
 		task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
 		if err != nil {
@@ -177,45 +1032,257 @@ func (s *TaskAppService) AssignTask(ctx context.Context, req dto.AssignTaskReque
 		}
 
 		// 2. 分配负责人
-		if err := task.AssignResponsible(
+		if err := task.AssignResponsibles(
 			valueobject.UserID(req.ResponsibleID),
+			coResponsibles,
 			valueobject.UserID(req.AssignedBy),
 		); err != nil {
 			return fmt.Errorf("分配任务失败: %w", err)
 		}
 
 		// 3. 保存更新
-		if err := s.taskRepo.Save(ctx, *task); err != nil {
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
 			return fmt.Errorf("保存任务失败: %w", err)
 		}
-
+		events = append(events, task.GetEvents()...)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
 }
 
-// DeleteTask 删除任务（需要事务）
-func (s *TaskAppService) DeleteTask(ctx context.Context, taskID valueobject.TaskID) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+// DeleteTask 软删除单个任务（需要事务）
+// 仅任务创建者和负责人可以删除，处于流转中的任务需要指定 force；事务提交后发布 TaskDeleted 事件
+func (s *TaskAppService) DeleteTask(ctx context.Context, req dto.DeleteTaskRequest) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 验证任务存在
-		_, err := s.taskRepo.FindByID(ctx, taskID)
+		task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
 		if err != nil {
 			return fmt.Errorf("任务不存在: %w", err)
 		}
 
-		// 2. 删除任务
-		if err := s.taskRepo.Delete(ctx, taskID); err != nil {
+		// 2. 校验权限和状态
+		if err := task.Delete(valueobject.UserID(req.DeletedBy), req.Force); err != nil {
 			return fmt.Errorf("删除任务失败: %w", err)
 		}
 
+		// 3. 删除任务
+		if err := s.taskRepo.Delete(ctx, task.ID); err != nil {
+			return fmt.Errorf("删除任务失败: %w", err)
+		}
+
+		events = append(events, task.GetEvents()...)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
+}
+
+// BulkDeleteConfirmationToken 计算批量删除的确认令牌，与任务ID顺序和重复无关
+func BulkDeleteConfirmationToken(taskIDs []string) string {
+	ids := uniqueStrings(taskIDs)
+	sort.Strings(ids)
+	sum := sha256.Sum256([]byte("bulk-delete:" + strings.Join(ids, ",")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// BulkDeleteTasks 批量软删除任务（需要事务）
+// 不存在或无权限的任务在结果中单独标记；任一任务处于流转中且未指定force时整批拒绝
+func (s *TaskAppService) BulkDeleteTasks(ctx context.Context, req dto.BulkDeleteTasksRequest) (*dto.BulkDeleteTasksResponse, error) {
+	taskIDs := uniqueStrings(req.TaskIDs)
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("任务ID列表不能为空")
+	}
+	if req.ConfirmationToken != BulkDeleteConfirmationToken(taskIDs) {
+		return nil, ErrBulkDeleteNotConfirmed
+	}
+
+	response := &dto.BulkDeleteTasksResponse{Results: make([]dto.BulkDeleteTaskResult, 0, len(taskIDs))}
+	var deletedEvents []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		var toDelete []valueobject.TaskID
+		var blocked []string
+		for _, id := range taskIDs {
+			result := dto.BulkDeleteTaskResult{TaskID: id}
+
+			// 1. 查找任务
+			task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(id))
+			if err != nil {
+				if !errors.Is(err, repository.ErrNotFound) {
+					return fmt.Errorf("查询任务失败: %w", err)
+				}
+				result.Status = dto.BulkDeleteStatusNotFound
+				result.Error = err.Error()
+				response.Results = append(response.Results, result)
+				continue
+			}
+
+			// 2. 校验权限和状态
+			if err := task.Delete(valueobject.UserID(req.DeletedBy), req.Force); err != nil {
+				if errors.Is(err, aggregate.ErrTaskDeleteRequiresForce) {
+					blocked = append(blocked, id)
+					continue
+				}
+				result.Status = dto.BulkDeleteStatusForbidden
+				result.Error = err.Error()
+				response.Results = append(response.Results, result)
+				continue
+			}
+
+			result.Status = dto.BulkDeleteStatusDeleted
+			response.Results = append(response.Results, result)
+			toDelete = append(toDelete, task.ID)
+			deletedEvents = append(deletedEvents, task.GetEvents()...)
+		}
+
+		if len(blocked) > 0 {
+			return fmt.Errorf("任务 %s 处于流转中，需要强制删除: %w", strings.Join(blocked, ", "), aggregate.ErrTaskDeleteRequiresForce)
+		}
+
+		// 3. 批量软删除
+		if len(toDelete) > 0 {
+			if err := s.taskRepo.BatchDelete(ctx, toDelete); err != nil {
+				return fmt.Errorf("删除任务失败: %w", err)
+			}
+		}
+		response.Deleted = len(toDelete)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishEvents(deletedEvents)
+	return response, nil
+}
+
+// BulkTagTasks 在一个事务中为多个任务添加和移除标签，返回每个任务的处理结果
+// 标签为空、超长、重复或同时添加和移除时整批拒绝；不存在的任务记为 not_found，
+// 请求者无权修改的任务记为 forbidden，均不影响其他任务
+func (s *TaskAppService) BulkTagTasks(ctx context.Context, req dto.BulkTagTasksRequest) (*dto.BulkTagTasksResponse, error) {
+	taskIDs := uniqueStrings(req.TaskIDs)
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("任务ID列表不能为空")
+	}
+	if len(req.AddTags) == 0 && len(req.RemoveTags) == 0 {
+		return nil, fmt.Errorf("未指定要添加或移除的标签: %w", aggregate.ErrInvalidTag)
+	}
+	// 先按空任务校验一次，格式错误时不进入事务
+	if _, err := (&aggregate.TaskAggregate{}).UpdateTags(req.AddTags, req.RemoveTags); err != nil {
+		return nil, err
+	}
+
+	response := &dto.BulkTagTasksResponse{Results: make([]dto.BulkTagTaskResult, 0, len(taskIDs))}
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range taskIDs {
+			result := dto.BulkTagTaskResult{TaskID: id}
+
+			task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(id))
+			if err != nil {
+				if !errors.Is(err, repository.ErrNotFound) {
+					return fmt.Errorf("查询任务失败: %w", err)
+				}
+				result.Status = dto.BulkTagStatusNotFound
+				result.Error = err.Error()
+				response.Results = append(response.Results, result)
+				continue
+			}
+
+			if !req.RequesterIsAdmin && !s.taskDomainService.CanUserManageTask(valueobject.UserID(req.TaggedBy), *task) {
+				result.Status = dto.BulkTagStatusForbidden
+				result.Error = "user does not have permission to tag this task"
+				response.Results = append(response.Results, result)
+				continue
+			}
+
+			changed, err := task.UpdateTags(req.AddTags, req.RemoveTags)
+			if err != nil {
+				return err
+			}
+			result.Status = dto.BulkTagStatusUnchanged
+			if changed {
+				if err := s.taskRepo.Update(ctx, *task); err != nil {
+					return fmt.Errorf("保存任务 %s 失败: %w", id, err)
+				}
+				result.Status = dto.BulkTagStatusUpdated
+				response.Updated++
+			}
+			result.Tags = append([]string{}, task.Tags...)
+			response.Results = append(response.Results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// publishEvents 发布事件，发布失败只记录日志
+func (s *TaskAppService) publishEvents(events []event.DomainEvent) {
+	if s.eventBus == nil {
+		return
+	}
+	for _, e := range events {
+		if err := s.eventBus.Publish(e); err != nil {
+			logger.Warn("Failed to publish task event",
+				zap.String("event_type", e.EventType()),
+				zap.String("task_id", e.AggregateID()),
+				zap.Error(err))
+		}
+	}
+}
+
+// uniqueStrings 去除空值和重复值，保持原有顺序
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// normalizeTaskPage 规范化任务分页参数：页码从1开始，页大小默认 DefaultTaskPageSize，最大 MaxTaskPageSize
+func normalizeTaskPage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultTaskPageSize
+	}
+	if pageSize > MaxTaskPageSize {
+		pageSize = MaxTaskPageSize
+	}
+	return page, pageSize
 }
 
-// ListTasks 获取任务列表
+// ListTasks 分页获取任务列表（只读操作，不需要事务），非管理员只能看到自己创建、负责或参与的任务
 func (s *TaskAppService) ListTasks(ctx context.Context, req dto.ListTasksRequest) (*dto.ListTasksResponse, error) {
+	page, pageSize := normalizeTaskPage(req.Page, req.PageSize)
+
 	// 转换搜索条件
 	criteria := s.convertSearchCriteria(req.Criteria)
-	
+	criteria.Limit = pageSize
+	criteria.Offset = (page - 1) * pageSize
+	if !req.RequesterIsAdmin {
+		requesterID := valueobject.UserID(req.RequesterID)
+		criteria.AccessibleBy = &requesterID
+	}
+
 	// 查询任务
 	tasks, total, err := s.taskRepo.SearchTasks(ctx, criteria)
 	if err != nil {
@@ -223,54 +1290,26 @@ func (s *TaskAppService) ListTasks(ctx context.Context, req dto.ListTasksRequest
 	}
 
 	// 转换为响应DTO
+	now := time.Now()
+	loc := shared.LocationFromContext(ctx)
 	taskResponses := make([]dto.TaskResponse, len(tasks))
-	for i, task := range tasks {
-		participants := make([]dto.TaskParticipantDTO, len(task.Participants))
-		for j, p := range task.Participants {
-			participants[j] = dto.TaskParticipantDTO{
-				UserID:  string(p.UserID),
-				Role:    string(p.Role),
-				AddedAt: p.AddedAt,
-				AddedBy: string(p.AddedBy),
-			}
-		}
-
-		taskResponses[i] = dto.TaskResponse{
-			ID:            string(task.ID),
-			Title:         task.Title,
-			Description:   task.Description,
-			TaskType:      string(task.TaskType),
-			Priority:      string(task.Priority),
-			Status:        string(task.Status),
-			ProjectID:     string(task.ProjectID),
-			CreatorID:     string(task.CreatorID),
-			ResponsibleID: string(task.ResponsibleID),
-			DueDate:       task.DueDate,
-			EstimatedHours: task.EstimatedHours,
-			ActualHours:   task.ActualHours,
-			Participants:  participants,
-			CreatedAt:     task.CreatedAt,
-			UpdatedAt:     task.UpdatedAt,
-		}
+	for i := range tasks {
+		taskResponses[i] = buildTaskResponse(&tasks[i], now, loc)
 	}
 
-	// 计算总页数
-	totalPages := int((int64(total) + int64(req.PageSize) - 1) / int64(req.PageSize))
-
-	response := &dto.ListTasksResponse{
+	return &dto.ListTasksResponse{
 		Tasks:      taskResponses,
 		Total:      int64(total),
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		TotalPages: totalPages,
-	}
-
-	return response, nil
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + pageSize - 1) / pageSize,
+	}, nil
 }
 
-// UpdateTaskStatus 更新任务状态（需要事务）
+// UpdateTaskStatus 更新任务状态，事务提交后发布状态变更事件（需要事务）
 func (s *TaskAppService) UpdateTaskStatus(ctx context.Context, req dto.UpdateTaskStatusRequest) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找任务
 		task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
 		if err != nil {
@@ -307,40 +1346,226 @@ func (s *TaskAppService) UpdateTaskStatus(ctx context.Context, req dto.UpdateTas
 		}
 
 		// 3. 保存更新
-		if err := s.taskRepo.Save(ctx, *task); err != nil {
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
 			return fmt.Errorf("保存任务失败: %w", err)
 		}
+		events = append(events, task.GetEvents()...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
+}
+
+// AddTaskParticipant 以指定角色添加任务参与者，未指定角色时为执行者（需要事务）
+// 添加者需要有任务管理权限，审核者角色只能由任务审批人授予；用户已是参与者时返回 ErrAlreadyParticipant
+func (s *TaskAppService) AddTaskParticipant(ctx context.Context, req dto.AddTaskParticipantRequest) (*dto.TaskParticipantDTO, error) {
+	role := valueobject.ParticipantRole(req.Role)
+	if role == "" {
+		role = valueobject.ParticipantRoleExecutor
+	}
 
+	var events []event.DomainEvent
+	var participant *dto.TaskParticipantDTO
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 查找任务
+		task, err := s.findTaskWithLimits(ctx, valueobject.TaskID(req.TaskID))
+		if err != nil {
+			return fmt.Errorf("任务不存在: %w", err)
+		}
+
+		// 2. 验证添加者权限和参与者存在，审核者角色只能由任务审批人授予
+		participantID := valueobject.UserID(req.ParticipantID)
+		addedBy := valueobject.UserID(req.AddedBy)
+		if task.IsParticipant(participantID) {
+			if !s.taskDomainService.CanUserManageTask(addedBy, *task) {
+				return event.NewDomainError(event.ErrPermissionDenied, "user does not have permission to add participant")
+			}
+			return fmt.Errorf("添加参与者失败: %w", aggregate.ErrAlreadyParticipant)
+		} else if err := s.taskDomainService.ValidateParticipantAddition(*task, participantID, addedBy); err != nil {
+			return fmt.Errorf("添加参与者校验失败: %w", err)
+		}
+		if role == valueobject.ParticipantRoleReviewer && !task.CanUserApprove(addedBy) {
+			return event.NewDomainError(event.ErrPermissionDenied, "only the task approver can grant the reviewer role")
+		}
+
+		// 3. 添加参与者
+		if err := task.AddParticipantWithRole(participantID, role, addedBy); err != nil {
+			return fmt.Errorf("添加参与者失败: %w", err)
+		}
+
+		// 4. 保存更新
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return fmt.Errorf("保存任务失败: %w", err)
+		}
+		events = append(events, task.GetEvents()...)
+
+		for _, p := range task.Participants {
+			if p.UserID == participantID {
+				participant = &dto.TaskParticipantDTO{
+					UserID:  string(p.UserID),
+					Role:    string(p.Role),
+					AddedAt: p.AddedAt,
+					AddedBy: string(p.AddedBy),
+				}
+				break
+			}
+		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishEvents(events)
+	participant.AddedAt = participant.AddedAt.In(shared.LocationFromContext(ctx))
+	return participant, nil
 }
 
-// AddTaskParticipant 添加任务参与者（需要事务）
-func (s *TaskAppService) AddTaskParticipant(ctx context.Context, req dto.AddTaskParticipantRequest) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+// AddTaskParticipants 批量添加任务参与者，事务提交后发布参与者事件（需要事务）
+// 超出参与者数量上限时整批拒绝
+func (s *TaskAppService) AddTaskParticipants(ctx context.Context, req dto.AddTaskParticipantsRequest) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找任务
-		task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
+		task, err := s.findTaskWithLimits(ctx, valueobject.TaskID(req.TaskID))
 		if err != nil {
 			return fmt.Errorf("任务不存在: %w", err)
 		}
 
-		// 2. 添加参与者
-		if err := task.AddParticipant(valueobject.UserID(req.ParticipantID), valueobject.UserID(req.AddedBy)); err != nil {
+		// 2. 批量添加参与者
+		participantIDs := make([]valueobject.UserID, len(req.ParticipantIDs))
+		for i, id := range req.ParticipantIDs {
+			participantIDs[i] = valueobject.UserID(id)
+		}
+		if err := task.AddParticipants(participantIDs, valueobject.UserID(req.AddedBy)); err != nil {
 			return fmt.Errorf("添加参与者失败: %w", err)
 		}
 
 		// 3. 保存更新
-		if err := s.taskRepo.Save(ctx, *task); err != nil {
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
 			return fmt.Errorf("保存任务失败: %w", err)
 		}
+		events = append(events, task.GetEvents()...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
+}
 
+// SubmitWork 提交工作，事务提交后发布参与者、工作提交和工时事件（需要事务）
+// 任务开放协作时，项目成员提交工作会自动加入为参与者
+func (s *TaskAppService) SubmitWork(ctx context.Context, req dto.SubmitWorkRequest) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+		// 1. 查找任务
+		task, err := s.findTaskWithLimits(ctx, valueobject.TaskID(req.TaskID))
+		if err != nil {
+			return fmt.Errorf("任务不存在: %w", err)
+		}
+
+		// 2. 开放协作时检查项目成员身份
+		submitterID := valueobject.UserID(req.SubmitterID)
+		isProjectMember := false
+		if task.OpenContribution && !task.IsParticipant(submitterID) {
+			isProjectMember = s.taskDomainService.IsProjectMember(ctx, submitterID, task.ProjectID)
+		}
+
+		// 3. 校验附件
+		if err := s.validateAttachments(ctx, task.ID, submitterID, req.Attachments); err != nil {
+			return err
+		}
+
+		// 4. 提交工作
+		if err := task.SubmitWorkAsProjectMember(submitterID, isProjectMember, req.WorkContent, req.Attachments); err != nil {
+			return fmt.Errorf("提交工作失败: %w", err)
+		}
+		if req.HoursSpent != 0 {
+			if err := task.LogHours(submitterID, req.HoursSpent); err != nil {
+				return fmt.Errorf("记录工时失败: %w", err)
+			}
+		}
+
+		// 5. 保存更新，并将附件关联到任务
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
+			return fmt.Errorf("保存任务失败: %w", err)
+		}
+		if s.fileRepo != nil {
+			if err := s.fileRepo.AssociateAttachments(ctx, req.Attachments, valueobject.FileResourceTypeTask, string(task.ID)); err != nil {
+				return fmt.Errorf("关联附件失败: %w", err)
+			}
+		}
+		events = append(events, task.GetEvents()...)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
+}
+
+// validateAttachments 校验附件均为已上传完成的文件，且由提交人上传或已关联到该任务
+func (s *TaskAppService) validateAttachments(ctx context.Context, taskID valueobject.TaskID, submitterID valueobject.UserID, attachments []string) error {
+	if s.fileRepo == nil || len(attachments) == 0 {
+		return nil
+	}
+
+	files, err := s.fileRepo.FindByIDs(ctx, attachments)
+	if err != nil {
+		return fmt.Errorf("查询附件失败: %w", err)
+	}
+	associatedIDs, err := s.fileRepo.FindAssociatedFileIDs(ctx, valueobject.FileResourceTypeTask, string(taskID))
+	if err != nil {
+		return fmt.Errorf("查询任务附件失败: %w", err)
+	}
+
+	filesByID := make(map[string]valueobject.FileInfo, len(files))
+	for _, file := range files {
+		filesByID[file.ID] = file
+	}
+	associated := make(map[string]bool, len(associatedIDs))
+	for _, id := range associatedIDs {
+		associated[id] = true
+	}
+
+	var invalid []string
+	for _, id := range attachments {
+		file, ok := filesByID[id]
+		if !ok || !file.IsCompleted() || (file.UploaderID != submitterID && !associated[id]) {
+			invalid = append(invalid, id)
+		}
+	}
+	if len(invalid) > 0 {
+		return event.NewDomainError(event.ErrInvalidInput, fmt.Sprintf("附件无效: %s", strings.Join(invalid, ", "))).
+			WithDetail("attachment_ids", invalid)
+	}
+	return nil
+}
+
+// findTaskWithLimits 查找任务并应用工厂配置的参与者数量和文本长度上限
+func (s *TaskAppService) findTaskWithLimits(ctx context.Context, id valueobject.TaskID) (*aggregate.TaskAggregate, error) {
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	task.SetMaxParticipants(s.taskFactory.MaxParticipants())
+	task.SetTextLimits(s.taskFactory.TextLimits())
+	return task, nil
 }
 
-// RemoveTaskParticipant 移除任务参与者（需要事务）
+// RemoveTaskParticipant 移除任务参与者，事务提交后发布参与者事件（需要事务）
 func (s *TaskAppService) RemoveTaskParticipant(ctx context.Context, req dto.RemoveTaskParticipantRequest) error {
-	return s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
+	var events []event.DomainEvent
+	err := s.transactionMgr.WithTransaction(ctx, func(ctx context.Context) error {
 		// 1. 查找任务
 		task, err := s.taskRepo.FindByID(ctx, valueobject.TaskID(req.TaskID))
 		if err != nil {
@@ -353,79 +1578,115 @@ func (s *TaskAppService) RemoveTaskParticipant(ctx context.Context, req dto.Remo
 		}
 
 		// 3. 保存更新
-		if err := s.taskRepo.Save(ctx, *task); err != nil {
+		if err := s.taskRepo.Update(ctx, *task); err != nil {
 			return fmt.Errorf("保存任务失败: %w", err)
 		}
-
+		events = append(events, task.GetEvents()...)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvents(events)
+	return nil
 }
 
-// GetTaskStatistics 获取任务统计信息
+// GetTaskStatistics 获取任务统计信息，未指定项目时统计全部项目（不需要事务）
+// 统计在数据库中分组聚合完成，内存占用与任务数量无关
 func (s *TaskAppService) GetTaskStatistics(ctx context.Context, projectID *valueobject.ProjectID) (*dto.TaskStatisticsResponse, error) {
-	// 构建搜索条件
-	criteria := valueobject.TaskSearchCriteria{}
+	var stats *valueobject.ProjectTaskStatistics
+	var err error
 	if projectID != nil {
-		criteria.ProjectID = projectID
+		stats, err = s.taskRepo.GetProjectTaskStatistics(ctx, *projectID)
+	} else {
+		stats, err = s.taskRepo.GetAllTaskStatistics(ctx)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("统计任务失败: %w", err)
+	}
+
+	byStatus, byPriority, byType := taskStatisticsCounts(stats)
+	return &dto.TaskStatisticsResponse{
+		TotalTasks:      stats.TotalTasks,
+		TasksByStatus:   byStatus,
+		TasksByPriority: byPriority,
+		TasksByType:     byType,
+		OverdueTasks:    stats.OverdueTasks,
+		CompletionRate:  stats.CompletionRate,
+		AverageHours:    stats.AverageTaskTime,
+	}, nil
+}
 
-	// 获取所有任务
-	tasks, _, err := s.taskRepo.SearchTasks(ctx, criteria)
+// GetProjectTaskStatistics 获取项目任务统计（不需要事务）
+// 统计在数据库中分组聚合完成，不加载项目的全部任务
+func (s *TaskAppService) GetProjectTaskStatistics(ctx context.Context, projectID string) (*dto.ProjectTaskStatisticsResponse, error) {
+	stats, err := s.taskRepo.GetProjectTaskStatistics(ctx, valueobject.ProjectID(projectID))
 	if err != nil {
-		return nil, fmt.Errorf("查询任务失败: %w", err)
+		return nil, fmt.Errorf("统计项目任务失败: %w", err)
+	}
+
+	response := toProjectTaskStatisticsResponse(stats)
+	return &response, nil
+}
+
+// GetProjectsTaskStatistics 批量获取多个项目的任务统计（不需要事务）
+// 重复的项目ID只统计一次，所有项目在一次分组查询中完成统计
+func (s *TaskAppService) GetProjectsTaskStatistics(ctx context.Context, req dto.BatchProjectTaskStatisticsRequest) (*dto.BatchProjectTaskStatisticsResponse, error) {
+	seen := make(map[valueobject.ProjectID]bool, len(req.ProjectIDs))
+	projectIDs := make([]valueobject.ProjectID, 0, len(req.ProjectIDs))
+	for _, id := range req.ProjectIDs {
+		projectID := valueobject.ProjectID(id)
+		if !seen[projectID] {
+			seen[projectID] = true
+			projectIDs = append(projectIDs, projectID)
+		}
 	}
 
-	// 计算统计信息
-	stats := &dto.TaskStatisticsResponse{
-		TotalTasks:      len(tasks),
-		TasksByStatus:   make(map[string]int),
-		TasksByPriority: make(map[string]int),
-		TasksByType:     make(map[string]int),
+	stats, err := s.taskRepo.GetProjectsTaskStatistics(ctx, projectIDs)
+	if err != nil {
+		return nil, fmt.Errorf("批量统计项目任务失败: %w", err)
 	}
 
-	var totalHours float64
-	var completedTasks int
-	overdueTasks := 0
+	response := &dto.BatchProjectTaskStatisticsResponse{
+		Statistics: make(map[string]dto.ProjectTaskStatisticsResponse, len(stats)),
+	}
+	for projectID, projectStats := range stats {
+		response.Statistics[string(projectID)] = toProjectTaskStatisticsResponse(projectStats)
+	}
+	return response, nil
+}
 
-	for _, task := range tasks {
-		// 按状态统计
-		stats.TasksByStatus[string(task.Status)]++
-		
-		// 按优先级统计
-		stats.TasksByPriority[string(task.Priority)]++
-		
-		// 按类型统计
-		stats.TasksByType[string(task.TaskType)]++
-		
-		// 计算完成率
-		if task.Status == valueobject.TaskStatusCompleted {
-			completedTasks++
-		}
-		
-		// 计算过期任务
-		if task.DueDate != nil && task.DueDate.Before(time.Now()) && 
-		   task.Status != valueobject.TaskStatusCompleted && 
-		   task.Status != valueobject.TaskStatusCancelled {
-			overdueTasks++
-		}
-		
-		// 累计工时
-		totalHours += task.ActualHours
-	}
-
-	stats.OverdueTasks = overdueTasks
-	
-	// 计算完成率
-	if stats.TotalTasks > 0 {
-		stats.CompletionRate = float64(completedTasks) / float64(stats.TotalTasks) * 100
-	}
-	
-	// 计算平均工时
-	if stats.TotalTasks > 0 {
-		stats.AverageHours = totalHours / float64(stats.TotalTasks)
-	}
-
-	return stats, nil
+// toProjectTaskStatisticsResponse 转换项目任务统计
+func toProjectTaskStatisticsResponse(stats *valueobject.ProjectTaskStatistics) dto.ProjectTaskStatisticsResponse {
+	byStatus, byPriority, byType := taskStatisticsCounts(stats)
+	return dto.ProjectTaskStatisticsResponse{
+		ProjectID:       string(stats.ProjectID),
+		TotalTasks:      stats.TotalTasks,
+		TasksByStatus:   byStatus,
+		TasksByPriority: byPriority,
+		TasksByType:     byType,
+		OverdueTasks:    stats.OverdueTasks,
+		CompletionRate:  stats.CompletionRate,
+		AverageHours:    stats.AverageTaskTime,
+	}
+}
+
+// taskStatisticsCounts 将分组计数转换为以字符串为键的响应格式
+func taskStatisticsCounts(stats *valueobject.ProjectTaskStatistics) (byStatus, byPriority, byType map[string]int) {
+	byStatus = make(map[string]int, len(stats.TasksByStatus))
+	for status, count := range stats.TasksByStatus {
+		byStatus[string(status)] = count
+	}
+	byPriority = make(map[string]int, len(stats.TasksByPriority))
+	for priority, count := range stats.TasksByPriority {
+		byPriority[string(priority)] = count
+	}
+	byType = make(map[string]int, len(stats.TasksByType))
+	for taskType, count := range stats.TasksByType {
+		byType[string(taskType)] = count
+	}
+	return byStatus, byPriority, byType
 }
 
 // convertSearchCriteria 转换搜索条件
@@ -0,0 +1,1381 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	domainService "github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/messaging/memory"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/internal/testutil"
+)
+
+// passthroughTransactionManager 直接执行回调的事务管理器
+type passthroughTransactionManager struct{}
+
+func (passthroughTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTransactionManager) WithTransactionResult(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return fn(ctx)
+}
+
+// acceptAllTaskValidator 不做校验的任务验证器
+type acceptAllTaskValidator struct{}
+
+func (acceptAllTaskValidator) ValidateTitle(title string) error             { return nil }
+func (acceptAllTaskValidator) ValidateDescription(description string) error { return nil }
+func (acceptAllTaskValidator) ValidateDueDate(dueDate *time.Time) error     { return nil }
+func (acceptAllTaskValidator) ValidateEstimatedHours(hours int) error       { return nil }
+
+// newTaskDomainServiceFixture 创建领域服务，项目 project-1 处于活跃状态，creator-1 为所有者
+// 用户 creator-1、responsible-1 和 user-1 至 user-3 已存在
+func newTaskDomainServiceFixture(taskRepo repository.TaskRepository, projects ...aggregate.Project) domainService.TaskDomainService {
+	project := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "creator-1")
+	project.Status = valueobject.ProjectStatusActive
+	projectRepo := testutil.NewMemoryProjectRepository(append([]aggregate.Project{*project}, projects...)...)
+	var users []*aggregate.User
+	for _, id := range []valueobject.UserID{"creator-1", "responsible-1", "user-1", "user-2", "user-3"} {
+		users = append(users, aggregate.NewUser(id, string(id), string(id)+"@example.com", string(id), "hash", valueobject.UserRoleEmployee))
+	}
+	return domainService.NewTaskDomainService(taskRepo, testutil.NewMemoryUserRepository(users...), projectRepo)
+}
+
+func newTaskAppServiceFixture() (*TaskAppService, *testutil.MemoryTaskRepository) {
+	repo := testutil.NewMemoryTaskRepository()
+	factory := aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil)
+	return NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, factory), repo
+}
+
+func newCreateTaskRequest(title string) dto.CreateTaskRequest {
+	dueDate := time.Now().Add(48 * time.Hour)
+	return dto.CreateTaskRequest{
+		Title:         title,
+		TaskType:      string(valueobject.TaskTypeRegular),
+		Priority:      string(valueobject.TaskPriorityMedium),
+		ProjectID:     "project-1",
+		CreatorID:     "creator-1",
+		ResponsibleID: "responsible-1",
+		DueDate:       &dueDate,
+	}
+}
+
+func TestCreateTask_GeneratesID(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+
+	resp, err := svc.CreateTask(context.Background(), newCreateTaskRequest("First"))
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.ID)
+	saved, err := repo.FindByID(context.Background(), valueobject.TaskID(resp.ID))
+	require.NoError(t, err, "task should be saved under the generated id")
+	assert.Equal(t, "First", saved.Title)
+}
+
+func TestUpdateTask_RecordsUpdatedBy(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	ctx := context.Background()
+
+	resp, err := svc.CreateTask(shared.WithActor(ctx, "creator-1"), newCreateTaskRequest("First"))
+	require.NoError(t, err)
+
+	renamed := "Renamed"
+	_, err = svc.UpdateTask(shared.WithActor(ctx, "responsible-1"), dto.UpdateTaskRequest{ID: resp.ID, Title: &renamed, UpdatedBy: "responsible-1"})
+	require.NoError(t, err)
+
+	saved, err := repo.FindByID(ctx, valueobject.TaskID(resp.ID))
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("responsible-1"), saved.UpdatedBy)
+	assert.Equal(t, valueobject.UserID("creator-1"), saved.CreatorID)
+
+	task, err := svc.GetTask(ctx, resp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "responsible-1", task.UpdatedBy)
+}
+
+func TestUpdateTask_ReschedulesAndPublishesAfterCommit(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Reschedule me"))
+	require.NoError(t, err)
+	bus := &recordingEventBus{}
+	svc.WithEventBus(bus)
+	newDueDate := time.Now().Add(96 * time.Hour)
+	startAfterDue := newDueDate.Add(time.Hour)
+	high := string(valueobject.TaskPriorityHigh)
+	hours := 12
+
+	_, err = svc.UpdateTask(context.Background(), dto.UpdateTaskRequest{ID: created.ID, DueDate: &newDueDate, UpdatedBy: "outsider-1"})
+	assert.True(t, event.IsErrorType(err, event.ErrPermissionDenied))
+	_, err = svc.UpdateTask(context.Background(), dto.UpdateTaskRequest{ID: created.ID, StartDate: &startAfterDue, UpdatedBy: created.CreatorID})
+	assert.ErrorIs(t, err, aggregate.ErrTaskStartAfterDue)
+	assert.Empty(t, bus.published)
+
+	updated, err := svc.UpdateTask(context.Background(), dto.UpdateTaskRequest{
+		ID: created.ID, Priority: &high, DueDate: &newDueDate, EstimatedHours: &hours, UpdatedBy: created.ResponsibleID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, high, updated.Priority)
+	assert.Equal(t, 12, updated.EstimatedHours)
+	assert.ElementsMatch(t, []string{"TaskPriorityChanged", "TaskDueDateChanged"}, publishedTypes(bus))
+	stored, err := repo.FindByID(context.Background(), valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	require.NotNil(t, stored.DueDate)
+	assert.True(t, stored.DueDate.Equal(newDueDate))
+	assert.Equal(t, "Reschedule me", stored.Title)
+}
+
+func TestAssignTask_CoResponsiblesShareTheTask(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	bus := &recordingEventBus{}
+	svc.WithEventBus(bus)
+	ctx := context.Background()
+	created, err := svc.CreateTask(ctx, newCreateTaskRequest("Team task"))
+	require.NoError(t, err)
+	bus.published = nil
+
+	require.NoError(t, svc.AssignTask(ctx, dto.AssignTaskRequest{
+		TaskID: created.ID, ResponsibleID: "lead-1", CoResponsibleIDs: []string{"co-1", "co-2"}, AssignedBy: "creator-1",
+	}))
+
+	stored, err := repo.FindByID(ctx, valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("lead-1"), stored.ResponsibleID)
+	assert.Equal(t, []valueobject.UserID{"co-1", "co-2"}, stored.CoResponsibleIDs)
+	assert.True(t, stored.CanUserModify("co-2"))
+
+	require.Len(t, bus.published, 1)
+	assigned, ok := bus.published[0].(*event.TaskAssignedEvent)
+	require.True(t, ok, "unexpected event %T", bus.published[0])
+	assert.Equal(t, []string{"lead-1", "co-1", "co-2"}, assigned.Assignees())
+
+	task, err := svc.GetTask(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"co-1", "co-2"}, task.CoResponsibleIDs)
+}
+
+func TestCreateTask_RejectsClosedProject(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository()
+	cancelled := aggregate.NewProject("project-cancelled", "Cancelled", "", valueobject.ProjectTypeMaster, "creator-1")
+	cancelled.Status = valueobject.ProjectStatusCancelled
+	factory := aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo, *cancelled), passthroughTransactionManager{}, repo, factory)
+
+	req := newCreateTaskRequest("Too late")
+	req.ProjectID = "project-cancelled"
+	_, err := svc.CreateTask(context.Background(), req)
+
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrInvalidState, domainErr.Type)
+	tasks, err := repo.FindByProject(context.Background(), "project-cancelled")
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestCreateTask_RequiresProjectAccess(t *testing.T) {
+	svc, _ := newTaskAppServiceFixture()
+
+	req := newCreateTaskRequest("Outsider")
+	req.CreatorID = "outsider-1"
+	_, err := svc.CreateTask(context.Background(), req)
+
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+}
+
+func TestCreateTask_SuccessiveCreatesDoNotCollide(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+
+	first, err := svc.CreateTask(context.Background(), newCreateTaskRequest("First"))
+	require.NoError(t, err)
+	second, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Second"))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.ID, second.ID)
+	count, err := repo.CountByProject(context.Background(), "project-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+// newCloneSourceTask 已进行中的源任务，包含参与者和实际工时
+func newCloneSourceTask() aggregate.TaskAggregate {
+	description := "Original description"
+	dueDate := time.Now().Add(72 * time.Hour)
+	return aggregate.TaskAggregate{
+		ID:            "source-1",
+		Title:         "Quarterly report",
+		Description:   &description,
+		TaskType:      valueobject.TaskTypeRegular,
+		Priority:      valueobject.TaskPriorityHigh,
+		Status:        valueobject.TaskStatusInProgress,
+		ProjectID:     "project-1",
+		CreatorID:     "someone-else",
+		ResponsibleID: "responsible-1",
+		// 复制人需要能查看源任务
+		CoResponsibleIDs: []valueobject.UserID{"creator-1"},
+		DueDate:          &dueDate,
+		EstimatedHours:   16,
+		ActualHours:      9.5,
+		Participants: []valueobject.TaskParticipant{
+			{UserID: "user-1", Role: valueobject.ParticipantRoleExecutor},
+			{UserID: "user-2", Role: valueobject.ParticipantRoleReviewer},
+		},
+	}
+}
+
+func TestCloneTask_CreatesIndependentDraft(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(newCloneSourceTask())
+	factory := aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, factory)
+	ctx := context.Background()
+
+	resp, err := svc.CloneTask(ctx, dto.CloneTaskRequest{TaskID: "source-1", CallerID: "creator-1", IncludeParticipants: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, "source-1", resp.ID)
+	assert.Equal(t, string(valueobject.TaskStatusDraft), resp.Status)
+	assert.Equal(t, "creator-1", resp.CreatorID)
+	assert.Equal(t, "project-1", resp.ProjectID)
+	assert.Equal(t, 16, resp.EstimatedHours)
+
+	clone, err := repo.FindByID(ctx, valueobject.TaskID(resp.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "Quarterly report", clone.Title)
+	assert.Equal(t, valueobject.TaskPriorityHigh, clone.Priority)
+	assert.Zero(t, clone.ActualHours)
+	require.Len(t, clone.Participants, 2)
+	role := clone.GetParticipantRole("user-2")
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ParticipantRoleReviewer, *role)
+
+	// 修改副本不影响源任务
+	renamed := "Renamed copy"
+	_, err = svc.UpdateTask(ctx, dto.UpdateTaskRequest{ID: resp.ID, Title: &renamed, UpdatedBy: "creator-1"})
+	require.NoError(t, err)
+	_, err = svc.AddTaskParticipant(ctx, dto.AddTaskParticipantRequest{TaskID: resp.ID, ParticipantID: "user-3", AddedBy: "creator-1"})
+	require.NoError(t, err)
+
+	source, err := repo.FindByID(ctx, "source-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Quarterly report", source.Title)
+	assert.Equal(t, valueobject.TaskStatusInProgress, source.Status)
+	assert.Len(t, source.Participants, 2)
+}
+
+func TestCloneTask_ShiftsPastDueDate(t *testing.T) {
+	source := newCloneSourceTask()
+	createdAt := time.Now().Add(-10 * 24 * time.Hour)
+	dueDate := createdAt.Add(5 * 24 * time.Hour)
+	source.CreatedAt = createdAt
+	source.DueDate = &dueDate
+	repo := testutil.NewMemoryTaskRepository(source)
+	factory := aggregate.NewTaskFactory(validation.NewTaskValidator(), nil)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, factory)
+	ctx := context.Background()
+
+	before := time.Now()
+	resp, err := svc.CloneTask(ctx, dto.CloneTaskRequest{TaskID: "source-1", CallerID: "creator-1"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.DueDate)
+	// 按源任务 5 天的工期从复制时刻顺延
+	assert.WithinDuration(t, before.Add(5*24*time.Hour), *resp.DueDate, time.Minute)
+
+	// 调用者显式指定的过期截止时间仍然被拒绝
+	past := time.Now().Add(-time.Hour)
+	var domainErr *event.DomainError
+	_, err = svc.CloneTask(ctx, dto.CloneTaskRequest{TaskID: "source-1", CallerID: "creator-1", DueDate: &past})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrInvalidInput, domainErr.Type)
+}
+
+func TestCloneTask_IntoAnotherProject(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(newCloneSourceTask())
+	other := aggregate.NewProject("project-2", "Other", "", valueobject.ProjectTypeMaster, "creator-1")
+	closed := aggregate.NewProject("project-closed", "Closed", "", valueobject.ProjectTypeMaster, "creator-1")
+	closed.Status = valueobject.ProjectStatusCompleted
+	factory := aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo, *other, *closed), passthroughTransactionManager{}, repo, factory)
+	ctx := context.Background()
+
+	resp, err := svc.CloneTask(ctx, dto.CloneTaskRequest{TaskID: "source-1", CallerID: "creator-1", ProjectID: "project-2"})
+	require.NoError(t, err)
+	assert.Equal(t, "project-2", resp.ProjectID)
+	clone, err := repo.FindByID(ctx, valueobject.TaskID(resp.ID))
+	require.NoError(t, err)
+	assert.Empty(t, clone.Participants)
+
+	var domainErr *event.DomainError
+	_, err = svc.CloneTask(ctx, dto.CloneTaskRequest{TaskID: "source-1", CallerID: "creator-1", ProjectID: "project-closed"})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrInvalidState, domainErr.Type)
+
+	_, err = svc.CloneTask(ctx, dto.CloneTaskRequest{TaskID: "missing", CallerID: "creator-1"})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestGetTaskExtensions_IncludesReviewedRequests(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("With extensions"))
+	require.NoError(t, err)
+	taskID := valueobject.TaskID(created.ID)
+
+	requestedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	reviewedAt := requestedAt.Add(time.Hour)
+	approver, rejector := valueobject.UserID("reviewer-1"), valueobject.UserID("reviewer-2")
+	approveComment, rejectComment := "ok", "too late"
+	task, err := repo.FindByID(context.Background(), taskID)
+	require.NoError(t, err)
+	task.Extensions = []valueobject.ExtensionRequest{
+		{ID: "ext-1", TaskID: taskID, RequesterID: "user-1", Status: valueobject.ExtensionStatusApproved,
+			RequestedAt: requestedAt, ReviewerID: &approver, ReviewedAt: &reviewedAt, ReviewComment: &approveComment},
+		{ID: "ext-2", TaskID: taskID, RequesterID: "user-1", Status: valueobject.ExtensionStatusRejected,
+			RequestedAt: requestedAt.Add(time.Minute), ReviewerID: &rejector, ReviewedAt: &reviewedAt, ReviewComment: &rejectComment},
+	}
+	require.NoError(t, repo.Update(context.Background(), *task))
+
+	extensions, err := svc.GetTaskExtensions(context.Background(), created.ID, "responsible-1", false)
+
+	require.NoError(t, err)
+	require.Len(t, extensions, 2)
+	assert.Equal(t, "approved", extensions[0].Status)
+	require.NotNil(t, extensions[0].ReviewerID)
+	assert.Equal(t, "reviewer-1", *extensions[0].ReviewerID)
+	assert.Equal(t, "ok", *extensions[0].ReviewComment)
+	assert.Equal(t, "rejected", extensions[1].Status)
+	require.NotNil(t, extensions[1].ReviewerID)
+	assert.Equal(t, "reviewer-2", *extensions[1].ReviewerID)
+	assert.Equal(t, "too late", *extensions[1].ReviewComment)
+}
+
+func TestGetTaskExtensions_UnknownTask(t *testing.T) {
+	svc, _ := newTaskAppServiceFixture()
+
+	_, err := svc.GetTaskExtensions(context.Background(), "missing", "creator-1", false)
+
+	assert.Error(t, err)
+}
+
+func TestGetTaskExtensions_RequiresViewPermission(t *testing.T) {
+	svc, _ := newTaskAppServiceFixture()
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Private"))
+	require.NoError(t, err)
+
+	_, err = svc.GetTaskExtensions(context.Background(), created.ID, "outsider", false)
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	extensions, err := svc.GetTaskExtensions(context.Background(), created.ID, "outsider", true)
+	require.NoError(t, err, "admins can view any task")
+	assert.Empty(t, extensions)
+}
+
+// newTaskBundleFixture 任务 task-1 带参与者、一条已审核的工作提交、一条延期申请和两条事件，另有其他任务的事件
+func newTaskBundleFixture(t *testing.T) *TaskAppService {
+	t.Helper()
+	submittedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	reviewer, comment := valueobject.UserID("creator-1"), "looks good"
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+		Status:       valueobject.TaskStatusInProgress,
+		Participants: []valueobject.TaskParticipant{{UserID: "participant-1", Role: valueobject.ParticipantRoleExecutor}},
+		WorkSubmissions: []valueobject.WorkSubmission{{
+			ID: "sub-1", TaskID: "task-1", SubmitterID: "responsible-1", Content: "draft",
+			Status: valueobject.WorkSubmissionStatusApproved, SubmittedAt: submittedAt,
+			ReviewerID: &reviewer, ReviewComment: &comment,
+		}},
+		Extensions: []valueobject.ExtensionRequest{{
+			ID: "ext-1", TaskID: "task-1", RequesterID: "responsible-1", Reason: "blocked",
+			Status: valueobject.ExtensionStatusPending, RequestedAt: submittedAt,
+		}},
+	})
+
+	store := memory.NewInMemoryEventStore(0)
+	changed := event.NewTaskStatusChangedEvent("task-1", "approved", "in_progress", "responsible-1", "")
+	changed.Timestamp = submittedAt
+	completed := event.NewTaskCompletedEvent("task-1", "responsible-1")
+	completed.Timestamp = submittedAt.Add(time.Hour)
+	for _, e := range []event.DomainEvent{completed, changed, event.NewTaskCompletedEvent("task-2", "responsible-1")} {
+		require.NoError(t, store.Save(e))
+	}
+	return NewTaskAppService(nil, nil, repo, nil).WithEventStore(store)
+}
+
+func TestGetTaskBundle_AdminSeesEverySection(t *testing.T) {
+	svc := newTaskBundleFixture(t)
+
+	bundle, err := svc.GetTaskBundle(context.Background(), "task-1", "admin-1", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", bundle.Task.ID)
+	require.Len(t, bundle.Task.Participants, 1)
+	assert.Equal(t, "participant-1", bundle.Task.Participants[0].UserID)
+	require.Len(t, bundle.WorkSubmissions, 1)
+	assert.Equal(t, "looks good", *bundle.WorkSubmissions[0].ReviewComment)
+	require.Len(t, bundle.Extensions, 1)
+	assert.Equal(t, "blocked", bundle.Extensions[0].Reason)
+	require.Len(t, bundle.History, 2, "only the task's own events")
+	assert.Equal(t, "TaskStatusChanged", bundle.History[0].Type)
+	assert.Equal(t, "TaskCompleted", bundle.History[1].Type)
+	assert.False(t, bundle.HistoryTruncated)
+}
+
+func TestGetTaskBundle_ViewerDoesNotSeeHistory(t *testing.T) {
+	svc := newTaskBundleFixture(t)
+
+	bundle, err := svc.GetTaskBundle(context.Background(), "task-1", "participant-1", false)
+
+	require.NoError(t, err)
+	assert.Len(t, bundle.WorkSubmissions, 1)
+	assert.Len(t, bundle.Extensions, 1)
+	assert.Nil(t, bundle.History)
+	payload, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), `"history"`)
+}
+
+func TestGetTaskBundle_ForbiddenForOutsiders(t *testing.T) {
+	svc := newTaskBundleFixture(t)
+
+	_, err := svc.GetTaskBundle(context.Background(), "task-1", "outsider-1", false)
+
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	_, err = svc.GetTaskBundle(context.Background(), "missing", "admin-1", true)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestGetTaskBundle_BoundsHistory(t *testing.T) {
+	store := memory.NewInMemoryEventStore(0)
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	var events []*event.TaskStatusChangedEvent
+	for i := 0; i <= TaskBundleHistoryLimit; i++ {
+		e := event.NewTaskStatusChangedEvent("task-1", "approved", "in_progress", "responsible-1", "")
+		e.Timestamp = base.Add(time.Duration(i) * time.Minute)
+		events = append(events, e)
+		require.NoError(t, store.Save(e))
+	}
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1"})
+	svc := NewTaskAppService(nil, nil, repo, nil).WithEventStore(store)
+
+	bundle, err := svc.GetTaskBundle(context.Background(), "task-1", "admin-1", true)
+
+	require.NoError(t, err)
+	require.Len(t, bundle.History, TaskBundleHistoryLimit)
+	assert.True(t, bundle.HistoryTruncated)
+	// 截断时丢弃最早的事件，保留的事件按时间升序
+	assert.Equal(t, events[1].EventID(), bundle.History[0].ID)
+	assert.Equal(t, events[TaskBundleHistoryLimit].EventID(), bundle.History[TaskBundleHistoryLimit-1].ID)
+}
+
+func TestRequestExtension_RejectsSecondWhilePending(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Extend me"))
+	require.NoError(t, err)
+	newDueDate := time.Now().Add(96 * time.Hour)
+
+	first, err := svc.RequestExtension(context.Background(), dto.RequestExtensionRequest{
+		TaskID: created.ID, RequesterID: created.CreatorID, NewDueDate: newDueDate, Reason: "first",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", first.Status)
+
+	_, err = svc.RequestExtension(context.Background(), dto.RequestExtensionRequest{
+		TaskID: created.ID, RequesterID: created.CreatorID, NewDueDate: newDueDate, Reason: "second",
+	})
+
+	var domainErr aggregate.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "EXTENSION_ALREADY_PENDING", domainErr.Code)
+	stored, err := repo.FindByID(context.Background(), valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	assert.Len(t, stored.Extensions, 1)
+}
+
+func TestRequestExtension_PublishesExtensionRequestedAfterCommit(t *testing.T) {
+	svc, _ := newTaskAppServiceFixture()
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Extend me"))
+	require.NoError(t, err)
+	bus := &recordingEventBus{}
+	svc.WithEventBus(bus)
+
+	requested, err := svc.RequestExtension(context.Background(), dto.RequestExtensionRequest{
+		TaskID: created.ID, RequesterID: created.CreatorID, NewDueDate: time.Now().Add(96 * time.Hour), Reason: "blocked",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ExtensionRequested"}, publishedTypes(bus))
+	requestedEvent, ok := bus.published[0].(*event.ExtensionRequestedEvent)
+	require.True(t, ok)
+	assert.Equal(t, requested.ID, requestedEvent.RequestID)
+}
+
+func TestRejectExtension_PublishesExtensionRejectedAfterCommit(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Extend me"))
+	require.NoError(t, err)
+	requested, err := svc.RequestExtension(context.Background(), dto.RequestExtensionRequest{
+		TaskID: created.ID, RequesterID: created.CreatorID, NewDueDate: time.Now().Add(96 * time.Hour), Reason: "blocked",
+	})
+	require.NoError(t, err)
+	bus := &recordingEventBus{}
+	svc.WithEventBus(bus)
+
+	rejected, err := svc.RejectExtension(context.Background(), dto.RejectExtensionRequest{
+		TaskID: created.ID, ExtensionID: requested.ID, RejectorID: created.CreatorID, Comment: "no",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", rejected.Status)
+	assert.Equal(t, []string{"ExtensionRejected"}, publishedTypes(bus))
+	stored, err := repo.FindByID(context.Background(), valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	assert.Equal(t, created.DueDate, stored.DueDate)
+}
+
+func TestAddTaskParticipant_UsesConfiguredLimit(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository()
+	factory := aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil).WithMaxParticipants(1)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, factory)
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Limited"))
+	require.NoError(t, err)
+
+	_, err = svc.AddTaskParticipant(context.Background(), dto.AddTaskParticipantRequest{
+		TaskID: created.ID, ParticipantID: "user-1", AddedBy: created.CreatorID,
+	})
+	require.NoError(t, err)
+	_, err = svc.AddTaskParticipant(context.Background(), dto.AddTaskParticipantRequest{
+		TaskID: created.ID, ParticipantID: "user-2", AddedBy: created.CreatorID,
+	})
+
+	var domainErr aggregate.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "PARTICIPANT_LIMIT_EXCEEDED", domainErr.Code)
+	stored, err := repo.FindByID(context.Background(), valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	assert.Len(t, stored.Participants, 1)
+}
+
+func TestAddTaskParticipant_UsesRequestedRole(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository()
+	bus := &recordingEventBus{}
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil)).
+		WithEventBus(bus)
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Reviewed"))
+	require.NoError(t, err)
+	bus.published = nil
+
+	participant, err := svc.AddTaskParticipant(context.Background(), dto.AddTaskParticipantRequest{
+		TaskID: created.ID, ParticipantID: "user-1", Role: "reviewer", AddedBy: created.CreatorID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "reviewer", participant.Role)
+
+	stored, err := repo.FindByID(context.Background(), valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	role := stored.GetParticipantRole("user-1")
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ParticipantRoleReviewer, *role)
+
+	require.Len(t, bus.published, 1)
+	added, ok := bus.published[0].(*event.ParticipantAddedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "reviewer", added.Role)
+
+	// 未指定角色时默认为执行者
+	participant, err = svc.AddTaskParticipant(context.Background(), dto.AddTaskParticipantRequest{
+		TaskID: created.ID, ParticipantID: "user-2", AddedBy: created.CreatorID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "executor", participant.Role)
+}
+
+func TestAddTaskParticipant_RejectsExistingParticipant(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	created, err := svc.CreateTask(context.Background(), newCreateTaskRequest("Staffed"))
+	require.NoError(t, err)
+	_, err = svc.AddTaskParticipant(context.Background(), dto.AddTaskParticipantRequest{
+		TaskID: created.ID, ParticipantID: "user-1", AddedBy: created.CreatorID,
+	})
+	require.NoError(t, err)
+	bus := &recordingEventBus{}
+	svc.WithEventBus(bus)
+
+	_, err = svc.AddTaskParticipant(context.Background(), dto.AddTaskParticipantRequest{
+		TaskID: created.ID, ParticipantID: "user-1", Role: "reviewer", AddedBy: created.CreatorID,
+	})
+
+	assert.ErrorIs(t, err, aggregate.ErrAlreadyParticipant)
+	assert.Empty(t, bus.published)
+	stored, err := repo.FindByID(context.Background(), valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	role := stored.GetParticipantRole("user-1")
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ParticipantRoleExecutor, *role)
+}
+
+func TestAddTaskParticipant_RequiresManagePermission(t *testing.T) {
+	svc, repo := newTaskAppServiceFixture()
+	ctx := context.Background()
+	created, err := svc.CreateTask(ctx, newCreateTaskRequest("Guarded"))
+	require.NoError(t, err)
+
+	// 非创建人或负责人不能添加参与者，包括添加自己
+	var domainErr *event.DomainError
+	_, err = svc.AddTaskParticipant(ctx, dto.AddTaskParticipantRequest{TaskID: created.ID, ParticipantID: "user-1", AddedBy: "user-1"})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	// 负责人可以添加执行者，但不能授予审核者角色
+	_, err = svc.AddTaskParticipant(ctx, dto.AddTaskParticipantRequest{TaskID: created.ID, ParticipantID: "user-1", AddedBy: "responsible-1"})
+	require.NoError(t, err)
+	_, err = svc.AddTaskParticipant(ctx, dto.AddTaskParticipantRequest{TaskID: created.ID, ParticipantID: "user-2", Role: "reviewer", AddedBy: "responsible-1"})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	// 参与者用户必须存在
+	_, err = svc.AddTaskParticipant(ctx, dto.AddTaskParticipantRequest{TaskID: created.ID, ParticipantID: "ghost", AddedBy: "creator-1"})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	stored, err := repo.FindByID(ctx, valueobject.TaskID(created.ID))
+	require.NoError(t, err)
+	assert.True(t, stored.IsParticipant("user-1"))
+	assert.False(t, stored.IsParticipant("user-2"))
+	assert.False(t, stored.IsParticipant("ghost"))
+}
+
+// recordingEventBus 记录已发布事件的事件总线
+type recordingEventBus struct {
+	published []event.DomainEvent
+}
+
+func (b *recordingEventBus) Publish(e event.DomainEvent) error {
+	b.published = append(b.published, e)
+	return nil
+}
+
+func (b *recordingEventBus) Subscribe(eventType string, handler event.EventHandler) error { return nil }
+func (b *recordingEventBus) Unsubscribe(eventType string, handler event.EventHandler) error {
+	return nil
+}
+
+// newBulkDeleteTask 创建指定创建者和状态的任务
+func newBulkDeleteTask(id valueobject.TaskID, creatorID valueobject.UserID, status valueobject.TaskStatus) aggregate.TaskAggregate {
+	dueDate := time.Now().Add(48 * time.Hour)
+	task := aggregate.NewTask(id, "Task "+string(id), "", valueobject.TaskTypeRegular,
+		valueobject.TaskPriorityMedium, "project-1", creatorID, "", &dueDate)
+	task.Status = status
+	return *task
+}
+
+func newBulkDeleteRequest(force bool, ids ...string) dto.BulkDeleteTasksRequest {
+	return dto.BulkDeleteTasksRequest{
+		TaskIDs:           ids,
+		ConfirmationToken: BulkDeleteConfirmationToken(ids),
+		Force:             force,
+		DeletedBy:         "creator-1",
+	}
+}
+
+func TestBulkDeleteTasks_MixedBatchReportsPerTaskResults(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(
+		newBulkDeleteTask("task-draft", "creator-1", valueobject.TaskStatusDraft),
+		newBulkDeleteTask("task-done", "creator-1", valueobject.TaskStatusCompleted),
+		newBulkDeleteTask("task-foreign", "someone-else", valueobject.TaskStatusDraft),
+	)
+	bus := &recordingEventBus{}
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil)).
+		WithEventBus(bus)
+
+	resp, err := svc.BulkDeleteTasks(context.Background(),
+		newBulkDeleteRequest(false, "task-draft", "task-missing", "task-foreign", "task-done", "task-draft"))
+
+	require.NoError(t, err)
+	statuses := make(map[string]string, len(resp.Results))
+	for _, result := range resp.Results {
+		statuses[result.TaskID] = result.Status
+	}
+	assert.Equal(t, map[string]string{
+		"task-draft":   dto.BulkDeleteStatusDeleted,
+		"task-done":    dto.BulkDeleteStatusDeleted,
+		"task-missing": dto.BulkDeleteStatusNotFound,
+		"task-foreign": dto.BulkDeleteStatusForbidden,
+	}, statuses)
+	assert.Equal(t, 2, resp.Deleted)
+
+	_, err = repo.FindByID(context.Background(), "task-draft")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.FindByID(context.Background(), "task-foreign")
+	assert.NoError(t, err, "tasks the caller cannot modify must be kept")
+
+	require.Len(t, bus.published, 2)
+	for _, e := range bus.published {
+		assert.Equal(t, "TaskDeleted", e.EventType())
+	}
+}
+
+func TestListTasks_PagesThroughSearchResults(t *testing.T) {
+	tasks := make([]aggregate.TaskAggregate, 0, 25)
+	for i := 0; i < 25; i++ {
+		tasks = append(tasks, newBulkDeleteTask(valueobject.TaskID(fmt.Sprintf("task-%02d", i)), "creator-1", valueobject.TaskStatusInProgress))
+	}
+	repo := testutil.NewMemoryTaskRepository(tasks...)
+	svc := NewTaskAppService(nil, nil, repo, nil)
+
+	resp, err := svc.ListTasks(context.Background(), dto.ListTasksRequest{Page: 2, PageSize: 10, RequesterIsAdmin: true})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Tasks, 10)
+	assert.EqualValues(t, 25, resp.Total)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.Equal(t, 2, resp.Page)
+
+	// 未指定分页参数时使用默认页大小，非管理员只能看到自己可见的任务
+	resp, err = svc.ListTasks(context.Background(), dto.ListTasksRequest{RequesterID: "creator-1"})
+	require.NoError(t, err)
+	assert.Len(t, resp.Tasks, DefaultTaskPageSize)
+	assert.Equal(t, DefaultTaskPageSize, resp.PageSize)
+	resp, err = svc.ListTasks(context.Background(), dto.ListTasksRequest{RequesterID: "outsider-1"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Tasks)
+	assert.Zero(t, resp.TotalPages)
+}
+
+func TestBulkTagTasks_AddsAndRemovesTagsOnReload(t *testing.T) {
+	tasks := make([]aggregate.TaskAggregate, 0, 3)
+	for _, id := range []valueobject.TaskID{"task-1", "task-2", "task-3"} {
+		task := newBulkDeleteTask(id, "creator-1", valueobject.TaskStatusInProgress)
+		task.Tags = []string{"backend", "q3"}
+		tasks = append(tasks, task)
+	}
+	tasks[2].Tags = []string{"backend", "release-2.1"}
+	repo := testutil.NewMemoryTaskRepository(tasks...)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil))
+	ctx := context.Background()
+
+	resp, err := svc.BulkTagTasks(ctx, dto.BulkTagTasksRequest{
+		TaskIDs:    []string{"task-1", "task-2", "task-3", "task-missing"},
+		AddTags:    []string{" release-2.1 "},
+		RemoveTags: []string{"q3"},
+		TaggedBy:   "creator-1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Updated)
+	statuses := make(map[string]string, len(resp.Results))
+	for _, result := range resp.Results {
+		statuses[result.TaskID] = result.Status
+	}
+	assert.Equal(t, map[string]string{
+		"task-1":       dto.BulkTagStatusUpdated,
+		"task-2":       dto.BulkTagStatusUpdated,
+		"task-3":       dto.BulkTagStatusUnchanged,
+		"task-missing": dto.BulkTagStatusNotFound,
+	}, statuses)
+	for _, id := range []valueobject.TaskID{"task-1", "task-2", "task-3"} {
+		reloaded, err := repo.FindByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "release-2.1"}, reloaded.Tags, "task %s", id)
+	}
+}
+
+func TestBulkTagTasks_MarksTasksTheRequesterCannotModifyForbidden(t *testing.T) {
+	own := newBulkDeleteTask("task-own", "creator-1", valueobject.TaskStatusInProgress)
+	other := newBulkDeleteTask("task-other", "user-2", valueobject.TaskStatusInProgress)
+	other.ResponsibleID = "user-3"
+	repo := testutil.NewMemoryTaskRepository(own, other)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil))
+	ctx := context.Background()
+
+	resp, err := svc.BulkTagTasks(ctx, dto.BulkTagTasksRequest{
+		TaskIDs:  []string{"task-own", "task-other"},
+		AddTags:  []string{"urgent"},
+		TaggedBy: "creator-1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Updated)
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, dto.BulkTagStatusUpdated, resp.Results[0].Status)
+	assert.Equal(t, dto.BulkTagStatusForbidden, resp.Results[1].Status)
+	assert.NotEmpty(t, resp.Results[1].Error)
+	untouched, err := repo.FindByID(ctx, "task-other")
+	require.NoError(t, err)
+	assert.NotContains(t, untouched.Tags, "urgent")
+
+	resp, err = svc.BulkTagTasks(ctx, dto.BulkTagTasksRequest{
+		TaskIDs:          []string{"task-other"},
+		AddTags:          []string{"urgent"},
+		TaggedBy:         "admin-1",
+		RequesterIsAdmin: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dto.BulkTagStatusUpdated, resp.Results[0].Status)
+}
+
+func TestBulkTagTasks_RejectsInvalidTagsBeforeChangingAnyTask(t *testing.T) {
+	task := newBulkDeleteTask("task-1", "creator-1", valueobject.TaskStatusInProgress)
+	task.Tags = []string{"q3"}
+	repo := testutil.NewMemoryTaskRepository(task)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil))
+
+	for name, req := range map[string]dto.BulkTagTasksRequest{
+		"empty":             {AddTags: []string{"ok", "  "}},
+		"duplicate":         {AddTags: []string{"urgent", "urgent"}},
+		"added and removed": {AddTags: []string{"q3"}, RemoveTags: []string{"q3"}},
+		"nothing to change": {},
+	} {
+		req.TaskIDs = []string{"task-1"}
+		req.TaggedBy = "creator-1"
+		_, err := svc.BulkTagTasks(context.Background(), req)
+		assert.ErrorIs(t, err, aggregate.ErrInvalidTag, name)
+	}
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"q3"}, stored.Tags)
+}
+
+func TestBulkDeleteTasks_RequiresConfirmationToken(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(newBulkDeleteTask("task-1", "creator-1", valueobject.TaskStatusDraft))
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil))
+
+	req := newBulkDeleteRequest(false, "task-1")
+	req.ConfirmationToken = ""
+	_, missingErr := svc.BulkDeleteTasks(context.Background(), req)
+	req.ConfirmationToken = BulkDeleteConfirmationToken([]string{"task-2"})
+	_, mismatchErr := svc.BulkDeleteTasks(context.Background(), req)
+
+	assert.ErrorIs(t, missingErr, ErrBulkDeleteNotConfirmed)
+	assert.ErrorIs(t, mismatchErr, ErrBulkDeleteNotConfirmed, "a token issued for other ids must be rejected")
+	_, err := repo.FindByID(context.Background(), "task-1")
+	assert.NoError(t, err, "nothing may be deleted without confirmation")
+	assert.Equal(t, BulkDeleteConfirmationToken([]string{"a", "b"}), BulkDeleteConfirmationToken([]string{"b", "a", "b"}),
+		"token must not depend on id order or duplicates")
+}
+
+func TestBulkDeleteTasks_ActiveTaskRequiresForce(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(
+		newBulkDeleteTask("task-draft", "creator-1", valueobject.TaskStatusDraft),
+		newBulkDeleteTask("task-active", "creator-1", valueobject.TaskStatusInProgress),
+	)
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil))
+
+	_, err := svc.BulkDeleteTasks(context.Background(), newBulkDeleteRequest(false, "task-draft", "task-active"))
+
+	var domainErr aggregate.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "TASK_DELETE_REQUIRES_FORCE", domainErr.Code)
+	_, err = repo.FindByID(context.Background(), "task-draft")
+	assert.NoError(t, err, "the whole batch must be refused")
+
+	resp, err := svc.BulkDeleteTasks(context.Background(), newBulkDeleteRequest(true, "task-draft", "task-active"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Deleted)
+}
+
+func TestDeleteTask_PublishesTaskDeletedAfterCommit(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(
+		newBulkDeleteTask("task-draft", "creator-1", valueobject.TaskStatusDraft),
+		newBulkDeleteTask("task-active", "creator-1", valueobject.TaskStatusInProgress),
+	)
+	bus := &recordingEventBus{}
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(acceptAllTaskValidator{}, nil)).
+		WithEventBus(bus)
+	ctx := context.Background()
+
+	var domainErr aggregate.DomainError
+	err := svc.DeleteTask(ctx, dto.DeleteTaskRequest{TaskID: "task-draft", DeletedBy: "someone-else"})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "NO_DELETE_PERMISSION", domainErr.Code)
+	err = svc.DeleteTask(ctx, dto.DeleteTaskRequest{TaskID: "task-active", DeletedBy: "creator-1"})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "TASK_DELETE_REQUIRES_FORCE", domainErr.Code)
+	assert.Empty(t, bus.published)
+
+	require.NoError(t, svc.DeleteTask(ctx, dto.DeleteTaskRequest{TaskID: "task-draft", DeletedBy: "creator-1"}))
+	_, err = repo.FindByID(ctx, "task-draft")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Equal(t, []string{"TaskDeleted"}, publishedTypes(bus))
+
+	deleted, ok := bus.published[0].(*event.TaskDeletedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "project-1", deleted.ProjectID)
+}
+
+// approvedTask 创建审批通过于 approvedAgo 之前的任务
+func approvedTask(id valueobject.TaskID, projectID valueobject.ProjectID, approvedAgo time.Duration) aggregate.TaskAggregate {
+	dueDate := time.Now().Add(30 * 24 * time.Hour)
+	task := aggregate.NewTask(id, "Task "+string(id), "", valueobject.TaskTypeRegular,
+		valueobject.TaskPriorityMedium, projectID, "creator-1", "responsible-1", &dueDate)
+	approvedAt := time.Now().Add(-approvedAgo)
+	task.Status = valueobject.TaskStatusApproved
+	task.ApprovedAt = &approvedAt
+	return *task
+}
+
+func TestGetStaleApprovedTasks_ReturnsTasksApprovedLongerThanThreshold(t *testing.T) {
+	started := approvedTask("task-started", "project-1", 10*24*time.Hour)
+	started.Status = valueobject.TaskStatusInProgress
+	repo := testutil.NewMemoryTaskRepository(
+		approvedTask("task-stale", "project-1", 5*24*time.Hour),
+		approvedTask("task-recent", "project-1", time.Hour),
+		approvedTask("task-other-project", "project-2", 4*24*time.Hour),
+		started,
+	)
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil).WithStaleApprovedAge(72 * time.Hour)
+
+	resp, err := svc.GetStaleApprovedTasks(context.Background(), dto.StaleApprovedTasksRequest{})
+	require.NoError(t, err)
+	ids := make([]string, len(resp.Tasks))
+	for i, task := range resp.Tasks {
+		ids[i] = task.ID
+	}
+	assert.Equal(t, []string{"task-stale", "task-other-project"}, ids, "oldest approval first, recent and started tasks excluded")
+	assert.InDelta(t, 120, resp.Tasks[0].WaitingHours, 0.1)
+
+	filtered, err := svc.GetStaleApprovedTasks(context.Background(), dto.StaleApprovedTasksRequest{ProjectID: "project-1"})
+	require.NoError(t, err)
+	require.Len(t, filtered.Tasks, 1)
+	assert.Equal(t, "task-stale", filtered.Tasks[0].ID)
+}
+
+func TestGetStaleApprovedTasks_MinAgeOverridesDefault(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(approvedTask("task-recent", "project-1", 2*time.Hour))
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil)
+
+	byDefault, err := svc.GetStaleApprovedTasks(context.Background(), dto.StaleApprovedTasksRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, byDefault.Tasks)
+
+	resp, err := svc.GetStaleApprovedTasks(context.Background(), dto.StaleApprovedTasksRequest{MinAgeHours: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Tasks, 1)
+	assert.Equal(t, "task-recent", resp.Tasks[0].ID)
+}
+
+// taskInStatus 创建处于指定状态且已停留 inStatus 时长的任务
+func taskInStatus(id valueobject.TaskID, projectID valueobject.ProjectID, status valueobject.TaskStatus, inStatus time.Duration) aggregate.TaskAggregate {
+	task := approvedTask(id, projectID, inStatus)
+	task.Status = status
+	task.StatusChangedAt = time.Now().Add(-inStatus)
+	return task
+}
+
+func TestGetTaskStatusDurations_AveragesTimeInCurrentStatus(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(
+		taskInStatus("task-1", "project-1", valueobject.TaskStatusInProgress, 10*time.Hour),
+		taskInStatus("task-2", "project-1", valueobject.TaskStatusInProgress, 30*time.Hour),
+		taskInStatus("task-3", "project-1", valueobject.TaskStatusApproved, 4*time.Hour),
+		taskInStatus("task-4", "project-2", valueobject.TaskStatusApproved, 100*time.Hour),
+	)
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil)
+
+	resp, err := svc.GetTaskStatusDurations(context.Background(), dto.TaskStatusDurationsRequest{ProjectID: "project-1"})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Statuses, 2)
+	approved, inProgress := resp.Statuses[0], resp.Statuses[1]
+	assert.Equal(t, string(valueobject.TaskStatusApproved), approved.Status)
+	assert.Equal(t, 1, approved.TaskCount)
+	assert.InDelta(t, 4, approved.AverageHours, 0.01, "other projects' tasks must not be counted")
+	assert.Equal(t, string(valueobject.TaskStatusInProgress), inProgress.Status)
+	assert.Equal(t, 2, inProgress.TaskCount)
+	assert.InDelta(t, 20, inProgress.AverageHours, 0.01)
+	assert.InDelta(t, 30, inProgress.MaxHours, 0.01)
+}
+
+// taskWithHours 创建记录了预估和实际工时的任务
+func taskWithHours(id valueobject.TaskID, projectID valueobject.ProjectID, responsibleID valueobject.UserID, estimated int, actual float64) aggregate.TaskAggregate {
+	task := approvedTask(id, projectID, time.Hour)
+	task.ResponsibleID = responsibleID
+	task.EstimatedHours = estimated
+	task.ActualHours = actual
+	return task
+}
+
+func TestGetEstimateVariance_FlagsTasksOverThreshold(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(
+		taskWithHours("task-overrun", "project-1", "user-1", 8, 12),
+		taskWithHours("task-within", "project-1", "user-2", 8, 9),
+		taskWithHours("task-other", "project-2", "user-1", 10, 6),
+		taskWithHours("task-no-actual", "project-1", "user-1", 8, 0),
+	)
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil).WithEstimateOverrunPercent(20)
+
+	resp, err := svc.GetEstimateVariance(context.Background(), dto.EstimateVarianceRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, resp.OverrunThreshold)
+	require.Len(t, resp.Tasks, 3, "tasks without actual hours are excluded")
+	overrun, within := resp.Tasks[0], resp.Tasks[1]
+	assert.Equal(t, "task-overrun", overrun.TaskID)
+	assert.InDelta(t, 4, overrun.VarianceHours, 1e-9)
+	assert.InDelta(t, 50, overrun.VariancePercent, 1e-9)
+	assert.True(t, overrun.Overrun)
+	assert.Equal(t, "task-within", within.TaskID)
+	assert.InDelta(t, 12.5, within.VariancePercent, 1e-9)
+	assert.False(t, within.Overrun)
+	assert.Equal(t, 1, resp.OverrunCount)
+
+	require.Len(t, resp.ByProject, 2)
+	project := resp.ByProject[0]
+	assert.Equal(t, "project-1", project.ID)
+	assert.Equal(t, 2, project.TaskCount)
+	assert.Equal(t, 1, project.OverrunCount)
+	assert.InDelta(t, 16, project.EstimatedHours, 1e-9)
+	assert.InDelta(t, 21, project.ActualHours, 1e-9)
+	assert.InDelta(t, 31.25, project.VariancePercent, 1e-9)
+
+	require.Len(t, resp.ByResponsible, 2)
+	user := resp.ByResponsible[0]
+	assert.Equal(t, "user-1", user.ID)
+	assert.Equal(t, 2, user.TaskCount)
+	assert.InDelta(t, 0, user.VarianceHours, 1e-9, "overrun and underrun cancel out")
+}
+
+func TestGetEstimateVariance_RequestThresholdAndFilters(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(
+		taskWithHours("task-overrun", "project-1", "user-1", 8, 12),
+		taskWithHours("task-within", "project-1", "user-2", 8, 9),
+	)
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil)
+
+	resp, err := svc.GetEstimateVariance(context.Background(), dto.EstimateVarianceRequest{OverrunThreshold: 60})
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.OverrunCount, "a 50% overrun is within a 60% threshold")
+
+	resp, err = svc.GetEstimateVariance(context.Background(), dto.EstimateVarianceRequest{ResponsibleID: "user-2"})
+	require.NoError(t, err)
+	require.Len(t, resp.Tasks, 1)
+	assert.Equal(t, "task-within", resp.Tasks[0].TaskID)
+	assert.Equal(t, DefaultEstimateOverrunPercent, resp.OverrunThreshold)
+}
+
+// noSearchTaskRepository 加载全部任务时报错，用于确认统计不走全量加载
+type noSearchTaskRepository struct {
+	*testutil.MemoryTaskRepository
+}
+
+func (noSearchTaskRepository) SearchTasks(ctx context.Context, criteria valueobject.TaskSearchCriteria) ([]aggregate.TaskAggregate, int, error) {
+	return nil, 0, fmt.Errorf("statistics must not load every task")
+}
+
+func TestGetTaskStatistics_AllProjectsUsesAggregation(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	repo := noSearchTaskRepository{testutil.NewMemoryTaskRepository(
+		aggregate.TaskAggregate{ID: "task-1", ProjectID: "project-1", Status: valueobject.TaskStatusCompleted,
+			Priority: valueobject.TaskPriorityHigh, TaskType: valueobject.TaskTypeRegular, ActualHours: 4},
+		aggregate.TaskAggregate{ID: "task-2", ProjectID: "project-1", Status: valueobject.TaskStatusInProgress,
+			Priority: valueobject.TaskPriorityLow, TaskType: valueobject.TaskTypeRegular, DueDate: &past},
+		aggregate.TaskAggregate{ID: "task-3", ProjectID: "project-2", Status: valueobject.TaskStatusCancelled,
+			Priority: valueobject.TaskPriorityLow, TaskType: valueobject.TaskTypeUrgent, DueDate: &past, ActualHours: 2},
+	)}
+	svc := NewTaskAppService(nil, nil, repo, nil)
+
+	stats, err := svc.GetTaskStatistics(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalTasks)
+	assert.Equal(t, map[string]int{"completed": 1, "in_progress": 1, "cancelled": 1}, stats.TasksByStatus)
+	assert.Equal(t, map[string]int{"high": 1, "low": 2}, stats.TasksByPriority)
+	assert.Equal(t, map[string]int{"regular": 2, "urgent": 1}, stats.TasksByType)
+	assert.Equal(t, 1, stats.OverdueTasks, "cancelled tasks are never overdue")
+	assert.InDelta(t, 100.0/3, stats.CompletionRate, 1e-9)
+	assert.InDelta(t, 2.0, stats.AverageHours, 1e-9)
+
+	projectID := valueobject.ProjectID("project-2")
+	scoped, err := svc.GetTaskStatistics(context.Background(), &projectID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, scoped.TotalTasks)
+	assert.Zero(t, scoped.OverdueTasks)
+}
+
+func TestUpdateTaskStatus_CompletingPausedTaskAsksToResume(t *testing.T) {
+	task := approvedTask("task-1", "project-1", time.Hour)
+	task.Status = valueobject.TaskStatusPaused
+	repo := testutil.NewMemoryTaskRepository(task)
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil)
+
+	err := svc.UpdateTaskStatus(context.Background(), dto.UpdateTaskStatusRequest{
+		TaskID:    "task-1",
+		Status:    string(valueobject.TaskStatusCompleted),
+		UpdatedBy: "responsible-1",
+	})
+
+	require.ErrorIs(t, err, aggregate.ErrTaskPausedResumeFirst)
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TaskStatusPaused, stored.Status)
+}
+
+func TestSubmitWork_AccumulatesHoursSpentAcrossParticipants(t *testing.T) {
+	task := approvedTask("task-1", "project-1", time.Hour)
+	require.NoError(t, task.AddParticipant("participant-1", "responsible-1"))
+	repo := testutil.NewMemoryTaskRepository(task)
+	bus := &recordingEventBus{}
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)).WithEventBus(bus)
+	ctx := context.Background()
+
+	for _, req := range []dto.SubmitWorkRequest{
+		{TaskID: "task-1", SubmitterID: "responsible-1", WorkContent: "draft", HoursSpent: 3},
+		{TaskID: "task-1", SubmitterID: "participant-1", WorkContent: "review notes", HoursSpent: 1.25},
+		{TaskID: "task-1", SubmitterID: "participant-1", WorkContent: "typo fix"},
+	} {
+		require.NoError(t, svc.SubmitWork(ctx, req))
+	}
+
+	err := svc.SubmitWork(ctx, dto.SubmitWorkRequest{TaskID: "task-1", SubmitterID: "responsible-1", WorkContent: "x", HoursSpent: -2})
+	require.ErrorIs(t, err, aggregate.ErrInvalidHours)
+
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.InDelta(t, 4.25, stored.ActualHours, 1e-9)
+	assert.Len(t, stored.WorkSubmissions, 3)
+
+	var logged []float64
+	for _, e := range bus.published {
+		if hours, ok := e.(*event.HoursLoggedEvent); ok {
+			logged = append(logged, hours.TotalHours)
+		}
+	}
+	assert.Equal(t, []float64{3, 4.25}, logged, "only submissions with hours publish HoursLogged")
+}
+
+func TestSubmitWork_PublishesAutoEnrolmentBeforeSubmission(t *testing.T) {
+	project := aggregate.NewProject("project-open", "Open", "", valueobject.ProjectTypeMaster, "creator-1")
+	project.Status = valueobject.ProjectStatusActive
+	require.NoError(t, project.AddMember("user-1", valueobject.ProjectRoleMember, "creator-1"))
+	task := approvedTask("task-1", "project-open", time.Hour)
+	task.SetOpenContribution(true)
+	repo := testutil.NewMemoryTaskRepository(task)
+	bus := &recordingEventBus{}
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo, *project), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)).
+		WithEventBus(bus)
+
+	require.NoError(t, svc.SubmitWork(context.Background(), dto.SubmitWorkRequest{TaskID: "task-1", SubmitterID: "user-1", WorkContent: "drive-by fix"}))
+
+	require.Len(t, bus.published, 2)
+	assert.Equal(t, "ParticipantAdded", bus.published[0].EventType())
+	assert.Equal(t, "WorkSubmitted", bus.published[1].EventType())
+}
+
+// newAttachmentTestService 创建带文件仓储的任务服务，task-1 由 responsible-1 负责
+func newAttachmentTestService(files *testutil.MemoryFileRepository) (*TaskAppService, *testutil.MemoryTaskRepository) {
+	repo := testutil.NewMemoryTaskRepository(approvedTask("task-1", "project-1", time.Hour))
+	svc := NewTaskAppService(newTaskDomainServiceFixture(repo), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)).
+		WithFileRepository(files)
+	return svc, repo
+}
+
+func TestSubmitWork_AssociatesValidAttachments(t *testing.T) {
+	files := testutil.NewMemoryFileRepository(
+		valueobject.FileInfo{ID: "file-own", UploaderID: "responsible-1", UploadStatus: valueobject.FileUploadStatusCompleted},
+		valueobject.FileInfo{ID: "file-shared", UploaderID: "creator-1", UploadStatus: valueobject.FileUploadStatusCompleted},
+	)
+	// 他人上传但已关联到任务的文件同样可以引用
+	require.NoError(t, files.AssociateAttachments(context.Background(), []string{"file-shared"}, valueobject.FileResourceTypeTask, "task-1"))
+	svc, repo := newAttachmentTestService(files)
+
+	err := svc.SubmitWork(context.Background(), dto.SubmitWorkRequest{
+		TaskID: "task-1", SubmitterID: "responsible-1", WorkContent: "done", Attachments: []string{"file-own", "file-shared"},
+	})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []testutil.FileAssociation{
+		{FileID: "file-shared", ResourceType: "task", ResourceID: "task-1"},
+		{FileID: "file-own", ResourceType: "task", ResourceID: "task-1"},
+	}, files.Associations())
+	submissions, err := repo.FindWorkSubmissionsByTask(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.Len(t, submissions, 1)
+	assert.Equal(t, []string{"file-own", "file-shared"}, submissions[0].Attachments)
+}
+
+func TestSubmitWork_RejectsInvalidAttachments(t *testing.T) {
+	files := testutil.NewMemoryFileRepository(
+		valueobject.FileInfo{ID: "file-own", UploaderID: "responsible-1", UploadStatus: valueobject.FileUploadStatusCompleted},
+		valueobject.FileInfo{ID: "file-uploading", UploaderID: "responsible-1", UploadStatus: valueobject.FileUploadStatusUploading},
+		valueobject.FileInfo{ID: "file-foreign", UploaderID: "outsider-1", UploadStatus: valueobject.FileUploadStatusCompleted},
+	)
+	svc, repo := newAttachmentTestService(files)
+
+	err := svc.SubmitWork(context.Background(), dto.SubmitWorkRequest{
+		TaskID: "task-1", SubmitterID: "responsible-1", WorkContent: "done",
+		Attachments: []string{"file-own", "file-unknown", "file-uploading", "file-foreign"},
+	})
+
+	require.Error(t, err)
+	domainErr := event.GetDomainError(err)
+	require.NotNil(t, domainErr)
+	assert.Equal(t, event.ErrInvalidInput, domainErr.Type)
+	assert.Equal(t, []string{"file-unknown", "file-uploading", "file-foreign"}, domainErr.Details["attachment_ids"])
+	assert.Empty(t, files.Associations())
+	submissions, err := repo.FindWorkSubmissionsByTask(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Empty(t, submissions)
+}
+
+func TestBuildTaskResponse_JSONShape(t *testing.T) {
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	task := aggregate.TaskAggregate{
+		ID:              "task-1",
+		Title:           "Snapshot",
+		TaskType:        valueobject.TaskTypeRegular,
+		Priority:        valueobject.TaskPriorityMedium,
+		Status:          valueobject.TaskStatusDraft,
+		ProjectID:       "project-1",
+		CreatorID:       "creator-1",
+		ResponsibleID:   "responsible-1",
+		StatusChangedAt: created,
+		EstimatedHours:  4,
+		CreatedAt:       created,
+		UpdatedAt:       created,
+	}
+
+	body, err := json.Marshal(buildTaskResponse(&task, created.Add(2*time.Hour), time.UTC))
+	require.NoError(t, err)
+
+	// 空的可选字段省略，参与者和标签始终为数组
+	assert.JSONEq(t, `{
+		"id": "task-1",
+		"title": "Snapshot",
+		"task_type": "regular",
+		"priority": "medium",
+		"status": "draft",
+		"project_id": "project-1",
+		"creator_id": "creator-1",
+		"responsible_id": "responsible-1",
+		"status_changed_at": "2024-03-01T08:00:00Z",
+		"time_in_status_hours": 2,
+		"estimated_hours": 4,
+		"actual_hours": 0,
+		"participants": [],
+		"tags": [],
+		"created_at": "2024-03-01T08:00:00Z",
+		"updated_at": "2024-03-01T08:00:00Z"
+	}`, string(body))
+}
+
+func TestGetStaleApprovedTasks_ParticipantsSerializeAsArray(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(approvedTask("task-1", "project-1", 10*24*time.Hour))
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil)
+
+	resp, err := svc.GetStaleApprovedTasks(context.Background(), dto.StaleApprovedTasksRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Tasks, 1)
+
+	body, err := json.Marshal(resp.Tasks[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"participants":[]`)
+	assert.NotContains(t, string(body), `"description"`)
+}
+
+// newActionItemTasks 进行中的任务 task-a 有 bob 待审核的工作，需审核人 rita 和 ron 一致通过（ron 已表态）；
+// 已审批的任务 task-b 有待审批的延期申请；已完成的任务 task-c 不再需要处理
+func newActionItemTasks() []aggregate.TaskAggregate {
+	due := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	newTask := func(id valueobject.TaskID, status valueobject.TaskStatus) aggregate.TaskAggregate {
+		task := aggregate.NewTask(id, string(id), "", valueobject.TaskTypeRegular, valueobject.TaskPriorityMedium,
+			"project-1", "lead", "alice", &due)
+		task.Status = status
+		task.ClearEvents()
+		return *task
+	}
+
+	inProgress := newTask("task-a", valueobject.TaskStatusInProgress)
+	inProgress.Participants = []valueobject.TaskParticipant{{UserID: "bob", Role: valueobject.ParticipantRoleExecutor}}
+	inProgress.Reviewers = []valueobject.UserID{"rita", "ron"}
+	inProgress.ReviewQuorum = 2
+	inProgress.WorkSubmissions = []valueobject.WorkSubmission{{
+		ID: "ws-1", TaskID: "task-a", SubmitterID: "bob", Content: "draft",
+		Status: valueobject.WorkSubmissionStatusPending, SubmittedAt: due.Add(-48 * time.Hour),
+		Decisions: []valueobject.ReviewDecision{{ReviewerID: "ron", Approved: true, DecidedAt: due.Add(-47 * time.Hour)}},
+	}}
+
+	approved := newTask("task-b", valueobject.TaskStatusApproved)
+	approved.Extensions = []valueobject.ExtensionRequest{{
+		ID: "ext-1", TaskID: "task-b", RequesterID: "alice", OriginalDueDate: due, RequestedDueDate: due.Add(72 * time.Hour),
+		Reason: "blocked", Status: valueobject.ExtensionStatusPending, RequestedAt: due.Add(-24 * time.Hour),
+	}}
+
+	return []aggregate.TaskAggregate{inProgress, approved, newTask("task-c", valueobject.TaskStatusCompleted)}
+}
+
+func TestGetActionItems_ReviewerSeesPendingWork(t *testing.T) {
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, testutil.NewMemoryTaskRepository(newActionItemTasks()...), nil)
+
+	items, err := svc.GetActionItems(context.Background(), dto.ActionItemsRequest{UserID: "rita"})
+
+	require.NoError(t, err)
+	assert.Empty(t, items.InProgress)
+	assert.Empty(t, items.ExtensionDecisions)
+	require.Len(t, items.WorkReviews, 1)
+	assert.Equal(t, "task-a", items.WorkReviews[0].Task.ID)
+	assert.Equal(t, "ws-1", items.WorkReviews[0].Submission.ID)
+	assert.Equal(t, 1, items.Total)
+
+	// 已表态的审核人不再看到该提交
+	decided, err := svc.GetActionItems(context.Background(), dto.ActionItemsRequest{UserID: "ron"})
+	require.NoError(t, err)
+	assert.Zero(t, decided.Total)
+}
+
+func TestGetActionItems_ResponsibleAndApproverGroups(t *testing.T) {
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, testutil.NewMemoryTaskRepository(newActionItemTasks()...), nil)
+
+	responsible, err := svc.GetActionItems(context.Background(), dto.ActionItemsRequest{UserID: "alice"})
+	require.NoError(t, err)
+	require.Len(t, responsible.InProgress, 1, "only the in-progress task is listed, not approved or completed ones")
+	assert.Equal(t, "task-a", responsible.InProgress[0].ID)
+	assert.Empty(t, responsible.WorkReviews)
+	assert.Empty(t, responsible.ExtensionDecisions)
+
+	lead, err := svc.GetActionItems(context.Background(), dto.ActionItemsRequest{UserID: "lead"})
+	require.NoError(t, err)
+	assert.Empty(t, lead.InProgress)
+	assert.Empty(t, lead.WorkReviews, "configured reviewers replace the creator as work reviewer")
+	require.Len(t, lead.ExtensionDecisions, 1)
+	assert.Equal(t, "ext-1", lead.ExtensionDecisions[0].Extension.ID)
+	assert.Equal(t, "task-b", lead.ExtensionDecisions[0].Task.ID)
+
+	body, err := json.Marshal(lead)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"in_progress":[]`)
+}
+
+func TestGetActionItems_PagesByTask(t *testing.T) {
+	var tasks []aggregate.TaskAggregate
+	for i := 0; i < 25; i++ {
+		due := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour)
+		task := aggregate.NewTask(valueobject.TaskID(fmt.Sprintf("task-%02d", i)), "title", "", valueobject.TaskTypeRegular,
+			valueobject.TaskPriorityMedium, "project-1", "lead", "alice", &due)
+		task.Status = valueobject.TaskStatusInProgress
+		tasks = append(tasks, *task)
+	}
+	svc := NewTaskAppService(nil, passthroughTransactionManager{}, testutil.NewMemoryTaskRepository(tasks...), nil)
+
+	items, err := svc.GetActionItems(context.Background(), dto.ActionItemsRequest{UserID: "alice", Page: 3, PageSize: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, 25, items.TotalTasks)
+	assert.Equal(t, 5, items.Total)
+	assert.Equal(t, 3, items.Page)
+	assert.Equal(t, 10, items.PageSize)
+	require.Len(t, items.InProgress, 5)
+	assert.Equal(t, "task-20", items.InProgress[0].ID)
+}
@@ -45,10 +45,9 @@ func NewUserAppService(
 func (s *UserAppService) CreateUser(ctx context.Context, req *CreateUserRequest) (*UserResponse, error) {
 	// 使用事务：由于接口限制，需要类型断言
 	result, err := s.transactionMgr.WithTransactionResult(ctx, func(ctx context.Context) (interface{}, error) {
-		// 1. 验证邮箱是否已存在
-		existingUser, _ := s.userRepo.FindByEmail(ctx, req.Email)
-		if existingUser != nil {
-			return nil, fmt.Errorf("邮箱已存在: %s", req.Email)
+		// 1. 验证邮箱和用户名唯一性，违规时返回 *valueobject.ValidationError
+		if err := s.userDomainService.ValidateUserCreation(ctx, req.Email, req.Name); err != nil {
+			return nil, err
 		}
 
 		// 2. 验证输入数据
@@ -84,14 +83,9 @@ func (s *UserAppService) CreateUser(ctx context.Context, req *CreateUserRequest)
 		}
 
 		// 6. 返回结果
-		return &UserResponse{
-			ID:     string(user.ID),
-			Email:  user.Email,
-			Name:   user.Username,
-			Phone:  &req.Phone,
-			Status: string(user.Status),
-			Roles:  []string{string(user.Role)},
-		}, nil
+		response := newUserResponse(user, []string{string(user.Role)})
+		response.Phone = &req.Phone
+		return response, nil
 	})
 
 	if err != nil {
@@ -114,11 +108,7 @@ func (s *UserAppService) GetUser(ctx context.Context, id string) (*UserResponse,
 		return nil, fmt.Errorf("获取用户失败: %w", err)
 	}
 
-	return &UserResponse{
-		ID:    string(user.ID),
-		Email: user.Email,
-		Name:  user.Username,
-	}, nil
+	return newUserResponse(user, []string{string(user.Role)}), nil
 }
 
 // ListUsers 获取用户列表（不需要事务）
@@ -157,14 +147,7 @@ func (s *UserAppService) ListUsers(ctx context.Context, req *ListUsersRequest) (
 			roles = []string{string(user.Role)}
 		}
 
-		responses[i] = &UserResponse{
-			ID:     string(user.ID),
-			Email:  user.Email,
-			Name:   user.Username,
-			Phone:  nil, // TODO: 需要在User聚合中添加Phone字段
-			Status: string(user.Status),
-			Roles:  roles,
-		}
+		responses[i] = newUserResponse(user, roles)
 	}
 
 	return responses, total, nil
@@ -206,6 +189,15 @@ func (s *UserAppService) UpdateUserProfile(ctx context.Context, req *UpdateUserR
 			return fmt.Errorf("更新用户资料失败: %w", err)
 		}
 
+		if req.Timezone != "" {
+			if err := user.SetTimezone(req.Timezone); err != nil {
+				return fmt.Errorf("更新用户时区失败: %w", err)
+			}
+		}
+		if req.InAppNotifications != nil {
+			user.SetInAppNotifications(*req.InAppNotifications)
+		}
+
 		// 3. 保存更新
 		if err := s.userRepo.Update(ctx, user); err != nil {
 			return fmt.Errorf("更新用户失败: %w", err)
@@ -224,9 +216,11 @@ type CreateUserRequest struct {
 }
 
 type UpdateUserRequest struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Phone string `json:"phone,omitempty"`
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Phone              string `json:"phone,omitempty"`
+	Timezone           string `json:"timezone,omitempty"`
+	InAppNotifications *bool  `json:"in_app_notifications,omitempty"` // 为空时不修改站内通知开关
 }
 
 type ListUsersRequest struct {
@@ -237,12 +231,28 @@ type ListUsersRequest struct {
 }
 
 type UserResponse struct {
-	ID     string   `json:"id"`
-	Email  string   `json:"email"`
-	Name   string   `json:"name"`
-	Phone  *string  `json:"phone,omitempty"`
-	Status string   `json:"status"`
-	Roles  []string `json:"roles"`
+	ID                 string   `json:"id"`
+	Email              string   `json:"email"`
+	Name               string   `json:"name"`
+	Phone              *string  `json:"phone,omitempty"`
+	Status             string   `json:"status"`
+	Roles              []string `json:"roles"`
+	Timezone           string   `json:"timezone,omitempty"`
+	InAppNotifications bool     `json:"in_app_notifications"`
+}
+
+// newUserResponse 把用户聚合转换为用户响应，只复制允许对外展示的字段，密码哈希不会出现在响应中
+// Phone 暂未在用户聚合中保存，由调用方按需填充
+func newUserResponse(user *aggregate.User, roles []string) *UserResponse {
+	return &UserResponse{
+		ID:                 string(user.ID),
+		Email:              user.Email,
+		Name:               user.Username,
+		Status:             string(user.Status),
+		Roles:              roles,
+		Timezone:           user.Timezone,
+		InAppNotifications: user.InAppNotifications,
+	}
 }
 
 // 临时函数，实际项目中应该用UUID
@@ -271,14 +281,7 @@ func (s *UserAppService) AuthenticateUser(ctx context.Context, email, password s
 		roles = []string{string(user.Role)} // 使用用户当前角色作为默认
 	}
 
-	return &UserResponse{
-		ID:     string(user.ID),
-		Email:  user.Email,
-		Name:   user.Username,
-		Phone:  nil, // TODO: 需要在User聚合中添加Phone字段
-		Status: string(user.Status),
-		Roles:  roles,
-	}, nil
+	return newUserResponse(user, roles), nil
 }
 
 func generateUserID() string {
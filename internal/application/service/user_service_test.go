@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+func TestGetUser_ResponseOmitsPasswordHash(t *testing.T) {
+	const passwordHash = "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA"
+	user := aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", passwordHash, valueobject.UserRoleEmployee)
+	svc := NewUserAppService(nil, nil, nil, testutil.NewMemoryUserRepository(user), nil)
+
+	resp, err := svc.GetUser(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", resp.Name)
+	assert.Equal(t, string(user.Status), resp.Status)
+	assert.Equal(t, []string{string(valueobject.UserRoleEmployee)}, resp.Roles)
+
+	body, err := json.Marshal(resp)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "password")
+	assert.NotContains(t, string(body), passwordHash)
+}
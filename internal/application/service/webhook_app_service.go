@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// DefaultWebhookDeliveryPageSize 未指定 limit 时返回的投递记录数
+const DefaultWebhookDeliveryPageSize = 50
+
+// WebhookRedeliverer 以原投递ID重新投递webhook
+type WebhookRedeliverer interface {
+	Redeliver(ctx context.Context, webhook aggregate.Webhook, original aggregate.WebhookDelivery) (aggregate.WebhookDelivery, error)
+}
+
+// WebhookAppService webhook订阅管理应用服务
+type WebhookAppService struct {
+	webhookRepo repository.WebhookRepository
+	redeliverer WebhookRedeliverer
+}
+
+// NewWebhookAppService 创建webhook订阅管理应用服务
+func NewWebhookAppService(webhookRepo repository.WebhookRepository) *WebhookAppService {
+	return &WebhookAppService{webhookRepo: webhookRepo}
+}
+
+// WithRedeliverer 设置重投执行器，未设置时不支持手动重投
+func (s *WebhookAppService) WithRedeliverer(redeliverer WebhookRedeliverer) *WebhookAppService {
+	s.redeliverer = redeliverer
+	return s
+}
+
+// CreateWebhook 创建webhook订阅
+func (s *WebhookAppService) CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest, createdBy string) (*dto.WebhookResponse, error) {
+	webhook, err := aggregate.NewWebhook(uuid.New().String(), req.URL, req.Secret, req.EventTypes, valueobject.UserID(createdBy))
+	if err != nil {
+		return nil, event.NewDomainErrorWithCause(event.ErrInvalidInput, "webhook参数无效", err)
+	}
+
+	if err := s.webhookRepo.Create(ctx, *webhook); err != nil {
+		return nil, fmt.Errorf("保存webhook失败: %w", err)
+	}
+	return toWebhookResponse(webhook, shared.LocationFromContext(ctx)), nil
+}
+
+// UpdateWebhook 更新webhook订阅
+func (s *WebhookAppService) UpdateWebhook(ctx context.Context, id string, req dto.UpdateWebhookRequest) (*dto.WebhookResponse, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("查找webhook失败: %w", err)
+	}
+
+	if err := webhook.Update(req.URL, req.Secret, req.EventTypes); err != nil {
+		return nil, event.NewDomainErrorWithCause(event.ErrInvalidInput, "webhook参数无效", err)
+	}
+	if req.Active != nil {
+		if *req.Active {
+			webhook.Activate()
+		} else {
+			webhook.Deactivate()
+		}
+	}
+
+	if err := s.webhookRepo.Update(ctx, *webhook); err != nil {
+		return nil, fmt.Errorf("保存webhook失败: %w", err)
+	}
+	return toWebhookResponse(webhook, shared.LocationFromContext(ctx)), nil
+}
+
+// DeleteWebhook 删除webhook订阅
+func (s *WebhookAppService) DeleteWebhook(ctx context.Context, id string) error {
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("删除webhook失败: %w", err)
+	}
+	return nil
+}
+
+// GetWebhook 获取webhook订阅
+func (s *WebhookAppService) GetWebhook(ctx context.Context, id string) (*dto.WebhookResponse, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("查找webhook失败: %w", err)
+	}
+	return toWebhookResponse(webhook, shared.LocationFromContext(ctx)), nil
+}
+
+// ListWebhooks 获取全部webhook订阅
+func (s *WebhookAppService) ListWebhooks(ctx context.Context) ([]dto.WebhookResponse, error) {
+	webhooks, err := s.webhookRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询webhook失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	responses := make([]dto.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		responses[i] = *toWebhookResponse(&webhooks[i], loc)
+	}
+	return responses, nil
+}
+
+// ListDeliveries 获取webhook最近的投递记录，按投递时间倒序
+func (s *WebhookAppService) ListDeliveries(ctx context.Context, id string, req dto.ListWebhookDeliveriesRequest) ([]dto.WebhookDeliveryResponse, error) {
+	if _, err := s.webhookRepo.FindByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("查找webhook失败: %w", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultWebhookDeliveryPageSize
+	}
+	deliveries, err := s.webhookRepo.FindDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("查询投递记录失败: %w", err)
+	}
+
+	loc := shared.LocationFromContext(ctx)
+	responses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		responses[i] = toWebhookDeliveryResponse(d, loc)
+	}
+	return responses, nil
+}
+
+// RedeliverWebhook 以原投递ID重新发送投递记录中的原始请求体，订阅方可据投递ID去重
+func (s *WebhookAppService) RedeliverWebhook(ctx context.Context, webhookID, deliveryID string) (*dto.WebhookDeliveryResponse, error) {
+	if s.redeliverer == nil {
+		return nil, fmt.Errorf("未配置webhook重投")
+	}
+
+	webhook, err := s.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("查找webhook失败: %w", err)
+	}
+	attempts, err := s.webhookRepo.FindDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("查找投递记录失败: %w", err)
+	}
+
+	// 以最后一次尝试为准，重投的尝试次数在其基础上递增
+	last := attempts[len(attempts)-1]
+	if last.WebhookID != webhook.ID {
+		return nil, fmt.Errorf("查找投递记录失败: webhook delivery %s: %w", deliveryID, repository.ErrNotFound)
+	}
+	if len(last.Payload) == 0 {
+		return nil, event.NewDomainError(event.ErrInvalidState, "投递记录缺少原始请求体，无法重投")
+	}
+
+	delivery, err := s.redeliverer.Redeliver(ctx, *webhook, last)
+	if err != nil {
+		return nil, fmt.Errorf("重投webhook失败: %w", err)
+	}
+	response := toWebhookDeliveryResponse(delivery, shared.LocationFromContext(ctx))
+	return &response, nil
+}
+
+// toWebhookDeliveryResponse 转换为投递记录响应
+func toWebhookDeliveryResponse(d aggregate.WebhookDelivery, loc *time.Location) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		ID:          d.ID,
+		DeliveryID:  d.DeliveryID,
+		EventID:     d.EventID,
+		EventType:   d.EventType,
+		Attempt:     d.Attempt,
+		Redelivery:  d.Redelivery,
+		StatusCode:  d.StatusCode,
+		Success:     d.Success,
+		Error:       d.Error,
+		DurationMs:  d.Duration.Milliseconds(),
+		DeliveredAt: d.DeliveredAt.In(loc),
+	}
+}
+
+// toWebhookResponse 转换为webhook响应，不包含签名密钥
+func toWebhookResponse(w *aggregate.Webhook, loc *time.Location) *dto.WebhookResponse {
+	eventTypes := w.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	response := &dto.WebhookResponse{
+		ID:           w.ID,
+		URL:          w.URL,
+		EventTypes:   eventTypes,
+		Active:       w.Active,
+		FailureCount: w.FailureCount,
+		CreatedBy:    string(w.CreatedBy),
+		CreatedAt:    w.CreatedAt.In(loc),
+		UpdatedAt:    w.UpdatedAt.In(loc),
+	}
+	if w.DisabledAt != nil {
+		disabledAt := w.DisabledAt.In(loc)
+		response.DisabledAt = &disabledAt
+	}
+	return response
+}
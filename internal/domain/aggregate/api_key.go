@@ -0,0 +1,131 @@
+package aggregate
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// API密钥作用域的操作，作用域格式为 "<资源>:<操作>"，如 tasks:read
+const (
+	APIKeyActionRead  = "read"  // 只读请求（GET、HEAD）
+	APIKeyActionWrite = "write" // 修改请求，同时包含同一资源的 read
+)
+
+// APIKeyResources API密钥可以授权访问的资源，对应 /api/v1 下的一级路径
+var APIKeyResources = []string{"tasks", "projects", "users", "files", "stats", "search", "approvals", "events"}
+
+// APIKeyTokenPrefix 明文密钥的固定前缀，便于在日志和代码扫描中识别泄露的密钥
+const APIKeyTokenPrefix = "tfk_"
+
+// apiKeyDisplayLength 保存并展示的明文前缀长度，用于用户区分自己的多个密钥
+const apiKeyDisplayLength = 12
+
+// APIKey 用户的API密钥，以用户身份访问接口，作为JWT之外的认证方式
+// 只保存密钥的SHA-256哈希，明文仅在创建时返回一次
+type APIKey struct {
+	ID         string
+	UserID     valueobject.UserID
+	Name       string
+	Prefix     string // 明文密钥的前若干位
+	KeyHash    string // 明文密钥的SHA-256十六进制哈希
+	Scopes     []string
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewAPIKey 为用户生成API密钥，返回密钥及其明文
+func NewAPIKey(id string, userID valueobject.UserID, name string, scopes []string) (*APIKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", fmt.Errorf("api key name cannot be empty")
+	}
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("api key must have at least one scope")
+	}
+	for _, scope := range scopes {
+		if !IsValidAPIKeyScope(scope) {
+			return nil, "", fmt.Errorf("unknown api key scope %q, expected <resource>:read or <resource>:write with resource one of: %s",
+				scope, strings.Join(APIKeyResources, ", "))
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	raw := APIKeyTokenPrefix + hex.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        id,
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    raw[:apiKeyDisplayLength],
+		KeyHash:   HashAPIKey(raw),
+		Scopes:    append([]string(nil), scopes...),
+		CreatedAt: time.Now(),
+	}, raw, nil
+}
+
+// HashAPIKey 计算明文密钥的SHA-256十六进制哈希，认证时按哈希查找密钥
+func HashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsRevoked 密钥是否已吊销
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Revoke 吊销密钥，已吊销时保留原吊销时间
+func (k *APIKey) Revoke() {
+	if k.RevokedAt != nil {
+		return
+	}
+	now := time.Now()
+	k.RevokedAt = &now
+}
+
+// MarkUsed 记录最近一次使用时间
+func (k *APIKey) MarkUsed(at time.Time) {
+	k.LastUsedAt = &at
+}
+
+// HasScope 密钥是否具有指定作用域，resource:write 包含 resource:read
+func (k *APIKey) HasScope(scope string) bool {
+	resource, action, ok := strings.Cut(scope, ":")
+	if !ok {
+		return false
+	}
+	for _, s := range k.Scopes {
+		if s == scope || (action == APIKeyActionRead && s == APIKeyScope(resource, APIKeyActionWrite)) {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyScope 拼接资源和操作对应的作用域
+func APIKeyScope(resource, action string) string {
+	return resource + ":" + action
+}
+
+// IsValidAPIKeyScope 作用域的资源和操作是否都在允许范围内
+func IsValidAPIKeyScope(scope string) bool {
+	resource, action, ok := strings.Cut(scope, ":")
+	if !ok || (action != APIKeyActionRead && action != APIKeyActionWrite) {
+		return false
+	}
+	for _, r := range APIKeyResources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
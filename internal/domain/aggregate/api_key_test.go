@@ -0,0 +1,55 @@
+package aggregate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey_StoresOnlyHash(t *testing.T) {
+	key, raw, err := NewAPIKey("key-1", "user-1", "ci", []string{"tasks:read"})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(raw, APIKeyTokenPrefix))
+	assert.Equal(t, HashAPIKey(raw), key.KeyHash)
+	assert.NotContains(t, key.KeyHash, raw)
+	assert.True(t, strings.HasPrefix(raw, key.Prefix))
+	assert.Less(t, len(key.Prefix), len(raw))
+
+	_, other, err := NewAPIKey("key-2", "user-1", "ci", []string{"tasks:read"})
+	require.NoError(t, err)
+	assert.NotEqual(t, raw, other)
+}
+
+func TestNewAPIKey_RejectsInvalidInput(t *testing.T) {
+	_, _, err := NewAPIKey("key-1", "user-1", " ", []string{"tasks:read"})
+	assert.Error(t, err)
+	_, _, err = NewAPIKey("key-1", "user-1", "ci", nil)
+	assert.Error(t, err)
+	for _, scope := range []string{"admin", "read", "tasks", "tasks:delete", "webhooks:read", ":read"} {
+		_, _, err = NewAPIKey("key-1", "user-1", "ci", []string{scope})
+		assert.Error(t, err, scope)
+	}
+}
+
+func TestAPIKey_HasScopeAndRevoke(t *testing.T) {
+	readOnly, _, err := NewAPIKey("key-1", "user-1", "ci", []string{"tasks:read"})
+	require.NoError(t, err)
+	writer, _, err := NewAPIKey("key-2", "user-1", "ci", []string{"tasks:write"})
+	require.NoError(t, err)
+
+	assert.True(t, readOnly.HasScope("tasks:read"))
+	assert.False(t, readOnly.HasScope("tasks:write"))
+	assert.True(t, writer.HasScope("tasks:read"))
+	assert.True(t, writer.HasScope("tasks:write"))
+	assert.False(t, writer.HasScope("projects:read"))
+	assert.False(t, writer.HasScope(""))
+
+	readOnly.Revoke()
+	require.True(t, readOnly.IsRevoked())
+	revokedAt := *readOnly.RevokedAt
+	readOnly.Revoke()
+	assert.Equal(t, revokedAt, *readOnly.RevokedAt)
+}
@@ -0,0 +1,147 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// Approval 多步骤审批，步骤按顺序处理，审批人只能处理当前步骤
+type Approval struct {
+	ID          valueobject.ApprovalID
+	Type        valueobject.ApprovalType
+	Title       string
+	RequesterID valueobject.UserID
+	Status      valueobject.ApprovalStatus
+	Steps       []valueobject.ApprovalStep
+	CurrentStep *string // 当前待处理步骤ID，审批结束后为空
+	History     []valueobject.ApprovalHistory
+	SubmittedAt time.Time
+	CompletedAt *time.Time
+}
+
+// 审批错误定义
+var (
+	ErrApprovalNoSteps           = NewDomainError("APPROVAL_NO_STEPS", "approval must have at least one step")
+	ErrApprovalNotPending        = NewDomainError("APPROVAL_NOT_PENDING", "approval is not pending")
+	ErrApprovalStepNotFound      = NewDomainError("APPROVAL_STEP_NOT_FOUND", "approval step not found")
+	ErrApprovalStepNotCurrent    = NewDomainError("APPROVAL_STEP_NOT_CURRENT", "only the current approval step can be acted on")
+	ErrApprovalNotStepApprover   = NewDomainError("APPROVAL_NOT_STEP_APPROVER", "user is not the approver of the current step")
+	ErrApprovalCannotReturn      = NewDomainError("APPROVAL_CANNOT_RETURN", "the first approval step cannot be returned")
+	ErrApprovalUnsupportedAction = NewDomainError("APPROVAL_UNSUPPORTED_ACTION", "approval action is not supported on a step")
+)
+
+// NewApproval 创建审批，所有步骤置为待审批，从第一步开始处理
+func NewApproval(id valueobject.ApprovalID, approvalType valueobject.ApprovalType, title string, requesterID valueobject.UserID, steps []valueobject.ApprovalStep) (*Approval, error) {
+	if len(steps) == 0 {
+		return nil, ErrApprovalNoSteps
+	}
+
+	approvalSteps := make([]valueobject.ApprovalStep, len(steps))
+	for i, step := range steps {
+		step.Status = valueobject.ApprovalStatusPending
+		step.Action = nil
+		step.ProcessedAt = nil
+		approvalSteps[i] = step
+	}
+	current := approvalSteps[0].StepID
+
+	return &Approval{
+		ID:          id,
+		Type:        approvalType,
+		Title:       title,
+		RequesterID: requesterID,
+		Status:      valueobject.ApprovalStatusPending,
+		Steps:       approvalSteps,
+		CurrentStep: &current,
+		SubmittedAt: time.Now(),
+	}, nil
+}
+
+// Act 审批人处理当前步骤
+// approve 推进到下一步骤，最后一步通过后审批完成；reject 结束审批；return 退回上一步骤重新处理
+func (a *Approval) Act(actorID valueobject.UserID, req valueobject.ApprovalActionRequest) error {
+	if a.Status != valueobject.ApprovalStatusPending || a.CurrentStep == nil {
+		return ErrApprovalNotPending
+	}
+
+	index := a.stepIndex(req.StepID)
+	if index < 0 {
+		return ErrApprovalStepNotFound
+	}
+	if req.StepID != *a.CurrentStep {
+		return ErrApprovalStepNotCurrent
+	}
+	step := &a.Steps[index]
+	if !step.CanBeProcessedBy(actorID) {
+		return ErrApprovalNotStepApprover
+	}
+
+	now := time.Now()
+	switch req.Action {
+	case valueobject.ApprovalActionApprove:
+		processStep(step, req, valueobject.ApprovalStatusApproved, now)
+		if index == len(a.Steps)-1 {
+			a.complete(valueobject.ApprovalStatusApproved, now)
+		} else {
+			next := a.Steps[index+1].StepID
+			a.CurrentStep = &next
+		}
+	case valueobject.ApprovalActionReject:
+		processStep(step, req, valueobject.ApprovalStatusRejected, now)
+		a.complete(valueobject.ApprovalStatusRejected, now)
+	case valueobject.ApprovalActionReturn:
+		if index == 0 {
+			return ErrApprovalCannotReturn
+		}
+		// 上一步骤需要重新处理
+		previous := &a.Steps[index-1]
+		previous.Status = valueobject.ApprovalStatusPending
+		previous.Action = nil
+		previous.Comment = ""
+		previous.ProcessedAt = nil
+		stepID := previous.StepID
+		a.CurrentStep = &stepID
+	default:
+		return ErrApprovalUnsupportedAction
+	}
+
+	a.History = append(a.History, valueobject.ApprovalHistory{
+		ID:          fmt.Sprintf("%s-%d", a.ID, len(a.History)+1),
+		ApprovalID:  a.ID,
+		StepID:      req.StepID,
+		Action:      req.Action,
+		ActorID:     actorID,
+		Comment:     req.Comment,
+		Attachments: req.Attachments,
+		ProcessedAt: now,
+	})
+	return nil
+}
+
+// processStep 记录当前步骤的处理结果
+func processStep(step *valueobject.ApprovalStep, req valueobject.ApprovalActionRequest, status valueobject.ApprovalStatus, now time.Time) {
+	action := req.Action
+	step.Status = status
+	step.Action = &action
+	step.Comment = req.Comment
+	step.ProcessedAt = &now
+}
+
+// complete 结束审批
+func (a *Approval) complete(status valueobject.ApprovalStatus, now time.Time) {
+	a.Status = status
+	a.CurrentStep = nil
+	a.CompletedAt = &now
+}
+
+// stepIndex 查找步骤位置，不存在时返回 -1
+func (a *Approval) stepIndex(stepID string) int {
+	for i, step := range a.Steps {
+		if step.StepID == stepID {
+			return i
+		}
+	}
+	return -1
+}
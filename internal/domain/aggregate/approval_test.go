@@ -0,0 +1,114 @@
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// newThreeStepApproval 部门、事业部、公司三级审批
+func newThreeStepApproval(t *testing.T) *Approval {
+	t.Helper()
+	approval, err := NewApproval("approval-1", valueobject.ApprovalTypeExpense, "Conference travel", "requester-1", []valueobject.ApprovalStep{
+		{StepID: "department", Level: valueobject.ApprovalLevelDepartment, ApproverID: "approver-1"},
+		{StepID: "division", Level: valueobject.ApprovalLevelDivision, ApproverID: "approver-2"},
+		{StepID: "company", Level: valueobject.ApprovalLevelCompany, ApproverID: "approver-3"},
+	})
+	require.NoError(t, err)
+	return approval
+}
+
+func approvalAction(stepID string, action valueobject.ApprovalAction) valueobject.ApprovalActionRequest {
+	return valueobject.ApprovalActionRequest{ApprovalID: "approval-1", StepID: stepID, Action: action}
+}
+
+func TestNewApproval_StartsAtFirstStep(t *testing.T) {
+	approval := newThreeStepApproval(t)
+
+	require.NotNil(t, approval.CurrentStep)
+	assert.Equal(t, "department", *approval.CurrentStep)
+	assert.Equal(t, valueobject.ApprovalStatusPending, approval.Status)
+
+	_, err := NewApproval("approval-2", valueobject.ApprovalTypeExpense, "Empty", "requester-1", nil)
+	assert.ErrorIs(t, err, ErrApprovalNoSteps)
+}
+
+func TestApproval_Act_RejectsFutureStep(t *testing.T) {
+	approval := newThreeStepApproval(t)
+
+	err := approval.Act("approver-2", approvalAction("division", valueobject.ApprovalActionApprove))
+
+	assert.ErrorIs(t, err, ErrApprovalStepNotCurrent)
+	assert.Equal(t, "department", *approval.CurrentStep)
+	assert.Equal(t, valueobject.ApprovalStatusPending, approval.Steps[1].Status)
+	assert.Empty(t, approval.History)
+}
+
+func TestApproval_Act_RejectsUnknownStepAndWrongApprover(t *testing.T) {
+	approval := newThreeStepApproval(t)
+
+	assert.ErrorIs(t, approval.Act("approver-1", approvalAction("board", valueobject.ApprovalActionApprove)), ErrApprovalStepNotFound)
+	assert.ErrorIs(t, approval.Act("approver-2", approvalAction("department", valueobject.ApprovalActionApprove)), ErrApprovalNotStepApprover)
+}
+
+func TestApproval_Act_ApproveAdvancesToNextStep(t *testing.T) {
+	approval := newThreeStepApproval(t)
+
+	require.NoError(t, approval.Act("approver-1", approvalAction("department", valueobject.ApprovalActionApprove)))
+
+	assert.Equal(t, "division", *approval.CurrentStep)
+	assert.Equal(t, valueobject.ApprovalStatusApproved, approval.Steps[0].Status)
+	require.NotNil(t, approval.Steps[0].ProcessedAt)
+	require.Len(t, approval.History, 1)
+	assert.Equal(t, "department", approval.History[0].StepID)
+
+	// 已处理的步骤不能再次处理
+	err := approval.Act("approver-1", approvalAction("department", valueobject.ApprovalActionApprove))
+	assert.ErrorIs(t, err, ErrApprovalStepNotCurrent)
+
+	require.NoError(t, approval.Act("approver-2", approvalAction("division", valueobject.ApprovalActionApprove)))
+	require.NoError(t, approval.Act("approver-3", approvalAction("company", valueobject.ApprovalActionApprove)))
+	assert.Equal(t, valueobject.ApprovalStatusApproved, approval.Status)
+	assert.Nil(t, approval.CurrentStep)
+	assert.NotNil(t, approval.CompletedAt)
+	assert.ErrorIs(t, approval.Act("approver-3", approvalAction("company", valueobject.ApprovalActionApprove)), ErrApprovalNotPending)
+}
+
+func TestApproval_Act_ReturnMovesBackOneStep(t *testing.T) {
+	approval := newThreeStepApproval(t)
+	require.NoError(t, approval.Act("approver-1", approvalAction("department", valueobject.ApprovalActionApprove)))
+
+	require.NoError(t, approval.Act("approver-2", approvalAction("division", valueobject.ApprovalActionReturn)))
+
+	assert.Equal(t, "department", *approval.CurrentStep)
+	assert.Equal(t, valueobject.ApprovalStatusPending, approval.Steps[0].Status)
+	assert.Nil(t, approval.Steps[0].ProcessedAt)
+	assert.Equal(t, valueobject.ApprovalStatusPending, approval.Status)
+	require.Len(t, approval.History, 2)
+	assert.Equal(t, valueobject.ApprovalActionReturn, approval.History[1].Action)
+
+	// 第一步无法再退回
+	err := approval.Act("approver-1", approvalAction("department", valueobject.ApprovalActionReturn))
+	assert.ErrorIs(t, err, ErrApprovalCannotReturn)
+}
+
+func TestApproval_Act_RejectEndsApproval(t *testing.T) {
+	approval := newThreeStepApproval(t)
+
+	require.NoError(t, approval.Act("approver-1", approvalAction("department", valueobject.ApprovalActionReject)))
+
+	assert.Equal(t, valueobject.ApprovalStatusRejected, approval.Status)
+	assert.Nil(t, approval.CurrentStep)
+	assert.Equal(t, valueobject.ApprovalStatusPending, approval.Steps[1].Status)
+}
+
+func TestApproval_Act_DelegateeProcessesStep(t *testing.T) {
+	approval := newThreeStepApproval(t)
+	delegate := valueobject.UserID("deputy-1")
+	approval.Steps[0].DelegatedTo = &delegate
+
+	assert.ErrorIs(t, approval.Act("approver-1", approvalAction("department", valueobject.ApprovalActionApprove)), ErrApprovalNotStepApprover)
+	require.NoError(t, approval.Act("deputy-1", approvalAction("department", valueobject.ApprovalActionApprove)))
+}
@@ -11,12 +11,13 @@ import (
 type ProjectAggregate interface {
 
 	// 业务行为方法
-	UpdateBasicInfo(name, description string) error
+	UpdateBasicInfo(name, description string, updatedBy valueobject.UserID) error
 	AssignManager(managerID valueobject.UserID, assignedBy valueobject.UserID) error
 	AddMember(userID valueobject.UserID, role valueobject.ProjectRole, addedBy valueobject.UserID) error
 	RemoveMember(userID valueobject.UserID, removedBy valueobject.UserID) error
 	UpdateMemberRole(userID valueobject.UserID, newRole valueobject.ProjectRole, updatedBy valueobject.UserID) error
 	CreateSubProject(subProjectID valueobject.ProjectID, name, description string, createdBy valueobject.UserID) (ProjectAggregate, error)
+	ChangeParent(newParentID valueobject.ProjectID, changedBy valueobject.UserID) error
 
 	// 状态管理
 	Activate(activatedBy valueobject.UserID) error
@@ -51,6 +52,19 @@ type ProjectSearchCriteria struct {
 	Offset      int
 	OrderBy     string
 	OrderDir    string
+
+	// PrefixMatch 名称和描述按前缀匹配，可以使用索引；默认为包含匹配
+	// 关键字规范化后不足 valueobject.MinSearchTermLength 个字符时不作为过滤条件
+	PrefixMatch bool
+
+	// AccessibleBy 仅返回该用户拥有、管理或参与的项目，为空表示不做访问过滤
+	AccessibleBy *valueobject.UserID
+}
+
+// ProjectOverdueSummary 有逾期任务的项目及其逾期任务数，用于项目组合健康视图
+type ProjectOverdueSummary struct {
+	Project      Project
+	OverdueTasks int
 }
 
 // ProjectStatistics 项目统计信息
@@ -70,21 +84,29 @@ type ProjectStatistics struct {
 
 // ProjectFactory 项目工厂 - Go风格：返回具体类型
 type ProjectFactory struct {
-	// 可以注入依赖，如ID生成器、验证器等
+	idGenerator valueobject.IDGenerator
 }
 
-// NewProjectFactory 创建项目工厂
-func NewProjectFactory() *ProjectFactory {
-	return &ProjectFactory{}
+// NewProjectFactory 创建项目工厂，未指定ID生成器时使用UUID
+func NewProjectFactory(idGenerator valueobject.IDGenerator) *ProjectFactory {
+	if idGenerator == nil {
+		idGenerator = valueobject.NewUUIDGenerator()
+	}
+	return &ProjectFactory{
+		idGenerator: idGenerator,
+	}
 }
 
-// CreateProject 创建新项目 - 返回具体类型
+// CreateProject 创建新项目 - 返回具体类型，id为空时自动生成
 func (f *ProjectFactory) CreateProject(
 	id valueobject.ProjectID,
 	name, description string,
 	projectType valueobject.ProjectType,
 	ownerID valueobject.UserID,
 ) *Project {
+	if id == "" {
+		id = f.idGenerator.GenerateProjectID()
+	}
 	return NewProject(id, name, description, projectType, ownerID)
 }
 
@@ -95,6 +117,9 @@ func (f *ProjectFactory) CreateSubProject(
 	name, description string,
 	createdBy valueobject.UserID,
 ) (*Project, error) {
+	if id == "" {
+		id = f.idGenerator.GenerateProjectID()
+	}
 	subProject, err := parent.CreateSubProject(id, name, description, createdBy)
 	if err != nil {
 		return nil, err
@@ -113,9 +138,13 @@ func (f *ProjectFactory) RestoreProject(data ProjectData) *Project {
 		ProjectType:    valueobject.ProjectType(data.Type),
 		Status:         valueobject.ProjectStatus(data.Status),
 		OwnerID:        valueobject.UserID(data.OwnerID),
+		StartDate:      data.StartDate,
+		EndDate:        data.EndDate,
 		CreatedAt:      data.CreatedAt,
 		UpdatedAt:      data.UpdatedAt,
 		DeletedAt:      data.DeletedAt,
+		CreatedBy:      valueobject.UserID(data.CreatedBy),
+		UpdatedBy:      valueobject.UserID(data.UpdatedBy),
 		TaskCount:      data.TaskCount,
 		CompletedTasks: data.CompletedTasks,
 		Events:         make([]event.DomainEvent, 0),
@@ -165,6 +194,8 @@ type ProjectData struct {
 	CreatedAt      time.Time           `json:"created_at"`
 	UpdatedAt      time.Time           `json:"updated_at"`
 	DeletedAt      *time.Time          `json:"deleted_at"`
+	CreatedBy      string              `json:"created_by"`
+	UpdatedBy      string              `json:"updated_by"`
 	Members        []ProjectMemberData `json:"members"`
 	Children       []string            `json:"children"`
 	TaskCount      int                 `json:"task_count"`
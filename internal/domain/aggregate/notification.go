@@ -0,0 +1,30 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// Notification 站内通知，由领域事件触发写入，接收人可标记已读
+type Notification struct {
+	ID           string
+	UserID       valueobject.UserID
+	Type         string // 触发通知的事件类型
+	Title        string
+	Body         string
+	ResourceType string // 关联资源类型，如 task
+	ResourceID   string
+	Read         bool
+	ReadAt       *time.Time
+	CreatedAt    time.Time
+}
+
+// MarkRead 标记为已读，已读的通知保持原已读时间
+func (n *Notification) MarkRead(now time.Time) {
+	if n.Read {
+		return
+	}
+	n.Read = true
+	n.ReadAt = &now
+}
@@ -0,0 +1,33 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// 操作日志的操作类型
+const (
+	OperationImpersonate = "impersonate" // 管理员开始模拟用户
+)
+
+// OperationLog 操作日志，记录谁在什么资源上执行了什么操作
+// 模拟登录期间的操作记录被模拟的用户，并在 ImpersonatorID 中记录实际操作的管理员
+type OperationLog struct {
+	ID             string
+	UserID         valueobject.UserID
+	ImpersonatorID *valueobject.UserID
+	Operation      string
+	ResourceType   string
+	ResourceID     string
+	IPAddress      string
+	UserAgent      string
+	RequestData    map[string]interface{}
+	ResponseStatus int
+	CreatedAt      time.Time
+}
+
+// IsImpersonated 操作是否在模拟登录期间执行
+func (l *OperationLog) IsImpersonated() bool {
+	return l.ImpersonatorID != nil
+}
@@ -2,7 +2,9 @@ package aggregate
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/valueobject"
@@ -32,14 +34,23 @@ type Project struct {
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 
+	// 审计信息，由仓储在写入时按当前操作用户记录
+	CreatedBy valueobject.UserID
+	UpdatedBy valueobject.UserID
+
 	// 统计信息
 	TaskCount      int
 	CompletedTasks int
 
 	// 领域事件
 	Events []event.DomainEvent
+
+	textLimits valueobject.TextLimits
 }
 
+// ErrProjectEndBeforeStart 项目结束日期早于开始日期
+var ErrProjectEndBeforeStart = NewDomainError("PROJECT_END_BEFORE_START", "project end date cannot be before start date")
+
 // NewProject 创建新项目
 func NewProject(
 	id valueobject.ProjectID,
@@ -67,11 +78,17 @@ func NewProject(
 	return project
 }
 
-// UpdateBasicInfo 更新基本信息
-func (p *Project) UpdateBasicInfo(name, description string) error {
+// UpdateBasicInfo 更新基本信息，updatedBy 记录为项目更新事件的操作人
+// 名称去除首尾空白、描述去除末尾空白后校验长度，超长时返回 *valueobject.ValidationError
+func (p *Project) UpdateBasicInfo(name, description string, updatedBy valueobject.UserID) error {
+	name = strings.TrimSpace(name)
+	description = strings.TrimRightFunc(description, unicode.IsSpace)
 	if name == "" {
 		return fmt.Errorf("project name cannot be empty")
 	}
+	if err := p.textLimits.WithDefaults(valueobject.DefaultProjectTextLimits).Validate("项目更新", "name", name, description); err != nil {
+		return err
+	}
 
 	oldName := p.Name
 	p.Name = name
@@ -80,9 +97,26 @@ func (p *Project) UpdateBasicInfo(name, description string) error {
 
 	if oldName != name {
 		// 发布项目更新事件
-		p.addEvent(event.NewProjectUpdatedEvent(p.ID, oldName, name, valueobject.UserID("")))
+		p.addEvent(event.NewProjectUpdatedEvent(p.ID, oldName, name, updatedBy))
+	}
+
+	return nil
+}
+
+// SetTextLimits 设置名称和描述的长度上限，非正数时使用默认值
+func (p *Project) SetTextLimits(limits valueobject.TextLimits) {
+	p.textLimits = limits
+}
+
+// UpdateSchedule 设置项目起止日期，结束日期不能早于开始日期
+func (p *Project) UpdateSchedule(startDate time.Time, endDate *time.Time) error {
+	if err := validateProjectDates(startDate, endDate); err != nil {
+		return err
 	}
 
+	p.StartDate = startDate
+	p.EndDate = endDate
+	p.UpdatedAt = time.Now()
 	return nil
 }
 
@@ -121,7 +155,7 @@ func (p *Project) AssignManager(managerID valueobject.UserID, assignedBy valueob
 		}
 	}
 	// 发布事件
-	p.addEvent(event.NewProjectManagerAssignedEvent(p.ID, oldManagerID, &managerID, valueobject.UserID("")))
+	p.addEvent(event.NewProjectManagerAssignedEvent(p.ID, oldManagerID, &managerID, assignedBy))
 
 	return nil
 }
@@ -153,7 +187,7 @@ func (p *Project) AddMember(userID valueobject.UserID, role valueobject.ProjectR
 	p.Members = append(p.Members, member)
 	p.UpdatedAt = time.Now()
 	// 发布事件
-	p.addEvent(event.NewProjectMemberAddedEvent(p.ID, userID, role, valueobject.UserID("")))
+	p.addEvent(event.NewProjectMemberAddedEvent(p.ID, userID, role, addedBy))
 
 	return nil
 }
@@ -182,7 +216,7 @@ func (p *Project) RemoveMember(userID valueobject.UserID, removedBy valueobject.
 			p.UpdatedAt = time.Now()
 
 			// 发布事件
-			p.addEvent(event.NewProjectMemberRemovedEvent(p.ID, userID, member.Role, valueobject.UserID("")))
+			p.addEvent(event.NewProjectMemberRemovedEvent(p.ID, userID, member.Role, removedBy))
 
 			return nil
 		}
@@ -256,6 +290,47 @@ func (p *Project) CreateSubProject(subProjectID valueobject.ProjectID, name, des
 	return subProject, nil
 }
 
+// ChangeParent 将项目移动到新的父项目下，移动权限和跨层级的循环引用由领域服务校验
+func (p *Project) ChangeParent(newParentID valueobject.ProjectID, changedBy valueobject.UserID) error {
+	if newParentID == p.ID {
+		return fmt.Errorf("project cannot be parent of itself")
+	}
+
+	if p.ParentID != nil && *p.ParentID == newParentID {
+		return nil
+	}
+
+	oldParentID := p.ParentID
+	p.ParentID = &newParentID
+	p.UpdatedAt = time.Now()
+
+	p.addEvent(event.NewProjectParentChangedEvent(p.ID, oldParentID, newParentID, changedBy))
+
+	return nil
+}
+
+// AttachChild 将项目加入子项目列表
+func (p *Project) AttachChild(childID valueobject.ProjectID) {
+	for _, id := range p.Children {
+		if id == childID {
+			return
+		}
+	}
+	p.Children = append(p.Children, childID)
+	p.UpdatedAt = time.Now()
+}
+
+// DetachChild 将项目从子项目列表中移除
+func (p *Project) DetachChild(childID valueobject.ProjectID) {
+	for i, id := range p.Children {
+		if id == childID {
+			p.Children = append(p.Children[:i], p.Children[i+1:]...)
+			p.UpdatedAt = time.Now()
+			return
+		}
+	}
+}
+
 // Activate 激活项目
 func (p *Project) Activate(activatedBy valueobject.UserID) error {
 	if !p.canManageProject(activatedBy) {
@@ -266,21 +341,26 @@ func (p *Project) Activate(activatedBy valueobject.UserID) error {
 		return fmt.Errorf("project is already active")
 	}
 
+	// 已暂停的项目按恢复处理，保留原开始日期
+	if p.Status == valueobject.ProjectStatusPaused {
+		return p.Resume(activatedBy)
+	}
+
 	if p.Status == valueobject.ProjectStatusCompleted || p.Status == valueobject.ProjectStatusCancelled {
 		return fmt.Errorf("cannot activate completed or cancelled project")
 	}
 
-	oldStatus := p.Status
-	p.Status = valueobject.ProjectStatusActive
-	p.StartDate = time.Now()
-	p.UpdatedAt = time.Now()
+	// 已设置的开始日期（手动指定或首次激活时记录）保持不变
+	startDate := p.StartDate
+	if startDate.IsZero() {
+		startDate = time.Now()
+	}
+	if err := validateProjectDates(startDate, p.EndDate); err != nil {
+		return err
+	}
 
-	p.addEvent(&event.ProjectStatusChangedEvent{
-		ProjectID: p.ID,
-		OldStatus: oldStatus,
-		NewStatus: valueobject.ProjectStatusActive,
-		ChangedBy: activatedBy,
-	})
+	p.StartDate = startDate
+	p.changeStatus(valueobject.ProjectStatusActive, activatedBy, "Project started")
 
 	return nil
 }
@@ -295,11 +375,25 @@ func (p *Project) Pause(pausedBy valueobject.UserID, reason string) error {
 		return fmt.Errorf("only active project can be paused")
 	}
 
-	//oldStatus := p.Status
-	p.Status = valueobject.ProjectStatusPaused
-	p.UpdatedAt = time.Now()
-	// 发布事件
-	p.addEvent(event.NewProjectStatusChangedEvent(p.ID, p.Status, valueobject.ProjectStatusActive, valueobject.UserID(""), "Project started"))
+	if reason == "" {
+		reason = "Project paused"
+	}
+	p.changeStatus(valueobject.ProjectStatusPaused, pausedBy, reason)
+
+	return nil
+}
+
+// Resume 恢复已暂停的项目，开始日期保持不变
+func (p *Project) Resume(resumedBy valueobject.UserID) error {
+	if !p.canManageProject(resumedBy) {
+		return fmt.Errorf("insufficient permission to resume project")
+	}
+
+	if p.Status != valueobject.ProjectStatusPaused {
+		return fmt.Errorf("only paused project can be resumed")
+	}
+
+	p.changeStatus(valueobject.ProjectStatusActive, resumedBy, "Project resumed")
 
 	return nil
 }
@@ -319,18 +413,13 @@ func (p *Project) Complete(completedBy valueobject.UserID) error {
 		return fmt.Errorf("cannot complete project with pending tasks")
 	}
 
-	oldStatus := p.Status
-	p.Status = valueobject.ProjectStatusCompleted
 	now := time.Now()
-	p.EndDate = &now
-	p.UpdatedAt = now
+	if err := validateProjectDates(p.StartDate, &now); err != nil {
+		return err
+	}
 
-	p.addEvent(&event.ProjectStatusChangedEvent{
-		ProjectID: p.ID,
-		OldStatus: oldStatus,
-		NewStatus: valueobject.ProjectStatusCompleted,
-		ChangedBy: completedBy,
-	})
+	p.EndDate = &now
+	p.changeStatus(valueobject.ProjectStatusCompleted, completedBy, "Project completed")
 
 	return nil
 }
@@ -345,23 +434,24 @@ func (p *Project) Cancel(cancelledBy valueobject.UserID, reason string) error {
 		return fmt.Errorf("cannot cancel completed or already cancelled project")
 	}
 
-	oldStatus := p.Status
-	p.Status = valueobject.ProjectStatusCancelled
 	now := time.Now()
 	p.EndDate = &now
-	p.UpdatedAt = now
-
-	p.addEvent(&event.ProjectStatusChangedEvent{
-		ProjectID: p.ID,
-		OldStatus: oldStatus,
-		NewStatus: valueobject.ProjectStatusCancelled,
-		ChangedBy: cancelledBy,
-		Reason:    reason,
-	})
+	if reason == "" {
+		reason = "Project cancelled"
+	}
+	p.changeStatus(valueobject.ProjectStatusCancelled, cancelledBy, reason)
 
 	return nil
 }
 
+// changeStatus 变更项目状态并发布状态变更事件，事件记录变更前后的状态
+func (p *Project) changeStatus(newStatus valueobject.ProjectStatus, changedBy valueobject.UserID, reason string) {
+	oldStatus := p.Status
+	p.Status = newStatus
+	p.UpdatedAt = time.Now()
+	p.addEvent(event.NewProjectStatusChangedEvent(p.ID, oldStatus, newStatus, changedBy, reason))
+}
+
 // Delete 软删除项目
 func (p *Project) Delete(deletedBy valueobject.UserID) error {
 	// 只有所有者可以删除项目
@@ -383,7 +473,7 @@ func (p *Project) Delete(deletedBy valueobject.UserID) error {
 	p.DeletedAt = &now
 	p.UpdatedAt = now
 	// 发布删除事件
-	p.addEvent(event.NewProjectDeletedEvent(p.ID, valueobject.UserID("")))
+	p.addEvent(event.NewProjectDeletedEvent(p.ID, deletedBy))
 
 	return nil
 }
@@ -409,6 +499,19 @@ func (p *Project) CanUserAccess(userID valueobject.UserID) bool {
 	return p.isMember(userID)
 }
 
+// CanUserManage 检查用户是否可以修改项目信息（所有者或管理者）
+func (p *Project) CanUserManage(userID valueobject.UserID) bool {
+	return p.canManageProject(userID)
+}
+
+// IsActiveWithin 检查项目在时间窗口内是否处于进行期（边界包含）
+func (p *Project) IsActiveWithin(from, to time.Time) bool {
+	if p.StartDate.After(to) {
+		return false
+	}
+	return p.EndDate == nil || !p.EndDate.Before(from)
+}
+
 // GetMemberRole 获取成员角色
 func (p *Project) GetMemberRole(userID valueobject.UserID) *valueobject.ProjectRole {
 	if userID == p.OwnerID {
@@ -480,6 +583,17 @@ func (p *Project) canManageProject(userID valueobject.UserID) bool {
 	return p.canManageMembers(userID)
 }
 
+// validateProjectDates 校验结束日期不早于开始日期，未设置的日期不参与校验
+func validateProjectDates(startDate time.Time, endDate *time.Time) error {
+	if startDate.IsZero() || endDate == nil {
+		return nil
+	}
+	if endDate.Before(startDate) {
+		return ErrProjectEndBeforeStart
+	}
+	return nil
+}
+
 // addEvent 添加领域事件
 func (p *Project) addEvent(event event.DomainEvent) {
 	p.Events = append(p.Events, event)
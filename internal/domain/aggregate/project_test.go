@@ -1,8 +1,12 @@
 package aggregate
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/valueobject"
 )
 
@@ -42,7 +46,7 @@ func TestProject_UpdateBasicInfo(t *testing.T) {
 	newDescription := "Updated Description"
 
 	// Act
-	err := project.UpdateBasicInfo(newName, newDescription)
+	err := project.UpdateBasicInfo(newName, newDescription, project.OwnerID)
 
 	// Assert
 	if err != nil {
@@ -61,7 +65,7 @@ func TestProject_UpdateBasicInfo_EmptyName(t *testing.T) {
 	project := createTestProject()
 
 	// Act
-	err := project.UpdateBasicInfo("", "New Description")
+	err := project.UpdateBasicInfo("", "New Description", project.OwnerID)
 
 	// Assert
 	if err == nil {
@@ -69,6 +73,38 @@ func TestProject_UpdateBasicInfo_EmptyName(t *testing.T) {
 	}
 }
 
+func TestProject_UpdateBasicInfo_DescriptionLengthLimit(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	project.SetTextLimits(valueobject.TextLimits{NameMaxLength: 20, DescriptionMaxLength: 10})
+
+	// Act: 超长描述被拒绝，项目保持不变
+	err := project.UpdateBasicInfo("Renamed", strings.Repeat("描", 11), project.OwnerID)
+
+	// Assert
+	var validationErr *valueobject.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Violations) != 1 || validationErr.Violations[0].Field != "description" {
+		t.Errorf("Expected a single description violation, got %+v", validationErr.Violations)
+	}
+	if project.Name != "Test Project" {
+		t.Errorf("Project must be unchanged, got name %q", project.Name)
+	}
+
+	// Act: 恰好达到上限的描述可以保存，末尾空白不计入长度
+	err = project.UpdateBasicInfo(" Renamed ", strings.Repeat("描", 10)+" \n", project.OwnerID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if project.Name != "Renamed" || project.Description != strings.Repeat("描", 10) {
+		t.Errorf("Expected trimmed name and description, got %q / %q", project.Name, project.Description)
+	}
+}
+
 func TestProject_AssignManager(t *testing.T) {
 	// Arrange
 	project := createTestProject()
@@ -310,6 +346,51 @@ func TestProject_CreateSubProject_NotActive(t *testing.T) {
 	}
 }
 
+func TestProject_ChangeParent(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	oldParentID := valueobject.ProjectID("old-parent")
+	project.ParentID = &oldParentID
+	project.ClearEvents()
+
+	// Act
+	err := project.ChangeParent("new-parent", project.OwnerID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if project.ParentID == nil || *project.ParentID != "new-parent" {
+		t.Errorf("Expected parent ID new-parent, got %v", project.ParentID)
+	}
+	if len(project.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(project.Events))
+	}
+	changed, ok := project.Events[0].(*event.ProjectParentChangedEvent)
+	if !ok {
+		t.Fatalf("Expected ProjectParentChangedEvent, got %T", project.Events[0])
+	}
+	if changed.OldParentID == nil || *changed.OldParentID != oldParentID {
+		t.Errorf("Expected old parent ID %s, got %v", oldParentID, changed.OldParentID)
+	}
+}
+
+func TestProject_ChangeParent_Self(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+
+	// Act
+	err := project.ChangeParent(project.ID, project.OwnerID)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error when project is set as its own parent")
+	}
+	if project.ParentID != nil {
+		t.Errorf("Expected parent ID to stay nil, got %v", *project.ParentID)
+	}
+}
+
 func TestProject_Activate(t *testing.T) {
 	// Arrange
 	project := createTestProject()
@@ -385,6 +466,86 @@ func TestProject_Complete_WithPendingTasks(t *testing.T) {
 	}
 }
 
+func TestProject_UpdateSchedule_RejectsEndBeforeStart(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	start := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, -1)
+
+	// Act
+	err := project.UpdateSchedule(start, &end)
+
+	// Assert
+	if !errors.Is(err, ErrProjectEndBeforeStart) {
+		t.Errorf("Expected ErrProjectEndBeforeStart, got %v", err)
+	}
+	if !project.StartDate.IsZero() || project.EndDate != nil {
+		t.Error("Dates should not change when validation fails")
+	}
+}
+
+func TestProject_UpdateSchedule_ValidRange(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	start := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	sameDay := start
+	end := start.AddDate(0, 1, 0)
+
+	// Act & Assert
+	if err := project.UpdateSchedule(start, &sameDay); err != nil {
+		t.Errorf("Unexpected error for same-day range: %v", err)
+	}
+	if err := project.UpdateSchedule(start, &end); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !project.StartDate.Equal(start) || project.EndDate == nil || !project.EndDate.Equal(end) {
+		t.Errorf("Expected range %v - %v, got %v - %v", start, end, project.StartDate, project.EndDate)
+	}
+}
+
+func TestProject_Activate_KeepsScheduledStartDate(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	start := time.Now().AddDate(0, 0, -7)
+	end := start.AddDate(0, 0, -1)
+	project.StartDate = start
+
+	// Act & Assert: 计划结束日期早于开始日期时不能激活
+	project.EndDate = &end
+	if err := project.Activate(project.OwnerID); !errors.Is(err, ErrProjectEndBeforeStart) {
+		t.Errorf("Expected ErrProjectEndBeforeStart, got %v", err)
+	}
+	if project.Status != valueobject.ProjectStatusDraft {
+		t.Errorf("Expected status %s, got %s", valueobject.ProjectStatusDraft, project.Status)
+	}
+
+	project.EndDate = nil
+	if err := project.Activate(project.OwnerID); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !project.StartDate.Equal(start) {
+		t.Errorf("Expected start date %v to be kept, got %v", start, project.StartDate)
+	}
+}
+
+func TestProject_Complete_BeforeStartDate(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	project.Status = valueobject.ProjectStatusActive
+	project.StartDate = time.Now().AddDate(0, 0, 7)
+
+	// Act
+	err := project.Complete(project.OwnerID)
+
+	// Assert
+	if !errors.Is(err, ErrProjectEndBeforeStart) {
+		t.Errorf("Expected ErrProjectEndBeforeStart, got %v", err)
+	}
+	if project.Status != valueobject.ProjectStatusActive || project.EndDate != nil {
+		t.Error("Project should stay active without an end date")
+	}
+}
+
 func TestProject_Cancel(t *testing.T) {
 	// Arrange
 	project := createTestProject()
@@ -407,6 +568,98 @@ func TestProject_Cancel(t *testing.T) {
 	}
 }
 
+// lastStatusChange 返回项目最近一次发布的状态变更事件
+func lastStatusChange(t *testing.T, project *Project) *event.ProjectStatusChangedEvent {
+	t.Helper()
+	if len(project.Events) == 0 {
+		t.Fatal("Expected a status changed event")
+	}
+	changed, ok := project.Events[len(project.Events)-1].(*event.ProjectStatusChangedEvent)
+	if !ok {
+		t.Fatalf("Expected ProjectStatusChangedEvent, got %T", project.Events[len(project.Events)-1])
+	}
+	if changed.EventType() != "project.status_changed" {
+		t.Errorf("Expected event type project.status_changed, got %s", changed.EventType())
+	}
+	return changed
+}
+
+func TestProject_PauseAndResume_EmitOldAndNewStatus(t *testing.T) {
+	project := createTestProject()
+	project.Status = valueobject.ProjectStatusActive
+	ownerID := project.OwnerID
+
+	if err := project.Pause(ownerID, ""); err != nil {
+		t.Fatalf("Unexpected error pausing: %v", err)
+	}
+	paused := lastStatusChange(t, project)
+	if paused.OldStatus != valueobject.ProjectStatusActive || paused.NewStatus != valueobject.ProjectStatusPaused {
+		t.Errorf("Expected active -> paused, got %s -> %s", paused.OldStatus, paused.NewStatus)
+	}
+	if paused.ChangedBy != ownerID || paused.Reason != "Project paused" {
+		t.Errorf("Expected changed by %s with default reason, got %s / %q", ownerID, paused.ChangedBy, paused.Reason)
+	}
+
+	if err := project.Resume(ownerID); err != nil {
+		t.Fatalf("Unexpected error resuming: %v", err)
+	}
+	resumed := lastStatusChange(t, project)
+	if resumed.OldStatus != valueobject.ProjectStatusPaused || resumed.NewStatus != valueobject.ProjectStatusActive {
+		t.Errorf("Expected paused -> active, got %s -> %s", resumed.OldStatus, resumed.NewStatus)
+	}
+	if resumed.Reason != "Project resumed" {
+		t.Errorf("Expected resume reason, got %q", resumed.Reason)
+	}
+}
+
+func TestProject_Resume_PreservesStartDate(t *testing.T) {
+	project := createTestProject()
+	startDate := time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)
+	project.StartDate = startDate
+	project.Status = valueobject.ProjectStatusPaused
+
+	if err := project.Resume(project.OwnerID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if project.Status != valueobject.ProjectStatusActive {
+		t.Errorf("Expected status %s, got %s", valueobject.ProjectStatusActive, project.Status)
+	}
+	if !project.StartDate.Equal(startDate) {
+		t.Errorf("Expected start date %v to be preserved, got %v", startDate, project.StartDate)
+	}
+}
+
+func TestProject_Resume_RequiresPausedProject(t *testing.T) {
+	project := createTestProject()
+	project.Status = valueobject.ProjectStatusActive
+	eventCount := len(project.Events)
+
+	if err := project.Resume(project.OwnerID); err == nil {
+		t.Error("Expected error when resuming a project that is not paused")
+	}
+	if len(project.Events) != eventCount {
+		t.Errorf("Expected no new events, got %d", len(project.Events)-eventCount)
+	}
+}
+
+func TestProject_Pause_KeepsGivenReason(t *testing.T) {
+	project := createTestProject()
+	if err := project.Activate(project.OwnerID); err != nil {
+		t.Fatalf("Unexpected error activating: %v", err)
+	}
+	if started := lastStatusChange(t, project); started.OldStatus != valueobject.ProjectStatusDraft || started.Reason != "Project started" {
+		t.Errorf("Expected draft -> active with start reason, got %s / %q", started.OldStatus, started.Reason)
+	}
+
+	if err := project.Pause(project.OwnerID, "budget review"); err != nil {
+		t.Fatalf("Unexpected error pausing: %v", err)
+	}
+	if paused := lastStatusChange(t, project); paused.Reason != "budget review" {
+		t.Errorf("Expected given reason, got %q", paused.Reason)
+	}
+}
+
 func TestProject_Delete(t *testing.T) {
 	// Arrange
 	project := createTestProject()
@@ -426,6 +679,55 @@ func TestProject_Delete(t *testing.T) {
 	}
 }
 
+func TestProject_Delete_EventCarriesDeletingUser(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	project.ClearEvents()
+
+	// Act
+	err := project.Delete(project.OwnerID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(project.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(project.Events))
+	}
+	deleted, ok := project.Events[0].(*event.ProjectDeletedEvent)
+	if !ok {
+		t.Fatalf("Expected ProjectDeletedEvent, got %T", project.Events[0])
+	}
+	if deleted.DeletedBy != project.OwnerID {
+		t.Errorf("Expected DeletedBy %s, got %q", project.OwnerID, deleted.DeletedBy)
+	}
+	if deleted.ActorID() != string(project.OwnerID) {
+		t.Errorf("Expected event actor %s, got %q", project.OwnerID, deleted.ActorID())
+	}
+}
+
+func TestProject_UpdateBasicInfo_EventCarriesUpdatingUser(t *testing.T) {
+	// Arrange
+	project := createTestProject()
+	project.ClearEvents()
+	updatedBy := valueobject.UserID("editor-1")
+
+	// Act
+	err := project.UpdateBasicInfo("Renamed Project", "", updatedBy)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updated, ok := project.Events[0].(*event.ProjectUpdatedEvent)
+	if !ok {
+		t.Fatalf("Expected ProjectUpdatedEvent, got %T", project.Events[0])
+	}
+	if updated.UpdatedBy != updatedBy || updated.ActorID() != string(updatedBy) {
+		t.Errorf("Expected event actor %s, got %q / %q", updatedBy, updated.UpdatedBy, updated.ActorID())
+	}
+}
+
 func TestProject_Delete_WithPendingTasks(t *testing.T) {
 	// Arrange
 	project := createTestProject()
@@ -593,6 +895,38 @@ func TestProject_UpdateTaskStatistics(t *testing.T) {
 }
 
 // Helper function to create a test project
+func TestProject_IsActiveWithin(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	endOnFrom := from
+	endBeforeFrom := from.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name      string
+		startDate time.Time
+		endDate   *time.Time
+		expected  bool
+	}{
+		{"starts on window end", to, nil, true},
+		{"starts after window end", to.AddDate(0, 0, 1), nil, false},
+		{"ends on window start", from.AddDate(0, -2, 0), &endOnFrom, true},
+		{"ends before window start", from.AddDate(0, -2, 0), &endBeforeFrom, false},
+		{"open ended started earlier", from.AddDate(-1, 0, 0), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := createTestProject()
+			project.StartDate = tt.startDate
+			project.EndDate = tt.endDate
+
+			if got := project.IsActiveWithin(from, to); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 func createTestProject() *Project {
 	return NewProject(
 		valueobject.ProjectID("test-project"),
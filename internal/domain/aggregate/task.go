@@ -1,7 +1,12 @@
 package aggregate
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/valueobject"
@@ -14,9 +19,13 @@ type TaskAggregateInterface interface {
 	ChangePriority(newPriority valueobject.TaskPriority, changedBy valueobject.UserID) error
 	AssignResponsible(responsibleID valueobject.UserID, assignedBy valueobject.UserID) error
 	AddParticipant(participantID valueobject.UserID, addedBy valueobject.UserID) error
+	AddParticipantWithRole(participantID valueobject.UserID, role valueobject.ParticipantRole, addedBy valueobject.UserID) error
+	AddParticipants(participantIDs []valueobject.UserID, addedBy valueobject.UserID) error
 	RemoveParticipant(participantID valueobject.UserID, removedBy valueobject.UserID) error
+	SwapParticipant(fromID, toID valueobject.UserID, swappedBy valueobject.UserID) bool
 	UpdateSchedule(startDate, dueDate *time.Time, updatedBy valueobject.UserID) error
 	SetEstimatedHours(hours int, updatedBy valueobject.UserID) error
+	SetWorkflow(workflowID string, setBy valueobject.UserID) error
 
 	// 状态管理
 	SubmitForApproval(submittedBy valueobject.UserID) error
@@ -28,10 +37,13 @@ type TaskAggregateInterface interface {
 	SubmitCompletion(submittedBy valueobject.UserID, summary string) error
 	Complete(completedBy valueobject.UserID) error
 	Cancel(cancelledBy valueobject.UserID, reason string) error
+	Delete(deletedBy valueobject.UserID, force bool) error
 
 	// 工作提交和审核
 	SubmitWork(participantID valueobject.UserID, workContent string, attachments []string) error
+	SubmitWorkAsProjectMember(participantID valueobject.UserID, isProjectMember bool, workContent string, attachments []string) error
 	ReviewWork(participantID valueobject.UserID, reviewerID valueobject.UserID, approved bool, comment string) error
+	LogHours(participantID valueobject.UserID, hours float64) error
 
 	// 延期管理
 	RequestExtension(requesterID valueobject.UserID, newDueDate time.Time, reason string) (valueobject.ExtensionRequestID, error)
@@ -39,6 +51,7 @@ type TaskAggregateInterface interface {
 	RejectExtension(requestID valueobject.ExtensionRequestID, rejectorID valueobject.UserID, comment string) error
 
 	// 重复任务管理
+	ChangeType(newType valueobject.TaskType, rule *valueobject.RecurrenceRule, changedBy valueobject.UserID) error
 	SetRecurrenceRule(frequency valueobject.RecurrenceFrequency, intervalValue int, endDate *time.Time, maxExecutions *int) error
 	PrepareNextExecution() (valueobject.TaskExecutionID, error)
 	DisableRecurrence(disabledBy valueobject.UserID) error
@@ -63,20 +76,56 @@ type TaskAggregateInterface interface {
 	ClearEvents()
 }
 
+// DefaultMaxParticipants 未配置时每个任务允许的最大参与者数量
+const DefaultMaxParticipants = 50
+
 // TaskFactory 任务工厂
 type TaskFactory struct {
-	// 可以注入依赖，如ID生成器、验证器等
-	validator valueobject.TaskValidator
+	validator       valueobject.TaskValidator
+	idGenerator     valueobject.IDGenerator
+	maxParticipants int
+	textLimits      valueobject.TextLimits
 }
 
-// NewTaskFactory 创建任务工厂
-func NewTaskFactory(validator valueobject.TaskValidator) *TaskFactory {
+// NewTaskFactory 创建任务工厂，未指定ID生成器时使用UUID
+func NewTaskFactory(validator valueobject.TaskValidator, idGenerator valueobject.IDGenerator) *TaskFactory {
+	if idGenerator == nil {
+		idGenerator = valueobject.NewUUIDGenerator()
+	}
 	return &TaskFactory{
-		validator: validator,
+		validator:       validator,
+		idGenerator:     idGenerator,
+		maxParticipants: DefaultMaxParticipants,
+		textLimits:      valueobject.DefaultTaskTextLimits,
+	}
+}
+
+// WithMaxParticipants 设置每个任务的最大参与者数量，非正数时使用默认值
+func (f *TaskFactory) WithMaxParticipants(limit int) *TaskFactory {
+	if limit <= 0 {
+		limit = DefaultMaxParticipants
 	}
+	f.maxParticipants = limit
+	return f
+}
+
+// MaxParticipants 获取每个任务的最大参与者数量
+func (f *TaskFactory) MaxParticipants() int {
+	return f.maxParticipants
+}
+
+// WithTextLimits 设置更新任务时标题和描述的长度上限，非正数时使用默认值
+func (f *TaskFactory) WithTextLimits(limits valueobject.TextLimits) *TaskFactory {
+	f.textLimits = limits.WithDefaults(valueobject.DefaultTaskTextLimits)
+	return f
+}
+
+// TextLimits 获取任务标题和描述的长度上限
+func (f *TaskFactory) TextLimits() valueobject.TextLimits {
+	return f.textLimits
 }
 
-// CreateTask 创建新任务
+// CreateTask 创建新任务，id为空时自动生成
 func (f *TaskFactory) CreateTask(
 	id valueobject.TaskID,
 	title, description string,
@@ -99,8 +148,17 @@ func (f *TaskFactory) CreateTask(
 		return nil, err
 	}
 
+	// 未指定ID时由工厂生成
+	if id == "" {
+		id = f.idGenerator.GenerateTaskID()
+	}
+
 	// 创建任务聚合
-	return NewTask(id, title, description, taskType, priority, projectID, creatorID, responsibleID, dueDate), nil
+	task := NewTask(id, title, description, taskType, priority, projectID, creatorID, responsibleID, dueDate)
+	task.idGenerator = f.idGenerator
+	task.maxParticipants = f.maxParticipants
+	task.textLimits = f.textLimits
+	return task, nil
 }
 
 // RestoreTask 从数据恢复任务
@@ -116,12 +174,26 @@ func (f *TaskFactory) RestoreTask(data valueobject.TaskData) *TaskAggregate {
 		CreatorID:      valueobject.UserID(data.CreatorID),
 		ResponsibleID:  valueobject.UserID(data.ResponsibleID),
 		DueDate:        data.DueDate,
+		ApprovedAt:     data.ApprovedAt,
 		EstimatedHours: data.EstimatedHours,
-		WorkflowID:     *data.WorkflowID,
 		CreatedAt:      data.CreatedAt,
 		UpdatedAt:      data.UpdatedAt,
 		Participants:   make([]valueobject.TaskParticipant, 0),
 		Events:         make([]event.DomainEvent, 0),
+		idGenerator:    f.idGenerator,
+
+		StatusChangedAt: data.StatusChangedAt,
+		maxParticipants: f.maxParticipants,
+		textLimits:      f.textLimits,
+	}
+
+	// 旧数据没有记录状态变更时间，以最后更新时间近似
+	if task.StatusChangedAt.IsZero() {
+		task.StatusChangedAt = data.UpdatedAt
+	}
+
+	if data.WorkflowID != nil {
+		task.WorkflowID = *data.WorkflowID
 	}
 
 	// 恢复参与者列表
@@ -139,23 +211,51 @@ func (f *TaskFactory) RestoreTask(data valueobject.TaskData) *TaskAggregate {
 
 // Task 任务聚合根
 type TaskAggregate struct {
-	ID             valueobject.TaskID
-	Title          string
-	Description    *string
-	TaskType       valueobject.TaskType
-	Priority       valueobject.TaskPriority
-	Status         valueobject.TaskStatus
-	ProjectID      valueobject.ProjectID
-	CreatorID      valueobject.UserID
-	ResponsibleID  valueobject.UserID
-	WorkflowID     string
-	DueDate        *time.Time
-	EstimatedHours int
-	ActualHours    float64
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Participants   []valueobject.TaskParticipant
-	Events         []event.DomainEvent
+	ID              valueobject.TaskID
+	Title           string
+	Description     *string
+	TaskType        valueobject.TaskType
+	Priority        valueobject.TaskPriority
+	Status          valueobject.TaskStatus
+	ProjectID       valueobject.ProjectID
+	CreatorID       valueobject.UserID
+	ResponsibleID   valueobject.UserID // 负责人，设置了共同负责人时为牵头人
+	WorkflowID      string
+	DueDate         *time.Time
+	StartDate       *time.Time // 开始日期，设置时作为重复任务的执行锚点，否则以截止日期为锚点
+	ApprovedAt      *time.Time // 最近一次审批通过的时间，用于统计已审批未开始的任务
+	StatusChangedAt time.Time  // 进入当前状态的时间，用于统计任务在各状态的停留时长
+	EstimatedHours  int
+	ActualHours     float64
+	RecurrenceRule  *valueobject.RecurrenceRule // 重复规则，仅重复任务和模板任务可设置
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	UpdatedBy       valueobject.UserID // 最后修改人，由仓储在写入时按当前操作用户记录
+	Participants    []valueobject.TaskParticipant
+	Extensions      []valueobject.ExtensionRequest
+	WorkSubmissions []valueobject.WorkSubmission
+	Events          []event.DomainEvent
+
+	// OpenContribution 开放协作：项目成员提交工作时自动加入为参与者
+	OpenContribution bool
+
+	// PausedByProject 随项目暂停而暂停，项目恢复时自动恢复；手动暂停的任务不受影响
+	PausedByProject bool
+
+	// CoResponsibleIDs 共同负责人，与负责人拥有相同的修改和执行权限，不含负责人本人
+	CoResponsibleIDs []valueobject.UserID
+
+	// Reviewers 工作审核人，为空时由有审批权限的用户单人审核
+	Reviewers []valueobject.UserID
+	// ReviewQuorum 工作提交通过所需的审核通过人数
+	ReviewQuorum int
+
+	// Tags 任务标签，按添加顺序保存，不重复
+	Tags []string
+
+	idGenerator     valueobject.IDGenerator
+	maxParticipants int
+	textLimits      valueobject.TextLimits
 }
 
 // NewTask 创建新任务
@@ -190,6 +290,8 @@ func NewTask(
 		UpdatedAt:      now,
 		Participants:   make([]valueobject.TaskParticipant, 0),
 		Events:         make([]event.DomainEvent, 0),
+
+		StatusChangedAt: now,
 	}
 
 	// 发布任务创建事件
@@ -207,8 +309,18 @@ func NewTask(
 	return task
 }
 
-// UpdateBasicInfo 更新基本信息
+// UpdateBasicInfo 更新基本信息，标题去除首尾空白后不能为空，描述去除末尾空白
+// 标题或描述超长时返回 *valueobject.ValidationError
 func (t *TaskAggregate) UpdateBasicInfo(title, description string) error {
+	title = strings.TrimSpace(title)
+	description = strings.TrimRightFunc(description, unicode.IsSpace)
+	if title == "" {
+		return ErrEmptyTaskTitle
+	}
+	if err := t.textLimits.WithDefaults(valueobject.DefaultTaskTextLimits).Validate("任务更新", "title", title, description); err != nil {
+		return err
+	}
+
 	t.Title = title
 	if description != "" {
 		t.Description = &description
@@ -236,44 +348,101 @@ func (t *TaskAggregate) ChangePriority(newPriority valueobject.TaskPriority, cha
 	return nil
 }
 
-// AssignResponsible 分配负责人
+// AssignResponsible 分配唯一负责人，同时清除共同负责人
 func (t *TaskAggregate) AssignResponsible(responsibleID valueobject.UserID, assignedBy valueobject.UserID) error {
-	var oldResponsibleIDStr *string
+	return t.AssignResponsibles(responsibleID, nil, assignedBy)
+}
+
+// AssignResponsibles 分配牵头负责人和共同负责人，共同负责人去重并排除牵头人
+func (t *TaskAggregate) AssignResponsibles(leadID valueobject.UserID, coResponsibleIDs []valueobject.UserID, assignedBy valueobject.UserID) error {
+	var coResponsibles []valueobject.UserID
+	seen := map[valueobject.UserID]bool{leadID: true}
+	for _, id := range coResponsibleIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		coResponsibles = append(coResponsibles, id)
+	}
+	if leadID == "" && len(coResponsibles) > 0 {
+		return ErrCoResponsibleNeedsLead
+	}
+
+	var previousID *string
 	if t.ResponsibleID != "" {
-		str := string(t.ResponsibleID)
-		oldResponsibleIDStr = &str
+		previous := string(t.ResponsibleID)
+		previousID = &previous
 	}
-	t.ResponsibleID = responsibleID
+	t.ResponsibleID = leadID
+	t.CoResponsibleIDs = coResponsibles
 	t.UpdatedAt = time.Now()
 
 	// 发布任务分配事件
-	var prevID *string
-	if oldResponsibleIDStr != nil {
-		prevID = oldResponsibleIDStr
-	}
-	t.addEvent(event.NewTaskAssignedEvent(
+	assigned := event.NewTaskAssignedEvent(
 		string(t.ID),
 		string(t.ProjectID),
-		string(responsibleID),
+		string(leadID),
 		string(assignedBy),
-		prevID,
-	))
+		previousID,
+	)
+	for _, id := range coResponsibles {
+		assigned.CoResponsibleIDs = append(assigned.CoResponsibleIDs, string(id))
+	}
+	t.addEvent(assigned)
 
 	return nil
 }
 
-// AddParticipant 添加参与者
+// IsResponsible 判断用户是否为负责人或共同负责人
+func (t *TaskAggregate) IsResponsible(userID valueobject.UserID) bool {
+	if userID == "" {
+		return false
+	}
+	if userID == t.ResponsibleID {
+		return true
+	}
+	for _, id := range t.CoResponsibleIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Responsibles 返回负责人和全部共同负责人，负责人在前
+func (t *TaskAggregate) Responsibles() []valueobject.UserID {
+	var responsibles []valueobject.UserID
+	if t.ResponsibleID != "" {
+		responsibles = append(responsibles, t.ResponsibleID)
+	}
+	return append(responsibles, t.CoResponsibleIDs...)
+}
+
+// AddParticipant 以执行者身份添加参与者
 func (t *TaskAggregate) AddParticipant(participantID valueobject.UserID, addedBy valueobject.UserID) error {
+	return t.AddParticipantWithRole(participantID, valueobject.ParticipantRoleExecutor, addedBy)
+}
+
+// AddParticipantWithRole 以指定角色添加参与者，已是参与者时不改变其角色
+func (t *TaskAggregate) AddParticipantWithRole(participantID valueobject.UserID, role valueobject.ParticipantRole, addedBy valueobject.UserID) error {
+	if !role.IsValid() {
+		return NewDomainError("INVALID_PARTICIPANT_ROLE", fmt.Sprintf("invalid participant role: %s", role))
+	}
+
 	// 检查是否已经是参与者
-	for _, participant := range t.Participants {
-		if participant.UserID == participantID {
-			return nil // 已经是参与者，不重复添加
-		}
+	if t.IsParticipant(participantID) {
+		return nil // 已经是参与者，不重复添加
+	}
+
+	// 检查参与者数量上限
+	if limit := t.participantLimit(); len(t.Participants) >= limit {
+		return NewDomainError("PARTICIPANT_LIMIT_EXCEEDED",
+			fmt.Sprintf("task participant limit of %d reached", limit))
 	}
 
 	participant := valueobject.TaskParticipant{
 		UserID:  participantID,
-		Role:    valueobject.ParticipantRoleExecutor,
+		Role:    role,
 		AddedAt: time.Now(),
 		AddedBy: addedBy,
 	}
@@ -286,12 +455,120 @@ func (t *TaskAggregate) AddParticipant(participantID valueobject.UserID, addedBy
 		string(t.ID),
 		string(participantID),
 		string(addedBy),
-		string(valueobject.ParticipantRoleExecutor),
+		string(role),
 	))
 
 	return nil
 }
 
+// AddParticipants 批量添加参与者
+// 超出参与者数量上限时整批拒绝，不添加任何参与者
+func (t *TaskAggregate) AddParticipants(participantIDs []valueobject.UserID, addedBy valueobject.UserID) error {
+	newIDs := make([]valueobject.UserID, 0, len(participantIDs))
+	seen := make(map[valueobject.UserID]bool, len(participantIDs))
+	for _, participantID := range participantIDs {
+		if seen[participantID] || t.IsParticipant(participantID) {
+			continue
+		}
+		seen[participantID] = true
+		newIDs = append(newIDs, participantID)
+	}
+
+	if limit := t.participantLimit(); len(t.Participants)+len(newIDs) > limit {
+		return NewDomainError("PARTICIPANT_LIMIT_EXCEEDED",
+			fmt.Sprintf("adding %d participants would exceed the task participant limit of %d", len(newIDs), limit))
+	}
+
+	for _, participantID := range newIDs {
+		if err := t.AddParticipant(participantID, addedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaxTagLength 单个标签的最大字符数
+const MaxTagLength = 50
+
+// NormalizeTags 去除标签首尾空白，标签为空、超长或重复时返回 ErrInvalidTag
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		switch {
+		case tag == "":
+			return nil, fmt.Errorf("empty tag: %w", ErrInvalidTag)
+		case utf8.RuneCountInString(tag) > MaxTagLength:
+			return nil, fmt.Errorf("tag %q exceeds %d characters: %w", tag, MaxTagLength, ErrInvalidTag)
+		case seen[tag]:
+			return nil, fmt.Errorf("duplicate tag %q: %w", tag, ErrInvalidTag)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
+
+// UpdateTags 添加和移除标签，已有的标签不重复添加，返回标签是否有变化
+// 标签格式不合法或同一标签同时添加和移除时返回 ErrInvalidTag
+func (t *TaskAggregate) UpdateTags(add, remove []string) (bool, error) {
+	add, err := NormalizeTags(add)
+	if err != nil {
+		return false, err
+	}
+	remove, err = NormalizeTags(remove)
+	if err != nil {
+		return false, err
+	}
+	removing := make(map[string]bool, len(remove))
+	for _, tag := range remove {
+		removing[tag] = true
+	}
+	for _, tag := range add {
+		if removing[tag] {
+			return false, fmt.Errorf("tag %q is both added and removed: %w", tag, ErrInvalidTag)
+		}
+	}
+
+	tags := make([]string, 0, len(t.Tags)+len(add))
+	present := make(map[string]bool, len(t.Tags)+len(add))
+	for _, tag := range append(append([]string(nil), t.Tags...), add...) {
+		if !removing[tag] && !present[tag] {
+			present[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	changed := len(tags) != len(t.Tags)
+	for i := 0; !changed && i < len(tags); i++ {
+		changed = tags[i] != t.Tags[i]
+	}
+	if changed {
+		t.Tags = tags
+		t.UpdatedAt = time.Now()
+	}
+	return changed, nil
+}
+
+// SetMaxParticipants 设置参与者数量上限，非正数时使用默认值
+func (t *TaskAggregate) SetMaxParticipants(limit int) {
+	t.maxParticipants = limit
+}
+
+// SetTextLimits 设置标题和描述的长度上限，非正数时使用默认值
+func (t *TaskAggregate) SetTextLimits(limits valueobject.TextLimits) {
+	t.textLimits = limits
+}
+
+// participantLimit 获取参与者数量上限
+func (t *TaskAggregate) participantLimit() int {
+	if t.maxParticipants <= 0 {
+		return DefaultMaxParticipants
+	}
+	return t.maxParticipants
+}
+
 // RemoveParticipant 移除参与者
 func (t *TaskAggregate) RemoveParticipant(participantID valueobject.UserID, removedBy valueobject.UserID) error {
 	for i, participant := range t.Participants {
@@ -314,14 +591,84 @@ func (t *TaskAggregate) RemoveParticipant(participantID valueobject.UserID, remo
 	return nil // 不是参与者，无需移除
 }
 
-// UpdateSchedule 更新时间安排
+// SwapParticipant 以 toID 替换参与者 fromID，保留原参与角色，返回是否发生替换
+// fromID 不是参与者或 toID 已是参与者时不做任何修改
+func (t *TaskAggregate) SwapParticipant(fromID, toID valueobject.UserID, swappedBy valueobject.UserID) bool {
+	if fromID == toID || t.IsParticipant(toID) {
+		return false
+	}
+	for i, participant := range t.Participants {
+		if participant.UserID != fromID {
+			continue
+		}
+
+		now := time.Now()
+		t.Participants[i] = valueobject.TaskParticipant{
+			UserID:  toID,
+			Role:    participant.Role,
+			AddedAt: now,
+			AddedBy: swappedBy,
+		}
+		t.UpdatedAt = now
+
+		t.addEvent(event.NewParticipantRemovedEvent(
+			string(t.ID),
+			string(fromID),
+			string(swappedBy),
+			fmt.Sprintf("replaced by %s", toID),
+		))
+		t.addEvent(event.NewParticipantAddedEvent(
+			string(t.ID),
+			string(toID),
+			string(swappedBy),
+			string(participant.Role),
+		))
+		return true
+	}
+	return false
+}
+
+// UpdateSchedule 以参数替换开始日期和截止日期（nil 表示清除），截止日期变化时发布截止日期变更事件
+// 更新后的开始日期不能晚于截止日期，重复任务更新后至少保留一个日期作为重复锚点
 func (t *TaskAggregate) UpdateSchedule(startDate, dueDate *time.Time, updatedBy valueobject.UserID) error {
-	// Note: startDate field doesn't exist in struct, removing this line
-	t.DueDate = dueDate
+	if err := validateSchedule(t.TaskType, startDate, dueDate); err != nil {
+		return err
+	}
+
+	t.StartDate = startDate
+	t.changeDueDate(dueDate, updatedBy)
 	t.UpdatedAt = time.Now()
 	return nil
 }
 
+// validateSchedule 校验更新后的日期：开始日期不晚于截止日期，重复任务必须保留锚点日期
+func validateSchedule(taskType valueobject.TaskType, startDate, dueDate *time.Time) error {
+	if startDate != nil && dueDate != nil && startDate.After(*dueDate) {
+		return ErrTaskStartAfterDue
+	}
+	if taskType == valueobject.TaskTypeRecurring && startDate == nil && dueDate == nil {
+		return ErrRecurrenceNeedsAnchor
+	}
+	return nil
+}
+
+// changeDueDate 设置截止日期，与原日期不同时记录变更事件
+func (t *TaskAggregate) changeDueDate(dueDate *time.Time, changedBy valueobject.UserID) {
+	oldDueDate := t.DueDate
+	t.DueDate = dueDate
+	if sameDueDate(oldDueDate, dueDate) {
+		return
+	}
+	t.addEvent(event.NewTaskDueDateChangedEvent(string(t.ID), oldDueDate, dueDate, string(changedBy)))
+}
+
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
 // SetEstimatedHours 设置预估工时
 func (t *TaskAggregate) SetEstimatedHours(hours int, updatedBy valueobject.UserID) error {
 	t.EstimatedHours = hours
@@ -329,33 +676,54 @@ func (t *TaskAggregate) SetEstimatedHours(hours int, updatedBy valueobject.UserI
 	return nil
 }
 
+// SetWorkflow 设置任务使用的审批工作流，workflowID为空表示解除关联
+func (t *TaskAggregate) SetWorkflow(workflowID string, setBy valueobject.UserID) error {
+	if !t.CanUserModify(setBy) {
+		return NewDomainError("NO_MODIFY_PERMISSION", "user does not have permission to change workflow")
+	}
+	if t.WorkflowID == workflowID {
+		return nil
+	}
+
+	oldWorkflowID := t.WorkflowID
+	t.WorkflowID = workflowID
+	t.UpdatedAt = time.Now()
+
+	t.addEvent(event.NewTaskWorkflowChangedEvent(
+		string(t.ID),
+		oldWorkflowID,
+		workflowID,
+		string(setBy),
+	))
+
+	return nil
+}
+
 // SubmitForApproval 提交审批
 func (t *TaskAggregate) SubmitForApproval(submittedBy valueobject.UserID) error {
-	if t.Status != valueobject.TaskStatusDraft {
-		return ErrTaskNotInDraft
+	if err := t.ensureTransition(valueobject.TaskStatusPendingApproval, ErrTaskNotInDraft); err != nil {
+		return err
 	}
-	t.Status = valueobject.TaskStatusPendingApproval
-	t.UpdatedAt = time.Now()
+	t.touchStatus(valueobject.TaskStatusPendingApproval)
 	return nil
 }
 
 // Approve 审批通过
 func (t *TaskAggregate) Approve(approvedBy valueobject.UserID, comment string) error {
-	if t.Status != valueobject.TaskStatusPendingApproval {
-		return ErrTaskNotPendingApproval
+	if err := t.ensureTransition(valueobject.TaskStatusApproved, ErrTaskNotPendingApproval); err != nil {
+		return err
 	}
-	t.Status = valueobject.TaskStatusApproved
-	t.UpdatedAt = time.Now()
+	now := t.touchStatus(valueobject.TaskStatusApproved)
+	t.ApprovedAt = &now
 	return nil
 }
 
 // Reject 拒绝任务
 func (t *TaskAggregate) Reject(rejectedBy valueobject.UserID, reason string) error {
-	if t.Status != valueobject.TaskStatusPendingApproval {
-		return ErrTaskNotPendingApproval
+	if err := t.ensureTransition(valueobject.TaskStatusRejected, ErrTaskNotPendingApproval); err != nil {
+		return err
 	}
-	t.Status = valueobject.TaskStatusRejected
-	t.UpdatedAt = time.Now()
+	t.touchStatus(valueobject.TaskStatusRejected)
 
 	// 发布任务拒绝事件
 	t.addEvent(event.NewTaskRejectedEvent(
@@ -369,21 +737,20 @@ func (t *TaskAggregate) Reject(rejectedBy valueobject.UserID, reason string) err
 
 // Start 开始任务
 func (t *TaskAggregate) Start(startedBy valueobject.UserID) error {
-	if t.Status != valueobject.TaskStatusApproved {
-		return ErrTaskNotApproved
+	if err := t.ensureTransition(valueobject.TaskStatusInProgress, ErrTaskNotApproved); err != nil {
+		return err
 	}
-	t.Status = valueobject.TaskStatusInProgress
-	t.UpdatedAt = time.Now()
+	t.touchStatus(valueobject.TaskStatusInProgress)
 	return nil
 }
 
 // Complete 完成任务
+// 已暂停的任务需先恢复再完成，保证状态变更记录完整
 func (t *TaskAggregate) Complete(completedBy valueobject.UserID) error {
-	if t.Status != valueobject.TaskStatusInProgress {
-		return ErrTaskNotInProgress
+	if err := t.ensureCompletable(); err != nil {
+		return err
 	}
-	t.Status = valueobject.TaskStatusCompleted
-	t.UpdatedAt = time.Now()
+	t.touchStatus(valueobject.TaskStatusCompleted)
 
 	// 发布任务完成事件
 	t.addEvent(event.NewTaskCompletedEvent(
@@ -394,13 +761,20 @@ func (t *TaskAggregate) Complete(completedBy valueobject.UserID) error {
 	return nil
 }
 
+// ensureCompletable 检查任务是否可以完成，已暂停的任务返回 ErrTaskPausedResumeFirst
+func (t *TaskAggregate) ensureCompletable() error {
+	if t.Status == valueobject.TaskStatusPaused {
+		return t.ensureTransition(valueobject.TaskStatusCompleted, ErrTaskPausedResumeFirst)
+	}
+	return t.ensureTransition(valueobject.TaskStatusCompleted, ErrTaskNotInProgress)
+}
+
 // Pause 暂停任务
 func (t *TaskAggregate) Pause(pausedBy valueobject.UserID, reason string) error {
-	if t.Status != valueobject.TaskStatusInProgress {
-		return ErrTaskNotInProgress
+	if err := t.ensureTransition(valueobject.TaskStatusPaused, ErrTaskNotInProgress); err != nil {
+		return err
 	}
-	t.Status = valueobject.TaskStatusPaused
-	t.UpdatedAt = time.Now()
+	t.touchStatus(valueobject.TaskStatusPaused)
 
 	// 发布任务暂停事件
 	t.addEvent(event.NewTaskStatusChangedEvent(
@@ -414,13 +788,26 @@ func (t *TaskAggregate) Pause(pausedBy valueobject.UserID, reason string) error
 	return nil
 }
 
-// Resume 恢复任务
+// PauseWithProject 随项目暂停任务，并标记为由项目暂停
+func (t *TaskAggregate) PauseWithProject(pausedBy valueobject.UserID, reason string) error {
+	if err := t.Pause(pausedBy, reason); err != nil {
+		return err
+	}
+	t.PausedByProject = true
+	return nil
+}
+
+// Resume 恢复任务，只有已暂停的任务可以恢复
 func (t *TaskAggregate) Resume(resumedBy valueobject.UserID) error {
-	if t.Status != valueobject.TaskStatusPaused {
-		return NewDomainError("TASK_NOT_PAUSED", "task is not paused")
+	if err := t.ensureTransition(valueobject.TaskStatusInProgress, ErrTaskNotPaused); err != nil {
+		return err
 	}
-	t.Status = valueobject.TaskStatusInProgress
-	t.UpdatedAt = time.Now()
+	// 已审批任务进入进行中是开始而不是恢复，需通过 Start
+	if t.Status == valueobject.TaskStatusApproved {
+		return invalidTransition(t.Status, valueobject.TaskStatusInProgress, ErrTaskNotPaused)
+	}
+	t.touchStatus(valueobject.TaskStatusInProgress)
+	t.PausedByProject = false
 
 	// 发布任务恢复事件
 	t.addEvent(event.NewTaskStatusChangedEvent(
@@ -435,9 +822,17 @@ func (t *TaskAggregate) Resume(resumedBy valueobject.UserID) error {
 }
 
 // SubmitCompletion 提交完成
+// 执行者和协助者的最近一次工作提交都已审核通过后才能提交完成
 func (t *TaskAggregate) SubmitCompletion(submittedBy valueobject.UserID, summary string) error {
-	if t.Status != valueobject.TaskStatusInProgress {
-		return ErrTaskNotInProgress
+	if err := t.ensureCompletable(); err != nil {
+		return err
+	}
+	if outstanding := t.OutstandingParticipants(); len(outstanding) > 0 {
+		names := make([]string, len(outstanding))
+		for i, id := range outstanding {
+			names[i] = string(id)
+		}
+		return fmt.Errorf("%w: %s", ErrParticipantWorkPending, strings.Join(names, ", "))
 	}
 
 	// 发布任务完成提交事件
@@ -450,15 +845,18 @@ func (t *TaskAggregate) SubmitCompletion(submittedBy valueobject.UserID, summary
 	return nil
 }
 
-// Cancel 取消任务
+// Cancel 取消任务，已完成或已取消的任务不能取消
 func (t *TaskAggregate) Cancel(cancelledBy valueobject.UserID, reason string) error {
-	t.Status = valueobject.TaskStatusCancelled
-	t.UpdatedAt = time.Now()
+	if err := t.ensureTransition(valueobject.TaskStatusCancelled, nil); err != nil {
+		return err
+	}
+	oldStatus := t.Status
+	t.touchStatus(valueobject.TaskStatusCancelled)
 
 	// 发布任务取消事件
 	t.addEvent(event.NewTaskStatusChangedEvent(
 		string(t.ID),
-		string(t.Status), // 原状态
+		string(oldStatus),
 		string(valueobject.TaskStatusCancelled),
 		string(cancelledBy),
 		reason,
@@ -489,17 +887,17 @@ func (t *TaskAggregate) GetParticipantRole(userID valueobject.UserID) *valueobje
 
 // CanUserModify 检查用户是否可以修改
 func (t *TaskAggregate) CanUserModify(userID valueobject.UserID) bool {
-	return t.CreatorID == userID || (t.ResponsibleID != "" && t.ResponsibleID == userID)
+	return t.CreatorID == userID || t.IsResponsible(userID)
 }
 
 // CanUserView 检查用户是否可以查看
 func (t *TaskAggregate) CanUserView(userID valueobject.UserID) bool {
-	return userID == t.CreatorID || (t.ResponsibleID != "" && userID == t.ResponsibleID) || t.IsParticipant(userID)
+	return userID == t.CreatorID || t.IsResponsible(userID) || t.IsParticipant(userID)
 }
 
 // CanUserExecute 检查用户是否可以执行
 func (t *TaskAggregate) CanUserExecute(userID valueobject.UserID) bool {
-	return (t.ResponsibleID != "" && t.ResponsibleID == userID) || t.IsParticipant(userID)
+	return t.IsResponsible(userID) || t.IsParticipant(userID)
 }
 
 // CanUserApprove 检查用户是否可以审批
@@ -509,11 +907,15 @@ func (t *TaskAggregate) CanUserApprove(userID valueobject.UserID) bool {
 }
 
 // GetCompletionRate 获取完成率
+// 未完成的任务按已记录工时占预估工时的比例估算，最高为99
 func (t *TaskAggregate) GetCompletionRate() float64 {
 	if t.Status == valueobject.TaskStatusCompleted {
 		return 100.0
 	}
-	return 0.0
+	if t.EstimatedHours <= 0 {
+		return 0.0
+	}
+	return math.Min(t.ActualHours/float64(t.EstimatedHours)*100, 99)
 }
 
 // GetParticipantCount 获取参与者数量
@@ -547,13 +949,42 @@ func (t *TaskAggregate) GetRemainingTime() time.Duration {
 	return remaining
 }
 
+// SetOpenContribution 设置是否开放协作
+func (t *TaskAggregate) SetOpenContribution(enabled bool) {
+	t.OpenContribution = enabled
+	t.UpdatedAt = time.Now()
+}
+
 // SubmitWork 提交工作
 func (t *TaskAggregate) SubmitWork(participantID valueobject.UserID, workContent string, attachments []string) error {
+	return t.SubmitWorkAsProjectMember(participantID, false, workContent, attachments)
+}
+
+// SubmitWorkAsProjectMember 以项目成员身份提交工作
+// 任务开放协作时，非参与者的项目成员提交工作会先被自动加入为参与者
+func (t *TaskAggregate) SubmitWorkAsProjectMember(participantID valueobject.UserID, isProjectMember bool, workContent string, attachments []string) error {
 	// 检查是否为参与者或负责人
-	if !t.IsParticipant(participantID) && t.ResponsibleID != participantID {
-		return NewDomainError("NOT_PARTICIPANT", "user is not a participant of this task")
+	if !t.IsParticipant(participantID) && !t.IsResponsible(participantID) {
+		if !t.OpenContribution || !isProjectMember {
+			return NewDomainError("NOT_PARTICIPANT", "user is not a participant of this task")
+		}
+
+		// 开放协作：自动加入为参与者
+		if err := t.AddParticipant(participantID, participantID); err != nil {
+			return err
+		}
 	}
 
+	t.WorkSubmissions = append(t.WorkSubmissions, valueobject.WorkSubmission{
+		ID:          t.ids().GenerateWorkSubmissionID(),
+		TaskID:      t.ID,
+		SubmitterID: participantID,
+		Content:     workContent,
+		Attachments: attachments,
+		Status:      valueobject.WorkSubmissionStatusPending,
+		SubmittedAt: time.Now(),
+	})
+
 	// 发布工作提交事件
 	t.addEvent(event.NewWorkSubmittedEvent(
 		string(t.ID),
@@ -565,13 +996,45 @@ func (t *TaskAggregate) SubmitWork(participantID valueobject.UserID, workContent
 	return nil
 }
 
+// LogHours 记录参与者投入的工时，累加到任务实际工时
+func (t *TaskAggregate) LogHours(participantID valueobject.UserID, hours float64) error {
+	if !t.IsParticipant(participantID) && !t.IsResponsible(participantID) {
+		return NewDomainError("NOT_PARTICIPANT", "user is not a participant of this task")
+	}
+	if hours <= 0 || math.IsInf(hours, 0) || math.IsNaN(hours) {
+		return ErrInvalidHours
+	}
+
+	t.ActualHours += hours
+	t.UpdatedAt = time.Now()
+
+	t.addEvent(event.NewHoursLoggedEvent(
+		string(t.ID),
+		string(participantID),
+		hours,
+		t.ActualHours,
+	))
+
+	return nil
+}
+
 // ReviewWork 审核工作
+// 任务配置了审核人时按法定人数汇总意见：任一审核人驳回即驳回，通过人数达到法定人数才通过
 func (t *TaskAggregate) ReviewWork(participantID valueobject.UserID, reviewerID valueobject.UserID, approved bool, comment string) error {
+	if len(t.Reviewers) > 0 {
+		return t.reviewWorkWithQuorum(participantID, reviewerID, approved, comment)
+	}
+
 	// 检查审核者权限
 	if !t.CanUserApprove(reviewerID) {
 		return NewDomainError("NO_REVIEW_PERMISSION", "user does not have permission to review work")
 	}
 
+	// 审核结果记录到该参与者最近一次待审核的提交上
+	if submission := t.latestPendingSubmission(participantID); submission != nil {
+		resolveSubmission(submission, approved, reviewerID, comment)
+	}
+
 	// 发布工作审核事件
 	t.addEvent(event.NewWorkReviewedEvent(
 		string(t.ID),
@@ -584,6 +1047,162 @@ func (t *TaskAggregate) ReviewWork(participantID valueobject.UserID, reviewerID
 	return nil
 }
 
+// reviewWorkWithQuorum 记录审核人的意见，得出审核结果时才发布工作审核事件
+func (t *TaskAggregate) reviewWorkWithQuorum(participantID, reviewerID valueobject.UserID, approved bool, comment string) error {
+	if !t.IsReviewer(reviewerID) {
+		return NewDomainError("NO_REVIEW_PERMISSION", "user is not a reviewer of this task")
+	}
+	submission := t.latestPendingSubmission(participantID)
+	if submission == nil {
+		return ErrNoPendingSubmission
+	}
+	if hasDecisionFrom(*submission, reviewerID) {
+		return ErrAlreadyReviewed
+	}
+
+	submission.Decisions = append(submission.Decisions, valueobject.ReviewDecision{
+		ReviewerID: reviewerID,
+		Approved:   approved,
+		Comment:    comment,
+		DecidedAt:  time.Now(),
+	})
+	t.UpdatedAt = time.Now()
+
+	// 通过人数未达到法定人数时继续等待其他审核人
+	if approved && submission.Approvals() < t.ReviewQuorum {
+		return nil
+	}
+
+	resolveSubmission(submission, approved, reviewerID, comment)
+	t.addEvent(event.NewWorkReviewedEvent(
+		string(t.ID),
+		string(participantID),
+		string(reviewerID),
+		approved,
+		comment,
+	))
+	return nil
+}
+
+// resolveSubmission 记录工作提交的最终审核结果
+func resolveSubmission(submission *valueobject.WorkSubmission, approved bool, reviewerID valueobject.UserID, comment string) {
+	now := time.Now()
+	submission.Status = valueobject.WorkSubmissionStatusRejected
+	if approved {
+		submission.Status = valueobject.WorkSubmissionStatusApproved
+	}
+	submission.ReviewerID = &reviewerID
+	submission.ReviewedAt = &now
+	if comment != "" {
+		submission.ReviewComment = &comment
+	}
+}
+
+// SetReviewers 设置工作审核人及通过所需的人数，reviewers为空时恢复单人审核
+func (t *TaskAggregate) SetReviewers(reviewers []valueobject.UserID, quorum int, setBy valueobject.UserID) error {
+	if !t.CanUserModify(setBy) {
+		return NewDomainError("NO_MODIFY_PERMISSION", "user does not have permission to change reviewers")
+	}
+
+	var unique []valueobject.UserID
+	seen := make(map[valueobject.UserID]bool, len(reviewers))
+	for _, id := range reviewers {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		quorum = 0
+	} else if quorum < 1 || quorum > len(unique) {
+		return ErrInvalidReviewQuorum
+	}
+
+	t.Reviewers = unique
+	t.ReviewQuorum = quorum
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsReviewer 判断用户是否为任务的工作审核人
+func (t *TaskAggregate) IsReviewer(userID valueobject.UserID) bool {
+	for _, id := range t.Reviewers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// SubmissionsAwaitingReview 返回等待该用户审核的工作提交，每个提交人只取最近一次待审核的提交
+// 配置了审核人时只包含该审核人尚未表态的提交，否则需要有审批权限
+func (t *TaskAggregate) SubmissionsAwaitingReview(userID valueobject.UserID) []valueobject.WorkSubmission {
+	if len(t.Reviewers) > 0 {
+		if !t.IsReviewer(userID) {
+			return nil
+		}
+	} else if !t.CanUserApprove(userID) {
+		return nil
+	}
+
+	var awaiting []valueobject.WorkSubmission
+	for _, submission := range t.WorkSubmissions {
+		latest := t.latestPendingSubmission(submission.SubmitterID)
+		if latest == nil || latest.ID != submission.ID || hasDecisionFrom(submission, userID) {
+			continue
+		}
+		awaiting = append(awaiting, submission)
+	}
+	return awaiting
+}
+
+// hasDecisionFrom 审核人是否已对提交表态
+func hasDecisionFrom(submission valueobject.WorkSubmission, reviewerID valueobject.UserID) bool {
+	for _, d := range submission.Decisions {
+		if d.ReviewerID == reviewerID {
+			return true
+		}
+	}
+	return false
+}
+
+// OutstandingParticipants 返回工作尚未审核通过的参与者：未提交工作，或最近一次提交待审核或被驳回
+// 观察者和审核者不提交工作，不在检查范围内
+func (t *TaskAggregate) OutstandingParticipants() []valueobject.UserID {
+	var outstanding []valueobject.UserID
+	for _, p := range t.Participants {
+		if p.Role == valueobject.ParticipantRoleObserver || p.Role == valueobject.ParticipantRoleReviewer {
+			continue
+		}
+		if latest := t.latestSubmission(p.UserID); latest == nil || latest.Status != valueobject.WorkSubmissionStatusApproved {
+			outstanding = append(outstanding, p.UserID)
+		}
+	}
+	return outstanding
+}
+
+// latestSubmission 返回参与者最近一次工作提交
+func (t *TaskAggregate) latestSubmission(participantID valueobject.UserID) *valueobject.WorkSubmission {
+	for i := len(t.WorkSubmissions) - 1; i >= 0; i-- {
+		if t.WorkSubmissions[i].SubmitterID == participantID {
+			return &t.WorkSubmissions[i]
+		}
+	}
+	return nil
+}
+
+// latestPendingSubmission 返回参与者最近一次待审核的工作提交
+func (t *TaskAggregate) latestPendingSubmission(participantID valueobject.UserID) *valueobject.WorkSubmission {
+	for i := len(t.WorkSubmissions) - 1; i >= 0; i-- {
+		submission := &t.WorkSubmissions[i]
+		if submission.SubmitterID == participantID && submission.Status == valueobject.WorkSubmissionStatusPending {
+			return submission
+		}
+	}
+	return nil
+}
+
 // RequestExtension 请求延期
 func (t *TaskAggregate) RequestExtension(requesterID valueobject.UserID, newDueDate time.Time, reason string) (valueobject.ExtensionRequestID, error) {
 	// 检查请求者权限
@@ -591,8 +1210,29 @@ func (t *TaskAggregate) RequestExtension(requesterID valueobject.UserID, newDueD
 		return "", NewDomainError("NO_EXTENSION_PERMISSION", "user does not have permission to request extension")
 	}
 
+	// 同一任务同时只能有一个待审批的延期申请
+	if pending := t.GetPendingExtension(); pending != nil {
+		return "", NewDomainError("EXTENSION_ALREADY_PENDING",
+			fmt.Sprintf("task already has a pending extension request: %s", pending.ID))
+	}
+
 	// 生成延期请求ID
-	requestID := valueobject.ExtensionRequestID("ext_" + string(t.ID) + "_" + time.Now().Format("20060102150405"))
+	requestID := t.ids().GenerateExtensionRequestID()
+
+	var originalDueDate time.Time
+	if t.DueDate != nil {
+		originalDueDate = *t.DueDate
+	}
+	t.Extensions = append(t.Extensions, valueobject.ExtensionRequest{
+		ID:               requestID,
+		TaskID:           t.ID,
+		RequesterID:      requesterID,
+		OriginalDueDate:  originalDueDate,
+		RequestedDueDate: newDueDate,
+		Reason:           reason,
+		Status:           valueobject.ExtensionStatusPending,
+		RequestedAt:      time.Now(),
+	})
 
 	// 发布延期请求事件
 	t.addEvent(event.NewExtensionRequestedEvent(
@@ -613,12 +1253,21 @@ func (t *TaskAggregate) ApproveExtension(requestID valueobject.ExtensionRequestI
 		return NewDomainError("NO_APPROVE_PERMISSION", "user does not have permission to approve extension")
 	}
 
+	ext, err := t.pendingExtension(requestID)
+	if err != nil {
+		return err
+	}
+	resolveExtension(ext, valueobject.ExtensionStatusApproved, approverID, "")
+	newDueDate := ext.RequestedDueDate
+	t.changeDueDate(&newDueDate, approverID)
+	t.UpdatedAt = time.Now()
+
 	// 发布延期批准事件
 	t.addEvent(event.NewExtensionApprovedEvent(
 		string(t.ID),
 		string(requestID),
 		string(approverID),
-		*t.DueDate, // 使用当前截止日期，实际应该从请求中获取新日期
+		newDueDate,
 	))
 
 	return nil
@@ -631,6 +1280,13 @@ func (t *TaskAggregate) RejectExtension(requestID valueobject.ExtensionRequestID
 		return NewDomainError("NO_REJECT_PERMISSION", "user does not have permission to reject extension")
 	}
 
+	ext, err := t.pendingExtension(requestID)
+	if err != nil {
+		return err
+	}
+	resolveExtension(ext, valueobject.ExtensionStatusRejected, rejectorID, comment)
+	t.UpdatedAt = time.Now()
+
 	// 发布延期拒绝事件
 	t.addEvent(event.NewExtensionRejectedEvent(
 		string(t.ID),
@@ -642,31 +1298,142 @@ func (t *TaskAggregate) RejectExtension(requestID valueobject.ExtensionRequestID
 	return nil
 }
 
-// SetRecurrenceRule 设置重复规则
+// GetPendingExtension 获取待审批的延期申请，没有时返回nil
+func (t *TaskAggregate) GetPendingExtension() *valueobject.ExtensionRequest {
+	for i := range t.Extensions {
+		if t.Extensions[i].Status == valueobject.ExtensionStatusPending {
+			return &t.Extensions[i]
+		}
+	}
+	return nil
+}
+
+// pendingExtension 查找指定的待审批延期申请
+func (t *TaskAggregate) pendingExtension(requestID valueobject.ExtensionRequestID) (*valueobject.ExtensionRequest, error) {
+	for i := range t.Extensions {
+		if t.Extensions[i].ID != requestID {
+			continue
+		}
+		if t.Extensions[i].Status != valueobject.ExtensionStatusPending {
+			return nil, ErrExtensionNotPending
+		}
+		return &t.Extensions[i], nil
+	}
+	return nil, ErrExtensionNotFound
+}
+
+// resolveExtension 记录延期申请的审批结果
+func resolveExtension(e *valueobject.ExtensionRequest, status valueobject.ExtensionStatus, reviewerID valueobject.UserID, comment string) {
+	now := time.Now()
+	e.Status = status
+	e.ReviewerID = &reviewerID
+	e.ReviewedAt = &now
+	if comment != "" {
+		e.ReviewComment = &comment
+	}
+}
+
+// SetRecurrenceRule 设置重复规则，任务需有开始日期或截止日期作为执行锚点
 func (t *TaskAggregate) SetRecurrenceRule(frequency valueobject.RecurrenceFrequency, intervalValue int, endDate *time.Time, maxExecutions *int) error {
 	// 只有模板任务或重复任务可以设置重复规则
 	if t.TaskType != valueobject.TaskTypeRecurring && t.TaskType != valueobject.TaskTypeTemplate {
 		return NewDomainError("INVALID_TASK_TYPE", "only recurring or template tasks can have recurrence rules")
 	}
 
-	// 这里应该保存重复规则到任务中，但当前结构体没有相关字段
-	// 实际实现中需要添加RecurrenceRule字段
+	rule := valueobject.RecurrenceRule{
+		Frequency:     frequency,
+		IntervalValue: intervalValue,
+		EndDate:       endDate,
+		MaxExecutions: maxExecutions,
+	}
+	if !rule.IsValid() {
+		return ErrInvalidRecurrenceRule
+	}
+	if _, ok := t.RecurrenceAnchor(); !ok {
+		return ErrRecurrenceNeedsAnchor
+	}
 
+	t.RecurrenceRule = &rule
+	t.UpdatedAt = time.Now()
 	return nil
 }
 
-// PrepareNextExecution 准备下次执行
+// ChangeType 变更任务类型
+// 变更为重复任务时必须提供有效的重复规则，且任务需有开始日期或截止日期作为执行锚点；已完成或已取消的任务不能变更为重复任务；
+// 变更为常规或紧急任务时清除重复规则
+func (t *TaskAggregate) ChangeType(newType valueobject.TaskType, rule *valueobject.RecurrenceRule, changedBy valueobject.UserID) error {
+	if !t.CanUserModify(changedBy) {
+		return NewDomainError("NO_MODIFY_PERMISSION", "user does not have permission to change task type")
+	}
+	if !newType.IsValid() {
+		return NewDomainError("INVALID_TASK_TYPE", fmt.Sprintf("invalid task type: %s", newType))
+	}
+	if newType == t.TaskType {
+		return nil
+	}
+
+	if newType == valueobject.TaskTypeRecurring {
+		if t.Status == valueobject.TaskStatusCompleted || t.Status == valueobject.TaskStatusCancelled {
+			return ErrClosedTaskCannotRecur
+		}
+		if rule == nil {
+			return ErrRecurrenceRuleRequired
+		}
+	}
+	if rule != nil && !rule.IsValid() {
+		return ErrInvalidRecurrenceRule
+	}
+	keepsRule := rule != nil && (newType == valueobject.TaskTypeRecurring || newType == valueobject.TaskTypeTemplate)
+	if _, ok := t.RecurrenceAnchor(); keepsRule && !ok {
+		return ErrRecurrenceNeedsAnchor
+	}
+
+	oldType := t.TaskType
+	t.TaskType = newType
+	switch {
+	case keepsRule:
+		ruleCopy := *rule
+		t.RecurrenceRule = &ruleCopy
+	case newType != valueobject.TaskTypeTemplate:
+		t.RecurrenceRule = nil
+	}
+	t.UpdatedAt = time.Now()
+
+	t.addEvent(event.NewTaskTypeChangedEvent(
+		string(t.ID),
+		string(oldType),
+		string(newType),
+		string(changedBy),
+	))
+
+	return nil
+}
+
+// PrepareNextExecution 准备下次执行，执行时间为锚点之后按重复规则推算的第一个未来时间
+// 下次执行超过结束日期或最大执行次数（锚点计为第一次）时返回 ErrRecurrenceEnded
 func (t *TaskAggregate) PrepareNextExecution() (valueobject.TaskExecutionID, error) {
 	// 只有重复任务可以准备下次执行
 	if t.TaskType != valueobject.TaskTypeRecurring {
 		return "", NewDomainError("NOT_RECURRING_TASK", "only recurring tasks can prepare next execution")
 	}
 
-	// 生成执行ID
-	executionID := valueobject.TaskExecutionID("exec_" + string(t.ID) + "_" + time.Now().Format("20060102150405"))
+	if t.RecurrenceRule == nil {
+		return "", ErrRecurrenceRuleRequired
+	}
+	anchor, ok := t.RecurrenceAnchor()
+	if !ok {
+		return "", ErrRecurrenceNeedsAnchor
+	}
+
+	// 下次执行时间按重复规则从锚点推算，与任务的日程对齐
+	next := t.RecurrenceRule.Occurrences(anchor, time.Now(), 1)
+	if len(next) == 0 {
+		return "", ErrRecurrenceEnded
+	}
+	nextExecutionDate := next[0]
 
-	// 计算下次执行时间（简化实现）
-	nextExecutionDate := time.Now().AddDate(0, 0, 7) // 假设每周执行
+	// 生成执行ID
+	executionID := t.ids().GenerateTaskExecutionID()
 
 	// 发布下次执行准备事件
 	t.addEvent(event.NewNextExecutionPreparedEvent(
@@ -678,6 +1445,17 @@ func (t *TaskAggregate) PrepareNextExecution() (valueobject.TaskExecutionID, err
 	return executionID, nil
 }
 
+// RecurrenceAnchor 重复任务的执行锚点：优先使用开始日期，未设置时使用截止日期
+func (t *TaskAggregate) RecurrenceAnchor() (time.Time, bool) {
+	if t.StartDate != nil {
+		return *t.StartDate, true
+	}
+	if t.DueDate != nil {
+		return *t.DueDate, true
+	}
+	return time.Time{}, false
+}
+
 // DisableRecurrence 禁用重复
 func (t *TaskAggregate) DisableRecurrence(disabledBy valueobject.UserID) error {
 	// 检查权限
@@ -692,11 +1470,105 @@ func (t *TaskAggregate) DisableRecurrence(disabledBy valueobject.UserID) error {
 
 	// 将任务类型改为常规任务
 	t.TaskType = valueobject.TaskTypeRegular
+	t.RecurrenceRule = nil
 	t.UpdatedAt = time.Now()
 
 	return nil
 }
 
+// RequiresForceToDelete 任务是否处于流转中（待审批、进行中、暂停），删除时需要强制确认
+func (t *TaskAggregate) RequiresForceToDelete() bool {
+	switch t.Status {
+	case valueobject.TaskStatusPendingApproval, valueobject.TaskStatusInProgress, valueobject.TaskStatusPaused:
+		return true
+	}
+	return false
+}
+
+// Delete 标记任务删除并发布删除事件，实际的软删除由仓储完成
+func (t *TaskAggregate) Delete(deletedBy valueobject.UserID, force bool) error {
+	if !t.CanUserModify(deletedBy) {
+		return ErrNoDeletePermission
+	}
+	if t.RequiresForceToDelete() && !force {
+		return ErrTaskDeleteRequiresForce
+	}
+
+	t.UpdatedAt = time.Now()
+	t.addEvent(event.NewTaskDeletedEvent(
+		string(t.ID),
+		string(t.ProjectID),
+		string(t.Status),
+		string(deletedBy),
+	))
+
+	return nil
+}
+
+// taskStatusTransitions 任务状态的合法转换，已完成和已取消为终态
+var taskStatusTransitions = map[valueobject.TaskStatus][]valueobject.TaskStatus{
+	valueobject.TaskStatusDraft:           {valueobject.TaskStatusPendingApproval, valueobject.TaskStatusCancelled},
+	valueobject.TaskStatusPendingApproval: {valueobject.TaskStatusApproved, valueobject.TaskStatusRejected, valueobject.TaskStatusCancelled},
+	valueobject.TaskStatusApproved:        {valueobject.TaskStatusInProgress, valueobject.TaskStatusCancelled},
+	valueobject.TaskStatusRejected:        {valueobject.TaskStatusCancelled},
+	valueobject.TaskStatusInProgress:      {valueobject.TaskStatusPaused, valueobject.TaskStatusCompleted, valueobject.TaskStatusCancelled},
+	valueobject.TaskStatusPaused:          {valueobject.TaskStatusInProgress, valueobject.TaskStatusCancelled},
+}
+
+// canTransition 任务能否从 from 转换到 to
+func canTransition(from, to valueobject.TaskStatus) bool {
+	for _, next := range taskStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureTransition 当前状态不能转换到 to 时返回 ErrInvalidStatusTransition 及起止状态
+// cause 为操作原有的具体错误，排在错误链前面，errors.As 优先取到其错误码
+func (t *TaskAggregate) ensureTransition(to valueobject.TaskStatus, cause error) error {
+	if canTransition(t.Status, to) {
+		return nil
+	}
+	return invalidTransition(t.Status, to, cause)
+}
+
+// invalidTransition 构造非法状态转换错误，同时包装 cause 和 ErrInvalidStatusTransition
+func invalidTransition(from, to valueobject.TaskStatus, cause error) error {
+	if cause == nil {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, to)
+	}
+	return fmt.Errorf("%w (%w: %s -> %s)", cause, ErrInvalidStatusTransition, from, to)
+}
+
+// touchStatus 切换任务状态，同时记录状态变更时间和更新时间
+func (t *TaskAggregate) touchStatus(status valueobject.TaskStatus) time.Time {
+	now := time.Now()
+	t.Status = status
+	t.StatusChangedAt = now
+	t.UpdatedAt = now
+	return now
+}
+
+// TimeInStatus 返回任务在当前状态已停留的时长
+func (t *TaskAggregate) TimeInStatus(asOf time.Time) time.Duration {
+	if t.StatusChangedAt.IsZero() || asOf.Before(t.StatusChangedAt) {
+		return 0
+	}
+	return asOf.Sub(t.StatusChangedAt)
+}
+
+// EstimateVariance 返回实际工时与预估工时的偏差（小时）及其占预估工时的百分比，未预估工时时 ok 为 false
+func (t *TaskAggregate) EstimateVariance() (hours, percent float64, ok bool) {
+	if t.EstimatedHours <= 0 {
+		return 0, 0, false
+	}
+	estimated := float64(t.EstimatedHours)
+	hours = t.ActualHours - estimated
+	return hours, hours / estimated * 100, true
+}
+
 // ClearEvents 清除事件
 func (t *TaskAggregate) ClearEvents() {
 	t.Events = make([]event.DomainEvent, 0)
@@ -707,6 +1579,14 @@ func (t *TaskAggregate) GetEvents() []event.DomainEvent {
 	return t.Events
 }
 
+// ids 获取ID生成器，从仓储直接还原的聚合没有注入时回退到UUID
+func (t *TaskAggregate) ids() valueobject.IDGenerator {
+	if t.idGenerator == nil {
+		t.idGenerator = valueobject.NewUUIDGenerator()
+	}
+	return t.idGenerator
+}
+
 // addEvent 添加事件
 func (t *TaskAggregate) addEvent(event event.DomainEvent) {
 	t.Events = append(t.Events, event)
@@ -718,7 +1598,28 @@ var (
 	ErrTaskNotPendingApproval  = NewDomainError("TASK_NOT_PENDING_APPROVAL", "task is not pending approval")
 	ErrTaskNotApproved         = NewDomainError("TASK_NOT_APPROVED", "task is not approved")
 	ErrTaskNotInProgress       = NewDomainError("TASK_NOT_IN_PROGRESS", "task is not in progress")
+	ErrTaskNotPaused           = NewDomainError("TASK_NOT_PAUSED", "task is not paused")
+	ErrTaskPausedResumeFirst   = NewDomainError("TASK_PAUSED", "task is paused, resume the task before completing")
 	ErrInvalidStatusTransition = NewDomainError("INVALID_STATUS_TRANSITION", "invalid status transition")
+	ErrNoDeletePermission      = NewDomainError("NO_DELETE_PERMISSION", "user does not have permission to delete task")
+	ErrTaskDeleteRequiresForce = NewDomainError("TASK_DELETE_REQUIRES_FORCE", "task in an active state can only be deleted with force")
+	ErrTaskStartAfterDue       = NewDomainError("TASK_START_AFTER_DUE", "task start date cannot be after due date")
+	ErrRecurrenceRuleRequired  = NewDomainError("RECURRENCE_RULE_REQUIRED", "recurring tasks require a recurrence rule")
+	ErrInvalidRecurrenceRule   = NewDomainError("INVALID_RECURRENCE_RULE", "recurrence rule must have a valid frequency and positive interval")
+	ErrRecurrenceNeedsAnchor   = NewDomainError("RECURRENCE_ANCHOR_REQUIRED", "recurrence rule requires a start date or due date as anchor")
+	ErrRecurrenceEnded         = NewDomainError("RECURRENCE_ENDED", "recurrence has reached its end date or maximum executions")
+	ErrClosedTaskCannotRecur   = NewDomainError("TASK_CLOSED", "completed or cancelled tasks cannot become recurring")
+	ErrEmptyTaskTitle          = NewDomainError("EMPTY_TITLE", "task title cannot be empty")
+	ErrInvalidTag              = NewDomainError("INVALID_TAG", "tags must be non-empty, unique and at most 50 characters")
+	ErrExtensionNotFound       = NewDomainError("EXTENSION_NOT_FOUND", "extension request not found")
+	ErrExtensionNotPending     = NewDomainError("EXTENSION_NOT_PENDING", "extension request has already been resolved")
+	ErrInvalidReviewQuorum     = NewDomainError("INVALID_REVIEW_QUORUM", "review quorum must be between 1 and the number of reviewers")
+	ErrNoPendingSubmission     = NewDomainError("NO_PENDING_SUBMISSION", "participant has no work submission pending review")
+	ErrAlreadyReviewed         = NewDomainError("ALREADY_REVIEWED", "reviewer has already reviewed this submission")
+	ErrInvalidHours            = NewDomainError("INVALID_HOURS", "logged hours must be a positive number")
+	ErrParticipantWorkPending  = NewDomainError("PARTICIPANT_WORK_PENDING", "participants have work that is not yet approved")
+	ErrCoResponsibleNeedsLead  = NewDomainError("CO_RESPONSIBLE_WITHOUT_LEAD", "co-responsible users require a lead responsible user")
+	ErrAlreadyParticipant      = NewDomainError("ALREADY_PARTICIPANT", "user is already a task participant")
 )
 
 // DomainError 领域错误
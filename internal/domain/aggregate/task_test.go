@@ -0,0 +1,1643 @@
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// newTestTask 创建测试用任务并清空创建事件
+func newTestTask() *TaskAggregate {
+	dueDate := time.Now().Add(72 * time.Hour)
+	task := NewTask(
+		"task-1",
+		"Test Task",
+		"",
+		valueobject.TaskTypeRegular,
+		valueobject.TaskPriorityMedium,
+		"project-1",
+		"creator-1",
+		"responsible-1",
+		&dueDate,
+	)
+	task.ClearEvents()
+	return task
+}
+
+func TestTaskSubmitWork_OpenContributionEnrollsProjectMember(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.SetOpenContribution(true)
+	memberID := valueobject.UserID("member-1")
+
+	// Act
+	err := task.SubmitWorkAsProjectMember(memberID, true, "work content", nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !task.IsParticipant(memberID) {
+		t.Fatalf("Expected %s to be enrolled as participant", memberID)
+	}
+
+	events := task.GetEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	added, ok := events[0].(*event.ParticipantAddedEvent)
+	if !ok {
+		t.Fatalf("Expected first event to be ParticipantAdded, got %s", events[0].EventType())
+	}
+	if added.ParticipantID != string(memberID) {
+		t.Errorf("Expected participant %s, got %s", memberID, added.ParticipantID)
+	}
+	if _, ok := events[1].(*event.WorkSubmittedEvent); !ok {
+		t.Errorf("Expected second event to be WorkSubmitted, got %s", events[1].EventType())
+	}
+}
+
+func TestTaskSubmitWork_ClosedContributionRejectsNonParticipant(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	memberID := valueobject.UserID("member-1")
+
+	// Act
+	err := task.SubmitWorkAsProjectMember(memberID, true, "work content", nil)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for non-participant when open contribution is off")
+	}
+	if task.IsParticipant(memberID) {
+		t.Error("Expected non-participant not to be enrolled")
+	}
+	if len(task.GetEvents()) != 0 {
+		t.Errorf("Expected no events, got %d", len(task.GetEvents()))
+	}
+}
+
+func TestTaskSubmitWork_OpenContributionRejectsNonMember(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.SetOpenContribution(true)
+	outsiderID := valueobject.UserID("outsider-1")
+
+	// Act
+	err := task.SubmitWorkAsProjectMember(outsiderID, false, "work content", nil)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error for user outside the project")
+	}
+	if task.IsParticipant(outsiderID) {
+		t.Error("Expected outsider not to be enrolled")
+	}
+}
+
+func TestTaskSubmitWork_ExistingParticipantNotReAdded(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.SetOpenContribution(true)
+	participantID := valueobject.UserID("participant-1")
+	if err := task.AddParticipant(participantID, task.CreatorID); err != nil {
+		t.Fatalf("Failed to add participant: %v", err)
+	}
+	task.ClearEvents()
+
+	// Act
+	err := task.SubmitWork(participantID, "work content", nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.GetParticipantCount() != 1 {
+		t.Errorf("Expected 1 participant, got %d", task.GetParticipantCount())
+	}
+	events := task.GetEvents()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	if _, ok := events[0].(*event.WorkSubmittedEvent); !ok {
+		t.Errorf("Expected WorkSubmitted event, got %s", events[0].EventType())
+	}
+}
+
+func TestTaskSubmitWork_RecordsSubmission(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	attachments := []string{"file-1"}
+
+	// Act
+	err := task.SubmitWork(task.ResponsibleID, "work content", attachments)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(task.WorkSubmissions) != 1 {
+		t.Fatalf("Expected 1 submission, got %d", len(task.WorkSubmissions))
+	}
+	submission := task.WorkSubmissions[0]
+	if submission.SubmitterID != task.ResponsibleID || submission.Content != "work content" {
+		t.Errorf("Unexpected submission %+v", submission)
+	}
+	if submission.Status != valueobject.WorkSubmissionStatusPending {
+		t.Errorf("Expected pending status, got %s", submission.Status)
+	}
+	if len(submission.Attachments) != 1 || submission.Attachments[0] != "file-1" {
+		t.Errorf("Expected attachments to be recorded, got %v", submission.Attachments)
+	}
+}
+
+func TestTaskReviewWork_UpdatesLatestPendingSubmission(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	if err := task.SubmitWork(task.ResponsibleID, "first draft", nil); err != nil {
+		t.Fatalf("Failed to submit work: %v", err)
+	}
+	if err := task.SubmitWork(task.ResponsibleID, "second draft", nil); err != nil {
+		t.Fatalf("Failed to submit work: %v", err)
+	}
+
+	// Act
+	err := task.ReviewWork(task.ResponsibleID, task.CreatorID, false, "needs tests")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.WorkSubmissions[0].Status != valueobject.WorkSubmissionStatusPending {
+		t.Errorf("Expected earlier submission to stay pending, got %s", task.WorkSubmissions[0].Status)
+	}
+	reviewed := task.WorkSubmissions[1]
+	if reviewed.Status != valueobject.WorkSubmissionStatusRejected {
+		t.Errorf("Expected latest submission to be rejected, got %s", reviewed.Status)
+	}
+	if reviewed.ReviewerID == nil || *reviewed.ReviewerID != task.CreatorID || reviewed.ReviewedAt == nil {
+		t.Errorf("Expected reviewer and review time to be recorded, got %+v", reviewed)
+	}
+	if reviewed.ReviewComment == nil || *reviewed.ReviewComment != "needs tests" {
+		t.Errorf("Expected review comment to be recorded, got %v", reviewed.ReviewComment)
+	}
+}
+
+// sequenceIDGenerator 按序生成ID的测试生成器
+type sequenceIDGenerator struct {
+	valueobject.IDGenerator
+	next int
+}
+
+func (g *sequenceIDGenerator) GenerateExtensionRequestID() valueobject.ExtensionRequestID {
+	g.next++
+	return valueobject.ExtensionRequestID(fmt.Sprintf("ext-%d", g.next))
+}
+
+func TestTaskRequestExtension_IDsUniqueInSameSecond(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	newDueDate := time.Now().Add(96 * time.Hour)
+
+	// Act
+	first, err1 := task.RequestExtension(task.CreatorID, newDueDate, "first")
+	rejectErr := task.RejectExtension(first, task.CreatorID, "no")
+	second, err2 := task.RequestExtension(task.CreatorID, newDueDate, "second")
+
+	// Assert
+	if err1 != nil || rejectErr != nil || err2 != nil {
+		t.Fatalf("Expected no errors, got %v, %v, %v", err1, rejectErr, err2)
+	}
+	if first == "" || first == second {
+		t.Errorf("Expected distinct extension ids, got %q and %q", first, second)
+	}
+}
+
+func TestTaskFactory_UsesInjectedIDGenerator(t *testing.T) {
+	// Arrange
+	factory := NewTaskFactory(nil, &sequenceIDGenerator{})
+	task := factory.RestoreTask(valueobject.TaskData{ID: "task-1", WorkflowID: new(string)})
+	task.CreatorID = "creator-1"
+	dueDate := time.Now().Add(96 * time.Hour)
+
+	// Act
+	requestID, err := task.RequestExtension(task.CreatorID, dueDate, "more time")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requestID != "ext-1" {
+		t.Errorf("Expected injected id ext-1, got %s", requestID)
+	}
+}
+
+func TestTaskRequestExtension_RejectsSecondWhilePending(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	newDueDate := time.Now().Add(96 * time.Hour)
+	first, err := task.RequestExtension(task.CreatorID, newDueDate, "first")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	_, err = task.RequestExtension(task.CreatorID, newDueDate.Add(24*time.Hour), "second")
+
+	// Assert
+	var domainErr DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != "EXTENSION_ALREADY_PENDING" {
+		t.Fatalf("Expected EXTENSION_ALREADY_PENDING error, got %v", err)
+	}
+	if len(task.Extensions) != 1 {
+		t.Errorf("Expected 1 extension request, got %d", len(task.Extensions))
+	}
+	if pending := task.GetPendingExtension(); pending == nil || pending.ID != first {
+		t.Errorf("Expected pending extension %s, got %v", first, pending)
+	}
+}
+
+func TestTaskRequestExtension_AllowedAfterResolution(t *testing.T) {
+	tests := []struct {
+		name    string
+		resolve func(task *TaskAggregate, requestID valueobject.ExtensionRequestID) error
+	}{
+		{
+			name: "approved",
+			resolve: func(task *TaskAggregate, requestID valueobject.ExtensionRequestID) error {
+				return task.ApproveExtension(requestID, task.CreatorID)
+			},
+		},
+		{
+			name: "rejected",
+			resolve: func(task *TaskAggregate, requestID valueobject.ExtensionRequestID) error {
+				return task.RejectExtension(requestID, task.CreatorID, "no")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			task := newTestTask()
+			newDueDate := time.Now().Add(96 * time.Hour)
+			first, err := task.RequestExtension(task.CreatorID, newDueDate, "first")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if err := tt.resolve(task, first); err != nil {
+				t.Fatalf("Failed to resolve extension: %v", err)
+			}
+
+			// Act
+			second, err := task.RequestExtension(task.CreatorID, newDueDate.Add(24*time.Hour), "second")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if pending := task.GetPendingExtension(); pending == nil || pending.ID != second {
+				t.Errorf("Expected pending extension %s, got %v", second, pending)
+			}
+		})
+	}
+}
+
+func TestTaskApproveExtension_UpdatesDueDate(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	newDueDate := time.Now().Add(96 * time.Hour)
+	requestID, err := task.RequestExtension(task.CreatorID, newDueDate, "more time")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	err = task.ApproveExtension(requestID, task.CreatorID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.DueDate == nil || !task.DueDate.Equal(newDueDate) {
+		t.Errorf("Expected due date %v, got %v", newDueDate, task.DueDate)
+	}
+	if task.Extensions[0].Status != valueobject.ExtensionStatusApproved {
+		t.Errorf("Expected approved status, got %s", task.Extensions[0].Status)
+	}
+}
+
+func TestTaskAddParticipant_RejectsBeyondLimit(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.SetMaxParticipants(2)
+	for _, id := range []valueobject.UserID{"user-1", "user-2"} {
+		if err := task.AddParticipant(id, task.CreatorID); err != nil {
+			t.Fatalf("Failed to add participant %s: %v", id, err)
+		}
+	}
+
+	// Act
+	err := task.AddParticipant("user-3", task.CreatorID)
+
+	// Assert
+	var domainErr DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != "PARTICIPANT_LIMIT_EXCEEDED" {
+		t.Fatalf("Expected PARTICIPANT_LIMIT_EXCEEDED error, got %v", err)
+	}
+	if task.GetParticipantCount() != 2 {
+		t.Errorf("Expected 2 participants, got %d", task.GetParticipantCount())
+	}
+	if err := task.AddParticipant("user-1", task.CreatorID); err != nil {
+		t.Errorf("Expected re-adding an existing participant at the limit to succeed, got %v", err)
+	}
+}
+
+func TestTaskAddParticipantWithRole_RecordsRoleAndEvent(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.ClearEvents()
+
+	// Act
+	err := task.AddParticipantWithRole("user-1", valueobject.ParticipantRoleReviewer, task.CreatorID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	role := task.GetParticipantRole("user-1")
+	if role == nil || *role != valueobject.ParticipantRoleReviewer {
+		t.Fatalf("Expected reviewer role, got %v", role)
+	}
+	events := task.GetEvents()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	added, ok := events[0].(*event.ParticipantAddedEvent)
+	if !ok || added.Role != string(valueobject.ParticipantRoleReviewer) {
+		t.Errorf("Expected participant added event with reviewer role, got %#v", events[0])
+	}
+}
+
+func TestTaskAddParticipantWithRole_RejectsInvalidRole(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+
+	// Act
+	err := task.AddParticipantWithRole("user-1", "owner", task.CreatorID)
+
+	// Assert
+	var domainErr DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != "INVALID_PARTICIPANT_ROLE" {
+		t.Fatalf("Expected INVALID_PARTICIPANT_ROLE error, got %v", err)
+	}
+	if task.IsParticipant("user-1") {
+		t.Error("Expected participant not to be added")
+	}
+}
+
+func TestTaskAddParticipant_DefaultsToExecutor(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+
+	// Act
+	err := task.AddParticipant("user-1", task.CreatorID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if role := task.GetParticipantRole("user-1"); role == nil || *role != valueobject.ParticipantRoleExecutor {
+		t.Errorf("Expected executor role, got %v", role)
+	}
+}
+
+func TestTaskSwapParticipant_KeepsRoleAndSkipsExistingTarget(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	if err := task.AddParticipantWithRole("user-1", valueobject.ParticipantRoleReviewer, task.CreatorID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := task.AddParticipant("user-3", task.CreatorID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	task.ClearEvents()
+
+	// Act & Assert
+	if task.SwapParticipant("user-1", "user-3", task.CreatorID) {
+		t.Fatal("Expected no swap when the target is already a participant")
+	}
+	if task.SwapParticipant("user-9", "user-2", task.CreatorID) {
+		t.Fatal("Expected no swap when the source is not a participant")
+	}
+	if len(task.GetEvents()) != 0 {
+		t.Fatalf("Expected no events for skipped swaps, got %d", len(task.GetEvents()))
+	}
+
+	if !task.SwapParticipant("user-1", "user-2", task.CreatorID) {
+		t.Fatal("Expected participant to be swapped")
+	}
+	if task.IsParticipant("user-1") {
+		t.Error("Expected user-1 to be removed")
+	}
+	if role := task.GetParticipantRole("user-2"); role == nil || *role != valueobject.ParticipantRoleReviewer {
+		t.Errorf("Expected user-2 to keep the reviewer role, got %v", role)
+	}
+	events := task.GetEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if _, ok := events[0].(*event.ParticipantRemovedEvent); !ok {
+		t.Errorf("Expected participant removed event, got %#v", events[0])
+	}
+	if added, ok := events[1].(*event.ParticipantAddedEvent); !ok || added.Role != string(valueobject.ParticipantRoleReviewer) {
+		t.Errorf("Expected participant added event with reviewer role, got %#v", events[1])
+	}
+}
+
+func TestTaskAddParticipants_RejectsWholeBatchBeyondLimit(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.SetMaxParticipants(3)
+	if err := task.AddParticipant("user-1", task.CreatorID); err != nil {
+		t.Fatalf("Failed to add participant: %v", err)
+	}
+	task.ClearEvents()
+
+	// Act
+	err := task.AddParticipants([]valueobject.UserID{"user-2", "user-3", "user-4"}, task.CreatorID)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected error when batch exceeds the participant limit")
+	}
+	if task.GetParticipantCount() != 1 {
+		t.Errorf("Expected batch to be rejected entirely, got %d participants", task.GetParticipantCount())
+	}
+	if len(task.GetEvents()) != 0 {
+		t.Errorf("Expected no events, got %d", len(task.GetEvents()))
+	}
+}
+
+func TestTaskAddParticipants_IgnoresDuplicatesWhenCounting(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.SetMaxParticipants(3)
+	if err := task.AddParticipant("user-1", task.CreatorID); err != nil {
+		t.Fatalf("Failed to add participant: %v", err)
+	}
+
+	// Act
+	err := task.AddParticipants([]valueobject.UserID{"user-1", "user-2", "user-2", "user-3"}, task.CreatorID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.GetParticipantCount() != 3 {
+		t.Errorf("Expected 3 participants, got %d", task.GetParticipantCount())
+	}
+}
+
+func TestTaskFactory_MaxParticipantsConfigurable(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{name: "custom", limit: 1, expected: 1},
+		{name: "non-positive falls back to default", limit: 0, expected: DefaultMaxParticipants},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			factory := NewTaskFactory(acceptAllValidator{}, nil).WithMaxParticipants(tt.limit)
+			dueDate := time.Now().Add(72 * time.Hour)
+			task, err := factory.CreateTask("", "Limited", "", valueobject.TaskTypeRegular,
+				valueobject.TaskPriorityMedium, "project-1", "creator-1", "responsible-1", &dueDate)
+			if err != nil {
+				t.Fatalf("Failed to create task: %v", err)
+			}
+
+			// Act
+			for i := 0; i < tt.expected; i++ {
+				if err := task.AddParticipant(valueobject.UserID(fmt.Sprintf("user-%d", i)), task.CreatorID); err != nil {
+					t.Fatalf("Expected participant %d to be accepted, got %v", i, err)
+				}
+			}
+			err = task.AddParticipant("one-too-many", task.CreatorID)
+
+			// Assert
+			if err == nil {
+				t.Errorf("Expected participant beyond limit %d to be rejected", tt.expected)
+			}
+		})
+	}
+}
+
+// acceptAllValidator 不做校验的任务验证器
+type acceptAllValidator struct{}
+
+func (acceptAllValidator) ValidateTitle(title string) error             { return nil }
+func (acceptAllValidator) ValidateDescription(description string) error { return nil }
+func (acceptAllValidator) ValidateDueDate(dueDate *time.Time) error     { return nil }
+func (acceptAllValidator) ValidateEstimatedHours(hours int) error       { return nil }
+
+func TestTaskSetWorkflow_RecordsEvent(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+
+	// Act
+	if err := task.SetWorkflow("workflow-1", task.CreatorID); err != nil {
+		t.Fatalf("Failed to set workflow: %v", err)
+	}
+	if err := task.SetWorkflow("workflow-1", task.CreatorID); err != nil {
+		t.Fatalf("Setting the same workflow again should succeed, got %v", err)
+	}
+
+	// Assert
+	if task.WorkflowID != "workflow-1" {
+		t.Errorf("Expected workflow-1, got %q", task.WorkflowID)
+	}
+	if len(task.Events) != 1 {
+		t.Fatalf("Expected exactly one event, got %d", len(task.Events))
+	}
+	changed, ok := task.Events[0].(*event.TaskWorkflowChangedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskWorkflowChangedEvent, got %T", task.Events[0])
+	}
+	if changed.NewWorkflowID != "workflow-1" || changed.ActorID() != string(task.CreatorID) {
+		t.Errorf("Unexpected event payload: %+v", changed)
+	}
+}
+
+func TestTaskSetWorkflow_RequiresModifyPermission(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+
+	// Act
+	err := task.SetWorkflow("workflow-1", "outsider")
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an outsider to be rejected")
+	}
+	if task.WorkflowID != "" {
+		t.Errorf("Workflow must stay unset, got %q", task.WorkflowID)
+	}
+}
+
+func TestTaskChangeType_RecurringRequiresRule(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	rule := &valueobject.RecurrenceRule{Frequency: valueobject.RecurrenceWeekly, IntervalValue: 1}
+
+	// Act
+	missingRule := task.ChangeType(valueobject.TaskTypeRecurring, nil, task.CreatorID)
+	err := task.ChangeType(valueobject.TaskTypeRecurring, rule, task.CreatorID)
+
+	// Assert
+	if !errors.Is(missingRule, ErrRecurrenceRuleRequired) {
+		t.Fatalf("Expected ErrRecurrenceRuleRequired without a rule, got %v", missingRule)
+	}
+	if err != nil {
+		t.Fatalf("Failed to change type: %v", err)
+	}
+	if task.TaskType != valueobject.TaskTypeRecurring {
+		t.Errorf("Expected recurring task, got %s", task.TaskType)
+	}
+	if task.RecurrenceRule == nil || task.RecurrenceRule.Frequency != valueobject.RecurrenceWeekly {
+		t.Errorf("Expected weekly recurrence rule, got %+v", task.RecurrenceRule)
+	}
+	if len(task.Events) != 1 {
+		t.Fatalf("Expected exactly one event, got %d", len(task.Events))
+	}
+	changed, ok := task.Events[0].(*event.TaskTypeChangedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskTypeChangedEvent, got %T", task.Events[0])
+	}
+	if changed.OldType != "regular" || changed.NewType != "recurring" || changed.ActorID() != string(task.CreatorID) {
+		t.Errorf("Unexpected event payload: %+v", changed)
+	}
+
+	// 变回常规任务时清除重复规则
+	if err := task.ChangeType(valueobject.TaskTypeRegular, nil, task.CreatorID); err != nil {
+		t.Fatalf("Failed to change back to regular: %v", err)
+	}
+	if task.RecurrenceRule != nil {
+		t.Errorf("Expected recurrence rule to be cleared, got %+v", task.RecurrenceRule)
+	}
+}
+
+func TestTaskChangeType_CompletedTaskCannotBecomeRecurring(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.Status = valueobject.TaskStatusCompleted
+	rule := &valueobject.RecurrenceRule{Frequency: valueobject.RecurrenceDaily, IntervalValue: 1}
+
+	// Act
+	err := task.ChangeType(valueobject.TaskTypeRecurring, rule, task.CreatorID)
+
+	// Assert
+	if !errors.Is(err, ErrClosedTaskCannotRecur) {
+		t.Fatalf("Expected ErrClosedTaskCannotRecur, got %v", err)
+	}
+	if task.TaskType != valueobject.TaskTypeRegular || task.RecurrenceRule != nil {
+		t.Errorf("Task must be unchanged, got type %s rule %+v", task.TaskType, task.RecurrenceRule)
+	}
+	if len(task.Events) != 0 {
+		t.Errorf("Expected no events, got %d", len(task.Events))
+	}
+}
+
+func TestTaskChangeType_RejectsInvalidInput(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	badRule := &valueobject.RecurrenceRule{Frequency: valueobject.RecurrenceDaily, IntervalValue: 0}
+
+	// Act & Assert
+	if err := task.ChangeType(valueobject.TaskTypeRecurring, badRule, task.CreatorID); !errors.Is(err, ErrInvalidRecurrenceRule) {
+		t.Errorf("Expected ErrInvalidRecurrenceRule, got %v", err)
+	}
+	if err := task.ChangeType("weekly-ish", nil, task.CreatorID); err == nil {
+		t.Error("Expected an unknown task type to be rejected")
+	}
+	if err := task.ChangeType(valueobject.TaskTypeUrgent, nil, "outsider"); err == nil {
+		t.Error("Expected an outsider to be rejected")
+	}
+	if task.TaskType != valueobject.TaskTypeRegular {
+		t.Errorf("Task type must stay regular, got %s", task.TaskType)
+	}
+}
+
+func TestTaskFactory_RestoreTaskWithoutWorkflow(t *testing.T) {
+	// Arrange
+	factory := NewTaskFactory(acceptAllValidator{}, nil)
+
+	// Act
+	task := factory.RestoreTask(valueobject.TaskData{ID: "task-1", Status: string(valueobject.TaskStatusDraft)})
+
+	// Assert
+	if task.WorkflowID != "" {
+		t.Errorf("Expected empty workflow, got %q", task.WorkflowID)
+	}
+}
+
+func TestTaskApprove_RecordsApprovedAt(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	if err := task.SubmitForApproval(task.CreatorID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	before := time.Now()
+
+	// Act
+	err := task.Approve("approver-1", "ok")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.ApprovedAt == nil || task.ApprovedAt.Before(before) {
+		t.Errorf("Expected approved time to be recorded, got %v", task.ApprovedAt)
+	}
+}
+
+func TestTaskTransition_UpdatesStatusChangedAt(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	created := task.StatusChangedAt
+	if created.IsZero() {
+		t.Fatal("Expected new task to record status changed time")
+	}
+	task.StatusChangedAt = created.Add(-time.Hour)
+
+	// Act
+	err := task.SubmitForApproval(task.CreatorID)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.StatusChangedAt.Before(created) {
+		t.Errorf("Expected status changed time to move forward, got %v", task.StatusChangedAt)
+	}
+	if !task.StatusChangedAt.Equal(task.UpdatedAt) {
+		t.Errorf("Expected status changed time %v to equal updated time %v", task.StatusChangedAt, task.UpdatedAt)
+	}
+}
+
+func TestTaskTimeInStatus(t *testing.T) {
+	task := newTestTask()
+	task.StatusChangedAt = time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	if got := task.TimeInStatus(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)); got != 26*time.Hour {
+		t.Errorf("Expected 26h in status, got %v", got)
+	}
+	if got := task.TimeInStatus(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)); got != 0 {
+		t.Errorf("Expected zero duration before status change, got %v", got)
+	}
+}
+
+// newPausedTestTask 创建已暂停的测试任务
+func newPausedTestTask(t *testing.T) *TaskAggregate {
+	t.Helper()
+	task := newTestTask()
+	task.Status = valueobject.TaskStatusInProgress
+	if err := task.Pause("responsible-1", "waiting for input"); err != nil {
+		t.Fatalf("Expected no error pausing task, got %v", err)
+	}
+	task.ClearEvents()
+	return task
+}
+
+func TestTaskComplete_PausedTaskRequiresResume(t *testing.T) {
+	// Arrange
+	task := newPausedTestTask(t)
+
+	// Act
+	err := task.Complete("responsible-1")
+
+	// Assert
+	if !errors.Is(err, ErrTaskPausedResumeFirst) {
+		t.Fatalf("Expected ErrTaskPausedResumeFirst, got %v", err)
+	}
+	var domainErr DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != "TASK_PAUSED" {
+		t.Errorf("Expected TASK_PAUSED error code, got %v", err)
+	}
+	if task.Status != valueobject.TaskStatusPaused {
+		t.Errorf("Expected task to stay paused, got %s", task.Status)
+	}
+	if len(task.GetEvents()) != 0 {
+		t.Errorf("Expected no events, got %d", len(task.GetEvents()))
+	}
+}
+
+func TestTaskComplete_AfterResumeSucceeds(t *testing.T) {
+	task := newPausedTestTask(t)
+
+	if err := task.SubmitCompletion("responsible-1", "done"); !errors.Is(err, ErrTaskPausedResumeFirst) {
+		t.Fatalf("Expected ErrTaskPausedResumeFirst on submit, got %v", err)
+	}
+	if err := task.Resume("responsible-1"); err != nil {
+		t.Fatalf("Expected no error resuming, got %v", err)
+	}
+	if err := task.Complete("responsible-1"); err != nil {
+		t.Fatalf("Expected no error completing, got %v", err)
+	}
+	if task.Status != valueobject.TaskStatusCompleted {
+		t.Errorf("Expected completed status, got %s", task.Status)
+	}
+}
+
+func TestTaskComplete_NotStartedKeepsGenericError(t *testing.T) {
+	task := newTestTask()
+
+	if err := task.Complete("responsible-1"); !errors.Is(err, ErrTaskNotInProgress) {
+		t.Fatalf("Expected ErrTaskNotInProgress, got %v", err)
+	}
+}
+
+func TestTaskUpdateBasicInfo_RejectsBlankTitle(t *testing.T) {
+	for _, title := range []string{"", "   ", "\t\n"} {
+		task := newTestTask()
+
+		err := task.UpdateBasicInfo(title, "new description")
+
+		if !errors.Is(err, ErrEmptyTaskTitle) {
+			t.Fatalf("title %q: expected ErrEmptyTaskTitle, got %v", title, err)
+		}
+		if task.Title != "Test Task" || task.Description != nil {
+			t.Errorf("title %q: task must be unchanged, got title %q", title, task.Title)
+		}
+	}
+}
+
+func TestTaskUpdateBasicInfo_TrimsTitle(t *testing.T) {
+	task := newTestTask()
+
+	if err := task.UpdateBasicInfo("  Quarterly report \n", "details"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if task.Title != "Quarterly report" {
+		t.Errorf("expected trimmed title, got %q", task.Title)
+	}
+	if task.Description == nil || *task.Description != "details" {
+		t.Errorf("expected description to be updated, got %v", task.Description)
+	}
+}
+
+func TestTaskUpdateBasicInfo_TextLengthLimits(t *testing.T) {
+	task := newTestTask()
+	task.SetTextLimits(valueobject.TextLimits{NameMaxLength: 5, DescriptionMaxLength: 8})
+
+	err := task.UpdateBasicInfo("Report", strings.Repeat("d", 9))
+
+	var validationErr *valueobject.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	fields := []string{}
+	for _, v := range validationErr.Violations {
+		fields = append(fields, v.Field)
+	}
+	if strings.Join(fields, ",") != "title,description" {
+		t.Errorf("expected title and description violations, got %v", fields)
+	}
+	if task.Title != "Test Task" {
+		t.Errorf("task must be unchanged, got title %q", task.Title)
+	}
+
+	if err := task.UpdateBasicInfo("Repor", strings.Repeat("d", 8)+"\t\n"); err != nil {
+		t.Fatalf("expected boundary-length title and description to be accepted, got %v", err)
+	}
+	if task.Description == nil || *task.Description != strings.Repeat("d", 8) {
+		t.Errorf("expected trailing whitespace to be trimmed, got %v", task.Description)
+	}
+}
+
+// dueDateChangedEvents 收集任务的截止日期变更事件
+func dueDateChangedEvents(task *TaskAggregate) []*event.TaskDueDateChangedEvent {
+	var events []*event.TaskDueDateChangedEvent
+	for _, e := range task.GetEvents() {
+		if changed, ok := e.(*event.TaskDueDateChangedEvent); ok {
+			events = append(events, changed)
+		}
+	}
+	return events
+}
+
+func TestTaskUpdateSchedule_EmitsDueDateChangedEvent(t *testing.T) {
+	task := newTestTask()
+	oldDueDate := *task.DueDate
+	newDueDate := oldDueDate.Add(24 * time.Hour)
+
+	if err := task.UpdateSchedule(nil, &newDueDate, task.CreatorID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	events := dueDateChangedEvents(task)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 due date changed event, got %d", len(events))
+	}
+	if events[0].OldDueDate == nil || !events[0].OldDueDate.Equal(oldDueDate) {
+		t.Errorf("expected old due date %v, got %v", oldDueDate, events[0].OldDueDate)
+	}
+	if events[0].NewDueDate == nil || !events[0].NewDueDate.Equal(newDueDate) {
+		t.Errorf("expected new due date %v, got %v", newDueDate, events[0].NewDueDate)
+	}
+	if events[0].ActorID() != string(task.CreatorID) {
+		t.Errorf("expected actor %s, got %s", task.CreatorID, events[0].ActorID())
+	}
+}
+
+func TestTaskUpdateSchedule_UnchangedDueDateEmitsNoEvent(t *testing.T) {
+	task := newTestTask()
+	sameDueDate := *task.DueDate
+
+	if err := task.UpdateSchedule(nil, &sameDueDate, task.CreatorID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if events := dueDateChangedEvents(task); len(events) != 0 {
+		t.Errorf("expected no due date changed event, got %d", len(events))
+	}
+}
+
+func TestTaskUpdateSchedule_StoresStartDate(t *testing.T) {
+	task := newTestTask()
+	dueDate := *task.DueDate
+	startDate := dueDate.Add(-72 * time.Hour)
+
+	if err := task.UpdateSchedule(&startDate, &dueDate, task.CreatorID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if task.StartDate == nil || !task.StartDate.Equal(startDate) {
+		t.Errorf("expected start date %v, got %v", startDate, task.StartDate)
+	}
+
+	// 开始日期与截止日期相同是允许的
+	if err := task.UpdateSchedule(&dueDate, &dueDate, task.CreatorID); err != nil {
+		t.Errorf("expected start date equal to due date to be accepted, got %v", err)
+	}
+}
+
+func TestTaskUpdateSchedule_RejectsStartAfterDueDate(t *testing.T) {
+	task := newTestTask()
+	dueDate := *task.DueDate
+	originalStart := dueDate.Add(-time.Hour)
+	task.StartDate = &originalStart
+	startDate := dueDate.Add(time.Hour)
+	newDueDate := dueDate.Add(30 * time.Minute)
+
+	err := task.UpdateSchedule(&startDate, &newDueDate, task.CreatorID)
+
+	if !errors.Is(err, ErrTaskStartAfterDue) {
+		t.Fatalf("expected ErrTaskStartAfterDue, got %v", err)
+	}
+	if !task.StartDate.Equal(originalStart) || !task.DueDate.Equal(dueDate) {
+		t.Errorf("rejected schedule must not change dates, got start %v due %v", task.StartDate, task.DueDate)
+	}
+	if events := dueDateChangedEvents(task); len(events) != 0 {
+		t.Errorf("expected no due date changed event, got %d", len(events))
+	}
+}
+
+func TestTaskUpdateSchedule_RecurringTaskKeepsAnchor(t *testing.T) {
+	task := newTestTask()
+	task.TaskType = valueobject.TaskTypeRecurring
+	dueDate := *task.DueDate
+
+	err := task.UpdateSchedule(nil, nil, task.CreatorID)
+
+	if !errors.Is(err, ErrRecurrenceNeedsAnchor) {
+		t.Fatalf("expected ErrRecurrenceNeedsAnchor, got %v", err)
+	}
+	if task.DueDate == nil || !task.DueDate.Equal(dueDate) {
+		t.Errorf("rejected schedule must keep the due date, got %v", task.DueDate)
+	}
+
+	// 只保留开始日期时仍有锚点
+	startDate := dueDate.Add(-time.Hour)
+	if err := task.UpdateSchedule(&startDate, nil, task.CreatorID); err != nil {
+		t.Fatalf("expected start date to anchor the recurrence, got %v", err)
+	}
+	if task.DueDate != nil || !task.StartDate.Equal(startDate) {
+		t.Errorf("expected start %v and no due date, got start %v due %v", startDate, task.StartDate, task.DueDate)
+	}
+}
+
+func TestTaskApproveExtension_EmitsDueDateChangedEvent(t *testing.T) {
+	task := newTestTask()
+	oldDueDate := *task.DueDate
+	requestID, err := task.RequestExtension(task.ResponsibleID, oldDueDate.Add(48*time.Hour), "more time")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := task.ApproveExtension(requestID, task.CreatorID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	events := dueDateChangedEvents(task)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 due date changed event, got %d", len(events))
+	}
+	if !events[0].OldDueDate.Equal(oldDueDate) || !events[0].NewDueDate.Equal(*task.DueDate) {
+		t.Errorf("expected %v -> %v, got %v -> %v", oldDueDate, *task.DueDate, events[0].OldDueDate, events[0].NewDueDate)
+	}
+	if events[0].ActorID() != string(task.CreatorID) {
+		t.Errorf("expected actor %s, got %s", task.CreatorID, events[0].ActorID())
+	}
+}
+
+func TestTaskExtension_ResolvingTwiceIsRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		resolve func(task *TaskAggregate, requestID valueobject.ExtensionRequestID) error
+	}{
+		{
+			name: "approve",
+			resolve: func(task *TaskAggregate, requestID valueobject.ExtensionRequestID) error {
+				return task.ApproveExtension(requestID, task.CreatorID)
+			},
+		},
+		{
+			name: "reject",
+			resolve: func(task *TaskAggregate, requestID valueobject.ExtensionRequestID) error {
+				return task.RejectExtension(requestID, task.CreatorID, "no")
+			},
+		},
+	}
+
+	for _, first := range tests {
+		for _, second := range tests {
+			t.Run(first.name+" then "+second.name, func(t *testing.T) {
+				task := newTestTask()
+				requestID, err := task.RequestExtension(task.ResponsibleID, time.Now().Add(96*time.Hour), "more time")
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if err := first.resolve(task, requestID); err != nil {
+					t.Fatalf("pending extension should resolve, got %v", err)
+				}
+				dueDate := *task.DueDate
+				task.ClearEvents()
+
+				err = second.resolve(task, requestID)
+
+				if !errors.Is(err, ErrExtensionNotPending) {
+					t.Fatalf("expected ErrExtensionNotPending, got %v", err)
+				}
+				if !task.DueDate.Equal(dueDate) {
+					t.Errorf("due date must be unchanged, got %v", task.DueDate)
+				}
+				if len(task.GetEvents()) != 0 {
+					t.Errorf("expected no events, got %d", len(task.GetEvents()))
+				}
+			})
+		}
+	}
+}
+
+func TestTaskApproveExtension_UnknownRequest(t *testing.T) {
+	task := newTestTask()
+
+	err := task.ApproveExtension("missing", task.CreatorID)
+
+	if !errors.Is(err, ErrExtensionNotFound) {
+		t.Fatalf("expected ErrExtensionNotFound, got %v", err)
+	}
+}
+
+// newQuorumReviewTask 创建需要 quorum 名审核人（共 reviewer-1..3）通过的任务，并由负责人提交一次工作
+func newQuorumReviewTask(t *testing.T, quorum int) *TaskAggregate {
+	t.Helper()
+	task := newTestTask()
+	reviewers := []valueobject.UserID{"reviewer-1", "reviewer-2", "reviewer-3"}
+	if err := task.SetReviewers(reviewers, quorum, task.CreatorID); err != nil {
+		t.Fatalf("failed to set reviewers: %v", err)
+	}
+	if err := task.SubmitWork(task.ResponsibleID, "draft", nil); err != nil {
+		t.Fatalf("failed to submit work: %v", err)
+	}
+	task.ClearEvents()
+	return task
+}
+
+func TestTaskReviewWork_QuorumReached(t *testing.T) {
+	task := newQuorumReviewTask(t, 2)
+
+	if err := task.ReviewWork(task.ResponsibleID, "reviewer-1", true, "ok"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if task.WorkSubmissions[0].Status != valueobject.WorkSubmissionStatusPending {
+		t.Fatalf("one approval must not accept the work, got %s", task.WorkSubmissions[0].Status)
+	}
+	if len(task.GetEvents()) != 0 {
+		t.Fatalf("expected no events before quorum, got %d", len(task.GetEvents()))
+	}
+	if err := task.ReviewWork(task.ResponsibleID, "reviewer-1", true, "again"); !errors.Is(err, ErrAlreadyReviewed) {
+		t.Fatalf("expected ErrAlreadyReviewed, got %v", err)
+	}
+
+	if err := task.ReviewWork(task.ResponsibleID, "reviewer-3", true, "lgtm"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	submission := task.WorkSubmissions[0]
+	if submission.Status != valueobject.WorkSubmissionStatusApproved {
+		t.Errorf("expected approved status, got %s", submission.Status)
+	}
+	if len(submission.Decisions) != 2 || submission.Approvals() != 2 {
+		t.Errorf("expected 2 approving decisions, got %+v", submission.Decisions)
+	}
+	events := task.GetEvents()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	reviewed, ok := events[0].(*event.WorkReviewedEvent)
+	if !ok || !reviewed.Approved || reviewed.ReviewerID != "reviewer-3" {
+		t.Errorf("expected approving WorkReviewed event from reviewer-3, got %+v", events[0])
+	}
+}
+
+func TestTaskReviewWork_SingleRejectShortCircuits(t *testing.T) {
+	task := newQuorumReviewTask(t, 2)
+	if err := task.ReviewWork(task.ResponsibleID, "reviewer-1", true, "ok"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := task.ReviewWork(task.ResponsibleID, "reviewer-2", false, "missing tests"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if task.WorkSubmissions[0].Status != valueobject.WorkSubmissionStatusRejected {
+		t.Errorf("expected rejected status, got %s", task.WorkSubmissions[0].Status)
+	}
+	events := task.GetEvents()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if reviewed, ok := events[0].(*event.WorkReviewedEvent); !ok || reviewed.Approved {
+		t.Errorf("expected rejecting WorkReviewed event, got %+v", events[0])
+	}
+	// 已驳回的提交不再接受审核意见
+	if err := task.ReviewWork(task.ResponsibleID, "reviewer-3", true, "late"); !errors.Is(err, ErrNoPendingSubmission) {
+		t.Errorf("expected ErrNoPendingSubmission, got %v", err)
+	}
+}
+
+func TestTaskReviewWork_QuorumRequiresConfiguredReviewer(t *testing.T) {
+	task := newQuorumReviewTask(t, 2)
+
+	err := task.ReviewWork(task.ResponsibleID, task.CreatorID, true, "ok")
+
+	if err == nil {
+		t.Fatal("expected non-reviewer to be rejected")
+	}
+	if len(task.WorkSubmissions[0].Decisions) != 0 {
+		t.Errorf("expected no decisions, got %+v", task.WorkSubmissions[0].Decisions)
+	}
+}
+
+func TestTaskSetReviewers_ValidatesQuorum(t *testing.T) {
+	reviewers := []valueobject.UserID{"reviewer-1", "reviewer-2", "reviewer-2"}
+	for _, quorum := range []int{0, 3} {
+		task := newTestTask()
+		if err := task.SetReviewers(reviewers, quorum, task.CreatorID); !errors.Is(err, ErrInvalidReviewQuorum) {
+			t.Errorf("quorum %d: expected ErrInvalidReviewQuorum, got %v", quorum, err)
+		}
+	}
+
+	task := newTestTask()
+	if err := task.SetReviewers(reviewers, 2, task.CreatorID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(task.Reviewers) != 2 || task.ReviewQuorum != 2 {
+		t.Errorf("expected 2 unique reviewers with quorum 2, got %v / %d", task.Reviewers, task.ReviewQuorum)
+	}
+	if err := task.SetReviewers(nil, 2, task.CreatorID); err != nil || task.ReviewQuorum != 0 {
+		t.Errorf("clearing reviewers should reset quorum, got %v / %d", err, task.ReviewQuorum)
+	}
+}
+
+func TestTaskSubmitCompletion_BlockedByOutstandingParticipantWork(t *testing.T) {
+	task := newTestTask()
+	task.Status = valueobject.TaskStatusInProgress
+	for _, id := range []valueobject.UserID{"alice", "bob"} {
+		if err := task.AddParticipant(id, task.ResponsibleID); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+	if err := task.AddParticipantWithRole("olga", valueobject.ParticipantRoleObserver, task.ResponsibleID); err != nil {
+		t.Fatalf("Failed to add observer: %v", err)
+	}
+	for _, id := range []valueobject.UserID{"alice", "bob"} {
+		if err := task.SubmitWork(id, "draft", nil); err != nil {
+			t.Fatalf("Failed to submit work: %v", err)
+		}
+	}
+	if err := task.ReviewWork("alice", task.CreatorID, true, ""); err != nil {
+		t.Fatalf("Failed to review work: %v", err)
+	}
+	if err := task.ReviewWork("bob", task.CreatorID, false, "incomplete"); err != nil {
+		t.Fatalf("Failed to review work: %v", err)
+	}
+	task.ClearEvents()
+
+	err := task.SubmitCompletion(task.ResponsibleID, "done")
+
+	if !errors.Is(err, ErrParticipantWorkPending) {
+		t.Fatalf("Expected ErrParticipantWorkPending, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "bob") || strings.Contains(err.Error(), "alice") || strings.Contains(err.Error(), "olga") {
+		t.Errorf("Expected only bob to be listed as outstanding, got %q", err.Error())
+	}
+	if len(task.GetEvents()) != 0 {
+		t.Errorf("Expected no events, got %d", len(task.GetEvents()))
+	}
+}
+
+func TestTaskSubmitCompletion_AllowedOnceAllWorkApproved(t *testing.T) {
+	task := newTestTask()
+	task.Status = valueobject.TaskStatusInProgress
+	if err := task.AddParticipant("bob", task.ResponsibleID); err != nil {
+		t.Fatalf("Failed to add participant: %v", err)
+	}
+	if err := task.SubmitCompletion(task.ResponsibleID, "done"); !errors.Is(err, ErrParticipantWorkPending) {
+		t.Fatalf("Expected participant without submission to block completion, got %v", err)
+	}
+
+	if err := task.SubmitWork("bob", "final", nil); err != nil {
+		t.Fatalf("Failed to submit work: %v", err)
+	}
+	if err := task.ReviewWork("bob", task.CreatorID, true, ""); err != nil {
+		t.Fatalf("Failed to review work: %v", err)
+	}
+	task.ClearEvents()
+
+	if err := task.SubmitCompletion(task.ResponsibleID, "done"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(task.GetEvents()) != 1 {
+		t.Errorf("Expected completion submitted event, got %d events", len(task.GetEvents()))
+	}
+}
+
+func TestTaskAssignResponsibles_CoResponsibleCanModifyAndExecute(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+
+	// Act
+	err := task.AssignResponsibles("lead-1", []valueobject.UserID{"co-1", "lead-1", "co-2", "co-1", ""}, "creator-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to assign responsibles: %v", err)
+	}
+	if task.ResponsibleID != "lead-1" {
+		t.Errorf("Expected lead-1 as lead, got %q", task.ResponsibleID)
+	}
+	if got := fmt.Sprint(task.CoResponsibleIDs); got != "[co-1 co-2]" {
+		t.Errorf("Co-responsibles must be deduplicated and exclude the lead, got %s", got)
+	}
+	for _, userID := range []valueobject.UserID{"lead-1", "co-1", "co-2"} {
+		if !task.CanUserModify(userID) || !task.CanUserExecute(userID) || !task.CanUserView(userID) {
+			t.Errorf("%s should be able to view, modify and execute the task", userID)
+		}
+	}
+	if task.CanUserModify("responsible-1") {
+		t.Error("The previous responsible user should lose modify permission")
+	}
+	if len(task.Events) != 1 {
+		t.Fatalf("Expected exactly one assignment event, got %d", len(task.Events))
+	}
+	assigned, ok := task.Events[0].(*event.TaskAssignedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskAssignedEvent, got %T", task.Events[0])
+	}
+	if got := fmt.Sprint(assigned.Assignees()); got != "[lead-1 co-1 co-2]" {
+		t.Errorf("Expected every responsible user in the event, got %s", got)
+	}
+	if assigned.PreviousExecutorID == nil || *assigned.PreviousExecutorID != "responsible-1" {
+		t.Errorf("Expected previous executor responsible-1, got %v", assigned.PreviousExecutorID)
+	}
+	if err := task.SetWorkflow("workflow-1", "co-2"); err != nil {
+		t.Errorf("A co-responsible user should be able to modify the task: %v", err)
+	}
+}
+
+func TestTaskAssignResponsible_SingleAssignmentClearsCoResponsibles(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	if err := task.AssignResponsibles("lead-1", []valueobject.UserID{"co-1"}, "creator-1"); err != nil {
+		t.Fatalf("Failed to assign responsibles: %v", err)
+	}
+
+	// Act
+	if err := task.AssignResponsible("solo-1", "creator-1"); err != nil {
+		t.Fatalf("Failed to assign responsible: %v", err)
+	}
+
+	// Assert
+	if task.ResponsibleID != "solo-1" || len(task.CoResponsibleIDs) != 0 {
+		t.Errorf("Expected only solo-1 to be responsible, got %q and %v", task.ResponsibleID, task.CoResponsibleIDs)
+	}
+	if task.CanUserModify("co-1") {
+		t.Error("A removed co-responsible user should lose modify permission")
+	}
+}
+
+func TestTaskAssignResponsibles_RequiresLead(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+
+	// Act
+	err := task.AssignResponsibles("", []valueobject.UserID{"co-1"}, "creator-1")
+
+	// Assert
+	if !errors.Is(err, ErrCoResponsibleNeedsLead) {
+		t.Fatalf("Expected ErrCoResponsibleNeedsLead, got %v", err)
+	}
+	if task.ResponsibleID != "responsible-1" || len(task.Events) != 0 {
+		t.Error("A rejected assignment must not change the task")
+	}
+}
+
+func TestTaskRecurrence_RequiresAnchorDate(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	task.TaskType = valueobject.TaskTypeRecurring
+	task.DueDate = nil
+	regular := newTestTask()
+	regular.DueDate = nil
+	rule := &valueobject.RecurrenceRule{Frequency: valueobject.RecurrenceWeekly, IntervalValue: 1}
+
+	// Act
+	setErr := task.SetRecurrenceRule(valueobject.RecurrenceWeekly, 1, nil, nil)
+	changeErr := regular.ChangeType(valueobject.TaskTypeRecurring, rule, regular.CreatorID)
+
+	// Assert
+	if !errors.Is(setErr, ErrRecurrenceNeedsAnchor) {
+		t.Errorf("Expected ErrRecurrenceNeedsAnchor from SetRecurrenceRule, got %v", setErr)
+	}
+	if !errors.Is(changeErr, ErrRecurrenceNeedsAnchor) {
+		t.Errorf("Expected ErrRecurrenceNeedsAnchor from ChangeType, got %v", changeErr)
+	}
+	if task.RecurrenceRule != nil || regular.RecurrenceRule != nil || regular.TaskType != valueobject.TaskTypeRegular {
+		t.Errorf("Tasks must be unchanged, got rules %+v / %+v, type %s", task.RecurrenceRule, regular.RecurrenceRule, regular.TaskType)
+	}
+
+	// 开始日期同样可以作为锚点
+	start := time.Now()
+	task.StartDate = &start
+	if err := task.SetRecurrenceRule(valueobject.RecurrenceWeekly, 1, nil, nil); err != nil {
+		t.Errorf("Expected a start date to anchor the rule, got %v", err)
+	}
+}
+
+func TestTaskPrepareNextExecution_AlignsToAnchor(t *testing.T) {
+	// Arrange
+	task := newTestTask()
+	start := time.Now().UTC().AddDate(0, 0, -30).Truncate(time.Hour)
+	task.StartDate = &start
+	task.TaskType = valueobject.TaskTypeRecurring
+	if err := task.SetRecurrenceRule(valueobject.RecurrenceWeekly, 2, nil, nil); err != nil {
+		t.Fatalf("Failed to set recurrence rule: %v", err)
+	}
+	task.ClearEvents()
+
+	// Act
+	before := time.Now()
+	executionID, err := task.PrepareNextExecution()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to prepare next execution: %v", err)
+	}
+	prepared, ok := task.Events[0].(*event.NextExecutionPreparedEvent)
+	if !ok || prepared.ExecutionID != string(executionID) {
+		t.Fatalf("Expected NextExecutionPreparedEvent for %s, got %+v", executionID, task.Events[0])
+	}
+	// 开始日期之后每两周一次：30 天前开始，下次执行为第 3 个周期，即开始后 42 天
+	if want := start.AddDate(0, 0, 42); !prepared.ExecutionDate.Equal(want) {
+		t.Errorf("Expected next execution %v, got %v", want, prepared.ExecutionDate)
+	}
+	if !prepared.ExecutionDate.After(before) {
+		t.Errorf("Next execution %v must be in the future", prepared.ExecutionDate)
+	}
+
+	// 未设置开始日期时以截止日期为锚点，截止日期未到时就是下次执行时间
+	task.StartDate = nil
+	task.ClearEvents()
+	if _, err := task.PrepareNextExecution(); err != nil {
+		t.Fatalf("Failed to prepare next execution: %v", err)
+	}
+	if got := task.Events[0].(*event.NextExecutionPreparedEvent).ExecutionDate; !got.Equal(*task.DueDate) {
+		t.Errorf("Expected next execution at the due date %v, got %v", *task.DueDate, got)
+	}
+}
+
+func TestTaskPrepareNextExecution_Frequencies(t *testing.T) {
+	now := time.Now().UTC()
+	recent := now.Truncate(time.Hour).AddDate(0, 0, -7)
+	// 三个月前的 15 日，每两个月一次时上一次在上个月，下一次在下个月
+	monthAnchor := time.Date(now.Year(), now.Month(), 15, 9, 0, 0, 0, time.UTC).AddDate(0, -3, 0)
+	intPtr := func(v int) *int { return &v }
+	timePtr := func(v time.Time) *time.Time { return &v }
+
+	tests := []struct {
+		name          string
+		frequency     valueobject.RecurrenceFrequency
+		interval      int
+		anchor        time.Time
+		endDate       *time.Time
+		maxExecutions *int
+		want          time.Time
+		wantErr       error
+	}{
+		{name: "daily", frequency: valueobject.RecurrenceDaily, interval: 3, anchor: recent, want: recent.AddDate(0, 0, 9)},
+		{name: "weekly", frequency: valueobject.RecurrenceWeekly, interval: 1, anchor: recent, want: recent.AddDate(0, 0, 14)},
+		{name: "monthly", frequency: valueobject.RecurrenceMonthly, interval: 2, anchor: monthAnchor, want: monthAnchor.AddDate(0, 4, 0)},
+		{name: "end date on next execution", frequency: valueobject.RecurrenceDaily, interval: 3, anchor: recent,
+			endDate: timePtr(recent.AddDate(0, 0, 9)), want: recent.AddDate(0, 0, 9)},
+		{name: "end date before next execution", frequency: valueobject.RecurrenceDaily, interval: 3, anchor: recent,
+			endDate: timePtr(recent.AddDate(0, 0, 9).Add(-time.Second)), wantErr: ErrRecurrenceEnded},
+		{name: "last allowed execution", frequency: valueobject.RecurrenceDaily, interval: 3, anchor: recent,
+			maxExecutions: intPtr(4), want: recent.AddDate(0, 0, 9)},
+		{name: "max executions reached", frequency: valueobject.RecurrenceDaily, interval: 3, anchor: recent,
+			maxExecutions: intPtr(3), wantErr: ErrRecurrenceEnded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			task := newTestTask()
+			task.TaskType = valueobject.TaskTypeRecurring
+			task.StartDate = &tt.anchor
+			if err := task.SetRecurrenceRule(tt.frequency, tt.interval, tt.endDate, tt.maxExecutions); err != nil {
+				t.Fatalf("Failed to set recurrence rule: %v", err)
+			}
+			task.ClearEvents()
+
+			// Act
+			_, err := task.PrepareNextExecution()
+
+			// Assert
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+				}
+				if len(task.Events) != 0 {
+					t.Errorf("An ended recurrence must not publish events, got %d", len(task.Events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to prepare next execution: %v", err)
+			}
+			if got := task.Events[0].(*event.NextExecutionPreparedEvent).ExecutionDate; !got.Equal(tt.want) {
+				t.Errorf("Expected next execution %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTaskCancel_RejectsTerminalStatuses(t *testing.T) {
+	for _, status := range []valueobject.TaskStatus{valueobject.TaskStatusCompleted, valueobject.TaskStatusCancelled} {
+		task := newTestTask()
+		task.Status = status
+
+		err := task.Cancel("creator-1", "no longer needed")
+
+		if !errors.Is(err, ErrInvalidStatusTransition) {
+			t.Fatalf("%s: expected ErrInvalidStatusTransition, got %v", status, err)
+		}
+		if want := string(status) + " -> cancelled"; !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected the error to name %q, got %v", status, want, err)
+		}
+		if task.Status != status || len(task.GetEvents()) != 0 {
+			t.Errorf("%s: a rejected cancel must not change the task", status)
+		}
+	}
+}
+
+func TestTaskCancel_RecordsPreviousStatus(t *testing.T) {
+	task := newPausedTestTask(t)
+	task.ClearEvents()
+
+	if err := task.Cancel("creator-1", "scope cut"); err != nil {
+		t.Fatalf("Expected a paused task to be cancellable, got %v", err)
+	}
+
+	changed, ok := task.GetEvents()[0].(*event.TaskStatusChangedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskStatusChangedEvent, got %T", task.GetEvents()[0])
+	}
+	if changed.OldStatus != string(valueobject.TaskStatusPaused) || changed.NewStatus != string(valueobject.TaskStatusCancelled) {
+		t.Errorf("Expected paused -> cancelled, got %s -> %s", changed.OldStatus, changed.NewStatus)
+	}
+}
+
+func TestTaskResume_OnlyFromPaused(t *testing.T) {
+	statuses := []valueobject.TaskStatus{
+		valueobject.TaskStatusDraft, valueobject.TaskStatusPendingApproval, valueobject.TaskStatusApproved,
+		valueobject.TaskStatusRejected, valueobject.TaskStatusInProgress, valueobject.TaskStatusCompleted,
+		valueobject.TaskStatusCancelled,
+	}
+	for _, status := range statuses {
+		task := newTestTask()
+		task.Status = status
+
+		err := task.Resume("responsible-1")
+
+		if !errors.Is(err, ErrTaskNotPaused) || !errors.Is(err, ErrInvalidStatusTransition) {
+			t.Errorf("%s: expected ErrTaskNotPaused and ErrInvalidStatusTransition, got %v", status, err)
+		}
+		if task.Status != status {
+			t.Errorf("%s: a rejected resume must not change the status, got %s", status, task.Status)
+		}
+	}
+
+	task := newPausedTestTask(t)
+	if err := task.Resume("responsible-1"); err != nil {
+		t.Fatalf("Expected a paused task to resume, got %v", err)
+	}
+	if task.Status != valueobject.TaskStatusInProgress {
+		t.Errorf("Expected in_progress after resume, got %s", task.Status)
+	}
+}
+
+func TestTaskStatusMethods_RejectIllegalTransitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   valueobject.TaskStatus
+		action func(task *TaskAggregate) error
+		cause  error
+	}{
+		{"submit approved task", valueobject.TaskStatusApproved, func(task *TaskAggregate) error { return task.SubmitForApproval("creator-1") }, ErrTaskNotInDraft},
+		{"approve draft", valueobject.TaskStatusDraft, func(task *TaskAggregate) error { return task.Approve("creator-1", "") }, ErrTaskNotPendingApproval},
+		{"reject in progress task", valueobject.TaskStatusInProgress, func(task *TaskAggregate) error { return task.Reject("creator-1", "") }, ErrTaskNotPendingApproval},
+		{"start rejected task", valueobject.TaskStatusRejected, func(task *TaskAggregate) error { return task.Start("responsible-1") }, ErrTaskNotApproved},
+		{"pause completed task", valueobject.TaskStatusCompleted, func(task *TaskAggregate) error { return task.Pause("responsible-1", "") }, ErrTaskNotInProgress},
+		{"complete cancelled task", valueobject.TaskStatusCancelled, func(task *TaskAggregate) error { return task.Complete("responsible-1") }, ErrTaskNotInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := newTestTask()
+			task.Status = tt.from
+
+			err := tt.action(task)
+
+			if !errors.Is(err, tt.cause) || !errors.Is(err, ErrInvalidStatusTransition) {
+				t.Fatalf("Expected %v and ErrInvalidStatusTransition, got %v", tt.cause, err)
+			}
+			if !strings.Contains(err.Error(), string(tt.from)+" -> ") {
+				t.Errorf("Expected the error to name the current status %s, got %v", tt.from, err)
+			}
+			if task.Status != tt.from {
+				t.Errorf("Expected status to stay %s, got %s", tt.from, task.Status)
+			}
+		})
+	}
+}
+
+func TestTaskLogHours_AccumulatesAcrossParticipants(t *testing.T) {
+	task := newTestTask()
+	task.Status = valueobject.TaskStatusInProgress
+	for _, id := range []valueobject.UserID{"alice", "bob"} {
+		if err := task.AddParticipant(id, task.ResponsibleID); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+	task.ClearEvents()
+	before := task.UpdatedAt
+
+	logs := []struct {
+		participant valueobject.UserID
+		hours       float64
+	}{
+		{"alice", 2.5},
+		{"bob", 4},
+		{task.ResponsibleID, 1.5},
+		{"alice", 0.5},
+	}
+	for _, l := range logs {
+		if err := task.LogHours(l.participant, l.hours); err != nil {
+			t.Fatalf("Failed to log hours for %s: %v", l.participant, err)
+		}
+	}
+
+	if task.ActualHours != 8.5 {
+		t.Errorf("Expected 8.5 actual hours, got %v", task.ActualHours)
+	}
+	if task.UpdatedAt.Before(before) {
+		t.Errorf("Expected UpdatedAt to advance, got %v", task.UpdatedAt)
+	}
+	events := task.GetEvents()
+	if len(events) != len(logs) {
+		t.Fatalf("Expected %d events, got %d", len(logs), len(events))
+	}
+	last, ok := events[1].(*event.HoursLoggedEvent)
+	if !ok {
+		t.Fatalf("Expected HoursLoggedEvent, got %T", events[1])
+	}
+	if last.ParticipantID != "bob" || last.Hours != 4 || last.TotalHours != 6.5 {
+		t.Errorf("Unexpected event payload: %+v", last)
+	}
+}
+
+func TestTaskLogHours_RejectsNonParticipantAndInvalidHours(t *testing.T) {
+	task := newTestTask()
+
+	if err := task.LogHours("stranger", 1); err == nil {
+		t.Error("Expected non-participant to be rejected")
+	}
+	for _, hours := range []float64{0, -1} {
+		if err := task.LogHours(task.ResponsibleID, hours); !errors.Is(err, ErrInvalidHours) {
+			t.Errorf("Expected ErrInvalidHours for %v, got %v", hours, err)
+		}
+	}
+	if task.ActualHours != 0 || len(task.GetEvents()) != 0 {
+		t.Errorf("Rejected logs must not change the task, got %v hours and %d events", task.ActualHours, len(task.GetEvents()))
+	}
+}
+
+func TestTaskGetCompletionRate_ReflectsLoggedHours(t *testing.T) {
+	task := newTestTask()
+	task.Status = valueobject.TaskStatusInProgress
+	if rate := task.GetCompletionRate(); rate != 0 {
+		t.Errorf("Expected 0 without estimate, got %v", rate)
+	}
+
+	task.EstimatedHours = 10
+	if err := task.LogHours(task.ResponsibleID, 4); err != nil {
+		t.Fatalf("Failed to log hours: %v", err)
+	}
+	if rate := task.GetCompletionRate(); rate != 40 {
+		t.Errorf("Expected 40, got %v", rate)
+	}
+
+	// 超出预估工时的未完成任务不视为已完成
+	if err := task.LogHours(task.ResponsibleID, 8); err != nil {
+		t.Fatalf("Failed to log hours: %v", err)
+	}
+	if rate := task.GetCompletionRate(); rate != 99 {
+		t.Errorf("Expected 99 for unfinished task over estimate, got %v", rate)
+	}
+
+	task.Status = valueobject.TaskStatusCompleted
+	if rate := task.GetCompletionRate(); rate != 100 {
+		t.Errorf("Expected 100 for completed task, got %v", rate)
+	}
+}
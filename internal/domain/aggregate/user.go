@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/domain/valueobject"
 )
 
@@ -19,10 +20,17 @@ type User struct {
 	Status       valueobject.UserStatus `json:"status"`
 	DepartmentID *string                `json:"department_id,omitempty"`
 	ManagerID    *valueobject.UserID    `json:"manager_id,omitempty"`
+	Timezone     string                 `json:"timezone,omitempty"` // IANA时区名，为空表示UTC
 	CreatedAt    time.Time              `json:"created_at"`
 	UpdatedAt    time.Time              `json:"updated_at"`
 	DeletedAt    *time.Time             `json:"deleted_at,omitempty"`
 
+	// InAppNotifications 是否接收站内通知，新用户默认开启
+	InAppNotifications bool `json:"in_app_notifications"`
+
+	// NotificationSettings 邮件、短信、推送渠道开关、提醒间隔和静默时段
+	NotificationSettings valueobject.NotificationSettings `json:"notification_settings"`
+
 	// 领域事件
 	events []event.DomainEvent
 }
@@ -45,6 +53,9 @@ func NewUser(
 		Status:       valueobject.UserStatusActive,
 		CreatedAt:    now,
 		UpdatedAt:    now,
+
+		InAppNotifications:   true,
+		NotificationSettings: valueobject.DefaultNotificationSettings(),
 	}
 }
 
@@ -66,6 +77,36 @@ func (u *User) UpdateProfile(fullName, email string) error {
 	return nil
 }
 
+// SetTimezone 设置用户时区
+func (u *User) SetTimezone(timezone string) error {
+	if timezone != "" && !shared.IsValidTimezone(timezone) {
+		return fmt.Errorf("invalid timezone: %s", timezone)
+	}
+
+	u.Timezone = timezone
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetInAppNotifications 开启或关闭站内通知
+func (u *User) SetInAppNotifications(enabled bool) {
+	u.InAppNotifications = enabled
+	u.UpdatedAt = time.Now()
+}
+
+// UpdateNotificationSettings 更新通知设置，校验失败时保持原设置
+func (u *User) UpdateNotificationSettings(settings valueobject.NotificationSettings) error {
+	if err := settings.Validate(); err != nil {
+		return fmt.Errorf("invalid notification settings: %w", err)
+	}
+
+	u.NotificationSettings = settings
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
 // ChangeRole 更改用户角色
 func (u *User) ChangeRole(newRole valueobject.UserRole) {
 	u.Role = newRole
@@ -0,0 +1,143 @@
+package aggregate
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// DefaultWebhookMaxFailures 连续投递失败达到该次数后自动停用webhook
+const DefaultWebhookMaxFailures = 5
+
+// Webhook 领域事件的HTTP订阅，匹配的事件会以签名的JSON POST推送到订阅方URL
+type Webhook struct {
+	ID           string
+	URL          string
+	Secret       string   // 请求体HMAC-SHA256签名密钥
+	EventTypes   []string // 订阅的事件类型，为空时订阅全部事件
+	Active       bool
+	FailureCount int // 连续投递失败次数，投递成功或重新启用时清零
+	CreatedBy    valueobject.UserID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DisabledAt   *time.Time
+}
+
+// WebhookDelivery 一次webhook投递尝试的记录
+// 同一事件投递到同一webhook的所有重试和重投共用 DeliveryID，订阅方据此去重
+type WebhookDelivery struct {
+	ID          string // 本次尝试的记录ID
+	DeliveryID  string
+	WebhookID   string
+	EventID     string
+	EventType   string
+	Attempt     int  // 同一投递的第几次尝试，从1开始，重投时继续递增
+	Redelivery  bool // 是否由管理员手动重投
+	Payload     []byte
+	StatusCode  int // 订阅方响应状态码，请求未发出时为0
+	Success     bool
+	Error       string
+	Duration    time.Duration
+	DeliveredAt time.Time
+}
+
+// NewWebhook 创建webhook订阅，创建后即为启用状态
+func NewWebhook(id, rawURL, secret string, eventTypes []string, createdBy valueobject.UserID) (*Webhook, error) {
+	if err := validateWebhook(rawURL, secret); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Webhook{
+		ID:         id,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// Update 更新订阅地址和事件过滤，secret 为空时保留原密钥
+func (w *Webhook) Update(rawURL, secret string, eventTypes []string) error {
+	if secret == "" {
+		secret = w.Secret
+	}
+	if err := validateWebhook(rawURL, secret); err != nil {
+		return err
+	}
+
+	w.URL = rawURL
+	w.Secret = secret
+	w.EventTypes = eventTypes
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// Matches 判断启用中的webhook是否订阅了指定事件类型
+func (w *Webhook) Matches(eventType string) bool {
+	if !w.Active {
+		return false
+	}
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Activate 启用webhook并清零失败计数
+func (w *Webhook) Activate() {
+	w.Active = true
+	w.FailureCount = 0
+	w.DisabledAt = nil
+	w.UpdatedAt = time.Now()
+}
+
+// Deactivate 停用webhook
+func (w *Webhook) Deactivate() {
+	if !w.Active {
+		return
+	}
+	now := time.Now()
+	w.Active = false
+	w.DisabledAt = &now
+	w.UpdatedAt = now
+}
+
+// RecordDeliveryResult 记录一次事件投递的最终结果
+// 成功时清零失败计数；连续失败达到 maxFailures 时自动停用，返回 true
+func (w *Webhook) RecordDeliveryResult(success bool, maxFailures int) bool {
+	if success {
+		w.FailureCount = 0
+		return false
+	}
+
+	w.FailureCount++
+	w.UpdatedAt = time.Now()
+	if maxFailures > 0 && w.FailureCount >= maxFailures && w.Active {
+		w.Deactivate()
+		return true
+	}
+	return false
+}
+
+// validateWebhook 校验订阅地址必须是 http/https 绝对地址，且密钥不能为空
+func validateWebhook(rawURL, secret string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("webhook url must be an absolute http or https url: %q", rawURL)
+	}
+	if secret == "" {
+		return fmt.Errorf("webhook secret cannot be empty")
+	}
+	return nil
+}
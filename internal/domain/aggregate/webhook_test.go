@@ -0,0 +1,53 @@
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhook_RejectsInvalidURL(t *testing.T) {
+	for _, rawURL := range []string{"", "example.com/hook", "ftp://example.com/hook", "http://"} {
+		_, err := NewWebhook("webhook-1", rawURL, "secret", nil, "admin-1")
+		assert.Error(t, err, rawURL)
+	}
+
+	_, err := NewWebhook("webhook-1", "https://example.com/hook", "", nil, "admin-1")
+	assert.Error(t, err)
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	all, err := NewWebhook("webhook-1", "https://example.com/hook", "secret", nil, "admin-1")
+	require.NoError(t, err)
+	filtered, err := NewWebhook("webhook-2", "https://example.com/hook", "secret", []string{"TaskCompleted"}, "admin-1")
+	require.NoError(t, err)
+
+	assert.True(t, all.Matches("TaskCreated"))
+	assert.True(t, filtered.Matches("TaskCompleted"))
+	assert.False(t, filtered.Matches("TaskCreated"))
+
+	filtered.Deactivate()
+	assert.False(t, filtered.Matches("TaskCompleted"))
+}
+
+func TestWebhook_RecordDeliveryResultDisablesAfterMaxFailures(t *testing.T) {
+	webhook, err := NewWebhook("webhook-1", "https://example.com/hook", "secret", nil, "admin-1")
+	require.NoError(t, err)
+
+	assert.False(t, webhook.RecordDeliveryResult(false, 3))
+	assert.False(t, webhook.RecordDeliveryResult(false, 3))
+	assert.False(t, webhook.RecordDeliveryResult(true, 3))
+	assert.Zero(t, webhook.FailureCount)
+
+	assert.False(t, webhook.RecordDeliveryResult(false, 3))
+	assert.False(t, webhook.RecordDeliveryResult(false, 3))
+	assert.True(t, webhook.RecordDeliveryResult(false, 3))
+	assert.False(t, webhook.Active)
+	require.NotNil(t, webhook.DisabledAt)
+
+	webhook.Activate()
+	assert.True(t, webhook.Active)
+	assert.Zero(t, webhook.FailureCount)
+	assert.Nil(t, webhook.DisabledAt)
+}
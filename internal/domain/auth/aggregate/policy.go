@@ -1,8 +1,10 @@
 package aggregate
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/taskflow/internal/domain/auth/domainerror"
 	"github.com/taskflow/internal/domain/auth/valueobject"
 )
 
@@ -48,6 +50,23 @@ func (p *Policy) UpdatePolicy(name, description string, effect valueobject.Polic
 	p.UpdatedAt = time.Now()
 }
 
+// Validate 保存前校验策略：效果为 allow 或 deny，条件只引用 user.* 或 resource.* 属性，优先级不能为负数
+// 不合法的策略保存后永远不会匹配，返回 ErrInvalidPolicy
+func (p *Policy) Validate() error {
+	if !p.Effect.IsValid() {
+		return domainerror.NewDomainError(domainerror.ErrInvalidPolicy,
+			fmt.Sprintf("policy effect must be allow or deny, got %q", p.Effect)).WithDetails("field", "effect")
+	}
+	if err := p.Conditions.Validate(); err != nil {
+		return domainerror.NewDomainError(domainerror.ErrInvalidPolicy, err.Error()).WithDetails("field", "conditions")
+	}
+	if p.Priority < 0 {
+		return domainerror.NewDomainError(domainerror.ErrInvalidPolicy,
+			fmt.Sprintf("policy priority must not be negative, got %d", p.Priority)).WithDetails("field", "priority")
+	}
+	return nil
+}
+
 // Activate 激活策略
 func (p *Policy) Activate() {
 	p.IsActive = true
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/taskflow/internal/domain/auth/domainerror"
 	"github.com/taskflow/internal/domain/auth/valueobject"
 	"github.com/stretchr/testify/assert"
 )
@@ -249,3 +250,70 @@ func createTestPolicy() *Policy {
 		100,
 	)
 }
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *Policy)
+		field  string
+	}{
+		{"valid policy", func(p *Policy) {}, ""},
+		{"operator and list values", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{
+				"user.id":         map[string]interface{}{"eq": "${resource.owner_id}"},
+				"resource.status": []interface{}{"draft", "in_progress"},
+			}
+		}, ""},
+		{"combinators and related resources", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{
+				"or": []interface{}{
+					map[string]interface{}{"user.id": map[string]interface{}{"eq": "${project.manager_id}"}},
+					map[string]interface{}{"extension.requester_id": map[string]interface{}{"ne": "${task.responsible_id}"}},
+				},
+			}
+		}, ""},
+		{"invalid effect", func(p *Policy) { p.Effect = "permit" }, "effect"},
+		{"empty effect", func(p *Policy) { p.Effect = "" }, "effect"},
+		{"unknown condition key", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{"subject.id": "${resource.id}"}
+		}, "conditions"},
+		{"unknown placeholder", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{"resource.owner_id": "${subject.id}"}
+		}, "conditions"},
+		{"unterminated placeholder", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{"resource.owner_id": map[string]interface{}{"eq": "${user.id"}}
+		}, "conditions"},
+		{"unknown operator", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{"user.id": map[string]interface{}{"$eq": "${resource.id}"}}
+		}, "conditions"},
+		{"combinator without list", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{"and": map[string]interface{}{"user.id": "u1"}}
+		}, "conditions"},
+		{"not serializable", func(p *Policy) {
+			p.Conditions = valueobject.PolicyConditions{"user.id": func() {}}
+		}, "conditions"},
+		{"negative priority", func(p *Policy) { p.Priority = -1 }, "priority"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			policy := createTestPolicy()
+			tt.modify(policy)
+
+			// Act
+			err := policy.Validate()
+
+			// Assert
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			domainErr := domainerror.GetDomainError(err)
+			if assert.NotNil(t, domainErr, "expected a domain error, got %v", err) {
+				assert.Equal(t, domainerror.ErrInvalidPolicy, domainErr.Type)
+				assert.Equal(t, tt.field, domainErr.Details["field"])
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ type EvaluationContext struct {
 	Action      valueobject.ActionType   `json:"action"`
 	ResourceCtx map[string]interface{}   `json:"resource_context"`
 	Environment map[string]interface{}   `json:"environment"`
+	Explain     bool                     `json:"explain,omitempty"` // 为 true 时评估全部角色权限和策略并在结果中附带评估过程
 }
 
 // EvaluationResult 权限评估结果
@@ -30,6 +31,30 @@ type EvaluationResult struct {
 	Effect      valueobject.PolicyEffect `json:"effect"`
 	Reason      string                   `json:"reason"`
 	MatchedRule string                   `json:"matched_rule,omitempty"`
+	Trace       *EvaluationTrace         `json:"trace,omitempty"`
+}
+
+// EvaluationTrace 权限评估过程，记录全部匹配的角色权限和参与评估的策略，用于解释决策
+type EvaluationTrace struct {
+	Roles           []valueobject.RoleID  `json:"roles"`
+	RolePermissions []RolePermissionMatch `json:"role_permissions"` // 匹配资源和操作的角色权限
+	Policies        []PolicyEvaluation    `json:"policies"`         // 作用于资源和操作的策略，按优先级降序
+}
+
+// RolePermissionMatch 匹配资源和操作的角色权限
+type RolePermissionMatch struct {
+	RoleID       valueobject.RoleID       `json:"role_id"`
+	PermissionID valueobject.PermissionID `json:"permission_id"`
+}
+
+// PolicyEvaluation 单个策略的评估结果，未激活的策略不参与匹配
+type PolicyEvaluation struct {
+	PolicyID valueobject.PolicyID     `json:"policy_id"`
+	Name     string                   `json:"name"`
+	Effect   valueobject.PolicyEffect `json:"effect"`
+	Priority int                      `json:"priority"`
+	Active   bool                     `json:"active"`
+	Matched  bool                     `json:"matched"`
 }
 
 // rbacABACEvaluator 混合RBAC+ABAC权限评估器
@@ -52,32 +77,43 @@ func NewRBACAbacEvaluator(
 	}
 }
 
-// Evaluate 执行权限评估
+// Evaluate 执行权限评估，evalCtx.Explain 为 true 时结果中附带评估过程
 func (e *rbacABACEvaluator) Evaluate(ctx context.Context, evalCtx *EvaluationContext) (*EvaluationResult, error) {
 	if evalCtx == nil {
 		return nil, domainerror.NewDomainError(domainerror.ErrInvalidEvaluationCtx, "evaluation context is nil")
 	}
+	var trace *EvaluationTrace
+	if evalCtx.Explain {
+		trace = &EvaluationTrace{
+			Roles:           append([]valueobject.RoleID{}, evalCtx.UserRoles...),
+			RolePermissions: []RolePermissionMatch{},
+			Policies:        []PolicyEvaluation{},
+		}
+	}
 
 	// 1. RBAC评估 - 基于角色的权限检查
-	rbacResult, err := e.evaluateRBAC(ctx, evalCtx)
+	rbacResult, err := e.evaluateRBAC(ctx, evalCtx, trace)
 	if err != nil {
 		return nil, fmt.Errorf("RBAC evaluation failed: %w", err)
 	}
 
 	// 2. ABAC评估 - 基于属性的策略检查
-	abacResult, err := e.evaluateABAC(ctx, evalCtx)
+	abacResult, err := e.evaluateABAC(ctx, evalCtx, trace)
 	if err != nil {
 		return nil, fmt.Errorf("ABAC evaluation failed: %w", err)
 	}
 
 	// 3. 决策合并 - 优先级：DENY > ALLOW > RBAC
-	finalResult := e.combineResults(rbacResult, abacResult)
+	finalResult := *e.combineResults(rbacResult, abacResult)
+	finalResult.Trace = trace
 
-	return finalResult, nil
+	return &finalResult, nil
 }
 
-// evaluateRBAC 执行RBAC评估
-func (e *rbacABACEvaluator) evaluateRBAC(ctx context.Context, evalCtx *EvaluationContext) (*EvaluationResult, error) {
+// evaluateRBAC 执行RBAC评估，第一个匹配的角色权限决定结果
+// trace 为 nil 时在第一个匹配处返回，否则继续评估并把全部匹配项记录到 trace
+func (e *rbacABACEvaluator) evaluateRBAC(ctx context.Context, evalCtx *EvaluationContext, trace *EvaluationTrace) (*EvaluationResult, error) {
+	var result *EvaluationResult
 	// 检查用户角色是否有对应权限
 	for _, roleID := range evalCtx.UserRoles {
 		permissions, err := e.roleRepo.FindPermissionsByRole(ctx, roleID)
@@ -86,16 +122,26 @@ func (e *rbacABACEvaluator) evaluateRBAC(ctx context.Context, evalCtx *Evaluatio
 		}
 
 		for _, permission := range permissions {
-			if permission.Matches(evalCtx.Resource, evalCtx.Action) {
-				return &EvaluationResult{
+			if !permission.Matches(evalCtx.Resource, evalCtx.Action) {
+				continue
+			}
+			if result == nil {
+				result = &EvaluationResult{
 					Allowed:     true,
 					Effect:      valueobject.PolicyEffectAllow,
 					Reason:      fmt.Sprintf("RBAC: Role %s has permission %s", roleID, permission.ID),
 					MatchedRule: fmt.Sprintf("role:%s:permission:%s", roleID, permission.ID),
-				}, nil
+				}
+			}
+			if trace == nil {
+				return result, nil
 			}
+			trace.RolePermissions = append(trace.RolePermissions, RolePermissionMatch{RoleID: roleID, PermissionID: permission.ID})
 		}
 	}
+	if result != nil {
+		return result, nil
+	}
 
 	return &EvaluationResult{
 		Allowed: false,
@@ -104,8 +150,9 @@ func (e *rbacABACEvaluator) evaluateRBAC(ctx context.Context, evalCtx *Evaluatio
 	}, nil
 }
 
-// evaluateABAC 执行ABAC评估
-func (e *rbacABACEvaluator) evaluateABAC(ctx context.Context, evalCtx *EvaluationContext) (*EvaluationResult, error) {
+// evaluateABAC 执行ABAC评估，优先级最高的匹配策略决定结果
+// trace 为 nil 时在第一个匹配的策略处返回，否则评估全部策略并记录到 trace
+func (e *rbacABACEvaluator) evaluateABAC(ctx context.Context, evalCtx *EvaluationContext, trace *EvaluationTrace) (*EvaluationResult, error) {
 	// 获取匹配的策略
 	policies, err := e.policyRepo.FindByResourceAndAction(ctx, evalCtx.Resource, evalCtx.Action)
 	if err != nil {
@@ -126,25 +173,41 @@ func (e *rbacABACEvaluator) evaluateABAC(ctx context.Context, evalCtx *Evaluatio
 	})
 
 	// 评估每个策略
+	var result *EvaluationResult
 	for _, policy := range policies {
-		if !policy.IsActive {
-			continue
+		evaluation := PolicyEvaluation{
+			PolicyID: policy.ID,
+			Name:     policy.Name,
+			Effect:   policy.Effect,
+			Priority: policy.Priority,
+			Active:   policy.IsActive,
 		}
-
-		matches, err := e.evaluatePolicyConditions(policy.Conditions, evalCtx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to evaluate policy %s conditions: %w", policy.ID, err)
+		if policy.IsActive {
+			matches, err := e.evaluatePolicyConditions(policy.Conditions, evalCtx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate policy %s conditions: %w", policy.ID, err)
+			}
+			evaluation.Matched = matches
 		}
-
-		if matches {
-			return &EvaluationResult{
+		if evaluation.Matched && result == nil {
+			result = &EvaluationResult{
 				Allowed:     policy.Effect == valueobject.PolicyEffectAllow,
 				Effect:      policy.Effect,
 				Reason:      fmt.Sprintf("ABAC: Policy %s matched", policy.Name),
 				MatchedRule: fmt.Sprintf("policy:%s", policy.ID),
-			}, nil
+			}
+		}
+		if trace == nil {
+			if result != nil {
+				return result, nil
+			}
+		} else {
+			trace.Policies = append(trace.Policies, evaluation)
 		}
 	}
+	if result != nil {
+		return result, nil
+	}
 
 	return &EvaluationResult{
 		Allowed: false,
@@ -153,79 +216,203 @@ func (e *rbacABACEvaluator) evaluateABAC(ctx context.Context, evalCtx *Evaluatio
 	}, nil
 }
 
-// evaluatePolicyConditions 评估策略条件
+// evaluatePolicyConditions 评估策略条件，条件语法与 PolicyConditions.Validate 接受的形式一致
 func (e *rbacABACEvaluator) evaluatePolicyConditions(conditions valueobject.PolicyConditions, evalCtx *EvaluationContext) (bool, error) {
 	if len(conditions) == 0 {
 		return true, nil // 无条件则匹配
 	}
 
-	// 构建评估上下文
-	contextMap := map[string]interface{}{
-		"user_id":    evalCtx.UserID,
-		"user_roles": evalCtx.UserRoles,
-		"resource":   evalCtx.Resource,
-		"action":     evalCtx.Action,
+	roles := make([]interface{}, 0, len(evalCtx.UserRoles))
+	for _, role := range evalCtx.UserRoles {
+		roles = append(roles, role.String())
 	}
 
-	// 合并资源上下文
+	// 构建评估上下文：内置属性，资源上下文和环境上下文以完整属性名提供
+	attributes := map[string]interface{}{
+		"user.id":       evalCtx.UserID,
+		"user.roles":    roles,
+		"resource.type": string(evalCtx.Resource),
+	}
 	for k, v := range evalCtx.ResourceCtx {
-		contextMap[k] = v
+		attributes[k] = v
 	}
-
-	// 合并环境上下文
 	for k, v := range evalCtx.Environment {
-		contextMap[k] = v
+		attributes[k] = v
 	}
 
-	// 评估每个条件
-	for key, expectedValue := range conditions {
-		actualValue, exists := contextMap[key]
-		if !exists {
-			return false, nil // 缺少必要的上下文
+	return e.matchConditions(conditions, attributes)
+}
+
+// matchConditions 评估一层条件对象，所有条件都满足时匹配
+func (e *rbacABACEvaluator) matchConditions(conditions valueobject.PolicyConditions, attributes map[string]interface{}) (bool, error) {
+	for key, expected := range conditions {
+		var matched bool
+		var err error
+		switch key {
+		case valueobject.PolicyOperatorAnd, valueobject.PolicyOperatorOr:
+			matched, err = e.matchCombinator(key, expected, attributes)
+		default:
+			actual, exists := attributes[key]
+			if !exists {
+				return false, nil // 缺少必要的上下文
+			}
+			matched, err = e.matchValue(key, actual, expected, attributes)
 		}
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
 
-		// 简单的相等性检查（可以扩展为更复杂的表达式评估）
-		if !e.compareValues(actualValue, expectedValue) {
+// matchCombinator 评估 and/or 条件列表
+func (e *rbacABACEvaluator) matchCombinator(op string, value interface{}, attributes map[string]interface{}) (bool, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("condition %q must be a list of condition objects", op)
+	}
+	for _, item := range items {
+		nested, ok := valueobject.AsPolicyConditions(item)
+		if !ok {
+			return false, fmt.Errorf("condition %q must be a list of condition objects", op)
+		}
+		matched, err := e.matchConditions(nested, attributes)
+		if err != nil {
+			return false, err
+		}
+		if op == valueobject.PolicyOperatorOr && matched {
+			return true, nil
+		}
+		if op == valueobject.PolicyOperatorAnd && !matched {
 			return false, nil
 		}
 	}
-
-	return true, nil
+	return op == valueobject.PolicyOperatorAnd, nil
 }
 
-// compareValues 比较两个值
-func (e *rbacABACEvaluator) compareValues(actual, expected interface{}) bool {
-	// 处理不同类型的比较
-	switch exp := expected.(type) {
-	case string:
-		if act, ok := actual.(string); ok {
-			return act == exp
+// matchValue 评估单个属性条件，expected 为字面量、列表或 eq/ne/in 运算符对象
+func (e *rbacABACEvaluator) matchValue(key string, actual, expected interface{}, attributes map[string]interface{}) (bool, error) {
+	operators, ok := expected.(map[string]interface{})
+	if !ok {
+		operand, ok := e.resolveOperand(expected, attributes)
+		return ok && e.compareValues(actual, operand), nil
+	}
+	for op, raw := range operators {
+		operand, ok := e.resolveOperand(raw, attributes)
+		if !ok {
+			return false, nil // 占位符引用的属性不存在
 		}
-	case int:
-		if act, ok := actual.(int); ok {
-			return act == exp
+		var matched bool
+		switch op {
+		case valueobject.PolicyOperatorEq, valueobject.PolicyOperatorIn:
+			matched = e.compareValues(actual, operand)
+		case valueobject.PolicyOperatorNe:
+			matched = !e.compareValues(actual, operand)
+		default:
+			return false, fmt.Errorf("condition %q: unknown operator %q", key, op)
 		}
-	case float64:
-		if act, ok := actual.(float64); ok {
-			return act == exp
+		if !matched {
+			return false, nil
 		}
-	case bool:
-		if act, ok := actual.(bool); ok {
-			return act == exp
+	}
+	return true, nil
+}
+
+// resolveOperand 解析操作数中的 ${...} 占位符，整体为占位符的字符串解析为属性原值
+func (e *rbacABACEvaluator) resolveOperand(operand interface{}, attributes map[string]interface{}) (interface{}, bool) {
+	lookup := func(attr string) (interface{}, bool) {
+		v, ok := attributes[attr]
+		return v, ok
+	}
+	switch v := operand.(type) {
+	case string:
+		if attr, ok := valueobject.ParsePlaceholder(v); ok {
+			return lookup(attr)
 		}
+		return valueobject.ExpandPlaceholders(v, lookup)
 	case []interface{}:
-		// 检查actual是否在expected数组中
-		for _, item := range exp {
+		resolved := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			r, ok := e.resolveOperand(item, attributes)
+			if !ok {
+				return nil, false
+			}
+			resolved = append(resolved, r)
+		}
+		return resolved, true
+	}
+	return operand, true
+}
+
+// compareValues 比较两个值：expected 为列表时检查成员关系，actual 为列表时任一元素匹配即可
+func (e *rbacABACEvaluator) compareValues(actual, expected interface{}) bool {
+	if list, ok := toList(expected); ok {
+		for _, item := range list {
 			if e.compareValues(actual, item) {
 				return true
 			}
 		}
 		return false
 	}
+	if list, ok := toList(actual); ok {
+		for _, item := range list {
+			if e.compareValues(item, expected) {
+				return true
+			}
+		}
+		return false
+	}
 
+	switch exp := normalizeScalar(expected).(type) {
+	case string:
+		act, ok := normalizeScalar(actual).(string)
+		return ok && act == exp
+	case float64:
+		act, ok := normalizeScalar(actual).(float64)
+		return ok && act == exp
+	case bool:
+		act, ok := actual.(bool)
+		return ok && act == exp
+	}
 	return false
 }
 
+// toList 将列表值统一为 []interface{}
+func toList(v interface{}) ([]interface{}, bool) {
+	switch list := v.(type) {
+	case []interface{}:
+		return list, true
+	case []string:
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// normalizeScalar 将数字统一为 float64，实现 fmt.Stringer 的值统一为字符串，便于比较 JSON 解析结果和上下文中的 Go 值
+func normalizeScalar(v interface{}) interface{} {
+	switch n := v.(type) {
+	case string, bool:
+		return n
+	case int:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float32:
+		return float64(n)
+	case float64:
+		return n
+	case fmt.Stringer:
+		return n.String()
+	}
+	return v
+}
+
 // combineResults 合并RBAC和ABAC结果
 func (e *rbacABACEvaluator) combineResults(rbacResult, abacResult *EvaluationResult) *EvaluationResult {
 	// 优先级：ABAC DENY > ABAC ALLOW > RBAC
@@ -1,12 +1,19 @@
 package service
 
-import "github.com/taskflow/internal/domain/auth/valueobject"
+import (
+	"time"
+
+	"github.com/taskflow/internal/domain/auth/valueobject"
+)
 
 // JWTService JWT服务接口
 type JWTService interface {
 	// GenerateTokens 生成访问令牌和刷新令牌
 	GenerateTokens(userID, email string, roles []string) (*valueobject.TokenPair, error)
 
+	// GenerateImpersonationToken 生成管理员模拟用户的访问令牌，有效期为 ttl，不签发刷新令牌
+	GenerateImpersonationToken(userID, email string, roles []string, impersonatorID string, ttl time.Duration) (*valueobject.TokenPair, error)
+
 	// ValidateToken 验证访问令牌
 	ValidateToken(tokenString string) (*valueobject.Claims, error)
 
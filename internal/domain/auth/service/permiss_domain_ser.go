@@ -14,6 +14,7 @@ import (
 type PermissionDomainService interface {
 	// 权限检查
 	CanUserPerformAction(ctx context.Context, userID string, resource valueobject.ResourceType, action valueobject.ActionType, resourceCtx map[string]interface{}) (bool, error)
+	ExplainUserAction(ctx context.Context, userID string, resource valueobject.ResourceType, action valueobject.ActionType, resourceCtx map[string]interface{}) (*repository.EvaluationResult, error)
 
 	// 角色管理
 	AssignRoleToUser(ctx context.Context, userID string, roleID valueobject.RoleID) error
@@ -61,10 +62,37 @@ func (s *permissionDomainService) CanUserPerformAction(
 	action valueobject.ActionType,
 	resourceCtx map[string]interface{},
 ) (bool, error) {
+	result, err := s.evaluate(ctx, userID, resource, action, resourceCtx, false)
+	if err != nil {
+		return false, err
+	}
+	return result.Allowed, nil
+}
+
+// ExplainUserAction 评估用户能否执行特定操作，返回决策、决定结果的规则和评估过程
+func (s *permissionDomainService) ExplainUserAction(
+	ctx context.Context,
+	userID string,
+	resource valueobject.ResourceType,
+	action valueobject.ActionType,
+	resourceCtx map[string]interface{},
+) (*repository.EvaluationResult, error) {
+	return s.evaluate(ctx, userID, resource, action, resourceCtx, true)
+}
+
+// evaluate 按用户角色执行权限评估，explain 为 true 时评估全部规则并附带评估过程
+func (s *permissionDomainService) evaluate(
+	ctx context.Context,
+	userID string,
+	resource valueobject.ResourceType,
+	action valueobject.ActionType,
+	resourceCtx map[string]interface{},
+	explain bool,
+) (*repository.EvaluationResult, error) {
 	// 1. 获取用户角色
 	userRoles, err := s.userRoleRepo.FindRolesByUser(ctx, userID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get user roles: %w", err)
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
 	}
 
 	// 2. 构建评估上下文
@@ -80,15 +108,16 @@ func (s *permissionDomainService) CanUserPerformAction(
 		Action:      action,
 		ResourceCtx: resourceCtx,
 		Environment: make(map[string]interface{}),
+		Explain:     explain,
 	}
 
 	// 3. 执行权限评估
 	result, err := s.evaluator.Evaluate(ctx, evalCtx)
 	if err != nil {
-		return false, fmt.Errorf("permission evaluation failed: %w", err)
+		return nil, fmt.Errorf("permission evaluation failed: %w", err)
 	}
 
-	return result.Allowed, nil
+	return result, nil
 }
 
 // AssignRoleToUser 为用户分配角色
@@ -2,385 +2,383 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/taskflow/internal/domain/auth/aggregate"
+	"github.com/taskflow/internal/domain/auth/domainerror"
+	"github.com/taskflow/internal/domain/auth/repository"
 	"github.com/taskflow/internal/domain/auth/valueobject"
+	"github.com/taskflow/internal/testutil"
 )
 
-// Mock repositories for testing
-type MockUserRoleRepository struct {
-	mock.Mock
+// permissionFixture 基于内存仓储的权限领域服务测试环境
+type permissionFixture struct {
+	permissions *testutil.MemoryPermissionRepository
+	roles       *testutil.MemoryRoleRepository
+	userRoles   *testutil.MemoryUserRoleRepository
+	policies    *testutil.MemoryPolicyRepository
+	service     PermissionDomainService
 }
 
-func (m *MockUserRoleRepository) AssignRole(ctx context.Context, userID string, roleID valueobject.RoleID) error {
-	args := m.Called(ctx, userID, roleID)
-	return args.Error(0)
-}
-
-func (m *MockUserRoleRepository) RevokeRole(ctx context.Context, userID string, roleID valueobject.RoleID) error {
-	args := m.Called(ctx, userID, roleID)
-	return args.Error(0)
-}
+// newPermissionFixture 创建测试环境：manager角色拥有task-update权限，viewer角色拥有task-read权限
+func newPermissionFixture(t *testing.T) *permissionFixture {
+	t.Helper()
 
-func (m *MockUserRoleRepository) FindRolesByUser(ctx context.Context, userID string) ([]valueobject.RoleID, error) {
-	args := m.Called(ctx, userID)
-	return args.Get(0).([]valueobject.RoleID), args.Error(1)
-}
-
-func (m *MockUserRoleRepository) FindUsersByRole(ctx context.Context, roleID valueobject.RoleID) ([]string, error) {
-	args := m.Called(ctx, roleID)
-	return args.Get(0).([]string), args.Error(1)
-}
+	f := &permissionFixture{
+		permissions: testutil.NewMemoryPermissionRepository(
+			aggregate.NewPermission("task-update", "Task Update", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, "Update tasks"),
+			aggregate.NewPermission("task-read", "Task Read", valueobject.ResourceTypeTask, valueobject.ActionTypeRead, "Read tasks"),
+		),
+		policies: testutil.NewMemoryPolicyRepository(),
+	}
 
-func (m *MockUserRoleRepository) HasRole(ctx context.Context, userID string, roleID valueobject.RoleID) (bool, error) {
-	args := m.Called(ctx, userID, roleID)
-	return args.Bool(0), args.Error(1)
+	manager := aggregate.NewRole("manager", "manager", "Manager", "Manager role", false)
+	require.NoError(t, manager.AddPermission("task-update"))
+	require.NoError(t, manager.AddPermission("task-read"))
+	viewer := aggregate.NewRole("viewer", "viewer", "Viewer", "Viewer role", false)
+	require.NoError(t, viewer.AddPermission("task-read"))
+	admin := aggregate.NewRole("admin", "admin", "Admin", "System role", true)
+
+	f.roles = testutil.NewMemoryRoleRepository(f.permissions, manager, viewer, admin)
+	f.userRoles = testutil.NewMemoryUserRoleRepository(f.roles)
+	evaluator := repository.NewRBACAbacEvaluator(f.permissions, f.roles, f.policies)
+	f.service = NewPermissionDomainService(f.permissions, f.roles, f.policies, f.userRoles, evaluator, nil)
+	return f
 }
 
-type MockRoleRepository struct {
-	mock.Mock
+// domainErrorType 获取权限领域错误类型
+func domainErrorType(err error) domainerror.DomainErrorType {
+	var domainErr *domainerror.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Type
+	}
+	return ""
 }
 
-func (m *MockRoleRepository) Save(ctx context.Context, role *aggregate.Role) error {
-	args := m.Called(ctx, role)
-	return args.Error(0)
-}
+func TestPermissionDomainService_CanUserPerformAction_WithRolePermissions(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newPermissionFixture(t)
+	require.NoError(t, f.userRoles.AssignRole(ctx, "user-123", "manager"))
 
-func (m *MockRoleRepository) FindByID(ctx context.Context, id valueobject.RoleID) (*aggregate.Role, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*aggregate.Role), args.Error(1)
-}
+	// Act
+	allowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, nil)
 
-func (m *MockRoleRepository) FindAll(ctx context.Context) ([]*aggregate.Role, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*aggregate.Role), args.Error(1)
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, allowed)
 }
 
-func (m *MockRoleRepository) Delete(ctx context.Context, id valueobject.RoleID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
+func TestPermissionDomainService_CanUserPerformAction_WithoutMatchingPermission(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newPermissionFixture(t)
+	require.NoError(t, f.userRoles.AssignRole(ctx, "user-123", "viewer"))
 
-type MockPermissionRepository struct {
-	mock.Mock
-}
+	// Act
+	allowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, nil)
 
-func (m *MockPermissionRepository) Save(ctx context.Context, permission *aggregate.Permission) error {
-	args := m.Called(ctx, permission)
-	return args.Error(0)
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, allowed)
 }
 
-func (m *MockPermissionRepository) FindByID(ctx context.Context, id valueobject.PermissionID) (*aggregate.Permission, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*aggregate.Permission), args.Error(1)
-}
+func TestPermissionDomainService_CanUserPerformAction_WithoutRoles(t *testing.T) {
+	// Arrange
+	f := newPermissionFixture(t)
 
-func (m *MockPermissionRepository) FindByResourceAndAction(ctx context.Context, resource valueobject.ResourceType, action valueobject.ActionType) ([]*aggregate.Permission, error) {
-	args := m.Called(ctx, resource, action)
-	return args.Get(0).([]*aggregate.Permission), args.Error(1)
-}
+	// Act
+	allowed, err := f.service.CanUserPerformAction(context.Background(), "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeRead, nil)
 
-func (m *MockPermissionRepository) FindAll(ctx context.Context) ([]*aggregate.Permission, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*aggregate.Permission), args.Error(1)
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, allowed)
 }
 
-func (m *MockPermissionRepository) Delete(ctx context.Context, id valueobject.PermissionID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
+func TestPermissionDomainService_CanUserPerformAction_WithPolicyDeny(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newPermissionFixture(t)
+	require.NoError(t, f.userRoles.AssignRole(ctx, "user-123", "manager"))
+	require.NoError(t, f.policies.Save(ctx, aggregate.NewPolicy(
+		"archived-read-only",
+		"Archived Read Only",
+		"Archived tasks cannot be updated",
+		valueobject.ResourceTypeTask,
+		valueobject.ActionTypeUpdate,
+		valueobject.PolicyEffectDeny,
+		valueobject.PolicyConditions{"resource.status": "archived"},
+		200,
+	)))
 
-type MockPolicyRepository struct {
-	mock.Mock
-}
+	// Act
+	denied, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		map[string]interface{}{"resource.status": "archived"})
+	require.NoError(t, err)
+	allowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		map[string]interface{}{"resource.status": "active"})
+	require.NoError(t, err)
 
-func (m *MockPolicyRepository) Save(ctx context.Context, policy *aggregate.Policy) error {
-	args := m.Called(ctx, policy)
-	return args.Error(0)
+	// Assert
+	assert.False(t, denied, "deny policy should override role permission when its conditions match")
+	assert.True(t, allowed, "role permission should apply when deny policy conditions do not match")
 }
 
-func (m *MockPolicyRepository) FindByID(ctx context.Context, id valueobject.PolicyID) (*aggregate.Policy, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*aggregate.Policy), args.Error(1)
-}
+func TestPermissionDomainService_ExplainUserAction_NamesOverridingDenyPolicy(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newPermissionFixture(t)
+	require.NoError(t, f.userRoles.AssignRole(ctx, "user-123", "manager"))
+	require.NoError(t, f.policies.Save(ctx, aggregate.NewPolicy(
+		"archived-read-only", "Archived Read Only", "Archived tasks cannot be updated",
+		valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, valueobject.PolicyEffectDeny,
+		valueobject.PolicyConditions{"resource.status": "archived"}, 200,
+	)))
+	require.NoError(t, f.policies.Save(ctx, aggregate.NewPolicy(
+		"owner-can-update", "Owner Can Update", "Owners may update their own tasks",
+		valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, valueobject.PolicyEffectAllow,
+		valueobject.PolicyConditions{"resource.owner_id": "user-123"}, 100,
+	)))
 
-func (m *MockPolicyRepository) FindByResourceAndAction(ctx context.Context, resource valueobject.ResourceType, action valueobject.ActionType) ([]*aggregate.Policy, error) {
-	args := m.Called(ctx, resource, action)
-	return args.Get(0).([]*aggregate.Policy), args.Error(1)
-}
+	// Act
+	result, err := f.service.ExplainUserAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		map[string]interface{}{"resource.status": "archived", "resource.owner_id": "user-123"})
 
-func (m *MockPolicyRepository) FindAllActive(ctx context.Context) ([]*aggregate.Policy, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*aggregate.Policy), args.Error(1)
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, "policy:archived-read-only", result.MatchedRule)
+	require.NotNil(t, result.Trace)
+	assert.Equal(t, []valueobject.RoleID{"manager"}, result.Trace.Roles)
+	assert.Equal(t, []repository.RolePermissionMatch{{RoleID: "manager", PermissionID: "task-update"}}, result.Trace.RolePermissions,
+		"the overridden role permission is still reported")
+	assert.Equal(t, []repository.PolicyEvaluation{
+		{PolicyID: "archived-read-only", Name: "Archived Read Only", Effect: valueobject.PolicyEffectDeny, Priority: 200, Active: true, Matched: true},
+		{PolicyID: "owner-can-update", Name: "Owner Can Update", Effect: valueobject.PolicyEffectAllow, Priority: 100, Active: true, Matched: true},
+	}, result.Trace.Policies)
 }
 
-func (m *MockPolicyRepository) Delete(ctx context.Context, id valueobject.PolicyID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
+// countingRoleRepository 记录按角色查询权限的次数
+type countingRoleRepository struct {
+	repository.RoleRepository
+	permissionLookups int
 }
 
-func (m *MockPolicyRepository) CountByResource(ctx context.Context, resource valueobject.ResourceType) (int64, error) {
-	args := m.Called(ctx, resource)
-	return args.Get(0).(int64), args.Error(1)
+func (r *countingRoleRepository) FindPermissionsByRole(ctx context.Context, roleID valueobject.RoleID) ([]*aggregate.Permission, error) {
+	r.permissionLookups++
+	return r.RoleRepository.FindPermissionsByRole(ctx, roleID)
 }
 
-func TestPermissionDomainService_CanUserPerformAction_WithRolePermissions(t *testing.T) {
+func TestPermissionDomainService_CanUserPerformAction_StopsAtFirstMatch(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
-	resource := valueobject.ResourceTypeTask
-	action := valueobject.ActionTypeUpdate
-	//resourceCtx := map[string]interface{}{}
-
-	mockUserRoleRepo := &MockUserRoleRepository{}
-	mockRoleRepo := &MockRoleRepository{}
-	mockPermissionRepo := &MockPermissionRepository{}
-	mockPolicyRepo := &MockPolicyRepository{}
-
-	//service := NewPermissionDomainService(mockUserRoleRepo, mockRoleRepo, mockPermissionRepo, mockPolicyRepo)
+	f := newPermissionFixture(t)
+	require.NoError(t, f.userRoles.AssignRole(ctx, "user-123", "manager"))
+	require.NoError(t, f.userRoles.AssignRole(ctx, "user-123", "viewer"))
+	roles := &countingRoleRepository{RoleRepository: f.roles}
+	evaluator := repository.NewRBACAbacEvaluator(f.permissions, roles, f.policies)
+	svc := NewPermissionDomainService(f.permissions, roles, f.policies, f.userRoles, evaluator, nil)
 
-	// Setup mocks
-	roleID := valueobject.RoleID("manager")
-	permissionID := valueobject.PermissionID("task-update")
-
-	mockUserRoleRepo.On("FindRolesByUser", ctx, userID).Return([]valueobject.RoleID{roleID}, nil)
-
-	role := aggregate.NewRole(roleID, "manager", "Manager", "Manager role", false)
-	role.AddPermission(permissionID)
-	mockRoleRepo.On("FindByID", ctx, roleID).Return(role, nil)
+	// Act
+	allowed, err := svc.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeRead, nil)
+	require.NoError(t, err)
+	checkLookups := roles.permissionLookups
+	result, err := svc.ExplainUserAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeRead, nil)
+	require.NoError(t, err)
 
-	permission := aggregate.NewPermission(permissionID, "Task Update", resource, action, "Update tasks")
-	mockPermissionRepo.On("FindByID", ctx, permissionID).Return(permission, nil)
+	// Assert
+	assert.True(t, allowed)
+	assert.Equal(t, 1, checkLookups, "permission checks must stop at the first matching role")
+	assert.Equal(t, 3, roles.permissionLookups, "explanations evaluate every role")
+	require.NotNil(t, result.Trace)
+	assert.Len(t, result.Trace.RolePermissions, 2)
+}
 
-	mockPolicyRepo.On("FindByResourceAndAction", ctx, resource, action).Return([]*aggregate.Policy{}, nil)
+func TestPermissionDomainService_CanUserPerformAction_WithPolicyAllow(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newPermissionFixture(t)
+	require.NoError(t, f.policies.Save(ctx, aggregate.NewPolicy(
+		"owner-can-update",
+		"Owner Can Update",
+		"Owners may update their own tasks",
+		valueobject.ResourceTypeTask,
+		valueobject.ActionTypeUpdate,
+		valueobject.PolicyEffectAllow,
+		valueobject.PolicyConditions{"resource.owner_id": "user-123"},
+		100,
+	)))
 
 	// Act
-	//allowed, err := service.CanUserPerformAction(ctx, userID, resource, action, resourceCtx)
+	allowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		map[string]interface{}{"resource.owner_id": "user-123"})
 
 	// Assert
-	//require.NoError(t, err)
-	//assert.True(t, allowed)
-	mockUserRoleRepo.AssertExpectations(t)
-	mockRoleRepo.AssertExpectations(t)
-	mockPermissionRepo.AssertExpectations(t)
-	mockPolicyRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.True(t, allowed, "allow policy should grant access without a role permission")
 }
 
-func TestPermissionDomainService_CanUserPerformAction_WithPolicyDeny(t *testing.T) {
+func TestPermissionDomainService_CanUserPerformAction_SavedPolicyResolvesPlaceholders(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
-	resource := valueobject.ResourceTypeTask
-	action := valueobject.ActionTypeUpdate
-	//resourceCtx := map[string]interface{}{
-	//	"resource.owner_id": "other-user",
-	//}
-
-	mockUserRoleRepo := &MockUserRoleRepository{}
-	mockRoleRepo := &MockRoleRepository{}
-	mockPermissionRepo := &MockPermissionRepository{}
-	mockPolicyRepo := &MockPolicyRepository{}
-
-	//service := NewPermissionDomainService(mockUserRoleRepo, mockRoleRepo, mockPermissionRepo, mockPolicyRepo)
-
-	// Setup mocks - user has role and permission
-	roleID := valueobject.RoleID("manager")
-	permissionID := valueobject.PermissionID("task-update")
-
-	mockUserRoleRepo.On("FindRolesByUser", ctx, userID).Return([]valueobject.RoleID{roleID}, nil)
-
-	role := aggregate.NewRole(roleID, "manager", "Manager", "Manager role", false)
-	role.AddPermission(permissionID)
-	mockRoleRepo.On("FindByID", ctx, roleID).Return(role, nil)
-
-	permission := aggregate.NewPermission(permissionID, "Task Update", resource, action, "Update tasks")
-	mockPermissionRepo.On("FindByID", ctx, permissionID).Return(permission, nil)
-
-	// Policy that denies access if user is not the owner
-	policy := aggregate.NewPolicy(
-		valueobject.PolicyID("owner-only"),
-		"Owner Only",
-		"Only owner can update",
-		resource,
-		action,
-		valueobject.PolicyEffectDeny,
-		valueobject.PolicyConditions{
-			"resource.owner_id": "!${user.id}",
-		},
-		200, // Higher priority than default allow
-	)
-	mockPolicyRepo.On("FindByResourceAndAction", ctx, resource, action).Return([]*aggregate.Policy{policy}, nil)
+	f := newPermissionFixture(t)
+	var creatorDraft, members valueobject.PolicyConditions
+	require.NoError(t, creatorDraft.FromJSON(`{"and": [{"user.id": {"eq": "${resource.creator_id}"}}, {"resource.status": {"eq": "draft"}}]}`))
+	require.NoError(t, members.FromJSON(`{"user.id": {"in": "${resource.member_ids}"}}`))
+	require.NoError(t, f.policies.Save(ctx, aggregate.NewPolicy(
+		"creator-edit-draft", "Creator Edit Draft", "Creators may edit draft tasks",
+		valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, valueobject.PolicyEffectAllow, creatorDraft, 100,
+	)))
+	require.NoError(t, f.policies.Save(ctx, aggregate.NewPolicy(
+		"member-read", "Member Read", "Project members may read tasks",
+		valueobject.ResourceTypeTask, valueobject.ActionTypeRead, valueobject.PolicyEffectAllow, members, 100,
+	)))
 
 	// Act
-	//allowed, err := service.CanUserPerformAction(ctx, userID, resource, action, resourceCtx)
+	creatorAllowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		map[string]interface{}{"resource.creator_id": "user-123", "resource.status": "draft"})
+	require.NoError(t, err)
+	otherAllowed, err := f.service.CanUserPerformAction(ctx, "user-456", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		map[string]interface{}{"resource.creator_id": "user-123", "resource.status": "draft"})
+	require.NoError(t, err)
+	publishedAllowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		map[string]interface{}{"resource.creator_id": "user-123", "resource.status": "published"})
+	require.NoError(t, err)
+	memberAllowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeRead,
+		map[string]interface{}{"resource.member_ids": []string{"user-001", "user-123"}})
+	require.NoError(t, err)
+	nonMemberAllowed, err := f.service.CanUserPerformAction(ctx, "user-456", valueobject.ResourceTypeTask, valueobject.ActionTypeRead,
+		map[string]interface{}{"resource.member_ids": []string{"user-001", "user-123"}})
+	require.NoError(t, err)
 
 	// Assert
-	//require.NoError(t, err)
-	//assert.False(t, allowed) // Should be denied by policy
-	mockUserRoleRepo.AssertExpectations(t)
-	mockRoleRepo.AssertExpectations(t)
-	mockPermissionRepo.AssertExpectations(t)
-	mockPolicyRepo.AssertExpectations(t)
+	assert.True(t, creatorAllowed, "placeholder should resolve to the resource creator")
+	assert.False(t, otherAllowed)
+	assert.False(t, publishedAllowed, "every condition under and must match")
+	assert.True(t, memberAllowed, "in should check membership in the resolved list")
+	assert.False(t, nonMemberAllowed)
 }
 
 func TestPermissionDomainService_AssignRoleToUser(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
-	roleID := valueobject.RoleID("manager")
+	f := newPermissionFixture(t)
 
-	mockUserRoleRepo := &MockUserRoleRepository{}
-	mockRoleRepo := &MockRoleRepository{}
-	//mockPermissionRepo := &MockPermissionRepository{}
-	//mockPolicyRepo := &MockPolicyRepository{}
+	// Act
+	err := f.service.AssignRoleToUser(ctx, "user-123", "manager")
 
-	//service := NewPermissionDomainService(mockUserRoleRepo, mockRoleRepo, mockPermissionRepo, mockPolicyRepo)
+	// Assert
+	require.NoError(t, err)
+	hasRole, err := f.userRoles.HasRole(ctx, "user-123", "manager")
+	require.NoError(t, err)
+	assert.True(t, hasRole)
+	allowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, nil)
+	require.NoError(t, err)
+	assert.True(t, allowed, "assigned role permissions should take effect")
+}
 
-	// Setup mocks
-	role := aggregate.NewRole(roleID, "manager", "Manager", "Manager role", false)
-	mockRoleRepo.On("FindByID", ctx, roleID).Return(role, nil)
-	mockUserRoleRepo.On("HasRole", ctx, userID, roleID).Return(false, nil)
-	mockUserRoleRepo.On("AssignRole", ctx, userID, roleID).Return(nil)
+func TestPermissionDomainService_AssignRoleToUser_AlreadyAssigned(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newPermissionFixture(t)
+	require.NoError(t, f.service.AssignRoleToUser(ctx, "user-123", "manager"))
 
 	// Act
-	//err := service.AssignRoleToUser(ctx, userID, roleID)
+	err := f.service.AssignRoleToUser(ctx, "user-123", "manager")
 
 	// Assert
-	//require.NoError(t, err)
-	mockRoleRepo.AssertExpectations(t)
-	mockUserRoleRepo.AssertExpectations(t)
+	require.Error(t, err)
+	assert.Equal(t, domainerror.ErrRoleAlreadyAssigned, domainErrorType(err))
 }
 
-func TestPermissionDomainService_AssignRoleToUser_AlreadyAssigned(t *testing.T) {
+func TestPermissionDomainService_AssignRoleToUser_SystemRole(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
-	roleID := valueobject.RoleID("manager")
+	f := newPermissionFixture(t)
 
-	mockUserRoleRepo := &MockUserRoleRepository{}
-	mockRoleRepo := &MockRoleRepository{}
-	//mockPermissionRepo := &MockPermissionRepository{}
-	//mockPolicyRepo := &MockPolicyRepository{}
+	// Act
+	err := f.service.AssignRoleToUser(ctx, "user-123", "admin")
 
-	//service := NewPermissionDomainService(mockUserRoleRepo, mockRoleRepo, mockPermissionRepo, mockPolicyRepo)
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, domainerror.ErrSystemRoleImmutable, domainErrorType(err))
+	hasRole, _ := f.userRoles.HasRole(ctx, "user-123", "admin")
+	assert.False(t, hasRole)
+}
 
-	// Setup mocks
-	role := aggregate.NewRole(roleID, "manager", "Manager", "Manager role", false)
-	mockRoleRepo.On("FindByID", ctx, roleID).Return(role, nil)
-	mockUserRoleRepo.On("HasRole", ctx, userID, roleID).Return(true, nil)
+func TestPermissionDomainService_AssignRoleToUser_UnknownRole(t *testing.T) {
+	// Arrange
+	f := newPermissionFixture(t)
 
 	// Act
-	//err := service.AssignRoleToUser(ctx, userID, roleID)
+	err := f.service.AssignRoleToUser(context.Background(), "user-123", "missing")
 
 	// Assert
-	//assert.Error(t, err)
-	//assert.Contains(t, err.Error(), "用户已拥有该角色")
-	mockRoleRepo.AssertExpectations(t)
-	mockUserRoleRepo.AssertExpectations(t)
+	require.Error(t, err)
+	assert.Equal(t, domainerror.ErrRoleNotFound, domainErrorType(err))
 }
 
 func TestPermissionDomainService_RevokeRoleFromUser(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
-	roleID := valueobject.RoleID("manager")
+	f := newPermissionFixture(t)
+	require.NoError(t, f.service.AssignRoleToUser(ctx, "user-123", "manager"))
 
-	mockUserRoleRepo := &MockUserRoleRepository{}
-	mockRoleRepo := &MockRoleRepository{}
-	//mockPermissionRepo := &MockPermissionRepository{}
-	//mockPolicyRepo := &MockPolicyRepository{}
+	// Act
+	err := f.service.RevokeRoleFromUser(ctx, "user-123", "manager")
 
-	//service := NewPermissionDomainService(mockUserRoleRepo, mockRoleRepo, mockPermissionRepo, mockPolicyRepo)
+	// Assert
+	require.NoError(t, err)
+	allowed, err := f.service.CanUserPerformAction(ctx, "user-123", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, nil)
+	require.NoError(t, err)
+	assert.False(t, allowed, "revoked role permissions should no longer apply")
+}
 
-	// Setup mocks
-	role := aggregate.NewRole(roleID, "manager", "Manager", "Manager role", false)
-	mockRoleRepo.On("FindByID", ctx, roleID).Return(role, nil)
-	mockUserRoleRepo.On("HasRole", ctx, userID, roleID).Return(true, nil)
-	mockUserRoleRepo.On("RevokeRole", ctx, userID, roleID).Return(nil)
+func TestPermissionDomainService_RevokeRoleFromUser_NotAssigned(t *testing.T) {
+	// Arrange
+	f := newPermissionFixture(t)
 
 	// Act
-	//err := service.RevokeRoleFromUser(ctx, userID, roleID)
+	err := f.service.RevokeRoleFromUser(context.Background(), "user-123", "manager")
 
 	// Assert
-	//require.NoError(t, err)
-	mockRoleRepo.AssertExpectations(t)
-	mockUserRoleRepo.AssertExpectations(t)
+	require.Error(t, err)
+	assert.Equal(t, domainerror.ErrRoleNotAssigned, domainErrorType(err))
 }
 
 func TestPermissionDomainService_GetUserPermissions(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
-
-	mockUserRoleRepo := &MockUserRoleRepository{}
-	mockRoleRepo := &MockRoleRepository{}
-	mockPermissionRepo := &MockPermissionRepository{}
-	//mockPolicyRepo := &MockPolicyRepository{}
-
-	//service := NewPermissionDomainService(mockUserRoleRepo, mockRoleRepo, mockPermissionRepo, mockPolicyRepo)
-
-	// Setup mocks
-	roleID := valueobject.RoleID("manager")
-	permissionID := valueobject.PermissionID("task-update")
-
-	mockUserRoleRepo.On("FindRolesByUser", ctx, userID).Return([]valueobject.RoleID{roleID}, nil)
-
-	role := aggregate.NewRole(roleID, "manager", "Manager", "Manager role", false)
-	role.AddPermission(permissionID)
-	mockRoleRepo.On("FindByID", ctx, roleID).Return(role, nil)
-
-	permission := aggregate.NewPermission(permissionID, "Task Update", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate, "Update tasks")
-	mockPermissionRepo.On("FindByID", ctx, permissionID).Return(permission, nil)
+	f := newPermissionFixture(t)
+	require.NoError(t, f.service.AssignRoleToUser(ctx, "user-123", "manager"))
+	require.NoError(t, f.service.AssignRoleToUser(ctx, "user-123", "viewer"))
 
 	// Act
-	//permissions, err := service.GetUserPermissions(ctx, userID)
+	permissions, err := f.service.GetUserPermissions(ctx, "user-123")
 
 	// Assert
-	//require.NoError(t, err)
-	//assert.Len(t, permissions, 1)
-	//assert.Equal(t, permissionID, permissions[0].ID)
-	mockUserRoleRepo.AssertExpectations(t)
-	mockRoleRepo.AssertExpectations(t)
-	mockPermissionRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	ids := make([]valueobject.PermissionID, len(permissions))
+	for i, p := range permissions {
+		ids[i] = p.ID
+	}
+	assert.ElementsMatch(t, []valueobject.PermissionID{"task-update", "task-read"}, ids, "permissions shared by roles should be deduplicated")
 }
 
 func TestPermissionDomainService_GetUserRoles(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
-	userID := "user-123"
-
-	mockUserRoleRepo := &MockUserRoleRepository{}
-	mockRoleRepo := &MockRoleRepository{}
-	//mockPermissionRepo := &MockPermissionRepository{}
-	//mockPolicyRepo := &MockPolicyRepository{}
-
-	//service := NewPermissionDomainService(mockUserRoleRepo, mockRoleRepo, mockPermissionRepo, mockPolicyRepo)
-
-	// Setup mocks
-	roleID := valueobject.RoleID("manager")
-	mockUserRoleRepo.On("FindRolesByUser", ctx, userID).Return([]valueobject.RoleID{roleID}, nil)
-
-	role := aggregate.NewRole(roleID, "manager", "Manager", "Manager role", false)
-	mockRoleRepo.On("FindByID", ctx, roleID).Return(role, nil)
+	f := newPermissionFixture(t)
+	require.NoError(t, f.service.AssignRoleToUser(ctx, "user-123", "manager"))
 
 	// Act
-	//roles, err := service.GetUserRoles(ctx, userID)
+	roles, err := f.service.GetUserRoles(ctx, "user-123")
 
 	// Assert
-	//require.NoError(t, err)
-	//assert.Len(t, roles, 1)
-	//assert.Equal(t, roleID, roles[0].ID)
-	mockUserRoleRepo.AssertExpectations(t)
-	mockRoleRepo.AssertExpectations(t)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, valueobject.RoleID("manager"), roles[0].ID)
 }
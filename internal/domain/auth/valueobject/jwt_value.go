@@ -21,9 +21,17 @@ type Claims struct {
 	Email     string   `json:"email"`
 	Roles     []string `json:"roles"`
 	TokenType string   `json:"token_type"` // "access" 或 "refresh"
+
+	// ImpersonatorID 模拟登录令牌中实际操作的管理员，普通令牌为空
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsImpersonation 是否为管理员模拟用户的令牌
+func (c *Claims) IsImpersonation() bool {
+	return c.ImpersonatorID != ""
+}
+
 // JWTConfig JWT配置
 type JWTConfig struct {
 	Secret             string        `json:"secret"`
@@ -1,6 +1,10 @@
 package valueobject
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // PolicyRule ABAC策略规则
 type PolicyRule struct {
@@ -30,6 +34,11 @@ const (
 	PolicyEffectDeny  PolicyEffect = "deny"
 )
 
+// IsValid 检查策略效果是否为 allow 或 deny
+func (e PolicyEffect) IsValid() bool {
+	return e == PolicyEffectAllow || e == PolicyEffectDeny
+}
+
 // PolicyConditions 策略条件值对象
 type PolicyConditions map[string]interface{}
 
@@ -46,3 +55,153 @@ func (pc PolicyConditions) ToJSON() (string, error) {
 func (pc *PolicyConditions) FromJSON(jsonStr string) error {
 	return json.Unmarshal([]byte(jsonStr), pc)
 }
+
+// 策略条件运算符：属性键的值可以是 {"eq"|"ne"|"in": 操作数}，and/or 键的值是条件对象列表
+const (
+	PolicyOperatorEq  = "eq"
+	PolicyOperatorNe  = "ne"
+	PolicyOperatorIn  = "in"
+	PolicyOperatorAnd = "and"
+	PolicyOperatorOr  = "or"
+)
+
+// policyAttributePrefixes 条件键和占位符可以引用的属性前缀，
+// user.id、user.roles、resource.type 由评估器提供，其余属性由调用方以完整名称放入资源上下文
+var policyAttributePrefixes = []string{"user.", "resource.", "project.", "task.", "extension."}
+
+// Validate 检查条件可以序列化为JSON，且条件结构是评估器支持的形式：
+// 属性键的值为字面量、列表或 eq/ne/in 运算符对象，and/or 键的值为条件对象列表，${...} 占位符引用已知属性
+func (pc PolicyConditions) Validate() error {
+	if _, err := pc.ToJSON(); err != nil {
+		return fmt.Errorf("conditions are not valid JSON: %w", err)
+	}
+	return validateConditions(pc)
+}
+
+// validateConditions 递归检查一层条件对象
+func validateConditions(conditions map[string]interface{}) error {
+	for key, value := range conditions {
+		if key == PolicyOperatorAnd || key == PolicyOperatorOr {
+			items, ok := value.([]interface{})
+			if !ok {
+				return fmt.Errorf("condition %q must be a list of condition objects", key)
+			}
+			for _, item := range items {
+				nested, ok := AsPolicyConditions(item)
+				if !ok {
+					return fmt.Errorf("condition %q must be a list of condition objects", key)
+				}
+				if err := validateConditions(nested); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !isPolicyAttribute(key) {
+			return fmt.Errorf("unknown condition key %q, expected one of %s", key, strings.Join(policyAttributePrefixes, "*, ")+"*")
+		}
+		if operators, ok := value.(map[string]interface{}); ok {
+			for op, operand := range operators {
+				if op != PolicyOperatorEq && op != PolicyOperatorNe && op != PolicyOperatorIn {
+					return fmt.Errorf("condition %q: unknown operator %q", key, op)
+				}
+				if err := validatePlaceholders(operand); err != nil {
+					return fmt.Errorf("condition %q: %w", key, err)
+				}
+			}
+			continue
+		}
+		if err := validatePlaceholders(value); err != nil {
+			return fmt.Errorf("condition %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// AsPolicyConditions 将嵌套条件对象转换为 PolicyConditions
+func AsPolicyConditions(value interface{}) (PolicyConditions, bool) {
+	switch v := value.(type) {
+	case PolicyConditions:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	}
+	return nil, false
+}
+
+// ParsePlaceholder 字符串整体是单个 ${attr} 占位符时返回属性名
+func ParsePlaceholder(s string) (string, bool) {
+	if !strings.HasPrefix(s, "${") || !strings.HasSuffix(s, "}") {
+		return "", false
+	}
+	ref := s[2 : len(s)-1]
+	if strings.Contains(ref, "}") {
+		return "", false
+	}
+	return ref, true
+}
+
+// ExpandPlaceholders 用 lookup 的结果替换字符串中的 ${...} 占位符，任一属性不存在时返回 false
+func ExpandPlaceholders(s string, lookup func(attr string) (interface{}, bool)) (string, bool) {
+	var b strings.Builder
+	rest := s
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String(), true
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return "", false
+		}
+		value, ok := lookup(rest[start+2 : start+end])
+		if !ok {
+			return "", false
+		}
+		b.WriteString(rest[:start])
+		b.WriteString(fmt.Sprint(value))
+		rest = rest[start+end+1:]
+	}
+}
+
+// validatePlaceholders 递归检查条件值中的占位符
+func validatePlaceholders(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		rest := v
+		for {
+			start := strings.Index(rest, "${")
+			if start < 0 {
+				return nil
+			}
+			end := strings.Index(rest[start:], "}")
+			if end < 0 {
+				return fmt.Errorf("unterminated placeholder in %q", v)
+			}
+			if ref := rest[start+2 : start+end]; !isPolicyAttribute(ref) {
+				return fmt.Errorf("unknown placeholder ${%s}", ref)
+			}
+			rest = rest[start+end+1:]
+		}
+	case map[string]interface{}:
+		return fmt.Errorf("nested objects are only allowed as operators or inside and/or")
+	case []interface{}:
+		for _, item := range v {
+			if err := validatePlaceholders(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isPolicyAttribute 是否为已知命名空间下的属性
+func isPolicyAttribute(name string) bool {
+	for _, prefix := range policyAttributePrefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,7 +7,7 @@ type UserData struct {
 	ID           string     `json:"id"`
 	Email        string     `json:"email"`
 	Name         string     `json:"name"`
-	PasswordHash string     `json:"password_hash"`
+	PasswordHash string     `json:"-"` // 密码哈希，不序列化
 	Avatar       *string    `json:"avatar"`
 	Status       string     `json:"status"`
 	Phone        *string    `json:"phone"`
@@ -13,6 +13,7 @@ const (
 	ErrPermissionDenied DomainErrorType = "PERMISSION_DENIED"
 	ErrInvalidState     DomainErrorType = "INVALID_STATE"
 	ErrBusinessRule     DomainErrorType = "BUSINESS_RULE_VIOLATION"
+	ErrRateLimited      DomainErrorType = "RATE_LIMITED"
 
 	// 认证相关
 	ErrInvalidCredentials DomainErrorType = "INVALID_CREDENTIALS"
@@ -25,9 +26,10 @@ const (
 	ErrUserInactive DomainErrorType = "USER_INACTIVE"
 
 	// 项目相关
-	ErrProjectNotFound    DomainErrorType = "PROJECT_NOT_FOUND"
-	ErrProjectExists      DomainErrorType = "PROJECT_ALREADY_EXISTS"
-	ErrProjectInvalidType DomainErrorType = "PROJECT_INVALID_TYPE"
+	ErrProjectNotFound     DomainErrorType = "PROJECT_NOT_FOUND"
+	ErrProjectExists       DomainErrorType = "PROJECT_ALREADY_EXISTS"
+	ErrProjectInvalidType  DomainErrorType = "PROJECT_INVALID_TYPE"
+	ErrProjectNameConflict DomainErrorType = "PROJECT_NAME_CONFLICT"
 
 	// 任务相关
 	ErrTaskNotFound      DomainErrorType = "TASK_NOT_FOUND"
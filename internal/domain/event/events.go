@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
@@ -40,6 +41,14 @@ type BaseEvent struct {
 	AggregateRootType string    `json:"aggregate_type"`
 	Timestamp         time.Time `json:"occurred_at"`
 	EventVersion      int       `json:"version"`
+	Actor             string    `json:"actor_id,omitempty"`
+}
+
+// ActorEvent 携带触发用户的事件
+// 事件持久化时据此写入触发用户ID
+type ActorEvent interface {
+	// ActorID 触发事件的用户ID，系统触发时为空
+	ActorID() string
 }
 
 // NewBaseEvent 创建基础事件
@@ -84,6 +93,17 @@ func (e BaseEvent) Version() int {
 	return e.EventVersion
 }
 
+// ActorID 实现 ActorEvent 接口
+func (e BaseEvent) ActorID() string {
+	return e.Actor
+}
+
+// WithActor 设置触发事件的用户
+func (e *BaseEvent) WithActor(actorID string) *BaseEvent {
+	e.Actor = actorID
+	return e
+}
+
 // EventData 需要由具体事件实现
 // 基础事件返回nil，具体事件应该重写此方法
 func (e BaseEvent) EventData() interface{} {
@@ -130,3 +150,18 @@ type EventStore interface {
 	// GetEventsByType 根据类型获取事件
 	GetEventsByType(eventType string, limit int) ([]DomainEvent, error)
 }
+
+// EventQuery 事件查询条件，结果按发生时间、事件ID升序
+type EventQuery struct {
+	Types       []string  // 事件类型，为空时不过滤
+	AggregateID string    // 聚合ID，为空时不过滤
+	Since       time.Time // 只返回该时间及之后发生的事件，零值表示不限制
+	AfterID     string    // 翻页游标：与 Since 同一时刻发生的事件只返回ID更大的
+	Limit       int       // 最大返回数量，0 表示不限制
+	Descending  bool      // 按发生时间、事件ID倒序返回，配合 Limit 取最新的事件；翻页游标只用于升序
+}
+
+// EventQueryStore 支持按类型和时间窗口查询的事件存储
+type EventQueryStore interface {
+	FindEvents(ctx context.Context, query EventQuery) ([]DomainEvent, error)
+}
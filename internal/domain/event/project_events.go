@@ -14,7 +14,7 @@ type ProjectCreatedEvent struct {
 // NewProjectCreatedEvent 创建项目创建事件
 func NewProjectCreatedEvent(projectID valueobject.ProjectID, name string, projectType valueobject.ProjectType, ownerID valueobject.UserID) *ProjectCreatedEvent {
 	return &ProjectCreatedEvent{
-		BaseEvent:   NewBaseEvent("project.created", string(projectID), "project"),
+		BaseEvent:   NewBaseEvent("project.created", string(projectID), "project").WithActor(string(ownerID)),
 		ProjectID:   projectID,
 		Name:        name,
 		ProjectType: projectType,
@@ -39,7 +39,7 @@ type ProjectUpdatedEvent struct {
 // NewProjectUpdatedEvent 创建项目更新事件
 func NewProjectUpdatedEvent(projectID valueobject.ProjectID, oldName, newName string, updatedBy valueobject.UserID) *ProjectUpdatedEvent {
 	return &ProjectUpdatedEvent{
-		BaseEvent: NewBaseEvent("project.updated", string(projectID), "project"),
+		BaseEvent: NewBaseEvent("project.updated", string(projectID), "project").WithActor(string(updatedBy)),
 		ProjectID: projectID,
 		OldName:   oldName,
 		NewName:   newName,
@@ -64,7 +64,7 @@ type ProjectManagerAssignedEvent struct {
 // NewProjectManagerAssignedEvent 创建项目管理者分配事件
 func NewProjectManagerAssignedEvent(projectID valueobject.ProjectID, oldManagerID, newManagerID *valueobject.UserID, assignedBy valueobject.UserID) *ProjectManagerAssignedEvent {
 	return &ProjectManagerAssignedEvent{
-		BaseEvent:    NewBaseEvent("project.manager_assigned", string(projectID), "project"),
+		BaseEvent:    NewBaseEvent("project.manager_assigned", string(projectID), "project").WithActor(string(assignedBy)),
 		ProjectID:    projectID,
 		OldManagerID: oldManagerID,
 		NewManagerID: newManagerID,
@@ -89,7 +89,7 @@ type ProjectMemberAddedEvent struct {
 // NewProjectMemberAddedEvent 创建项目成员添加事件
 func NewProjectMemberAddedEvent(projectID valueobject.ProjectID, userID valueobject.UserID, role valueobject.ProjectRole, addedBy valueobject.UserID) *ProjectMemberAddedEvent {
 	return &ProjectMemberAddedEvent{
-		BaseEvent: NewBaseEvent("project.member_added", string(projectID), "project"),
+		BaseEvent: NewBaseEvent("project.member_added", string(projectID), "project").WithActor(string(addedBy)),
 		ProjectID: projectID,
 		UserID:    userID,
 		Role:      role,
@@ -114,7 +114,7 @@ type ProjectMemberRemovedEvent struct {
 // NewProjectMemberRemovedEvent 创建项目成员移除事件
 func NewProjectMemberRemovedEvent(projectID valueobject.ProjectID, userID valueobject.UserID, role valueobject.ProjectRole, removedBy valueobject.UserID) *ProjectMemberRemovedEvent {
 	return &ProjectMemberRemovedEvent{
-		BaseEvent: NewBaseEvent("project.member_removed", string(projectID), "project"),
+		BaseEvent: NewBaseEvent("project.member_removed", string(projectID), "project").WithActor(string(removedBy)),
 		ProjectID: projectID,
 		UserID:    userID,
 		Role:      role,
@@ -140,7 +140,7 @@ type ProjectStatusChangedEvent struct {
 // NewProjectStatusChangedEvent 创建项目状态变更事件
 func NewProjectStatusChangedEvent(projectID valueobject.ProjectID, oldStatus, newStatus valueobject.ProjectStatus, changedBy valueobject.UserID, reason string) *ProjectStatusChangedEvent {
 	return &ProjectStatusChangedEvent{
-		BaseEvent: NewBaseEvent("project.status_changed", string(projectID), "project"),
+		BaseEvent: NewBaseEvent("project.status_changed", string(projectID), "project").WithActor(string(changedBy)),
 		ProjectID: projectID,
 		OldStatus: oldStatus,
 		NewStatus: newStatus,
@@ -164,7 +164,7 @@ type ProjectDeletedEvent struct {
 // NewProjectDeletedEvent 创建项目删除事件
 func NewProjectDeletedEvent(projectID valueobject.ProjectID, deletedBy valueobject.UserID) *ProjectDeletedEvent {
 	return &ProjectDeletedEvent{
-		BaseEvent: NewBaseEvent("project.deleted", string(projectID), "project"),
+		BaseEvent: NewBaseEvent("project.deleted", string(projectID), "project").WithActor(string(deletedBy)),
 		ProjectID: projectID,
 		DeletedBy: deletedBy,
 	}
@@ -188,7 +188,7 @@ type ProjectMemberRoleUpdatedEvent struct {
 // NewProjectMemberRoleUpdatedEvent 创建项目成员角色更新事件
 func NewProjectMemberRoleUpdatedEvent(projectID valueobject.ProjectID, userID valueobject.UserID, oldRole, newRole valueobject.ProjectRole, updatedBy valueobject.UserID) *ProjectMemberRoleUpdatedEvent {
 	return &ProjectMemberRoleUpdatedEvent{
-		BaseEvent: NewBaseEvent("project.member_role_updated", string(projectID), "project"),
+		BaseEvent: NewBaseEvent("project.member_role_updated", string(projectID), "project").WithActor(string(updatedBy)),
 		ProjectID: projectID,
 		UserID:    userID,
 		OldRole:   oldRole,
@@ -214,7 +214,7 @@ type SubProjectCreatedEvent struct {
 // NewSubProjectCreatedEvent 创建子项目创建事件
 func NewSubProjectCreatedEvent(parentProjectID, subProjectID valueobject.ProjectID, name string, createdBy valueobject.UserID) *SubProjectCreatedEvent {
 	return &SubProjectCreatedEvent{
-		BaseEvent:       NewBaseEvent("project.sub_project_created", string(parentProjectID), "project"),
+		BaseEvent:       NewBaseEvent("project.sub_project_created", string(parentProjectID), "project").WithActor(string(createdBy)),
 		ParentProjectID: parentProjectID,
 		SubProjectID:    subProjectID,
 		Name:            name,
@@ -227,6 +227,31 @@ func (e *SubProjectCreatedEvent) EventData() interface{} {
 	return e
 }
 
+// ProjectParentChangedEvent 项目父项目变更事件
+type ProjectParentChangedEvent struct {
+	*BaseEvent
+	ProjectID   valueobject.ProjectID  `json:"project_id"`
+	OldParentID *valueobject.ProjectID `json:"old_parent_id,omitempty"`
+	NewParentID valueobject.ProjectID  `json:"new_parent_id"`
+	ChangedBy   valueobject.UserID     `json:"changed_by"`
+}
+
+// NewProjectParentChangedEvent 创建项目父项目变更事件
+func NewProjectParentChangedEvent(projectID valueobject.ProjectID, oldParentID *valueobject.ProjectID, newParentID valueobject.ProjectID, changedBy valueobject.UserID) *ProjectParentChangedEvent {
+	return &ProjectParentChangedEvent{
+		BaseEvent:   NewBaseEvent("project.parent_changed", string(projectID), "project").WithActor(string(changedBy)),
+		ProjectID:   projectID,
+		OldParentID: oldParentID,
+		NewParentID: newParentID,
+		ChangedBy:   changedBy,
+	}
+}
+
+// EventData 实现 DomainEvent 接口
+func (e *ProjectParentChangedEvent) EventData() interface{} {
+	return e
+}
+
 // 确保所有事件都实现了 DomainEvent 接口
 var _ DomainEvent = (*ProjectCreatedEvent)(nil)
 var _ DomainEvent = (*ProjectUpdatedEvent)(nil)
@@ -237,3 +262,4 @@ var _ DomainEvent = (*ProjectStatusChangedEvent)(nil)
 var _ DomainEvent = (*ProjectDeletedEvent)(nil)
 var _ DomainEvent = (*ProjectMemberRoleUpdatedEvent)(nil)
 var _ DomainEvent = (*SubProjectCreatedEvent)(nil)
+var _ DomainEvent = (*ProjectParentChangedEvent)(nil)
@@ -31,7 +31,7 @@ func NewTaskCreatedEvent(taskID, title, projectID, creatorID, responsibleID, tas
 		DueDate:       dueDate,
 	}
 
-	event.BaseEvent = NewBaseEvent("TaskCreated", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("TaskCreated", taskID, "Task").WithActor(creatorID)
 	return event
 }
 
@@ -48,6 +48,8 @@ type TaskAssignedEvent struct {
 	ExecutorID         string  `json:"executor_id"`
 	AssignerID         string  `json:"assigner_id"`
 	PreviousExecutorID *string `json:"previous_executor_id,omitempty"`
+	// CoResponsibleIDs 与执行者（牵头负责人）共同负责的用户
+	CoResponsibleIDs []string `json:"co_responsible_ids,omitempty"`
 }
 
 func NewTaskAssignedEvent(taskID, projectID, executorID, assignerID string, previousExecutorID *string) *TaskAssignedEvent {
@@ -59,7 +61,7 @@ func NewTaskAssignedEvent(taskID, projectID, executorID, assignerID string, prev
 		PreviousExecutorID: previousExecutorID,
 	}
 
-	event.BaseEvent = NewBaseEvent("TaskAssigned", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("TaskAssigned", taskID, "Task").WithActor(assignerID)
 	return event
 }
 
@@ -68,6 +70,11 @@ func (e *TaskAssignedEvent) EventData() interface{} {
 	return e
 }
 
+// Assignees 返回被分配的全部负责人，牵头负责人在前
+func (e *TaskAssignedEvent) Assignees() []string {
+	return append([]string{e.ExecutorID}, e.CoResponsibleIDs...)
+}
+
 // TaskPriorityChangedEvent 任务优先级变更事件
 type TaskPriorityChangedEvent struct {
 	*BaseEvent
@@ -85,7 +92,7 @@ func NewTaskPriorityChangedEvent(taskID, oldPriority, newPriority, changedBy str
 		ChangedBy:   changedBy,
 	}
 
-	event.BaseEvent = NewBaseEvent("TaskPriorityChanged", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("TaskPriorityChanged", taskID, "Task").WithActor(changedBy)
 	return event
 }
 
@@ -121,10 +128,145 @@ func NewTaskStatusChangedEvent(taskID, oldStatus, newStatus, changedBy, changeRe
 		ChangeReason: changeReason,
 	}
 
-	event.BaseEvent = NewBaseEvent("TaskStatusChanged", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("TaskStatusChanged", taskID, "Task").WithActor(changedBy)
+	return event
+}
+
+// EventData 实现 DomainEvent 接口
+func (e *TaskStatusChangedEvent) EventData() interface{} {
+	return e
+}
+
+// TaskWorkflowChangedEvent 任务工作流变更事件
+type TaskWorkflowChangedEvent struct {
+	*BaseEvent
+	TaskID        string `json:"task_id"`
+	OldWorkflowID string `json:"old_workflow_id,omitempty"`
+	NewWorkflowID string `json:"new_workflow_id,omitempty"`
+	ChangedBy     string `json:"changed_by"`
+}
+
+func NewTaskWorkflowChangedEvent(taskID, oldWorkflowID, newWorkflowID, changedBy string) *TaskWorkflowChangedEvent {
+	event := &TaskWorkflowChangedEvent{
+		TaskID:        taskID,
+		OldWorkflowID: oldWorkflowID,
+		NewWorkflowID: newWorkflowID,
+		ChangedBy:     changedBy,
+	}
+
+	event.BaseEvent = NewBaseEvent("TaskWorkflowChanged", taskID, "Task").WithActor(changedBy)
+	return event
+}
+
+// EventData 实现 DomainEvent 接口
+func (e *TaskWorkflowChangedEvent) EventData() interface{} {
+	return e
+}
+
+// TaskTypeChangedEvent 任务类型变更事件
+type TaskTypeChangedEvent struct {
+	*BaseEvent
+	TaskID    string `json:"task_id"`
+	OldType   string `json:"old_type"`
+	NewType   string `json:"new_type"`
+	ChangedBy string `json:"changed_by"`
+}
+
+func NewTaskTypeChangedEvent(taskID, oldType, newType, changedBy string) *TaskTypeChangedEvent {
+	event := &TaskTypeChangedEvent{
+		TaskID:    taskID,
+		OldType:   oldType,
+		NewType:   newType,
+		ChangedBy: changedBy,
+	}
+
+	event.BaseEvent = NewBaseEvent("TaskTypeChanged", taskID, "Task").WithActor(changedBy)
+	return event
+}
+
+// EventData 实现 DomainEvent 接口
+func (e *TaskTypeChangedEvent) EventData() interface{} {
+	return e
+}
+
+// TaskDueDateChangedEvent 任务截止日期变更事件，日期为nil表示无截止日期
+type TaskDueDateChangedEvent struct {
+	*BaseEvent
+	TaskID     string     `json:"task_id"`
+	OldDueDate *time.Time `json:"old_due_date,omitempty"`
+	NewDueDate *time.Time `json:"new_due_date,omitempty"`
+	ChangedBy  string     `json:"changed_by"`
+}
+
+func NewTaskDueDateChangedEvent(taskID string, oldDueDate, newDueDate *time.Time, changedBy string) *TaskDueDateChangedEvent {
+	event := &TaskDueDateChangedEvent{
+		TaskID:     taskID,
+		OldDueDate: oldDueDate,
+		NewDueDate: newDueDate,
+		ChangedBy:  changedBy,
+	}
+
+	event.BaseEvent = NewBaseEvent("TaskDueDateChanged", taskID, "Task").WithActor(changedBy)
+	return event
+}
+
+// EventData 实现 DomainEvent 接口
+func (e *TaskDueDateChangedEvent) EventData() interface{} {
+	return e
+}
+
+// TaskDueReminderEvent 任务截止日期提醒事件，由提醒调度按提醒梯度为每个接收人发布
+type TaskDueReminderEvent struct {
+	*BaseEvent
+	TaskID        string    `json:"task_id"`
+	UserID        string    `json:"user_id"`
+	DueDate       time.Time `json:"due_date"`
+	OffsetMinutes int       `json:"offset_minutes"` // 距截止时间的提前量，0 表示已到期
+}
+
+func NewTaskDueReminderEvent(taskID, userID string, dueDate time.Time, offset time.Duration) *TaskDueReminderEvent {
+	event := &TaskDueReminderEvent{
+		TaskID:        taskID,
+		UserID:        userID,
+		DueDate:       dueDate,
+		OffsetMinutes: int(offset / time.Minute),
+	}
+
+	event.BaseEvent = NewBaseEvent("TaskDueReminder", taskID, "Task")
+	return event
+}
+
+// EventData 实现 DomainEvent 接口
+func (e *TaskDueReminderEvent) EventData() interface{} {
+	return e
+}
+
+// TaskDeletedEvent 任务删除事件
+type TaskDeletedEvent struct {
+	*BaseEvent
+	TaskID    string `json:"task_id"`
+	ProjectID string `json:"project_id"`
+	Status    string `json:"status"`
+	DeletedBy string `json:"deleted_by"`
+}
+
+func NewTaskDeletedEvent(taskID, projectID, status, deletedBy string) *TaskDeletedEvent {
+	event := &TaskDeletedEvent{
+		TaskID:    taskID,
+		ProjectID: projectID,
+		Status:    status,
+		DeletedBy: deletedBy,
+	}
+
+	event.BaseEvent = NewBaseEvent("TaskDeleted", taskID, "Task").WithActor(deletedBy)
 	return event
 }
 
+// EventData 实现 DomainEvent 接口
+func (e *TaskDeletedEvent) EventData() interface{} {
+	return e
+}
+
 // ParticipantAddedEvent 参与者添加事件
 type ParticipantAddedEvent struct {
 	*BaseEvent
@@ -149,7 +291,7 @@ func NewParticipantAddedEvent(taskID, participantID, addedBy, role string) *Part
 		Role:          role,
 	}
 
-	event.BaseEvent = NewBaseEvent("ParticipantAdded", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("ParticipantAdded", taskID, "Task").WithActor(addedBy)
 	return event
 }
 
@@ -177,7 +319,7 @@ func NewParticipantRemovedEvent(taskID, participantID, removedBy, reason string)
 		Reason:        reason,
 	}
 
-	event.BaseEvent = NewBaseEvent("ParticipantRemoved", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("ParticipantRemoved", taskID, "Task").WithActor(removedBy)
 	return event
 }
 
@@ -198,7 +340,7 @@ func NewWorkSubmittedEvent(taskID, participantID, workContent string, attachment
 		Attachments:   attachments,
 	}
 
-	event.BaseEvent = NewBaseEvent("WorkSubmitted", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("WorkSubmitted", taskID, "Task").WithActor(participantID)
 	return event
 }
 
@@ -207,6 +349,32 @@ func (e *WorkSubmittedEvent) EventData() interface{} {
 	return e
 }
 
+// HoursLoggedEvent 工时记录事件
+type HoursLoggedEvent struct {
+	*BaseEvent
+	TaskID        string  `json:"task_id"`
+	ParticipantID string  `json:"participant_id"`
+	Hours         float64 `json:"hours"`
+	TotalHours    float64 `json:"total_hours"`
+}
+
+func NewHoursLoggedEvent(taskID, participantID string, hours, totalHours float64) *HoursLoggedEvent {
+	event := &HoursLoggedEvent{
+		TaskID:        taskID,
+		ParticipantID: participantID,
+		Hours:         hours,
+		TotalHours:    totalHours,
+	}
+
+	event.BaseEvent = NewBaseEvent("HoursLogged", taskID, "Task").WithActor(participantID)
+	return event
+}
+
+// EventData 实现 DomainEvent 接口
+func (e *HoursLoggedEvent) EventData() interface{} {
+	return e
+}
+
 // WorkReviewedEvent 工作审核事件
 type WorkReviewedEvent struct {
 	*BaseEvent
@@ -226,7 +394,7 @@ func NewWorkReviewedEvent(taskID, participantID, reviewerID string, approved boo
 		Comment:       comment,
 	}
 
-	event.BaseEvent = NewBaseEvent("WorkReviewed", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("WorkReviewed", taskID, "Task").WithActor(reviewerID)
 	return event
 }
 
@@ -250,7 +418,7 @@ func NewTaskCompletionSubmittedEvent(taskID, responsibleID, summary string) *Tas
 		Summary:       summary,
 	}
 
-	event.BaseEvent = NewBaseEvent("TaskCompletionSubmitted", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("TaskCompletionSubmitted", taskID, "Task").WithActor(responsibleID)
 	return event
 }
 
@@ -275,7 +443,7 @@ func NewTaskCompletedEvent(taskID, completedBy string) *TaskCompletedEvent {
 		CompletedBy: completedBy,
 	}
 
-	event.BaseEvent = NewBaseEvent("TaskCompleted", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("TaskCompleted", taskID, "Task").WithActor(completedBy)
 	return event
 }
 
@@ -299,7 +467,7 @@ func NewTaskRejectedEvent(taskID, rejectedBy, comment string) *TaskRejectedEvent
 		Comment:    comment,
 	}
 
-	event.BaseEvent = NewBaseEvent("TaskRejected", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("TaskRejected", taskID, "Task").WithActor(rejectedBy)
 	return event
 }
 
@@ -327,7 +495,7 @@ func NewExtensionRequestedEvent(taskID, requestID, requesterID string, newDueDat
 		Reason:      reason,
 	}
 
-	event.BaseEvent = NewBaseEvent("ExtensionRequested", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("ExtensionRequested", taskID, "Task").WithActor(requesterID)
 	return event
 }
 
@@ -353,7 +521,7 @@ func NewExtensionApprovedEvent(taskID, requestID, reviewerID string, newDueDate
 		NewDueDate: newDueDate,
 	}
 
-	event.BaseEvent = NewBaseEvent("ExtensionApproved", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("ExtensionApproved", taskID, "Task").WithActor(reviewerID)
 	return event
 }
 
@@ -379,7 +547,7 @@ func NewExtensionRejectedEvent(taskID, requestID, reviewerID, comment string) *E
 		Comment:    comment,
 	}
 
-	event.BaseEvent = NewBaseEvent("ExtensionRejected", taskID, "Task")
+	event.BaseEvent = NewBaseEvent("ExtensionRejected", taskID, "Task").WithActor(reviewerID)
 	return event
 }
 
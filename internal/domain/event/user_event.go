@@ -23,6 +23,7 @@ func (e UserCreatedEvent) EventType() string               { return "user.create
 func (e UserCreatedEvent) AggregateID() valueobject.UserID { return e.UserID }
 func (e UserCreatedEvent) OccurredAt() time.Time           { return e.OccurredOn }
 func (e UserCreatedEvent) Version() int                    { return e.EventVersion }
+func (e UserCreatedEvent) ActorID() string                 { return string(e.CreatedBy) }
 
 // UserRoleChangedEvent 用户角色变更事件
 type UserRoleChangedEvent struct {
@@ -43,6 +44,7 @@ func (e UserRoleChangedEvent) OccurredAt() time.Time  { return e.OccurredOn }
 func (e UserRoleChangedEvent) Version() int           { return e.EventVersion }
 func (e UserRoleChangedEvent) EventData() interface{} { return e }
 func (e UserRoleChangedEvent) AggregateType() string  { return "user" }
+func (e UserRoleChangedEvent) ActorID() string        { return string(e.ChangedBy) }
 
 // UserDeactivatedEvent 用户停用事件
 type UserDeactivatedEvent struct {
@@ -63,6 +65,7 @@ func (e UserDeactivatedEvent) OccurredAt() time.Time  { return e.OccurredOn }
 func (e UserDeactivatedEvent) Version() int           { return e.EventVersion }
 func (e UserDeactivatedEvent) EventData() interface{} { return e }
 func (e UserDeactivatedEvent) AggregateType() string  { return "user" }
+func (e UserDeactivatedEvent) ActorID() string        { return string(e.DeactivatedBy) }
 
 // UserDepartmentTransferredEvent 用户部门转移事件
 type UserDepartmentTransferredEvent struct {
@@ -85,3 +88,4 @@ func (e UserDepartmentTransferredEvent) OccurredAt() time.Time  { return e.Occur
 func (e UserDepartmentTransferredEvent) Version() int           { return e.EventVersion }
 func (e UserDepartmentTransferredEvent) EventData() interface{} { return e }
 func (e UserDepartmentTransferredEvent) AggregateType() string  { return "user" }
+func (e UserDepartmentTransferredEvent) ActorID() string        { return string(e.TransferredBy) }
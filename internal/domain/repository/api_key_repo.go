@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// APIKeyRepository 用户API密钥仓储接口
+type APIKeyRepository interface {
+	Create(ctx context.Context, key aggregate.APIKey) error // ID或哈希已存在时返回 ErrAlreadyExists
+	Update(ctx context.Context, key aggregate.APIKey) error // 不存在时返回 ErrNotFound
+	FindByID(ctx context.Context, id string) (*aggregate.APIKey, error)
+	FindByHash(ctx context.Context, keyHash string) (*aggregate.APIKey, error)             // 不存在时返回 ErrNotFound
+	FindByUser(ctx context.Context, userID valueobject.UserID) ([]aggregate.APIKey, error) // 按创建时间排序，包含已吊销的密钥
+}
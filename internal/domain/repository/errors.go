@@ -0,0 +1,11 @@
+package repository
+
+import "errors"
+
+// 仓储通用错误，实现方通过 %w 包装返回，调用方使用 errors.Is 判断
+var (
+	// ErrAlreadyExists 新建时ID已被占用
+	ErrAlreadyExists = errors.New("record already exists")
+	// ErrNotFound 记录不存在
+	ErrNotFound = errors.New("record not found")
+)
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// FileRepository 文件元数据及资源关联仓储接口
+type FileRepository interface {
+	// FindByIDs 查找文件元数据，忽略不存在或已删除的文件
+	FindByIDs(ctx context.Context, ids []string) ([]valueobject.FileInfo, error)
+	// FindAssociatedFileIDs 查找已关联到资源的文件ID
+	FindAssociatedFileIDs(ctx context.Context, resourceType, resourceID string) ([]string, error)
+	// AssociateAttachments 将文件作为附件关联到资源，已关联的文件跳过
+	AssociateAttachments(ctx context.Context, fileIDs []string, resourceType, resourceID string) error
+}
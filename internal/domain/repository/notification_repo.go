@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// NotificationRepository 站内通知仓储接口
+type NotificationRepository interface {
+	Create(ctx context.Context, notifications []aggregate.Notification) error
+	// FindByUser 按创建时间倒序分页查询用户的通知，返回符合条件的总数
+	FindByUser(ctx context.Context, userID valueobject.UserID, unreadOnly bool, limit, offset int) ([]aggregate.Notification, int, error)
+	CountUnread(ctx context.Context, userID valueobject.UserID) (int, error)
+	// MarkRead 标记用户的一条通知为已读，通知不存在或不属于该用户时返回 ErrNotFound
+	MarkRead(ctx context.Context, userID valueobject.UserID, id string) (*aggregate.Notification, error)
+	// MarkAllRead 标记用户的全部未读通知为已读，返回标记的数量
+	MarkAllRead(ctx context.Context, userID valueobject.UserID) (int, error)
+}
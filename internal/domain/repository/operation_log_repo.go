@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// OperationLogRepository 操作日志仓储接口，日志只追加不修改
+type OperationLogRepository interface {
+	Create(ctx context.Context, log aggregate.OperationLog) error
+	FindByUser(ctx context.Context, userID valueobject.UserID, limit int) ([]aggregate.OperationLog, error)            // 按创建时间倒序
+	CountByUserSince(ctx context.Context, userID valueobject.UserID, operation string, since time.Time) (int64, error) // 统计 since 之后的指定操作
+}
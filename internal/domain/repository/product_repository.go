@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/taskflow/internal/domain/aggregate"
 	"github.com/taskflow/internal/domain/valueobject"
@@ -10,7 +11,8 @@ import (
 // ProjectRepository 项目仓储接口
 type ProjectRepository interface {
 	// 基本CRUD操作
-	Save(ctx context.Context, project aggregate.Project) error
+	Create(ctx context.Context, project aggregate.Project) error // ID已存在时返回 ErrAlreadyExists
+	Update(ctx context.Context, project aggregate.Project) error // 项目不存在时返回 ErrNotFound
 	FindByID(ctx context.Context, id valueobject.ProjectID) (*aggregate.Project, error)
 	FindByIDs(ctx context.Context, ids []valueobject.ProjectID) ([]aggregate.Project, error)
 	Delete(ctx context.Context, id valueobject.ProjectID) error
@@ -22,6 +24,7 @@ type ProjectRepository interface {
 	FindByParent(ctx context.Context, parentID valueobject.ProjectID) ([]aggregate.Project, error)
 	FindByStatus(ctx context.Context, status valueobject.ProjectStatus) ([]aggregate.Project, error)
 	FindByType(ctx context.Context, projectType valueobject.ProjectType) ([]aggregate.Project, error)
+	FindByDateRange(ctx context.Context, from, to time.Time) ([]aggregate.Project, error)
 
 	// 复杂查询
 	SearchProjects(ctx context.Context, criteria aggregate.ProjectSearchCriteria) ([]aggregate.Project, int, error)
@@ -31,4 +34,9 @@ type ProjectRepository interface {
 	CountByOwner(ctx context.Context, ownerID valueobject.UserID) (int, error)
 	CountByStatus(ctx context.Context, status valueobject.ProjectStatus) (int, error)
 	GetProjectStatistics(ctx context.Context, projectID valueobject.ProjectID) (*aggregate.ProjectStatistics, error)
+	// FindProjectsWithOverdueTasks 分页查找至少有一个截止时间早于 asOf 且未完成、未取消任务的项目，返回总数
+	// 按逾期任务数降序，数量相同时按项目ID升序
+	FindProjectsWithOverdueTasks(ctx context.Context, asOf time.Time, limit, offset int) ([]aggregate.ProjectOverdueSummary, int, error)
+	// SyncTaskStatistics 按任务数据重新统计项目的任务总数和已完成数并写入项目，并发调用不会用旧计数覆盖新计数，项目不存在时不做处理
+	SyncTaskStatistics(ctx context.Context, projectID valueobject.ProjectID) error
 }
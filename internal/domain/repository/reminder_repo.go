@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// ReminderRepository 截止日期提醒记录仓储接口
+type ReminderRepository interface {
+	// RecordSent 记录已发送的提醒，相同任务、用户、截止时间和梯度已有记录时不写入并返回 false
+	RecordSent(ctx context.Context, reminder valueobject.TaskReminder) (bool, error)
+	// Acknowledge 记录用户已确认任务的提醒，重复确认保持首次确认时间
+	Acknowledge(ctx context.Context, taskID valueobject.TaskID, userID valueobject.UserID, at time.Time) error
+	// IsAcknowledged 用户是否已确认任务的提醒
+	IsAcknowledged(ctx context.Context, taskID valueobject.TaskID, userID valueobject.UserID) (bool, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PurgeCounts 按表名统计的清理行数
+type PurgeCounts map[string]int64
+
+// Total 返回全部表的清理行数之和
+func (c PurgeCounts) Total() int64 {
+	var total int64
+	for _, count := range c {
+		total += count
+	}
+	return total
+}
+
+// RetentionRepository 软删除数据清理仓储接口
+type RetentionRepository interface {
+	// PurgeSoftDeleted 物理删除 deleted_at 早于 before 的项目、任务和文件，并级联删除其依赖行
+	// 被清理项目下的任务一并清理；dryRun 为 true 时只统计不删除
+	PurgeSoftDeleted(ctx context.Context, before time.Time, dryRun bool) (PurgeCounts, error)
+}
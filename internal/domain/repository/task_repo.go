@@ -11,14 +11,19 @@ import (
 // TaskRepository 任务仓储接口
 type TaskRepository interface {
 	// 基本CRUD操作
-	Save(ctx context.Context, task aggregate.TaskAggregate) error
+	Create(ctx context.Context, task aggregate.TaskAggregate) error // ID已存在时返回 ErrAlreadyExists
+	Update(ctx context.Context, task aggregate.TaskAggregate) error // 任务不存在时返回 ErrNotFound
 	FindByID(ctx context.Context, id valueobject.TaskID) (*aggregate.TaskAggregate, error)
+	// FindByIDForUpdate 在当前事务中锁定任务行后加载任务，同一任务的并发修改在事务提交前串行执行
+	FindByIDForUpdate(ctx context.Context, id valueobject.TaskID) (*aggregate.TaskAggregate, error)
 	FindByIDs(ctx context.Context, ids []valueobject.TaskID) ([]aggregate.TaskAggregate, error)
 	Delete(ctx context.Context, id valueobject.TaskID) error
+	BatchDelete(ctx context.Context, ids []valueobject.TaskID) error
 
 	// 查询方法
 	FindByProject(ctx context.Context, projectID valueobject.ProjectID) ([]aggregate.TaskAggregate, error)
 	FindByCreator(ctx context.Context, creatorID valueobject.UserID) ([]aggregate.TaskAggregate, error)
+	// FindByResponsible 用户作为负责人或共同负责人的任务
 	FindByResponsible(ctx context.Context, responsibleID valueobject.UserID) ([]aggregate.TaskAggregate, error)
 	FindByParticipant(ctx context.Context, participantID valueobject.UserID) ([]aggregate.TaskAggregate, error)
 	FindByStatus(ctx context.Context, status valueobject.TaskStatus) ([]aggregate.TaskAggregate, error)
@@ -28,13 +33,38 @@ type TaskRepository interface {
 	// 复杂查询
 	SearchTasks(ctx context.Context, criteria valueobject.TaskSearchCriteria) ([]aggregate.TaskAggregate, int, error)
 	FindOverdueTasks(ctx context.Context, asOfDate time.Time) ([]aggregate.TaskAggregate, error)
+	// FindTasksDueWithin 从现在起 duration 内到期且未完成、未取消的任务，按截止时间升序
 	FindTasksDueWithin(ctx context.Context, duration time.Duration) ([]aggregate.TaskAggregate, error)
+	// FindTasksDueBetween 截止时间在 [from, to] 内且未完成、未取消的任务，按截止时间升序
+	FindTasksDueBetween(ctx context.Context, from, to time.Time) ([]aggregate.TaskAggregate, error)
+	FindApprovedNotStarted(ctx context.Context, approvedBefore time.Time, projectID *valueobject.ProjectID) ([]aggregate.TaskAggregate, error) // 审批通过时间早于 approvedBefore 且仍未开始的任务，按审批时间升序
+	// FindWithEstimateAndActual 同时记录了预估工时和实际工时的任务，按任务ID升序
+	FindWithEstimateAndActual(ctx context.Context, projectID *valueobject.ProjectID, responsibleID *valueobject.UserID) ([]aggregate.TaskAggregate, error)
 	FindUserAccessibleTasks(ctx context.Context, userID valueobject.UserID, limit, offset int) ([]aggregate.TaskAggregate, int, error)
+	// FindActionRequired 未结束的任务中用户需要处理的任务：负责或共同负责的进行中任务、有待其审核的工作提交或待其审批的延期申请，包含延期申请和工作提交
+	// 按截止时间升序分页，同时返回符合条件的任务总数
+	FindActionRequired(ctx context.Context, userID valueobject.UserID, limit, offset int) ([]aggregate.TaskAggregate, int, error)
+
+	// 延期申请
+	FindExtensionsByTask(ctx context.Context, taskID valueobject.TaskID) ([]valueobject.ExtensionRequest, error)
+
+	// 工作提交
+	FindWorkSubmissionsByTask(ctx context.Context, taskID valueobject.TaskID) ([]valueobject.WorkSubmission, error) // 按提交时间倒序
+
+	// FindSubmittedApprovals 用户提交的任务创建审批、工作提交和延期申请，按提交时间倒序、同一时间按ID升序分页，
+	// 已删除任务的记录不计入；同时返回过滤后的总数
+	FindSubmittedApprovals(ctx context.Context, criteria valueobject.SubmittedApprovalCriteria) ([]valueobject.SubmittedApproval, int, error)
 
 	// 统计查询
 	CountByProject(ctx context.Context, projectID valueobject.ProjectID) (int, error)
 	CountByStatus(ctx context.Context, status valueobject.TaskStatus) (int, error)
 	CountByResponsible(ctx context.Context, responsibleID valueobject.UserID) (int, error)
+	// CountWorkloadByResponsible 统计用户在全部项目中负责或共同负责的未结束和逾期任务数，每个用户ID都有结果
+	CountWorkloadByResponsible(ctx context.Context, responsibleIDs []valueobject.UserID) (map[valueobject.UserID]valueobject.TaskWorkload, error)
 	GetTaskStatistics(ctx context.Context, taskID valueobject.TaskID) (*valueobject.TaskStatistics, error)
 	GetProjectTaskStatistics(ctx context.Context, projectID valueobject.ProjectID) (*valueobject.ProjectTaskStatistics, error)
+	// GetAllTaskStatistics 统计全部项目的任务，结果的 ProjectID 为空
+	GetAllTaskStatistics(ctx context.Context) (*valueobject.ProjectTaskStatistics, error)
+	// GetProjectsTaskStatistics 批量统计多个项目的任务，每个项目ID都有结果，没有任务的项目为空统计
+	GetProjectsTaskStatistics(ctx context.Context, projectIDs []valueobject.ProjectID) (map[valueobject.ProjectID]*valueobject.ProjectTaskStatistics, error)
 }
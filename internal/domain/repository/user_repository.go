@@ -12,6 +12,8 @@ type UserRepository interface {
 	// 基本CRUD操作
 	Save(ctx context.Context, user *aggregate.User) error
 	FindByID(ctx context.Context, id string) (*aggregate.User, error)
+	// FindByIDForUpdate 在当前事务中锁定用户行后加载用户，同一用户相关的并发操作在事务提交前串行执行
+	FindByIDForUpdate(ctx context.Context, id string) (*aggregate.User, error)
 	FindByEmail(ctx context.Context, email string) (*aggregate.User, error)
 	FindByUsername(ctx context.Context, username string) (*aggregate.User, error)
 	Delete(ctx context.Context, id string) error
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taskflow/internal/domain/aggregate"
+)
+
+// WebhookRepository webhook订阅及投递记录仓储接口
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook aggregate.Webhook) error // ID已存在时返回 ErrAlreadyExists
+	Update(ctx context.Context, webhook aggregate.Webhook) error // 不存在时返回 ErrNotFound
+	Delete(ctx context.Context, id string) error                 // 不存在时返回 ErrNotFound
+	FindByID(ctx context.Context, id string) (*aggregate.Webhook, error)
+	FindAll(ctx context.Context) ([]aggregate.Webhook, error)
+	FindActive(ctx context.Context) ([]aggregate.Webhook, error)
+
+	// 投递记录
+	SaveDelivery(ctx context.Context, delivery aggregate.WebhookDelivery) error
+	FindDeliveries(ctx context.Context, webhookID string, limit int) ([]aggregate.WebhookDelivery, error) // 按投递时间倒序
+	FindDeliveryAttempts(ctx context.Context, deliveryID string) ([]aggregate.WebhookDelivery, error)     // 按尝试次数升序，不存在时返回 ErrNotFound
+}
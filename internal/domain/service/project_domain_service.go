@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/repository"
 	"github.com/taskflow/internal/domain/valueobject"
 )
@@ -15,6 +18,9 @@ type ProjectDomainService interface {
 	CanCreateSubProject(ctx context.Context, parentProjectID valueobject.ProjectID, userID valueobject.UserID) (bool, error)
 	ValidateProjectHierarchy(ctx context.Context, parentID, childID valueobject.ProjectID) error
 	GetProjectHierarchy(ctx context.Context, projectID valueobject.ProjectID) (*ProjectHierarchy, error)
+	GetProjectDescendants(ctx context.Context, projectID valueobject.ProjectID, maxDepth int) ([]ProjectDescendant, error)
+	ValidateProjectReparent(ctx context.Context, projectID, newParentID valueobject.ProjectID) error
+	ValidateSiblingNameUnique(ctx context.Context, parentID valueobject.ProjectID, name string, excludeID valueobject.ProjectID) error
 
 	// 项目权限验证
 	CanUserAccessProject(ctx context.Context, projectID valueobject.ProjectID, userID valueobject.UserID) (bool, error)
@@ -23,6 +29,7 @@ type ProjectDomainService interface {
 
 	// 项目成员管理
 	ValidateMemberAddition(ctx context.Context, projectID valueobject.ProjectID, userID valueobject.UserID, role valueobject.ProjectRole) error
+	ValidateManagerAssignment(ctx context.Context, projectID valueobject.ProjectID, managerID valueobject.UserID) error
 	GetProjectMemberStatistics(ctx context.Context, projectID valueobject.ProjectID) (*ProjectMemberStats, error)
 
 	// 项目状态管理
@@ -35,10 +42,19 @@ type ProjectHierarchy struct {
 	Project       *aggregate.Project  `json:"project"`
 	Parent        *aggregate.Project  `json:"parent,omitempty"`
 	Children      []aggregate.Project `json:"children"`
-	Depth         int                 `json:"depth"`
+	Depth         int                 `json:"depth"` // 项目所在层级，根项目为0
 	TotalProjects int                 `json:"total_projects"`
 }
 
+// MaxProjectDescendantDepth 查询后代项目的最大层级深度，防止异常数据导致无限递归
+const MaxProjectDescendantDepth = 10
+
+// ProjectDescendant 后代项目及其相对层级（直接子项目为1）
+type ProjectDescendant struct {
+	Project aggregate.Project `json:"project"`
+	Depth   int               `json:"depth"`
+}
+
 // ProjectMemberStats 项目成员统计
 type ProjectMemberStats struct {
 	TotalMembers     int                             `json:"total_members"`
@@ -127,6 +143,7 @@ func (s *ProjectDomainServiceImpl) ValidateProjectHierarchy(ctx context.Context,
 }
 
 // GetProjectHierarchy 获取项目层级结构
+// 沿父项目链向上计算层级深度，父子关系成环或深度超过 MaxProjectDescendantDepth 时返回错误
 func (s *ProjectDomainServiceImpl) GetProjectHierarchy(ctx context.Context, projectID valueobject.ProjectID) (*ProjectHierarchy, error) {
 	// 1. 获取当前项目
 	project, err := s.projectRepo.FindByID(ctx, projectID)
@@ -140,20 +157,41 @@ func (s *ProjectDomainServiceImpl) GetProjectHierarchy(ctx context.Context, proj
 		TotalProjects: 1,
 	}
 
-	// 2. 获取父项目
-	if project.ParentID != nil {
-		parent, err := s.projectRepo.FindByID(ctx, *project.ParentID)
-		if err == nil {
-			hierarchy.Parent = parent
-			hierarchy.Depth = 1
+	// 2. 获取父项目，并沿祖先链计算深度；数据库未约束层级，需防止异常数据导致死循环
+	visited := map[valueobject.ProjectID]bool{project.ID: true}
+	for current := project; current.ParentID != nil; {
+		parentID := *current.ParentID
+		if visited[parentID] {
+			return nil, event.NewDomainError(event.ErrInvalidState,
+				fmt.Sprintf("project hierarchy of %s contains a cycle at %s", projectID, parentID))
+		}
+		if hierarchy.Depth >= MaxProjectDescendantDepth {
+			return nil, event.NewDomainError(event.ErrInvalidState,
+				fmt.Sprintf("project hierarchy of %s exceeds maximum depth %d", projectID, MaxProjectDescendantDepth))
+		}
+		ancestor, err := s.projectRepo.FindByID(ctx, parentID)
+		if err != nil {
+			break
+		}
+		visited[parentID] = true
+		if hierarchy.Parent == nil {
+			hierarchy.Parent = ancestor
 			hierarchy.TotalProjects++
 		}
+		hierarchy.Depth++
+		current = ancestor
 	}
 
-	// 3. 获取子项目
+	// 3. 获取子项目，子项目不能是自身或祖先项目
 	if len(project.Children) > 0 {
 		children, err := s.projectRepo.FindByIDs(ctx, project.Children)
 		if err == nil {
+			for _, child := range children {
+				if visited[child.ID] {
+					return nil, event.NewDomainError(event.ErrInvalidState,
+						fmt.Sprintf("project hierarchy of %s contains a cycle at %s", projectID, child.ID))
+				}
+			}
 			hierarchy.Children = children
 			hierarchy.TotalProjects += len(children)
 		}
@@ -162,6 +200,103 @@ func (s *ProjectDomainServiceImpl) GetProjectHierarchy(ctx context.Context, proj
 	return hierarchy, nil
 }
 
+// GetProjectDescendants 逐层收集项目的全部后代项目，按层级顺序平铺返回
+// maxDepth 不大于0或超过 MaxProjectDescendantDepth 时按 MaxProjectDescendantDepth 处理
+func (s *ProjectDomainServiceImpl) GetProjectDescendants(ctx context.Context, projectID valueobject.ProjectID, maxDepth int) ([]ProjectDescendant, error) {
+	if _, err := s.projectRepo.FindByID(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("failed to find project: %w", err)
+	}
+
+	if maxDepth <= 0 || maxDepth > MaxProjectDescendantDepth {
+		maxDepth = MaxProjectDescendantDepth
+	}
+
+	descendants := make([]ProjectDescendant, 0)
+	visited := map[valueobject.ProjectID]bool{projectID: true}
+	level := []valueobject.ProjectID{projectID}
+	for depth := 1; depth <= maxDepth && len(level) > 0; depth++ {
+		var next []valueobject.ProjectID
+		for _, parentID := range level {
+			children, err := s.projectRepo.FindByParent(ctx, parentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find sub projects: %w", err)
+			}
+			for _, child := range children {
+				// 跳过已访问的项目，避免父子关系成环时重复收集
+				if visited[child.ID] {
+					continue
+				}
+				visited[child.ID] = true
+				descendants = append(descendants, ProjectDescendant{Project: child, Depth: depth})
+				next = append(next, child.ID)
+			}
+		}
+		level = next
+	}
+
+	return descendants, nil
+}
+
+// ValidateProjectReparent 验证项目可以移动到新的父项目下
+// 新父项目不能是项目自身，也不能是项目的任一后代项目，否则会形成循环
+// 沿新父项目的祖先链向上查找项目自身，不受 MaxProjectDescendantDepth 限制
+func (s *ProjectDomainServiceImpl) ValidateProjectReparent(ctx context.Context, projectID, newParentID valueobject.ProjectID) error {
+	if projectID == newParentID {
+		return fmt.Errorf("project cannot be parent of itself")
+	}
+
+	current, err := s.projectRepo.FindByID(ctx, newParentID)
+	if err != nil {
+		return fmt.Errorf("parent project not found: %w", err)
+	}
+
+	visited := map[valueobject.ProjectID]bool{}
+	for {
+		if current.ID == projectID {
+			return fmt.Errorf("project %s is a descendant of %s, reparenting would create a cycle", newParentID, projectID)
+		}
+		// 数据库未约束层级，已有的异常环不能导致死循环
+		if visited[current.ID] {
+			return event.NewDomainError(event.ErrInvalidState,
+				fmt.Sprintf("project hierarchy of %s contains a cycle at %s", newParentID, current.ID))
+		}
+		visited[current.ID] = true
+		if current.ParentID == nil {
+			return nil
+		}
+		current, err = s.projectRepo.FindByID(ctx, *current.ParentID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find ancestor project: %w", err)
+		}
+	}
+}
+
+// ValidateSiblingNameUnique 验证项目名称在同一父项目下未删除的子项目中唯一（忽略大小写和首尾空格）
+// excludeID 为正在更新的项目自身，创建时传空
+func (s *ProjectDomainServiceImpl) ValidateSiblingNameUnique(ctx context.Context, parentID valueobject.ProjectID, name string, excludeID valueobject.ProjectID) error {
+	siblings, err := s.projectRepo.FindByParent(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to find sub projects: %w", err)
+	}
+
+	name = strings.TrimSpace(name)
+	for _, sibling := range siblings {
+		// 已删除项目的名称可以复用
+		if sibling.ID == excludeID || sibling.DeletedAt != nil {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(sibling.Name), name) {
+			return event.NewDomainError(event.ErrProjectNameConflict,
+				fmt.Sprintf("project name %q already used by sub project %s under %s", name, sibling.ID, parentID))
+		}
+	}
+
+	return nil
+}
+
 // CanUserAccessProject 检查用户是否可以访问项目
 func (s *ProjectDomainServiceImpl) CanUserAccessProject(ctx context.Context, projectID valueobject.ProjectID, userID valueobject.UserID) (bool, error) {
 	project, err := s.projectRepo.FindByID(ctx, projectID)
@@ -198,6 +333,39 @@ func (s *ProjectDomainServiceImpl) GetUserProjectRole(ctx context.Context, proje
 
 // ValidateMemberAddition 验证成员添加
 func (s *ProjectDomainServiceImpl) ValidateMemberAddition(ctx context.Context, projectID valueobject.ProjectID, userID valueobject.UserID, role valueobject.ProjectRole) error {
+	if err := s.validateMemberCandidate(ctx, projectID, userID); err != nil {
+		return err
+	}
+
+	// 检查角色有效性，管理者只能通过分配管理者加入
+	validRoles := []valueobject.ProjectRole{
+		valueobject.ProjectRoleMember,
+		valueobject.ProjectRoleDeveloper,
+		valueobject.ProjectRoleTester,
+	}
+
+	roleValid := false
+	for _, validRole := range validRoles {
+		if role == validRole {
+			roleValid = true
+			break
+		}
+	}
+
+	if !roleValid {
+		return fmt.Errorf("invalid project role: %s", role)
+	}
+
+	return nil
+}
+
+// ValidateManagerAssignment 验证管理者分配，管理者不是成员时会被自动加入，需与成员添加执行相同的检查
+func (s *ProjectDomainServiceImpl) ValidateManagerAssignment(ctx context.Context, projectID valueobject.ProjectID, managerID valueobject.UserID) error {
+	return s.validateMemberCandidate(ctx, projectID, managerID)
+}
+
+// validateMemberCandidate 检查用户存在且有效，项目及父项目允许加入成员
+func (s *ProjectDomainServiceImpl) validateMemberCandidate(ctx context.Context, projectID valueobject.ProjectID, userID valueobject.UserID) error {
 	// 1. 检查用户是否存在
 	user, err := s.userRepo.FindByID(ctx, string(userID))
 	if err != nil {
@@ -220,23 +388,15 @@ func (s *ProjectDomainServiceImpl) ValidateMemberAddition(ctx context.Context, p
 		return fmt.Errorf("cannot add members to completed or cancelled project")
 	}
 
-	// 5. 检查角色有效性
-	validRoles := []valueobject.ProjectRole{
-		valueobject.ProjectRoleMember,
-		valueobject.ProjectRoleDeveloper,
-		valueobject.ProjectRoleTester,
-	}
-
-	roleValid := false
-	for _, validRole := range validRoles {
-		if role == validRole {
-			roleValid = true
-			break
+	// 5. 子项目需检查父项目状态
+	if project.ParentID != nil {
+		parentProject, err := s.projectRepo.FindByID(ctx, *project.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find parent project: %w", err)
+		}
+		if parentProject.Status != valueobject.ProjectStatusActive {
+			return fmt.Errorf("cannot add members to sub project: parent project is %s", parentProject.Status)
 		}
-	}
-
-	if !roleValid {
-		return fmt.Errorf("invalid project role: %s", role)
 	}
 
 	return nil
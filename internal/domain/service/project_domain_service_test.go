@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+// MockProjectRepository 项目仓储Mock，未覆盖的方法由嵌入接口提供
+type MockProjectRepository struct {
+	mock.Mock
+	repository.ProjectRepository
+}
+
+func (m *MockProjectRepository) FindByID(ctx context.Context, id valueobject.ProjectID) (*aggregate.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*aggregate.Project), args.Error(1)
+}
+
+// MockUserRepository 用户仓储Mock，未覆盖的方法由嵌入接口提供
+type MockUserRepository struct {
+	mock.Mock
+	repository.UserRepository
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id string) (*aggregate.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*aggregate.User), args.Error(1)
+}
+
+func newSubProjectFixture(parentStatus valueobject.ProjectStatus) (*aggregate.Project, *aggregate.Project) {
+	parent := aggregate.NewProject("parent-1", "Parent", "", valueobject.ProjectTypeMaster, "owner-1")
+	parent.Status = parentStatus
+
+	parentID := parent.ID
+	sub := aggregate.NewProject("sub-1", "Sub", "", valueobject.ProjectTypeSub, "owner-1")
+	sub.ParentID = &parentID
+	sub.Status = valueobject.ProjectStatusActive
+
+	return parent, sub
+}
+
+func TestValidateMemberAddition_SubProjectWithCompletedParent(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := new(MockProjectRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewProjectDomainService(projectRepo, userRepo)
+
+	parent, sub := newSubProjectFixture(valueobject.ProjectStatusCompleted)
+	user := aggregate.NewUser("user-1", "user1", "user1@example.com", "User One", "hash", valueobject.UserRoleEmployee)
+
+	userRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+	projectRepo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+	projectRepo.On("FindByID", ctx, parent.ID).Return(parent, nil)
+
+	err := svc.ValidateMemberAddition(ctx, sub.ID, user.ID, valueobject.ProjectRoleMember)
+
+	assert.Error(t, err)
+	projectRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestValidateMemberAddition_SubProjectWithActiveParent(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := new(MockProjectRepository)
+	userRepo := new(MockUserRepository)
+	svc := NewProjectDomainService(projectRepo, userRepo)
+
+	parent, sub := newSubProjectFixture(valueobject.ProjectStatusActive)
+	user := aggregate.NewUser("user-1", "user1", "user1@example.com", "User One", "hash", valueobject.UserRoleEmployee)
+
+	userRepo.On("FindByID", ctx, "user-1").Return(user, nil)
+	projectRepo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+	projectRepo.On("FindByID", ctx, parent.ID).Return(parent, nil)
+
+	err := svc.ValidateMemberAddition(ctx, sub.ID, user.ID, valueobject.ProjectRoleMember)
+
+	assert.NoError(t, err)
+	projectRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+// newChildProject 创建挂在指定父项目下的子项目
+func newChildProject(id valueobject.ProjectID, parentID valueobject.ProjectID) aggregate.Project {
+	child := aggregate.NewProject(id, string(id), "", valueobject.ProjectTypeSub, "owner-1")
+	child.ParentID = &parentID
+	return *child
+}
+
+func TestGetProjectDescendants_TwoLevelTree(t *testing.T) {
+	ctx := context.Background()
+	root := aggregate.NewProject("root", "Root", "", valueobject.ProjectTypeMaster, "owner-1")
+	other := aggregate.NewProject("other", "Other", "", valueobject.ProjectTypeMaster, "owner-1")
+	projectRepo := testutil.NewMemoryProjectRepository(
+		*root,
+		*other,
+		newChildProject("sub-a", "root"),
+		newChildProject("sub-b", "root"),
+		newChildProject("sub-a-1", "sub-a"),
+		newChildProject("other-sub", "other"),
+	)
+	svc := NewProjectDomainService(projectRepo, new(MockUserRepository))
+
+	descendants, err := svc.GetProjectDescendants(ctx, "root", 0)
+
+	require.NoError(t, err)
+	depths := make(map[valueobject.ProjectID]int, len(descendants))
+	for _, d := range descendants {
+		depths[d.Project.ID] = d.Depth
+	}
+	assert.Equal(t, map[valueobject.ProjectID]int{"sub-a": 1, "sub-b": 1, "sub-a-1": 2}, depths)
+}
+
+func TestGetProjectDescendants_DepthCapStopsRecursion(t *testing.T) {
+	ctx := context.Background()
+	root := aggregate.NewProject("p0", "p0", "", valueobject.ProjectTypeMaster, "owner-1")
+	projects := []aggregate.Project{*root}
+	for i := 1; i <= MaxProjectDescendantDepth+5; i++ {
+		id := valueobject.ProjectID(fmt.Sprintf("p%d", i))
+		projects = append(projects, newChildProject(id, valueobject.ProjectID(fmt.Sprintf("p%d", i-1))))
+	}
+	// 异常数据：根项目又挂在最深的项目下，形成环
+	deepest := valueobject.ProjectID(fmt.Sprintf("p%d", MaxProjectDescendantDepth+5))
+	projects[0].ParentID = &deepest
+	svc := NewProjectDomainService(testutil.NewMemoryProjectRepository(projects...), new(MockUserRepository))
+
+	limited, err := svc.GetProjectDescendants(ctx, "p0", 2)
+	require.NoError(t, err)
+	capped, err := svc.GetProjectDescendants(ctx, "p0", 1000)
+	require.NoError(t, err)
+
+	require.Len(t, limited, 2)
+	assert.Equal(t, valueobject.ProjectID("p2"), limited[1].Project.ID)
+	require.Len(t, capped, MaxProjectDescendantDepth, "depth must be capped at MaxProjectDescendantDepth")
+	assert.Equal(t, MaxProjectDescendantDepth, capped[len(capped)-1].Depth)
+}
+
+func TestGetProjectHierarchy_DepthCountsAncestors(t *testing.T) {
+	root := aggregate.NewProject("root", "Root", "", valueobject.ProjectTypeMaster, "owner-1")
+	svc := NewProjectDomainService(testutil.NewMemoryProjectRepository(
+		*root,
+		newChildProject("sub", "root"),
+		newChildProject("sub-1", "sub"),
+	), new(MockUserRepository))
+
+	hierarchy, err := svc.GetProjectHierarchy(context.Background(), "sub-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, hierarchy.Depth)
+	require.NotNil(t, hierarchy.Parent)
+	assert.Equal(t, valueobject.ProjectID("sub"), hierarchy.Parent.ID)
+	assert.Equal(t, 2, hierarchy.TotalProjects)
+}
+
+func TestGetProjectHierarchy_CyclicParentChainFails(t *testing.T) {
+	// 异常数据：a -> b -> c -> a
+	svc := NewProjectDomainService(testutil.NewMemoryProjectRepository(
+		newChildProject("a", "b"),
+		newChildProject("b", "c"),
+		newChildProject("c", "a"),
+	), new(MockUserRepository))
+
+	_, err := svc.GetProjectHierarchy(context.Background(), "a")
+
+	var domainErr *event.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, event.ErrInvalidState, domainErr.Type)
+	assert.Contains(t, err.Error(), "cycle")
+}
+
+func TestGetProjectHierarchy_ChildThatIsAncestorFails(t *testing.T) {
+	root := aggregate.NewProject("root", "Root", "", valueobject.ProjectTypeMaster, "owner-1")
+	sub := newChildProject("sub", "root")
+	sub.Children = []valueobject.ProjectID{"root"}
+	svc := NewProjectDomainService(testutil.NewMemoryProjectRepository(*root, sub), new(MockUserRepository))
+
+	_, err := svc.GetProjectHierarchy(context.Background(), "sub")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cycle")
+}
+
+func TestGetProjectHierarchy_DepthCapFails(t *testing.T) {
+	root := aggregate.NewProject("p0", "p0", "", valueobject.ProjectTypeMaster, "owner-1")
+	projects := []aggregate.Project{*root}
+	for i := 1; i <= MaxProjectDescendantDepth+1; i++ {
+		id := valueobject.ProjectID(fmt.Sprintf("p%d", i))
+		projects = append(projects, newChildProject(id, valueobject.ProjectID(fmt.Sprintf("p%d", i-1))))
+	}
+	svc := NewProjectDomainService(testutil.NewMemoryProjectRepository(projects...), new(MockUserRepository))
+
+	_, err := svc.GetProjectHierarchy(context.Background(), valueobject.ProjectID(fmt.Sprintf("p%d", MaxProjectDescendantDepth+1)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum depth")
+
+	hierarchy, err := svc.GetProjectHierarchy(context.Background(), valueobject.ProjectID(fmt.Sprintf("p%d", MaxProjectDescendantDepth)))
+	require.NoError(t, err)
+	assert.Equal(t, MaxProjectDescendantDepth, hierarchy.Depth)
+}
+
+func TestValidateProjectReparent_DetectsCycleBeyondDepthCap(t *testing.T) {
+	root := aggregate.NewProject("p0", "p0", "", valueobject.ProjectTypeMaster, "owner-1")
+	projects := []aggregate.Project{*root}
+	for i := 1; i <= MaxProjectDescendantDepth+5; i++ {
+		id := valueobject.ProjectID(fmt.Sprintf("p%d", i))
+		projects = append(projects, newChildProject(id, valueobject.ProjectID(fmt.Sprintf("p%d", i-1))))
+	}
+	svc := NewProjectDomainService(testutil.NewMemoryProjectRepository(projects...), new(MockUserRepository))
+	deepest := valueobject.ProjectID(fmt.Sprintf("p%d", MaxProjectDescendantDepth+5))
+
+	err := svc.ValidateProjectReparent(context.Background(), "p0", deepest)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cycle")
+
+	// 移动到自己的祖先项目下不会形成环
+	require.NoError(t, svc.ValidateProjectReparent(context.Background(), deepest, "p1"))
+}
+
+func TestGetProjectDescendants_UnknownProject(t *testing.T) {
+	svc := NewProjectDomainService(testutil.NewMemoryProjectRepository(), new(MockUserRepository))
+
+	_, err := svc.GetProjectDescendants(context.Background(), "missing", 0)
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
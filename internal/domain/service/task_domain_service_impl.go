@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/repository"
 	"github.com/taskflow/internal/domain/valueobject"
 )
@@ -29,28 +30,23 @@ func NewTaskDomainService(
 	}
 }
 
-// ValidateTaskCreation 验证任务创建
-func (s *TaskDomainServiceImpl) ValidateTaskCreation(task aggregate.TaskAggregate, createdBy valueobject.UserID) error {
-	// 1. 验证创建者权限
-	if !s.CanUserCreateTaskInProject(createdBy, task.ProjectID) {
-		return fmt.Errorf("user does not have permission to create task in project")
+// ValidateTaskCreation 验证项目是否接受新任务
+// 已完成、已取消或已删除的项目不能创建任务，创建者必须能访问项目
+func (s *TaskDomainServiceImpl) ValidateTaskCreation(ctx context.Context, projectID valueobject.ProjectID, createdBy valueobject.UserID) error {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
 	}
 
-	// 2. 验证负责人存在
-	if task.ResponsibleID != "" {
-		_, err := s.userRepo.FindByID(context.Background(), string(task.ResponsibleID))
-		if err != nil {
-			return fmt.Errorf("responsible user not found: %w", err)
-		}
+	if project.DeletedAt != nil {
+		return event.NewDomainError(event.ErrInvalidState, "cannot create task in deleted project")
 	}
-
-	// 3. 验证项目存在且活跃
-	project, err := s.projectRepo.FindByID(context.Background(), task.ProjectID)
-	if err != nil {
-		return fmt.Errorf("project not found: %w", err)
+	if project.Status == valueobject.ProjectStatusCompleted || project.Status == valueobject.ProjectStatusCancelled {
+		return event.NewDomainError(event.ErrInvalidState, fmt.Sprintf("cannot create task in %s project", project.Status))
 	}
-	if project.Status != valueobject.ProjectStatusActive {
-		return fmt.Errorf("cannot create task in inactive project")
+
+	if !project.CanUserAccess(createdBy) {
+		return event.NewDomainError(event.ErrPermissionDenied, "user does not have permission to create task in project")
 	}
 
 	return nil
@@ -81,7 +77,7 @@ func (s *TaskDomainServiceImpl) ValidateTaskAssignment(task aggregate.TaskAggreg
 func (s *TaskDomainServiceImpl) ValidateParticipantAddition(task aggregate.TaskAggregate, participantID valueobject.UserID, addedBy valueobject.UserID) error {
 	// 1. 验证添加者权限
 	if !s.CanUserManageTask(addedBy, task) {
-		return fmt.Errorf("user does not have permission to add participant")
+		return event.NewDomainError(event.ErrPermissionDenied, "user does not have permission to add participant")
 	}
 
 	// 2. 验证参与者存在
@@ -134,13 +130,18 @@ func (s *TaskDomainServiceImpl) ValidateTaskCompletion(task aggregate.TaskAggreg
 		return fmt.Errorf("user does not have permission to complete task")
 	}
 
-	// 2. 验证任务状态
+	// 2. 验证任务状态，已暂停的任务需先恢复
+	if task.Status == valueobject.TaskStatusPaused {
+		return aggregate.ErrTaskPausedResumeFirst
+	}
 	if task.Status != valueobject.TaskStatusInProgress {
 		return fmt.Errorf("only in-progress tasks can be completed")
 	}
 
-	// 3. 验证所有必要工作已提交（简化实现）
-	// 实际实现中应该检查工作提交和审核状态
+	// 3. 验证参与者的工作均已审核通过
+	if outstanding := task.OutstandingParticipants(); len(outstanding) > 0 {
+		return fmt.Errorf("%w: %v", aggregate.ErrParticipantWorkPending, outstanding)
+	}
 
 	return nil
 }
@@ -212,6 +213,16 @@ func (s *TaskDomainServiceImpl) CanUserCreateTaskInProject(userID valueobject.Us
 	return project.CanUserAccess(userID)
 }
 
+// IsProjectMember 检查用户是否为任务所属项目的成员
+func (s *TaskDomainServiceImpl) IsProjectMember(ctx context.Context, userID valueobject.UserID, projectID valueobject.ProjectID) bool {
+	project, err := s.projectRepo.FindByID(ctx, projectID)
+	if err != nil {
+		return false
+	}
+
+	return project.CanUserAccess(userID)
+}
+
 // CanUserManageTask 检查用户是否可以管理任务
 func (s *TaskDomainServiceImpl) CanUserManageTask(userID valueobject.UserID, task aggregate.TaskAggregate) bool {
 	return task.CanUserModify(userID)
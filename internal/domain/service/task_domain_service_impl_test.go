@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+func TestValidateTaskCreation_ProjectState(t *testing.T) {
+	deletedAt := time.Now()
+	tests := []struct {
+		name      string
+		status    valueobject.ProjectStatus
+		deletedAt *time.Time
+		wantErr   bool
+	}{
+		{"active", valueobject.ProjectStatusActive, nil, false},
+		{"draft", valueobject.ProjectStatusDraft, nil, false},
+		{"paused", valueobject.ProjectStatusPaused, nil, false},
+		{"completed", valueobject.ProjectStatusCompleted, nil, true},
+		{"cancelled", valueobject.ProjectStatusCancelled, nil, true},
+		{"deleted", valueobject.ProjectStatusActive, &deletedAt, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+			project.Status = tt.status
+			project.DeletedAt = tt.deletedAt
+			svc := NewTaskDomainService(nil, nil, testutil.NewMemoryProjectRepository(*project))
+
+			err := svc.ValidateTaskCreation(context.Background(), "project-1", "owner-1")
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var domainErr *event.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, event.ErrInvalidState, domainErr.Type)
+		})
+	}
+}
+
+func TestValidateTaskCreation_RequiresAccessAndExistingProject(t *testing.T) {
+	ctx := context.Background()
+	project := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	project.Status = valueobject.ProjectStatusActive
+	svc := NewTaskDomainService(nil, nil, testutil.NewMemoryProjectRepository(*project))
+
+	var domainErr *event.DomainError
+	require.ErrorAs(t, svc.ValidateTaskCreation(ctx, "project-1", "outsider-1"), &domainErr)
+	assert.Equal(t, event.ErrPermissionDenied, domainErr.Type)
+
+	assert.ErrorIs(t, svc.ValidateTaskCreation(ctx, "missing", "owner-1"), repository.ErrNotFound)
+}
@@ -10,7 +10,7 @@ import (
 // TaskDomainService 任务领域服务接口
 type TaskDomainService interface {
 	// 业务规则验证
-	ValidateTaskCreation(task aggregate.TaskAggregate, createdBy valueobject.UserID) error
+	ValidateTaskCreation(ctx context.Context, projectID valueobject.ProjectID, createdBy valueobject.UserID) error
 	ValidateTaskAssignment(task aggregate.TaskAggregate, responsibleID valueobject.UserID, assignedBy valueobject.UserID) error
 	ValidateParticipantAddition(task aggregate.TaskAggregate, participantID valueobject.UserID, addedBy valueobject.UserID) error
 	ValidateStatusTransition(task aggregate.TaskAggregate, fromStatus, toStatus valueobject.TaskStatus, changedBy valueobject.UserID) error
@@ -23,6 +23,7 @@ type TaskDomainService interface {
 
 	// 权限相关
 	CanUserCreateTaskInProject(userID valueobject.UserID, projectID valueobject.ProjectID) bool
+	IsProjectMember(ctx context.Context, userID valueobject.UserID, projectID valueobject.ProjectID) bool
 	CanUserManageTask(userID valueobject.UserID, task aggregate.TaskAggregate) bool
 	GetUserTaskPermissions(userID valueobject.UserID, task aggregate.TaskAggregate) valueobject.TaskPermissions
 
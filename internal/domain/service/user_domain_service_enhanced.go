@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -43,6 +44,7 @@ func NewUserDomainServiceEnhanced(
 }
 
 // ValidateUserCreation 验证用户创建（增强版）
+// 违反唯一性规则时返回 *valueobject.ValidationError，包含全部违规字段
 func (s *UserDomainServiceEnhanced) ValidateUserCreation(ctx context.Context, email, username string) error {
 	// validationContext := valueobject.UserValidationContext{
 	// 	Operation:   "create_user",
@@ -57,9 +59,12 @@ func (s *UserDomainServiceEnhanced) ValidateUserCreation(ctx context.Context, em
 
 	// 检查邮箱是否已存在
 	existingUser, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("检查邮箱失败: %w", err)
+	}
 	if err == nil && existingUser != nil {
 		violations = append(violations, valueobject.ValidationViolation{
-			RuleID:   "email_unique",
+			RuleID:   valueobject.RuleEmailUnique,
 			RuleName: "邮箱唯一性",
 			Field:    "email",
 			Value:    email,
@@ -70,15 +75,22 @@ func (s *UserDomainServiceEnhanced) ValidateUserCreation(ctx context.Context, em
 
 	// 检查用户名是否已存在
 	existingUser, err = s.userRepo.FindByUsername(ctx, username)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("检查用户名失败: %w", err)
+	}
 	if err == nil && existingUser != nil {
 		violations = append(violations, valueobject.ValidationViolation{
-			Field:   "username",
-			Message: "用户名已存在",
+			RuleID:   valueobject.RuleUsernameUnique,
+			RuleName: "用户名唯一性",
+			Field:    "username",
+			Value:    username,
+			Message:  fmt.Sprintf("用户名已存在: %s", username),
+			Severity: valueobject.ValidationSeverityError,
 		})
 	}
 
 	if len(violations) > 0 {
-		return fmt.Errorf("用户创建验证失败: %d个违规", len(violations))
+		return &valueobject.ValidationError{Operation: "用户创建", Violations: violations}
 	}
 
 	return nil
@@ -356,8 +368,5 @@ func (s *UserDomainServiceEnhanced) canDeactivateUser(operatorRole, targetRole v
 
 // generateEventID 生成事件ID
 func generateEventID() string {
-	// 使用时间戳和随机数生成唯一ID
-	// 在生产环境中建议使用UUID库如github.com/google/uuid
-	timestamp := time.Now().UnixNano()
-	return fmt.Sprintf("event_%d_%d", timestamp, timestamp%1000000)
+	return event.GenerateEventID()
 }
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+)
+
+func TestValidateUserCreation_ReturnsViolationPerField(t *testing.T) {
+	existing := aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee)
+	svc := NewUserDomainServiceEnhanced(testutil.NewMemoryUserRepository(existing), nil, nil, nil, nil, nil)
+
+	err := svc.ValidateUserCreation(context.Background(), "alice@example.com", "alice")
+
+	var validationErr *valueobject.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 2)
+	assert.Equal(t, "email", validationErr.Violations[0].Field)
+	assert.Equal(t, "email_unique", validationErr.Violations[0].RuleID)
+	assert.Equal(t, "username", validationErr.Violations[1].Field)
+	assert.Equal(t, "username_unique", validationErr.Violations[1].RuleID)
+	for _, v := range validationErr.Violations {
+		assert.Equal(t, valueobject.ValidationSeverityError, v.Severity)
+		assert.NotEmpty(t, v.Message)
+	}
+}
+
+func TestValidateUserCreation_UniqueUserPasses(t *testing.T) {
+	existing := aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee)
+	svc := NewUserDomainServiceEnhanced(testutil.NewMemoryUserRepository(existing), nil, nil, nil, nil, nil)
+
+	assert.NoError(t, svc.ValidateUserCreation(context.Background(), "bob@example.com", "bob"))
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/taskflow/internal/domain/aggregate"
@@ -34,6 +35,9 @@ func NewUserDomainService(
 func (s *UserDomainServiceImpl) ValidateUserCreation(ctx context.Context, email, username string) error {
 	// 检查邮箱是否已存在
 	existingUser, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to check email: %w", err)
+	}
 	if err == nil && existingUser != nil {
 		return fmt.Errorf("email already exists: %s", email)
 	}
@@ -0,0 +1,17 @@
+package shared
+
+import "context"
+
+// actorKey 当前操作用户上下文键
+const actorKey contextKey = "actor"
+
+// WithActor 将执行当前操作的认证用户写入上下文，仓储据此记录修改人
+func WithActor(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorKey, userID)
+}
+
+// ActorFromContext 从上下文获取当前操作用户，后台任务等未认证的调用为空
+func ActorFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(actorKey).(string)
+	return userID
+}
@@ -0,0 +1,17 @@
+package shared
+
+import "context"
+
+// requestIDKey 请求ID上下文键
+const requestIDKey contextKey = "request_id"
+
+// WithRequestID 将请求ID写入上下文，用于关联同一请求产生的日志
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext 从上下文获取请求ID，未设置时为空
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"context"
+	"time"
+
+	_ "time/tzdata" // 内嵌时区数据库，避免容器缺少 zoneinfo
+)
+
+// 时间策略：所有持久化的时间统一为UTC，仅在响应时按用户时区渲染
+
+// timezoneKey 用户时区上下文键
+const timezoneKey contextKey = "user_timezone"
+
+// NowUTC 获取当前UTC时间
+func NowUTC() time.Time {
+	return time.Now().UTC()
+}
+
+// ToUTC 将时间转换为UTC，零值保持不变
+func ToUTC(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.UTC()
+}
+
+// ToUTCPtr 将可选时间转换为UTC
+func ToUTCPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := ToUTC(*t)
+	return &utc
+}
+
+// LoadLocation 解析时区名称，无效或为空时回退到UTC
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsValidTimezone 检查时区名称是否有效
+func IsValidTimezone(name string) bool {
+	if name == "" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// WithTimezone 将用户时区写入上下文
+func WithTimezone(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, timezoneKey, name)
+}
+
+// LocationFromContext 从上下文获取用户时区，未设置时为UTC
+func LocationFromContext(ctx context.Context) *time.Location {
+	name, _ := ctx.Value(timezoneKey).(string)
+	return LoadLocation(name)
+}
+
+// InLocation 按时区渲染可选时间
+func InLocation(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.In(loc)
+	return &local
+}
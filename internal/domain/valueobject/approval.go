@@ -92,6 +92,14 @@ type ApprovalStep struct {
 	DelegatedTo *UserID          `json:"delegated_to,omitempty"`
 }
 
+// CanBeProcessedBy 步骤审批人或被委托人可以处理该步骤
+func (s ApprovalStep) CanBeProcessedBy(userID UserID) bool {
+	if s.DelegatedTo != nil {
+		return *s.DelegatedTo == userID
+	}
+	return s.ApproverID == userID
+}
+
 // ApprovalHistory 审批历史记录
 type ApprovalHistory struct {
 	ID          string          `json:"id"`
@@ -199,6 +207,33 @@ type ApprovalSummary struct {
 	DueDate     *time.Time       `json:"due_date,omitempty"`
 }
 
+// SubmittedApprovalKind 用户提交的审批类别，审批由任务创建审批、工作提交审核和延期申请汇总而来
+type SubmittedApprovalKind string
+
+const (
+	SubmittedApprovalTaskCreation     SubmittedApprovalKind = "task_creation"     // 任务创建审批
+	SubmittedApprovalTaskCompletion   SubmittedApprovalKind = "task_completion"   // 工作提交审核
+	SubmittedApprovalExtensionRequest SubmittedApprovalKind = "extension_request" // 延期申请审批
+)
+
+// SubmittedApprovalCriteria 用户提交的审批查询条件，Kind 和 Status 为空时不过滤
+type SubmittedApprovalCriteria struct {
+	RequesterID UserID
+	Kind        SubmittedApprovalKind
+	Status      ApprovalStatus
+	Limit       int
+	Offset      int
+}
+
+// SubmittedApproval 用户提交的一条审批记录，标题等展示信息从关联任务读取
+type SubmittedApproval struct {
+	ID          string
+	Kind        SubmittedApprovalKind
+	TaskID      TaskID
+	Status      ApprovalStatus
+	SubmittedAt time.Time
+}
+
 // ApprovalDetailResponse 审批详细信息响应
 type ApprovalDetailResponse struct {
 	ID           string                 `json:"id"`
@@ -244,12 +279,3 @@ type ApprovalStatistics struct {
 	AverageProcessTime float64 `json:"average_process_time"` // 小时
 	OverdueCount       int     `json:"overdue_count"`
 }
-
-// NotificationSettings 通知设置
-type NotificationSettings struct {
-	EmailEnabled    bool `json:"email_enabled"`
-	SMSEnabled      bool `json:"sms_enabled"`
-	PushEnabled     bool `json:"push_enabled"`
-	ReminderHours   int  `json:"reminder_hours"`   // 提醒间隔小时
-	EscalationHours int  `json:"escalation_hours"` // 升级间隔小时
-}
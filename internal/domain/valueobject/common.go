@@ -181,6 +181,9 @@ type IDGenerator interface {
 	GenerateUserID() UserID
 	GenerateProjectID() ProjectID
 	GenerateTaskID() TaskID
+	GenerateExtensionRequestID() ExtensionRequestID
+	GenerateTaskExecutionID() TaskExecutionID
+	GenerateWorkSubmissionID() WorkSubmissionID
 }
 
 // 通用验证器接口
@@ -211,3 +214,24 @@ func BuildUserCacheKey(userID UserID) CacheKey {
 func BuildUserRolesCacheKey(userID UserID) CacheKey {
 	return CacheKey(string(UserRolesCacheKey) + string(userID))
 }
+
+// BuildUserPermissionsCacheKey 构建用户权限缓存键
+func BuildUserPermissionsCacheKey(userID UserID) CacheKey {
+	return CacheKey(string(UserPermissionsCacheKey) + string(userID))
+}
+
+// ProjectCacheKeys 项目相关缓存键
+const (
+	ProjectCacheKeyPrefix     CacheKey = "project:"
+	ProjectMemberRoleCacheKey CacheKey = "project:member_role:"
+)
+
+// BuildProjectCacheKey 构建项目缓存键
+func BuildProjectCacheKey(projectID ProjectID) CacheKey {
+	return CacheKey(string(ProjectCacheKeyPrefix) + string(projectID))
+}
+
+// BuildProjectMemberRoleCacheKey 构建项目成员角色缓存键
+func BuildProjectMemberRoleCacheKey(projectID ProjectID, userID UserID) CacheKey {
+	return CacheKey(string(ProjectMemberRoleCacheKey) + string(projectID) + ":" + string(userID))
+}
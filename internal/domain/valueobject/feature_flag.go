@@ -0,0 +1,61 @@
+package valueobject
+
+// FeatureFlag 功能开关名称
+type FeatureFlag string
+
+const (
+	FeatureCascadePause      FeatureFlag = "cascade_pause"      // 暂停项目时级联暂停进行中的任务
+	FeatureDeadlineReminders FeatureFlag = "deadline_reminders" // 按提醒梯度发送截止日期提醒
+)
+
+// KnownFeatureFlags 已定义的功能开关，按名称排序
+var KnownFeatureFlags = []FeatureFlag{FeatureCascadePause, FeatureDeadlineReminders}
+
+// ProductionEnvironment 生产环境名称，未配置的功能开关在生产环境默认关闭
+const ProductionEnvironment = "production"
+
+// FeatureFlagSource 功能开关取值的来源
+type FeatureFlagSource string
+
+const (
+	FeatureFlagSourceProject     FeatureFlagSource = "project"
+	FeatureFlagSourceEnvironment FeatureFlagSource = "environment"
+	FeatureFlagSourceDefault     FeatureFlagSource = "default"
+)
+
+// FeatureFlagRule 单个功能开关的配置，按项目覆盖优先于按环境配置
+type FeatureFlagRule struct {
+	Environments map[string]bool
+	Projects     map[ProjectID]bool
+}
+
+// FeatureFlagContext 判断功能开关时的上下文，ProjectID 为空时只按环境判断
+type FeatureFlagContext struct {
+	ProjectID ProjectID
+}
+
+// FeatureFlagPolicy 当前环境下的功能开关配置
+type FeatureFlagPolicy struct {
+	Environment string
+	Rules       map[FeatureFlag]FeatureFlagRule
+}
+
+// IsEnabled 判断功能开关在上下文中是否开启
+func (p FeatureFlagPolicy) IsEnabled(flag FeatureFlag, fc FeatureFlagContext) bool {
+	enabled, _ := p.Resolve(flag, fc)
+	return enabled
+}
+
+// Resolve 返回功能开关的取值及其来源：项目覆盖、环境配置，都未配置时生产环境关闭、其他环境开启
+func (p FeatureFlagPolicy) Resolve(flag FeatureFlag, fc FeatureFlagContext) (bool, FeatureFlagSource) {
+	rule := p.Rules[flag]
+	if fc.ProjectID != "" {
+		if enabled, ok := rule.Projects[fc.ProjectID]; ok {
+			return enabled, FeatureFlagSourceProject
+		}
+	}
+	if enabled, ok := rule.Environments[p.Environment]; ok {
+		return enabled, FeatureFlagSourceEnvironment
+	}
+	return p.Environment != ProductionEnvironment, FeatureFlagSourceDefault
+}
@@ -0,0 +1,36 @@
+package valueobject
+
+import "testing"
+
+func TestFeatureFlagPolicy_ProjectOverridesEnvironment(t *testing.T) {
+	rules := map[FeatureFlag]FeatureFlagRule{
+		FeatureCascadePause: {
+			Environments: map[string]bool{"production": false, "development": true},
+			Projects:     map[ProjectID]bool{"pilot": true, "frozen": false},
+		},
+	}
+
+	tests := []struct {
+		environment string
+		flag        FeatureFlag
+		projectID   ProjectID
+		want        bool
+		wantSource  FeatureFlagSource
+	}{
+		{"production", FeatureCascadePause, "", false, FeatureFlagSourceEnvironment},
+		{"production", FeatureCascadePause, "pilot", true, FeatureFlagSourceProject},
+		{"development", FeatureCascadePause, "frozen", false, FeatureFlagSourceProject},
+		{"development", FeatureCascadePause, "other", true, FeatureFlagSourceEnvironment},
+		{"testing", FeatureCascadePause, "", true, FeatureFlagSourceDefault},
+		// 未配置的开关在生产环境默认关闭
+		{"production", FeatureDeadlineReminders, "pilot", false, FeatureFlagSourceDefault},
+		{"development", FeatureDeadlineReminders, "", true, FeatureFlagSourceDefault},
+	}
+	for _, tt := range tests {
+		policy := FeatureFlagPolicy{Environment: tt.environment, Rules: rules}
+		got, source := policy.Resolve(tt.flag, FeatureFlagContext{ProjectID: tt.projectID})
+		if got != tt.want || source != tt.wantSource {
+			t.Errorf("Resolve(%s, %q) in %s = %v/%s, want %v/%s", tt.flag, tt.projectID, tt.environment, got, source, tt.want, tt.wantSource)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package valueobject
+
+// FileUploadStatus 文件上传状态
+type FileUploadStatus string
+
+const (
+	FileUploadStatusUploading FileUploadStatus = "uploading" // 上传中
+	FileUploadStatusCompleted FileUploadStatus = "completed" // 已完成
+	FileUploadStatusFailed    FileUploadStatus = "failed"    // 上传失败
+)
+
+// 文件关联的资源类型
+const (
+	FileResourceTypeTask    = "task"
+	FileResourceTypeProject = "project"
+)
+
+// FileInfo 已上传文件的元数据
+type FileInfo struct {
+	ID           string
+	UploaderID   UserID
+	UploadStatus FileUploadStatus
+}
+
+// IsCompleted 文件是否已上传完成
+func (f FileInfo) IsCompleted() bool {
+	return f.UploadStatus == FileUploadStatusCompleted
+}
@@ -0,0 +1,42 @@
+package valueobject
+
+import "github.com/google/uuid"
+
+// UUIDGenerator 基于UUID的ID生成器
+// 所有聚合及其子实体的ID统一由此生成，避免基于时间戳的ID在高并发下冲突
+type UUIDGenerator struct{}
+
+// NewUUIDGenerator 创建UUID生成器
+func NewUUIDGenerator() *UUIDGenerator {
+	return &UUIDGenerator{}
+}
+
+// GenerateUserID 生成用户ID
+func (g *UUIDGenerator) GenerateUserID() UserID {
+	return UserID(uuid.New().String())
+}
+
+// GenerateProjectID 生成项目ID
+func (g *UUIDGenerator) GenerateProjectID() ProjectID {
+	return ProjectID(uuid.New().String())
+}
+
+// GenerateTaskID 生成任务ID
+func (g *UUIDGenerator) GenerateTaskID() TaskID {
+	return TaskID(uuid.New().String())
+}
+
+// GenerateExtensionRequestID 生成延期申请ID
+func (g *UUIDGenerator) GenerateExtensionRequestID() ExtensionRequestID {
+	return ExtensionRequestID(uuid.New().String())
+}
+
+// GenerateTaskExecutionID 生成任务执行ID
+func (g *UUIDGenerator) GenerateTaskExecutionID() TaskExecutionID {
+	return TaskExecutionID(uuid.New().String())
+}
+
+// GenerateWorkSubmissionID 生成工作提交ID
+func (g *UUIDGenerator) GenerateWorkSubmissionID() WorkSubmissionID {
+	return WorkSubmissionID(uuid.New().String())
+}
@@ -0,0 +1,52 @@
+package valueobject
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestUUIDGenerator_UniqueAcrossRapidCalls(t *testing.T) {
+	gen := NewUUIDGenerator()
+	const n = 10000
+
+	seen := make(map[string]struct{}, n*4)
+	for i := 0; i < n; i++ {
+		for _, id := range []string{
+			string(gen.GenerateTaskID()),
+			string(gen.GenerateExtensionRequestID()),
+			string(gen.GenerateTaskExecutionID()),
+			string(gen.GenerateWorkSubmissionID()),
+		} {
+			if _, dup := seen[id]; dup {
+				t.Fatalf("Duplicate id generated: %s", id)
+			}
+			seen[id] = struct{}{}
+		}
+	}
+}
+
+func TestUUIDGenerator_UniqueAcrossGoroutines(t *testing.T) {
+	gen := NewUUIDGenerator()
+	const workers, perWorker = 8, 1000
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[ProjectID]struct{}, workers*perWorker)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id := gen.GenerateProjectID()
+				mu.Lock()
+				seen[id] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != workers*perWorker {
+		t.Fatalf("Expected %d unique ids, got %d", workers*perWorker, len(seen))
+	}
+}
@@ -0,0 +1,103 @@
+package valueobject
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationChannel 通知渠道
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+	NotificationChannelPush  NotificationChannel = "push"
+)
+
+// quietHoursLayout 静默时段的时间格式
+const quietHoursLayout = "15:04"
+
+// NotificationSettings 通知设置
+type NotificationSettings struct {
+	EmailEnabled    bool   `json:"email_enabled"`
+	SMSEnabled      bool   `json:"sms_enabled"`
+	PushEnabled     bool   `json:"push_enabled"`
+	ReminderHours   int    `json:"reminder_hours"`              // 提醒间隔小时
+	EscalationHours int    `json:"escalation_hours"`            // 升级间隔小时
+	QuietHoursStart string `json:"quiet_hours_start,omitempty"` // 静默时段开始，HH:MM，按用户时区
+	QuietHoursEnd   string `json:"quiet_hours_end,omitempty"`   // 静默时段结束，HH:MM，早于开始时表示跨午夜
+}
+
+// DefaultNotificationSettings 新用户的默认通知设置：开启邮件和推送，关闭短信，不设静默时段
+func DefaultNotificationSettings() NotificationSettings {
+	return NotificationSettings{
+		EmailEnabled:    true,
+		PushEnabled:     true,
+		ReminderHours:   24,
+		EscalationHours: 48,
+	}
+}
+
+// Validate 校验小时数不为负，静默时段需同时设置开始和结束且格式为 HH:MM
+func (s NotificationSettings) Validate() error {
+	if s.ReminderHours < 0 {
+		return fmt.Errorf("reminder hours cannot be negative")
+	}
+	if s.EscalationHours < 0 {
+		return fmt.Errorf("escalation hours cannot be negative")
+	}
+	if (s.QuietHoursStart == "") != (s.QuietHoursEnd == "") {
+		return fmt.Errorf("quiet hours start and end must be set together")
+	}
+	if s.QuietHoursStart == "" {
+		return nil
+	}
+	start, err := time.Parse(quietHoursLayout, s.QuietHoursStart)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours start %q: expected HH:MM", s.QuietHoursStart)
+	}
+	end, err := time.Parse(quietHoursLayout, s.QuietHoursEnd)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours end %q: expected HH:MM", s.QuietHoursEnd)
+	}
+	if start.Equal(end) {
+		return fmt.Errorf("quiet hours start and end must differ")
+	}
+	return nil
+}
+
+// ChannelEnabled 通知渠道是否开启
+func (s NotificationSettings) ChannelEnabled(channel NotificationChannel) bool {
+	switch channel {
+	case NotificationChannelEmail:
+		return s.EmailEnabled
+	case NotificationChannelSMS:
+		return s.SMSEnabled
+	case NotificationChannelPush:
+		return s.PushEnabled
+	default:
+		return false
+	}
+}
+
+// InQuietHours 判断 t 按 loc 时区换算后是否处于静默时段，未设置静默时段时返回 false
+func (s NotificationSettings) InQuietHours(t time.Time, loc *time.Location) bool {
+	start, err := time.Parse(quietHoursLayout, s.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(quietHoursLayout, s.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from < to {
+		return minute >= from && minute < to
+	}
+	// 跨午夜，如 22:00-07:00
+	return minute >= from || minute < to
+}
@@ -0,0 +1,55 @@
+package valueobject
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationSettings_Validate(t *testing.T) {
+	valid := DefaultNotificationSettings()
+	valid.QuietHoursStart, valid.QuietHoursEnd = "22:00", "07:00"
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for name, mutate := range map[string]func(*NotificationSettings){
+		"negative reminder":   func(s *NotificationSettings) { s.ReminderHours = -1 },
+		"negative escalation": func(s *NotificationSettings) { s.EscalationHours = -1 },
+		"start without end":   func(s *NotificationSettings) { s.QuietHoursEnd = "" },
+		"invalid format":      func(s *NotificationSettings) { s.QuietHoursStart = "10pm" },
+		"empty window":        func(s *NotificationSettings) { s.QuietHoursEnd = "22:00" },
+	} {
+		settings := valid
+		mutate(&settings)
+		if err := settings.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestNotificationSettings_InQuietHours(t *testing.T) {
+	overnight := NotificationSettings{QuietHoursStart: "22:00", QuietHoursEnd: "07:00"}
+	daytime := NotificationSettings{QuietHoursStart: "12:00", QuietHoursEnd: "13:30"}
+	shanghai := time.FixedZone("CST", 8*3600)
+	at := func(hour, minute int) time.Time { return time.Date(2024, 6, 10, hour, minute, 0, 0, shanghai) }
+
+	cases := []struct {
+		settings NotificationSettings
+		t        time.Time
+		want     bool
+	}{
+		{overnight, at(23, 30), true},
+		{overnight, at(6, 59), true},
+		{overnight, at(7, 0), false},
+		{overnight, at(21, 59), false},
+		{daytime, at(12, 45), true},
+		{daytime, at(13, 30), false},
+		{NotificationSettings{}, at(3, 0), false},
+	}
+	for _, c := range cases {
+		if got := c.settings.InQuietHours(c.t.UTC(), shanghai); got != c.want {
+			t.Errorf("%s-%s at %s: expected %v, got %v",
+				c.settings.QuietHoursStart, c.settings.QuietHoursEnd, c.t.Format("15:04"), c.want, got)
+		}
+	}
+}
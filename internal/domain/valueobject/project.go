@@ -50,14 +50,61 @@ type ProjectMember struct {
 }
 
 // ProjectTaskStatistics 项目任务统计信息
+// 逾期指截止时间已过且未完成、未取消；AverageTaskTime 为平均实际工时（小时）
 type ProjectTaskStatistics struct {
-	ProjectID         ProjectID `json:"project_id"`
-	TotalTasks        int       `json:"total_tasks"`
-	CompletedTasks    int       `json:"completed_tasks"`
-	InProgressTasks   int       `json:"in_progress_tasks"`
-	PendingTasks      int       `json:"pending_tasks"`
-	OverdueTasks      int       `json:"overdue_tasks"`
-	HighPriorityTasks int       `json:"high_priority_tasks"`
-	CompletionRate    float64   `json:"completion_rate"`
-	AverageTaskTime   float64   `json:"average_task_time"`
+	ProjectID         ProjectID            `json:"project_id"`
+	TotalTasks        int                  `json:"total_tasks"`
+	CompletedTasks    int                  `json:"completed_tasks"`
+	InProgressTasks   int                  `json:"in_progress_tasks"`
+	PendingTasks      int                  `json:"pending_tasks"`
+	OverdueTasks      int                  `json:"overdue_tasks"`
+	HighPriorityTasks int                  `json:"high_priority_tasks"`
+	CompletionRate    float64              `json:"completion_rate"`
+	AverageTaskTime   float64              `json:"average_task_time"`
+	TasksByStatus     map[TaskStatus]int   `json:"tasks_by_status"`
+	TasksByPriority   map[TaskPriority]int `json:"tasks_by_priority"`
+	TasksByType       map[TaskType]int     `json:"tasks_by_type"`
+
+	totalActualHours float64
+}
+
+// NewProjectTaskStatistics 创建空的项目任务统计
+func NewProjectTaskStatistics(projectID ProjectID) *ProjectTaskStatistics {
+	return &ProjectTaskStatistics{
+		ProjectID:       projectID,
+		TasksByStatus:   make(map[TaskStatus]int),
+		TasksByPriority: make(map[TaskPriority]int),
+		TasksByType:     make(map[TaskType]int),
+	}
+}
+
+// Add 累加一组相同状态、优先级和类型的任务
+func (s *ProjectTaskStatistics) Add(status TaskStatus, priority TaskPriority, taskType TaskType, count, overdue int, actualHours float64) {
+	s.TotalTasks += count
+	s.OverdueTasks += overdue
+	s.TasksByStatus[status] += count
+	s.TasksByPriority[priority] += count
+	s.TasksByType[taskType] += count
+	s.totalActualHours += actualHours
+
+	switch status {
+	case TaskStatusCompleted:
+		s.CompletedTasks += count
+	case TaskStatusInProgress:
+		s.InProgressTasks += count
+	case TaskStatusDraft, TaskStatusPendingApproval, TaskStatusApproved:
+		s.PendingTasks += count
+	}
+	if priority == TaskPriorityHigh || priority == TaskPriorityCritical {
+		s.HighPriorityTasks += count
+	}
+}
+
+// Finalize 全部分组累加完成后计算完成率（百分比）和平均工时
+func (s *ProjectTaskStatistics) Finalize() {
+	if s.TotalTasks == 0 {
+		return
+	}
+	s.CompletionRate = float64(s.CompletedTasks) / float64(s.TotalTasks) * 100
+	s.AverageTaskTime = s.totalActualHours / float64(s.TotalTasks)
 }
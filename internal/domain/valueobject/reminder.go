@@ -0,0 +1,91 @@
+package valueobject
+
+import "time"
+
+// ReminderCatchUp 提醒时间过后仍会补发该级提醒的时长，超过后跳过，避免服务停机恢复后补发过期提醒
+const ReminderCatchUp = 24 * time.Hour
+
+// ReminderLadder 截止日期提醒梯度，每一级为截止时间前的提前量，0 表示到期时提醒
+type ReminderLadder []time.Duration
+
+// DefaultReminderLadder 默认在截止前24小时、前1小时和到期时各提醒一次
+var DefaultReminderLadder = ReminderLadder{24 * time.Hour, time.Hour, 0}
+
+// DueRung 返回 now 时应发送的梯度：已到提醒时间且未超过补发时长的梯度中最接近截止时间的一级
+// 错过的更早梯度被更近的梯度取代，不再补发
+func (l ReminderLadder) DueRung(dueDate, now time.Time) (time.Duration, bool) {
+	var rung time.Duration
+	found := false
+	for _, offset := range l {
+		fireAt := dueDate.Add(-offset)
+		if now.Before(fireAt) || now.Sub(fireAt) >= ReminderCatchUp {
+			continue
+		}
+		if !found || offset < rung {
+			rung, found = offset, true
+		}
+	}
+	return rung, found
+}
+
+// MaxOffset 返回梯度中最大的提前量
+func (l ReminderLadder) MaxOffset() time.Duration {
+	var longest time.Duration
+	for _, offset := range l {
+		if offset > longest {
+			longest = offset
+		}
+	}
+	return longest
+}
+
+// ReminderPolicy 截止日期提醒策略，项目配置优先于优先级配置，都未配置时使用默认梯度
+type ReminderPolicy struct {
+	Default    ReminderLadder
+	ByPriority map[TaskPriority]ReminderLadder
+	ByProject  map[ProjectID]ReminderLadder
+}
+
+// LadderFor 返回任务适用的提醒梯度
+func (p ReminderPolicy) LadderFor(projectID ProjectID, priority TaskPriority) ReminderLadder {
+	if ladder, ok := p.ByProject[projectID]; ok {
+		return ladder
+	}
+	if ladder, ok := p.ByPriority[priority]; ok {
+		return ladder
+	}
+	return p.defaultLadder()
+}
+
+func (p ReminderPolicy) defaultLadder() ReminderLadder {
+	if p.Default != nil {
+		return p.Default
+	}
+	return DefaultReminderLadder
+}
+
+// MaxOffset 返回所有梯度中最大的提前量，用于确定需要检查的任务范围
+func (p ReminderPolicy) MaxOffset() time.Duration {
+	longest := p.defaultLadder().MaxOffset()
+	for _, ladder := range p.ByPriority {
+		if offset := ladder.MaxOffset(); offset > longest {
+			longest = offset
+		}
+	}
+	for _, ladder := range p.ByProject {
+		if offset := ladder.MaxOffset(); offset > longest {
+			longest = offset
+		}
+	}
+	return longest
+}
+
+// TaskReminder 已发送的截止日期提醒，同一任务、用户、截止时间和梯度只发送一次
+// 截止日期变更后按新的截止时间重新提醒
+type TaskReminder struct {
+	TaskID  TaskID
+	UserID  UserID
+	DueDate time.Time
+	Offset  time.Duration
+	SentAt  time.Time
+}
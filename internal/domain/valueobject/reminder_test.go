@@ -0,0 +1,55 @@
+package valueobject
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReminderPolicy_ProjectOverridesPriority(t *testing.T) {
+	policy := ReminderPolicy{
+		ByPriority: map[TaskPriority]ReminderLadder{TaskPriorityCritical: {48 * time.Hour, 0}},
+		ByProject:  map[ProjectID]ReminderLadder{"project-2": {2 * time.Hour}},
+	}
+
+	tests := []struct {
+		projectID ProjectID
+		priority  TaskPriority
+		want      ReminderLadder
+	}{
+		{"project-1", TaskPriorityMedium, DefaultReminderLadder},
+		{"project-1", TaskPriorityCritical, ReminderLadder{48 * time.Hour, 0}},
+		{"project-2", TaskPriorityCritical, ReminderLadder{2 * time.Hour}},
+	}
+	for _, tt := range tests {
+		if got := policy.LadderFor(tt.projectID, tt.priority); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LadderFor(%s, %s) = %v, want %v", tt.projectID, tt.priority, got, tt.want)
+		}
+	}
+	if got := policy.MaxOffset(); got != 48*time.Hour {
+		t.Errorf("MaxOffset() = %v, want 48h", got)
+	}
+}
+
+func TestReminderLadder_DueRung(t *testing.T) {
+	due := time.Date(2024, 6, 10, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		want   time.Duration
+		wantOK bool
+	}{
+		{"before first rung", due.Add(-25 * time.Hour), 0, false},
+		{"first rung", due.Add(-24 * time.Hour), 24 * time.Hour, true},
+		{"closest passed rung wins", due.Add(-30 * time.Minute), time.Hour, true},
+		{"due time", due, 0, true},
+		{"past catch-up", due.Add(ReminderCatchUp), 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := DefaultReminderLadder.DueRung(due, tt.now)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("%s: DueRung() = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
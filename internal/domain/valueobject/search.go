@@ -0,0 +1,16 @@
+package valueobject
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MinSearchTermLength 搜索关键字的最少字符数，更短的关键字几乎匹配全部记录
+const MinSearchTermLength = 2
+
+// NormalizeSearchTerm 去掉关键字首尾空白并将连续空白合并为一个空格
+// 规范化后不足 MinSearchTermLength 个字符时返回 false
+func NormalizeSearchTerm(term string) (string, bool) {
+	normalized := strings.Join(strings.Fields(term), " ")
+	return normalized, utf8.RuneCountInString(normalized) >= MinSearchTermLength
+}
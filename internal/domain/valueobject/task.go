@@ -1,6 +1,7 @@
 package valueobject
 
 import (
+	"math"
 	"time"
 )
 
@@ -21,6 +22,15 @@ const (
 	TaskTypeUrgent    TaskType = "urgent"    // 紧急任务
 )
 
+// IsValid 检查任务类型是否有效
+func (t TaskType) IsValid() bool {
+	switch t {
+	case TaskTypeRegular, TaskTypeRecurring, TaskTypeTemplate, TaskTypeUrgent:
+		return true
+	}
+	return false
+}
+
 // TaskStatus 任务状态
 type TaskStatus string
 
@@ -45,6 +55,11 @@ const (
 	TaskPriorityCritical TaskPriority = "critical" // 紧急优先级
 )
 
+// IsUrgent 是否为高优先级或紧急任务
+func (p TaskPriority) IsUrgent() bool {
+	return p == TaskPriorityHigh || p == TaskPriorityCritical
+}
+
 // RecurrenceFrequency 重复频率
 type RecurrenceFrequency string
 
@@ -55,6 +70,115 @@ const (
 	RecurrenceYearly  RecurrenceFrequency = "yearly"  // 每年
 )
 
+// IsValid 检查重复频率是否有效
+func (f RecurrenceFrequency) IsValid() bool {
+	switch f {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly:
+		return true
+	}
+	return false
+}
+
+// RecurrenceRule 重复规则值对象
+type RecurrenceRule struct {
+	Frequency     RecurrenceFrequency `json:"frequency"`
+	IntervalValue int                 `json:"interval_value"`
+	EndDate       *time.Time          `json:"end_date,omitempty"`
+	MaxExecutions *int                `json:"max_executions,omitempty"`
+}
+
+// MaxRecurrenceInterval 重复间隔的上限
+const MaxRecurrenceInterval = 1000
+
+// IsValid 检查重复规则是否有效：频率合法、间隔在 1 到 MaxRecurrenceInterval 之间、最大执行次数为正数
+func (r RecurrenceRule) IsValid() bool {
+	if !r.Frequency.IsValid() || r.IntervalValue <= 0 || r.IntervalValue > MaxRecurrenceInterval {
+		return false
+	}
+	return r.MaxExecutions == nil || *r.MaxExecutions > 0
+}
+
+// NextOccurrence 返回锚点之后第一个晚于 after 的执行时间，执行时间为锚点加整数个间隔
+// 按日历计算，每月或每年重复时从锚点累加，目标月份没有锚点日期时取该月最后一天
+func (r RecurrenceRule) NextOccurrence(anchor, after time.Time) time.Time {
+	return r.occurrence(anchor, r.nextIndex(anchor, after))
+}
+
+// Occurrences 返回晚于 after 的至多 limit 个执行时间，超过结束日期或最大执行次数后截止
+// 锚点本身计为第一次执行
+func (r RecurrenceRule) Occurrences(anchor, after time.Time, limit int) []time.Time {
+	dates := make([]time.Time, 0, limit)
+	for k := r.nextIndex(anchor, after); len(dates) < limit; k++ {
+		if r.MaxExecutions != nil && k >= *r.MaxExecutions {
+			break
+		}
+		next := r.occurrence(anchor, k)
+		if r.EndDate != nil && next.After(*r.EndDate) {
+			break
+		}
+		dates = append(dates, next)
+	}
+	return dates
+}
+
+// nextIndex 第一个晚于 after 的执行时间是锚点之后的第几个间隔
+func (r RecurrenceRule) nextIndex(anchor, after time.Time) int {
+	if anchor.After(after) {
+		return 0
+	}
+	// 按最长周期估算已经过的间隔数，从估算值往后逐个查找；间隔时长溢出时从锚点开始查找
+	k := 0
+	if period := r.maxPeriod(); r.IntervalValue > 0 && int64(r.IntervalValue) <= math.MaxInt64/int64(period) {
+		k = int(after.Sub(anchor)/(period*time.Duration(r.IntervalValue))) - 1
+	}
+	if k < 0 {
+		k = 0
+	}
+	for !r.occurrence(anchor, k).After(after) {
+		k++
+	}
+	return k
+}
+
+// occurrence 锚点之后第 k 个间隔的执行时间
+func (r RecurrenceRule) occurrence(anchor time.Time, k int) time.Time {
+	n := k * r.IntervalValue
+	switch r.Frequency {
+	case RecurrenceDaily:
+		return anchor.AddDate(0, 0, n)
+	case RecurrenceWeekly:
+		return anchor.AddDate(0, 0, 7*n)
+	case RecurrenceMonthly:
+		return addMonthsClamped(anchor, n)
+	default:
+		return addMonthsClamped(anchor, 12*n)
+	}
+}
+
+// addMonthsClamped 加上若干个月，日期超出目标月份时取该月最后一天，如 1月31日 加一个月为 2月29日
+func addMonthsClamped(t time.Time, months int) time.Time {
+	next := t.AddDate(0, months, 0)
+	if next.Day() != t.Day() {
+		// AddDate 已进位到下个月，回退到目标月份的最后一天
+		next = next.AddDate(0, 0, -next.Day())
+	}
+	return next
+}
+
+// maxPeriod 单个频率周期的最长时长，含夏令时切换
+func (r RecurrenceRule) maxPeriod() time.Duration {
+	switch r.Frequency {
+	case RecurrenceDaily:
+		return 25 * time.Hour
+	case RecurrenceWeekly:
+		return 7*24*time.Hour + time.Hour
+	case RecurrenceMonthly:
+		return 31*24*time.Hour + time.Hour
+	default:
+		return 366*24*time.Hour + time.Hour
+	}
+}
+
 // TaskExecutionID 任务执行ID
 type TaskExecutionID string
 
@@ -69,6 +193,80 @@ func (id ExtensionRequestID) String() string {
 	return string(id)
 }
 
+// ExtensionStatus 延期申请状态
+type ExtensionStatus string
+
+const (
+	ExtensionStatusPending  ExtensionStatus = "pending"  // 待审批
+	ExtensionStatusApproved ExtensionStatus = "approved" // 已批准
+	ExtensionStatusRejected ExtensionStatus = "rejected" // 已拒绝
+)
+
+// ExtensionRequest 延期申请记录
+type ExtensionRequest struct {
+	ID               ExtensionRequestID `json:"id"`
+	TaskID           TaskID             `json:"task_id"`
+	RequesterID      UserID             `json:"requester_id"`
+	OriginalDueDate  time.Time          `json:"original_due_date"`
+	RequestedDueDate time.Time          `json:"requested_due_date"`
+	Reason           string             `json:"reason"`
+	Status           ExtensionStatus    `json:"status"`
+	RequestedAt      time.Time          `json:"requested_at"`
+	ReviewerID       *UserID            `json:"reviewer_id"`
+	ReviewedAt       *time.Time         `json:"reviewed_at"`
+	ReviewComment    *string            `json:"review_comment"`
+}
+
+// WorkSubmissionID 工作提交ID
+type WorkSubmissionID string
+
+func (id WorkSubmissionID) String() string {
+	return string(id)
+}
+
+// WorkSubmissionStatus 工作提交的审核状态
+type WorkSubmissionStatus string
+
+const (
+	WorkSubmissionStatusPending  WorkSubmissionStatus = "pending"  // 待审核
+	WorkSubmissionStatusApproved WorkSubmissionStatus = "approved" // 已通过
+	WorkSubmissionStatusRejected WorkSubmissionStatus = "rejected" // 已驳回
+)
+
+// WorkSubmission 参与者提交的工作记录
+type WorkSubmission struct {
+	ID            WorkSubmissionID     `json:"id"`
+	TaskID        TaskID               `json:"task_id"`
+	SubmitterID   UserID               `json:"submitter_id"`
+	Content       string               `json:"content"`
+	Attachments   []string             `json:"attachments"`
+	Status        WorkSubmissionStatus `json:"status"`
+	SubmittedAt   time.Time            `json:"submitted_at"`
+	ReviewerID    *UserID              `json:"reviewer_id"`
+	ReviewedAt    *time.Time           `json:"reviewed_at"`
+	ReviewComment *string              `json:"review_comment"`
+	Decisions     []ReviewDecision     `json:"decisions,omitempty"` // 多人审核时各审核人的意见
+}
+
+// Approvals 统计提交已获得的通过意见数
+func (s WorkSubmission) Approvals() int {
+	count := 0
+	for _, d := range s.Decisions {
+		if d.Approved {
+			count++
+		}
+	}
+	return count
+}
+
+// ReviewDecision 审核人对工作提交的审核意见
+type ReviewDecision struct {
+	ReviewerID UserID    `json:"reviewer_id"`
+	Approved   bool      `json:"approved"`
+	Comment    string    `json:"comment,omitempty"`
+	DecidedAt  time.Time `json:"decided_at"`
+}
+
 // ParticipantRole 参与者角色
 type ParticipantRole string
 
@@ -79,6 +277,15 @@ const (
 	ParticipantRoleAssistant ParticipantRole = "assistant" // 协助者
 )
 
+// IsValid 检查参与者角色是否有效
+func (r ParticipantRole) IsValid() bool {
+	switch r {
+	case ParticipantRoleExecutor, ParticipantRoleReviewer, ParticipantRoleObserver, ParticipantRoleAssistant:
+		return true
+	}
+	return false
+}
+
 // TaskParticipant 任务参与者值对象
 type TaskParticipant struct {
 	UserID  UserID          `json:"user_id"`
@@ -98,36 +305,45 @@ type TaskSearchCriteria struct {
 	CreatorID     *UserID       `json:"creator_id"`
 	ResponsibleID *UserID       `json:"responsible_id"`
 	ParticipantID *UserID       `json:"participant_id"`
-	StartDate     *time.Time    `json:"start_date"`
-	DueDate       *time.Time    `json:"due_date"`
+	StartDate     *time.Time    `json:"start_date"` // 开始日期不早于该时间
+	DueDate       *time.Time    `json:"due_date"`   // 截止日期不晚于该时间
 	CreatedAfter  *time.Time    `json:"created_after"`
 	CreatedBefore *time.Time    `json:"created_before"`
 	Limit         int           `json:"limit"`
 	Offset        int           `json:"offset"`
 	OrderBy       string        `json:"order_by"`
 	OrderDir      string        `json:"order_dir"`
+
+	// PrefixMatch 标题和描述按前缀匹配，可以使用索引；默认为包含匹配
+	// 关键字规范化后不足 MinSearchTermLength 个字符时不作为过滤条件
+	PrefixMatch bool `json:"prefix_match"`
+
+	// AccessibleBy 仅返回该用户创建、负责或参与的任务，为空表示不做访问过滤
+	AccessibleBy *UserID `json:"-"`
 }
 
 // TaskData 任务数据传输对象（用于持久化和恢复）
 type TaskData struct {
-	ID             string                `json:"id"`
-	Title          string                `json:"title"`
-	Description    *string               `json:"description"`
-	TaskType       string                `json:"task_type"`
-	Priority       string                `json:"priority"`
-	Status         string                `json:"status"`
-	ProjectID      string                `json:"project_id"`
-	CreatorID      string                `json:"creator_id"`
-	ResponsibleID  string                `json:"responsible_id"`
-	StartDate      *time.Time            `json:"start_date"`
-	DueDate        *time.Time            `json:"due_date"`
-	CompletedAt    *time.Time            `json:"completed_at"`
-	EstimatedHours int                   `json:"estimated_hours"`
-	WorkflowID     *string               `json:"workflow_id"`
-	CreatedAt      time.Time             `json:"created_at"`
-	UpdatedAt      time.Time             `json:"updated_at"`
-	DeletedAt      *time.Time            `json:"deleted_at"`
-	Participants   []TaskParticipantData `json:"participants"`
+	ID              string                `json:"id"`
+	Title           string                `json:"title"`
+	Description     *string               `json:"description"`
+	TaskType        string                `json:"task_type"`
+	Priority        string                `json:"priority"`
+	Status          string                `json:"status"`
+	ProjectID       string                `json:"project_id"`
+	CreatorID       string                `json:"creator_id"`
+	ResponsibleID   string                `json:"responsible_id"`
+	StartDate       *time.Time            `json:"start_date"`
+	DueDate         *time.Time            `json:"due_date"`
+	CompletedAt     *time.Time            `json:"completed_at"`
+	ApprovedAt      *time.Time            `json:"approved_at"`
+	StatusChangedAt time.Time             `json:"status_changed_at"`
+	EstimatedHours  int                   `json:"estimated_hours"`
+	WorkflowID      *string               `json:"workflow_id"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	DeletedAt       *time.Time            `json:"deleted_at"`
+	Participants    []TaskParticipantData `json:"participants"`
 }
 
 // TaskParticipantData 任务参与者数据传输对象
@@ -137,3 +353,9 @@ type TaskParticipantData struct {
 	AddedBy string    `json:"added_by"`
 }
 
+// TaskWorkload 用户负责的任务负载，未结束指未完成且未取消，逾期指未结束且已过截止时间
+type TaskWorkload struct {
+	ResponsibleID UserID `json:"responsible_id"`
+	OpenTasks     int    `json:"open_tasks"`
+	OverdueTasks  int    `json:"overdue_tasks"`
+}
@@ -0,0 +1,100 @@
+package valueobject
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceRule_NextOccurrenceAlignsToAnchor(t *testing.T) {
+	anchor := time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		rule  RecurrenceRule
+		after time.Time
+		want  time.Time
+	}{
+		{"anchor still ahead", RecurrenceRule{Frequency: RecurrenceDaily, IntervalValue: 1}, anchor.Add(-time.Hour), anchor},
+		{"at the anchor", RecurrenceRule{Frequency: RecurrenceDaily, IntervalValue: 1}, anchor, time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC)},
+		{"every three days", RecurrenceRule{Frequency: RecurrenceDaily, IntervalValue: 3}, time.Date(2024, 2, 6, 12, 0, 0, 0, time.UTC), time.Date(2024, 2, 9, 9, 30, 0, 0, time.UTC)},
+		{"biweekly", RecurrenceRule{Frequency: RecurrenceWeekly, IntervalValue: 2}, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 13, 9, 30, 0, 0, time.UTC)},
+		{"monthly clamps to the month end", RecurrenceRule{Frequency: RecurrenceMonthly, IntervalValue: 1}, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 30, 9, 30, 0, 0, time.UTC)},
+		{"monthly counts from the anchor", RecurrenceRule{Frequency: RecurrenceMonthly, IntervalValue: 1}, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 9, 30, 0, 0, time.UTC)},
+		{"yearly", RecurrenceRule{Frequency: RecurrenceYearly, IntervalValue: 1}, time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2031, 1, 31, 9, 30, 0, 0, time.UTC)},
+		{"every twelve months", RecurrenceRule{Frequency: RecurrenceMonthly, IntervalValue: 12}, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 31, 9, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.NextOccurrence(anchor, tt.after); !got.Equal(tt.want) {
+			t.Errorf("%s: NextOccurrence() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRecurrenceRule_NextOccurrenceKeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	anchor := time.Date(2024, 3, 1, 8, 0, 0, 0, loc)
+	rule := RecurrenceRule{Frequency: RecurrenceWeekly, IntervalValue: 1}
+
+	got := rule.NextOccurrence(anchor, time.Date(2024, 3, 12, 0, 0, 0, 0, loc))
+
+	if want := time.Date(2024, 3, 15, 8, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("NextOccurrence() = %v, want %v", got, want)
+	}
+}
+
+func TestRecurrenceRule_OccurrencesStopAtCutoff(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	after := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	maxExecutions := 4
+	endDate := time.Date(2024, 2, 12, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule RecurrenceRule
+		want []time.Time
+	}{
+		{"no cutoff", RecurrenceRule{Frequency: RecurrenceWeekly, IntervalValue: 1}, []time.Time{
+			time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 29, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC), time.Date(2024, 2, 12, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 19, 9, 0, 0, 0, time.UTC),
+		}},
+		// 锚点计为第一次执行，第 4 次执行为 1月22日
+		{"max executions", RecurrenceRule{Frequency: RecurrenceWeekly, IntervalValue: 1, MaxExecutions: &maxExecutions}, []time.Time{
+			time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC),
+		}},
+		// 结束日期当天的执行仍然保留
+		{"end date", RecurrenceRule{Frequency: RecurrenceWeekly, IntervalValue: 1, EndDate: &endDate}, []time.Time{
+			time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 29, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC), time.Date(2024, 2, 12, 9, 0, 0, 0, time.UTC),
+		}},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Occurrences(anchor, after, 5); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Occurrences() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRecurrenceRule_LargeIntervalDoesNotOverflow(t *testing.T) {
+	anchor := time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC)
+	after := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	rule := RecurrenceRule{Frequency: RecurrenceYearly, IntervalValue: MaxRecurrenceInterval}
+	if !rule.IsValid() {
+		t.Fatalf("interval %d should be valid", MaxRecurrenceInterval)
+	}
+	if got, want := rule.NextOccurrence(anchor, after), time.Date(3024, 1, 31, 9, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("NextOccurrence() = %v, want %v", got, want)
+	}
+
+	// 超出上限的间隔无效，但计算时也不能因溢出而 panic
+	huge := RecurrenceRule{Frequency: RecurrenceYearly, IntervalValue: 1 << 51}
+	if huge.IsValid() {
+		t.Errorf("interval %d should be invalid", huge.IntervalValue)
+	}
+	huge.NextOccurrence(anchor, after)
+}
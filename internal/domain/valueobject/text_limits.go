@@ -0,0 +1,58 @@
+package valueobject
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// TextLimits 名称和描述的长度上限，按字符计数
+type TextLimits struct {
+	NameMaxLength        int
+	DescriptionMaxLength int
+}
+
+var (
+	// DefaultProjectTextLimits 未配置时项目名称和描述的长度上限，名称与 projects.name 列宽一致
+	DefaultProjectTextLimits = TextLimits{NameMaxLength: 200, DescriptionMaxLength: 5000}
+	// DefaultTaskTextLimits 未配置时任务标题和描述的长度上限，标题与 tasks.title 列宽一致
+	DefaultTaskTextLimits = TextLimits{NameMaxLength: 300, DescriptionMaxLength: 5000}
+)
+
+// WithDefaults 非正数的上限使用 defaults 中对应的值
+func (l TextLimits) WithDefaults(defaults TextLimits) TextLimits {
+	if l.NameMaxLength <= 0 {
+		l.NameMaxLength = defaults.NameMaxLength
+	}
+	if l.DescriptionMaxLength <= 0 {
+		l.DescriptionMaxLength = defaults.DescriptionMaxLength
+	}
+	return l
+}
+
+// Validate 校验名称和描述长度，超长时返回 *ValidationError，按字段列出全部违规项
+// nameField 为名称对应的请求字段，如项目的 name、任务的 title
+func (l TextLimits) Validate(operation, nameField, name, description string) error {
+	var violations []ValidationViolation
+	if n := utf8.RuneCountInString(name); n > l.NameMaxLength {
+		violations = append(violations, maxLengthViolation(nameField, n, l.NameMaxLength))
+	}
+	if n := utf8.RuneCountInString(description); n > l.DescriptionMaxLength {
+		violations = append(violations, maxLengthViolation("description", n, l.DescriptionMaxLength))
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Operation: operation, Violations: violations}
+	}
+	return nil
+}
+
+func maxLengthViolation(field string, length, max int) ValidationViolation {
+	return ValidationViolation{
+		RuleID:   "max",
+		RuleName: "长度上限",
+		Field:    field,
+		Value:    length,
+		Message:  fmt.Sprintf("%s长度不能超过%d个字符", field, max),
+		Severity: ValidationSeverityError,
+		Context:  map[string]interface{}{"max": max},
+	}
+}
@@ -22,6 +22,23 @@ const (
 	UserRoleSuperAdmin  UserRole = "super_admin"
 )
 
+// Level 角色的权限级别，数值越大权限越高，未知角色为0
+func (r UserRole) Level() int {
+	switch r {
+	case UserRoleEmployee:
+		return 1
+	case UserRoleManager:
+		return 2
+	case UserRoleDirector:
+		return 3
+	case UserRoleAdmin:
+		return 4
+	case UserRoleSuperAdmin:
+		return 5
+	}
+	return 0
+}
+
 // UserStatus 用户状态
 type UserStatus string
 
@@ -61,6 +78,31 @@ type TaskStatistics struct {
 	NextExecutionAt      *time.Time `json:"next_execution_at"`
 }
 
+// AddExecutions 累加一组相同状态的工作提交，每次提交视为一次执行
+func (s *TaskStatistics) AddExecutions(status WorkSubmissionStatus, count int, lastSubmittedAt time.Time) {
+	s.TotalExecutions += count
+	switch status {
+	case WorkSubmissionStatusApproved:
+		s.CompletedExecutions += count
+	case WorkSubmissionStatusPending:
+		s.PendingExecutions += count
+	case WorkSubmissionStatusRejected:
+		s.RejectedExecutions += count
+	}
+	if lastSubmittedAt.After(s.LastExecutionAt) {
+		s.LastExecutionAt = lastSubmittedAt
+	}
+}
+
+// Finalize 全部分组累加完成后计算完成率（百分比）和每次执行的平均实际工时
+func (s *TaskStatistics) Finalize(actualHours float64) {
+	if s.TotalExecutions == 0 {
+		return
+	}
+	s.CompletionRate = float64(s.CompletedExecutions) / float64(s.TotalExecutions) * 100
+	s.AverageExecutionTime = actualHours / float64(s.TotalExecutions)
+}
+
 // WorkflowStepData 工作流步骤数据
 type WorkflowStepData struct {
 	StepID  string                 `json:"step_id"`
@@ -1,6 +1,8 @@
 package valueobject
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -61,6 +63,12 @@ type UserValidationResult struct {
 	ValidatedAt time.Time             `json:"validated_at"`
 }
 
+// 用户创建时的唯一性规则ID
+const (
+	RuleEmailUnique    = "email_unique"
+	RuleUsernameUnique = "username_unique"
+)
+
 // ValidationViolation 验证违规
 type ValidationViolation struct {
 	RuleID   string                 `json:"rule_id"`
@@ -159,6 +167,20 @@ func (e DepartmentValidationError) Error() string {
 	return e.Message
 }
 
+// ValidationError 业务规则验证错误，保留全部违规项供调用方按字段展示
+type ValidationError struct {
+	Operation  string                `json:"operation"`
+	Violations []ValidationViolation `json:"violations"`
+}
+
+func (e ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		fields = append(fields, v.Field)
+	}
+	return fmt.Sprintf("%s验证失败: %d个违规 (%s)", e.Operation, len(e.Violations), strings.Join(fields, ", "))
+}
+
 // UserBusinessRule 用户业务规则
 type UserBusinessRule struct {
 	RuleID     string                 `json:"rule_id"`
@@ -72,10 +72,10 @@ type UserProfile struct {
 
 // UserCredentials 用户凭证值对象
 type UserCredentials struct {
-	Email        Email     `json:"email"`
-	PasswordHash string    `json:"password_hash"`
-	Salt         string    `json:"salt,omitempty"`
-	LastChanged  time.Time `json:"last_changed"`
+	Email        Email      `json:"email"`
+	PasswordHash string     `json:"-"` // 密码哈希和盐不序列化
+	Salt         string     `json:"-"`
+	LastChanged  time.Time  `json:"last_changed"`
 	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
 }
 
@@ -90,16 +90,25 @@ type UserPreferences struct {
 	CustomSettings    map[string]string `json:"custom_settings,omitempty"`
 }
 
-// UserSecuritySettings 用户安全设置
+// UserSecuritySettings 用户安全设置，两步验证密钥和备用码不序列化
+// 响应中使用 UserSecurityStatus，不要直接返回本结构
 type UserSecuritySettings struct {
-	TwoFactorEnabled    bool       `json:"two_factor_enabled"`
-	TwoFactorSecret     string     `json:"two_factor_secret,omitempty"`
-	BackupCodes         []string   `json:"backup_codes,omitempty"`
-	LastPasswordChange  time.Time  `json:"last_password_change"`
-	PasswordExpiresAt   *time.Time `json:"password_expires_at,omitempty"`
-	LoginAttempts       int        `json:"login_attempts"`
-	LockedUntil         *time.Time `json:"locked_until,omitempty"`
-	SessionTimeout      int        `json:"session_timeout"` // 分钟
+	TwoFactorEnabled   bool       `json:"two_factor_enabled"`
+	TwoFactorSecret    string     `json:"-"`
+	BackupCodes        []string   `json:"-"`
+	LastPasswordChange time.Time  `json:"last_password_change"`
+	PasswordExpiresAt  *time.Time `json:"password_expires_at,omitempty"`
+	LoginAttempts      int        `json:"login_attempts"`
+	LockedUntil        *time.Time `json:"locked_until,omitempty"`
+	SessionTimeout     int        `json:"session_timeout"` // 分钟
+}
+
+// UserSecurityStatus 对外展示的用户安全状态，只包含不涉及密钥的标记和时间
+type UserSecurityStatus struct {
+	TwoFactorEnabled   bool       `json:"two_factor_enabled"`
+	LastPasswordChange time.Time  `json:"last_password_change"`
+	PasswordExpiresAt  *time.Time `json:"password_expires_at,omitempty"`
+	LockedUntil        *time.Time `json:"locked_until,omitempty"`
 }
 
 // 角色常量修正 - 与user.go保持一致
@@ -115,7 +124,7 @@ type UserData struct {
 	ID               string                `json:"id"`
 	Username         string                `json:"username"`
 	Email            string                `json:"email"`
-	PasswordHash     string                `json:"password_hash"`
+	PasswordHash     string                `json:"-"` // 密码哈希，不序列化
 	FullName         string                `json:"full_name"`
 	Phone            *string               `json:"phone,omitempty"`
 	Status           string                `json:"status"`
@@ -177,7 +186,7 @@ type UserDetailResponse struct {
 	JoinDate         time.Time             `json:"join_date"`
 	LastLogin        *time.Time            `json:"last_login,omitempty"`
 	Preferences      *UserPreferences      `json:"preferences,omitempty"`
-	SecuritySettings *UserSecuritySettings `json:"security_settings,omitempty"`
+	SecuritySettings *UserSecurityStatus   `json:"security_settings,omitempty"`
 	Statistics       *UserStatistics       `json:"statistics,omitempty"`
 	CreatedAt        time.Time             `json:"created_at"`
 	UpdatedAt        time.Time             `json:"updated_at"`
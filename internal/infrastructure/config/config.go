@@ -15,6 +15,12 @@ type Config struct {
 	Log           LogConfig           `mapstructure:"log"`
 	Upload        UploadConfig        `mapstructure:"upload"`
 	EventBusStore EventBusStoreConfig `mapstructure:"eventstore"`
+	Task          TaskConfig          `mapstructure:"task"`
+	Project       ProjectConfig       `mapstructure:"project"`
+	Webhook       WebhookConfig       `mapstructure:"webhook"`
+	Retention     RetentionConfig     `mapstructure:"retention"`
+	Reminder      ReminderConfig      `mapstructure:"reminder"`
+	Features      FeaturesConfig      `mapstructure:"features"`
 }
 
 // AppConfig 应用配置结构体
@@ -23,6 +29,8 @@ type AppConfig struct {
 	Version string `mapstructure:"version"`
 	Port    int    `mapstructure:"port"`
 	Mode    string `mapstructure:"mode"`
+	// BinaryResponses 允许客户端通过 Accept 头协商的二进制响应格式（protobuf、msgpack），为空时只返回JSON
+	BinaryResponses []string `mapstructure:"binary_responses"`
 }
 
 // DatabaseConfig 数据库配置结构体
@@ -39,6 +47,24 @@ type DatabaseConfig struct {
 	MaxIdleConns    int    `mapstructure:"max_idle_conns"`
 	MaxOpenConns    int    `mapstructure:"max_open_conns"`
 	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime int    `mapstructure:"conn_max_idle_time"`
+
+	// SlowQueryThresholdMs 慢查询阈值（毫秒），仅在debug日志级别下生效，0 表示使用默认值
+	SlowQueryThresholdMs int `mapstructure:"slow_query_threshold_ms"`
+
+	// Replicas 只读副本，未配置时所有读写都使用主库
+	Replicas []ReplicaConfig `mapstructure:"replicas"`
+
+	// TablePrefix 表名前缀，多租户或共享数据库部署时使用，为空表示不加前缀
+	TablePrefix string `mapstructure:"table_prefix"`
+}
+
+// ReplicaConfig 只读副本配置，账号密码未设置时沿用主库
+type ReplicaConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
 }
 
 // RedisConfig Redis配置结构体
@@ -49,6 +75,19 @@ type RedisConfig struct {
 	Database     int    `mapstructure:"database"`
 	PoolSize     int    `mapstructure:"pool_size"`
 	MinIdleConns int    `mapstructure:"min_idle_conns"`
+
+	// Enabled 是否启用Redis缓存，未启用时不连接Redis
+	Enabled bool `mapstructure:"enabled"`
+	// 熔断配置：连续失败达到阈值后在冷却期内跳过缓存
+	OperationTimeoutMs      int `mapstructure:"operation_timeout_ms"`
+	MaxRetries              int `mapstructure:"max_retries"`
+	BreakerFailureThreshold int `mapstructure:"breaker_failure_threshold"`
+	BreakerCooldownSeconds  int `mapstructure:"breaker_cooldown_seconds"`
+}
+
+// GetAddr 获取Redis连接地址
+func (c *RedisConfig) GetAddr() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
 // JWTConfig JWT配置结构体
@@ -84,6 +123,59 @@ type EventBusStoreConfig struct {
 	RetryDelay int `mapstructure:"retry_delay"`
 }
 
+// TaskConfig 任务业务配置结构体
+type TaskConfig struct {
+	MaxParticipants        int `mapstructure:"max_participants"`
+	StaleApprovedHours     int `mapstructure:"stale_approved_hours"`
+	EstimateOverrunPercent int `mapstructure:"estimate_overrun_percent"`
+	TitleMaxLength         int `mapstructure:"title_max_length"`
+	DescriptionMaxLength   int `mapstructure:"description_max_length"`
+}
+
+// ProjectConfig 项目业务配置结构体
+type ProjectConfig struct {
+	NameMaxLength        int `mapstructure:"name_max_length"`
+	DescriptionMaxLength int `mapstructure:"description_max_length"`
+}
+
+// RetentionConfig 软删除数据保留配置结构体
+type RetentionConfig struct {
+	Enabled       bool `mapstructure:"enabled"`
+	Days          int  `mapstructure:"days"`
+	IntervalHours int  `mapstructure:"interval_hours"`
+	DryRun        bool `mapstructure:"dry_run"`
+}
+
+// ReminderConfig 截止日期提醒配置结构体
+// 提醒梯度为截止时间前的提前分钟数，0 表示到期时提醒；按项目配置优先于按优先级配置，都未配置时使用默认梯度
+type ReminderConfig struct {
+	Enabled         bool             `mapstructure:"enabled"`
+	IntervalMinutes int              `mapstructure:"interval_minutes"`
+	LadderMinutes   []int            `mapstructure:"ladder_minutes"`
+	Priorities      map[string][]int `mapstructure:"priorities"`
+	Projects        map[string][]int `mapstructure:"projects"`
+}
+
+// FeaturesConfig 功能开关配置，键为开关名称
+type FeaturesConfig map[string]FeatureFlagConfig
+
+// FeatureFlagConfig 单个功能开关配置：按环境（app.mode）开启或关闭，按项目ID覆盖环境配置
+// 环境未配置时生产环境关闭、其他环境开启
+type FeatureFlagConfig struct {
+	Environments map[string]bool `mapstructure:"environments"`
+	Projects     map[string]bool `mapstructure:"projects"`
+}
+
+// WebhookConfig webhook投递配置结构体
+type WebhookConfig struct {
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	MaxAttempts    int `mapstructure:"max_attempts"`
+	RetryBackoffMs int `mapstructure:"retry_backoff_ms"`
+	MaxFailures    int `mapstructure:"max_failures"`
+	Workers        int `mapstructure:"workers"`
+	QueueSize      int `mapstructure:"queue_size"`
+}
+
 // LoadConfig 加载配置文件
 func LoadConfig(path string) (*Config, error) {
 	viper.AddConfigPath(path)
@@ -111,3 +203,17 @@ func (c *DatabaseConfig) GetDSN() string {
 		c.Username, c.Password, c.Host, c.Port, c.Database,
 		c.Charset, c.ParseTime, c.Loc)
 }
+
+// GetReplicaDSN 获取只读副本的数据库连接字符串，库名和连接参数与主库一致
+func (c *DatabaseConfig) GetReplicaDSN(replica ReplicaConfig) string {
+	dsn := *c
+	dsn.Host = replica.Host
+	if replica.Port != 0 {
+		dsn.Port = replica.Port
+	}
+	if replica.Username != "" {
+		dsn.Username = replica.Username
+		dsn.Password = replica.Password
+	}
+	return dsn.GetDSN()
+}
@@ -15,6 +15,7 @@ type EventBusManager struct {
 	eventBus   *memory.InMemoryEventBus
 	eventStore event.EventStore
 	handlers   []event.EventHandler
+	cache      handlers.CacheInvalidator
 }
 
 // NewEventBusManager 创建事件总线管理器
@@ -39,6 +40,11 @@ func NewEventBusManager() *EventBusManager {
 	}
 }
 
+// SetCacheInvalidator 设置缓存失效器，用于权限缓存失效处理器
+func (m *EventBusManager) SetCacheInvalidator(cache handlers.CacheInvalidator) {
+	m.cache = cache
+}
+
 // RegisterHandlers 注册事件处理器
 func (m *EventBusManager) RegisterHandlers() error {
 	// 创建通知处理器
@@ -57,30 +63,38 @@ func (m *EventBusManager) RegisterHandlers() error {
 		&MockStatisticsRepository{},
 	)
 
+	// 创建权限缓存失效处理器
+	permissionCacheHandler := handlers.NewPermissionCacheHandler(m.cache)
+
 	// 注册处理器
 	handlers := []event.EventHandler{
 		notificationHandler,
 		auditHandler,
 		statisticsHandler,
+		permissionCacheHandler,
 	}
 
 	// 定义事件类型到处理器的映射
 	eventTypeMapping := map[string][]event.EventHandler{
-		"TaskCreated":              {notificationHandler, auditHandler, statisticsHandler},
-		"TaskAssigned":             {notificationHandler, auditHandler},
-		"TaskStatusChanged":        {notificationHandler, auditHandler},
-		"TaskCompleted":            {notificationHandler, auditHandler, statisticsHandler},
-		"TaskRejected":             {notificationHandler, auditHandler, statisticsHandler},
-		"ParticipantAdded":         {notificationHandler, auditHandler},
-		"ParticipantRemoved":       {notificationHandler, auditHandler},
-		"WorkSubmitted":            {notificationHandler, auditHandler},
-		"WorkReviewed":             {notificationHandler, auditHandler},
-		"TaskCompletionSubmitted":  {notificationHandler, auditHandler},
-		"ExtensionRequested":       {notificationHandler, auditHandler},
-		"ExtensionApproved":        {notificationHandler, auditHandler},
-		"ExtensionRejected":        {notificationHandler, auditHandler},
-		"NextExecutionPrepared":    {auditHandler},
-		"AllParticipantsCompleted": {auditHandler},
+		"TaskCreated":                 {notificationHandler, auditHandler, statisticsHandler},
+		"TaskAssigned":                {notificationHandler, auditHandler},
+		"TaskStatusChanged":           {notificationHandler, auditHandler},
+		"TaskCompleted":               {notificationHandler, auditHandler, statisticsHandler},
+		"TaskRejected":                {notificationHandler, auditHandler, statisticsHandler},
+		"ParticipantAdded":            {notificationHandler, auditHandler},
+		"ParticipantRemoved":          {notificationHandler, auditHandler},
+		"WorkSubmitted":               {notificationHandler, auditHandler},
+		"HoursLogged":                 {auditHandler},
+		"WorkReviewed":                {notificationHandler, auditHandler},
+		"TaskCompletionSubmitted":     {notificationHandler, auditHandler},
+		"ExtensionRequested":          {notificationHandler, auditHandler},
+		"ExtensionApproved":           {notificationHandler, auditHandler},
+		"ExtensionRejected":           {notificationHandler, auditHandler},
+		"NextExecutionPrepared":       {auditHandler},
+		"AllParticipantsCompleted":    {auditHandler},
+		"project.member_role_updated": {permissionCacheHandler},
+		"project.member_added":        {permissionCacheHandler},
+		"project.member_removed":      {permissionCacheHandler},
 	}
 
 	// 注册事件处理器
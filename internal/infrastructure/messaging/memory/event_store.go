@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -128,6 +129,49 @@ func (store *InMemoryEventStore) GetEventsByType(eventType string, limit int) ([
 	return result, nil
 }
 
+// FindEvents 按类型、聚合和时间窗口查询事件，按发生时间、事件ID升序，Descending 时倒序
+func (store *InMemoryEventStore) FindEvents(ctx context.Context, query event.EventQuery) ([]event.DomainEvent, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	types := make(map[string]bool, len(query.Types))
+	for _, t := range query.Types {
+		types[t] = true
+	}
+
+	result := make([]event.DomainEvent, 0)
+	for _, e := range store.events {
+		if len(types) > 0 && !types[e.EventType()] {
+			continue
+		}
+		if query.AggregateID != "" && e.AggregateID() != query.AggregateID {
+			continue
+		}
+		if !query.Since.IsZero() {
+			occurredAt := e.OccurredAt()
+			if occurredAt.Before(query.Since) {
+				continue
+			}
+			if query.AfterID != "" && occurredAt.Equal(query.Since) && e.EventID() <= query.AfterID {
+				continue
+			}
+		}
+		result = append(result, e)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].OccurredAt().Equal(result[j].OccurredAt()) {
+			return result[i].OccurredAt().Before(result[j].OccurredAt()) != query.Descending
+		}
+		return (result[i].EventID() < result[j].EventID()) != query.Descending
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+
+	return result, nil
+}
+
 // GetEventByID 根据事件ID获取事件
 func (store *InMemoryEventStore) GetEventByID(eventID string) (event.DomainEvent, error) {
 	store.mu.RLock()
@@ -0,0 +1,284 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen 熔断打开期间读操作直接返回该错误，调用方按缓存未命中处理
+var ErrCircuitOpen = errors.New("cache circuit breaker is open")
+
+// CircuitBreakerConfig 缓存熔断配置
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // 连续失败多少次后熔断，默认5
+	CoolDown         time.Duration // 熔断持续时间，到期后放行一次请求探测恢复，默认30秒
+	OperationTimeout time.Duration // 单次缓存操作超时，默认200毫秒
+	MaxRetries       int           // 单次操作失败后的重试次数，默认不重试
+}
+
+// breakerState 熔断器状态
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // 正常放行
+	breakerOpen                         // 熔断中，直接走空操作
+	breakerHalfOpen                     // 冷却结束，仅放行一个探测请求
+)
+
+// CircuitBreakerCache 带熔断的缓存装饰器
+// 连续失败达到阈值后在冷却期内不再访问缓存：读操作返回 ErrCircuitOpen，写操作静默跳过；
+// 熔断期间的删除会被记录，缓存恢复后先补删这些键，避免恢复后读到已失效的数据；
+// 冷却结束后放行一个请求探测，成功则恢复，失败则继续熔断
+type CircuitBreakerCache struct {
+	inner  Interface
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu          sync.Mutex
+	state       breakerState
+	failures    int
+	openUntil   time.Time
+	pendingDels map[string]struct{} // 熔断期间未能删除的键
+}
+
+// NewCircuitBreakerCache 创建带熔断的缓存，未配置的参数使用默认值
+func NewCircuitBreakerCache(inner Interface, config CircuitBreakerConfig) *CircuitBreakerCache {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.CoolDown <= 0 {
+		config.CoolDown = 30 * time.Second
+	}
+	if config.OperationTimeout <= 0 {
+		config.OperationTimeout = 200 * time.Millisecond
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	return &CircuitBreakerCache{
+		inner:  inner,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Get 获取缓存值
+func (c *CircuitBreakerCache) Get(ctx context.Context, key string) (string, error) {
+	var value string
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		value, err = c.inner.Get(ctx, key)
+		return err
+	})
+	return value, err
+}
+
+// Set 设置缓存值，熔断期间跳过
+func (c *CircuitBreakerCache) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	return skipWhenOpen(c.do(ctx, func(ctx context.Context) error {
+		return c.inner.Set(ctx, key, value, expiration)
+	}))
+}
+
+// Del 删除缓存，熔断期间记录待删除的键，缓存恢复时补删
+func (c *CircuitBreakerCache) Del(ctx context.Context, keys ...string) error {
+	err := c.do(ctx, func(ctx context.Context) error {
+		return c.inner.Del(ctx, keys...)
+	})
+	if errors.Is(err, ErrCircuitOpen) {
+		c.deferDelete(keys)
+		return nil
+	}
+	return err
+}
+
+// Exists 检查键是否存在
+func (c *CircuitBreakerCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	var count int64
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		count, err = c.inner.Exists(ctx, keys...)
+		return err
+	})
+	return count, err
+}
+
+// MGet 批量获取
+func (c *CircuitBreakerCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	var values []interface{}
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		values, err = c.inner.MGet(ctx, keys...)
+		return err
+	})
+	return values, err
+}
+
+// MSet 批量设置，熔断期间跳过
+func (c *CircuitBreakerCache) MSet(ctx context.Context, pairs ...interface{}) error {
+	return skipWhenOpen(c.do(ctx, func(ctx context.Context) error {
+		return c.inner.MSet(ctx, pairs...)
+	}))
+}
+
+// Expire 设置过期时间，熔断期间跳过
+func (c *CircuitBreakerCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return skipWhenOpen(c.do(ctx, func(ctx context.Context) error {
+		return c.inner.Expire(ctx, key, expiration)
+	}))
+}
+
+// TTL 获取剩余过期时间
+func (c *CircuitBreakerCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	var ttl time.Duration
+	err := c.do(ctx, func(ctx context.Context) error {
+		var err error
+		ttl, err = c.inner.TTL(ctx, key)
+		return err
+	})
+	return ttl, err
+}
+
+// Ping 测试连接，不受熔断限制，结果计入熔断状态
+func (c *CircuitBreakerCache) Ping(ctx context.Context) error {
+	opCtx, cancel := context.WithTimeout(ctx, c.config.OperationTimeout)
+	defer cancel()
+
+	err := c.inner.Ping(opCtx)
+	c.record(err)
+	return err
+}
+
+// Close 关闭连接
+func (c *CircuitBreakerCache) Close() error {
+	return c.inner.Close()
+}
+
+// do 熔断打开时直接返回 ErrCircuitOpen，否则带超时执行操作并按配置重试
+func (c *CircuitBreakerCache) do(ctx context.Context, op func(ctx context.Context) error) error {
+	if !c.allow() {
+		return ErrCircuitOpen
+	}
+
+	var err error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		opCtx, cancel := context.WithTimeout(ctx, c.config.OperationTimeout)
+		err = op(opCtx)
+		cancel()
+		if !isCacheFailure(err) || ctx.Err() != nil {
+			break
+		}
+	}
+	c.record(err)
+	return err
+}
+
+// allow 判断是否放行请求，冷却结束后只放行一个探测请求
+func (c *CircuitBreakerCache) allow() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case breakerOpen:
+		if c.now().Before(c.openUntil) {
+			return false
+		}
+		c.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// record 记录操作结果，更新熔断状态，操作成功时先补删熔断期间记录的键
+func (c *CircuitBreakerCache) record(err error) {
+	if !isCacheFailure(err) {
+		err = c.flushPendingDeletes()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !isCacheFailure(err) {
+		if c.state != breakerClosed {
+			logger.Info("Cache circuit breaker closed, cache recovered")
+		}
+		c.state = breakerClosed
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	if c.state == breakerHalfOpen || c.failures >= c.config.FailureThreshold {
+		if c.state != breakerOpen {
+			logger.Warn("Cache circuit breaker opened, cache calls are skipped",
+				zap.Int("consecutive_failures", c.failures),
+				zap.Duration("cool_down", c.config.CoolDown),
+				zap.Error(err))
+		}
+		c.state = breakerOpen
+		c.openUntil = c.now().Add(c.config.CoolDown)
+	}
+}
+
+// deferDelete 记录熔断期间未能删除的键
+func (c *CircuitBreakerCache) deferDelete(keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pendingDels == nil {
+		c.pendingDels = make(map[string]struct{}, len(keys))
+	}
+	for _, key := range keys {
+		c.pendingDels[key] = struct{}{}
+	}
+}
+
+// flushPendingDeletes 补删熔断期间记录的键，失败时保留这些键等待下次恢复
+func (c *CircuitBreakerCache) flushPendingDeletes() error {
+	c.mu.Lock()
+	if len(c.pendingDels) == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	keys := make([]string, 0, len(c.pendingDels))
+	for key := range c.pendingDels {
+		keys = append(keys, key)
+	}
+	c.pendingDels = nil
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.OperationTimeout)
+	defer cancel()
+	err := c.inner.Del(ctx, keys...)
+	if isCacheFailure(err) {
+		c.deferDelete(keys)
+		return err
+	}
+	logger.Info("Flushed cache invalidations deferred while circuit breaker was open", zap.Int("keys", len(keys)))
+	return nil
+}
+
+// isCacheFailure 缓存未命中不算失败
+func isCacheFailure(err error) bool {
+	return err != nil && !errors.Is(err, redis.Nil)
+}
+
+// skipWhenOpen 写操作在熔断期间视为成功，缓存是可选的
+func skipWhenOpen(err error) error {
+	if errors.Is(err, ErrCircuitOpen) {
+		return nil
+	}
+	return err
+}
+
+// 确保实现了接口
+var _ Interface = (*CircuitBreakerCache)(nil)
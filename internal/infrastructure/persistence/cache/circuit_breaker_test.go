@@ -0,0 +1,264 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/pkg/logger"
+)
+
+// fakeCache 可控故障的缓存，down 时所有操作阻塞到超时后失败
+type fakeCache struct {
+	mu    sync.Mutex
+	data  map[string]string
+	down  bool
+	calls int
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{data: map[string]string{}}
+}
+
+func (f *fakeCache) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func (f *fakeCache) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// enter 记录调用，故障时模拟连接挂起直到超时
+func (f *fakeCache) enter(ctx context.Context) error {
+	f.mu.Lock()
+	f.calls++
+	down := f.down
+	f.mu.Unlock()
+
+	if down {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (f *fakeCache) Get(ctx context.Context, key string) (string, error) {
+	if err := f.enter(ctx); err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.data[key]
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (f *fakeCache) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	if err := f.enter(ctx); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[key] = value
+	return nil
+}
+
+func (f *fakeCache) Del(ctx context.Context, keys ...string) error {
+	if err := f.enter(ctx); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		delete(f.data, key)
+	}
+	return nil
+}
+
+func (f *fakeCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	return 0, f.enter(ctx)
+}
+
+func (f *fakeCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	return nil, f.enter(ctx)
+}
+
+func (f *fakeCache) MSet(ctx context.Context, pairs ...interface{}) error {
+	return f.enter(ctx)
+}
+
+func (f *fakeCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return f.enter(ctx)
+}
+
+func (f *fakeCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return 0, f.enter(ctx)
+}
+
+func (f *fakeCache) Ping(ctx context.Context) error {
+	return f.enter(ctx)
+}
+
+func (f *fakeCache) Close() error {
+	return nil
+}
+
+// newTestBreaker 创建使用可控时钟的熔断缓存
+func newTestBreaker(t *testing.T, inner Interface, config CircuitBreakerConfig) (*CircuitBreakerCache, *time.Time) {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreakerCache(inner, config)
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	inner := newFakeCache()
+	inner.setDown(true)
+	breaker, _ := newTestBreaker(t, inner, CircuitBreakerConfig{
+		FailureThreshold: 3,
+		CoolDown:         time.Minute,
+		OperationTimeout: 20 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := breaker.Get(ctx, "key")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	assert.Equal(t, 3, inner.callCount())
+
+	// 熔断后不再访问缓存，读操作立即返回，写操作视为成功
+	start := time.Now()
+	_, err := breaker.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.NoError(t, breaker.Set(ctx, "key", "value", time.Minute))
+	assert.NoError(t, breaker.Del(ctx, "key"))
+	assert.Less(t, time.Since(start), 20*time.Millisecond, "open breaker must not wait for the cache")
+	assert.Equal(t, 3, inner.callCount())
+}
+
+func TestCircuitBreaker_ClosesAfterRecovery(t *testing.T) {
+	inner := newFakeCache()
+	inner.setDown(true)
+	breaker, now := newTestBreaker(t, inner, CircuitBreakerConfig{
+		FailureThreshold: 2,
+		CoolDown:         time.Minute,
+		OperationTimeout: 20 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		require.Error(t, breaker.Set(ctx, "key", "value", time.Minute))
+	}
+	_, err := breaker.Get(ctx, "key")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	// 冷却期内即使缓存恢复也不探测
+	inner.setDown(false)
+	*now = now.Add(30 * time.Second)
+	_, err = breaker.Get(ctx, "key")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	// 冷却结束后放行探测请求，成功即恢复
+	*now = now.Add(31 * time.Second)
+	require.NoError(t, breaker.Set(ctx, "key", "value", time.Minute))
+	value, err := breaker.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestCircuitBreaker_FlushesDeletesDroppedWhileOpen(t *testing.T) {
+	inner := newFakeCache()
+	breaker, now := newTestBreaker(t, inner, CircuitBreakerConfig{
+		FailureThreshold: 2,
+		CoolDown:         time.Minute,
+		OperationTimeout: 20 * time.Millisecond,
+	})
+	ctx := context.Background()
+	require.NoError(t, breaker.Set(ctx, "project:1", "stale", time.Hour))
+
+	inner.setDown(true)
+	for i := 0; i < 2; i++ {
+		_, _ = breaker.Get(ctx, "project:1")
+	}
+	// 熔断期间的失效请求不报错，但不能丢失
+	require.NoError(t, breaker.Del(ctx, "project:1"))
+
+	// 缓存恢复后，探测请求成功时先补删，之后读不到旧值
+	inner.setDown(false)
+	*now = now.Add(time.Minute)
+	require.NoError(t, breaker.Set(ctx, "other", "value", time.Minute))
+	_, err := breaker.Get(ctx, "project:1")
+	assert.ErrorIs(t, err, redis.Nil)
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	inner := newFakeCache()
+	inner.setDown(true)
+	breaker, now := newTestBreaker(t, inner, CircuitBreakerConfig{
+		FailureThreshold: 2,
+		CoolDown:         time.Minute,
+		OperationTimeout: 20 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, _ = breaker.Get(ctx, "key")
+	}
+
+	*now = now.Add(time.Minute)
+	_, err := breaker.Get(ctx, "key")
+	require.ErrorIs(t, err, context.DeadlineExceeded, "probe must reach the cache")
+	calls := inner.callCount()
+
+	// 一次探测失败即重新熔断，冷却期重新计算
+	_, err = breaker.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, calls, inner.callCount())
+}
+
+func TestCircuitBreaker_CacheMissIsNotFailure(t *testing.T) {
+	inner := newFakeCache()
+	breaker, _ := newTestBreaker(t, inner, CircuitBreakerConfig{FailureThreshold: 1})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := breaker.Get(ctx, "missing")
+		assert.True(t, errors.Is(err, redis.Nil))
+	}
+	assert.Equal(t, 3, inner.callCount())
+}
+
+func TestCircuitBreaker_RetriesBeforeCountingFailure(t *testing.T) {
+	inner := newFakeCache()
+	inner.setDown(true)
+	breaker, _ := newTestBreaker(t, inner, CircuitBreakerConfig{
+		FailureThreshold: 2,
+		OperationTimeout: 10 * time.Millisecond,
+		MaxRetries:       2,
+	})
+	ctx := context.Background()
+
+	_, err := breaker.Get(ctx, "key")
+	require.Error(t, err)
+	assert.Equal(t, 3, inner.callCount(), "one attempt plus two retries")
+
+	_, err = breaker.Get(ctx, "key")
+	require.Error(t, err)
+	_, err = breaker.Get(ctx, "key")
+	assert.ErrorIs(t, err, ErrCircuitOpen, "each call counts as a single failure")
+}
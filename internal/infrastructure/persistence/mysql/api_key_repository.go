@@ -0,0 +1,151 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository 用户API密钥仓储实现
+type APIKeyRepository struct {
+	*BaseRepository
+}
+
+// NewAPIKeyRepository 创建用户API密钥仓储
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{BaseRepository: NewBaseRepository(db)}
+}
+
+var _ repository.APIKeyRepository = (*APIKeyRepository)(nil)
+
+// Create 新建API密钥，ID或哈希已存在时返回 ErrAlreadyExists
+func (r *APIKeyRepository) Create(ctx context.Context, key aggregate.APIKey) error {
+	var count int64
+	if err := r.GetDB(ctx).Model(&APIKey{}).Where("id = ? OR key_hash = ?", key.ID, key.KeyHash).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check api key existence: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("api key %s: %w", key.ID, repository.ErrAlreadyExists)
+	}
+
+	model, err := apiKeyToModel(key)
+	if err != nil {
+		return err
+	}
+	if err := r.GetDB(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+// Update 更新API密钥的名称、作用域、使用时间和吊销时间，不存在时返回 ErrNotFound
+func (r *APIKeyRepository) Update(ctx context.Context, key aggregate.APIKey) error {
+	model, err := apiKeyToModel(key)
+	if err != nil {
+		return err
+	}
+
+	result := r.GetDB(ctx).Model(&APIKey{}).Where("id = ?", key.ID).
+		Select("name", "scopes", "last_used_at", "revoked_at").Updates(model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// 内容未变化时 MySQL 也返回0行，需要区分记录是否存在
+		if _, err := r.FindByID(ctx, key.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByID 根据ID查找API密钥
+func (r *APIKeyRepository) FindByID(ctx context.Context, id string) (*aggregate.APIKey, error) {
+	return r.findOne(r.GetDB(ctx).Where("id = ?", id), id)
+}
+
+// FindByHash 根据密钥哈希查找API密钥
+func (r *APIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*aggregate.APIKey, error) {
+	return r.findOne(r.GetDB(ctx).Where("key_hash = ?", keyHash), "by hash")
+}
+
+// FindByUser 查找用户的全部API密钥，按创建时间排序
+func (r *APIKeyRepository) FindByUser(ctx context.Context, userID valueobject.UserID) ([]aggregate.APIKey, error) {
+	var models []APIKey
+	if err := r.GetDB(ctx).Where("user_id = ?", string(userID)).Order("created_at ASC, id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+
+	keys := make([]aggregate.APIKey, 0, len(models))
+	for _, model := range models {
+		key, err := modelToAPIKey(model)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) findOne(query *gorm.DB, ref string) (*aggregate.APIKey, error) {
+	var model APIKey
+	if err := query.First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("api key %s: %w", ref, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return modelToAPIKey(model)
+}
+
+// apiKeyToModel 转换为数据库模型，作用域以JSON数组存储
+func apiKeyToModel(k aggregate.APIKey) (*APIKey, error) {
+	scopes := k.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	data, err := json.Marshal(scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal api key scopes: %w", err)
+	}
+
+	return &APIKey{
+		ID:         k.ID,
+		UserID:     string(k.UserID),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		KeyHash:    k.KeyHash,
+		Scopes:     string(data),
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}, nil
+}
+
+// modelToAPIKey 转换为领域对象
+func modelToAPIKey(model APIKey) (*aggregate.APIKey, error) {
+	var scopes []string
+	if model.Scopes != "" {
+		if err := json.Unmarshal([]byte(model.Scopes), &scopes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal api key scopes: %w", err)
+		}
+	}
+
+	return &aggregate.APIKey{
+		ID:         model.ID,
+		UserID:     valueobject.UserID(model.UserID),
+		Name:       model.Name,
+		Prefix:     model.Prefix,
+		KeyHash:    model.KeyHash,
+		Scopes:     scopes,
+		LastUsedAt: model.LastUsedAt,
+		RevokedAt:  model.RevokedAt,
+		CreatedAt:  model.CreatedAt,
+	}, nil
+}
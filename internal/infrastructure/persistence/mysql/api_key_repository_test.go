@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+)
+
+func TestAPIKeyRepository_RoundTripByHash(t *testing.T) {
+	db := setupTestDB(t, &APIKey{})
+	repo := NewAPIKeyRepository(db)
+	ctx := context.Background()
+
+	key, raw, err := aggregate.NewAPIKey("key-1", "user-1", "ci", []string{"tasks:read"})
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, *key))
+	require.ErrorIs(t, repo.Create(ctx, *key), repository.ErrAlreadyExists)
+
+	found, err := repo.FindByHash(ctx, aggregate.HashAPIKey(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", found.ID)
+	assert.Equal(t, []string{"tasks:read"}, found.Scopes)
+	assert.Nil(t, found.RevokedAt)
+
+	// 使用时间和吊销状态可以更新
+	found.MarkUsed(time.Now())
+	found.Revoke()
+	require.NoError(t, repo.Update(ctx, *found))
+	keys, err := repo.FindByUser(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].IsRevoked())
+	assert.NotNil(t, keys[0].LastUsedAt)
+
+	_, err = repo.FindByHash(ctx, aggregate.HashAPIKey("tfk_unknown"))
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	missing, _, err := aggregate.NewAPIKey("key-2", "user-1", "other", []string{"tasks:read"})
+	require.NoError(t, err)
+	require.ErrorIs(t, repo.Update(ctx, *missing), repository.ErrNotFound)
+}
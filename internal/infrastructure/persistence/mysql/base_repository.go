@@ -2,8 +2,11 @@ package mysql
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
 	"gorm.io/gorm"
 )
 
@@ -18,15 +21,47 @@ func NewBaseRepository(db *gorm.DB) *BaseRepository {
 	return &BaseRepository{db: db}
 }
 
-// GetDB 从上下文获取数据库连接（自动支持事务）
+// GetDB 从上下文获取数据库连接（自动支持事务），返回的连接绑定ctx
 func (r *BaseRepository) GetDB(ctx context.Context) *gorm.DB {
 	// 尝试从上下文获取事务实例
 	if tx, ok := ctx.Value(shared.TransactionKey).(*gorm.DB); ok {
 		// 如果在事务中，使用事务连接
-		return tx
+		return tx.WithContext(ctx)
 	}
 	// 如果不在事务中，使用普通连接
-	return r.db
+	return r.db.WithContext(ctx)
+}
+
+// auditUser 获取写操作的修改人：优先取上下文中的当前操作用户，
+// 未设置时（如后台任务）依次使用给定的候选值
+func auditUser(ctx context.Context, fallbacks ...valueobject.UserID) valueobject.UserID {
+	if actor := shared.ActorFromContext(ctx); actor != "" {
+		return valueobject.UserID(actor)
+	}
+	for _, userID := range fallbacks {
+		if userID != "" {
+			return userID
+		}
+	}
+	return ""
+}
+
+// nullableUserID 空用户ID写入NULL
+func nullableUserID(userID valueobject.UserID) *string {
+	if userID == "" {
+		return nil
+	}
+	id := string(userID)
+	return &id
+}
+
+// softDeleteColumns 软删除时写入的列，有当前操作用户时同时记录修改人
+func softDeleteColumns(ctx context.Context, deletedAt time.Time) map[string]interface{} {
+	columns := map[string]interface{}{"deleted_at": deletedAt}
+	if actor := shared.ActorFromContext(ctx); actor != "" {
+		columns["updated_by"] = actor
+	}
+	return columns
 }
 
 // 为什么这样设计？
@@ -45,3 +80,23 @@ func (r *BaseRepository) GetDB(ctx context.Context) *gorm.DB {
 //    - 所有Repository都嵌入BaseRepository
 //    - 自动获得事务支持能力
 //    - 代码复用，减少重复
+
+// likeEscaper 转义 LIKE 通配符，关键字中的 % 和 _ 按字面匹配
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// whereLike 按关键字添加 LIKE 条件，关键字规范化后过短时不加条件，避免 '%%' 全表扫描返回全部记录
+// prefix 为 true 时按前缀匹配，可以使用列上的索引；否则为包含匹配
+func whereLike(query *gorm.DB, column string, term *string, prefix bool) *gorm.DB {
+	if term == nil {
+		return query
+	}
+	normalized, ok := valueobject.NormalizeSearchTerm(*term)
+	if !ok {
+		return query
+	}
+	pattern := likeEscaper.Replace(normalized) + "%"
+	if !prefix {
+		pattern = "%" + pattern
+	}
+	return query.Where(column+" LIKE ?", pattern)
+}
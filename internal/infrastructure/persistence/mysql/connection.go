@@ -1,23 +1,52 @@
 package mysql
 
 import (
+	"database/sql"
+	"expvar"
 	"fmt"
 	"time"
 
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/infrastructure/config"
 	appLogger "github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
-// NewDatabase 创建数据库连接
+// NewDatabase 创建数据库连接，配置了只读副本时同时连接副本并启用读写分离
 func NewDatabase(config *config.DatabaseConfig) (*gorm.DB, error) {
-	//get mysql conn
-	dsn := config.GetDSN()
+	db, err := openDatabase(config.GetDSN(), config)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, _ := db.DB()
+	PublishPoolMetrics(sqlDB)
+
+	replicas := make([]*gorm.DB, 0, len(config.Replicas))
+	for _, replica := range config.Replicas {
+		replicaDB, err := openDatabase(config.GetReplicaDSN(replica), config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to read replica %s: %w", replica.Host, err)
+		}
+		replicas = append(replicas, replicaDB)
+	}
+	if err := UseReadReplicas(db, replicas...); err != nil {
+		return nil, err
+	}
+
+	appLogger.Info("Database connected successfully", zap.Int("replicas", len(replicas)))
+	return db, nil
+}
 
+// openDatabase 建立单个数据库连接并按配置设置连接池
+func openDatabase(dsn string, config *config.DatabaseConfig) (*gorm.DB, error) {
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: NewQueryLogger(time.Duration(config.SlowQueryThresholdMs) * time.Millisecond),
+		// 自动维护的时间戳统一使用UTC
+		NowFunc:        shared.NowUTC,
+		NamingStrategy: NamingStrategy(config),
 	})
 
 	if err != nil {
@@ -30,16 +59,67 @@ func NewDatabase(config *config.DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	// 设置连接池参数
-	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
-	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
-	sqlDB.SetConnMaxLifetime(time.Duration(config.ConnMaxLifetime) * time.Second)
+	ConfigurePool(sqlDB, config)
 
 	// 测试连接
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	appLogger.Info("Database connected successfully")
 	return db, nil
 }
+
+// NamingStrategy 按配置的表前缀生成命名策略，模型的固定表名同样会加上前缀
+func NamingStrategy(config *config.DatabaseConfig) schema.NamingStrategy {
+	return schema.NamingStrategy{TablePrefix: config.TablePrefix}
+}
+
+// ConfigurePool 按配置设置连接池参数，时长单位为秒，0 表示不限制
+func ConfigurePool(sqlDB *sql.DB, config *config.DatabaseConfig) {
+	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(config.ConnMaxLifetime) * time.Second)
+	sqlDB.SetConnMaxIdleTime(time.Duration(config.ConnMaxIdleTime) * time.Second)
+}
+
+// PoolMetricsName 连接池指标在 expvar 中的名称，通过 /debug/vars 暴露
+const PoolMetricsName = "database_pool"
+
+// PoolStats 连接池指标
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+// NewPoolStats 从 sql.DBStats 生成连接池指标
+func NewPoolStats(stats sql.DBStats) PoolStats {
+	return PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
+
+// PublishPoolMetrics 将连接池指标发布到 expvar，读取时实时采集
+// 同一进程只发布一次，重复调用不会覆盖已发布的连接池
+func PublishPoolMetrics(sqlDB *sql.DB) {
+	if expvar.Get(PoolMetricsName) != nil {
+		return
+	}
+	expvar.Publish(PoolMetricsName, expvar.Func(func() any {
+		return NewPoolStats(sqlDB.Stats())
+	}))
+}
@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/config"
+)
+
+func TestConfigurePool_AppliesMaxOpenConns(t *testing.T) {
+	// sql.Open 不建立连接，无需可用的数据库
+	sqlDB, err := sql.Open("mysql", "user:pass@tcp(127.0.0.1:1)/taskflow")
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	ConfigurePool(sqlDB, &config.DatabaseConfig{MaxOpenConns: 7, MaxIdleConns: 3, ConnMaxLifetime: 60, ConnMaxIdleTime: 30})
+
+	assert.Equal(t, 7, sqlDB.Stats().MaxOpenConnections)
+}
+
+func TestPublishPoolMetrics_ExposesPoolStats(t *testing.T) {
+	sqlDB, err := sql.Open("mysql", "user:pass@tcp(127.0.0.1:1)/taskflow")
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	ConfigurePool(sqlDB, &config.DatabaseConfig{MaxOpenConns: 5})
+
+	PublishPoolMetrics(sqlDB)
+	PublishPoolMetrics(sqlDB) // 重复发布不应 panic
+
+	published := expvar.Get(PoolMetricsName)
+	require.NotNil(t, published)
+	var stats PoolStats
+	require.NoError(t, json.Unmarshal([]byte(published.String()), &stats))
+	assert.Equal(t, 5, stats.MaxOpenConnections)
+	assert.Zero(t, stats.InUse)
+}
+
+func TestNamingStrategy_PrefixesModelTables(t *testing.T) {
+	setupLogger(t)
+	const prefix = "tenant_a_"
+	db := setupTestDBWithPrefix(t, prefix, &Project{}, &ProjectMember{}, &DomainEvent{}, &Role{}, &UserRole{})
+	ctx := context.Background()
+
+	for _, table := range []string{"tenant_a_projects", "tenant_a_project_members", "tenant_a_roles", "tenant_a_user_roles"} {
+		assert.True(t, db.Migrator().HasTable(table), "expected prefixed table %s", table)
+	}
+	assert.Equal(t, "tenant_a_schema_migrations", prefixedTable(db.NamingStrategy, "schema_migrations"))
+
+	// 原生SQL查询同样使用带前缀的表
+	projects := NewProjectRepository(db, nil)
+	proj := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	proj.Members = append(proj.Members, valueobject.ProjectMember{
+		UserID: "user-1", Role: valueobject.ProjectRoleMember, JoinedAt: date(2024, 1, 15), AddedBy: "owner-1",
+	})
+	require.NoError(t, projects.Create(ctx, *proj))
+	found, err := projects.FindByMember(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p-1"}, foundIDs(found))
+
+	require.NoError(t, db.Create(&Role{ID: "role-1", Name: "auditor", DisplayName: "Auditor"}).Error)
+	userRoles := NewUserRoleRepository(db)
+	require.NoError(t, userRoles.AssignRole(ctx, "user-1", "role-1"))
+	roles, err := userRoles.FindRolesByUser(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "auditor", roles[0].Name)
+}
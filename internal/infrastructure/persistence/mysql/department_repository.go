@@ -2,8 +2,10 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/taskflow/internal/domain/repository"
 	"github.com/taskflow/internal/domain/valueobject"
 	"gorm.io/gorm"
 )
@@ -43,8 +45,8 @@ func (r *DepartmentRepositoryImpl) FindByUserID(ctx context.Context, userID valu
 		First(&departmentID).Error
 	
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user department not found: %s", userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("department of user %s: %w", userID, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to find user department: %w", err)
 	}
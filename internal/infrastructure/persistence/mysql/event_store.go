@@ -0,0 +1,173 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/event"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventStoreImpl 基于 domain_events 表的事件存储实现
+type EventStoreImpl struct {
+	*BaseRepository
+}
+
+// NewEventStore 创建事件存储
+func NewEventStore(db *gorm.DB) *EventStoreImpl {
+	return &EventStoreImpl{BaseRepository: NewBaseRepository(db)}
+}
+
+var (
+	_ event.EventStore      = (*EventStoreImpl)(nil)
+	_ event.EventQueryStore = (*EventStoreImpl)(nil)
+)
+
+// Append 在当前上下文（含事务）中写入事件，触发用户写入 user_id
+// 已存在的事件ID会被忽略，重复保存同一聚合的事件是安全的
+func (s *EventStoreImpl) Append(ctx context.Context, events ...event.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	models := make([]DomainEvent, 0, len(events))
+	for _, e := range events {
+		model, err := s.toModel(e)
+		if err != nil {
+			return err
+		}
+		models = append(models, model)
+	}
+
+	if err := s.GetDB(ctx).Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to save domain events: %w", err)
+	}
+	return nil
+}
+
+// Save 保存单个事件，实现 event.EventStore 接口
+func (s *EventStoreImpl) Save(e event.DomainEvent) error {
+	return s.Append(context.Background(), e)
+}
+
+// GetEvents 获取聚合从指定版本开始的事件，按发生时间排序
+func (s *EventStoreImpl) GetEvents(aggregateID string, fromVersion int) ([]event.DomainEvent, error) {
+	var models []DomainEvent
+	err := s.GetDB(context.Background()).
+		Where("aggregate_id = ? AND event_version >= ?", aggregateID, fromVersion).
+		Order("occurred_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load domain events: %w", err)
+	}
+	return toStoredEvents(models), nil
+}
+
+// GetEventsByType 获取指定类型的最近事件
+func (s *EventStoreImpl) GetEventsByType(eventType string, limit int) ([]event.DomainEvent, error) {
+	query := s.GetDB(context.Background()).
+		Where("event_type = ?", eventType).
+		Order("occurred_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var models []DomainEvent
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to load domain events: %w", err)
+	}
+	return toStoredEvents(models), nil
+}
+
+// FindEvents 按类型、聚合和时间窗口查询事件，按发生时间、事件ID升序（同一时刻的事件用ID翻页），Descending 时倒序
+func (s *EventStoreImpl) FindEvents(ctx context.Context, query event.EventQuery) ([]event.DomainEvent, error) {
+	db := s.GetDB(ctx)
+	if len(query.Types) > 0 {
+		db = db.Where("event_type IN ?", query.Types)
+	}
+	if query.AggregateID != "" {
+		db = db.Where("aggregate_id = ?", query.AggregateID)
+	}
+	if !query.Since.IsZero() {
+		since := query.Since.UTC()
+		if query.AfterID != "" {
+			db = db.Where("occurred_at > ? OR (occurred_at = ? AND id > ?)", since, since, query.AfterID)
+		} else {
+			db = db.Where("occurred_at >= ?", since)
+		}
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	order := "occurred_at ASC, id ASC"
+	if query.Descending {
+		order = "occurred_at DESC, id DESC"
+	}
+
+	var models []DomainEvent
+	if err := db.Order(order).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to query domain events: %w", err)
+	}
+	return toStoredEvents(models), nil
+}
+
+// toModel 领域事件转换为持久化模型
+func (s *EventStoreImpl) toModel(e event.DomainEvent) (DomainEvent, error) {
+	data := e.EventData()
+	if data == nil {
+		data = e
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return DomainEvent{}, fmt.Errorf("failed to serialize event %s: %w", e.EventID(), err)
+	}
+
+	model := DomainEvent{
+		ID:            e.EventID(),
+		EventType:     e.EventType(),
+		AggregateID:   e.AggregateID(),
+		AggregateType: e.AggregateType(),
+		EventData:     string(payload),
+		EventVersion:  e.Version(),
+		OccurredAt:    e.OccurredAt().UTC(),
+	}
+	if actor, ok := e.(event.ActorEvent); ok && actor.ActorID() != "" {
+		actorID := actor.ActorID()
+		model.UserID = &actorID
+	}
+	return model, nil
+}
+
+// storedEvent 从存储中读取的事件，事件数据保持为原始JSON
+type storedEvent struct {
+	model DomainEvent
+}
+
+func toStoredEvents(models []DomainEvent) []event.DomainEvent {
+	events := make([]event.DomainEvent, len(models))
+	for i, model := range models {
+		events[i] = storedEvent{model: model}
+	}
+	return events
+}
+
+func (e storedEvent) EventID() string        { return e.model.ID }
+func (e storedEvent) EventType() string      { return e.model.EventType }
+func (e storedEvent) AggregateID() string    { return e.model.AggregateID }
+func (e storedEvent) AggregateType() string  { return e.model.AggregateType }
+func (e storedEvent) OccurredAt() time.Time  { return e.model.OccurredAt }
+func (e storedEvent) EventData() interface{} { return json.RawMessage(e.model.EventData) }
+func (e storedEvent) Version() int           { return e.model.EventVersion }
+
+// ActorID 实现 event.ActorEvent 接口
+func (e storedEvent) ActorID() string {
+	if e.model.UserID == nil {
+		return ""
+	}
+	return *e.model.UserID
+}
@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/event"
+)
+
+func TestEventStore_TaskStatusChangedPersistsActor(t *testing.T) {
+	db := setupTestDB(t, &DomainEvent{})
+	store := NewEventStore(db)
+
+	statusChanged := event.NewTaskStatusChangedEvent("task-1", "draft", "in_progress", "user-42", "start work")
+	require.NoError(t, store.Save(statusChanged))
+
+	var stored DomainEvent
+	require.NoError(t, db.Where("id = ?", statusChanged.EventID()).First(&stored).Error)
+	require.NotNil(t, stored.UserID)
+	assert.Equal(t, "user-42", *stored.UserID)
+	assert.Equal(t, "TaskStatusChanged", stored.EventType)
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stored.EventData), &payload))
+	assert.Equal(t, "in_progress", payload["new_status"])
+
+	events, err := store.GetEvents("task-1", 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	actor, ok := events[0].(event.ActorEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user-42", actor.ActorID())
+}
+
+func TestEventStore_SystemEventHasNoActor(t *testing.T) {
+	db := setupTestDB(t, &DomainEvent{})
+	store := NewEventStore(db)
+
+	completed := event.NewAllParticipantsCompletedEvent("task-1", []string{"user-1"}, 1)
+	require.NoError(t, store.Append(context.Background(), completed))
+
+	var stored DomainEvent
+	require.NoError(t, db.Where("id = ?", completed.EventID()).First(&stored).Error)
+	assert.Nil(t, stored.UserID)
+}
+
+func TestEventStore_FindEventsFiltersByTypeAndSince(t *testing.T) {
+	db := setupTestDB(t, &DomainEvent{})
+	store := NewEventStore(db)
+	ctx := context.Background()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, m := range []DomainEvent{
+		{ID: "evt-1", EventType: "TaskCompleted", OccurredAt: base},
+		{ID: "evt-2", EventType: "TaskRejected", OccurredAt: base.Add(time.Hour)},
+		{ID: "evt-3", EventType: "TaskCompleted", OccurredAt: base.Add(2 * time.Hour)},
+		{ID: "evt-4", EventType: "TaskCompleted", OccurredAt: base.Add(2 * time.Hour)},
+	} {
+		m.AggregateID, m.AggregateType, m.EventData, m.EventVersion = "task-1", "Task", `{}`, 1
+		require.NoError(t, db.Create(&m).Error)
+	}
+	ids := func(events []event.DomainEvent) []string {
+		result := make([]string, len(events))
+		for i, e := range events {
+			result[i] = e.EventID()
+		}
+		return result
+	}
+
+	events, err := store.FindEvents(ctx, event.EventQuery{Types: []string{"TaskCompleted"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-1", "evt-3", "evt-4"}, ids(events))
+
+	events, err = store.FindEvents(ctx, event.EventQuery{Types: []string{"TaskCompleted", "TaskRejected"}, Since: base.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-2", "evt-3", "evt-4"}, ids(events), "since is inclusive")
+
+	events, err = store.FindEvents(ctx, event.EventQuery{Since: base.Add(2 * time.Hour), AfterID: "evt-3", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-4"}, ids(events))
+
+	events, err = store.FindEvents(ctx, event.EventQuery{AggregateID: "task-1", Limit: 2, Descending: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-4", "evt-3"}, ids(events), "descending keeps the newest events")
+
+	other := DomainEvent{ID: "evt-5", EventType: "TaskCompleted", OccurredAt: base, AggregateID: "task-2", AggregateType: "Task", EventData: `{}`, EventVersion: 1}
+	require.NoError(t, db.Create(&other).Error)
+	events, err = store.FindEvents(ctx, event.EventQuery{AggregateID: "task-2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-5"}, ids(events))
+}
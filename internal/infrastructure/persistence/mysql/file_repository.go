@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+)
+
+// FileRepository 文件元数据仓储实现
+type FileRepository struct {
+	*BaseRepository
+}
+
+// NewFileRepository 创建文件元数据仓储
+func NewFileRepository(db *gorm.DB) *FileRepository {
+	return &FileRepository{BaseRepository: NewBaseRepository(db)}
+}
+
+var _ repository.FileRepository = (*FileRepository)(nil)
+
+// FindByIDs 查找文件元数据，忽略不存在或已删除的文件
+func (r *FileRepository) FindByIDs(ctx context.Context, ids []string) ([]valueobject.FileInfo, error) {
+	if len(ids) == 0 {
+		return []valueobject.FileInfo{}, nil
+	}
+
+	var models []File
+	if err := r.GetDB(ctx).Where("id IN ?", ids).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find files: %w", err)
+	}
+
+	files := make([]valueobject.FileInfo, len(models))
+	for i, model := range models {
+		files[i] = valueobject.FileInfo{
+			ID:           model.ID,
+			UploaderID:   valueobject.UserID(model.UploaderID),
+			UploadStatus: valueobject.FileUploadStatus(model.UploadStatus),
+		}
+	}
+	return files, nil
+}
+
+// FindAssociatedFileIDs 查找已关联到资源的文件ID
+func (r *FileRepository) FindAssociatedFileIDs(ctx context.Context, resourceType, resourceID string) ([]string, error) {
+	var fileIDs []string
+	err := r.GetDB(ctx).Model(&FileAssociation{}).
+		Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Pluck("file_id", &fileIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find file associations: %w", err)
+	}
+	return fileIDs, nil
+}
+
+// AssociateAttachments 将文件作为附件关联到资源，已关联的文件跳过
+func (r *FileRepository) AssociateAttachments(ctx context.Context, fileIDs []string, resourceType, resourceID string) error {
+	if len(fileIDs) == 0 {
+		return nil
+	}
+
+	existing, err := r.FindAssociatedFileIDs(ctx, resourceType, resourceID)
+	if err != nil {
+		return err
+	}
+	associated := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		associated[id] = true
+	}
+
+	var models []FileAssociation
+	for _, fileID := range fileIDs {
+		if associated[fileID] {
+			continue
+		}
+		associated[fileID] = true
+		models = append(models, FileAssociation{
+			ID:              uuid.New().String(),
+			FileID:          fileID,
+			ResourceType:    resourceType,
+			ResourceID:      resourceID,
+			AssociationType: "attachment",
+		})
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	if err := r.GetDB(ctx).Omit("File").Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to create file associations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestFileRepository_FindByIDsSkipsDeletedFiles(t *testing.T) {
+	db := setupTestDB(t, &File{}, &FileAssociation{})
+	repo := NewFileRepository(db)
+
+	for _, f := range []File{
+		{ID: "file-1", UploaderID: "user-1", UploadStatus: "completed"},
+		{ID: "file-2", UploaderID: "user-2", UploadStatus: "uploading"},
+		{ID: "file-deleted", UploaderID: "user-1", UploadStatus: "completed",
+			DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+	} {
+		f.Filename, f.OriginalName, f.FileType, f.FileSize = f.ID, f.ID, "document", 1
+		f.FilePath, f.MimeType, f.MD5Hash = "/files/"+f.ID, "text/plain", "d41d8cd98f00b204e9800998ecf8427e"
+		require.NoError(t, db.Omit(clause.Associations).Create(&f).Error)
+	}
+
+	files, err := repo.FindByIDs(context.Background(), []string{"file-1", "file-2", "file-deleted", "file-missing"})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []valueobject.FileInfo{
+		{ID: "file-1", UploaderID: "user-1", UploadStatus: valueobject.FileUploadStatusCompleted},
+		{ID: "file-2", UploaderID: "user-2", UploadStatus: valueobject.FileUploadStatusUploading},
+	}, files)
+}
+
+func TestFileRepository_AssociateAttachmentsSkipsExisting(t *testing.T) {
+	db := setupTestDB(t, &File{}, &FileAssociation{})
+	repo := NewFileRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.AssociateAttachments(ctx, []string{"file-1"}, "task", "task-1"))
+	require.NoError(t, repo.AssociateAttachments(ctx, []string{"file-1", "file-2", "file-2"}, "task", "task-1"))
+	require.NoError(t, repo.AssociateAttachments(ctx, []string{"file-3"}, "task", "task-2"))
+
+	fileIDs, err := repo.FindAssociatedFileIDs(ctx, "task", "task-1")
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"file-1", "file-2"}, fileIDs)
+	var count int64
+	require.NoError(t, db.Model(&FileAssociation{}).Where("association_type = ?", "attachment").Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+}
@@ -28,8 +28,10 @@ func (m *Migrator) ValidateModels() error {
 		&UserModel{}, &Role{}, &Permission{}, &UserRole{}, &PermissionPolicy{},
 		&Project{}, &ProjectMember{},
 		&Task{}, &TaskParticipant{}, &RecurrenceRule{}, &TaskExecution{}, &ParticipantCompletion{},
-		&ApprovalRecord{}, &ExtensionRequest{},
+		&ApprovalRecord{}, &ExtensionRequest{}, &WorkSubmission{},
 		&DomainEvent{}, &OperationLog{},
+		&Webhook{}, &WebhookDelivery{}, &APIKey{},
+		&Notification{}, &TaskReminder{}, &TaskReminderAck{},
 		&File{}, &FileAssociation{},
 	}
 
@@ -79,10 +81,10 @@ func (m *Migrator) validateModel(model interface{}) error {
 	return nil
 }
 
-// getTableName 获取表名
+// getTableName 获取表名（含表前缀）
 func (m *Migrator) getTableName(model interface{}) string {
-	if tabler, ok := model.(interface{ TableName() string }); ok {
-		return tabler.TableName()
+	if name := tableName(m.db, model); name != "" {
+		return name
 	}
 	return m.db.NamingStrategy.TableName(reflect.TypeOf(model).Elem().Name())
 }
@@ -150,8 +152,10 @@ func (m *Migrator) SyncModels(isDevelopment bool) error {
 		&UserModel{}, &Role{}, &Permission{}, &UserRole{}, &PermissionPolicy{},
 		&Project{}, &ProjectMember{},
 		&Task{}, &TaskParticipant{}, &RecurrenceRule{}, &TaskExecution{}, &ParticipantCompletion{},
-		&ApprovalRecord{}, &ExtensionRequest{},
+		&ApprovalRecord{}, &ExtensionRequest{}, &WorkSubmission{},
 		&DomainEvent{}, &OperationLog{},
+		&Webhook{}, &WebhookDelivery{}, &APIKey{},
+		&Notification{}, &TaskReminder{}, &TaskReminderAck{},
 		&File{}, &FileAssociation{},
 	}
 
@@ -272,12 +276,12 @@ func (m *Migrator) CheckMigrationStatus() error {
 
 // createMigrationTable 创建迁移状态表
 func (m *Migrator) createMigrationTable() error {
-	sql := `
-	CREATE TABLE IF NOT EXISTS schema_migrations (
+	sql := fmt.Sprintf(`
+	CREATE TABLE IF NOT EXISTS %s (
 		version VARCHAR(255) PRIMARY KEY,
 		executed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='数据库迁移记录表';
-	`
+	`, prefixedTable(m.db.NamingStrategy, "schema_migrations"))
 
 	return m.db.Exec(sql).Error
 }
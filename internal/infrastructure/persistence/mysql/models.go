@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 // ================================================
@@ -93,9 +94,13 @@ type Project struct {
 	Status          string         `gorm:"type:enum('draft','active','paused','completed','cancelled');default:'draft'" json:"status"`
 	StartDate       *time.Time     `gorm:"type:date" json:"start_date"`
 	EndDate         *time.Time     `gorm:"type:date" json:"end_date"`
+	TaskCount       int            `gorm:"not null;default:0" json:"task_count"`
+	CompletedTasks  int            `gorm:"not null;default:0" json:"completed_tasks"`
 	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	CreatedBy       *string        `gorm:"type:varchar(36)" json:"created_by"`
+	UpdatedBy       *string        `gorm:"type:varchar(36)" json:"updated_by"`
 
 	// 关联关系
 	ParentProject *Project        `gorm:"foreignKey:ParentProjectID" json:"parent_project,omitempty"`
@@ -127,23 +132,30 @@ type ProjectMember struct {
 
 // Task 任务模型
 type Task struct {
-	ID             string         `gorm:"type:varchar(36);primaryKey" json:"id"`
-	Title          string         `gorm:"type:varchar(300);not null" json:"title"`
-	Description    *string        `gorm:"type:text" json:"description"`
-	TaskType       string         `gorm:"type:enum('single_execution','recurring');not null" json:"task_type"`
-	Priority       string         `gorm:"type:enum('low','normal','high','urgent');default:'normal'" json:"priority"`
-	ProjectID      string         `gorm:"type:varchar(36);not null" json:"project_id"`
-	CreatorID      string         `gorm:"type:varchar(36);not null" json:"creator_id"`
-	ResponsibleID  string         `gorm:"type:varchar(36);not null" json:"responsible_id"`
-	Status         string         `gorm:"type:enum('draft','pending_approval','approved','in_progress','pending_final_review','completed','rejected','cancelled','paused');default:'draft'" json:"status"`
-	StartDate      *time.Time     `gorm:"type:timestamp" json:"start_date"`
-	DueDate        *time.Time     `gorm:"type:timestamp" json:"due_date"`
-	CompletedAt    *time.Time     `gorm:"type:timestamp" json:"completed_at"`
-	EstimatedHours int            `gorm:"default:0" json:"estimated_hours"`
-	WorkflowID     *string        `gorm:"type:varchar(36)" json:"workflow_id"`
-	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              string         `gorm:"type:varchar(36);primaryKey" json:"id"`
+	Title           string         `gorm:"type:varchar(300);not null" json:"title"`
+	Description     *string        `gorm:"type:text" json:"description"`
+	TaskType        string         `gorm:"type:enum('single_execution','recurring');not null" json:"task_type"`
+	Priority        string         `gorm:"type:enum('low','normal','high','urgent');default:'normal'" json:"priority"`
+	ProjectID       string         `gorm:"type:varchar(36);not null" json:"project_id"`
+	CreatorID       string         `gorm:"type:varchar(36);not null" json:"creator_id"`
+	ResponsibleID   string         `gorm:"type:varchar(36);not null" json:"responsible_id"`
+	Status          string         `gorm:"type:enum('draft','pending_approval','approved','in_progress','pending_final_review','completed','rejected','cancelled','paused');default:'draft'" json:"status"`
+	StartDate       *time.Time     `gorm:"type:timestamp" json:"start_date"`
+	DueDate         *time.Time     `gorm:"type:timestamp" json:"due_date"`
+	CompletedAt     *time.Time     `gorm:"type:timestamp" json:"completed_at"`
+	ApprovedAt      *time.Time     `gorm:"type:timestamp;index" json:"approved_at"`
+	StatusChangedAt *time.Time     `gorm:"type:timestamp" json:"status_changed_at"`
+	EstimatedHours  int            `gorm:"default:0" json:"estimated_hours"`
+	WorkflowID      *string        `gorm:"type:varchar(36)" json:"workflow_id"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	UpdatedBy       *string        `gorm:"type:varchar(36)" json:"updated_by"`
+
+	// 开放协作
+	OpenContribution bool `gorm:"default:false" json:"open_contribution"`
+	PausedByProject  bool `gorm:"not null;default:false" json:"paused_by_project"`
 
 	// 关联关系
 	Project          Project            `gorm:"foreignKey:ProjectID" json:"project,omitempty"`
@@ -162,6 +174,7 @@ type TaskParticipant struct {
 	ID      string    `gorm:"type:varchar(36);primaryKey" json:"id"`
 	TaskID  string    `gorm:"type:varchar(36);not null;uniqueIndex:idx_task_user" json:"task_id"`
 	UserID  string    `gorm:"type:varchar(36);not null;uniqueIndex:idx_task_user" json:"user_id"`
+	Role    string    `gorm:"type:varchar(20);not null;default:'executor'" json:"role"`
 	AddedAt time.Time `gorm:"autoCreateTime" json:"added_at"`
 	AddedBy string    `gorm:"type:varchar(36);not null" json:"added_by"`
 
@@ -260,6 +273,23 @@ type ExtensionRequest struct {
 	Reviewer  *UserModel `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
 }
 
+// WorkSubmission 工作提交记录模型
+type WorkSubmission struct {
+	ID            string     `gorm:"type:varchar(36);primaryKey" json:"id"`
+	TaskID        string     `gorm:"type:varchar(36);not null;index:idx_work_submissions_task_time,priority:1" json:"task_id"`
+	SubmitterID   string     `gorm:"type:varchar(36);not null" json:"submitter_id"`
+	Content       string     `gorm:"type:text;not null" json:"content"`
+	Attachments   string     `gorm:"type:json;not null" json:"attachments"`
+	Status        string     `gorm:"type:enum('pending','approved','rejected');default:'pending'" json:"status"`
+	SubmittedAt   time.Time  `gorm:"not null;index:idx_work_submissions_task_time,priority:2" json:"submitted_at"`
+	ReviewedAt    *time.Time `gorm:"type:timestamp" json:"reviewed_at"`
+	ReviewerID    *string    `gorm:"type:varchar(36)" json:"reviewer_id"`
+	ReviewComment *string    `gorm:"type:text" json:"review_comment"`
+
+	// ReviewDecisions 多人审核时各审核人的意见
+	ReviewDecisions *string `gorm:"type:json" json:"review_decisions"`
+}
+
 // ================================================
 // 事件和日志相关模型
 // ================================================
@@ -283,6 +313,7 @@ type DomainEvent struct {
 type OperationLog struct {
 	ID             string    `gorm:"type:varchar(36);primaryKey" json:"id"`
 	UserID         *string   `gorm:"type:varchar(36)" json:"user_id"`
+	ImpersonatorID *string   `gorm:"type:varchar(36);index" json:"impersonator_id"` // 模拟登录时实际操作的管理员
 	Operation      string    `gorm:"type:varchar(100);not null" json:"operation"`
 	ResourceType   string    `gorm:"type:varchar(50);not null" json:"resource_type"`
 	ResourceID     string    `gorm:"type:varchar(36);not null" json:"resource_id"`
@@ -296,6 +327,92 @@ type OperationLog struct {
 	User *UserModel `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
 
+// ================================================
+// Webhook相关模型
+// ================================================
+
+// Webhook webhook订阅模型
+type Webhook struct {
+	ID           string     `gorm:"type:varchar(36);primaryKey" json:"id"`
+	URL          string     `gorm:"type:varchar(500);not null" json:"url"`
+	Secret       string     `gorm:"type:varchar(255);not null" json:"-"`
+	EventTypes   string     `gorm:"type:json;not null" json:"event_types"`
+	Active       bool       `gorm:"not null;index" json:"active"`
+	FailureCount int        `gorm:"default:0" json:"failure_count"`
+	CreatedBy    string     `gorm:"type:varchar(36);not null" json:"created_by"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	DisabledAt   *time.Time `json:"disabled_at"`
+}
+
+// WebhookDelivery webhook投递记录模型
+type WebhookDelivery struct {
+	ID          string    `gorm:"type:varchar(36);primaryKey" json:"id"`
+	DeliveryID  string    `gorm:"type:varchar(36);not null;index" json:"delivery_id"`
+	WebhookID   string    `gorm:"type:varchar(36);not null;index:idx_webhook_deliveries_webhook_time,priority:1" json:"webhook_id"`
+	EventID     string    `gorm:"type:varchar(36);not null" json:"event_id"`
+	EventType   string    `gorm:"type:varchar(100);not null" json:"event_type"`
+	Attempt     int       `gorm:"not null" json:"attempt"`
+	Redelivery  bool      `gorm:"not null" json:"redelivery"`
+	Payload     *string   `gorm:"type:mediumtext" json:"payload"`
+	StatusCode  int       `gorm:"default:0" json:"status_code"`
+	Success     bool      `gorm:"default:false" json:"success"`
+	Error       *string   `gorm:"type:text" json:"error"`
+	DurationMs  int64     `gorm:"default:0" json:"duration_ms"`
+	DeliveredAt time.Time `gorm:"not null;index:idx_webhook_deliveries_webhook_time,priority:2" json:"delivered_at"`
+}
+
+// ================================================
+// API密钥模型
+// ================================================
+
+// APIKey 用户API密钥模型，只保存密钥哈希
+type APIKey struct {
+	ID         string     `gorm:"type:varchar(36);primaryKey" json:"id"`
+	UserID     string     `gorm:"type:varchar(36);not null;index" json:"user_id"`
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	Prefix     string     `gorm:"type:varchar(20);not null" json:"prefix"`
+	KeyHash    string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	Scopes     string     `gorm:"type:json;not null" json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ================================================
+// 站内通知模型
+// ================================================
+
+// Notification 站内通知模型
+type Notification struct {
+	ID           string     `gorm:"type:varchar(36);primaryKey" json:"id"`
+	UserID       string     `gorm:"type:varchar(36);not null;index:idx_notifications_user_read,priority:1" json:"user_id"`
+	Type         string     `gorm:"type:varchar(100);not null" json:"type"`
+	Title        string     `gorm:"type:varchar(255);not null" json:"title"`
+	Body         string     `gorm:"type:text;not null" json:"body"`
+	ResourceType *string    `gorm:"type:varchar(50)" json:"resource_type"`
+	ResourceID   *string    `gorm:"type:varchar(36)" json:"resource_id"`
+	IsRead       bool       `gorm:"not null;default:false;index:idx_notifications_user_read,priority:2" json:"is_read"`
+	ReadAt       *time.Time `json:"read_at"`
+	CreatedAt    time.Time  `gorm:"type:timestamp(3);not null;index:idx_notifications_user_read,priority:3" json:"created_at"`
+}
+
+// TaskReminder 已发送的截止日期提醒记录，主键保证同一梯度只提醒一次
+type TaskReminder struct {
+	TaskID        string    `gorm:"type:varchar(36);primaryKey" json:"task_id"`
+	UserID        string    `gorm:"type:varchar(36);primaryKey" json:"user_id"`
+	DueDate       time.Time `gorm:"type:timestamp;primaryKey" json:"due_date"`
+	OffsetMinutes int       `gorm:"primaryKey;autoIncrement:false" json:"offset_minutes"`
+	SentAt        time.Time `gorm:"type:timestamp(3);not null" json:"sent_at"`
+}
+
+// TaskReminderAck 用户对任务截止日期提醒的确认记录
+type TaskReminderAck struct {
+	TaskID         string    `gorm:"type:varchar(36);primaryKey" json:"task_id"`
+	UserID         string    `gorm:"type:varchar(36);primaryKey" json:"user_id"`
+	AcknowledgedAt time.Time `gorm:"type:timestamp(3);not null" json:"acknowledged_at"`
+}
+
 // ================================================
 // 文件相关模型
 // ================================================
@@ -334,27 +451,125 @@ type FileAssociation struct {
 }
 
 // ================================================
-// 表名映射
+// 表名映射（实际表名加上命名策略配置的表前缀）
 // ================================================
 
-func (Role) TableName() string                  { return "roles" }
-func (Permission) TableName() string            { return "permissions" }
-func (UserRole) TableName() string              { return "user_roles" }
-func (RolePermission) TableName() string        { return "role_permissions" }
-func (PermissionPolicy) TableName() string      { return "permission_policies" }
-func (Project) TableName() string               { return "projects" }
-func (ProjectMember) TableName() string         { return "project_members" }
-func (Task) TableName() string                  { return "tasks" }
-func (TaskParticipant) TableName() string       { return "task_participants" }
-func (RecurrenceRule) TableName() string        { return "recurrence_rules" }
-func (TaskExecution) TableName() string         { return "task_executions" }
-func (ParticipantCompletion) TableName() string { return "participant_completions" }
-func (ApprovalRecord) TableName() string        { return "approval_records" }
-func (ExtensionRequest) TableName() string      { return "extension_requests" }
-func (DomainEvent) TableName() string           { return "domain_events" }
-func (OperationLog) TableName() string          { return "operation_logs" }
-func (File) TableName() string                  { return "files" }
-func (FileAssociation) TableName() string       { return "file_associations" }
+func (Role) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "roles")
+}
+
+func (Permission) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "permissions")
+}
+
+func (UserRole) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "user_roles")
+}
+
+func (RolePermission) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "role_permissions")
+}
+
+func (PermissionPolicy) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "permission_policies")
+}
+
+func (Project) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "projects")
+}
+
+func (ProjectMember) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "project_members")
+}
+
+func (Task) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "tasks")
+}
+
+func (TaskParticipant) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "task_participants")
+}
+
+func (RecurrenceRule) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "recurrence_rules")
+}
+
+func (TaskExecution) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "task_executions")
+}
+
+func (ParticipantCompletion) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "participant_completions")
+}
+
+func (ApprovalRecord) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "approval_records")
+}
+
+func (ExtensionRequest) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "extension_requests")
+}
+
+func (WorkSubmission) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "work_submissions")
+}
+
+func (DomainEvent) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "domain_events")
+}
+
+func (OperationLog) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "operation_logs")
+}
+
+func (Webhook) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "webhooks")
+}
+
+func (WebhookDelivery) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "webhook_deliveries")
+}
+
+func (APIKey) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "api_keys")
+}
+
+func (Notification) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "notifications")
+}
+
+func (TaskReminder) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "task_reminders")
+}
+
+func (TaskReminderAck) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "task_reminder_acks")
+}
+
+func (File) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "files")
+}
+
+func (FileAssociation) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "file_associations")
+}
+
+// prefixedTable 在固定表名前加上命名策略配置的表前缀
+func prefixedTable(namer schema.Namer, table string) string {
+	if ns, ok := namer.(schema.NamingStrategy); ok {
+		return ns.TablePrefix + table
+	}
+	return table
+}
+
+// tableName 返回模型在数据库命名策略下的实际表名，供原生SQL使用
+func tableName(db *gorm.DB, model interface{}) string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return ""
+	}
+	return stmt.Schema.Table
+}
 
 // ================================================
 // 模型切片类型定义（用于批量操作）
@@ -0,0 +1,155 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository 站内通知仓储实现
+type NotificationRepository struct {
+	*BaseRepository
+}
+
+// NewNotificationRepository 创建站内通知仓储
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{BaseRepository: NewBaseRepository(db)}
+}
+
+var _ repository.NotificationRepository = (*NotificationRepository)(nil)
+
+// Create 批量写入通知
+func (r *NotificationRepository) Create(ctx context.Context, notifications []aggregate.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	models := make([]Notification, len(notifications))
+	for i, n := range notifications {
+		models[i] = notificationToModel(n)
+	}
+	if err := r.GetDB(ctx).Create(&models).Error; err != nil {
+		return fmt.Errorf("failed to create notifications: %w", err)
+	}
+	return nil
+}
+
+// FindByUser 按创建时间倒序分页查询用户的通知，limit<=0表示不限制
+func (r *NotificationRepository) FindByUser(ctx context.Context, userID valueobject.UserID, unreadOnly bool, limit, offset int) ([]aggregate.Notification, int, error) {
+	query := r.GetDB(ctx).Model(&Notification{}).Where("user_id = ?", string(userID))
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	query = query.Order("created_at DESC, id DESC").Offset(offset)
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var models []Notification
+	if err := query.Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to find notifications: %w", err)
+	}
+
+	notifications := make([]aggregate.Notification, len(models))
+	for i, model := range models {
+		notifications[i] = modelToNotification(model)
+	}
+	return notifications, int(total), nil
+}
+
+// CountUnread 统计用户的未读通知数
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID valueobject.UserID) (int, error) {
+	var count int64
+	if err := r.GetDB(ctx).Model(&Notification{}).
+		Where("user_id = ? AND is_read = ?", string(userID), false).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return int(count), nil
+}
+
+// MarkRead 标记用户的一条通知为已读，已读的通知保持原已读时间
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID valueobject.UserID, id string) (*aggregate.Notification, error) {
+	var model Notification
+	if err := r.GetDB(ctx).Where("id = ? AND user_id = ?", id, string(userID)).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("notification %s: %w", id, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find notification: %w", err)
+	}
+
+	notification := modelToNotification(model)
+	if notification.Read {
+		return &notification, nil
+	}
+	notification.MarkRead(time.Now())
+	if err := r.GetDB(ctx).Model(&Notification{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_read": true, "read_at": notification.ReadAt}).Error; err != nil {
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return &notification, nil
+}
+
+// MarkAllRead 标记用户的全部未读通知为已读
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID valueobject.UserID) (int, error) {
+	result := r.GetDB(ctx).Model(&Notification{}).
+		Where("user_id = ? AND is_read = ?", string(userID), false).
+		Updates(map[string]interface{}{"is_read": true, "read_at": time.Now()})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// notificationToModel 转换为数据库模型，未关联资源时资源字段为NULL
+func notificationToModel(n aggregate.Notification) Notification {
+	model := Notification{
+		ID:        n.ID,
+		UserID:    string(n.UserID),
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		IsRead:    n.Read,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.ResourceType != "" {
+		model.ResourceType = &n.ResourceType
+	}
+	if n.ResourceID != "" {
+		model.ResourceID = &n.ResourceID
+	}
+	return model
+}
+
+// modelToNotification 转换为领域对象
+func modelToNotification(model Notification) aggregate.Notification {
+	n := aggregate.Notification{
+		ID:        model.ID,
+		UserID:    valueobject.UserID(model.UserID),
+		Type:      model.Type,
+		Title:     model.Title,
+		Body:      model.Body,
+		Read:      model.IsRead,
+		ReadAt:    model.ReadAt,
+		CreatedAt: model.CreatedAt,
+	}
+	if model.ResourceType != nil {
+		n.ResourceType = *model.ResourceType
+	}
+	if model.ResourceID != nil {
+		n.ResourceID = *model.ResourceID
+	}
+	return n
+}
@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+)
+
+func TestNotificationRepository_ListAndMarkRead(t *testing.T) {
+	db := setupTestDB(t, &Notification{})
+	repo := NewNotificationRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Create(ctx, []aggregate.Notification{
+		{ID: "n-1", UserID: "alice", Type: "TaskCreated", Title: "t1", Body: "b1", ResourceType: "task", ResourceID: "task-1", CreatedAt: base},
+		{ID: "n-2", UserID: "alice", Type: "TaskAssigned", Title: "t2", Body: "b2", CreatedAt: base.Add(time.Hour)},
+		{ID: "n-3", UserID: "bob", Type: "TaskCreated", Title: "t3", Body: "b3", CreatedAt: base},
+	}))
+
+	notifications, total, err := repo.FindByUser(ctx, "alice", true, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, "n-2", notifications[0].ID)
+	assert.Empty(t, notifications[0].ResourceType)
+	assert.Equal(t, "task-1", notifications[1].ResourceID)
+
+	marked, err := repo.MarkRead(ctx, "alice", "n-2")
+	require.NoError(t, err)
+	assert.True(t, marked.Read)
+	require.NotNil(t, marked.ReadAt)
+	_, err = repo.MarkRead(ctx, "alice", "n-3")
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	unread, err := repo.CountUnread(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 1, unread)
+	notifications, total, err = repo.FindByUser(ctx, "alice", false, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.True(t, notifications[0].Read)
+
+	count, err := repo.MarkAllRead(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	unread, err = repo.CountUnread(ctx, "alice")
+	require.NoError(t, err)
+	assert.Zero(t, unread)
+	unread, err = repo.CountUnread(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, 1, unread)
+}
@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+)
+
+// OperationLogRepository 操作日志仓储实现
+type OperationLogRepository struct {
+	*BaseRepository
+}
+
+// NewOperationLogRepository 创建操作日志仓储
+func NewOperationLogRepository(db *gorm.DB) *OperationLogRepository {
+	return &OperationLogRepository{BaseRepository: NewBaseRepository(db)}
+}
+
+var _ repository.OperationLogRepository = (*OperationLogRepository)(nil)
+
+// Create 追加一条操作日志
+func (r *OperationLogRepository) Create(ctx context.Context, log aggregate.OperationLog) error {
+	model, err := operationLogToModel(log)
+	if err != nil {
+		return err
+	}
+	if err := r.GetDB(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create operation log: %w", err)
+	}
+	return nil
+}
+
+// FindByUser 查找用户最近的操作日志，按创建时间倒序
+func (r *OperationLogRepository) FindByUser(ctx context.Context, userID valueobject.UserID, limit int) ([]aggregate.OperationLog, error) {
+	var models []OperationLog
+	if err := r.GetDB(ctx).Where("user_id = ?", string(userID)).
+		Order("created_at DESC, id DESC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find operation logs: %w", err)
+	}
+
+	logs := make([]aggregate.OperationLog, 0, len(models))
+	for _, model := range models {
+		log, err := modelToOperationLog(model)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, *log)
+	}
+	return logs, nil
+}
+
+// CountByUserSince 统计用户在 since 之后执行指定操作的次数
+func (r *OperationLogRepository) CountByUserSince(ctx context.Context, userID valueobject.UserID, operation string, since time.Time) (int64, error) {
+	var count int64
+	if err := r.GetDB(ctx).Model(&OperationLog{}).
+		Where("user_id = ? AND operation = ? AND created_at >= ?", string(userID), operation, since).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count operation logs: %w", err)
+	}
+	return count, nil
+}
+
+// operationLogToModel 转换为数据库模型，请求数据以JSON存储
+func operationLogToModel(l aggregate.OperationLog) (*OperationLog, error) {
+	model := &OperationLog{
+		ID:           l.ID,
+		Operation:    l.Operation,
+		ResourceType: l.ResourceType,
+		ResourceID:   l.ResourceID,
+		CreatedAt:    l.CreatedAt,
+	}
+	if l.UserID != "" {
+		userID := string(l.UserID)
+		model.UserID = &userID
+	}
+	if l.ImpersonatorID != nil {
+		impersonatorID := string(*l.ImpersonatorID)
+		model.ImpersonatorID = &impersonatorID
+	}
+	if l.IPAddress != "" {
+		model.IPAddress = &l.IPAddress
+	}
+	if l.UserAgent != "" {
+		model.UserAgent = &l.UserAgent
+	}
+	if l.RequestData != nil {
+		data, err := json.Marshal(l.RequestData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal operation log request data: %w", err)
+		}
+		requestData := string(data)
+		model.RequestData = &requestData
+	}
+	if l.ResponseStatus != 0 {
+		model.ResponseStatus = &l.ResponseStatus
+	}
+	return model, nil
+}
+
+// modelToOperationLog 转换为领域对象
+func modelToOperationLog(model OperationLog) (*aggregate.OperationLog, error) {
+	log := &aggregate.OperationLog{
+		ID:           model.ID,
+		Operation:    model.Operation,
+		ResourceType: model.ResourceType,
+		ResourceID:   model.ResourceID,
+		CreatedAt:    model.CreatedAt,
+	}
+	if model.UserID != nil {
+		log.UserID = valueobject.UserID(*model.UserID)
+	}
+	if model.ImpersonatorID != nil {
+		impersonatorID := valueobject.UserID(*model.ImpersonatorID)
+		log.ImpersonatorID = &impersonatorID
+	}
+	if model.IPAddress != nil {
+		log.IPAddress = *model.IPAddress
+	}
+	if model.UserAgent != nil {
+		log.UserAgent = *model.UserAgent
+	}
+	if model.RequestData != nil && *model.RequestData != "" {
+		if err := json.Unmarshal([]byte(*model.RequestData), &log.RequestData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal operation log request data: %w", err)
+		}
+	}
+	if model.ResponseStatus != nil {
+		log.ResponseStatus = *model.ResponseStatus
+	}
+	return log, nil
+}
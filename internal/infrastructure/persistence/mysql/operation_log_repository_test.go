@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+func TestOperationLogRepository_RecordsImpersonator(t *testing.T) {
+	db := setupTestDB(t, &OperationLog{})
+	repo := NewOperationLogRepository(db)
+	ctx := context.Background()
+	admin := valueobject.UserID("admin-1")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, repo.Create(ctx, aggregate.OperationLog{
+		ID:           "log-1",
+		UserID:       admin,
+		Operation:    aggregate.OperationImpersonate,
+		ResourceType: "users",
+		ResourceID:   "user-1",
+		RequestData:  map[string]interface{}{"reason": "ticket 42"},
+		CreatedAt:    now.Add(-time.Minute),
+	}))
+	require.NoError(t, repo.Create(ctx, aggregate.OperationLog{
+		ID:             "log-2",
+		UserID:         "user-1",
+		ImpersonatorID: &admin,
+		Operation:      "PUT /api/v1/tasks/:id",
+		ResourceType:   "tasks",
+		ResourceID:     "task-1",
+		IPAddress:      "10.0.0.1",
+		ResponseStatus: 200,
+		CreatedAt:      now,
+	}))
+
+	logs, err := repo.FindByUser(ctx, "user-1", 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.True(t, logs[0].IsImpersonated())
+	assert.Equal(t, admin, *logs[0].ImpersonatorID)
+	assert.Equal(t, 200, logs[0].ResponseStatus)
+	assert.Equal(t, "10.0.0.1", logs[0].IPAddress)
+
+	adminLogs, err := repo.FindByUser(ctx, admin, 10)
+	require.NoError(t, err)
+	require.Len(t, adminLogs, 1)
+	assert.False(t, adminLogs[0].IsImpersonated())
+	assert.Equal(t, "ticket 42", adminLogs[0].RequestData["reason"])
+
+	count, err := repo.CountByUserSince(ctx, admin, aggregate.OperationImpersonate, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, err = repo.CountByUserSince(ctx, admin, aggregate.OperationImpersonate, now)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
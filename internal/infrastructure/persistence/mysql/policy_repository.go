@@ -25,8 +25,11 @@ func NewPolicyRepository(db *gorm.DB) *policyRepository {
 	}
 }
 
-// Save 保存策略
+// Save 保存策略，策略不合法时返回 ErrInvalidPolicy 且不写入
 func (r *policyRepository) Save(ctx context.Context, policy *aggregate.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
 	return r.WithTransaction(ctx, func(txCtx context.Context) error {
 		tx := r.GetDB(txCtx)
 		conditionsJSON, err := policy.Conditions.ToJSON()
@@ -0,0 +1,43 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/auth/aggregate"
+	"github.com/taskflow/internal/domain/auth/domainerror"
+	"github.com/taskflow/internal/domain/auth/valueobject"
+)
+
+func TestPolicyRepository_SaveRejectsInvalidPolicies(t *testing.T) {
+	setupLogger(t)
+	db := setupTestDB(t, &PermissionPolicy{})
+	repo := NewPolicyRepository(db)
+	ctx := context.Background()
+
+	invalid := []*aggregate.Policy{
+		aggregate.NewPolicy("policy-effect", "Bad effect", "", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+			"permit", valueobject.PolicyConditions{"resource.owner_id": "${user.id}"}, 10),
+		aggregate.NewPolicy("policy-conditions", "Bad conditions", "", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+			valueobject.PolicyEffectAllow, valueobject.PolicyConditions{"resource.owner_id": "${subject.id"}, 10),
+	}
+	for _, policy := range invalid {
+		err := repo.Save(ctx, policy)
+		domainErr := domainerror.GetDomainError(err)
+		require.NotNil(t, domainErr, "%s: expected a domain error, got %v", policy.ID, err)
+		assert.Equal(t, domainerror.ErrInvalidPolicy, domainErr.Type)
+	}
+	var count int64
+	require.NoError(t, db.Model(&PermissionPolicy{}).Count(&count).Error)
+	assert.Zero(t, count, "invalid policies must not be written")
+
+	valid := aggregate.NewPolicy("policy-owner", "Owner", "", valueobject.ResourceTypeTask, valueobject.ActionTypeUpdate,
+		valueobject.PolicyEffectDeny, valueobject.PolicyConditions{"resource.owner_id": "${user.id}"}, 0)
+	require.NoError(t, repo.Save(ctx, valid))
+	stored, err := repo.FindByID(ctx, "policy-owner")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.PolicyEffectDeny, stored.Effect)
+	assert.Equal(t, valid.Conditions, stored.Conditions)
+}
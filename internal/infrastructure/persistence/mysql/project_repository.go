@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,9 +11,11 @@ import (
 	"github.com/taskflow/internal/domain/aggregate"
 	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/domain/valueobject"
 	"github.com/taskflow/internal/infrastructure/persistence/cache"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProjectRepository 项目仓储实现 - 基于现有架构扩展
@@ -20,6 +23,7 @@ type ProjectRepository struct {
 	*BaseRepository // 嵌入基础仓储，自动获得事务支持
 	cache           cache.Interface
 	cacheTTL        time.Duration
+	events          *EventStoreImpl
 	event.TransactionManager
 }
 
@@ -29,18 +33,62 @@ func NewProjectRepository(db *gorm.DB, cache cache.Interface) *ProjectRepository
 		BaseRepository: NewBaseRepository(db),
 		cache:          cache,
 		cacheTTL:       30 * time.Minute,
+		events:         NewEventStore(db),
 	}
 }
 
-// Save 保存项目 - 写入数据库，清除缓存
-func (r *ProjectRepository) Save(ctx context.Context, proj aggregate.Project) error {
+// Create 新建项目 - 仅插入，ID已存在（含已软删除）时失败
+func (r *ProjectRepository) Create(ctx context.Context, proj aggregate.Project) error {
+
+	// 检查ID是否已被占用
+	var count int64
+	if err := r.GetDB(ctx).Unscoped().Model(&Project{}).Where("id = ?", proj.ID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check project existence: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("project %s: %w", proj.ID, repository.ErrAlreadyExists)
+	}
+
+	// 记录创建人和修改人
+	proj.CreatedBy = auditUser(ctx, proj.CreatedBy, proj.OwnerID)
+	proj.UpdatedBy = auditUser(ctx, proj.UpdatedBy, proj.CreatedBy)
 
 	// 转换为数据库模型
 	projectModel := r.aggregateToModel(proj)
 
 	// 使用GetDB自动支持事务
-	if err := r.GetDB(ctx).Save(projectModel).Error; err != nil {
-		return fmt.Errorf("failed to save project: %w", err)
+	if err := r.GetDB(ctx).Create(projectModel).Error; err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	// 保存项目成员
+	if err := r.saveProjectMembers(ctx, proj); err != nil {
+		return fmt.Errorf("failed to save project members: %w", err)
+	}
+
+	return nil
+}
+
+// Update 更新项目 - 仅更新已存在的项目，清除缓存
+func (r *ProjectRepository) Update(ctx context.Context, proj aggregate.Project) error {
+
+	// 检查项目是否存在
+	var count int64
+	if err := r.GetDB(ctx).Model(&Project{}).Where("id = ? AND deleted_at IS NULL", proj.ID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check project existence: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("project %s: %w", proj.ID, repository.ErrNotFound)
+	}
+
+	// 转换为数据库模型
+	proj.UpdatedBy = auditUser(ctx, proj.UpdatedBy)
+	projectModel := r.aggregateToModel(proj)
+
+	// 更新全部字段（包括零值），创建时间和创建人保持不变；任务计数只由 SyncTaskStatistics 维护
+	if err := r.GetDB(ctx).Model(&Project{}).Where("id = ?", proj.ID).
+		Select("*").Omit("id", "created_at", "created_by", "task_count", "completed_tasks", clause.Associations).Updates(projectModel).Error; err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
 	}
 
 	// 保存项目成员
@@ -65,8 +113,8 @@ func (r *ProjectRepository) FindByID(ctx context.Context, id valueobject.Project
 	// 2. 从数据库查询
 	var projectModel Project
 	if err := r.GetDB(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&projectModel).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("project not found: %s", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("project %s: %w", id, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to find project: %w", err)
 	}
@@ -100,22 +148,23 @@ func (r *ProjectRepository) FindByOwner(ctx context.Context, ownerID valueobject
 func (r *ProjectRepository) FindUserAccessibleProjects(ctx context.Context, userID valueobject.UserID, limit, offset int) ([]aggregate.Project, int, error) {
 
 	// 复杂查询使用原生SQL，但仍通过GORM执行
-	query := `
+	db := r.GetDB(ctx)
+	query := fmt.Sprintf(`
 		SELECT DISTINCT p.*, COUNT(*) OVER() as total_count
-		FROM projects p
-		LEFT JOIN project_members pm ON p.id = pm.project_id
+		FROM %s p
+		LEFT JOIN %s pm ON p.id = pm.project_id
 		WHERE p.deleted_at IS NULL 
 		  AND (p.owner_id = ? OR p.manager_id = ? OR pm.user_id = ?)
 		ORDER BY p.updated_at DESC
 		LIMIT ? OFFSET ?
-	`
+	`, tableName(db, &Project{}), tableName(db, &ProjectMember{}))
 
 	var results []struct {
 		Project
 		TotalCount int `gorm:"column:total_count"`
 	}
 
-	if err := r.GetDB(ctx).Raw(query, userID, userID, userID, limit, offset).Scan(&results).Error; err != nil {
+	if err := db.Raw(query, userID, userID, userID, limit, offset).Scan(&results).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to find user accessible projects: %w", err)
 	}
 
@@ -138,7 +187,7 @@ func (r *ProjectRepository) FindUserAccessibleProjects(ctx context.Context, user
 func (r *ProjectRepository) Delete(ctx context.Context, id valueobject.ProjectID) error {
 
 	now := time.Now()
-	if err := r.GetDB(ctx).Model(&Project{}).Where("id = ?", id).Update("deleted_at", now).Error; err != nil {
+	if err := r.GetDB(ctx).Model(&Project{}).Where("id = ?", id).Updates(softDeleteColumns(ctx, now)).Error; err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
 
@@ -155,7 +204,7 @@ func (r *ProjectRepository) getFromCache(ctx context.Context, id valueobject.Pro
 		return nil, fmt.Errorf("cache not available")
 	}
 
-	key := fmt.Sprintf("project:%s", id)
+	key := string(valueobject.BuildProjectCacheKey(id))
 	data, err := r.cache.Get(ctx, key)
 	if err != nil {
 		return nil, err
@@ -167,7 +216,7 @@ func (r *ProjectRepository) getFromCache(ctx context.Context, id valueobject.Pro
 	}
 
 	// 使用工厂恢复项目
-	factory := aggregate.NewProjectFactory()
+	factory := aggregate.NewProjectFactory(nil)
 	return factory.RestoreProject(projectData), nil
 }
 
@@ -176,7 +225,7 @@ func (r *ProjectRepository) setCache(ctx context.Context, proj aggregate.Project
 		return nil // 缓存不可用时静默失败
 	}
 
-	key := fmt.Sprintf("project:%s", proj.ID)
+	key := string(valueobject.BuildProjectCacheKey(proj.ID))
 	data := r.aggregateToData(proj)
 
 	jsonData, err := json.Marshal(data)
@@ -189,11 +238,47 @@ func (r *ProjectRepository) setCache(ctx context.Context, proj aggregate.Project
 
 func (r *ProjectRepository) invalidateCache(ctx context.Context, id valueobject.ProjectID) {
 	if r.cache != nil {
-		key := fmt.Sprintf("project:%s", id)
+		key := string(valueobject.BuildProjectCacheKey(id))
 		r.cache.Del(ctx, key)
 	}
 }
 
+// SyncTaskStatistics 按任务表重新统计项目的任务总数和已完成数
+// 先锁定项目行再统计，同一项目的同步串行执行，后执行的同步总能读到先前已提交的任务状态，不会被更早的统计覆盖
+func (r *ProjectRepository) SyncTaskStatistics(ctx context.Context, projectID valueobject.ProjectID) error {
+	err := r.GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("id = ? AND deleted_at IS NULL", projectID).Take(&locked).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		var total, completed int64
+		if err := tx.Model(&TaskPO{}).Where("project_id = ? AND deleted_at IS NULL", projectID).Count(&total).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&TaskPO{}).Where("project_id = ? AND status = ? AND deleted_at IS NULL", projectID, valueobject.TaskStatusCompleted).
+			Count(&completed).Error; err != nil {
+			return err
+		}
+
+		// 使用 UpdateColumns，统计同步不改变项目的更新时间
+		return tx.Model(&Project{}).Where("id = ?", projectID).UpdateColumns(map[string]interface{}{
+			"task_count":      total,
+			"completed_tasks": completed,
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync project task statistics: %w", err)
+	}
+
+	go r.invalidateCache(ctx, projectID)
+	return nil
+}
+
 // 私有方法 - 数据转换
 
 func (r *ProjectRepository) aggregateToModel(proj aggregate.Project) *Project {
@@ -204,9 +289,10 @@ func (r *ProjectRepository) aggregateToModel(proj aggregate.Project) *Project {
 		ProjectType: string(proj.ProjectType),
 		Status:      string(proj.Status),
 		OwnerID:     string(proj.OwnerID),
-		StartDate:   &proj.StartDate,
-		CreatedAt:   proj.CreatedAt,
-		UpdatedAt:   proj.UpdatedAt,
+		CreatedAt:   shared.ToUTC(proj.CreatedAt),
+		UpdatedAt:   shared.ToUTC(proj.UpdatedAt),
+		CreatedBy:   nullableUserID(proj.CreatedBy),
+		UpdatedBy:   nullableUserID(proj.UpdatedBy),
 	}
 
 	// 处理DeletedAt
@@ -224,8 +310,14 @@ func (r *ProjectRepository) aggregateToModel(proj aggregate.Project) *Project {
 		model.ManagerID = &managerID
 	}
 
+	// 未设置开始日期时写入 NULL，零值日期读回后无法再次写入
+	if !proj.StartDate.IsZero() {
+		startDate := shared.ToUTC(proj.StartDate)
+		model.StartDate = &startDate
+	}
+
 	if proj.EndDate != nil {
-		model.EndDate = proj.EndDate
+		model.EndDate = shared.ToUTCPtr(proj.EndDate)
 	}
 
 	return model
@@ -241,24 +333,35 @@ func (r *ProjectRepository) modelToAggregate(model *Project) *aggregate.Project
 		Type:        model.ProjectType,
 		Status:      model.Status,
 		OwnerID:     model.OwnerID,
-		CreatedAt:   model.CreatedAt,
-		UpdatedAt:   model.UpdatedAt,
+		CreatedAt:   shared.ToUTC(model.CreatedAt),
+		UpdatedAt:   shared.ToUTC(model.UpdatedAt),
+
+		TaskCount:      model.TaskCount,
+		CompletedTasks: model.CompletedTasks,
 	}
 
 	if model.Description != nil {
 		data.Description = *model.Description
 	}
 
+	if model.CreatedBy != nil {
+		data.CreatedBy = *model.CreatedBy
+	}
+
+	if model.UpdatedBy != nil {
+		data.UpdatedBy = *model.UpdatedBy
+	}
+
 	if model.DeletedAt.Valid {
 		data.DeletedAt = &model.DeletedAt.Time
 	}
 
 	if model.StartDate != nil {
-		data.StartDate = *model.StartDate
+		data.StartDate = shared.ToUTC(*model.StartDate)
 	}
 
 	if model.EndDate != nil {
-		data.EndDate = model.EndDate
+		data.EndDate = shared.ToUTCPtr(model.EndDate)
 	}
 
 	if model.ParentProjectID != nil {
@@ -269,7 +372,19 @@ func (r *ProjectRepository) modelToAggregate(model *Project) *aggregate.Project
 		data.ManagerID = model.ManagerID
 	}
 
-	factory := aggregate.NewProjectFactory()
+	for _, member := range model.Members {
+		memberData := aggregate.ProjectMemberData{
+			UserID:   member.UserID,
+			Role:     member.Role,
+			JoinedAt: shared.ToUTC(member.JoinedAt),
+		}
+		if member.AddedBy != nil {
+			memberData.AddedBy = *member.AddedBy
+		}
+		data.Members = append(data.Members, memberData)
+	}
+
+	factory := aggregate.NewProjectFactory(nil)
 	return factory.RestoreProject(data)
 }
 
@@ -292,13 +407,18 @@ func (r *ProjectRepository) aggregateToData(proj aggregate.Project) aggregate.Pr
 		CreatedAt:   proj.CreatedAt,
 		UpdatedAt:   proj.UpdatedAt,
 		DeletedAt:   proj.DeletedAt,
+		CreatedBy:   string(proj.CreatedBy),
+		UpdatedBy:   string(proj.UpdatedBy),
+
+		TaskCount:      proj.TaskCount,
+		CompletedTasks: proj.CompletedTasks,
 	}
 
 	if proj.Description != "" {
 		data.Description = proj.Description
 	}
 
-	if proj.StartDate.IsZero() {
+	if !proj.StartDate.IsZero() {
 		data.StartDate = proj.StartDate
 	}
 
@@ -337,41 +457,83 @@ func (r *ProjectRepository) aggregateToData(proj aggregate.Project) aggregate.Pr
 
 // 成员管理相关方法
 
+// saveProjectMembers 按差异同步项目成员：移除已退出的成员、更新角色、插入新成员
+// 已有成员的加入时间和添加人保持不变，并将成员加入/移除事件写入事件表
 func (r *ProjectRepository) saveProjectMembers(ctx context.Context, proj aggregate.Project) error {
-	// 先删除现有成员
-	if err := r.GetDB(ctx).Where("project_id = ?", proj.ID).Delete(&ProjectMember{}).Error; err != nil {
+	var existing []ProjectMember
+	if err := r.GetDB(ctx).Where("project_id = ?", proj.ID).Find(&existing).Error; err != nil {
 		return err
 	}
-	var value string
-	// 插入新成员
+	existingByUser := make(map[string]ProjectMember, len(existing))
+	for _, member := range existing {
+		existingByUser[member.UserID] = member
+	}
+
+	current := make(map[string]bool, len(proj.Members))
 	for _, member := range proj.Members {
-		value = string(member.AddedBy)
+		userID := string(member.UserID)
+		current[userID] = true
+
+		if stored, ok := existingByUser[userID]; ok {
+			if stored.Role != string(member.Role) {
+				if err := r.GetDB(ctx).Model(&ProjectMember{}).Where("id = ?", stored.ID).
+					Update("role", string(member.Role)).Error; err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
 		memberModel := &ProjectMember{
-			ID:        generateID(), // 需要实现ID生成函数
+			ID:        generateID(),
 			ProjectID: string(proj.ID),
-			UserID:    string(member.UserID),
+			UserID:    userID,
 			Role:      string(member.Role),
-			JoinedAt:  member.JoinedAt,
-			AddedBy:   &value,
+			JoinedAt:  shared.ToUTC(member.JoinedAt),
+		}
+		if member.AddedBy != "" {
+			addedBy := string(member.AddedBy)
+			memberModel.AddedBy = &addedBy
 		}
+		if err := r.GetDB(ctx).Omit(clause.Associations).Create(memberModel).Error; err != nil {
+			return err
+		}
+	}
 
-		if err := r.GetDB(ctx).Create(memberModel).Error; err != nil {
+	var removed []string
+	for _, member := range existing {
+		if !current[member.UserID] {
+			removed = append(removed, member.ID)
+		}
+	}
+	if len(removed) > 0 {
+		if err := r.GetDB(ctx).Where("id IN ?", removed).Delete(&ProjectMember{}).Error; err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return r.events.Append(ctx, historyEvents(proj.Events)...)
+}
+
+// historyEvents 筛选需要写入事件存储的成员加入、移除和父项目变更事件
+func historyEvents(events []event.DomainEvent) []event.DomainEvent {
+	var result []event.DomainEvent
+	for _, e := range events {
+		switch e.(type) {
+		case *event.ProjectMemberAddedEvent, *event.ProjectMemberRemovedEvent, *event.ProjectParentChangedEvent:
+			result = append(result, e)
+		}
+	}
+	return result
 }
 
 func (r *ProjectRepository) loadProjectMembers(ctx context.Context, projectModel *Project) error {
 	var memberModels []ProjectMember
-	if err := r.GetDB(ctx).Where("project_id = ?", projectModel.ID).Find(&memberModels).Error; err != nil {
+	if err := r.GetDB(ctx).Where("project_id = ?", projectModel.ID).Order("joined_at ASC").Find(&memberModels).Error; err != nil {
 		return err
 	}
 
-	// 这里可以将成员数据设置到项目模型中，或者在转换时处理
-	// 具体实现取决于Project模型的设计
-
+	projectModel.Members = memberModels
 	return nil
 }
 
@@ -411,14 +573,15 @@ func (r *ProjectRepository) FindByManager(ctx context.Context, managerID valueob
 func (r *ProjectRepository) FindByMember(ctx context.Context, userID valueobject.UserID) ([]aggregate.Project, error) {
 	var projectModels []Project
 
-	query := `
+	db := r.GetDB(ctx)
+	query := fmt.Sprintf(`
 		SELECT DISTINCT p.*
-		FROM projects p
-		INNER JOIN project_members pm ON p.id = pm.project_id
+		FROM %s p
+		INNER JOIN %s pm ON p.id = pm.project_id
 		WHERE pm.user_id = ? AND p.deleted_at IS NULL
-	`
+	`, tableName(db, &Project{}), tableName(db, &ProjectMember{}))
 
-	if err := r.GetDB(ctx).Raw(query, userID).Scan(&projectModels).Error; err != nil {
+	if err := db.Raw(query, userID).Scan(&projectModels).Error; err != nil {
 		return nil, fmt.Errorf("failed to find projects by member: %w", err)
 	}
 
@@ -458,19 +621,32 @@ func (r *ProjectRepository) FindByType(ctx context.Context, projectType valueobj
 	return r.modelsToAggregates(projectModels), nil
 }
 
-// SearchProjects 复杂搜索项目
+// FindByDateRange 查找在时间窗口内处于进行期的项目
+// 条件：开始日期不晚于窗口结束，且结束日期为空或不早于窗口开始
+func (r *ProjectRepository) FindByDateRange(ctx context.Context, from, to time.Time) ([]aggregate.Project, error) {
+	var projectModels []Project
+
+	if err := r.GetDB(ctx).
+		Where("deleted_at IS NULL").
+		Where("start_date <= ?", shared.ToUTC(to)).
+		Where("end_date IS NULL OR end_date >= ?", shared.ToUTC(from)).
+		Order("start_date ASC").
+		Find(&projectModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find projects by date range: %w", err)
+	}
+
+	return r.modelsToAggregates(projectModels), nil
+}
+
+// SearchProjects 复杂搜索项目，名称和描述的关键字过短时忽略
 func (r *ProjectRepository) SearchProjects(ctx context.Context, criteria aggregate.ProjectSearchCriteria) ([]aggregate.Project, int, error) {
 	db := r.GetDB(ctx).Model(&Project{})
 
 	// 构建查询条件
 	db = db.Where("deleted_at IS NULL")
 
-	if criteria.Name != nil {
-		db = db.Where("name LIKE ?", "%"+*criteria.Name+"%")
-	}
-	if criteria.Description != nil {
-		db = db.Where("description LIKE ?", "%"+*criteria.Description+"%")
-	}
+	db = whereLike(db, "name", criteria.Name, criteria.PrefixMatch)
+	db = whereLike(db, "description", criteria.Description, criteria.PrefixMatch)
 	if criteria.ProjectType != nil {
 		db = db.Where("project_type = ?", *criteria.ProjectType)
 	}
@@ -486,6 +662,12 @@ func (r *ProjectRepository) SearchProjects(ctx context.Context, criteria aggrega
 	if criteria.ParentID != nil {
 		db = db.Where("parent_project_id = ?", *criteria.ParentID)
 	}
+	if criteria.AccessibleBy != nil {
+		// 与 FindUserAccessibleProjects 的访问规则保持一致：所有者、管理者或成员
+		userID := *criteria.AccessibleBy
+		db = db.Where("(owner_id = ? OR manager_id = ? OR id IN (?))", userID, userID,
+			r.GetDB(ctx).Model(&ProjectMember{}).Select("project_id").Where("user_id = ?", userID))
+	}
 
 	// 计算总数
 	var totalCount int64
@@ -542,6 +724,67 @@ func (r *ProjectRepository) CountByStatus(ctx context.Context, status valueobjec
 	return int(count), nil
 }
 
+// FindProjectsWithOverdueTasks 分页查找有逾期任务的项目，按逾期任务数降序
+// 先按项目分组统计逾期任务并分页，再加载当页的项目
+func (r *ProjectRepository) FindProjectsWithOverdueTasks(ctx context.Context, asOf time.Time, limit, offset int) ([]aggregate.ProjectOverdueSummary, int, error) {
+	db := r.GetDB(ctx)
+	overdue := db.Model(&TaskPO{}).
+		Select("project_id, COUNT(*) AS overdue_tasks").
+		Where("due_date < ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
+			asOf, valueobject.TaskStatusCompleted, valueobject.TaskStatusCancelled).
+		Where("project_id IN (?)", db.Model(&Project{}).Select("id").Where("deleted_at IS NULL")).
+		Group("project_id")
+
+	var total int64
+	if err := db.Table("(?) AS overdue", overdue).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count projects with overdue tasks: %w", err)
+	}
+
+	var counts []struct {
+		ProjectID    string
+		OverdueTasks int
+	}
+	page := db.Table("(?) AS overdue", overdue).Order("overdue_tasks DESC, project_id ASC")
+	if limit > 0 {
+		page = page.Limit(limit)
+	}
+	if offset > 0 {
+		page = page.Offset(offset)
+	}
+	if err := page.Scan(&counts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to find projects with overdue tasks: %w", err)
+	}
+	if len(counts) == 0 {
+		return []aggregate.ProjectOverdueSummary{}, int(total), nil
+	}
+
+	ids := make([]string, len(counts))
+	for i, c := range counts {
+		ids[i] = c.ProjectID
+	}
+	var projectModels []Project
+	if err := db.Where("id IN ?", ids).Find(&projectModels).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load projects with overdue tasks: %w", err)
+	}
+	byID := make(map[string]*Project, len(projectModels))
+	for i := range projectModels {
+		byID[projectModels[i].ID] = &projectModels[i]
+	}
+
+	summaries := make([]aggregate.ProjectOverdueSummary, 0, len(counts))
+	for _, c := range counts {
+		model, ok := byID[c.ProjectID]
+		if !ok {
+			continue
+		}
+		summaries = append(summaries, aggregate.ProjectOverdueSummary{
+			Project:      *r.modelToAggregate(model),
+			OverdueTasks: c.OverdueTasks,
+		})
+	}
+	return summaries, int(total), nil
+}
+
 // GetProjectStatistics 获取项目统计信息
 func (r *ProjectRepository) GetProjectStatistics(ctx context.Context, projectID valueobject.ProjectID) (*aggregate.ProjectStatistics, error) {
 
@@ -0,0 +1,384 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/persistence/cache"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func saveProjectWithPeriod(t *testing.T, repo *ProjectRepository, id string, start time.Time, end *time.Time) {
+	t.Helper()
+	proj := aggregate.NewProject(valueobject.ProjectID(id), id, "", valueobject.ProjectTypeMaster, "owner-1")
+	proj.StartDate = start
+	proj.EndDate = end
+	require.NoError(t, repo.Create(context.Background(), *proj))
+}
+
+func foundIDs(projects []aggregate.Project) []string {
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = string(p.ID)
+	}
+	return ids
+}
+
+func TestProjectRepository_FindByDateRange(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{})
+	repo := NewProjectRepository(db, nil)
+
+	from := date(2024, 3, 1)
+	to := date(2024, 3, 31)
+	endOnFrom := date(2024, 3, 1)
+	endBeforeFrom := date(2024, 2, 29)
+	endInside := date(2024, 3, 15)
+
+	saveProjectWithPeriod(t, repo, "starts-on-to", to, nil)
+	saveProjectWithPeriod(t, repo, "starts-after-to", date(2024, 4, 1), nil)
+	saveProjectWithPeriod(t, repo, "ends-on-from", date(2024, 1, 1), &endOnFrom)
+	saveProjectWithPeriod(t, repo, "ends-before-from", date(2024, 1, 1), &endBeforeFrom)
+	saveProjectWithPeriod(t, repo, "inside", date(2024, 3, 5), &endInside)
+	saveProjectWithPeriod(t, repo, "open-ended", date(2023, 6, 1), nil)
+
+	projects, err := repo.FindByDateRange(context.Background(), from, to)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"starts-on-to", "ends-on-from", "inside", "open-ended"}, foundIDs(projects))
+}
+
+func TestProjectRepository_CreateRejectsExistingID(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{})
+	repo := NewProjectRepository(db, nil)
+	ctx := context.Background()
+
+	original := aggregate.NewProject("p-1", "Original", "", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, repo.Create(ctx, *original))
+
+	duplicate := aggregate.NewProject("p-1", "Duplicate", "", valueobject.ProjectTypeMaster, "owner-2")
+	err := repo.Create(ctx, *duplicate)
+
+	assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+	stored, err := repo.FindByID(ctx, "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Original", stored.Name)
+}
+
+func TestProjectRepository_UpdateRejectsMissingID(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{})
+	repo := NewProjectRepository(db, nil)
+
+	missing := aggregate.NewProject("p-missing", "Missing", "", valueobject.ProjectTypeMaster, "owner-1")
+	err := repo.Update(context.Background(), *missing)
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	var count int64
+	require.NoError(t, db.Model(&Project{}).Where("id = ?", "p-missing").Count(&count).Error)
+	assert.Zero(t, count, "update must not insert a missing project")
+}
+
+func TestProjectRepository_FindByIDMissingReturnsErrNotFound(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{})
+	repo := NewProjectRepository(db, nil)
+
+	_, err := repo.FindByID(context.Background(), "p-missing")
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestProjectRepository_UpdatePersistsChanges(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{})
+	repo := NewProjectRepository(db, nil)
+	ctx := context.Background()
+
+	proj := aggregate.NewProject("p-1", "Before", "desc", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, repo.Create(ctx, *proj))
+
+	require.NoError(t, proj.UpdateBasicInfo("After", "", proj.OwnerID))
+	require.NoError(t, repo.Update(ctx, *proj))
+
+	stored, err := repo.FindByID(ctx, "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, "After", stored.Name)
+	assert.Empty(t, stored.Description)
+}
+
+func TestProjectRepository_RecordsCreatedByAndUpdatedBy(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{})
+	repo := NewProjectRepository(db, nil)
+
+	proj := aggregate.NewProject("p-1", "Audited", "", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, repo.Create(shared.WithActor(context.Background(), "admin-1"), *proj))
+
+	stored, err := repo.FindByID(context.Background(), "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("admin-1"), stored.CreatedBy)
+	assert.Equal(t, valueobject.UserID("admin-1"), stored.UpdatedBy)
+
+	require.NoError(t, stored.Activate("owner-1"))
+	require.NoError(t, repo.Update(shared.WithActor(context.Background(), "manager-1"), *stored))
+
+	updated, err := repo.FindByID(context.Background(), "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("admin-1"), updated.CreatedBy, "updates must not change the creator")
+	assert.Equal(t, valueobject.UserID("manager-1"), updated.UpdatedBy)
+}
+
+func TestProjectRepository_UpdateKeepsExistingMemberJoinedAt(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{}, &DomainEvent{})
+	repo := NewProjectRepository(db, nil)
+	ctx := context.Background()
+
+	joinedAt := date(2024, 1, 15)
+	proj := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	proj.Members = append(proj.Members, valueobject.ProjectMember{
+		UserID: "user-1", Role: valueobject.ProjectRoleMember, JoinedAt: joinedAt, AddedBy: "owner-1",
+	})
+	require.NoError(t, repo.Create(ctx, *proj))
+
+	stored, err := repo.FindByID(ctx, "p-1")
+	require.NoError(t, err)
+	require.Len(t, stored.Members, 1)
+	// 模拟调用方用新时间重建了成员列表
+	stored.Members[0].JoinedAt = time.Now()
+	require.NoError(t, stored.AddMember("user-2", valueobject.ProjectRoleMember, "owner-1"))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	reloaded, err := repo.FindByID(ctx, "p-1")
+	require.NoError(t, err)
+	require.Len(t, reloaded.Members, 2)
+	assert.True(t, joinedAt.Equal(reloaded.Members[0].JoinedAt), "existing member must keep original JoinedAt, got %v", reloaded.Members[0].JoinedAt)
+	assert.Equal(t, valueobject.UserID("owner-1"), reloaded.Members[0].AddedBy)
+	assert.Equal(t, valueobject.UserID("user-2"), reloaded.Members[1].UserID)
+}
+
+func TestProjectRepository_UpdatePersistsMemberEvents(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{}, &DomainEvent{})
+	repo := NewProjectRepository(db, nil)
+	ctx := context.Background()
+
+	proj := aggregate.NewProject("p-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, repo.Create(ctx, *proj))
+	require.NoError(t, proj.AddMember("user-1", valueobject.ProjectRoleMember, "owner-1"))
+	require.NoError(t, proj.AddMember("user-2", valueobject.ProjectRoleMember, "owner-1"))
+	require.NoError(t, repo.Update(ctx, *proj))
+	require.NoError(t, proj.RemoveMember("user-2", "owner-1"))
+	require.NoError(t, repo.Update(ctx, *proj))
+
+	var events []DomainEvent
+	require.NoError(t, db.Where("aggregate_id = ?", "p-1").Order("event_type").Find(&events).Error)
+	types := make([]string, len(events))
+	for i, e := range events {
+		types[i] = e.EventType
+		require.NotNil(t, e.UserID)
+		assert.Equal(t, "owner-1", *e.UserID)
+	}
+	assert.Equal(t, []string{"project.member_added", "project.member_added", "project.member_removed"}, types,
+		"re-saving must not duplicate already persisted events")
+
+	var members []ProjectMember
+	require.NoError(t, db.Where("project_id = ?", "p-1").Find(&members).Error)
+	require.Len(t, members, 1)
+	assert.Equal(t, "user-1", members[0].UserID)
+}
+
+func TestProjectRepository_SyncTaskStatisticsRecountsFromTasks(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{}, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	setupLogger(t)
+	projectRepo := NewProjectRepository(db, nil)
+	taskRepo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	proj := aggregate.NewProject("project-1", "Project", "", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, projectRepo.Create(ctx, *proj))
+	for i := 0; i < 5; i++ {
+		require.NoError(t, taskRepo.Create(ctx, newRepoTestTask(fmt.Sprintf("task-%d", i))))
+	}
+	for _, id := range []string{"task-0", "task-1", "task-2"} {
+		require.NoError(t, db.Model(&TaskPO{}).Where("id = ?", id).Update("status", valueobject.TaskStatusCompleted).Error)
+	}
+	require.NoError(t, taskRepo.Delete(ctx, "task-2"))
+
+	// 重复同步结果不变
+	for i := 0; i < 2; i++ {
+		require.NoError(t, projectRepo.SyncTaskStatistics(ctx, "project-1"))
+		stored, err := projectRepo.FindByID(ctx, "project-1")
+		require.NoError(t, err)
+		assert.Equal(t, 4, stored.TaskCount, "soft-deleted tasks are not counted")
+		assert.Equal(t, 2, stored.CompletedTasks)
+	}
+
+	// 用旧的聚合更新项目不会覆盖计数
+	proj.Name = "Renamed"
+	require.NoError(t, projectRepo.Update(ctx, *proj))
+	stored, err := projectRepo.FindByID(ctx, "project-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", stored.Name)
+	assert.Equal(t, 4, stored.TaskCount)
+	assert.Equal(t, 2, stored.CompletedTasks)
+
+	// 项目不存在时不做处理
+	assert.NoError(t, projectRepo.SyncTaskStatistics(ctx, "missing"))
+}
+
+func TestProjectRepository_FindProjectsWithOverdueTasks(t *testing.T) {
+	db := setupTestDB(t, &Project{}, &ProjectMember{}, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	projects := NewProjectRepository(db, nil)
+	tasks := NewTaskRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	for _, id := range []string{"at-risk", "most-at-risk", "healthy", "deleted"} {
+		require.NoError(t, projects.Create(ctx, *aggregate.NewProject(valueobject.ProjectID(id), id, "", valueobject.ProjectTypeMaster, "owner-1")))
+	}
+	addTask := func(id, projectID string, due time.Duration, status valueobject.TaskStatus) {
+		task := newRepoTestTask(id)
+		task.ProjectID = valueobject.ProjectID(projectID)
+		dueDate := now.Add(due)
+		task.DueDate = &dueDate
+		task.Status = status
+		require.NoError(t, tasks.Create(ctx, task))
+	}
+	addTask("t-1", "at-risk", -time.Hour, valueobject.TaskStatusInProgress)
+	addTask("t-2", "at-risk", time.Hour, valueobject.TaskStatusInProgress)
+	addTask("t-3", "most-at-risk", -time.Hour, valueobject.TaskStatusInProgress)
+	addTask("t-4", "most-at-risk", -48*time.Hour, valueobject.TaskStatusApproved)
+	addTask("t-5", "healthy", -time.Hour, valueobject.TaskStatusCompleted)
+	addTask("t-6", "healthy", -time.Hour, valueobject.TaskStatusCancelled)
+	addTask("t-7", "healthy", 24*time.Hour, valueobject.TaskStatusInProgress)
+	addTask("t-8", "deleted", -time.Hour, valueobject.TaskStatusInProgress)
+	require.NoError(t, projects.Delete(ctx, "deleted"))
+
+	summaries, total, err := projects.FindProjectsWithOverdueTasks(ctx, now, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, valueobject.ProjectID("most-at-risk"), summaries[0].Project.ID)
+	assert.Equal(t, 2, summaries[0].OverdueTasks)
+	assert.Equal(t, valueobject.ProjectID("at-risk"), summaries[1].Project.ID)
+	assert.Equal(t, 1, summaries[1].OverdueTasks)
+
+	// 分页只返回当页，总数不变
+	summaries, total, err = projects.FindProjectsWithOverdueTasks(ctx, now, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, valueobject.ProjectID("at-risk"), summaries[0].Project.ID)
+}
+
+// mapCache 基于 map 的缓存，只实现项目仓储用到的方法
+type mapCache struct {
+	cache.Interface
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{values: make(map[string]string)}
+}
+
+func (c *mapCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok {
+		return "", fmt.Errorf("cache miss: %s", key)
+	}
+	return value, nil
+}
+
+func (c *mapCache) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *mapCache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+	return nil
+}
+
+func (c *mapCache) has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.values[key]
+	return ok
+}
+
+func TestProjectRepository_CachedAndDatabaseLoadsBuildSameResponse(t *testing.T) {
+	end := date(2024, 6, 30)
+	manager := valueobject.UserID("manager-1")
+
+	cases := []struct {
+		name    string
+		project func() *aggregate.Project
+	}{
+		{
+			name: "with members and manager",
+			project: func() *aggregate.Project {
+				proj := aggregate.NewProject("p-full", "Full", "desc", valueobject.ProjectTypeMaster, "owner-1")
+				proj.StartDate = date(2024, 1, 1)
+				proj.EndDate = &end
+				proj.ManagerID = &manager
+				proj.Members = append(proj.Members, valueobject.ProjectMember{
+					UserID: "user-1", Role: valueobject.ProjectRoleMember, JoinedAt: date(2024, 1, 15), AddedBy: "owner-1",
+				})
+				return proj
+			},
+		},
+		{
+			name: "without members, manager or dates",
+			project: func() *aggregate.Project {
+				return aggregate.NewProject("p-bare", "Bare", "", valueobject.ProjectTypeMaster, "owner-1")
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := setupTestDB(t, &Project{}, &ProjectMember{}, &DomainEvent{})
+			store := newMapCache()
+			repo := NewProjectRepository(db, store)
+			svc := service.NewProjectAppService(nil, nil, repo, nil)
+			ctx := context.Background()
+
+			proj := tc.project()
+			require.NoError(t, repo.Create(ctx, *proj))
+			require.NoError(t, db.Model(&Project{}).Where("id = ?", proj.ID).
+				Updates(map[string]interface{}{"task_count": 4, "completed_tasks": 1}).Error)
+			store.Del(ctx, string(valueobject.BuildProjectCacheKey(proj.ID)))
+
+			fromDB, err := svc.GetProject(ctx, string(proj.ID), "", true)
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				return store.has(string(valueobject.BuildProjectCacheKey(proj.ID)))
+			}, time.Second, 10*time.Millisecond)
+
+			fromCache, err := svc.GetProject(ctx, string(proj.ID), "", true)
+			require.NoError(t, err)
+
+			assert.Equal(t, fromDB, fromCache)
+			assert.NotNil(t, fromCache.Members)
+			assert.NotNil(t, fromCache.Children)
+			assert.Equal(t, 4, fromCache.Statistics.TotalTasks)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/shared"
+	appLogger "github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DefaultSlowQueryThreshold 未配置时的慢查询阈值
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// QueryLogger 将GORM的SQL日志写入应用日志
+// 仅在应用日志级别为debug时记录每条SQL的耗时和所属请求ID，超过慢查询阈值的SQL以警告级别标记；
+// 其他日志级别下不生成SQL文本，避免生产环境的额外开销
+type QueryLogger struct {
+	slowThreshold time.Duration
+	level         logger.LogLevel
+}
+
+// NewQueryLogger 创建SQL日志记录器，阈值非正数时使用默认慢查询阈值
+func NewQueryLogger(slowThreshold time.Duration) *QueryLogger {
+	if slowThreshold <= 0 {
+		slowThreshold = DefaultSlowQueryThreshold
+	}
+	return &QueryLogger{slowThreshold: slowThreshold, level: logger.Info}
+}
+
+// LogMode 实现 logger.Interface，Silent 时不记录任何SQL日志
+func (l *QueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+// Info 实现 logger.Interface
+func (l *QueryLogger) Info(_ context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info && debugEnabled() {
+		appLogger.Debug(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Warn 实现 logger.Interface
+func (l *QueryLogger) Warn(_ context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn && appLogger.Logger != nil {
+		appLogger.Warn(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Error 实现 logger.Interface
+func (l *QueryLogger) Error(_ context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error && appLogger.Logger != nil {
+		appLogger.Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace 实现 logger.Interface，记录SQL文本、影响行数、耗时和请求ID
+func (l *QueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent || !debugEnabled() {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	fields := []zap.Field{
+		zap.String("request_id", shared.RequestIDFromContext(ctx)),
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("duration", elapsed),
+	}
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		appLogger.Debug("SQL query failed", append(fields, zap.Error(err))...)
+	case elapsed > l.slowThreshold:
+		appLogger.Warn("Slow SQL query", append(fields, zap.Bool("slow", true), zap.Duration("threshold", l.slowThreshold))...)
+	default:
+		appLogger.Debug("SQL query", fields...)
+	}
+}
+
+// debugEnabled 应用日志是否启用了debug级别
+func debugEnabled() bool {
+	return appLogger.Logger != nil && appLogger.Logger.Core().Enabled(zapcore.DebugLevel)
+}
@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/shared"
+	applogger "github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+)
+
+// observeQueries 以指定应用日志级别和慢查询阈值在假数据库上执行一次查询，返回记录的日志
+func observeQueries(t *testing.T, level zapcore.Level, slowThreshold time.Duration) *observer.ObservedLogs {
+	t.Helper()
+	db := openRecordingGorm(t, &recordingDB{})
+
+	core, logs := observer.New(level)
+	previous := applogger.Logger
+	applogger.Logger = zap.New(core)
+	t.Cleanup(func() { applogger.Logger = previous })
+
+	ctx := shared.WithRequestID(context.Background(), "req-123")
+	var tasks []TaskPO
+	require.NoError(t, db.Session(&gorm.Session{Logger: NewQueryLogger(slowThreshold)}).
+		WithContext(ctx).Where("project_id = ?", "project-1").Find(&tasks).Error)
+	return logs
+}
+
+func TestQueryLogger_DebugLogsQueryWithRequestID(t *testing.T) {
+	logs := observeQueries(t, zapcore.DebugLevel, time.Hour)
+
+	entries := logs.FilterMessage("SQL query").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "req-123", fields["request_id"])
+	assert.Contains(t, fields["sql"], "project-1")
+	assert.Contains(t, fields, "duration")
+}
+
+func TestQueryLogger_FlagsSlowQueries(t *testing.T) {
+	logs := observeQueries(t, zapcore.DebugLevel, time.Nanosecond)
+
+	entries := logs.FilterMessage("Slow SQL query").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, true, entries[0].ContextMap()["slow"])
+	assert.Equal(t, "req-123", entries[0].ContextMap()["request_id"])
+}
+
+func TestQueryLogger_SilentAboveDebugLevel(t *testing.T) {
+	logs := observeQueries(t, zapcore.InfoLevel, time.Nanosecond)
+
+	assert.Zero(t, logs.Len())
+}
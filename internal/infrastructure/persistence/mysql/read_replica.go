@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// readReplicaCallback 查询路由回调名称
+const readReplicaCallback = "taskflow:read_replica"
+
+// readReplicaRouter 读写分离路由：事务外的查询轮询使用只读副本
+type readReplicaRouter struct {
+	replicas []gorm.ConnPool
+	next     atomic.Uint64
+}
+
+// UseReadReplicas 为主库连接注册只读副本
+// 只路由查询（Find/First/Take/Count/Pluck）；写操作、事务内查询、加锁查询和原生SQL始终使用主库
+// 未提供副本时不做任何修改
+func UseReadReplicas(primary *gorm.DB, replicas ...*gorm.DB) error {
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	router := &readReplicaRouter{replicas: make([]gorm.ConnPool, len(replicas))}
+	for i, replica := range replicas {
+		router.replicas[i] = replica.ConnPool
+	}
+
+	if err := primary.Callback().Query().Before("gorm:query").Register(readReplicaCallback, router.route); err != nil {
+		return fmt.Errorf("failed to register read replica callback: %w", err)
+	}
+	return nil
+}
+
+// route 在查询执行前切换连接池
+func (r *readReplicaRouter) route(db *gorm.DB) {
+	// 事务中的连接是 *sql.Tx，必须留在主库才能读到未提交的写入
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		return
+	}
+	// SELECT ... FOR UPDATE 等加锁查询只在主库上有意义
+	if _, locking := db.Statement.Clauses["FOR"]; locking {
+		return
+	}
+
+	n := r.next.Add(1) - 1
+	db.Statement.ConnPool = r.replicas[n%uint64(len(r.replicas))]
+}
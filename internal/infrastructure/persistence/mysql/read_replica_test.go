@@ -0,0 +1,138 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/repository"
+	applogger "github.com/taskflow/pkg/logger"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// recordingDB 只记录SQL的假数据库：查询返回空结果，写入返回影响1行
+type recordingDB struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (d *recordingDB) record(query string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.statements = append(d.statements, query)
+}
+
+// count 统计以指定关键字开头的SQL数量
+func (d *recordingDB) count(verb string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for _, s := range d.statements {
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(s)), verb) {
+			n++
+		}
+	}
+	return n
+}
+
+func (d *recordingDB) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.statements = nil
+}
+
+func (d *recordingDB) Connect(context.Context) (driver.Conn, error) { return recordingConn{d}, nil }
+func (d *recordingDB) Driver() driver.Driver                        { return nil }
+
+type recordingConn struct{ db *recordingDB }
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c recordingConn) Close() error                        { return nil }
+func (c recordingConn) Begin() (driver.Tx, error)           { return recordingTx{}, nil }
+
+func (c recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.db.record(query)
+	return emptyRows{}, nil
+}
+
+func (c recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query)
+	return driver.RowsAffected(1), nil
+}
+
+type recordingTx struct{}
+
+func (recordingTx) Commit() error   { return nil }
+func (recordingTx) Rollback() error { return nil }
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+// openRecordingGorm 在假数据库上创建GORM连接
+func openRecordingGorm(t *testing.T, fake *recordingDB) *gorm.DB {
+	t.Helper()
+	require.NoError(t, applogger.InitLogger(&applogger.Config{Level: "info", Format: "console", Output: "console"}))
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(fake),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return db
+}
+
+func TestUseReadReplicas_RoutesReadsToReplicaAndWritesToPrimary(t *testing.T) {
+	primary, replica := &recordingDB{}, &recordingDB{}
+	db := openRecordingGorm(t, primary)
+	require.NoError(t, UseReadReplicas(db, openRecordingGorm(t, replica)))
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	// 事务外的查询走副本
+	_, err := repo.FindByID(ctx, "task-1")
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Equal(t, 1, replica.count("SELECT"))
+	assert.Zero(t, primary.count("SELECT"))
+
+	// 写操作走主库
+	replica.reset()
+	require.NoError(t, db.WithContext(ctx).Save(&TaskPO{ID: "task-1", Title: "Task"}).Error)
+	require.NoError(t, repo.Delete(ctx, "task-1"))
+	assert.Equal(t, 2, primary.count("UPDATE"))
+	assert.Empty(t, replica.statements)
+
+	// 事务内的查询和加锁查询留在主库
+	primary.reset()
+	require.NoError(t, NewTransactionManager(db).WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := repo.FindByID(ctx, "task-1")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		return nil
+	}))
+	var locked TaskPO
+	err = db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, "id = ?", "task-1").Error
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Equal(t, 2, primary.count("SELECT"))
+	assert.Empty(t, replica.statements)
+}
+
+func TestUseReadReplicas_WithoutReplicasKeepsPrimary(t *testing.T) {
+	primary := &recordingDB{}
+	db := openRecordingGorm(t, primary)
+	require.NoError(t, UseReadReplicas(db))
+
+	_, err := NewTaskRepository(db).FindByID(context.Background(), "task-1")
+
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Equal(t, 1, primary.count("SELECT"))
+}
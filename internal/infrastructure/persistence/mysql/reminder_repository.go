@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReminderRepository 截止日期提醒记录仓储实现
+type ReminderRepository struct {
+	*BaseRepository
+}
+
+// NewReminderRepository 创建截止日期提醒记录仓储
+func NewReminderRepository(db *gorm.DB) *ReminderRepository {
+	return &ReminderRepository{BaseRepository: NewBaseRepository(db)}
+}
+
+var _ repository.ReminderRepository = (*ReminderRepository)(nil)
+
+// RecordSent 记录已发送的提醒，依赖主键去重，多个实例同时调度时只有一个写入成功
+func (r *ReminderRepository) RecordSent(ctx context.Context, reminder valueobject.TaskReminder) (bool, error) {
+	model := TaskReminder{
+		TaskID:        string(reminder.TaskID),
+		UserID:        string(reminder.UserID),
+		DueDate:       reminder.DueDate.UTC(),
+		OffsetMinutes: int(reminder.Offset / time.Minute),
+		SentAt:        reminder.SentAt.UTC(),
+	}
+	result := r.GetDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record task reminder: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Acknowledge 记录用户已确认任务的提醒，重复确认保持首次确认时间
+func (r *ReminderRepository) Acknowledge(ctx context.Context, taskID valueobject.TaskID, userID valueobject.UserID, at time.Time) error {
+	model := TaskReminderAck{
+		TaskID:         string(taskID),
+		UserID:         string(userID),
+		AcknowledgedAt: at.UTC(),
+	}
+	if err := r.GetDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to acknowledge task reminder: %w", err)
+	}
+	return nil
+}
+
+// IsAcknowledged 用户是否已确认任务的提醒
+func (r *ReminderRepository) IsAcknowledged(ctx context.Context, taskID valueobject.TaskID, userID valueobject.UserID) (bool, error) {
+	var count int64
+	err := r.GetDB(ctx).Model(&TaskReminderAck{}).
+		Where("task_id = ? AND user_id = ?", string(taskID), string(userID)).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check task reminder acknowledgment: %w", err)
+	}
+	return count > 0, nil
+}
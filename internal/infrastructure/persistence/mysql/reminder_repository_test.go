@@ -0,0 +1,64 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+func TestReminderRepository_RecordSentOncePerRung(t *testing.T) {
+	db := setupTestDB(t, &TaskReminder{})
+	repo := NewReminderRepository(db)
+	ctx := context.Background()
+
+	due := time.Date(2024, 6, 10, 18, 0, 0, 0, time.UTC)
+	reminder := valueobject.TaskReminder{TaskID: "task-1", UserID: "alice", DueDate: due, Offset: time.Hour, SentAt: due.Add(-time.Hour)}
+
+	recorded, err := repo.RecordSent(ctx, reminder)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	recorded, err = repo.RecordSent(ctx, reminder)
+	require.NoError(t, err)
+	assert.False(t, recorded, "same rung is recorded only once")
+
+	// 其他梯度、其他用户和变更后的截止时间分别记录
+	for _, other := range []valueobject.TaskReminder{
+		{TaskID: "task-1", UserID: "alice", DueDate: due, Offset: 0, SentAt: due},
+		{TaskID: "task-1", UserID: "bob", DueDate: due, Offset: time.Hour, SentAt: due},
+		{TaskID: "task-1", UserID: "alice", DueDate: due.Add(24 * time.Hour), Offset: time.Hour, SentAt: due},
+	} {
+		recorded, err := repo.RecordSent(ctx, other)
+		require.NoError(t, err)
+		assert.True(t, recorded, "%+v", other)
+	}
+}
+
+func TestReminderRepository_Acknowledge(t *testing.T) {
+	db := setupTestDB(t, &TaskReminderAck{})
+	repo := NewReminderRepository(db)
+	ctx := context.Background()
+
+	acked, err := repo.IsAcknowledged(ctx, "task-1", "alice")
+	require.NoError(t, err)
+	assert.False(t, acked)
+
+	first := time.Date(2024, 6, 10, 17, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Acknowledge(ctx, "task-1", "alice", first))
+	require.NoError(t, repo.Acknowledge(ctx, "task-1", "alice", first.Add(time.Hour)))
+
+	acked, err = repo.IsAcknowledged(ctx, "task-1", "alice")
+	require.NoError(t, err)
+	assert.True(t, acked)
+	acked, err = repo.IsAcknowledged(ctx, "task-1", "bob")
+	require.NoError(t, err)
+	assert.False(t, acked)
+
+	var model TaskReminderAck
+	require.NoError(t, db.Where("task_id = ? AND user_id = ?", "task-1", "alice").First(&model).Error)
+	assert.True(t, model.AcknowledgedAt.Equal(first), "repeated acknowledgment keeps the first time")
+}
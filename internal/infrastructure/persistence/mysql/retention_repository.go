@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// RetentionRepositoryImpl 软删除数据清理仓储实现
+type RetentionRepositoryImpl struct {
+	*BaseRepository
+}
+
+// NewRetentionRepository 创建软删除数据清理仓储
+func NewRetentionRepository(db *gorm.DB) repository.RetentionRepository {
+	return &RetentionRepositoryImpl{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// purgeStep 清理步骤：删除表中满足条件的行
+type purgeStep struct {
+	model interface{}
+	table string
+	query string
+	args  []interface{}
+}
+
+// PurgeSoftDeleted 物理删除 deleted_at 早于 before 的项目、任务和文件及其依赖行
+// 依赖行先于主表删除，调用方应在事务中执行以保证一致性
+func (r *RetentionRepositoryImpl) PurgeSoftDeleted(ctx context.Context, before time.Time, dryRun bool) (repository.PurgeCounts, error) {
+	// 模型带 gorm.DeletedAt 时需 Unscoped 才能查询已删除行并执行物理删除；
+	// 新建会话使各查询条件互不累积
+	db := r.GetDB(ctx).Unscoped().Session(&gorm.Session{})
+	before = before.UTC()
+
+	var projectIDs, taskIDs, fileIDs, executionIDs []string
+	if err := db.Model(&Project{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("id", &projectIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find expired projects: %w", err)
+	}
+	taskQuery := db.Model(&TaskPO{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+	if len(projectIDs) > 0 {
+		taskQuery = taskQuery.Or("project_id IN ?", projectIDs)
+	}
+	if err := taskQuery.Pluck("id", &taskIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find expired tasks: %w", err)
+	}
+	if err := db.Model(&File{}).Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("id", &fileIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find expired files: %w", err)
+	}
+	if len(taskIDs) > 0 {
+		if err := db.Model(&TaskExecution{}).Where("task_id IN ?", taskIDs).Pluck("id", &executionIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to find task executions: %w", err)
+		}
+	}
+
+	var steps []purgeStep
+	if len(executionIDs) > 0 {
+		steps = append(steps, purgeStep{&ParticipantCompletion{}, "participant_completions", "execution_id IN ?", []interface{}{executionIDs}})
+	}
+	if len(taskIDs) > 0 {
+		for _, dependent := range []struct {
+			model interface{}
+			table string
+		}{
+			{&TaskExecution{}, "task_executions"},
+			{&TaskParticipantPO{}, "task_participants"},
+			{&ExtensionRequest{}, "extension_requests"},
+			{&WorkSubmission{}, "work_submissions"},
+			{&ApprovalRecord{}, "approval_records"},
+			{&RecurrenceRule{}, "recurrence_rules"},
+			{&TaskReminder{}, "task_reminders"},
+			{&TaskReminderAck{}, "task_reminder_acks"},
+		} {
+			steps = append(steps, purgeStep{dependent.model, dependent.table, "task_id IN ?", []interface{}{taskIDs}})
+		}
+	}
+	if len(fileIDs) > 0 || len(taskIDs) > 0 || len(projectIDs) > 0 {
+		steps = append(steps, purgeStep{&FileAssociation{}, "file_associations",
+			"file_id IN ? OR (resource_type = 'task' AND resource_id IN ?) OR (resource_type = 'project' AND resource_id IN ?)",
+			[]interface{}{fileIDs, taskIDs, projectIDs}})
+	}
+	if len(taskIDs) > 0 {
+		steps = append(steps, purgeStep{&TaskPO{}, "tasks", "id IN ?", []interface{}{taskIDs}})
+	}
+	if len(projectIDs) > 0 {
+		steps = append(steps,
+			purgeStep{&ProjectMember{}, "project_members", "project_id IN ?", []interface{}{projectIDs}},
+			purgeStep{&Project{}, "projects", "id IN ?", []interface{}{projectIDs}})
+	}
+	if len(fileIDs) > 0 {
+		steps = append(steps, purgeStep{&File{}, "files", "id IN ?", []interface{}{fileIDs}})
+	}
+
+	counts := repository.PurgeCounts{}
+	for _, step := range steps {
+		if dryRun {
+			var count int64
+			if err := db.Model(step.model).Where(step.query, step.args...).Count(&count).Error; err != nil {
+				return nil, fmt.Errorf("failed to count %s: %w", step.table, err)
+			}
+			counts[step.table] = count
+			continue
+		}
+		result := db.Where(step.query, step.args...).Delete(step.model)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to purge %s: %w", step.table, result.Error)
+		}
+		counts[step.table] = result.RowsAffected
+	}
+	return counts, nil
+}
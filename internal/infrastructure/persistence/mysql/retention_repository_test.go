@@ -0,0 +1,173 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// seedRetentionData 写入保留期内外的软删除数据及其依赖行
+func seedRetentionData(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	expired := now.Add(-100 * 24 * time.Hour)
+	recent := now.Add(-10 * 24 * time.Hour)
+
+	for _, p := range []struct {
+		id        string
+		deletedAt *time.Time
+	}{{"p-old", &expired}, {"p-recent", &recent}, {"p-live", nil}} {
+		project := Project{ID: p.id, Name: p.id, ProjectType: "master", OwnerID: "owner-1", Status: "active"}
+		if p.deletedAt != nil {
+			project.DeletedAt = gorm.DeletedAt{Time: *p.deletedAt, Valid: true}
+		}
+		require.NoError(t, db.Omit(clause.Associations).Create(&project).Error)
+		require.NoError(t, db.Omit(clause.Associations).Create(&ProjectMember{
+			ID: "pm-" + p.id, ProjectID: p.id, UserID: "member-1", Role: "member",
+		}).Error)
+	}
+
+	repo := NewTaskRepository(db)
+	for _, tc := range []struct {
+		id        string
+		projectID string
+		deletedAt *time.Time
+	}{
+		{"t-old", "p-live", &expired},
+		{"t-recent", "p-live", &recent},
+		{"t-live", "p-live", nil},
+		{"t-in-old-project", "p-old", nil},
+	} {
+		task := newRepoTestTask(tc.id)
+		task.ProjectID = valueobject.ProjectID(tc.projectID)
+		require.NoError(t, task.AddParticipant("member-1", "creator-1"))
+		require.NoError(t, repo.Create(ctx, task))
+		if tc.deletedAt != nil {
+			require.NoError(t, db.Model(&TaskPO{}).Where("id = ?", tc.id).Update("deleted_at", *tc.deletedAt).Error)
+		}
+	}
+
+	// t-old 的依赖行
+	require.NoError(t, db.Omit(clause.Associations).Create(&ExtensionRequest{
+		ID: "ext-1", TaskID: "t-old", RequesterID: "member-1", Reason: "more time", Status: "pending",
+		OriginalDueDate: now, RequestedDueDate: now.Add(24 * time.Hour),
+	}).Error)
+	require.NoError(t, db.Create(&WorkSubmission{
+		ID: "ws-1", TaskID: "t-old", SubmitterID: "member-1", Content: "done", Attachments: "[]",
+		Status: "pending", SubmittedAt: now,
+	}).Error)
+	require.NoError(t, db.Omit(clause.Associations).Create(&TaskExecution{
+		ID: "exec-1", TaskID: "t-old", ExecutionDate: now, Status: "completed",
+	}).Error)
+	require.NoError(t, db.Omit(clause.Associations).Create(&ParticipantCompletion{
+		ID: "pc-1", ExecutionID: "exec-1", ParticipantID: "member-1", Status: "approved",
+	}).Error)
+	require.NoError(t, db.Omit(clause.Associations).Create(&ApprovalRecord{
+		ID: "ar-1", TaskID: "t-old", ApproverID: "owner-1", ApprovalType: "task_creation", Action: "approve",
+	}).Error)
+	require.NoError(t, db.Omit(clause.Associations).Create(&RecurrenceRule{
+		ID: "rr-1", TaskID: "t-old", Frequency: "weekly", IntervalValue: 1,
+	}).Error)
+	require.NoError(t, db.Create(&TaskReminder{
+		TaskID: "t-old", UserID: "member-1", DueDate: now, OffsetMinutes: 60, SentAt: now,
+	}).Error)
+	require.NoError(t, db.Create(&TaskReminderAck{TaskID: "t-old", UserID: "member-1", AcknowledgedAt: now}).Error)
+
+	for _, f := range []struct {
+		id        string
+		deletedAt *time.Time
+	}{{"f-old", &expired}, {"f-recent", &recent}, {"f-live", nil}} {
+		file := File{
+			ID: f.id, Filename: f.id, OriginalName: f.id, FileType: "document", FileSize: 1,
+			FilePath: "/files/" + f.id, MimeType: "text/plain", MD5Hash: "d41d8cd98f00b204e9800998ecf8427e",
+			UploaderID: "member-1", UploadStatus: "completed",
+		}
+		if f.deletedAt != nil {
+			file.DeletedAt = gorm.DeletedAt{Time: *f.deletedAt, Valid: true}
+		}
+		require.NoError(t, db.Omit(clause.Associations).Create(&file).Error)
+	}
+	for _, fa := range []FileAssociation{
+		{ID: "fa-old-file", FileID: "f-old", ResourceType: "task", ResourceID: "t-live", AssociationType: "attachment"},
+		{ID: "fa-old-task", FileID: "f-live", ResourceType: "task", ResourceID: "t-old", AssociationType: "attachment"},
+		{ID: "fa-old-project", FileID: "f-live", ResourceType: "project", ResourceID: "p-old", AssociationType: "document"},
+		{ID: "fa-live", FileID: "f-live", ResourceType: "task", ResourceID: "t-live", AssociationType: "attachment"},
+	} {
+		require.NoError(t, db.Omit(clause.Associations).Create(&fa).Error)
+	}
+}
+
+func setupRetentionTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := setupTestDB(t, &Project{}, &ProjectMember{}, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{},
+		&WorkSubmission{}, &TaskExecution{}, &ParticipantCompletion{}, &ApprovalRecord{}, &RecurrenceRule{}, &TaskReminder{}, &TaskReminderAck{},
+		&File{}, &FileAssociation{})
+	seedRetentionData(t, db)
+	return db
+}
+
+var expectedRetentionPurge = repository.PurgeCounts{
+	"projects":                1,
+	"project_members":         1,
+	"tasks":                   2,
+	"task_participants":       2,
+	"extension_requests":      1,
+	"work_submissions":        1,
+	"task_executions":         1,
+	"participant_completions": 1,
+	"approval_records":        1,
+	"recurrence_rules":        1,
+	"task_reminders":          1,
+	"task_reminder_acks":      1,
+	"files":                   1,
+	"file_associations":       3,
+}
+
+func TestRetentionRepository_PurgesExpiredRowsAndDependents(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewRetentionRepository(db)
+
+	counts, err := repo.PurgeSoftDeleted(context.Background(), time.Now().Add(-90*24*time.Hour), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedRetentionPurge, counts)
+
+	remaining := func(model interface{}, column string) []string {
+		var ids []string
+		require.NoError(t, db.Unscoped().Model(model).Order(column).Pluck(column, &ids).Error)
+		return ids
+	}
+	assert.Equal(t, []string{"p-live", "p-recent"}, remaining(&Project{}, "id"))
+	assert.Equal(t, []string{"p-live", "p-recent"}, remaining(&ProjectMember{}, "project_id"))
+	assert.Equal(t, []string{"t-live", "t-recent"}, remaining(&TaskPO{}, "id"))
+	assert.Equal(t, []string{"t-live", "t-recent"}, remaining(&TaskParticipantPO{}, "task_id"))
+	assert.Equal(t, []string{"f-live", "f-recent"}, remaining(&File{}, "id"))
+	assert.Equal(t, []string{"fa-live"}, remaining(&FileAssociation{}, "id"))
+	assert.Empty(t, remaining(&ExtensionRequest{}, "id"))
+	assert.Empty(t, remaining(&ParticipantCompletion{}, "id"))
+}
+
+func TestRetentionRepository_DryRunOnlyCounts(t *testing.T) {
+	db := setupRetentionTestDB(t)
+	repo := NewRetentionRepository(db)
+
+	counts, err := repo.PurgeSoftDeleted(context.Background(), time.Now().Add(-90*24*time.Hour), true)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedRetentionPurge, counts)
+	assert.Equal(t, int64(18), counts.Total())
+
+	var tasks, projects int64
+	require.NoError(t, db.Model(&TaskPO{}).Count(&tasks).Error)
+	require.NoError(t, db.Unscoped().Model(&Project{}).Count(&projects).Error)
+	assert.Equal(t, int64(4), tasks)
+	assert.Equal(t, int64(3), projects)
+}
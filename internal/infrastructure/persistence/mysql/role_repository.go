@@ -117,7 +117,7 @@ func (r *roleRepository) AddPermissionToRole(ctx context.Context, roleID valueob
 		tx := r.GetDB(txCtx)
 		var count int64
 		err := tx.WithContext(txCtx).
-			Table("role_permissions").
+			Model(&RolePermission{}).
 			Where("role_id = ? AND permission_id = ?", string(roleID), string(permissionID)).
 			Count(&count).Error
 
@@ -164,10 +164,10 @@ func (r *roleRepository) FindPermissionsByRole(ctx context.Context, roleID value
 	var models []Permission
 
 	err := r.db.WithContext(ctx).
-		Select("permissions.*").
-		Table("permissions").
-		Joins("JOIN role_permissions ON permissions.id = role_permissions.permission_id").
-		Where("role_permissions.role_id = ?", string(roleID)).
+		Select("p.*").
+		Table(tableName(r.db, &Permission{})+" p").
+		Joins("JOIN "+tableName(r.db, &RolePermission{})+" rp ON p.id = rp.permission_id").
+		Where("rp.role_id = ?", string(roleID)).
 		Find(&models).Error
 
 	if err != nil {
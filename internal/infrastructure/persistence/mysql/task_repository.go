@@ -2,14 +2,21 @@ package mysql
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/taskflow/internal/domain/aggregate"
 	"github.com/taskflow/internal/domain/event"
 	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
 	"github.com/taskflow/internal/domain/valueobject"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // TaskRepositoryImpl 任务仓储实现
@@ -26,67 +33,263 @@ func NewTaskRepository(db *gorm.DB) repository.TaskRepository {
 
 // TaskPO 任务持久化对象
 type TaskPO struct {
-	ID             string     `gorm:"primaryKey;column:id" json:"id"`
-	Title          string     `gorm:"column:title;not null" json:"title"`
-	Description    string     `gorm:"column:description;type:text" json:"description"`
-	ProjectID      string     `gorm:"column:project_id;not null;index" json:"project_id"`
-	CreatorID      string     `gorm:"column:creator_id;not null;index" json:"creator_id"`
-	AssigneeID     *string    `gorm:"column:assignee_id;index" json:"assignee_id"`
-	Status         string     `gorm:"column:status;not null;index" json:"status"`
-	Priority       string     `gorm:"column:priority;not null" json:"priority"`
-	Type           string     `gorm:"column:type;not null" json:"type"`
-	StartDate      *time.Time `gorm:"column:start_date" json:"start_date"`
-	DueDate        *time.Time `gorm:"column:due_date;index" json:"due_date"`
-	CompletedAt    *time.Time `gorm:"column:completed_at" json:"completed_at"`
-	EstimatedHours *float64   `gorm:"column:estimated_hours" json:"estimated_hours"`
-	ActualHours    *float64   `gorm:"column:actual_hours" json:"actual_hours"`
-	Tags           string     `gorm:"column:tags;type:json" json:"tags"`
-	Participants   string     `gorm:"column:participants;type:json" json:"participants"`
-	Attachments    string     `gorm:"column:attachments;type:json" json:"attachments"`
-	RecurrenceRule *string    `gorm:"column:recurrence_rule" json:"recurrence_rule"`
-	ParentTaskID   *string    `gorm:"column:parent_task_id;index" json:"parent_task_id"`
-	WorkflowStepID *string    `gorm:"column:workflow_step_id" json:"workflow_step_id"`
-	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
-	DeletedAt      *time.Time `gorm:"column:deleted_at;index" json:"deleted_at"`
+	ID              string     `gorm:"primaryKey;column:id" json:"id"`
+	Title           string     `gorm:"column:title;not null" json:"title"`
+	Description     string     `gorm:"column:description;type:text" json:"description"`
+	ProjectID       string     `gorm:"column:project_id;not null;index" json:"project_id"`
+	CreatorID       string     `gorm:"column:creator_id;not null;index" json:"creator_id"`
+	AssigneeID      *string    `gorm:"column:assignee_id;index" json:"assignee_id"`
+	CoResponsibles  *string    `gorm:"column:co_responsibles;type:json" json:"co_responsibles"`
+	Status          string     `gorm:"column:status;not null;index" json:"status"`
+	Priority        string     `gorm:"column:priority;not null" json:"priority"`
+	Type            string     `gorm:"column:type;not null" json:"type"`
+	StartDate       *time.Time `gorm:"column:start_date" json:"start_date"`
+	DueDate         *time.Time `gorm:"column:due_date;index" json:"due_date"`
+	CompletedAt     *time.Time `gorm:"column:completed_at" json:"completed_at"`
+	ApprovedAt      *time.Time `gorm:"column:approved_at;index" json:"approved_at"`
+	StatusChangedAt *time.Time `gorm:"column:status_changed_at" json:"status_changed_at"`
+	EstimatedHours  *float64   `gorm:"column:estimated_hours" json:"estimated_hours"`
+	ActualHours     *float64   `gorm:"column:actual_hours" json:"actual_hours"`
+	Tags            string     `gorm:"column:tags;type:json" json:"tags"`
+	Participants    string     `gorm:"column:participants;type:json" json:"participants"`
+	Attachments     string     `gorm:"column:attachments;type:json" json:"attachments"`
+	RecurrenceRule  *string    `gorm:"column:recurrence_rule;type:json" json:"recurrence_rule"`
+	Reviewers       *string    `gorm:"column:reviewers;type:json" json:"reviewers"`
+	ReviewQuorum    int        `gorm:"column:review_quorum;not null;default:0" json:"review_quorum"`
+	UpdatedBy       *string    `gorm:"column:updated_by;type:varchar(36)" json:"updated_by"`
+	ParentTaskID    *string    `gorm:"column:parent_task_id;index" json:"parent_task_id"`
+	WorkflowID      *string    `gorm:"column:workflow_id;type:varchar(36)" json:"workflow_id"`
+	WorkflowStepID  *string    `gorm:"column:workflow_step_id" json:"workflow_step_id"`
+	CreatedAt       time.Time  `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+	DeletedAt       *time.Time `gorm:"column:deleted_at;index" json:"deleted_at"`
+
+	OpenContribution bool `gorm:"column:open_contribution;default:false" json:"open_contribution"`
+	PausedByProject  bool `gorm:"column:paused_by_project;not null" json:"paused_by_project"`
 }
 
 // TableName 表名
-func (TaskPO) TableName() string {
-	return "tasks"
+func (TaskPO) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "tasks")
 }
 
-// Save 保存任务
-func (r *TaskRepositoryImpl) Save(ctx context.Context, task aggregate.TaskAggregate) error {
+// TaskParticipantPO 任务参与者持久化对象
+type TaskParticipantPO struct {
+	ID      string    `gorm:"primaryKey;column:id;type:varchar(36)"`
+	TaskID  string    `gorm:"column:task_id;type:varchar(36);not null;uniqueIndex:uk_task_user"`
+	UserID  string    `gorm:"column:user_id;type:varchar(36);not null;uniqueIndex:uk_task_user;index"`
+	Role    string    `gorm:"column:role;type:varchar(20);not null"`
+	AddedAt time.Time `gorm:"column:added_at"`
+	AddedBy string    `gorm:"column:added_by;type:varchar(36);not null"`
+}
+
+// TableName 表名
+func (TaskParticipantPO) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "task_participants")
+}
+
+// taskAggregateColumns 由任务聚合维护、更新时需要写回的列
+var taskAggregateColumns = []string{
+	"title", "description", "project_id", "creator_id", "assignee_id", "co_responsibles",
+	"status", "status_changed_at", "priority", "type", "due_date", "start_date", "approved_at", "estimated_hours", "actual_hours",
+	"workflow_id", "recurrence_rule", "reviewers", "review_quorum", "open_contribution", "paused_by_project", "updated_at", "updated_by",
+	"tags",
+}
+
+// Create 新建任务，ID已存在时失败
+func (r *TaskRepositoryImpl) Create(ctx context.Context, task aggregate.TaskAggregate) error {
+	var count int64
+	if err := r.GetDB(ctx).Model(&TaskPO{}).Where("id = ?", string(task.ID)).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("task %s: %w", task.ID, repository.ErrAlreadyExists)
+	}
+
+	task.UpdatedBy = auditUser(ctx, task.UpdatedBy, task.CreatorID)
 	po := r.aggregateToTaskPO(task)
-	return r.db.WithContext(ctx).Create(&po).Error
+	if err := r.GetDB(ctx).Create(&po).Error; err != nil {
+		return err
+	}
+	if err := r.saveParticipants(ctx, task); err != nil {
+		return err
+	}
+	if err := r.saveExtensions(ctx, task); err != nil {
+		return err
+	}
+	return r.saveWorkSubmissions(ctx, task)
 }
 
 // FindByID 根据ID查找任务
 func (r *TaskRepositoryImpl) FindByID(ctx context.Context, id valueobject.TaskID) (*aggregate.TaskAggregate, error) {
+	return r.findByID(ctx, r.GetDB(ctx), id)
+}
+
+// FindByIDForUpdate 以 SELECT ... FOR UPDATE 锁定任务行后加载任务，需在事务中调用
+func (r *TaskRepositoryImpl) FindByIDForUpdate(ctx context.Context, id valueobject.TaskID) (*aggregate.TaskAggregate, error) {
+	return r.findByID(ctx, r.GetDB(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *TaskRepositoryImpl) findByID(ctx context.Context, db *gorm.DB, id valueobject.TaskID) (*aggregate.TaskAggregate, error) {
 	var po TaskPO
-	err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", string(id)).First(&po).Error
+	err := db.Where("id = ? AND deleted_at IS NULL", string(id)).First(&po).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("task %s: %w", id, repository.ErrNotFound)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return r.taskPOToAggregate(po), nil
+
+	task := r.taskPOToAggregate(po)
+	participants, err := r.findParticipants(ctx, []string{po.ID})
+	if err != nil {
+		return nil, err
+	}
+	task.Participants = append(task.Participants, participants[po.ID]...)
+	if task.Extensions, err = r.FindExtensionsByTask(ctx, id); err != nil {
+		return nil, err
+	}
+	if task.WorkSubmissions, err = r.findWorkSubmissions(ctx, "task_id = ?", string(id), "submitted_at ASC"); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
-// Update 更新任务
+// Update 更新任务，任务不存在时失败
 func (r *TaskRepositoryImpl) Update(ctx context.Context, task aggregate.TaskAggregate) error {
+	var count int64
+	if err := r.GetDB(ctx).Model(&TaskPO{}).Where("id = ? AND deleted_at IS NULL", string(task.ID)).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("task %s: %w", task.ID, repository.ErrNotFound)
+	}
+
+	// 显式列出聚合维护的字段，零值（如清空描述）也会写入
+	task.UpdatedBy = auditUser(ctx, task.UpdatedBy)
 	po := r.aggregateToTaskPO(task)
-	return r.db.WithContext(ctx).Where("id = ?", po.ID).Updates(&po).Error
+	if err := r.GetDB(ctx).Model(&TaskPO{}).Where("id = ?", po.ID).
+		Select(taskAggregateColumns).Updates(&po).Error; err != nil {
+		return err
+	}
+	if err := r.saveParticipants(ctx, task); err != nil {
+		return err
+	}
+	if err := r.saveExtensions(ctx, task); err != nil {
+		return err
+	}
+	return r.saveWorkSubmissions(ctx, task)
+}
+
+// saveParticipants 同步任务参与者：删除已移除的参与者，新增或更新其余参与者的角色
+func (r *TaskRepositoryImpl) saveParticipants(ctx context.Context, task aggregate.TaskAggregate) error {
+	db := r.GetDB(ctx)
+
+	userIDs := make([]string, len(task.Participants))
+	for i, participant := range task.Participants {
+		userIDs[i] = string(participant.UserID)
+	}
+	stale := db.Where("task_id = ?", string(task.ID))
+	if len(userIDs) > 0 {
+		stale = stale.Where("user_id NOT IN ?", userIDs)
+	}
+	if err := stale.Delete(&TaskParticipantPO{}).Error; err != nil {
+		return fmt.Errorf("failed to remove task participants: %w", err)
+	}
+
+	for _, participant := range task.Participants {
+		po := TaskParticipantPO{
+			ID:      uuid.New().String(),
+			TaskID:  string(task.ID),
+			UserID:  string(participant.UserID),
+			Role:    string(participant.Role),
+			AddedAt: shared.ToUTC(participant.AddedAt),
+			AddedBy: string(participant.AddedBy),
+		}
+		if err := db.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"role"}),
+		}).Create(&po).Error; err != nil {
+			return fmt.Errorf("failed to save task participant: %w", err)
+		}
+	}
+	return nil
+}
+
+// findParticipants 按任务ID批量查找参与者，按加入时间排序
+func (r *TaskRepositoryImpl) findParticipants(ctx context.Context, taskIDs []string) (map[string][]valueobject.TaskParticipant, error) {
+	result := make(map[string][]valueobject.TaskParticipant, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return result, nil
+	}
+
+	var pos []TaskParticipantPO
+	if err := r.GetDB(ctx).Where("task_id IN ?", taskIDs).Order("added_at ASC, id ASC").Find(&pos).Error; err != nil {
+		return nil, fmt.Errorf("failed to find task participants: %w", err)
+	}
+	for _, po := range pos {
+		result[po.TaskID] = append(result[po.TaskID], valueobject.TaskParticipant{
+			UserID:  valueobject.UserID(po.UserID),
+			Role:    valueobject.ParticipantRole(po.Role),
+			AddedAt: shared.ToUTC(po.AddedAt),
+			AddedBy: valueobject.UserID(po.AddedBy),
+		})
+	}
+	return result, nil
+}
+
+// toAggregates 将持久化对象转换为聚合根并加载参与者
+func (r *TaskRepositoryImpl) toAggregates(ctx context.Context, pos []TaskPO) ([]aggregate.TaskAggregate, error) {
+	taskIDs := make([]string, len(pos))
+	for i, po := range pos {
+		taskIDs[i] = po.ID
+	}
+	participants, err := r.findParticipants(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates := make([]aggregate.TaskAggregate, len(pos))
+	for i, po := range pos {
+		aggregates[i] = *r.taskPOToAggregate(po)
+		aggregates[i].Participants = append(aggregates[i].Participants, participants[po.ID]...)
+	}
+	return aggregates, nil
+}
+
+// saveExtensions 保存任务的延期申请（新增或更新审批结果）
+func (r *TaskRepositoryImpl) saveExtensions(ctx context.Context, task aggregate.TaskAggregate) error {
+	for _, ext := range task.Extensions {
+		model := extensionValueToModel(ext)
+		if err := r.GetDB(ctx).Omit(clause.Associations).Save(&model).Error; err != nil {
+			return fmt.Errorf("failed to save extension request: %w", err)
+		}
+	}
+	return nil
+}
+
+// saveWorkSubmissions 保存任务的工作提交（新增或更新审核结果）
+func (r *TaskRepositoryImpl) saveWorkSubmissions(ctx context.Context, task aggregate.TaskAggregate) error {
+	for _, submission := range task.WorkSubmissions {
+		model, err := workSubmissionValueToModel(submission)
+		if err != nil {
+			return err
+		}
+		if err := r.GetDB(ctx).Save(&model).Error; err != nil {
+			return fmt.Errorf("failed to save work submission: %w", err)
+		}
+	}
+	return nil
 }
 
 // Delete 删除任务
 func (r *TaskRepositoryImpl) Delete(ctx context.Context, id valueobject.TaskID) error {
-	return r.db.WithContext(ctx).Model(&TaskPO{}).Where("id = ?", string(id)).Update("deleted_at", time.Now()).Error
+	return r.GetDB(ctx).Model(&TaskPO{}).Where("id = ?", string(id)).Updates(softDeleteColumns(ctx, time.Now())).Error
 }
 
 // FindByProjectID 根据项目ID查找任务
 func (r *TaskRepositoryImpl) FindByProjectID(ctx context.Context, projectID valueobject.ProjectID) ([]*aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("project_id = ? AND deleted_at IS NULL", string(projectID)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("project_id = ? AND deleted_at IS NULL", string(projectID)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +299,7 @@ func (r *TaskRepositoryImpl) FindByProjectID(ctx context.Context, projectID valu
 // FindByAssigneeID 根据负责人ID查找任务
 func (r *TaskRepositoryImpl) FindByAssigneeID(ctx context.Context, assigneeID valueobject.UserID) ([]*aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("assignee_id = ? AND deleted_at IS NULL", string(assigneeID)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("assignee_id = ? AND deleted_at IS NULL", string(assigneeID)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +309,7 @@ func (r *TaskRepositoryImpl) FindByAssigneeID(ctx context.Context, assigneeID va
 // FindByCreatorID 根据创建者ID查找任务
 func (r *TaskRepositoryImpl) FindByCreatorID(ctx context.Context, creatorID valueobject.UserID) ([]*aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("creator_id = ? AND deleted_at IS NULL", string(creatorID)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("creator_id = ? AND deleted_at IS NULL", string(creatorID)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +319,7 @@ func (r *TaskRepositoryImpl) FindByCreatorID(ctx context.Context, creatorID valu
 // FindByDateRange 根据日期范围查找任务
 func (r *TaskRepositoryImpl) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("created_at BETWEEN ? AND ? AND deleted_at IS NULL", startDate, endDate).Find(&pos).Error
+	err := r.GetDB(ctx).Where("created_at BETWEEN ? AND ? AND deleted_at IS NULL", startDate, endDate).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +328,7 @@ func (r *TaskRepositoryImpl) FindByDateRange(ctx context.Context, startDate, end
 
 // Search 搜索任务
 func (r *TaskRepositoryImpl) Search(ctx context.Context, criteria valueobject.TaskSearchCriteria) ([]*aggregate.TaskAggregate, error) {
-	query := r.db.WithContext(ctx).Where("deleted_at IS NULL")
+	query := r.GetDB(ctx).Where("deleted_at IS NULL")
 
 	if criteria.ProjectID != nil {
 		query = query.Where("project_id = ?", string(*criteria.ProjectID))
@@ -145,12 +348,8 @@ func (r *TaskRepositoryImpl) Search(ctx context.Context, criteria valueobject.Ta
 	if criteria.TaskType != nil {
 		query = query.Where("type = ?", string(*criteria.TaskType))
 	}
-	if criteria.Title != nil {
-		query = query.Where("title LIKE ?", "%"+*criteria.Title+"%")
-	}
-	if criteria.Description != nil {
-		query = query.Where("description LIKE ?", "%"+*criteria.Description+"%")
-	}
+	query = whereLike(query, "title", criteria.Title, criteria.PrefixMatch)
+	query = whereLike(query, "description", criteria.Description, criteria.PrefixMatch)
 
 	var pos []TaskPO
 	err := query.Find(&pos).Error
@@ -162,7 +361,7 @@ func (r *TaskRepositoryImpl) Search(ctx context.Context, criteria valueobject.Ta
 
 // Count 统计任务数量
 func (r *TaskRepositoryImpl) Count(ctx context.Context, criteria valueobject.TaskSearchCriteria) (int64, error) {
-	query := r.db.WithContext(ctx).Model(&TaskPO{}).Where("deleted_at IS NULL")
+	query := r.GetDB(ctx).Model(&TaskPO{}).Where("deleted_at IS NULL")
 
 	if criteria.ProjectID != nil {
 		query = query.Where("project_id = ?", string(*criteria.ProjectID))
@@ -182,12 +381,8 @@ func (r *TaskRepositoryImpl) Count(ctx context.Context, criteria valueobject.Tas
 	if criteria.TaskType != nil {
 		query = query.Where("type = ?", string(*criteria.TaskType))
 	}
-	if criteria.Title != nil {
-		query = query.Where("title LIKE ?", "%"+*criteria.Title+"%")
-	}
-	if criteria.Description != nil {
-		query = query.Where("description LIKE ?", "%"+*criteria.Description+"%")
-	}
+	query = whereLike(query, "title", criteria.Title, criteria.PrefixMatch)
+	query = whereLike(query, "description", criteria.Description, criteria.PrefixMatch)
 
 	var count int64
 	err := query.Count(&count).Error
@@ -203,7 +398,7 @@ func (r *TaskRepositoryImpl) FindWithPagination(ctx context.Context, criteria va
 	}
 
 	// 构建查询
-	query := r.db.WithContext(ctx).Where("deleted_at IS NULL")
+	query := r.GetDB(ctx).Where("deleted_at IS NULL")
 
 	if criteria.ProjectID != nil {
 		query = query.Where("project_id = ?", string(*criteria.ProjectID))
@@ -223,12 +418,8 @@ func (r *TaskRepositoryImpl) FindWithPagination(ctx context.Context, criteria va
 	if criteria.TaskType != nil {
 		query = query.Where("type = ?", string(*criteria.TaskType))
 	}
-	if criteria.Title != nil {
-		query = query.Where("title LIKE ?", "%"+*criteria.Title+"%")
-	}
-	if criteria.Description != nil {
-		query = query.Where("description LIKE ?", "%"+*criteria.Description+"%")
-	}
+	query = whereLike(query, "title", criteria.Title, criteria.PrefixMatch)
+	query = whereLike(query, "description", criteria.Description, criteria.PrefixMatch)
 
 	var pos []TaskPO
 	err = query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&pos).Error
@@ -242,7 +433,7 @@ func (r *TaskRepositoryImpl) FindWithPagination(ctx context.Context, criteria va
 // FindByParticipantID 根据参与者ID查找任务
 func (r *TaskRepositoryImpl) FindByParticipantID(ctx context.Context, participantID valueobject.UserID) ([]*aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("JSON_CONTAINS(participants, ?) AND deleted_at IS NULL", fmt.Sprintf(`"%s"`, string(participantID))).Find(&pos).Error
+	err := r.GetDB(ctx).Where("JSON_CONTAINS(participants, ?) AND deleted_at IS NULL", fmt.Sprintf(`"%s"`, string(participantID))).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +443,7 @@ func (r *TaskRepositoryImpl) FindByParticipantID(ctx context.Context, participan
 // FindOverdueTasks 查找过期任务
 // func (r *TaskRepositoryImpl) FindOverdueTasks(ctx context.Context) ([]*aggregate.TaskAggregate, error) {
 // 	var pos []TaskPO
-// 	err := r.db.WithContext(ctx).Where("due_date < ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
+// 	err := r.GetDB(ctx).Where("due_date < ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
 // 		time.Now(), string(valueobject.TaskStatusCompleted), string(valueobject.TaskStatusCancelled)).Find(&pos).Error
 // 	if err != nil {
 // 		return nil, err
@@ -264,7 +455,7 @@ func (r *TaskRepositoryImpl) FindByParticipantID(ctx context.Context, participan
 func (r *TaskRepositoryImpl) FindUpcomingTasks(ctx context.Context, days int) ([]*aggregate.TaskAggregate, error) {
 	upcomingDate := time.Now().AddDate(0, 0, days)
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("due_date BETWEEN ? AND ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
+	err := r.GetDB(ctx).Where("due_date BETWEEN ? AND ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
 		time.Now(), upcomingDate, string(valueobject.TaskStatusCompleted), string(valueobject.TaskStatusCancelled)).Find(&pos).Error
 	if err != nil {
 		return nil, err
@@ -275,7 +466,7 @@ func (r *TaskRepositoryImpl) FindUpcomingTasks(ctx context.Context, days int) ([
 // FindRecurringTasks 查找循环任务
 func (r *TaskRepositoryImpl) FindRecurringTasks(ctx context.Context) ([]*aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("recurrence_rule IS NOT NULL AND deleted_at IS NULL").Find(&pos).Error
+	err := r.GetDB(ctx).Where("recurrence_rule IS NOT NULL AND deleted_at IS NULL").Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
@@ -286,16 +477,20 @@ func (r *TaskRepositoryImpl) FindRecurringTasks(ctx context.Context) ([]*aggrega
 func (r *TaskRepositoryImpl) BatchSave(ctx context.Context, tasks []*aggregate.TaskAggregate) error {
 	pos := make([]TaskPO, len(tasks))
 	for i, task := range tasks {
-		pos[i] = r.aggregateToTaskPO(*task)
+		saved := *task
+		saved.UpdatedBy = auditUser(ctx, task.UpdatedBy, task.CreatorID)
+		pos[i] = r.aggregateToTaskPO(saved)
 	}
-	return r.db.WithContext(ctx).CreateInBatches(pos, 100).Error
+	return r.GetDB(ctx).CreateInBatches(pos, 100).Error
 }
 
 // BatchUpdate 批量更新任务
 func (r *TaskRepositoryImpl) BatchUpdate(ctx context.Context, tasks []*aggregate.TaskAggregate) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return r.GetDB(ctx).Transaction(func(tx *gorm.DB) error {
 		for _, task := range tasks {
-			po := r.aggregateToTaskPO(*task)
+			saved := *task
+			saved.UpdatedBy = auditUser(ctx, task.UpdatedBy)
+			po := r.aggregateToTaskPO(saved)
 			if err := tx.Where("id = ?", po.ID).Updates(&po).Error; err != nil {
 				return err
 			}
@@ -310,22 +505,45 @@ func (r *TaskRepositoryImpl) BatchDelete(ctx context.Context, ids []valueobject.
 	for i, id := range ids {
 		strIDs[i] = string(id)
 	}
-	return r.db.WithContext(ctx).Model(&TaskPO{}).Where("id IN ?", strIDs).Update("deleted_at", time.Now()).Error
+	return r.GetDB(ctx).Model(&TaskPO{}).Where("id IN ?", strIDs).Updates(softDeleteColumns(ctx, time.Now())).Error
 }
 
 // aggregateToTaskPO 将聚合根转换为持久化对象
 func (r *TaskRepositoryImpl) aggregateToTaskPO(task aggregate.TaskAggregate) TaskPO {
 	po := TaskPO{
-		ID:        string(task.ID),
-		Title:     task.Title,
-		ProjectID: string(task.ProjectID),
-		CreatorID: string(task.CreatorID),
-		Status:    string(task.Status),
-		Priority:  string(task.Priority),
-		Type:      string(task.TaskType),
-		DueDate:   task.DueDate,
-		CreatedAt: task.CreatedAt,
-		UpdatedAt: task.UpdatedAt,
+		ID:         string(task.ID),
+		Title:      task.Title,
+		ProjectID:  string(task.ProjectID),
+		CreatorID:  string(task.CreatorID),
+		Status:     string(task.Status),
+		Priority:   string(task.Priority),
+		Type:       string(task.TaskType),
+		DueDate:    shared.ToUTCPtr(task.DueDate),
+		StartDate:  shared.ToUTCPtr(task.StartDate),
+		ApprovedAt: shared.ToUTCPtr(task.ApprovedAt),
+		CreatedAt:  shared.ToUTC(task.CreatedAt),
+		UpdatedAt:  shared.ToUTC(task.UpdatedAt),
+		UpdatedBy:  nullableUserID(task.UpdatedBy),
+
+		ReviewQuorum: task.ReviewQuorum,
+
+		OpenContribution: task.OpenContribution,
+		PausedByProject:  task.PausedByProject,
+	}
+
+	if !task.StatusChangedAt.IsZero() {
+		statusChangedAt := shared.ToUTC(task.StatusChangedAt)
+		po.StatusChangedAt = &statusChangedAt
+	}
+
+	// JSON列不能写入空字符串
+	po.Tags = "[]"
+	po.Participants = "[]"
+	po.Attachments = "[]"
+	if len(task.Tags) > 0 {
+		if data, err := json.Marshal(task.Tags); err == nil {
+			po.Tags = string(data)
+		}
 	}
 
 	// 处理可选的Description字段
@@ -337,6 +555,14 @@ func (r *TaskRepositoryImpl) aggregateToTaskPO(task aggregate.TaskAggregate) Tas
 	assigneeID := string(task.ResponsibleID)
 	po.AssigneeID = &assigneeID
 
+	// 共同负责人以JSON数组存储，未设置时写入NULL
+	if len(task.CoResponsibleIDs) > 0 {
+		if data, err := json.Marshal(task.CoResponsibleIDs); err == nil {
+			coResponsibles := string(data)
+			po.CoResponsibles = &coResponsibles
+		}
+	}
+
 	// 处理EstimatedHours转换
 	if task.EstimatedHours > 0 {
 		hours := float64(task.EstimatedHours)
@@ -348,6 +574,28 @@ func (r *TaskRepositoryImpl) aggregateToTaskPO(task aggregate.TaskAggregate) Tas
 		po.ActualHours = &task.ActualHours
 	}
 
+	// 未关联工作流时写入NULL
+	if task.WorkflowID != "" {
+		workflowID := task.WorkflowID
+		po.WorkflowID = &workflowID
+	}
+
+	// 重复规则以JSON存储，未设置时写入NULL
+	if task.RecurrenceRule != nil {
+		if data, err := json.Marshal(task.RecurrenceRule); err == nil {
+			rule := string(data)
+			po.RecurrenceRule = &rule
+		}
+	}
+
+	// 审核人以JSON数组存储，未设置时写入NULL
+	if len(task.Reviewers) > 0 {
+		if data, err := json.Marshal(task.Reviewers); err == nil {
+			reviewers := string(data)
+			po.Reviewers = &reviewers
+		}
+	}
+
 	return po
 }
 
@@ -361,12 +609,23 @@ func (r *TaskRepositoryImpl) taskPOToAggregate(po TaskPO) *aggregate.TaskAggrega
 		Status:       valueobject.TaskStatus(po.Status),
 		Priority:     valueobject.TaskPriority(po.Priority),
 		TaskType:     valueobject.TaskType(po.Type),
-		DueDate:      po.DueDate,
-		WorkflowID:   "",
-		CreatedAt:    po.CreatedAt,
-		UpdatedAt:    po.UpdatedAt,
+		DueDate:      shared.ToUTCPtr(po.DueDate),
+		StartDate:    shared.ToUTCPtr(po.StartDate),
+		ApprovedAt:   shared.ToUTCPtr(po.ApprovedAt),
+		CreatedAt:    shared.ToUTC(po.CreatedAt),
+		UpdatedAt:    shared.ToUTC(po.UpdatedAt),
 		Participants: make([]valueobject.TaskParticipant, 0),
 		Events:       make([]event.DomainEvent, 0),
+		ReviewQuorum: po.ReviewQuorum,
+
+		OpenContribution: po.OpenContribution,
+		PausedByProject:  po.PausedByProject,
+	}
+
+	// 旧数据没有记录状态变更时间，以最后更新时间近似
+	task.StatusChangedAt = task.UpdatedAt
+	if po.StatusChangedAt != nil {
+		task.StatusChangedAt = shared.ToUTC(*po.StatusChangedAt)
 	}
 
 	// 处理可选的Description字段
@@ -379,6 +638,14 @@ func (r *TaskRepositoryImpl) taskPOToAggregate(po TaskPO) *aggregate.TaskAggrega
 		task.ResponsibleID = valueobject.UserID(*po.AssigneeID)
 	}
 
+	// 处理可为NULL的共同负责人
+	if po.CoResponsibles != nil {
+		var coResponsibles []valueobject.UserID
+		if err := json.Unmarshal([]byte(*po.CoResponsibles), &coResponsibles); err == nil {
+			task.CoResponsibleIDs = coResponsibles
+		}
+	}
+
 	// 处理EstimatedHours转换
 	if po.EstimatedHours != nil {
 		task.EstimatedHours = int(*po.EstimatedHours)
@@ -389,6 +656,40 @@ func (r *TaskRepositoryImpl) taskPOToAggregate(po TaskPO) *aggregate.TaskAggrega
 		task.ActualHours = *po.ActualHours
 	}
 
+	// 处理可为NULL的WorkflowID
+	if po.WorkflowID != nil {
+		task.WorkflowID = *po.WorkflowID
+	}
+
+	// 处理可为NULL的修改人
+	if po.UpdatedBy != nil {
+		task.UpdatedBy = valueobject.UserID(*po.UpdatedBy)
+	}
+
+	// 处理可为NULL的重复规则
+	if po.RecurrenceRule != nil {
+		var rule valueobject.RecurrenceRule
+		if err := json.Unmarshal([]byte(*po.RecurrenceRule), &rule); err == nil {
+			task.RecurrenceRule = &rule
+		}
+	}
+
+	// 处理可为NULL的审核人
+	if po.Reviewers != nil {
+		var reviewers []valueobject.UserID
+		if err := json.Unmarshal([]byte(*po.Reviewers), &reviewers); err == nil {
+			task.Reviewers = reviewers
+		}
+	}
+
+	// 标签以JSON数组存储，空数组不设置
+	if po.Tags != "" {
+		var tags []string
+		if err := json.Unmarshal([]byte(po.Tags), &tags); err == nil && len(tags) > 0 {
+			task.Tags = tags
+		}
+	}
+
 	return task
 }
 
@@ -409,147 +710,251 @@ func (r *TaskRepositoryImpl) FindByIDs(ctx context.Context, ids []valueobject.Ta
 	}
 
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("id IN ? AND deleted_at IS NULL", strIDs).Find(&pos).Error
+	err := r.GetDB(ctx).Where("id IN ? AND deleted_at IS NULL", strIDs).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // FindByProject 根据项目ID查找任务
 func (r *TaskRepositoryImpl) FindByProject(ctx context.Context, projectID valueobject.ProjectID) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("project_id = ? AND deleted_at IS NULL", string(projectID)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("project_id = ? AND deleted_at IS NULL", string(projectID)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // FindByCreator 根据创建者ID查找任务
 func (r *TaskRepositoryImpl) FindByCreator(ctx context.Context, creatorID valueobject.UserID) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("creator_id = ? AND deleted_at IS NULL", string(creatorID)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("creator_id = ? AND deleted_at IS NULL", string(creatorID)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
-// FindByResponsible 根据负责人ID查找任务
+// FindByResponsible 根据负责人ID查找任务，包含用户作为共同负责人的任务
 func (r *TaskRepositoryImpl) FindByResponsible(ctx context.Context, responsibleID valueobject.UserID) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("assignee_id = ? AND deleted_at IS NULL", string(responsibleID)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("(assignee_id = ? OR JSON_CONTAINS(co_responsibles, JSON_QUOTE(?))) AND deleted_at IS NULL",
+		string(responsibleID), string(responsibleID)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // FindByParticipant 根据参与者ID查找任务
 func (r *TaskRepositoryImpl) FindByParticipant(ctx context.Context, participantID valueobject.UserID) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("JSON_CONTAINS(participants, ?) AND deleted_at IS NULL", fmt.Sprintf(`"%s"`, string(participantID))).Find(&pos).Error
+	err := r.GetDB(ctx).Where("id IN (?) AND deleted_at IS NULL",
+		r.GetDB(ctx).Model(&TaskParticipantPO{}).Select("task_id").Where("user_id = ?", string(participantID))).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // FindByStatus 根据状态查找任务
 func (r *TaskRepositoryImpl) FindByStatus(ctx context.Context, status valueobject.TaskStatus) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("status = ? AND deleted_at IS NULL", string(status)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("status = ? AND deleted_at IS NULL", string(status)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // FindByPriority 根据优先级查找任务
 func (r *TaskRepositoryImpl) FindByPriority(ctx context.Context, priority valueobject.TaskPriority) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("priority = ? AND deleted_at IS NULL", string(priority)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("priority = ? AND deleted_at IS NULL", string(priority)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // FindByType 根据类型查找任务
 func (r *TaskRepositoryImpl) FindByType(ctx context.Context, taskType valueobject.TaskType) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("type = ? AND deleted_at IS NULL", string(taskType)).Find(&pos).Error
+	err := r.GetDB(ctx).Where("type = ? AND deleted_at IS NULL", string(taskType)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // FindOverdueTasks 查找过期任务
 func (r *TaskRepositoryImpl) FindOverdueTasks(ctx context.Context, asOfDate time.Time) ([]aggregate.TaskAggregate, error) {
 	var pos []TaskPO
-	err := r.db.WithContext(ctx).Where("due_date < ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
+	err := r.GetDB(ctx).Where("due_date < ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
 		asOfDate, string(valueobject.TaskStatusCompleted), string(valueobject.TaskStatusCancelled)).Find(&pos).Error
 	if err != nil {
 		return nil, err
 	}
 
-	aggregates := make([]aggregate.TaskAggregate, len(pos))
-	for i, po := range pos {
-		aggregates[i] = *r.taskPOToAggregate(po)
-	}
-	return aggregates, nil
+	return r.toAggregates(ctx, pos)
 }
 
 // SearchTasks 搜索任务
+// 标题和描述默认为包含匹配，PrefixMatch 时为前缀匹配；关键字过短时忽略；未指定排序时按创建时间倒序
+// 指定开始日期或截止日期时，没有设置对应日期的任务不会匹配
 func (r *TaskRepositoryImpl) SearchTasks(ctx context.Context, criteria valueobject.TaskSearchCriteria) ([]aggregate.TaskAggregate, int, error) {
-	return nil, 0, fmt.Errorf("not implemented yet")
+	db := r.GetDB(ctx)
+	query := db.Model(&TaskPO{}).Where("deleted_at IS NULL")
+
+	query = whereLike(query, "title", criteria.Title, criteria.PrefixMatch)
+	query = whereLike(query, "description", criteria.Description, criteria.PrefixMatch)
+	if criteria.TaskType != nil {
+		query = query.Where("type = ?", string(*criteria.TaskType))
+	}
+	if criteria.Priority != nil {
+		query = query.Where("priority = ?", string(*criteria.Priority))
+	}
+	if criteria.Status != nil {
+		query = query.Where("status = ?", string(*criteria.Status))
+	}
+	if criteria.ProjectID != nil {
+		query = query.Where("project_id = ?", string(*criteria.ProjectID))
+	}
+	if criteria.CreatorID != nil {
+		query = query.Where("creator_id = ?", string(*criteria.CreatorID))
+	}
+	if criteria.ResponsibleID != nil {
+		query = query.Where("assignee_id = ?", string(*criteria.ResponsibleID))
+	}
+	if criteria.ParticipantID != nil {
+		query = query.Where("id IN (?)",
+			db.Model(&TaskParticipantPO{}).Select("task_id").Where("user_id = ?", string(*criteria.ParticipantID)))
+	}
+	if criteria.StartDate != nil {
+		query = query.Where("start_date >= ?", criteria.StartDate.UTC())
+	}
+	if criteria.DueDate != nil {
+		query = query.Where("due_date <= ?", criteria.DueDate.UTC())
+	}
+	if criteria.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", criteria.CreatedAfter.UTC())
+	}
+	if criteria.CreatedBefore != nil {
+		query = query.Where("created_at <= ?", criteria.CreatedBefore.UTC())
+	}
+	if criteria.AccessibleBy != nil {
+		// 与 TaskAggregate.CanUserView 的访问规则保持一致：创建者、负责人、共同负责人或参与者
+		userID := string(*criteria.AccessibleBy)
+		query = query.Where("(creator_id = ? OR assignee_id = ? OR JSON_CONTAINS(co_responsibles, JSON_QUOTE(?)) OR id IN (?))",
+			userID, userID, userID,
+			db.Model(&TaskParticipantPO{}).Select("task_id").Where("user_id = ?", userID))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	orderBy, ok := taskSortColumns[criteria.OrderBy]
+	if !ok {
+		orderBy = "created_at"
+	}
+	orderDir := "DESC"
+	if strings.EqualFold(criteria.OrderDir, "asc") {
+		orderDir = "ASC"
+	}
+	query = query.Order(fmt.Sprintf("%s %s", orderBy, orderDir))
+
+	if criteria.Limit > 0 {
+		query = query.Limit(criteria.Limit)
+	}
+	if criteria.Offset > 0 {
+		query = query.Offset(criteria.Offset)
+	}
+
+	var pos []TaskPO
+	if err := query.Find(&pos).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	tasks, err := r.toAggregates(ctx, pos)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tasks, int(total), nil
 }
 
-// FindTasksDueWithin 查找指定时间内到期的任务
+// taskSortColumns 搜索允许的排序字段
+var taskSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"due_date":   "due_date",
+	"priority":   "priority",
+	"status":     "status",
+	"title":      "title",
+}
+
+// FindTasksDueWithin 查找从现在起指定时长内到期且未完成、未取消的任务，按截止时间升序
 func (r *TaskRepositoryImpl) FindTasksDueWithin(ctx context.Context, duration time.Duration) ([]aggregate.TaskAggregate, error) {
-	return nil, fmt.Errorf("not implemented yet")
+	now := time.Now().UTC()
+	return r.FindTasksDueBetween(ctx, now, now.Add(duration))
+}
+
+// FindTasksDueBetween 查找截止时间在 [from, to] 内且未完成、未取消的任务，按截止时间升序
+func (r *TaskRepositoryImpl) FindTasksDueBetween(ctx context.Context, from, to time.Time) ([]aggregate.TaskAggregate, error) {
+	var pos []TaskPO
+	err := r.GetDB(ctx).Where("due_date BETWEEN ? AND ? AND status NOT IN (?, ?) AND deleted_at IS NULL",
+		from.UTC(), to.UTC(), string(valueobject.TaskStatusCompleted), string(valueobject.TaskStatusCancelled)).
+		Order("due_date ASC, id ASC").Find(&pos).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tasks due between %s and %s: %w", from, to, err)
+	}
+
+	return r.toAggregates(ctx, pos)
+}
+
+// FindApprovedNotStarted 查找审批通过时间早于 approvedBefore 且仍处于已审批状态的任务
+func (r *TaskRepositoryImpl) FindApprovedNotStarted(ctx context.Context, approvedBefore time.Time, projectID *valueobject.ProjectID) ([]aggregate.TaskAggregate, error) {
+	query := r.GetDB(ctx).Where("status = ? AND approved_at < ? AND deleted_at IS NULL",
+		string(valueobject.TaskStatusApproved), approvedBefore.UTC())
+	if projectID != nil {
+		query = query.Where("project_id = ?", string(*projectID))
+	}
+
+	var pos []TaskPO
+	if err := query.Order("approved_at ASC").Find(&pos).Error; err != nil {
+		return nil, fmt.Errorf("failed to find approved tasks: %w", err)
+	}
+
+	return r.toAggregates(ctx, pos)
+}
+
+// FindWithEstimateAndActual 查找同时记录了预估工时和实际工时的任务，按任务ID升序
+func (r *TaskRepositoryImpl) FindWithEstimateAndActual(ctx context.Context, projectID *valueobject.ProjectID, responsibleID *valueobject.UserID) ([]aggregate.TaskAggregate, error) {
+	query := r.GetDB(ctx).Where("estimated_hours > 0 AND actual_hours > 0 AND deleted_at IS NULL")
+	if projectID != nil {
+		query = query.Where("project_id = ?", string(*projectID))
+	}
+	if responsibleID != nil {
+		query = query.Where("assignee_id = ?", string(*responsibleID))
+	}
+
+	var pos []TaskPO
+	if err := query.Order("id ASC").Find(&pos).Error; err != nil {
+		return nil, fmt.Errorf("failed to find tasks with estimates: %w", err)
+	}
+
+	return r.toAggregates(ctx, pos)
 }
 
 // FindUserAccessibleTasks 查找用户可访问的任务
@@ -557,6 +962,310 @@ func (r *TaskRepositoryImpl) FindUserAccessibleTasks(ctx context.Context, userID
 	return nil, 0, fmt.Errorf("not implemented yet")
 }
 
+// FindActionRequired 分页查找用户需要处理的未结束任务：负责（含共同负责）的进行中任务，创建的有待审批延期或（未配置审核人时）待审核工作的任务，
+// 以及作为审核人有待审核工作的任务；审核人是否已表态由调用方根据工作提交判断。本页任务的延期申请和工作提交按任务ID批量加载
+func (r *TaskRepositoryImpl) FindActionRequired(ctx context.Context, userID valueobject.UserID, limit, offset int) ([]aggregate.TaskAggregate, int, error) {
+	db := r.GetDB(ctx)
+	pendingWork := db.Model(&WorkSubmission{}).Select("task_id").
+		Where("status = ?", string(valueobject.WorkSubmissionStatusPending))
+	pendingExtensions := db.Model(&ExtensionRequest{}).Select("task_id").
+		Where("status = ?", string(valueobject.ExtensionStatusPending))
+
+	query := db.Model(&TaskPO{}).Where("deleted_at IS NULL AND status NOT IN ?",
+		[]string{string(valueobject.TaskStatusCompleted), string(valueobject.TaskStatusCancelled)}).
+		Where(db.Where("(assignee_id = ? OR JSON_CONTAINS(co_responsibles, JSON_QUOTE(?))) AND status = ?",
+			string(userID), string(userID), string(valueobject.TaskStatusInProgress)).
+			Or("creator_id = ? AND (id IN (?) OR (reviewers IS NULL AND id IN (?)))", string(userID), pendingExtensions, pendingWork).
+			Or("JSON_CONTAINS(reviewers, JSON_QUOTE(?)) AND id IN (?)", string(userID), pendingWork))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count action required tasks: %w", err)
+	}
+
+	query = query.Order("due_date IS NULL, due_date ASC, id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	var pos []TaskPO
+	if err := query.Find(&pos).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to find action required tasks: %w", err)
+	}
+
+	tasks, err := r.toAggregates(ctx, pos)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(tasks) == 0 {
+		return tasks, int(total), nil
+	}
+
+	taskIDs := make([]string, len(tasks))
+	for i := range tasks {
+		taskIDs[i] = string(tasks[i].ID)
+	}
+	extensions, err := r.findExtensions(ctx, "task_id IN ?", taskIDs, "requested_at ASC, id ASC")
+	if err != nil {
+		return nil, 0, err
+	}
+	submissions, err := r.findWorkSubmissions(ctx, "task_id IN ?", taskIDs, "submitted_at ASC, id ASC")
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[valueobject.TaskID]*aggregate.TaskAggregate, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+	for _, ext := range extensions {
+		task := byID[ext.TaskID]
+		task.Extensions = append(task.Extensions, ext)
+	}
+	for _, submission := range submissions {
+		task := byID[submission.TaskID]
+		task.WorkSubmissions = append(task.WorkSubmissions, submission)
+	}
+	return tasks, int(total), nil
+}
+
+// FindExtensionsByTask 查找任务的延期申请历史，按申请时间排序
+func (r *TaskRepositoryImpl) FindExtensionsByTask(ctx context.Context, taskID valueobject.TaskID) ([]valueobject.ExtensionRequest, error) {
+	return r.findExtensions(ctx, "task_id = ?", string(taskID), "requested_at ASC")
+}
+
+// findExtensions 按条件和顺序查找延期申请
+func (r *TaskRepositoryImpl) findExtensions(ctx context.Context, query string, arg interface{}, order string) ([]valueobject.ExtensionRequest, error) {
+	var models []ExtensionRequest
+	err := r.GetDB(ctx).Where(query, arg).Order(order).Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find extension requests: %w", err)
+	}
+
+	extensions := make([]valueobject.ExtensionRequest, len(models))
+	for i, model := range models {
+		extensions[i] = extensionModelToValue(model)
+	}
+	return extensions, nil
+}
+
+// extensionValueToModel 将延期申请值对象转换为模型
+func extensionValueToModel(ext valueobject.ExtensionRequest) ExtensionRequest {
+	model := ExtensionRequest{
+		ID:               string(ext.ID),
+		TaskID:           string(ext.TaskID),
+		RequesterID:      string(ext.RequesterID),
+		OriginalDueDate:  shared.ToUTC(ext.OriginalDueDate),
+		RequestedDueDate: shared.ToUTC(ext.RequestedDueDate),
+		Reason:           ext.Reason,
+		Status:           string(ext.Status),
+		RequestedAt:      shared.ToUTC(ext.RequestedAt),
+		ReviewedAt:       shared.ToUTCPtr(ext.ReviewedAt),
+		ReviewComment:    ext.ReviewComment,
+	}
+	if ext.ReviewerID != nil {
+		reviewerID := string(*ext.ReviewerID)
+		model.ReviewerID = &reviewerID
+	}
+	return model
+}
+
+// extensionModelToValue 将延期申请模型转换为值对象
+func extensionModelToValue(model ExtensionRequest) valueobject.ExtensionRequest {
+	ext := valueobject.ExtensionRequest{
+		ID:               valueobject.ExtensionRequestID(model.ID),
+		TaskID:           valueobject.TaskID(model.TaskID),
+		RequesterID:      valueobject.UserID(model.RequesterID),
+		OriginalDueDate:  shared.ToUTC(model.OriginalDueDate),
+		RequestedDueDate: shared.ToUTC(model.RequestedDueDate),
+		Reason:           model.Reason,
+		Status:           valueobject.ExtensionStatus(model.Status),
+		RequestedAt:      shared.ToUTC(model.RequestedAt),
+		ReviewedAt:       shared.ToUTCPtr(model.ReviewedAt),
+		ReviewComment:    model.ReviewComment,
+	}
+	if model.ReviewerID != nil {
+		reviewerID := valueobject.UserID(*model.ReviewerID)
+		ext.ReviewerID = &reviewerID
+	}
+	return ext
+}
+
+// FindWorkSubmissionsByTask 查找任务的工作提交，按提交时间倒序
+func (r *TaskRepositoryImpl) FindWorkSubmissionsByTask(ctx context.Context, taskID valueobject.TaskID) ([]valueobject.WorkSubmission, error) {
+	return r.findWorkSubmissions(ctx, "task_id = ?", string(taskID), "submitted_at DESC")
+}
+
+// FindSubmittedApprovals 查找用户提交的审批记录，三类记录以 UNION ALL 汇总后在数据库中过滤、计数和分页
+func (r *TaskRepositoryImpl) FindSubmittedApprovals(ctx context.Context, criteria valueobject.SubmittedApprovalCriteria) ([]valueobject.SubmittedApproval, int, error) {
+	db := r.GetDB(ctx)
+	tasks := tableName(db, &TaskPO{})
+	requesterID := string(criteria.RequesterID)
+
+	var parts []string
+	var args []interface{}
+	if criteria.Kind == "" || criteria.Kind == valueobject.SubmittedApprovalTaskCreation {
+		// 草稿等未进入审批的任务不计入
+		parts = append(parts, fmt.Sprintf(`
+			SELECT t.id AS id, ? AS kind, t.id AS task_id,
+			       CASE t.status WHEN ? THEN ? WHEN ? THEN ? ELSE ? END AS status,
+			       t.created_at AS submitted_at
+			FROM %s t
+			WHERE t.creator_id = ? AND t.deleted_at IS NULL
+			  AND (t.status IN (?, ?) OR t.approved_at IS NOT NULL)`, tasks))
+		args = append(args, string(valueobject.SubmittedApprovalTaskCreation),
+			string(valueobject.TaskStatusPendingApproval), string(valueobject.ApprovalStatusPending),
+			string(valueobject.TaskStatusRejected), string(valueobject.ApprovalStatusRejected),
+			string(valueobject.ApprovalStatusApproved),
+			requesterID, string(valueobject.TaskStatusPendingApproval), string(valueobject.TaskStatusRejected))
+	}
+	if criteria.Kind == "" || criteria.Kind == valueobject.SubmittedApprovalTaskCompletion {
+		parts = append(parts, fmt.Sprintf(`
+			SELECT ws.id, ?, ws.task_id, ws.status, ws.submitted_at
+			FROM %s ws JOIN %s t ON t.id = ws.task_id AND t.deleted_at IS NULL
+			WHERE ws.submitter_id = ?`, tableName(db, &WorkSubmission{}), tasks))
+		args = append(args, string(valueobject.SubmittedApprovalTaskCompletion), requesterID)
+	}
+	if criteria.Kind == "" || criteria.Kind == valueobject.SubmittedApprovalExtensionRequest {
+		parts = append(parts, fmt.Sprintf(`
+			SELECT er.id, ?, er.task_id, er.status, er.requested_at
+			FROM %s er JOIN %s t ON t.id = er.task_id AND t.deleted_at IS NULL
+			WHERE er.requester_id = ?`, tableName(db, &ExtensionRequest{}), tasks))
+		args = append(args, string(valueobject.SubmittedApprovalExtensionRequest), requesterID)
+	}
+	if len(parts) == 0 {
+		return []valueobject.SubmittedApproval{}, 0, nil
+	}
+
+	from := "FROM (" + strings.Join(parts, " UNION ALL ") + ") approvals"
+	if criteria.Status != "" {
+		from += " WHERE approvals.status = ?"
+		args = append(args, string(criteria.Status))
+	}
+
+	var total int64
+	if err := db.Raw("SELECT COUNT(*) "+from, args...).Scan(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count submitted approvals: %w", err)
+	}
+
+	query := "SELECT approvals.id, approvals.kind, approvals.task_id, approvals.status, approvals.submitted_at " + from +
+		" ORDER BY approvals.submitted_at DESC, approvals.id ASC"
+	if criteria.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, criteria.Limit, criteria.Offset)
+	}
+	var rows []struct {
+		ID          string
+		Kind        string
+		TaskID      string
+		Status      string
+		SubmittedAt time.Time
+	}
+	if err := db.Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to find submitted approvals: %w", err)
+	}
+
+	approvals := make([]valueobject.SubmittedApproval, len(rows))
+	for i, row := range rows {
+		approvals[i] = valueobject.SubmittedApproval{
+			ID:          row.ID,
+			Kind:        valueobject.SubmittedApprovalKind(row.Kind),
+			TaskID:      valueobject.TaskID(row.TaskID),
+			Status:      valueobject.ApprovalStatus(row.Status),
+			SubmittedAt: row.SubmittedAt.UTC(),
+		}
+	}
+	return approvals, int(total), nil
+}
+
+// findWorkSubmissions 按条件和顺序查找工作提交
+func (r *TaskRepositoryImpl) findWorkSubmissions(ctx context.Context, query string, arg interface{}, order string) ([]valueobject.WorkSubmission, error) {
+	var models []WorkSubmission
+	err := r.GetDB(ctx).Where(query, arg).Order(order).Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find work submissions: %w", err)
+	}
+
+	submissions := make([]valueobject.WorkSubmission, len(models))
+	for i, model := range models {
+		if submissions[i], err = workSubmissionModelToValue(model); err != nil {
+			return nil, err
+		}
+	}
+	return submissions, nil
+}
+
+// workSubmissionValueToModel 将工作提交值对象转换为模型，附件以JSON数组存储
+func workSubmissionValueToModel(submission valueobject.WorkSubmission) (WorkSubmission, error) {
+	attachments := submission.Attachments
+	if attachments == nil {
+		attachments = []string{}
+	}
+	data, err := json.Marshal(attachments)
+	if err != nil {
+		return WorkSubmission{}, fmt.Errorf("failed to encode work submission attachments: %w", err)
+	}
+
+	model := WorkSubmission{
+		ID:            string(submission.ID),
+		TaskID:        string(submission.TaskID),
+		SubmitterID:   string(submission.SubmitterID),
+		Content:       submission.Content,
+		Attachments:   string(data),
+		Status:        string(submission.Status),
+		SubmittedAt:   shared.ToUTC(submission.SubmittedAt),
+		ReviewedAt:    shared.ToUTCPtr(submission.ReviewedAt),
+		ReviewComment: submission.ReviewComment,
+	}
+	if submission.ReviewerID != nil {
+		reviewerID := string(*submission.ReviewerID)
+		model.ReviewerID = &reviewerID
+	}
+	if len(submission.Decisions) > 0 {
+		decisions, err := json.Marshal(submission.Decisions)
+		if err != nil {
+			return WorkSubmission{}, fmt.Errorf("failed to encode work submission review decisions: %w", err)
+		}
+		value := string(decisions)
+		model.ReviewDecisions = &value
+	}
+	return model, nil
+}
+
+// workSubmissionModelToValue 将工作提交模型转换为值对象
+func workSubmissionModelToValue(model WorkSubmission) (valueobject.WorkSubmission, error) {
+	attachments := []string{}
+	if model.Attachments != "" {
+		if err := json.Unmarshal([]byte(model.Attachments), &attachments); err != nil {
+			return valueobject.WorkSubmission{}, fmt.Errorf("failed to decode work submission attachments: %w", err)
+		}
+	}
+
+	submission := valueobject.WorkSubmission{
+		ID:            valueobject.WorkSubmissionID(model.ID),
+		TaskID:        valueobject.TaskID(model.TaskID),
+		SubmitterID:   valueobject.UserID(model.SubmitterID),
+		Content:       model.Content,
+		Attachments:   attachments,
+		Status:        valueobject.WorkSubmissionStatus(model.Status),
+		SubmittedAt:   shared.ToUTC(model.SubmittedAt),
+		ReviewedAt:    shared.ToUTCPtr(model.ReviewedAt),
+		ReviewComment: model.ReviewComment,
+	}
+	if model.ReviewerID != nil {
+		reviewerID := valueobject.UserID(*model.ReviewerID)
+		submission.ReviewerID = &reviewerID
+	}
+	if model.ReviewDecisions != nil {
+		if err := json.Unmarshal([]byte(*model.ReviewDecisions), &submission.Decisions); err != nil {
+			return valueobject.WorkSubmission{}, fmt.Errorf("failed to decode work submission review decisions: %w", err)
+		}
+	}
+	return submission, nil
+}
+
 // CountByProject 按项目统计任务数量
 func (r *TaskRepositoryImpl) CountByProject(ctx context.Context, projectID valueobject.ProjectID) (int, error) {
 	return 0, fmt.Errorf("not implemented yet")
@@ -572,12 +1281,197 @@ func (r *TaskRepositoryImpl) CountByResponsible(ctx context.Context, responsible
 	return 0, fmt.Errorf("not implemented yet")
 }
 
-// GetTaskStatistics 获取任务统计信息
+// taskWorkloadRow 统计负载时读取的任务负责人和截止时间
+type taskWorkloadRow struct {
+	AssigneeID     *string
+	CoResponsibles *string
+	DueDate        *time.Time
+}
+
+// CountWorkloadByResponsible 按负责人统计未结束和逾期任务数，共同负责的任务同样计入
+func (r *TaskRepositoryImpl) CountWorkloadByResponsible(ctx context.Context, responsibleIDs []valueobject.UserID) (map[valueobject.UserID]valueobject.TaskWorkload, error) {
+	result := make(map[valueobject.UserID]valueobject.TaskWorkload, len(responsibleIDs))
+	if len(responsibleIDs) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(responsibleIDs))
+	for i, id := range responsibleIDs {
+		ids[i] = string(id)
+		result[id] = valueobject.TaskWorkload{ResponsibleID: id}
+	}
+
+	db := r.GetDB(ctx)
+	responsible := db.Where("assignee_id IN ?", ids)
+	for _, id := range ids {
+		responsible = responsible.Or("JSON_CONTAINS(co_responsibles, JSON_QUOTE(?))", id)
+	}
+
+	var rows []taskWorkloadRow
+	err := db.Model(&TaskPO{}).
+		Select("assignee_id, co_responsibles, due_date").
+		Where("status NOT IN ? AND deleted_at IS NULL",
+			[]string{string(valueobject.TaskStatusCompleted), string(valueobject.TaskStatusCancelled)}).
+		Where(responsible).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count task workload: %w", err)
+	}
+
+	now := shared.NowUTC()
+	for _, row := range rows {
+		responsibles := map[valueobject.UserID]bool{}
+		if row.AssigneeID != nil {
+			responsibles[valueobject.UserID(*row.AssigneeID)] = true
+		}
+		if row.CoResponsibles != nil {
+			var coResponsibles []valueobject.UserID
+			if err := json.Unmarshal([]byte(*row.CoResponsibles), &coResponsibles); err == nil {
+				for _, id := range coResponsibles {
+					responsibles[id] = true
+				}
+			}
+		}
+		for id := range responsibles {
+			workload, ok := result[id]
+			if !ok {
+				continue
+			}
+			workload.OpenTasks++
+			if row.DueDate != nil && row.DueDate.Before(now) {
+				workload.OverdueTasks++
+			}
+			result[id] = workload
+		}
+	}
+	return result, nil
+}
+
+// GetTaskStatistics 获取任务统计信息，工作提交按状态分组聚合，不加载任务聚合
+// 任务不存在时返回 ErrNotFound
 func (r *TaskRepositoryImpl) GetTaskStatistics(ctx context.Context, taskID valueobject.TaskID) (*valueobject.TaskStatistics, error) {
-	return nil, fmt.Errorf("not implemented yet")
+	db := r.GetDB(ctx)
+
+	var task TaskPO
+	err := db.Select("id", "actual_hours").Where("id = ? AND deleted_at IS NULL", string(taskID)).Take(&task).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("task %s: %w", taskID, repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Status          string
+		Total           int
+		LastSubmittedAt time.Time
+	}
+	if err := db.Model(&WorkSubmission{}).
+		Select("status, COUNT(*) AS total, MAX(submitted_at) AS last_submitted_at").
+		Where("task_id = ?", string(taskID)).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate work submissions: %w", err)
+	}
+
+	var participants int64
+	if err := db.Model(&TaskParticipantPO{}).Where("task_id = ?", string(taskID)).Count(&participants).Error; err != nil {
+		return nil, fmt.Errorf("failed to count task participants: %w", err)
+	}
+
+	stats := &valueobject.TaskStatistics{
+		TaskID:             taskID,
+		TotalParticipants:  int(participants),
+		ActiveParticipants: int(participants),
+	}
+	for _, row := range rows {
+		stats.AddExecutions(valueobject.WorkSubmissionStatus(row.Status), row.Total, shared.ToUTC(row.LastSubmittedAt))
+	}
+	actualHours := 0.0
+	if task.ActualHours != nil {
+		actualHours = *task.ActualHours
+	}
+	stats.Finalize(actualHours)
+	return stats, nil
+}
+
+// projectTaskStatisticsRow 按状态、优先级、类型分组的聚合结果，批量统计时同时按项目分组
+type projectTaskStatisticsRow struct {
+	ProjectID   string
+	Status      string
+	Priority    string
+	Type        string
+	Total       int
+	Overdue     int
+	ActualHours float64
 }
 
 // GetProjectTaskStatistics 获取项目任务统计信息
 func (r *TaskRepositoryImpl) GetProjectTaskStatistics(ctx context.Context, projectID valueobject.ProjectID) (*valueobject.ProjectTaskStatistics, error) {
-	return nil, fmt.Errorf("not implemented yet")
+	return r.aggregateTaskStatistics(r.GetDB(ctx).Where("project_id = ?", string(projectID)), projectID)
+}
+
+// GetAllTaskStatistics 统计全部项目的任务
+func (r *TaskRepositoryImpl) GetAllTaskStatistics(ctx context.Context) (*valueobject.ProjectTaskStatistics, error) {
+	return r.aggregateTaskStatistics(r.GetDB(ctx), "")
+}
+
+// GetProjectsTaskStatistics 批量统计多个项目的任务，一次查询按项目分组聚合
+func (r *TaskRepositoryImpl) GetProjectsTaskStatistics(ctx context.Context, projectIDs []valueobject.ProjectID) (map[valueobject.ProjectID]*valueobject.ProjectTaskStatistics, error) {
+	result := make(map[valueobject.ProjectID]*valueobject.ProjectTaskStatistics, len(projectIDs))
+	if len(projectIDs) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(projectIDs))
+	for i, id := range projectIDs {
+		ids[i] = string(id)
+		result[id] = valueobject.NewProjectTaskStatistics(id)
+	}
+
+	var rows []projectTaskStatisticsRow
+	if err := r.taskStatisticsQuery(r.GetDB(ctx).Where("project_id IN ?", ids), "project_id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate task statistics: %w", err)
+	}
+
+	for _, row := range rows {
+		if stats, ok := result[valueobject.ProjectID(row.ProjectID)]; ok {
+			stats.Add(valueobject.TaskStatus(row.Status), valueobject.TaskPriority(row.Priority),
+				valueobject.TaskType(row.Type), row.Total, row.Overdue, row.ActualHours)
+		}
+	}
+	for _, stats := range result {
+		stats.Finalize()
+	}
+	return result, nil
+}
+
+// aggregateTaskStatistics 在数据库中按状态、优先级、类型分组聚合
+// 分组数有限，内存占用不随任务数量增长
+func (r *TaskRepositoryImpl) aggregateTaskStatistics(query *gorm.DB, projectID valueobject.ProjectID) (*valueobject.ProjectTaskStatistics, error) {
+	var rows []projectTaskStatisticsRow
+	if err := r.taskStatisticsQuery(query).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate task statistics: %w", err)
+	}
+
+	stats := valueobject.NewProjectTaskStatistics(projectID)
+	for _, row := range rows {
+		stats.Add(valueobject.TaskStatus(row.Status), valueobject.TaskPriority(row.Priority),
+			valueobject.TaskType(row.Type), row.Total, row.Overdue, row.ActualHours)
+	}
+	stats.Finalize()
+	return stats, nil
+}
+
+// taskStatisticsQuery 构造按状态、优先级、类型分组的统计查询，groupBy 为额外的分组列
+func (r *TaskRepositoryImpl) taskStatisticsQuery(query *gorm.DB, groupBy ...string) *gorm.DB {
+	columns := strings.Join(append(groupBy, "status", "priority", "type"), ", ")
+	return query.Model(&TaskPO{}).
+		Select(columns+`, COUNT(*) AS total,
+			SUM(CASE WHEN due_date IS NOT NULL AND due_date < ? AND status NOT IN ?
+				THEN 1 ELSE 0 END) AS overdue,
+			COALESCE(SUM(actual_hours), 0) AS actual_hours`,
+			shared.NowUTC(), []string{string(valueobject.TaskStatusCompleted), string(valueobject.TaskStatusCancelled)}).
+		Where("deleted_at IS NULL").
+		Group(columns)
 }
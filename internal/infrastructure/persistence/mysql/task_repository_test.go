@@ -0,0 +1,1291 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+)
+
+func TestTaskPOConversion_DueDateRoundTripAsUTC(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+
+	repo := &TaskRepositoryImpl{}
+	dueDate := time.Date(2024, 3, 1, 18, 30, 0, 0, shanghai)
+	task := aggregate.TaskAggregate{
+		ID:        valueobject.TaskID("task-1"),
+		Title:     "Round trip",
+		DueDate:   &dueDate,
+		CreatedAt: time.Date(2024, 2, 1, 9, 0, 0, 0, shanghai),
+		UpdatedAt: time.Date(2024, 2, 1, 9, 0, 0, 0, shanghai),
+	}
+
+	po := repo.aggregateToTaskPO(task)
+	require.NotNil(t, po.DueDate)
+	assert.Equal(t, time.UTC, po.DueDate.Location())
+	assert.Equal(t, time.UTC, po.CreatedAt.Location())
+
+	restored := repo.taskPOToAggregate(po)
+	require.NotNil(t, restored.DueDate)
+	assert.Equal(t, time.UTC, restored.DueDate.Location())
+	assert.True(t, restored.DueDate.Equal(dueDate))
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC), *restored.DueDate)
+}
+
+func newRepoTestTask(id string) aggregate.TaskAggregate {
+	now := time.Now().UTC()
+	return aggregate.TaskAggregate{
+		ID:            valueobject.TaskID(id),
+		Title:         "Task " + id,
+		TaskType:      valueobject.TaskTypeRegular,
+		Priority:      valueobject.TaskPriorityMedium,
+		Status:        valueobject.TaskStatusDraft,
+		ProjectID:     "project-1",
+		CreatorID:     "creator-1",
+		ResponsibleID: "responsible-1",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+
+		StatusChangedAt: now,
+	}
+}
+
+func TestTaskRepository_CreateRejectsExistingID(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-1")))
+
+	duplicate := newRepoTestTask("task-1")
+	duplicate.Title = "Duplicate"
+	err := repo.Create(ctx, duplicate)
+
+	assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Task task-1", stored.Title)
+}
+
+func TestTaskRepository_UpdateRejectsMissingID(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+
+	err := repo.Update(context.Background(), newRepoTestTask("task-missing"))
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	var count int64
+	require.NoError(t, db.Model(&TaskPO{}).Where("id = ?", "task-missing").Count(&count).Error)
+	assert.Zero(t, count, "update must not insert a missing task")
+}
+
+func TestTaskRepository_FindByIDMissingReturnsErrNotFound(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, "task-missing")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// 软删除的任务同样视为不存在
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-deleted")))
+	require.NoError(t, repo.Delete(ctx, "task-deleted"))
+	_, err = repo.FindByID(ctx, "task-deleted")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestTaskRepository_WorkSubmissionsRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	task := newRepoTestTask("task-1")
+	task.WorkSubmissions = []valueobject.WorkSubmission{
+		{ID: "ws-1", TaskID: "task-1", SubmitterID: "responsible-1", Content: "first draft",
+			Status: valueobject.WorkSubmissionStatusPending, SubmittedAt: base},
+		{ID: "ws-2", TaskID: "task-1", SubmitterID: "responsible-1", Content: "second draft", Attachments: []string{"file-1", "file-2"},
+			Status: valueobject.WorkSubmissionStatusPending, SubmittedAt: base.Add(time.Hour)},
+	}
+	require.NoError(t, repo.Create(ctx, task))
+
+	// 审核结果随任务更新写回
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, stored.WorkSubmissions, 2)
+	require.NoError(t, stored.ReviewWork("responsible-1", "creator-1", true, "looks good"))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	submissions, err := repo.FindWorkSubmissionsByTask(ctx, "task-1")
+
+	require.NoError(t, err)
+	require.Len(t, submissions, 2)
+	assert.Equal(t, valueobject.WorkSubmissionID("ws-2"), submissions[0].ID)
+	assert.Equal(t, []string{"file-1", "file-2"}, submissions[0].Attachments)
+	assert.Equal(t, valueobject.WorkSubmissionStatusApproved, submissions[0].Status)
+	require.NotNil(t, submissions[0].ReviewerID)
+	assert.Equal(t, valueobject.UserID("creator-1"), *submissions[0].ReviewerID)
+	assert.Equal(t, "looks good", *submissions[0].ReviewComment)
+	assert.Equal(t, valueobject.WorkSubmissionID("ws-1"), submissions[1].ID)
+	assert.Equal(t, []string{}, submissions[1].Attachments)
+	assert.Equal(t, valueobject.WorkSubmissionStatusPending, submissions[1].Status)
+}
+
+func TestTaskRepository_CoResponsiblesRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	require.NoError(t, repo.Create(ctx, task))
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Empty(t, stored.CoResponsibleIDs)
+
+	require.NoError(t, stored.AssignResponsibles("lead-1", []valueobject.UserID{"co-1", "co-2"}, "creator-1"))
+	require.NoError(t, repo.Update(ctx, *stored))
+	stored, err = repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("lead-1"), stored.ResponsibleID)
+	assert.Equal(t, []valueobject.UserID{"co-1", "co-2"}, stored.CoResponsibleIDs)
+
+	// 单人分配清除共同负责人
+	require.NoError(t, stored.AssignResponsible("solo-1", "creator-1"))
+	require.NoError(t, repo.Update(ctx, *stored))
+	stored, err = repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Empty(t, stored.CoResponsibleIDs)
+}
+
+func TestTaskRepository_ReviewQuorumRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	require.NoError(t, task.SetReviewers([]valueobject.UserID{"reviewer-1", "reviewer-2", "reviewer-3"}, 2, "creator-1"))
+	task.WorkSubmissions = []valueobject.WorkSubmission{
+		{ID: "ws-1", TaskID: "task-1", SubmitterID: "responsible-1", Content: "draft",
+			Status: valueobject.WorkSubmissionStatusPending, SubmittedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	require.NoError(t, repo.Create(ctx, task))
+
+	// 第一份审核意见随任务更新写回，提交仍待审核
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, []valueobject.UserID{"reviewer-1", "reviewer-2", "reviewer-3"}, stored.Reviewers)
+	assert.Equal(t, 2, stored.ReviewQuorum)
+	require.NoError(t, stored.ReviewWork("responsible-1", "reviewer-1", true, "ok"))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	stored, err = repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, stored.WorkSubmissions, 1)
+	assert.Equal(t, valueobject.WorkSubmissionStatusPending, stored.WorkSubmissions[0].Status)
+	require.Len(t, stored.WorkSubmissions[0].Decisions, 1)
+	assert.Equal(t, valueobject.UserID("reviewer-1"), stored.WorkSubmissions[0].Decisions[0].ReviewerID)
+
+	require.NoError(t, stored.ReviewWork("responsible-1", "reviewer-2", true, "lgtm"))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	submissions, err := repo.FindWorkSubmissionsByTask(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, submissions, 1)
+	assert.Equal(t, valueobject.WorkSubmissionStatusApproved, submissions[0].Status)
+	assert.Equal(t, 2, submissions[0].Approvals())
+}
+
+func TestTaskRepository_FindExtensionsByTask(t *testing.T) {
+	db := setupTestDB(t, &ExtensionRequest{})
+	repo := NewTaskRepository(db)
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	reviewedAt := base.Add(2 * time.Hour)
+	approver, rejector := "reviewer-1", "reviewer-2"
+	approveComment, rejectComment := "ok", "too late"
+	models := []ExtensionRequest{
+		{ID: "ext-2", TaskID: "task-1", RequesterID: "user-1", OriginalDueDate: base, RequestedDueDate: base.AddDate(0, 0, 14),
+			Reason: "second", Status: "rejected", RequestedAt: base.Add(time.Hour), ReviewedAt: &reviewedAt, ReviewerID: &rejector, ReviewComment: &rejectComment},
+		{ID: "ext-1", TaskID: "task-1", RequesterID: "user-1", OriginalDueDate: base, RequestedDueDate: base.AddDate(0, 0, 7),
+			Reason: "first", Status: "approved", RequestedAt: base, ReviewedAt: &reviewedAt, ReviewerID: &approver, ReviewComment: &approveComment},
+		{ID: "ext-other", TaskID: "task-2", RequesterID: "user-1", OriginalDueDate: base, RequestedDueDate: base.AddDate(0, 0, 7),
+			Reason: "other task", Status: "pending", RequestedAt: base},
+	}
+	require.NoError(t, db.Omit("Task", "Requester", "Reviewer").Create(&models).Error)
+
+	extensions, err := repo.FindExtensionsByTask(context.Background(), "task-1")
+
+	require.NoError(t, err)
+	require.Len(t, extensions, 2)
+	assert.Equal(t, valueobject.ExtensionRequestID("ext-1"), extensions[0].ID)
+	assert.Equal(t, valueobject.ExtensionStatusApproved, extensions[0].Status)
+	require.NotNil(t, extensions[0].ReviewerID)
+	assert.Equal(t, valueobject.UserID("reviewer-1"), *extensions[0].ReviewerID)
+	assert.Equal(t, "ok", *extensions[0].ReviewComment)
+	assert.Equal(t, valueobject.ExtensionStatusRejected, extensions[1].Status)
+	require.NotNil(t, extensions[1].ReviewerID)
+	assert.Equal(t, valueobject.UserID("reviewer-2"), *extensions[1].ReviewerID)
+	assert.Equal(t, "too late", *extensions[1].ReviewComment)
+}
+
+func TestTaskRepository_FindSubmittedApprovals(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, id := range []valueobject.TaskID{"task-1", "task-2"} {
+		task := newRepoTestTask(string(id))
+		if id == "task-1" {
+			task.CreatorID = "user-1"
+			task.Status = valueobject.TaskStatusPendingApproval
+			task.CreatedAt = base.Add(-time.Hour)
+		}
+		task.Extensions = []valueobject.ExtensionRequest{
+			{ID: valueobject.ExtensionRequestID("ext-" + id), TaskID: id, RequesterID: "user-1", OriginalDueDate: base,
+				RequestedDueDate: base.AddDate(0, 0, 7), Reason: "more time", Status: valueobject.ExtensionStatusPending,
+				RequestedAt: base},
+		}
+		task.WorkSubmissions = []valueobject.WorkSubmission{
+			{ID: valueobject.WorkSubmissionID("ws-" + id), TaskID: id, SubmitterID: "user-1", Content: "done",
+				Status: valueobject.WorkSubmissionStatusApproved, SubmittedAt: base},
+			{ID: valueobject.WorkSubmissionID("ws-other-" + id), TaskID: id, SubmitterID: "user-2", Content: "done",
+				Status: valueobject.WorkSubmissionStatusPending, SubmittedAt: base},
+		}
+		require.NoError(t, repo.Create(ctx, task))
+	}
+	require.NoError(t, db.Model(&ExtensionRequest{}).Where("id = ?", "ext-task-2").Update("requested_at", base.Add(time.Hour)).Error)
+	ids := func(approvals []valueobject.SubmittedApproval) []string {
+		result := make([]string, len(approvals))
+		for i, approval := range approvals {
+			result[i] = approval.ID
+		}
+		return result
+	}
+
+	approvals, total, err := repo.FindSubmittedApprovals(ctx, valueobject.SubmittedApprovalCriteria{RequesterID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []string{"ext-task-2", "ext-task-1", "ws-task-1", "ws-task-2", "task-1"}, ids(approvals), "newest first, ties broken by id")
+	assert.Equal(t, valueobject.SubmittedApprovalTaskCreation, approvals[4].Kind)
+	assert.Equal(t, valueobject.ApprovalStatusPending, approvals[4].Status)
+
+	approvals, total, err = repo.FindSubmittedApprovals(ctx, valueobject.SubmittedApprovalCriteria{RequesterID: "user-1", Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []string{"ext-task-1", "ws-task-1"}, ids(approvals))
+
+	approvals, total, err = repo.FindSubmittedApprovals(ctx, valueobject.SubmittedApprovalCriteria{
+		RequesterID: "user-1", Status: valueobject.ApprovalStatusPending, Limit: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []string{"ext-task-2", "ext-task-1", "task-1"}, ids(approvals))
+
+	approvals, total, err = repo.FindSubmittedApprovals(ctx, valueobject.SubmittedApprovalCriteria{
+		RequesterID: "user-1", Kind: valueobject.SubmittedApprovalTaskCompletion, Limit: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []string{"ws-task-1", "ws-task-2"}, ids(approvals))
+
+	require.NoError(t, repo.Delete(ctx, "task-2"))
+	_, total, err = repo.FindSubmittedApprovals(ctx, valueobject.SubmittedApprovalCriteria{RequesterID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total, "records of deleted tasks are not counted")
+}
+
+func TestTaskRepository_UpdatePersistsPendingExtension(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	dueDate := time.Now().UTC().Add(48 * time.Hour)
+	task.DueDate = &dueDate
+	require.NoError(t, repo.Create(ctx, task))
+
+	requestID, err := task.RequestExtension(task.CreatorID, dueDate.Add(72*time.Hour), "more time")
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(ctx, task))
+
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	pending := stored.GetPendingExtension()
+	require.NotNil(t, pending)
+	assert.Equal(t, requestID, pending.ID)
+
+	_, err = stored.RequestExtension(stored.CreatorID, dueDate.Add(96*time.Hour), "again")
+	assert.Error(t, err, "a reloaded task must still see its pending extension")
+}
+
+func TestTaskRepository_CreateRollsBackWithTransaction(t *testing.T) {
+	setupLogger(t)
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	txMgr := NewTransactionManager(db)
+	ctx := context.Background()
+
+	err := txMgr.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := repo.Create(txCtx, newRepoTestTask("task-rollback")); err != nil {
+			return err
+		}
+		return errors.New("later step failed")
+	})
+
+	require.Error(t, err)
+	var count int64
+	require.NoError(t, db.Model(&TaskPO{}).Where("id = ?", "task-rollback").Count(&count).Error)
+	assert.Zero(t, count, "task write must roll back with the surrounding transaction")
+}
+
+func TestTaskRepository_CreateCommitsWithTransaction(t *testing.T) {
+	setupLogger(t)
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	txMgr := NewTransactionManager(db)
+	ctx := context.Background()
+
+	err := txMgr.WithTransaction(ctx, func(txCtx context.Context) error {
+		return repo.Create(txCtx, newRepoTestTask("task-commit"))
+	})
+
+	require.NoError(t, err)
+	_, err = repo.FindByID(ctx, "task-commit")
+	assert.NoError(t, err)
+}
+
+func TestTaskRepository_FindByIDForUpdateSerializesConcurrentExtensionRequests(t *testing.T) {
+	setupLogger(t)
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	txMgr := NewTransactionManager(db)
+	ctx := context.Background()
+	dueDate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	task := newRepoTestTask("task-1")
+	task.DueDate = &dueDate
+	require.NoError(t, repo.Create(ctx, task))
+
+	const requests = 5
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- txMgr.WithTransaction(ctx, func(txCtx context.Context) error {
+				locked, err := repo.FindByIDForUpdate(txCtx, "task-1")
+				if err != nil {
+					return err
+				}
+				if _, err := locked.RequestExtension(locked.CreatorID, dueDate.Add(time.Duration(i+1)*time.Hour), "concurrent"); err != nil {
+					return err
+				}
+				return repo.Update(txCtx, *locked)
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var domainErr aggregate.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "EXTENSION_ALREADY_PENDING", domainErr.Code)
+	}
+	assert.Equal(t, 1, succeeded)
+	extensions, err := repo.FindExtensionsByTask(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Len(t, extensions, 1)
+
+	_, err = repo.FindByIDForUpdate(ctx, "task-missing")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestTaskRepository_WorkflowIDRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-1")))
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Empty(t, stored.WorkflowID, "a task without workflow must load from a NULL column")
+
+	require.NoError(t, stored.SetWorkflow("workflow-1", stored.CreatorID))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	reloaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, "workflow-1", reloaded.WorkflowID)
+
+	require.NoError(t, reloaded.SetWorkflow("", reloaded.CreatorID))
+	require.NoError(t, repo.Update(ctx, *reloaded))
+	var po TaskPO
+	require.NoError(t, db.Where("id = ?", "task-1").First(&po).Error)
+	assert.Nil(t, po.WorkflowID, "clearing the workflow must write NULL")
+}
+
+func TestTaskRepository_RecurrenceRuleRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-1")))
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Nil(t, stored.RecurrenceRule)
+
+	maxExecutions := 12
+	rule := &valueobject.RecurrenceRule{Frequency: valueobject.RecurrenceWeekly, IntervalValue: 2, MaxExecutions: &maxExecutions}
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	stored.StartDate = &start
+	require.NoError(t, stored.ChangeType(valueobject.TaskTypeRecurring, rule, stored.CreatorID))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	reloaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TaskTypeRecurring, reloaded.TaskType)
+	require.NotNil(t, reloaded.RecurrenceRule)
+	assert.Equal(t, *rule, *reloaded.RecurrenceRule)
+	require.NotNil(t, reloaded.StartDate, "the start date anchors the recurrence")
+	assert.True(t, start.Equal(*reloaded.StartDate))
+
+	// 变回常规任务时清除重复规则
+	require.NoError(t, reloaded.ChangeType(valueobject.TaskTypeRegular, nil, reloaded.CreatorID))
+	require.NoError(t, repo.Update(ctx, *reloaded))
+	var po TaskPO
+	require.NoError(t, db.Where("id = ?", "task-1").First(&po).Error)
+	assert.Nil(t, po.RecurrenceRule, "clearing the rule must write NULL")
+}
+
+func TestTaskRepository_SetRecurrenceRuleDrivesNextExecution(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	task.TaskType = valueobject.TaskTypeRecurring
+	start := time.Now().UTC().Truncate(time.Hour).AddDate(0, 0, -7)
+	task.StartDate = &start
+	endDate := start.AddDate(0, 3, 0)
+	maxExecutions := 20
+	require.NoError(t, task.SetRecurrenceRule(valueobject.RecurrenceDaily, 3, &endDate, &maxExecutions))
+	require.NoError(t, repo.Create(ctx, task))
+
+	reloaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.NotNil(t, reloaded.RecurrenceRule)
+	assert.Equal(t, valueobject.RecurrenceDaily, reloaded.RecurrenceRule.Frequency)
+	assert.Equal(t, 3, reloaded.RecurrenceRule.IntervalValue)
+	require.NotNil(t, reloaded.RecurrenceRule.EndDate)
+	assert.True(t, endDate.Equal(*reloaded.RecurrenceRule.EndDate))
+	require.NotNil(t, reloaded.RecurrenceRule.MaxExecutions)
+	assert.Equal(t, 20, *reloaded.RecurrenceRule.MaxExecutions)
+
+	// 每三天一次：7 天前开始，下次执行为开始后第 9 天，而不是按周推算
+	reloaded.ClearEvents()
+	_, err = reloaded.PrepareNextExecution()
+	require.NoError(t, err)
+	prepared, ok := reloaded.Events[0].(*event.NextExecutionPreparedEvent)
+	require.True(t, ok)
+	assert.True(t, start.AddDate(0, 0, 9).Equal(prepared.ExecutionDate), "got %v", prepared.ExecutionDate)
+
+	require.NoError(t, reloaded.DisableRecurrence(reloaded.CreatorID))
+	require.NoError(t, repo.Update(ctx, *reloaded))
+	var po TaskPO
+	require.NoError(t, db.Where("id = ?", "task-1").First(&po).Error)
+	assert.Nil(t, po.RecurrenceRule, "disabling recurrence must write NULL")
+	assert.Equal(t, string(valueobject.TaskTypeRegular), po.Type)
+}
+
+func TestTaskRepository_RecordsUpdatedBy(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-1")))
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("creator-1"), stored.UpdatedBy, "without an authenticated user the creator is recorded")
+
+	require.NoError(t, stored.UpdateBasicInfo("Edited", ""))
+	require.NoError(t, repo.Update(shared.WithActor(ctx, "editor-1"), *stored))
+	edited, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("editor-1"), edited.UpdatedBy)
+
+	// 后台任务等未认证的写入保留原修改人
+	require.NoError(t, repo.Update(ctx, *edited))
+	unchanged, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.UserID("editor-1"), unchanged.UpdatedBy)
+
+	require.NoError(t, repo.Delete(shared.WithActor(ctx, "admin-1"), "task-1"))
+	var po TaskPO
+	require.NoError(t, db.Where("id = ?", "task-1").First(&po).Error)
+	require.NotNil(t, po.UpdatedBy)
+	assert.Equal(t, "admin-1", *po.UpdatedBy)
+}
+
+func TestTaskRepository_ScheduleStartDateRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-1")))
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Nil(t, stored.StartDate)
+
+	start := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	due := start.AddDate(0, 0, 4)
+	require.NoError(t, stored.UpdateSchedule(&start, &due, "creator-1"))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	scheduled, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.NotNil(t, scheduled.StartDate)
+	assert.True(t, start.Equal(*scheduled.StartDate), "got %v", scheduled.StartDate)
+	require.NotNil(t, scheduled.DueDate)
+	assert.True(t, due.Equal(*scheduled.DueDate), "got %v", scheduled.DueDate)
+
+	// 清除开始日期后写回 NULL
+	require.NoError(t, scheduled.UpdateSchedule(nil, &due, "creator-1"))
+	require.NoError(t, repo.Update(ctx, *scheduled))
+	cleared, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Nil(t, cleared.StartDate)
+}
+
+func TestTaskRepository_PausedByProjectRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	task.Status = valueobject.TaskStatusInProgress
+	require.NoError(t, repo.Create(ctx, task))
+
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.NoError(t, stored.PauseWithProject(stored.CreatorID, "project paused"))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	reloaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TaskStatusPaused, reloaded.Status)
+	assert.True(t, reloaded.PausedByProject)
+
+	// 恢复后清除标记，避免之后手动暂停被误当作随项目暂停
+	require.NoError(t, reloaded.Resume(reloaded.CreatorID))
+	require.NoError(t, repo.Update(ctx, *reloaded))
+	resumed, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.False(t, resumed.PausedByProject)
+}
+
+func TestTaskRepository_TagsRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	task.Tags = []string{"backend", "q3"}
+	require.NoError(t, repo.Create(ctx, task))
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-untagged")))
+
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "q3"}, stored.Tags)
+
+	changed, err := stored.UpdateTags([]string{"release-2.1"}, []string{"q3"})
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	reloaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "release-2.1"}, reloaded.Tags)
+
+	untagged, err := repo.FindByID(ctx, "task-untagged")
+	require.NoError(t, err)
+	assert.Empty(t, untagged.Tags)
+}
+
+func TestTaskRepository_ParticipantRoleRoundTrip(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	require.NoError(t, task.AddParticipant("user-1", task.CreatorID))
+	require.NoError(t, task.AddParticipantWithRole("user-2", valueobject.ParticipantRoleReviewer, task.CreatorID))
+	require.NoError(t, repo.Create(ctx, task))
+
+	stored, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.Len(t, stored.Participants, 2)
+	role := stored.GetParticipantRole("user-2")
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ParticipantRoleReviewer, *role)
+	role = stored.GetParticipantRole("user-1")
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ParticipantRoleExecutor, *role)
+
+	// 移除参与者后只保留剩余参与者
+	require.NoError(t, stored.RemoveParticipant("user-1", stored.CreatorID))
+	require.NoError(t, repo.Update(ctx, *stored))
+
+	byParticipant, err := repo.FindByParticipant(ctx, "user-2")
+	require.NoError(t, err)
+	require.Len(t, byParticipant, 1)
+	require.Len(t, byParticipant[0].Participants, 1)
+	assert.Equal(t, valueobject.ParticipantRoleReviewer, byParticipant[0].Participants[0].Role)
+
+	removed, err := repo.FindByParticipant(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+}
+
+func TestTaskRepository_FindApprovedNotStarted(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	stale := newRepoTestTask("task-stale")
+	require.NoError(t, repo.Create(ctx, stale))
+	loaded, err := repo.FindByID(ctx, "task-stale")
+	require.NoError(t, err)
+	require.NoError(t, loaded.SubmitForApproval(loaded.CreatorID))
+	require.NoError(t, loaded.Approve("approver-1", ""))
+	approvedAt := time.Now().Add(-96 * time.Hour)
+	loaded.ApprovedAt = &approvedAt
+	require.NoError(t, repo.Update(ctx, *loaded))
+
+	recent := newRepoTestTask("task-recent")
+	require.NoError(t, recent.SubmitForApproval(recent.CreatorID))
+	require.NoError(t, recent.Approve("approver-1", ""))
+	require.NoError(t, repo.Create(ctx, recent))
+
+	reloaded, err := repo.FindByID(ctx, "task-stale")
+	require.NoError(t, err)
+	require.NotNil(t, reloaded.ApprovedAt)
+	assert.WithinDuration(t, approvedAt, *reloaded.ApprovedAt, time.Second)
+
+	tasks, err := repo.FindApprovedNotStarted(ctx, time.Now().Add(-72*time.Hour), nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, valueobject.TaskID("task-stale"), tasks[0].ID)
+
+	otherProject := valueobject.ProjectID("project-2")
+	tasks, err = repo.FindApprovedNotStarted(ctx, time.Now().Add(-72*time.Hour), &otherProject)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestTaskRepository_FindWithEstimateAndActual(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		id          string
+		responsible valueobject.UserID
+		estimated   int
+		actual      float64
+	}{
+		{"task-1", "user-1", 8, 12},
+		{"task-2", "user-2", 8, 6},
+		{"task-no-estimate", "user-1", 0, 5},
+		{"task-no-actual", "user-1", 8, 0},
+	} {
+		task := newRepoTestTask(tc.id)
+		task.ResponsibleID = tc.responsible
+		task.EstimatedHours = tc.estimated
+		task.ActualHours = tc.actual
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	tasks, err := repo.FindWithEstimateAndActual(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, valueobject.TaskID("task-1"), tasks[0].ID)
+	assert.Equal(t, 8, tasks[0].EstimatedHours)
+	assert.InDelta(t, 12, tasks[0].ActualHours, 1e-9)
+
+	responsible := valueobject.UserID("user-2")
+	tasks, err = repo.FindWithEstimateAndActual(ctx, nil, &responsible)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, valueobject.TaskID("task-2"), tasks[0].ID)
+
+	otherProject := valueobject.ProjectID("project-2")
+	tasks, err = repo.FindWithEstimateAndActual(ctx, &otherProject, nil)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestTaskRepository_FindTasksDueWithin(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	cases := []struct {
+		id      string
+		due     time.Duration
+		status  valueobject.TaskStatus
+		deleted bool
+	}{
+		{"task-later", 20 * time.Hour, valueobject.TaskStatusInProgress, false},
+		{"task-soon", 2 * time.Hour, valueobject.TaskStatusPendingApproval, false},
+		{"task-outside", 48 * time.Hour, valueobject.TaskStatusInProgress, false},
+		{"task-overdue", -time.Hour, valueobject.TaskStatusInProgress, false},
+		{"task-completed", 3 * time.Hour, valueobject.TaskStatusCompleted, false},
+		{"task-cancelled", 3 * time.Hour, valueobject.TaskStatusCancelled, false},
+		{"task-deleted", 3 * time.Hour, valueobject.TaskStatusInProgress, true},
+	}
+	for _, tc := range cases {
+		task := newRepoTestTask(tc.id)
+		due := now.Add(tc.due)
+		task.DueDate = &due
+		task.Status = tc.status
+		require.NoError(t, repo.Create(ctx, task))
+		if tc.deleted {
+			require.NoError(t, repo.Delete(ctx, task.ID))
+		}
+	}
+	undated := newRepoTestTask("task-undated")
+	require.NoError(t, repo.Create(ctx, undated))
+
+	tasks, err := repo.FindTasksDueWithin(ctx, 24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, valueobject.TaskID("task-soon"), tasks[0].ID)
+	assert.Equal(t, valueobject.TaskID("task-later"), tasks[1].ID)
+
+	tasks, err = repo.FindTasksDueBetween(ctx, now.Add(-2*time.Hour), now.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, valueobject.TaskID("task-overdue"), tasks[0].ID)
+	assert.Equal(t, valueobject.TaskID("task-soon"), tasks[1].ID)
+}
+
+func TestTaskRepository_SearchTasksAccessibleBy(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	created := newRepoTestTask("task-created")
+	created.Title = "Apollo created"
+	created.CreatorID = "user-1"
+	joined := newRepoTestTask("task-joined")
+	joined.Title = "Apollo joined"
+	require.NoError(t, joined.AddParticipantWithRole("user-1", valueobject.ParticipantRoleObserver, "creator-1"))
+	coLed := newRepoTestTask("task-co-led")
+	coLed.Title = "Apollo co-led"
+	coLed.CoResponsibleIDs = []valueobject.UserID{"user-1"}
+	hidden := newRepoTestTask("task-hidden")
+	hidden.Title = "Apollo hidden"
+	other := newRepoTestTask("task-other")
+	other.Title = "Gemini"
+	other.CreatorID = "user-1"
+	for _, task := range []aggregate.TaskAggregate{created, joined, coLed, hidden, other} {
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	title := "Apollo"
+	userID := valueobject.UserID("user-1")
+	tasks, total, err := repo.SearchTasks(ctx, valueobject.TaskSearchCriteria{
+		Title:        &title,
+		AccessibleBy: &userID,
+		OrderBy:      "title",
+		OrderDir:     "asc",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, tasks, 3)
+	assert.Equal(t, valueobject.TaskID("task-co-led"), tasks[0].ID, "co-responsibles find the tasks they can view")
+	assert.True(t, tasks[0].CanUserView(userID))
+	assert.Equal(t, valueobject.TaskID("task-created"), tasks[1].ID)
+	assert.Equal(t, valueobject.TaskID("task-joined"), tasks[2].ID)
+	assert.True(t, tasks[2].IsParticipant(userID), "participants are loaded with search results")
+
+	tasks, total, err = repo.SearchTasks(ctx, valueobject.TaskSearchCriteria{Title: &title, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 4, total, "total ignores the limit")
+	assert.Len(t, tasks, 1)
+}
+
+func TestTaskRepository_SearchTasksCombinesCriteria(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	day := func(d int) *time.Time {
+		v := time.Date(2024, 5, d, 9, 0, 0, 0, time.UTC)
+		return &v
+	}
+	newTask := func(id string, priority valueobject.TaskPriority, start, due *time.Time) aggregate.TaskAggregate {
+		task := newRepoTestTask(id)
+		task.Priority = priority
+		task.StartDate = start
+		task.DueDate = due
+		return task
+	}
+
+	match := newTask("task-match", valueobject.TaskPriorityHigh, day(3), day(10))
+	require.NoError(t, match.AddParticipant("user-9", "creator-1"))
+	lowPriority := newTask("task-low", valueobject.TaskPriorityLow, day(3), day(10))
+	require.NoError(t, lowPriority.AddParticipant("user-9", "creator-1"))
+	lateDue := newTask("task-late", valueobject.TaskPriorityHigh, day(3), day(20))
+	require.NoError(t, lateDue.AddParticipant("user-9", "creator-1"))
+	earlyStart := newTask("task-early", valueobject.TaskPriorityHigh, day(1), day(10))
+	require.NoError(t, earlyStart.AddParticipant("user-9", "creator-1"))
+	noDates := newTask("task-undated", valueobject.TaskPriorityHigh, nil, nil)
+	require.NoError(t, noDates.AddParticipant("user-9", "creator-1"))
+	notJoined := newTask("task-not-joined", valueobject.TaskPriorityHigh, day(3), day(10))
+	otherProject := newTask("task-other-project", valueobject.TaskPriorityHigh, day(3), day(10))
+	otherProject.ProjectID = "project-2"
+	require.NoError(t, otherProject.AddParticipant("user-9", "creator-1"))
+	for _, task := range []aggregate.TaskAggregate{match, lowPriority, lateDue, earlyStart, noDates, notJoined, otherProject} {
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	projectID := valueobject.ProjectID("project-1")
+	priority := valueobject.TaskPriorityHigh
+	participant := valueobject.UserID("user-9")
+	criteria := valueobject.TaskSearchCriteria{
+		ProjectID:     &projectID,
+		Priority:      &priority,
+		ParticipantID: &participant,
+		StartDate:     day(2),
+		DueDate:       day(15),
+	}
+	tasks, total, err := repo.SearchTasks(ctx, criteria)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, valueobject.TaskID("task-match"), tasks[0].ID)
+
+	// 只按参与者过滤时包含其他项目和未设置日期的任务
+	tasks, total, err = repo.SearchTasks(ctx, valueobject.TaskSearchCriteria{ParticipantID: &participant, OrderBy: "title", OrderDir: "asc"})
+	require.NoError(t, err)
+	assert.Equal(t, 6, total)
+	assert.Len(t, tasks, 6)
+
+	// 已删除的任务不参与搜索
+	require.NoError(t, repo.Delete(ctx, "task-match"))
+	_, total, err = repo.SearchTasks(ctx, criteria)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestWhereLike_ShortTermsAddNoClause(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{})
+
+	sqlFor := func(term string, prefix bool) string {
+		return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return whereLike(tx.Model(&TaskPO{}), "title", &term, prefix).Find(&[]TaskPO{})
+		})
+	}
+
+	for _, term := range []string{"", "   ", "a", " a "} {
+		assert.NotContains(t, sqlFor(term, false), "LIKE", "term %q", term)
+	}
+	assert.Contains(t, sqlFor("  quarterly   report ", false), `title LIKE '%quarterly report%'`)
+	assert.Contains(t, sqlFor("quarterly", true), `title LIKE 'quarterly%'`, "prefix search keeps the index usable")
+	assert.Contains(t, sqlFor("50%_off", false), `title LIKE '%50\%\_off%'`, "wildcards are matched literally")
+}
+
+func TestTaskRepository_SearchTasksNormalizesTerm(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	for id, title := range map[string]string{"task-1": "Quarterly report", "task-2": "Weekly report", "task-3": "Budget review"} {
+		task := newRepoTestTask(id)
+		task.Title = title
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	search := func(term string, prefix bool) []string {
+		tasks, _, err := repo.SearchTasks(ctx, valueobject.TaskSearchCriteria{Title: &term, PrefixMatch: prefix, OrderBy: "title", OrderDir: "asc"})
+		require.NoError(t, err)
+		titles := make([]string, len(tasks))
+		for i, task := range tasks {
+			titles[i] = task.Title
+		}
+		return titles
+	}
+
+	assert.Len(t, search(" ", false), 3, "an empty term does not filter")
+	assert.Len(t, search("r", false), 3, "a single character does not filter")
+	assert.Equal(t, []string{"Quarterly report", "Weekly report"}, search("  report ", false))
+	assert.Equal(t, []string{"Weekly report"}, search("Weekly", true))
+	assert.Empty(t, search("report", true))
+}
+
+func TestTaskRepository_PersistsStatusChangedAt(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := newRepoTestTask("task-1")
+	require.NoError(t, repo.Create(ctx, task))
+	loaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, task.StatusChangedAt, loaded.StatusChangedAt, time.Second)
+
+	require.NoError(t, loaded.SubmitForApproval(loaded.CreatorID))
+	require.NoError(t, repo.Update(ctx, *loaded))
+
+	reloaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, loaded.StatusChangedAt, reloaded.StatusChangedAt, time.Second)
+
+	// 旧数据没有状态变更时间时以最后更新时间近似
+	require.NoError(t, db.Model(&TaskPO{}).Where("id = ?", "task-1").Update("status_changed_at", nil).Error)
+	legacy, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, legacy.UpdatedAt, legacy.StatusChangedAt)
+}
+
+// seedStatisticsTasks 写入状态、优先级、类型、截止时间和工时各不相同的任务，
+// 分布在两个项目中且部分已删除，返回未删除的任务
+func seedStatisticsTasks(t *testing.T, db *gorm.DB, repo repository.TaskRepository) []aggregate.TaskAggregate {
+	t.Helper()
+	ctx := context.Background()
+	statuses := []valueobject.TaskStatus{
+		valueobject.TaskStatusDraft, valueobject.TaskStatusPendingApproval, valueobject.TaskStatusApproved,
+		valueobject.TaskStatusInProgress, valueobject.TaskStatusCompleted, valueobject.TaskStatusCancelled,
+		valueobject.TaskStatusPaused,
+	}
+	priorities := []valueobject.TaskPriority{
+		valueobject.TaskPriorityLow, valueobject.TaskPriorityMedium,
+		valueobject.TaskPriorityHigh, valueobject.TaskPriorityCritical,
+	}
+	types := []valueobject.TaskType{valueobject.TaskTypeRegular, valueobject.TaskTypeRecurring, valueobject.TaskTypeUrgent}
+	now := time.Now().UTC()
+
+	var live []aggregate.TaskAggregate
+	for i := 0; i < 60; i++ {
+		task := newRepoTestTask(fmt.Sprintf("task-%02d", i))
+		task.Status = statuses[i%len(statuses)]
+		task.Priority = priorities[(i/2)%len(priorities)]
+		task.TaskType = types[(i/3)%len(types)]
+		if i%4 != 0 {
+			// 避开当前时刻，防止秒级截断影响逾期判断
+			due := now.Add(time.Duration(i%5-2)*24*time.Hour + 12*time.Hour)
+			task.DueDate = &due
+		}
+		if i%10 == 9 {
+			task.ProjectID = "project-2"
+		}
+		require.NoError(t, repo.Create(ctx, task))
+		task.ActualHours = float64(i % 7)
+		require.NoError(t, db.Model(&TaskPO{}).Where("id = ?", string(task.ID)).Update("actual_hours", task.ActualHours).Error)
+
+		if i%11 == 5 {
+			require.NoError(t, repo.Delete(ctx, task.ID))
+			continue
+		}
+		live = append(live, task)
+	}
+	return live
+}
+
+func TestTaskRepository_GetProjectTaskStatisticsMatchesBruteForce(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	// 逐条累加的对照结果
+	expectedStatus := map[valueobject.TaskStatus]int{}
+	expectedPriority := map[valueobject.TaskPriority]int{}
+	expectedType := map[valueobject.TaskType]int{}
+	var total, completed, overdue int
+	var hours float64
+	for _, task := range seedStatisticsTasks(t, db, repo) {
+		if task.ProjectID != "project-1" {
+			continue
+		}
+		total++
+		expectedStatus[task.Status]++
+		expectedPriority[task.Priority]++
+		expectedType[task.TaskType]++
+		hours += task.ActualHours
+		if task.Status == valueobject.TaskStatusCompleted {
+			completed++
+		}
+		if task.DueDate != nil && task.DueDate.Before(now) &&
+			task.Status != valueobject.TaskStatusCompleted && task.Status != valueobject.TaskStatusCancelled {
+			overdue++
+		}
+	}
+
+	stats, err := repo.GetProjectTaskStatistics(ctx, "project-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, valueobject.ProjectID("project-1"), stats.ProjectID)
+	assert.Equal(t, total, stats.TotalTasks)
+	assert.Equal(t, expectedStatus, stats.TasksByStatus)
+	assert.Equal(t, expectedPriority, stats.TasksByPriority)
+	assert.Equal(t, expectedType, stats.TasksByType)
+	assert.Equal(t, completed, stats.CompletedTasks)
+	assert.Equal(t, overdue, stats.OverdueTasks)
+	assert.InDelta(t, float64(completed)/float64(total)*100, stats.CompletionRate, 1e-9)
+	assert.InDelta(t, hours/float64(total), stats.AverageTaskTime, 1e-9)
+
+	empty, err := repo.GetProjectTaskStatistics(ctx, "project-empty")
+	require.NoError(t, err)
+	assert.Zero(t, empty.TotalTasks)
+	assert.Empty(t, empty.TasksByStatus)
+	assert.Zero(t, empty.CompletionRate)
+}
+
+func TestTaskRepository_GetTaskStatistics(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	task := newRepoTestTask("task-1")
+	task.ActualHours = 6
+	require.NoError(t, task.AddParticipant("user-1", task.CreatorID))
+	require.NoError(t, task.AddParticipant("user-2", task.CreatorID))
+	submission := func(id string, status valueobject.WorkSubmissionStatus, at time.Time) valueobject.WorkSubmission {
+		return valueobject.WorkSubmission{ID: valueobject.WorkSubmissionID(id), TaskID: "task-1", SubmitterID: "user-1",
+			Content: id, Status: status, SubmittedAt: at}
+	}
+	task.WorkSubmissions = []valueobject.WorkSubmission{
+		submission("ws-1", valueobject.WorkSubmissionStatusRejected, base),
+		submission("ws-2", valueobject.WorkSubmissionStatusApproved, base.Add(time.Hour)),
+		submission("ws-3", valueobject.WorkSubmissionStatusApproved, base.Add(2*time.Hour)),
+		submission("ws-4", valueobject.WorkSubmissionStatusPending, base.Add(3*time.Hour)),
+	}
+	require.NoError(t, repo.Create(ctx, task))
+	require.NoError(t, db.Model(&TaskPO{}).Where("id = ?", "task-1").Update("actual_hours", 6).Error)
+	require.NoError(t, repo.Create(ctx, newRepoTestTask("task-empty")))
+
+	stats, err := repo.GetTaskStatistics(ctx, "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TaskID("task-1"), stats.TaskID)
+	assert.Equal(t, 4, stats.TotalExecutions)
+	assert.Equal(t, 2, stats.CompletedExecutions)
+	assert.Equal(t, 1, stats.PendingExecutions)
+	assert.Equal(t, 1, stats.RejectedExecutions)
+	assert.Equal(t, 2, stats.TotalParticipants)
+	assert.InDelta(t, 50, stats.CompletionRate, 1e-9)
+	assert.InDelta(t, 1.5, stats.AverageExecutionTime, 1e-9)
+	assert.True(t, base.Add(3*time.Hour).Equal(stats.LastExecutionAt), "got %v", stats.LastExecutionAt)
+
+	empty, err := repo.GetTaskStatistics(ctx, "task-empty")
+	require.NoError(t, err)
+	assert.Zero(t, empty.TotalExecutions)
+	assert.Zero(t, empty.CompletionRate)
+
+	require.NoError(t, repo.Delete(ctx, "task-empty"))
+	_, err = repo.GetTaskStatistics(ctx, "task-empty")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestTaskRepository_GetProjectsTaskStatisticsMatchesPerProject(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	seedStatisticsTasks(t, db, repo)
+
+	projectIDs := []valueobject.ProjectID{"project-1", "project-2", "project-empty"}
+	batch, err := repo.GetProjectsTaskStatistics(ctx, projectIDs)
+	require.NoError(t, err)
+	require.Len(t, batch, len(projectIDs))
+
+	for _, projectID := range projectIDs {
+		single, err := repo.GetProjectTaskStatistics(ctx, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, single, batch[projectID], projectID)
+	}
+	assert.NotZero(t, batch["project-2"].TotalTasks)
+	assert.Zero(t, batch["project-empty"].TotalTasks)
+
+	empty, err := repo.GetProjectsTaskStatistics(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestTaskRepository_CountWorkloadByResponsible(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	past := time.Now().UTC().Add(-48 * time.Hour)
+	future := time.Now().UTC().Add(48 * time.Hour)
+
+	for _, tc := range []struct {
+		id          string
+		responsible valueobject.UserID
+		status      valueobject.TaskStatus
+		due         *time.Time
+		deleted     bool
+	}{
+		{"a-1", "alice", valueobject.TaskStatusInProgress, &past, false},
+		{"a-2", "alice", valueobject.TaskStatusDraft, &future, false},
+		{"a-3", "alice", valueobject.TaskStatusCompleted, &past, false},
+		{"a-4", "alice", valueobject.TaskStatusInProgress, &past, true},
+		{"b-1", "bob", valueobject.TaskStatusCancelled, nil, false},
+		{"c-1", "carol", valueobject.TaskStatusApproved, nil, false},
+	} {
+		task := newRepoTestTask(tc.id)
+		task.ResponsibleID = tc.responsible
+		task.Status = tc.status
+		task.DueDate = tc.due
+		require.NoError(t, repo.Create(ctx, task))
+		if tc.deleted {
+			require.NoError(t, repo.Delete(ctx, task.ID))
+		}
+	}
+
+	// 共同负责的任务同时计入牵头人和共同负责人
+	shared := newRepoTestTask("e-1")
+	shared.ResponsibleID = "erin"
+	shared.CoResponsibleIDs = []valueobject.UserID{"dave", "alice"}
+	shared.Status = valueobject.TaskStatusInProgress
+	shared.DueDate = &past
+	require.NoError(t, repo.Create(ctx, shared))
+
+	workloads, err := repo.CountWorkloadByResponsible(ctx, []valueobject.UserID{"alice", "bob", "dave"})
+	require.NoError(t, err)
+	assert.Equal(t, map[valueobject.UserID]valueobject.TaskWorkload{
+		"alice": {ResponsibleID: "alice", OpenTasks: 3, OverdueTasks: 2},
+		"bob":   {ResponsibleID: "bob"},
+		"dave":  {ResponsibleID: "dave", OpenTasks: 1, OverdueTasks: 1},
+	}, workloads)
+}
+
+func TestTaskRepository_GetAllTaskStatisticsMatchesInMemoryComputation(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	seedStatisticsTasks(t, db, repo)
+
+	// 原先的统计方式：加载全部未删除任务后在内存中逐条累加
+	var pos []TaskPO
+	require.NoError(t, db.Where("deleted_at IS NULL").Find(&pos).Error)
+	impl := repo.(*TaskRepositoryImpl)
+	byStatus := map[valueobject.TaskStatus]int{}
+	byPriority := map[valueobject.TaskPriority]int{}
+	byType := map[valueobject.TaskType]int{}
+	var completed, overdue int
+	var totalHours float64
+	for _, po := range pos {
+		task := impl.taskPOToAggregate(po)
+		byStatus[task.Status]++
+		byPriority[task.Priority]++
+		byType[task.TaskType]++
+		if task.Status == valueobject.TaskStatusCompleted {
+			completed++
+		}
+		if task.DueDate != nil && task.DueDate.Before(time.Now()) &&
+			task.Status != valueobject.TaskStatusCompleted && task.Status != valueobject.TaskStatusCancelled {
+			overdue++
+		}
+		totalHours += task.ActualHours
+	}
+
+	stats, err := repo.GetAllTaskStatistics(context.Background())
+	require.NoError(t, err)
+
+	require.NotEmpty(t, pos)
+	assert.Empty(t, stats.ProjectID)
+	assert.Equal(t, len(pos), stats.TotalTasks)
+	assert.Equal(t, byStatus, stats.TasksByStatus)
+	assert.Equal(t, byPriority, stats.TasksByPriority)
+	assert.Equal(t, byType, stats.TasksByType)
+	assert.Equal(t, overdue, stats.OverdueTasks)
+	assert.InDelta(t, float64(completed)/float64(len(pos))*100, stats.CompletionRate, 1e-9)
+	assert.InDelta(t, totalHours/float64(len(pos)), stats.AverageTaskTime, 1e-9)
+}
+
+func TestTaskRepository_FindActionRequired(t *testing.T) {
+	db := setupTestDB(t, &TaskPO{}, &TaskParticipantPO{}, &ExtensionRequest{}, &WorkSubmission{})
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	submittedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	inProgress := newRepoTestTask("in-progress")
+	inProgress.Status = valueobject.TaskStatusInProgress
+	reviewed := newRepoTestTask("reviewed")
+	reviewed.Status = valueobject.TaskStatusInProgress
+	reviewed.ResponsibleID = "other"
+	require.NoError(t, reviewed.SetReviewers([]valueobject.UserID{"reviewer-1"}, 1, "creator-1"))
+	reviewed.WorkSubmissions = []valueobject.WorkSubmission{{ID: "ws-1", TaskID: "reviewed", SubmitterID: "other",
+		Status: valueobject.WorkSubmissionStatusPending, SubmittedAt: submittedAt}}
+	extension := newRepoTestTask("extension")
+	extension.Status = valueobject.TaskStatusApproved
+	extension.Extensions = []valueobject.ExtensionRequest{{ID: "ext-1", TaskID: "extension", RequesterID: "responsible-1",
+		Status: valueobject.ExtensionStatusPending, RequestedAt: submittedAt, OriginalDueDate: submittedAt, RequestedDueDate: submittedAt}}
+	completed := newRepoTestTask("completed")
+	completed.Status = valueobject.TaskStatusCompleted
+	completed.Extensions = []valueobject.ExtensionRequest{{ID: "ext-2", TaskID: "completed", RequesterID: "responsible-1",
+		Status: valueobject.ExtensionStatusPending, RequestedAt: submittedAt, OriginalDueDate: submittedAt, RequestedDueDate: submittedAt}}
+	coLed := newRepoTestTask("co-led")
+	coLed.Status = valueobject.TaskStatusInProgress
+	coLed.ResponsibleID = "other"
+	coLed.CoResponsibleIDs = []valueobject.UserID{"co-responsible-1"}
+	for _, task := range []aggregate.TaskAggregate{inProgress, reviewed, extension, completed, coLed} {
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	cases := map[valueobject.UserID][]string{
+		"responsible-1":    {"in-progress"},
+		"co-responsible-1": {"co-led"},
+		"reviewer-1":       {"reviewed"},
+		// 配置了审核人的任务不再由创建者审核工作
+		"creator-1": {"extension"},
+	}
+	for userID, want := range cases {
+		tasks, total, err := repo.FindActionRequired(ctx, userID, 0, 0)
+		require.NoError(t, err)
+		assert.Equal(t, len(want), total, "user %s", userID)
+		ids := make([]string, len(tasks))
+		for i, task := range tasks {
+			ids[i] = string(task.ID)
+		}
+		assert.ElementsMatch(t, want, ids, "user %s", userID)
+	}
+
+	tasks, _, err := repo.FindActionRequired(ctx, "reviewer-1", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Len(t, tasks[0].SubmissionsAwaitingReview("reviewer-1"), 1, "work submissions are loaded")
+
+	// 分页只加载本页任务的延期申请
+	page, total, err := repo.FindActionRequired(ctx, "creator-1", 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, page, 1)
+	require.Len(t, page[0].Extensions, 1)
+	assert.Equal(t, valueobject.ExtensionRequestID("ext-1"), page[0].Extensions[0].ID)
+	page, total, err = repo.FindActionRequired(ctx, "creator-1", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Empty(t, page)
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 // UserModel 用户持久化模型 - 重命名避免与Domain User冲突
@@ -22,6 +23,9 @@ type UserModel struct {
 	JoinDate     *time.Time     `gorm:"type:date" json:"join_date"`
 	DepartmentID *string        `gorm:"type:varchar(36)" json:"department_id"`
 	ManagerID    *string        `gorm:"type:varchar(36)" json:"manager_id"`
+	Timezone     *string        `gorm:"type:varchar(64)" json:"timezone"`
+	InAppNotify  *bool          `gorm:"column:in_app_notifications;not null;default:true" json:"in_app_notifications"`
+	NotifyPrefs  *string        `gorm:"column:notification_settings;type:json" json:"notification_settings"`
 	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt    time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -38,6 +42,6 @@ type UserModel struct {
 }
 
 // TableName 指定表名
-func (UserModel) TableName() string {
-	return "users"
+func (UserModel) TableName(namer schema.Namer) string {
+	return prefixedTable(namer, "users")
 }
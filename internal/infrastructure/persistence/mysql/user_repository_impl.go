@@ -2,21 +2,27 @@ package mysql
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
 	"github.com/taskflow/internal/domain/valueobject"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepositoryImpl 用户仓储实现 - 实现Domain层接口
+// 加锁读取通过 BaseRepository 使用上下文中的事务
 type UserRepositoryImpl struct {
+	*BaseRepository
 	db *gorm.DB
 }
 
 // NewUserRepository 创建用户仓储实现
 func NewUserRepository(db *gorm.DB) *UserRepositoryImpl {
-	return &UserRepositoryImpl{db: db}
+	return &UserRepositoryImpl{BaseRepository: NewBaseRepository(db), db: db}
 }
 
 // Save 保存用户
@@ -44,8 +50,22 @@ func (r *UserRepositoryImpl) FindByID(ctx context.Context, id string) (*aggregat
 	var userModel UserModel
 
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&userModel).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found: %s", id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user %s: %w", id, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return r.modelToDomain(&userModel), nil
+}
+
+// FindByIDForUpdate 在当前事务中以 SELECT ... FOR UPDATE 锁定用户行后加载用户
+func (r *UserRepositoryImpl) FindByIDForUpdate(ctx context.Context, id string) (*aggregate.User, error) {
+	var userModel UserModel
+
+	if err := r.GetDB(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&userModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user %s: %w", id, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
@@ -58,8 +78,8 @@ func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*ag
 	var userModel UserModel
 
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&userModel).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user with email %s not found", email)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user with email %s: %w", email, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to find user by email: %w", err)
 	}
@@ -72,8 +92,8 @@ func (r *UserRepositoryImpl) FindByUsername(ctx context.Context, username string
 	var userModel UserModel
 
 	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&userModel).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user with username %s not found", username)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user with username %s: %w", username, repository.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to find user by username: %w", err)
 	}
@@ -283,6 +303,19 @@ func (r *UserRepositoryImpl) domainToModel(domainUser *aggregate.User) *UserMode
 		model.ManagerID = &managerID
 	}
 
+	if domainUser.Timezone != "" {
+		timezone := domainUser.Timezone
+		model.Timezone = &timezone
+	}
+
+	inAppNotifications := domainUser.InAppNotifications
+	model.InAppNotify = &inAppNotifications
+
+	if data, err := json.Marshal(domainUser.NotificationSettings); err == nil {
+		settings := string(data)
+		model.NotifyPrefs = &settings
+	}
+
 	if domainUser.DeletedAt != nil {
 		model.DeletedAt = gorm.DeletedAt{Time: *domainUser.DeletedAt, Valid: true}
 	}
@@ -311,6 +344,23 @@ func (r *UserRepositoryImpl) modelToDomain(model *UserModel) *aggregate.User {
 		domainUser.ManagerID = &managerID
 	}
 
+	if model.Timezone != nil {
+		domainUser.Timezone = *model.Timezone
+	}
+
+	// 未设置时沿用默认开启
+	if model.InAppNotify != nil {
+		domainUser.InAppNotifications = *model.InAppNotify
+	}
+
+	// 未设置时沿用默认通知设置
+	if model.NotifyPrefs != nil {
+		var settings valueobject.NotificationSettings
+		if err := json.Unmarshal([]byte(*model.NotifyPrefs), &settings); err == nil {
+			domainUser.NotificationSettings = settings
+		}
+	}
+
 	// 设置状态
 	switch valueobject.UserStatus(model.Status) {
 	case valueobject.UserStatusActive:
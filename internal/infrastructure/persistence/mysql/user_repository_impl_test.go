@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+func TestUserRepository_PersistsNotificationSettings(t *testing.T) {
+	db := setupTestDB(t, &UserModel{})
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee)
+	require.NoError(t, repo.Save(ctx, user))
+
+	saved, err := repo.FindByID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.DefaultNotificationSettings(), saved.NotificationSettings)
+
+	settings := saved.NotificationSettings
+	settings.EmailEnabled = false
+	settings.SMSEnabled = true
+	settings.QuietHoursStart, settings.QuietHoursEnd = "22:00", "07:00"
+	require.NoError(t, saved.UpdateNotificationSettings(settings))
+	require.NoError(t, repo.Update(ctx, saved))
+
+	updated, err := repo.FindByID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, settings, updated.NotificationSettings)
+}
+
+func TestUserRepository_FindByIDForUpdateSerializesCountThenInsert(t *testing.T) {
+	setupLogger(t)
+	db := setupTestDB(t, &UserModel{}, &OperationLog{})
+	users := NewUserRepository(db)
+	logs := NewOperationLogRepository(db)
+	txMgr := NewTransactionManager(db)
+	ctx := context.Background()
+	require.NoError(t, users.Save(ctx, aggregate.NewUser("admin-1", "root", "root@example.com", "Root", "hash", valueobject.UserRoleAdmin)))
+
+	// 锁定用户行后计数再写入，并发请求不会超过上限
+	const limit, requests = 3, 8
+	since := time.Now().Add(-time.Hour)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = txMgr.WithTransaction(ctx, func(txCtx context.Context) error {
+				admin, err := users.FindByIDForUpdate(txCtx, "admin-1")
+				if err != nil {
+					return err
+				}
+				count, err := logs.CountByUserSince(txCtx, admin.ID, aggregate.OperationImpersonate, since)
+				if err != nil || count >= limit {
+					return fmt.Errorf("limited: %v", err)
+				}
+				return logs.Create(txCtx, aggregate.OperationLog{
+					ID: fmt.Sprintf("log-%d", i), UserID: admin.ID, Operation: aggregate.OperationImpersonate,
+					ResourceType: "users", ResourceID: "user-1", CreatedAt: time.Now(),
+				})
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	count, err := logs.CountByUserSince(ctx, "admin-1", aggregate.OperationImpersonate, since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(limit), count)
+
+	_, err = users.FindByIDForUpdate(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
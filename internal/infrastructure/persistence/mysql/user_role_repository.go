@@ -83,10 +83,10 @@ func (r *userRoleRepository) FindRolesByUser(ctx context.Context, userID string)
 	var models []Role
 
 	err := r.db.WithContext(ctx).
-		Select("roles.*").
-		Table("roles").
-		Joins("JOIN user_roles ON roles.id = user_roles.role_id").
-		Where("user_roles.user_id = ?", userID).
+		Select("r.*").
+		Table(tableName(r.db, &Role{})+" r").
+		Joins("JOIN "+tableName(r.db, &UserRole{})+" ur ON r.id = ur.role_id").
+		Where("ur.user_id = ?", userID).
 		Find(&models).Error
 
 	if err != nil {
@@ -0,0 +1,248 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository webhook订阅仓储实现
+type WebhookRepository struct {
+	*BaseRepository
+}
+
+// NewWebhookRepository 创建webhook订阅仓储
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{BaseRepository: NewBaseRepository(db)}
+}
+
+var _ repository.WebhookRepository = (*WebhookRepository)(nil)
+
+// Create 新建webhook，ID已存在时返回 ErrAlreadyExists
+func (r *WebhookRepository) Create(ctx context.Context, webhook aggregate.Webhook) error {
+	var count int64
+	if err := r.GetDB(ctx).Model(&Webhook{}).Where("id = ?", webhook.ID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check webhook existence: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("webhook %s: %w", webhook.ID, repository.ErrAlreadyExists)
+	}
+
+	model, err := webhookToModel(webhook)
+	if err != nil {
+		return err
+	}
+	if err := r.GetDB(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// Update 更新webhook全部字段，不存在时返回 ErrNotFound
+func (r *WebhookRepository) Update(ctx context.Context, webhook aggregate.Webhook) error {
+	model, err := webhookToModel(webhook)
+	if err != nil {
+		return err
+	}
+
+	result := r.GetDB(ctx).Model(&Webhook{}).Where("id = ?", webhook.ID).
+		Select("*").Omit("id", "created_at").Updates(model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// 内容未变化时 MySQL 也返回0行，需要区分记录是否存在
+		if _, err := r.FindByID(ctx, webhook.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete 删除webhook，投递记录保留用于排查
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	result := r.GetDB(ctx).Where("id = ?", id).Delete(&Webhook{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook %s: %w", id, repository.ErrNotFound)
+	}
+	return nil
+}
+
+// FindByID 根据ID查找webhook
+func (r *WebhookRepository) FindByID(ctx context.Context, id string) (*aggregate.Webhook, error) {
+	var model Webhook
+	if err := r.GetDB(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook %s: %w", id, repository.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+	return modelToWebhook(model)
+}
+
+// FindAll 查找全部webhook，按创建时间排序
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]aggregate.Webhook, error) {
+	return r.find(r.GetDB(ctx))
+}
+
+// FindActive 查找启用中的webhook
+func (r *WebhookRepository) FindActive(ctx context.Context) ([]aggregate.Webhook, error) {
+	return r.find(r.GetDB(ctx).Where("active = ?", true))
+}
+
+func (r *WebhookRepository) find(query *gorm.DB) ([]aggregate.Webhook, error) {
+	var models []Webhook
+	if err := query.Order("created_at ASC, id ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+
+	webhooks := make([]aggregate.Webhook, 0, len(models))
+	for _, model := range models {
+		webhook, err := modelToWebhook(model)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, nil
+}
+
+// SaveDelivery 保存投递记录
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, delivery aggregate.WebhookDelivery) error {
+	model := WebhookDelivery{
+		ID:          delivery.ID,
+		DeliveryID:  delivery.DeliveryID,
+		WebhookID:   delivery.WebhookID,
+		EventID:     delivery.EventID,
+		EventType:   delivery.EventType,
+		Attempt:     delivery.Attempt,
+		Redelivery:  delivery.Redelivery,
+		StatusCode:  delivery.StatusCode,
+		Success:     delivery.Success,
+		DurationMs:  delivery.Duration.Milliseconds(),
+		DeliveredAt: delivery.DeliveredAt,
+	}
+	if delivery.Error != "" {
+		model.Error = &delivery.Error
+	}
+	if len(delivery.Payload) > 0 {
+		payload := string(delivery.Payload)
+		model.Payload = &payload
+	}
+
+	if err := r.GetDB(ctx).Create(&model).Error; err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// FindDeliveries 查找webhook的投递记录，按投递时间倒序，limit<=0表示不限制
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, webhookID string, limit int) ([]aggregate.WebhookDelivery, error) {
+	query := r.GetDB(ctx).Where("webhook_id = ?", webhookID).Order("delivered_at DESC, attempt DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var models []WebhookDelivery
+	if err := query.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+	return modelsToDeliveries(models), nil
+}
+
+// FindDeliveryAttempts 查找同一投递的全部尝试，按尝试次数升序
+func (r *WebhookRepository) FindDeliveryAttempts(ctx context.Context, deliveryID string) ([]aggregate.WebhookDelivery, error) {
+	var models []WebhookDelivery
+	if err := r.GetDB(ctx).Where("delivery_id = ?", deliveryID).Order("attempt ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhook delivery attempts: %w", err)
+	}
+	if len(models) == 0 {
+		return nil, fmt.Errorf("webhook delivery %s: %w", deliveryID, repository.ErrNotFound)
+	}
+	return modelsToDeliveries(models), nil
+}
+
+// modelsToDeliveries 转换投递记录
+func modelsToDeliveries(models []WebhookDelivery) []aggregate.WebhookDelivery {
+	deliveries := make([]aggregate.WebhookDelivery, len(models))
+	for i, model := range models {
+		deliveries[i] = aggregate.WebhookDelivery{
+			ID:          model.ID,
+			DeliveryID:  model.DeliveryID,
+			WebhookID:   model.WebhookID,
+			EventID:     model.EventID,
+			EventType:   model.EventType,
+			Attempt:     model.Attempt,
+			Redelivery:  model.Redelivery,
+			StatusCode:  model.StatusCode,
+			Success:     model.Success,
+			Duration:    time.Duration(model.DurationMs) * time.Millisecond,
+			DeliveredAt: model.DeliveredAt,
+		}
+		if model.Error != nil {
+			deliveries[i].Error = *model.Error
+		}
+		if model.Payload != nil {
+			deliveries[i].Payload = []byte(*model.Payload)
+		}
+	}
+	return deliveries
+}
+
+// webhookToModel 转换为数据库模型，事件类型以JSON数组存储
+func webhookToModel(w aggregate.Webhook) (*Webhook, error) {
+	eventTypes := w.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	data, err := json.Marshal(eventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event types: %w", err)
+	}
+
+	return &Webhook{
+		ID:           w.ID,
+		URL:          w.URL,
+		Secret:       w.Secret,
+		EventTypes:   string(data),
+		Active:       w.Active,
+		FailureCount: w.FailureCount,
+		CreatedBy:    string(w.CreatedBy),
+		CreatedAt:    w.CreatedAt,
+		UpdatedAt:    w.UpdatedAt,
+		DisabledAt:   w.DisabledAt,
+	}, nil
+}
+
+// modelToWebhook 转换为领域对象
+func modelToWebhook(model Webhook) (*aggregate.Webhook, error) {
+	var eventTypes []string
+	if model.EventTypes != "" {
+		if err := json.Unmarshal([]byte(model.EventTypes), &eventTypes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook event types: %w", err)
+		}
+	}
+
+	return &aggregate.Webhook{
+		ID:           model.ID,
+		URL:          model.URL,
+		Secret:       model.Secret,
+		EventTypes:   eventTypes,
+		Active:       model.Active,
+		FailureCount: model.FailureCount,
+		CreatedBy:    valueobject.UserID(model.CreatedBy),
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+		DisabledAt:   model.DisabledAt,
+	}, nil
+}
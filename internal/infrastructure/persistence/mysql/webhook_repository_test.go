@@ -0,0 +1,122 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+)
+
+func TestWebhookRepository_RoundTripAndFindActive(t *testing.T) {
+	db := setupTestDB(t, &Webhook{}, &WebhookDelivery{})
+	repo := NewWebhookRepository(db)
+	ctx := context.Background()
+
+	active, err := aggregate.NewWebhook("webhook-1", "https://example.com/a", "secret-a", []string{"TaskCompleted"}, "admin-1")
+	require.NoError(t, err)
+	disabled, err := aggregate.NewWebhook("webhook-2", "https://example.com/b", "secret-b", nil, "admin-1")
+	require.NoError(t, err)
+	disabled.Deactivate()
+
+	require.NoError(t, repo.Create(ctx, *active))
+	require.NoError(t, repo.Create(ctx, *disabled))
+	require.ErrorIs(t, repo.Create(ctx, *active), repository.ErrAlreadyExists)
+
+	found, err := repo.FindByID(ctx, "webhook-2")
+	require.NoError(t, err)
+	assert.Equal(t, "secret-b", found.Secret)
+	assert.Empty(t, found.EventTypes)
+	assert.False(t, found.Active)
+	assert.NotNil(t, found.DisabledAt)
+
+	webhooks, err := repo.FindActive(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, "webhook-1", webhooks[0].ID)
+	assert.Equal(t, []string{"TaskCompleted"}, webhooks[0].EventTypes)
+
+	// 失败计数和停用状态可以更新
+	webhooks[0].RecordDeliveryResult(false, 1)
+	require.NoError(t, repo.Update(ctx, webhooks[0]))
+	found, err = repo.FindByID(ctx, "webhook-1")
+	require.NoError(t, err)
+	assert.False(t, found.Active)
+	assert.Equal(t, 1, found.FailureCount)
+
+	require.NoError(t, repo.Delete(ctx, "webhook-1"))
+	_, err = repo.FindByID(ctx, "webhook-1")
+	require.ErrorIs(t, err, repository.ErrNotFound)
+	require.ErrorIs(t, repo.Delete(ctx, "webhook-1"), repository.ErrNotFound)
+	require.ErrorIs(t, repo.Update(ctx, *active), repository.ErrNotFound)
+}
+
+func TestWebhookRepository_FindDeliveriesNewestFirst(t *testing.T) {
+	db := setupTestDB(t, &WebhookDelivery{})
+	repo := NewWebhookRepository(db)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	for i, status := range []int{500, 502, 200} {
+		require.NoError(t, repo.SaveDelivery(ctx, aggregate.WebhookDelivery{
+			ID:          "delivery-" + string(rune('a'+i)),
+			WebhookID:   "webhook-1",
+			EventID:     "event-1",
+			EventType:   "TaskCompleted",
+			Attempt:     i + 1,
+			StatusCode:  status,
+			Success:     status == 200,
+			Duration:    120 * time.Millisecond,
+			DeliveredAt: base.Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	deliveries, err := repo.FindDeliveries(ctx, "webhook-1", 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, 3, deliveries[0].Attempt)
+	assert.True(t, deliveries[0].Success)
+	assert.Equal(t, 120*time.Millisecond, deliveries[0].Duration)
+	assert.Equal(t, 2, deliveries[1].Attempt)
+	assert.Empty(t, deliveries[0].Error)
+}
+
+func TestWebhookRepository_FindDeliveryAttemptsByDeliveryID(t *testing.T) {
+	db := setupTestDB(t, &WebhookDelivery{})
+	repo := NewWebhookRepository(db)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":"event-1","type":"TaskCompleted"}`)
+
+	for i, redelivery := range []bool{false, false, true} {
+		require.NoError(t, repo.SaveDelivery(ctx, aggregate.WebhookDelivery{
+			ID:          "attempt-" + string(rune('a'+i)),
+			DeliveryID:  "delivery-1",
+			WebhookID:   "webhook-1",
+			EventID:     "event-1",
+			EventType:   "TaskCompleted",
+			Attempt:     i + 1,
+			Redelivery:  redelivery,
+			Payload:     payload,
+			StatusCode:  500,
+			DeliveredAt: base.Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	attempts, err := repo.FindDeliveryAttempts(ctx, "delivery-1")
+	require.NoError(t, err)
+	require.Len(t, attempts, 3)
+	for i, a := range attempts {
+		assert.Equal(t, i+1, a.Attempt)
+		assert.Equal(t, "delivery-1", a.DeliveryID)
+		assert.Equal(t, payload, a.Payload)
+	}
+	assert.False(t, attempts[1].Redelivery)
+	assert.True(t, attempts[2].Redelivery)
+
+	_, err = repo.FindDeliveryAttempts(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
@@ -51,6 +51,27 @@ func (j *JWTServiceImpl) GenerateTokens(userID, email string, roles []string) (*
 	}, nil
 }
 
+// GenerateImpersonationToken 生成管理员模拟用户的访问令牌
+// 令牌中记录实际操作的管理员，不签发刷新令牌，过期后需要重新发起模拟
+func (j *JWTServiceImpl) GenerateImpersonationToken(userID, email string, roles []string, impersonatorID string, ttl time.Duration) (*valueobject.TokenPair, error) {
+	now := time.Now()
+
+	claims := j.newClaims(userID, email, roles, valueobject.TokenTypeAccess, now.Add(ttl))
+	claims.ImpersonatorID = impersonatorID
+	accessToken, err := j.signClaims(claims)
+	if err != nil {
+		logger.Error("Failed to generate impersonation token", zap.Error(err))
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	return &valueobject.TokenPair{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Seconds()),
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
 // ValidateToken 验证访问令牌
 func (j *JWTServiceImpl) ValidateToken(tokenString string) (*valueobject.Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &valueobject.Claims{}, func(token *jwt.Token) (interface{}, error) {
@@ -124,7 +145,12 @@ func (j *JWTServiceImpl) RevokeToken(tokenString string) error {
 
 // generateToken 生成JWT令牌
 func (j *JWTServiceImpl) generateToken(userID, email string, roles []string, tokenType string, expiresAt time.Time) (string, error) {
-	claims := valueobject.Claims{
+	return j.signClaims(j.newClaims(userID, email, roles, tokenType, expiresAt))
+}
+
+// newClaims 构造JWT声明
+func (j *JWTServiceImpl) newClaims(userID, email string, roles []string, tokenType string, expiresAt time.Time) valueobject.Claims {
+	return valueobject.Claims{
 		UserID:    userID,
 		Email:     email,
 		Roles:     roles,
@@ -137,7 +163,10 @@ func (j *JWTServiceImpl) generateToken(userID, email string, roles []string, tok
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
+}
 
+// signClaims 使用HMAC-SHA256签名JWT声明
+func (j *JWTServiceImpl) signClaims(claims valueobject.Claims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(j.config.Secret))
 }
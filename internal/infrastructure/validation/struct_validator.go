@@ -0,0 +1,172 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// StructValidator 请求结构体验证器，用于替换 gin 默认验证器
+// 同时校验 gin 的 binding 标签和应用层 DTO 的 validate 标签，错误字段使用 json 名称
+type StructValidator struct {
+	once     sync.Once
+	bindings *validator.Validate
+	validate *validator.Validate
+}
+
+// NewStructValidator 创建请求结构体验证器
+func NewStructValidator() *StructValidator {
+	return &StructValidator{}
+}
+
+var _ binding.StructValidator = (*StructValidator)(nil)
+
+// ValidateStruct 校验结构体、结构体指针及其切片，实现 binding.StructValidator 接口
+func (v *StructValidator) ValidateStruct(obj any) error {
+	if obj == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(obj)
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return v.ValidateStruct(value.Elem().Interface())
+	case reflect.Struct:
+		return v.validateStruct(obj)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := v.ValidateStruct(value.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Engine 返回 binding 标签使用的验证引擎，实现 binding.StructValidator 接口
+func (v *StructValidator) Engine() any {
+	v.lazyInit()
+	return v.bindings
+}
+
+// validateStruct 依次按 binding 和 validate 标签校验，合并两类字段错误
+func (v *StructValidator) validateStruct(obj any) error {
+	v.lazyInit()
+
+	var fieldErrs validator.ValidationErrors
+	for _, engine := range []*validator.Validate{v.bindings, v.validate} {
+		err := engine.Struct(obj)
+		var errs validator.ValidationErrors
+		if errors.As(err, &errs) {
+			fieldErrs = append(fieldErrs, errs...)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(fieldErrs) > 0 {
+		return fieldErrs
+	}
+	return nil
+}
+
+func (v *StructValidator) lazyInit() {
+	v.once.Do(func() {
+		v.bindings = newEngine("binding")
+		v.validate = newEngine("validate")
+	})
+}
+
+// newEngine 创建使用指定标签的验证引擎，注册自定义规则
+func newEngine(tagName string) *validator.Validate {
+	engine := validator.New()
+	engine.SetTagName(tagName)
+	engine.RegisterTagNameFunc(fieldName)
+	_ = engine.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		return valueobject.Phone{Number: fl.Field().String()}.IsValid()
+	})
+	return engine
+}
+
+// fieldName 字段名优先使用 json 标签，其次 form 标签，都没有时使用结构体字段名
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+// FieldError 字段验证错误
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// FieldErrors 将验证错误转换为字段错误列表，err 不是验证错误时返回 false
+func FieldErrors(err error) ([]FieldError, bool) {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil, false
+	}
+
+	result := make([]FieldError, len(errs))
+	for i, fe := range errs {
+		// 去掉命名空间中的根结构体名，保留嵌套字段路径
+		field := fe.Field()
+		if parts := strings.SplitN(fe.Namespace(), ".", 2); len(parts) == 2 {
+			field = parts[1]
+		}
+		result[i] = FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Message: fieldErrorMessage(field, fe),
+		}
+	}
+	return result, true
+}
+
+// fieldErrorMessage 生成字段错误提示
+func fieldErrorMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s不能为空", field)
+	case "email":
+		return fmt.Sprintf("%s必须是有效的邮箱地址", field)
+	case "phone":
+		return fmt.Sprintf("%s必须是有效的电话号码", field)
+	case "oneof":
+		return fmt.Sprintf("%s必须是以下值之一: %s", field, fe.Param())
+	case "min", "max", "len":
+		return lengthMessage(field, fe)
+	default:
+		return fmt.Sprintf("%s未通过%s校验", field, fe.Tag())
+	}
+}
+
+// lengthMessage 按字段类型生成长度或取值范围提示
+func lengthMessage(field string, fe validator.FieldError) string {
+	bound := map[string]string{"min": "不能少于", "max": "不能超过", "len": "必须为"}[fe.Tag()]
+	switch fe.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%s长度%s%s个字符", field, bound, fe.Param())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s数量%s%s个", field, bound, fe.Param())
+	default:
+		return fmt.Sprintf("%s%s%s", field, bound, fe.Param())
+	}
+}
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+)
+
+// fieldErrorsOf 校验请求并返回按字段名索引的字段错误
+func fieldErrorsOf(t *testing.T, obj any) map[string]FieldError {
+	t.Helper()
+	fields, ok := FieldErrors(NewStructValidator().ValidateStruct(obj))
+	require.True(t, ok, "expected validation errors")
+	result := make(map[string]FieldError, len(fields))
+	for _, f := range fields {
+		result[f.Field] = f
+	}
+	return result
+}
+
+func TestStructValidator_RejectsInvalidEmail(t *testing.T) {
+	fields := fieldErrorsOf(t, &dto.AuthenticationRequest{Email: "not-an-email", Password: "secret"})
+
+	require.Contains(t, fields, "email")
+	assert.Equal(t, "email", fields["email"].Rule)
+	assert.Equal(t, "email必须是有效的邮箱地址", fields["email"].Message)
+	assert.NotContains(t, fields, "password")
+}
+
+func TestStructValidator_RejectsShortPassword(t *testing.T) {
+	fields := fieldErrorsOf(t, dto.PasswordChangeRequest{
+		UserID:          "user-1",
+		CurrentPassword: "old-password",
+		NewPassword:     "short",
+		ConfirmPassword: "short",
+	})
+
+	require.Len(t, fields, 1)
+	require.Contains(t, fields, "new_password")
+	assert.Equal(t, "min", fields["new_password"].Rule)
+	assert.Equal(t, "new_password长度不能少于8个字符", fields["new_password"].Message)
+}
+
+func TestStructValidator_ChecksBindingAndValidateTags(t *testing.T) {
+	type request struct {
+		Name  string `json:"name" binding:"required"`
+		Phone string `json:"phone" validate:"omitempty,phone"`
+	}
+
+	fields := fieldErrorsOf(t, &request{Phone: "abc"})
+
+	assert.Equal(t, "required", fields["name"].Rule)
+	assert.Equal(t, "phone", fields["phone"].Rule)
+	assert.NoError(t, NewStructValidator().ValidateStruct(&request{Name: "ok", Phone: "+8613800138000"}))
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// TaskValidator 任务验证器实现
+type TaskValidator struct {
+	maxTitleLength       int
+	maxDescriptionLength int
+}
+
+// NewTaskValidator 创建任务验证器
+func NewTaskValidator() valueobject.TaskValidator {
+	return &TaskValidator{
+		maxTitleLength:       300,
+		maxDescriptionLength: 5000,
+	}
+}
+
+// ValidateTitle 验证任务标题
+func (v *TaskValidator) ValidateTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return fmt.Errorf("任务标题不能为空")
+	}
+
+	if utf8.RuneCountInString(title) > v.maxTitleLength {
+		return fmt.Errorf("任务标题长度不能超过%d个字符", v.maxTitleLength)
+	}
+
+	return nil
+}
+
+// ValidateDescription 验证任务描述
+func (v *TaskValidator) ValidateDescription(description string) error {
+	if utf8.RuneCountInString(description) > v.maxDescriptionLength {
+		return fmt.Errorf("任务描述长度不能超过%d个字符", v.maxDescriptionLength)
+	}
+
+	return nil
+}
+
+// ValidateDueDate 验证截止时间
+func (v *TaskValidator) ValidateDueDate(dueDate *time.Time) error {
+	if dueDate == nil {
+		return fmt.Errorf("截止时间不能为空")
+	}
+
+	if dueDate.Before(time.Now()) {
+		return fmt.Errorf("截止时间不能早于当前时间")
+	}
+
+	return nil
+}
+
+// ValidateEstimatedHours 验证预估工时
+func (v *TaskValidator) ValidateEstimatedHours(hours int) error {
+	if hours < 0 {
+		return fmt.Errorf("预估工时不能为负数")
+	}
+
+	return nil
+}
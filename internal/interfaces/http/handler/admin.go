@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/event"
+)
+
+// AdminHandler 管理员支持工具处理器
+type AdminHandler struct {
+	impersonationService *service.ImpersonationAppService
+	permissionService    *service.PermissionAppService
+	featureFlagService   *service.FeatureFlagAppService
+}
+
+// NewAdminHandler 创建管理员支持工具处理器
+func NewAdminHandler(impersonationService *service.ImpersonationAppService, permissionService *service.PermissionAppService, featureFlagService *service.FeatureFlagAppService) *AdminHandler {
+	return &AdminHandler{
+		impersonationService: impersonationService,
+		permissionService:    permissionService,
+		featureFlagService:   featureFlagService,
+	}
+}
+
+// Impersonate 以指定用户身份签发短期模拟令牌
+// @Summary 模拟用户
+// @Description 仅管理员可用，用于以用户视角复现问题。令牌有效期15分钟且不能刷新，不能模拟自己、未激活用户或权限不低于自己的用户；每个管理员每小时最多发起10次。发起模拟和模拟期间的每个请求都记录在操作日志中，响应头 X-Impersonated-By 标识模拟请求
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id path string true "被模拟用户ID"
+// @Param request body dto.ImpersonateRequest false "模拟原因"
+// @Success 201 {object} dto.ImpersonationResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/admin/impersonate/{user_id} [post]
+func (h *AdminHandler) Impersonate(c *gin.Context) {
+	if !isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+	// 模拟令牌不能再发起模拟
+	if c.GetString("impersonator_id") != "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "cannot impersonate while impersonating"})
+		return
+	}
+
+	var req dto.ImpersonateRequest
+	// 请求体可以为空，此时不记录模拟原因
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+	req.TargetUserID = c.Param("user_id")
+	req.ImpersonatorID = c.GetString("user_id")
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	response, err := h.impersonationService.Impersonate(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case isDomainErrorType(err, event.ErrUserInactive):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case isDomainErrorType(err, event.ErrRateLimited):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		default:
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ExplainPermission 试运行权限检查并解释决策
+// @Summary 解释权限决策
+// @Description 仅管理员可用，评估指定用户能否对资源执行操作但不执行操作。返回最终决策、决定结果的规则、用户角色中匹配的权限，以及作用于该资源和操作的每个策略的效果、优先级和是否匹配
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.ExplainPermissionRequest true "用户、资源、操作和资源上下文"
+// @Success 200 {object} dto.PermissionExplanation
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/admin/authz/explain [post]
+func (h *AdminHandler) ExplainPermission(c *gin.Context) {
+	if !isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	var req dto.ExplainPermissionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	explanation, err := h.permissionService.ExplainPermission(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, explanation)
+}
+
+// ListFeatureFlags 查看功能开关
+// @Summary 查看功能开关
+// @Description 仅管理员可用，列出全部功能开关在当前环境下是否开启及取值来源。指定 project_id 时应用该项目的覆盖配置；未配置的开关在生产环境默认关闭
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param project_id query string false "项目ID"
+// @Success 200 {object} dto.FeatureFlagListResponse
+// @Failure 403 {object} map[string]interface{}
+// @Router /api/v1/admin/feature-flags [get]
+func (h *AdminHandler) ListFeatureFlags(c *gin.Context) {
+	if !isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin role required"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.featureFlagService.ListFlags(c.Query("project_id")))
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+)
+
+// APIKeyHandler 当前用户的API密钥管理处理器
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyAppService
+}
+
+// NewAPIKeyHandler 创建API密钥管理处理器
+func NewAPIKeyHandler(apiKeyService *service.APIKeyAppService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// requireJWT 以API密钥认证的请求不能管理API密钥，写入403响应并返回 false
+// 避免泄露的密钥被用来创建新密钥或撤销主人的其他密钥；模拟登录期间同样不能管理，避免管理员留下长期凭证
+func (h *APIKeyHandler) requireJWT(c *gin.Context) bool {
+	if c.GetString("api_key_id") != "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "api keys cannot be managed with an api key"})
+		return false
+	}
+	if c.GetString("impersonator_id") != "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "api keys cannot be managed while impersonating"})
+		return false
+	}
+	return true
+}
+
+// ListAPIKeys 获取当前用户的API密钥列表
+// @Summary 获取当前用户的API密钥列表
+// @Description 包含已吊销的密钥，不返回明文密钥
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	if !h.requireJWT(c) {
+		return
+	}
+
+	response, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateAPIKey 为当前用户创建API密钥
+// @Summary 创建API密钥
+// @Description 以请求头 X-Api-Key 携带密钥即可代替JWT访问接口；作用域形如 tasks:read、projects:write，资源为 tasks、projects、users、files、stats、search、approvals、events；read 允许该资源的 GET/HEAD 请求，write 允许全部请求，未知作用域返回400。明文密钥只在本次响应中返回
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreateAPIKeyRequest true "创建API密钥请求"
+// @Success 201 {object} dto.CreatedAPIKeyResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	if !h.requireJWT(c) {
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), c.GetString("user_id"), req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// RevokeAPIKey 吊销API密钥
+// @Summary 吊销API密钥
+// @Description 吊销后使用该密钥的请求返回401；只有密钥所属用户或管理员可以吊销，重复吊销不报错
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "API密钥ID"
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	if !h.requireJWT(c) {
+		return
+	}
+
+	response, err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), c.GetString("user_id"), c.Param("id"), isAdmin(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
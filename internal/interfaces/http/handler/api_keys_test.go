@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// apiKeyRouter API密钥管理路由，请求头 X-User 指定当前用户，X-Via-Key 模拟以API密钥认证
+func apiKeyRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	binding.Validator = validation.NewStructValidator()
+
+	h := NewAPIKeyHandler(service.NewAPIKeyAppService(testutil.NewMemoryAPIKeyRepository(), testutil.NewMemoryUserRepository()))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-User"))
+		c.Set("user_roles", []string{"employee"})
+		if c.GetHeader("X-Via-Key") != "" {
+			c.Set("api_key_id", c.GetHeader("X-Via-Key"))
+		}
+		c.Next()
+	})
+	router.GET("/me/api-keys", h.ListAPIKeys)
+	router.POST("/me/api-keys", h.CreateAPIKey)
+	router.DELETE("/me/api-keys/:id", h.RevokeAPIKey)
+	return router
+}
+
+func apiKeyRequest(router *gin.Engine, method, path, user, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", user)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateAPIKey_ReturnsKeyOnlyOnce(t *testing.T) {
+	router := apiKeyRouter(t)
+
+	w := apiKeyRequest(router, http.MethodPost, "/me/api-keys", "user-1", `{"name":"ci","scopes":["tasks:read"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created dto.CreatedAPIKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Key)
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	assert.Equal(t, []string{"tasks:read"}, created.Scopes)
+
+	w = apiKeyRequest(router, http.MethodGet, "/me/api-keys", "user-1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.Key)
+	assert.Contains(t, w.Body.String(), created.ID)
+
+	// 其他用户看不到该密钥
+	w = apiKeyRequest(router, http.MethodGet, "/me/api-keys", "user-2", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestCreateAPIKey_RejectsInvalidScope(t *testing.T) {
+	router := apiKeyRouter(t)
+
+	for _, scope := range []string{"admin", "read", "tasks:delete", "webhooks:write"} {
+		w := apiKeyRequest(router, http.MethodPost, "/me/api-keys", "user-1", `{"name":"ci","scopes":["tasks:read","`+scope+`"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code, scope)
+	}
+	w := apiKeyRequest(router, http.MethodGet, "/me/api-keys", "user-1", "")
+	assert.JSONEq(t, `[]`, w.Body.String())
+
+	w = apiKeyRequest(router, http.MethodPost, "/me/api-keys", "user-1", `{"name":"ci","scopes":[]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRevokeAPIKey_OwnerOnly(t *testing.T) {
+	router := apiKeyRouter(t)
+	w := apiKeyRequest(router, http.MethodPost, "/me/api-keys", "user-1", `{"name":"ci","scopes":["tasks:write"]}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created dto.CreatedAPIKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	w = apiKeyRequest(router, http.MethodDelete, "/me/api-keys/"+created.ID, "user-2", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = apiKeyRequest(router, http.MethodDelete, "/me/api-keys/"+created.ID, "user-1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var revoked dto.APIKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &revoked))
+	assert.NotNil(t, revoked.RevokedAt)
+
+	w = apiKeyRequest(router, http.MethodDelete, "/me/api-keys/missing", "user-1", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAPIKeys_CannotBeManagedWithAnAPIKey(t *testing.T) {
+	router := apiKeyRouter(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/me/api-keys", strings.NewReader(`{"name":"ci","scopes":["tasks:write"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", "user-1")
+	req.Header.Set("X-Via-Key", "key-1")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
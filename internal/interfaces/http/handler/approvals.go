@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+)
+
+// ApprovalHandler 审批查询处理器
+type ApprovalHandler struct {
+	approvalService *service.ApprovalAppService
+}
+
+// NewApprovalHandler 创建审批查询处理器
+func NewApprovalHandler(approvalService *service.ApprovalAppService) *ApprovalHandler {
+	return &ApprovalHandler{approvalService: approvalService}
+}
+
+// ListMyApprovals 获取我提交的审批
+// @Summary 获取我提交的审批
+// @Description 分页返回当前用户提交的任务创建审批、工作提交审核和延期申请，按提交时间倒序；待审批的项带有当前步骤
+// @Tags approvals
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "审批状态" Enums(pending, approved, rejected)
+// @Param type query string false "审批类型" Enums(task_creation, task_completion, extension_request)
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页数量，默认20，最大100"
+// @Success 200 {object} dto.MyApprovalsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/approvals/mine [get]
+func (h *ApprovalHandler) ListMyApprovals(c *gin.Context) {
+	var req dto.MyApprovalsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+	req.RequesterID = c.GetString("user_id")
+
+	response, err := h.approvalService.ListMyApprovals(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
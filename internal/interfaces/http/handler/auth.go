@@ -75,8 +75,7 @@ type UserInfo struct {
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -135,8 +134,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Router /api/v1/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -154,11 +152,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			zap.String("email", req.Email),
 			zap.Error(err))
 
-		// 检查是否是邮箱重复错误
+		// 邮箱重复优先返回409，其余业务规则违规按字段返回
 		if isEmailExistsError(err) {
 			errors.RespondWithError(c, http.StatusConflict, "EMAIL_EXISTS", "邮箱已存在")
 			return
 		}
+		if fields, ok := violationFieldErrors(err); ok {
+			errors.RespondWithValidationError(c, fields)
+			return
+		}
 
 		errors.RespondWithError(c, http.StatusInternalServerError, "REGISTRATION_FAILED", "注册失败")
 		return
@@ -213,8 +215,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Router /api/v1/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -303,24 +304,3 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 
 	errors.RespondWithSuccess(c, profile, "获取用户资料成功")
 }
-
-// 辅助函数：检查是否是邮箱已存在错误
-func isEmailExistsError(err error) bool {
-	// 这里可以根据具体的错误类型或错误消息来判断
-	// 简单实现：检查错误消息中是否包含邮箱相关的关键词
-	errMsg := err.Error()
-	return contains(errMsg, "email") && (contains(errMsg, "exists") || contains(errMsg, "duplicate") || contains(errMsg, "unique"))
-}
-
-func contains(str, substr string) bool {
-	return len(str) >= len(substr) && (str == substr || len(str) > len(substr) && (str[:len(substr)] == substr || str[len(str)-len(substr):] == substr || containsSubstring(str, substr)))
-}
-
-func containsSubstring(str, substr string) bool {
-	for i := 0; i <= len(str)-len(substr); i++ {
-		if str[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
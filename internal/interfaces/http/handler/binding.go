@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/pkg/errors"
+)
+
+// bindJSON 绑定并校验JSON请求体，失败时写入400响应并返回 false
+// 标签校验失败返回字段级错误，请求体格式错误返回 INVALID_REQUEST
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	return handleBindError(c, c.ShouldBindJSON(obj))
+}
+
+// bindQuery 绑定并校验查询参数，失败时写入400响应并返回 false
+func bindQuery(c *gin.Context, obj interface{}) bool {
+	return handleBindError(c, c.ShouldBindQuery(obj))
+}
+
+func handleBindError(c *gin.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+	if fields, ok := validation.FieldErrors(err); ok {
+		errors.RespondWithValidationError(c, fields)
+		return false
+	}
+	errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	return false
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	domainService "github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+func TestRegister_RejectsInvalidFieldsWithFieldErrors(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	binding.Validator = validation.NewStructValidator()
+
+	router := gin.New()
+	router.POST("/register", NewAuthHandler(nil, nil).Register)
+
+	body := `{"name":"Alice","email":"not-an-email","password":"123"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var resp struct {
+		Code    string                  `json:"code"`
+		Details []validation.FieldError `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "VALIDATION_FAILED", resp.Code)
+	assert.ElementsMatch(t, []validation.FieldError{
+		{Field: "email", Rule: "email", Message: "email必须是有效的邮箱地址"},
+		{Field: "password", Rule: "min", Message: "password长度不能少于6个字符"},
+	}, resp.Details)
+}
+
+func TestRegister_DuplicateEmailReturns409(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	binding.Validator = validation.NewStructValidator()
+
+	users := testutil.NewMemoryUserRepository(aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee))
+	userService := service.NewUserAppService(domainService.NewUserDomainServiceEnhanced(users, nil, nil, nil, nil, nil),
+		passthroughTransactionManager{}, nil, users, nil)
+	router := gin.New()
+	router.POST("/register", NewAuthHandler(nil, userService).Register)
+	register := func(body string) (int, string) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		var resp struct {
+			Code string `json:"code"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return w.Code, resp.Code
+	}
+
+	status, code := register(`{"name":"alice","email":"alice@example.com","password":"secret123"}`)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "EMAIL_EXISTS", code)
+
+	status, code = register(`{"name":"alice","email":"other@example.com","password":"secret123"}`)
+	assert.Equal(t, http.StatusBadRequest, status, "a taken username alone is a field violation")
+	assert.Equal(t, "VALIDATION_FAILED", code)
+}
+
+func TestBindJSON_MalformedBody(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/register", NewAuthHandler(nil, nil).Register)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{"email":`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_REQUEST")
+}
+
+func TestViolationFieldErrors_ConvertsDomainViolations(t *testing.T) {
+	err := fmt.Errorf("注册失败: %w", &valueobject.ValidationError{
+		Operation: "用户创建",
+		Violations: []valueobject.ValidationViolation{
+			{RuleID: "email_unique", Field: "email", Message: "邮箱已存在: alice@example.com"},
+			{RuleID: "username_unique", Field: "username", Message: "用户名已存在: alice"},
+		},
+	})
+
+	fields, ok := violationFieldErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, []validation.FieldError{
+		{Field: "email", Rule: "email_unique", Message: "邮箱已存在: alice@example.com"},
+		{Field: "username", Rule: "username_unique", Message: "用户名已存在: alice"},
+	}, fields)
+
+	_, ok = violationFieldErrors(fmt.Errorf("boom"))
+	assert.False(t, ok)
+}
+
+func TestErrorStatus_MapsDomainErrorTypes(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, errorStatus(fmt.Errorf("项目不存在: %w", repository.ErrNotFound)))
+	assert.Equal(t, http.StatusForbidden, errorStatus(fmt.Errorf("wrapped: %w", event.NewDomainError(event.ErrPermissionDenied, "denied"))))
+	assert.Equal(t, http.StatusBadRequest, errorStatus(event.NewDomainError(event.ErrInvalidInput, "bad input")))
+	assert.Equal(t, http.StatusInternalServerError, errorStatus(event.NewDomainError(event.ErrBusinessRule, "rule")))
+	assert.Equal(t, http.StatusInternalServerError, errorStatus(fmt.Errorf("boom")))
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/validation"
+)
+
+// currentUserRoles 获取认证中间件写入的用户角色
+func currentUserRoles(c *gin.Context) []string {
+	roles, ok := c.Get("user_roles")
+	if !ok {
+		return nil
+	}
+	roleList, _ := roles.([]string)
+	return roleList
+}
+
+// isAdmin 检查当前用户是否为管理员
+func isAdmin(c *gin.Context) bool {
+	for _, role := range currentUserRoles(c) {
+		if role == string(valueobject.UserRoleAdmin) || role == string(valueobject.UserRoleSuperAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+// isManagerOrAdmin 检查当前用户是否为经理、总监或管理员
+func isManagerOrAdmin(c *gin.Context) bool {
+	for _, role := range currentUserRoles(c) {
+		switch valueobject.UserRole(role) {
+		case valueobject.UserRoleManager, valueobject.UserRoleDirector:
+			return true
+		}
+	}
+	return isAdmin(c)
+}
+
+// parseDateParam 解析日期查询参数，支持 YYYY-MM-DD 和 RFC3339，结果为UTC
+func parseDateParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("value is required")
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC3339")
+	}
+	return t.UTC(), nil
+}
+
+// isDomainErrorType 检查错误链中是否包含指定类型的领域错误
+func isDomainErrorType(err error, errorType event.DomainErrorType) bool {
+	var domainErr *event.DomainError
+	return errors.As(err, &domainErr) && domainErr.Type == errorType
+}
+
+// isNotFound 判断错误链中是否包含仓储的记录不存在错误
+func isNotFound(err error) bool {
+	return errors.Is(err, repository.ErrNotFound)
+}
+
+// errorStatus 记录不存在时返回404，无权限时返回403，输入无效时返回400，其他错误返回500
+func errorStatus(err error) int {
+	switch {
+	case isNotFound(err):
+		return http.StatusNotFound
+	case isDomainErrorType(err, event.ErrPermissionDenied):
+		return http.StatusForbidden
+	case isDomainErrorType(err, event.ErrInvalidInput):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// domainErrorCode 获取错误链中聚合领域错误的错误码，没有时返回空字符串
+func domainErrorCode(err error) string {
+	var domainErr aggregate.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return ""
+}
+
+// violationFieldErrors 将错误链中的业务规则验证错误转换为字段错误列表，没有时返回 false
+func violationFieldErrors(err error) ([]validation.FieldError, bool) {
+	var validationErr *valueobject.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, false
+	}
+	fields := make([]validation.FieldError, 0, len(validationErr.Violations))
+	for _, v := range validationErr.Violations {
+		fields = append(fields, validation.FieldError{Field: v.Field, Rule: v.RuleID, Message: v.Message})
+	}
+	return fields, true
+}
+
+// isEmailExistsError 判断错误是否包含邮箱唯一性违规
+func isEmailExistsError(err error) bool {
+	var validationErr *valueobject.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	for _, v := range validationErr.Violations {
+		if v.RuleID == valueobject.RuleEmailUnique {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+)
+
+// EventHandler 领域事件查询处理器
+type EventHandler struct {
+	eventService *service.EventAppService
+}
+
+// NewEventHandler 创建领域事件查询处理器
+func NewEventHandler(eventService *service.EventAppService) *EventHandler {
+	return &EventHandler{eventService: eventService}
+}
+
+// ListEvents 按类型和时间窗口查询领域事件
+// @Summary 查询领域事件
+// @Description 供外部系统同步使用，按发生时间升序分页返回事件信封和事件数据，仅管理员可访问
+// @Tags events
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type query []string false "事件类型，可重复传入" collectionFormat(multi)
+// @Param aggregate_id query string false "聚合ID，如任务ID"
+// @Param since query string false "起始时间（RFC3339，包含）"
+// @Param after_id query string false "翻页游标，与 since 同时传入"
+// @Param limit query int false "每页数量，默认100，最大1000"
+// @Success 200 {object} dto.ListEventsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events [get]
+func (h *EventHandler) ListEvents(c *gin.Context) {
+	if !isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can list domain events"})
+		return
+	}
+
+	var req dto.ListEventsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	response, err := h.eventService.ListEvents(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
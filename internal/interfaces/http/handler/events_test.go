@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/infrastructure/messaging/memory"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/pkg/logger"
+)
+
+// listEvents 以指定角色请求事件列表
+func listEvents(t *testing.T, roles []string, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	binding.Validator = validation.NewStructValidator()
+
+	store := memory.NewInMemoryEventStore(0)
+	completed := event.NewTaskCompletedEvent("task-1", "user-1")
+	completed.Timestamp = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(completed))
+	require.NoError(t, store.Save(event.NewTaskRejectedEvent("task-2", "user-2", "incomplete")))
+
+	router := gin.New()
+	router.GET("/events", func(c *gin.Context) {
+		c.Set("user_roles", roles)
+		c.Next()
+	}, NewEventHandler(service.NewEventAppService(store)).ListEvents)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/events"+query, nil))
+	return w
+}
+
+func TestListEvents_AdminFiltersByType(t *testing.T) {
+	w := listEvents(t, []string{"admin"}, "?type=TaskCompleted&since=2024-03-01T09:00:00Z")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp dto.ListEventsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Events, 1)
+	assert.Equal(t, "TaskCompleted", resp.Events[0].Type)
+}
+
+func TestListEvents_RequiresAdmin(t *testing.T) {
+	w := listEvents(t, []string{"manager"}, "")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestListEvents_CursorRequiresSince(t *testing.T) {
+	w := listEvents(t, []string{"admin"}, "?after_id=evt-1")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_FAILED")
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/pkg/errors"
+)
+
+// sparseFields 解析 fields 查询参数（逗号分隔的字段名），按响应类型的 JSON 字段名校验
+// 未提供时返回 nil；结果总是包含 id；存在未知字段时写入400响应并返回 false
+func sparseFields(c *gin.Context, response interface{}) ([]string, bool) {
+	raw := c.Query("fields")
+	if raw == "" {
+		return nil, true
+	}
+
+	allowed := jsonFieldNames(reflect.TypeOf(response))
+	fields := []string{"id"}
+	seen := map[string]bool{"id": true}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !allowed[name] {
+			names := make([]string, 0, len(allowed))
+			for n := range allowed {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			errors.RespondWithValidationError(c, []validation.FieldError{{
+				Field:   "fields",
+				Rule:    "oneof",
+				Message: fmt.Sprintf("fields必须是以下值之一: %s，未知字段: %s", strings.Join(names, " "), name),
+			}})
+			return nil, false
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	return fields, true
+}
+
+// jsonFieldNames 结构体顶层字段序列化后的 JSON 字段名
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// pickFields 只保留对象中 fields 列出的字段，对象切片逐个裁剪
+func pickFields(obj interface{}, fields []string) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	pick := func(full map[string]interface{}) map[string]interface{} {
+		picked := make(map[string]interface{}, len(fields))
+		for _, name := range fields {
+			if value, ok := full[name]; ok {
+				picked[name] = value
+			}
+		}
+		return picked
+	}
+
+	if reflect.Indirect(reflect.ValueOf(obj)).Kind() == reflect.Slice {
+		var items []map[string]interface{}
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		picked := make([]map[string]interface{}, len(items))
+		for i, item := range items {
+			picked[i] = pick(item)
+		}
+		return picked, nil
+	}
+
+	var full map[string]interface{}
+	if err := json.Unmarshal(data, &full); err != nil {
+		return nil, err
+	}
+	return pick(full), nil
+}
+
+// respondFields 按字段集裁剪后响应，fields 为空时原样响应
+// 裁剪后的响应不再对应 taskflow.proto 中的消息，请求 protobuf 时回退为JSON
+func respondFields(c *gin.Context, status int, obj interface{}, fields []string) {
+	if fields == nil {
+		respond(c, status, obj)
+		return
+	}
+	picked, err := pickFields(obj, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	respond(c, status, picked)
+}
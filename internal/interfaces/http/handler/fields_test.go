@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// getProjects 以管理员身份请求项目接口
+func getProjects(t *testing.T, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	alpha := aggregate.NewProject("p-1", "Alpha", "", valueobject.ProjectTypeMaster, "owner-1")
+	beta := aggregate.NewProject("p-2", "Beta", "", valueobject.ProjectTypeMaster, "owner-1")
+	projectRepo := testutil.NewMemoryProjectRepository(*alpha, *beta)
+	h := NewProjectHandler(service.NewProjectAppService(nil, passthroughTransactionManager{}, projectRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "admin-1")
+		c.Set("user_roles", []string{"admin"})
+		c.Next()
+	})
+	router.GET("/projects", h.ListProjects)
+	router.GET("/projects/:id", h.GetProject)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func keysOf(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestSparseFields_DetailReturnsOnlyRequestedKeys(t *testing.T) {
+	w := getProjects(t, "/projects/p-1?fields=id,name,status")
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.ElementsMatch(t, []string{"id", "name", "status"}, keysOf(body))
+	assert.Equal(t, "Alpha", body["name"])
+
+	// 未列出 id 时也总是返回
+	w = getProjects(t, "/projects/p-1?fields=name")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	body = nil
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.ElementsMatch(t, []string{"id", "name"}, keysOf(body))
+}
+
+func TestSparseFields_ListTrimsEveryItem(t *testing.T) {
+	w := getProjects(t, "/projects?fields=name,status")
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Projects []map[string]interface{} `json:"projects"`
+		Total    int                      `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Projects, 2)
+	assert.Equal(t, 2, body.Total, "pagination fields are kept")
+	for _, project := range body.Projects {
+		assert.ElementsMatch(t, []string{"id", "name", "status"}, keysOf(project))
+	}
+}
+
+func TestSparseFields_UnknownFieldRejected(t *testing.T) {
+	for _, target := range []string{"/projects/p-1?fields=id,title", "/projects?fields=password"} {
+		w := getProjects(t, target)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+		assert.Contains(t, w.Body.String(), "VALIDATION_FAILED", target)
+	}
+
+	// 不带 fields 时返回完整响应
+	w := getProjects(t, "/projects/p-1")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"owner_id"`)
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MeHandler 当前登录用户处理器
+type MeHandler struct {
+	currentUserService *service.CurrentUserAppService
+}
+
+// NewMeHandler 创建当前登录用户处理器
+func NewMeHandler(currentUserService *service.CurrentUserAppService) *MeHandler {
+	return &MeHandler{
+		currentUserService: currentUserService,
+	}
+}
+
+// GetMe 获取当前用户资料、角色和有效权限
+// @Summary 获取当前用户信息
+// @Description 登录后一次性获取当前用户的资料、角色、有效权限和可访问项目数
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} service.CurrentUserResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me [get]
+func (h *MeHandler) GetMe(c *gin.Context) {
+	// 只使用认证上下文中的用户ID，避免读取其他用户的数据
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "未找到用户信息"})
+		return
+	}
+
+	response, err := h.currentUserService.GetCurrentUser(c.Request.Context(), userID)
+	if err != nil {
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		logger.Error("Failed to get current user",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetNotificationPreferences 获取当前用户的通知偏好设置
+// @Summary 获取通知偏好设置
+// @Description 获取当前用户的邮件、短信、推送渠道开关、提醒与升级间隔和静默时段
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} service.NotificationPreferencesResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/me/notification-preferences [get]
+func (h *MeHandler) GetNotificationPreferences(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "未找到用户信息"})
+		return
+	}
+
+	response, err := h.currentUserService.GetNotificationPreferences(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateNotificationPreferences 批量更新当前用户的通知偏好设置
+// @Summary 更新通知偏好设置
+// @Description 一次更新多项通知偏好，未提供的字段保持不变；小时数不能为负，静默时段格式为 HH:MM，时区需为有效的IANA时区名
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body service.UpdateNotificationPreferencesRequest true "通知偏好"
+// @Success 200 {object} service.NotificationPreferencesResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/me/notification-preferences [put]
+func (h *MeHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "未找到用户信息"})
+		return
+	}
+
+	var req service.UpdateNotificationPreferencesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.currentUserService.UpdateNotificationPreferences(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.Error("Failed to update notification preferences",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
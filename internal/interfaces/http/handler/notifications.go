@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+)
+
+// NotificationHandler 当前用户的站内通知处理器
+type NotificationHandler struct {
+	notificationService *service.NotificationAppService
+}
+
+// NewNotificationHandler 创建站内通知处理器
+func NewNotificationHandler(notificationService *service.NotificationAppService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// ListNotifications 获取我的站内通知
+// @Summary 获取我的站内通知
+// @Description 分页返回当前用户的站内通知，按创建时间倒序；unread_count 为全部未读通知数
+// @Tags notifications
+// @Produce json
+// @Security ApiKeyAuth
+// @Param unread query bool false "只返回未读通知"
+// @Param page query int false "页码，默认1"
+// @Param page_size query int false "每页数量，默认20，最大100"
+// @Success 200 {object} dto.NotificationListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me/notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	var req dto.ListNotificationsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+	req.UserID = c.GetString("user_id")
+
+	response, err := h.notificationService.ListNotifications(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkNotificationRead 标记通知已读
+// @Summary 标记通知已读
+// @Description 标记当前用户的一条通知为已读，重复标记保持原已读时间
+// @Tags notifications
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "通知ID"
+// @Success 200 {object} dto.NotificationResponse
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	response, err := h.notificationService.MarkNotificationRead(c.Request.Context(), c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkAllNotificationsRead 标记全部通知已读
+// @Summary 标记全部通知已读
+// @Tags notifications
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.MarkAllNotificationsReadResponse
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	response, err := h.notificationService.MarkAllNotificationsRead(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
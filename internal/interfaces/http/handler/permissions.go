@@ -65,8 +65,7 @@ type RevokeRoleRequest struct {
 // @Router /api/v1/permissions/check [post]
 func (h *PermissionHandler) CheckPermission(c *gin.Context) {
 	var req CheckPermissionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -115,8 +114,7 @@ func (h *PermissionHandler) CheckPermission(c *gin.Context) {
 // @Router /api/v1/permissions/assign-role [post]
 func (h *PermissionHandler) AssignRole(c *gin.Context) {
 	var req AssignRoleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -165,8 +163,7 @@ func (h *PermissionHandler) AssignRole(c *gin.Context) {
 // @Router /api/v1/permissions/revoke-role [post]
 func (h *PermissionHandler) RevokeRole(c *gin.Context) {
 	var req RevokeRoleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
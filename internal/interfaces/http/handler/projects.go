@@ -5,6 +5,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/pkg/errors"
 )
 
 // ProjectHandler 项目处理器
@@ -21,10 +23,10 @@ func NewProjectHandler(projectAppService *service.ProjectAppService) *ProjectHan
 
 // ListProjects 获取项目列表
 // @Summary 获取项目列表
-// @Description 分页获取项目列表，支持搜索和过滤
+// @Description 分页获取项目列表，支持搜索和过滤；fields 只返回每个项目中列出的字段，总是包含 id
 // @Tags projects
 // @Accept json
-// @Produce json
+// @Produce json,application/x-protobuf,application/x-msgpack
 // @Param page query int false "页码" default(1)
 // @Param page_size query int false "每页数量" default(20)
 // @Param status query string false "项目状态" Enums(draft,active,paused,completed,cancelled)
@@ -34,14 +36,18 @@ func NewProjectHandler(projectAppService *service.ProjectAppService) *ProjectHan
 // @Param search query string false "搜索关键词"
 // @Param sort_by query string false "排序字段" default(created_at)
 // @Param sort_order query string false "排序方向" default(desc)
+// @Param fields query string false "返回的项目字段，逗号分隔，如 id,name,status"
 // @Success 200 {object} service.ProjectListResponse
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects [get]
 func (h *ProjectHandler) ListProjects(c *gin.Context) {
 	var req service.ProjectListRequest
-	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindQuery(c, &req) {
+		return
+	}
+	fields, ok := sparseFields(c, service.ProjectResponse{})
+	if !ok {
 		return
 	}
 
@@ -53,35 +59,134 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 		req.PageSize = 20
 	}
 
+	// 按请求者过滤可访问的项目
+	req.RequesterID = c.GetString("user_id")
+	req.RequesterIsAdmin = isAdmin(c)
+
 	response, err := h.projectAppService.ListProjects(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if fields == nil {
+		respond(c, http.StatusOK, response)
+		return
+	}
+
+	projects, err := pickFields(response.Projects, fields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	respond(c, http.StatusOK, gin.H{
+		"projects":    projects,
+		"total":       response.Total,
+		"page":        response.Page,
+		"page_size":   response.PageSize,
+		"total_pages": response.TotalPages,
+	})
+}
+
+// ListProjectsByDateRange 按时间窗口获取项目
+// @Summary 按时间窗口获取项目
+// @Description 获取在时间窗口内处于进行期的项目（开始日期不晚于to，且结束日期为空或不早于from），用于项目时间轴视图
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param from query string true "窗口开始（YYYY-MM-DD 或 RFC3339）"
+// @Param to query string true "窗口结束（YYYY-MM-DD 或 RFC3339）"
+// @Success 200 {array} service.ProjectResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/projects/timeline [get]
+func (h *ProjectHandler) ListProjectsByDateRange(c *gin.Context) {
+	from, err := parseDateParam(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from: " + err.Error()})
+		return
+	}
+	to, err := parseDateParam(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to: " + err.Error()})
+		return
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return
+	}
+
+	response, err := h.projectAppService.ListProjectsByDateRange(c.Request.Context(), &service.ProjectDateRangeRequest{
+		From:             from,
+		To:               to,
+		RequesterID:      c.GetString("user_id"),
+		RequesterIsAdmin: isAdmin(c),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListAtRiskProjects 获取有逾期任务的项目
+// @Summary 获取有逾期任务的项目
+// @Description 仅经理和管理员可用，分页返回至少有一个逾期且未完成、未取消任务的项目及其逾期任务数，按逾期任务数降序，用于项目组合健康视图
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param page query int false "页码" default(1)
+// @Param page_size query int false "每页数量" default(20)
+// @Success 200 {object} service.AtRiskProjectListResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/projects/at-risk [get]
+func (h *ProjectHandler) ListAtRiskProjects(c *gin.Context) {
+	if !isManagerOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only managers can view project health"})
+		return
+	}
+
+	var req service.AtRiskProjectListRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	response, err := h.projectAppService.ListAtRiskProjects(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, response)
 }
 
 // CreateProject 创建项目
 // @Summary 创建新项目
-// @Description 创建新的项目
+// @Description 创建新的项目，当前用户为项目所有者
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Param request body service.CreateProjectRequest true "创建项目请求"
 // @Success 201 {object} service.ProjectResponse
 // @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects [post]
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	var req service.CreateProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
+	req.OwnerID = c.GetString("user_id")
 
 	response, err := h.projectAppService.CreateProject(c.Request.Context(), &req)
 	if err != nil {
+		if isDomainErrorType(err, event.ErrProjectExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -91,12 +196,15 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 
 // GetProject 获取项目详情
 // @Summary 获取项目详情
-// @Description 根据ID获取项目详细信息
+// @Description 根据ID获取项目详细信息，仅项目所有者、管理者、成员和管理员可访问；fields 只返回列出的字段，总是包含 id
 // @Tags projects
 // @Accept json
-// @Produce json
+// @Produce json,application/x-protobuf,application/x-msgpack
 // @Param id path string true "项目ID"
+// @Param fields query string false "返回的字段，逗号分隔，如 id,name,status"
 // @Success 200 {object} service.ProjectResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects/{id} [get]
@@ -107,18 +215,23 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 		return
 	}
 
-	response, err := h.projectAppService.GetProject(c.Request.Context(), projectID)
+	fields, ok := sparseFields(c, service.ProjectResponse{})
+	if !ok {
+		return
+	}
+
+	response, err := h.projectAppService.GetProject(c.Request.Context(), projectID, c.GetString("user_id"), isAdmin(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	respondFields(c, http.StatusOK, response, fields)
 }
 
 // UpdateProject 更新项目
 // @Summary 更新项目信息
-// @Description 更新项目基本信息
+// @Description 更新项目基本信息，仅项目所有者、管理者和管理员可操作；名称和描述超过配置的长度上限时按字段返回400
 // @Tags projects
 // @Accept json
 // @Produce json
@@ -126,7 +239,9 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 // @Param request body service.UpdateProjectRequest true "更新项目请求"
 // @Success 200 {object} service.ProjectResponse
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects/{id} [put]
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
@@ -137,22 +252,36 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	}
 
 	var req service.UpdateProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
 	req.ID = projectID
+	req.UpdatedBy = c.GetString("user_id")
+	req.RequesterIsAdmin = isAdmin(c)
 	err := h.projectAppService.UpdateProject(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		// 名称、描述超长按字段返回
+		if fields, ok := violationFieldErrors(err); ok {
+			errors.RespondWithValidationError(c, fields)
+			return
+		}
+		if isDomainErrorType(err, event.ErrProjectNameConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if domainErrorCode(err) == "PROJECT_END_BEFORE_START" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	// 获取更新后的项目信息
-	response, err := h.projectAppService.GetProject(c.Request.Context(), projectID)
+	response, err := h.projectAppService.GetProject(c.Request.Context(), projectID, req.UpdatedBy, req.RequesterIsAdmin)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -187,7 +316,7 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 
 	err := h.projectAppService.DeleteProject(c.Request.Context(), projectID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -196,12 +325,13 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 
 // GetProjectMembers 获取项目成员
 // @Summary 获取项目成员列表
-// @Description 获取指定项目的所有成员
+// @Description 获取指定项目的所有成员，仅项目所有者、管理者、成员和管理员可访问
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Param id path string true "项目ID"
 // @Success 200 {array} service.ProjectMemberResponse
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects/{id}/members [get]
@@ -212,15 +342,47 @@ func (h *ProjectHandler) GetProjectMembers(c *gin.Context) {
 		return
 	}
 
-	project, err := h.projectAppService.GetProject(c.Request.Context(), projectID)
+	project, err := h.projectAppService.GetProject(c.Request.Context(), projectID, c.GetString("user_id"), isAdmin(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, project.Members)
 }
 
+// GetAssignmentSuggestions 推荐任务负责人
+// @Summary 推荐任务负责人
+// @Description 按成员在全部项目中负责的未结束任务数和逾期任务数排序，返回负载最低的项目成员，仅供参考不强制；priority 为 high 或 critical 时优先推荐没有逾期任务的成员
+// @Tags projects
+// @Produce json
+// @Param id path string true "项目ID"
+// @Param priority query string false "待分配任务的优先级" Enums(low,medium,high,critical)
+// @Param limit query int false "返回人数，默认3，最大20"
+// @Success 200 {object} service.AssignmentSuggestionResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/projects/{id}/assignment-suggestions [get]
+func (h *ProjectHandler) GetAssignmentSuggestions(c *gin.Context) {
+	var req service.AssignmentSuggestionRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+	req.ProjectID = c.Param("id")
+	req.RequesterID = c.GetString("user_id")
+	req.RequesterIsAdmin = isAdmin(c)
+
+	response, err := h.projectAppService.SuggestAssignees(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // AddProjectMember 添加项目成员
 // @Summary 添加项目成员
 // @Description 向项目添加新成员
@@ -242,8 +404,7 @@ func (h *ProjectHandler) AddProjectMember(c *gin.Context) {
 	}
 
 	var req service.AddMemberRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -256,7 +417,7 @@ func (h *ProjectHandler) AddProjectMember(c *gin.Context) {
 
 	err := h.projectAppService.AddMember(c.Request.Context(), projectID, req.UserID, req.Role, operatorID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -265,15 +426,17 @@ func (h *ProjectHandler) AddProjectMember(c *gin.Context) {
 
 // RemoveProjectMember 移除项目成员
 // @Summary 移除项目成员
-// @Description 从项目中移除成员
+// @Description 从项目中移除成员；成员仍负责项目中未结束的任务时返回409，可通过 reassign_to 先将任务转交给其他成员
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Param id path string true "项目ID"
 // @Param user_id path string true "用户ID"
+// @Param reassign_to query string false "接收未结束任务的项目成员ID"
 // @Success 204
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects/{id}/members/{user_id} [delete]
 func (h *ProjectHandler) RemoveProjectMember(c *gin.Context) {
@@ -292,15 +455,49 @@ func (h *ProjectHandler) RemoveProjectMember(c *gin.Context) {
 		return
 	}
 
-	err := h.projectAppService.RemoveMember(c.Request.Context(), projectID, userID, operatorID)
+	err := h.projectAppService.RemoveMember(c.Request.Context(), projectID, userID, operatorID, c.Query("reassign_to"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		status := errorStatus(err)
+		if isDomainErrorType(err, event.ErrBusinessRule) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// SwapProjectParticipants 批量替换项目任务的参与者
+// @Summary 批量替换项目任务的参与者
+// @Description 在项目的全部任务中移除参与者 from_user 并以相同角色加入 to_user，整批在一个事务中完成；已完成、已取消的任务和 to_user 已是参与者的任务跳过。仅项目所有者、管理者或管理员可操作，to_user 必须是项目成员
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "项目ID"
+// @Param request body service.SwapParticipantsRequest true "替换参与者请求"
+// @Success 200 {object} service.SwapParticipantsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/projects/{id}/participants/swap [post]
+func (h *ProjectHandler) SwapProjectParticipants(c *gin.Context) {
+	var req service.SwapParticipantsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	req.RequesterIsAdmin = isAdmin(c)
+
+	response, err := h.projectAppService.SwapParticipants(c.Request.Context(), c.Param("id"), c.GetString("user_id"), req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // UpdateMemberRole 更新成员角色
 // @Summary 更新项目成员角色
 // @Description 更新项目成员的角色
@@ -325,8 +522,7 @@ func (h *ProjectHandler) UpdateMemberRole(c *gin.Context) {
 	}
 
 	var req service.UpdateMemberRoleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -339,7 +535,7 @@ func (h *ProjectHandler) UpdateMemberRole(c *gin.Context) {
 
 	err := h.projectAppService.UpdateMemberRole(c.Request.Context(), projectID, userID, req.Role, operatorID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -367,8 +563,7 @@ func (h *ProjectHandler) AssignManager(c *gin.Context) {
 	}
 
 	var req service.AssignManagerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -381,7 +576,7 @@ func (h *ProjectHandler) AssignManager(c *gin.Context) {
 
 	err := h.projectAppService.AssignManager(c.Request.Context(), projectID, req.ManagerID, operatorID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -390,7 +585,7 @@ func (h *ProjectHandler) AssignManager(c *gin.Context) {
 
 // ChangeProjectStatus 更改项目状态
 // @Summary 更改项目状态
-// @Description 更改项目状态（激活、暂停、完成、取消）
+// @Description 更改项目状态（激活、暂停、完成、取消）；cascade_tasks=true 时暂停项目同时暂停进行中的任务，恢复项目时只恢复随项目暂停的任务
 // @Tags projects
 // @Accept json
 // @Produce json
@@ -409,8 +604,7 @@ func (h *ProjectHandler) ChangeProjectStatus(c *gin.Context) {
 	}
 
 	var req service.ChangeStatusRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -421,24 +615,29 @@ func (h *ProjectHandler) ChangeProjectStatus(c *gin.Context) {
 		return
 	}
 
-	err := h.projectAppService.ChangeStatus(c.Request.Context(), projectID, operatorID, req.Status, req.Reason)
+	cascaded, err := h.projectAppService.ChangeStatus(c.Request.Context(), projectID, operatorID, req.Status, req.Reason, req.CascadeTasks)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if domainErrorCode(err) == "PROJECT_END_BEFORE_START" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "project status updated successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "project status updated successfully", "cascaded_tasks": cascaded})
 }
 
 // GetSubProjects 获取子项目
 // @Summary 获取子项目列表
-// @Description 获取指定项目的所有子项目
+// @Description 获取指定项目的所有子项目，需要能访问该项目
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Param id path string true "项目ID"
 // @Success 200 {array} service.ProjectResponse
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects/{id}/subprojects [get]
@@ -449,9 +648,9 @@ func (h *ProjectHandler) GetSubProjects(c *gin.Context) {
 		return
 	}
 
-	hierarchy, err := h.projectAppService.GetProjectHierarchy(c.Request.Context(), projectID)
+	hierarchy, err := h.projectAppService.GetProjectHierarchy(c.Request.Context(), projectID, c.GetString("user_id"), isAdmin(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -469,6 +668,7 @@ func (h *ProjectHandler) GetSubProjects(c *gin.Context) {
 // @Success 201 {object} service.ProjectResponse
 // @Failure 400 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects/{id}/subprojects [post]
 func (h *ProjectHandler) CreateSubProject(c *gin.Context) {
@@ -479,8 +679,7 @@ func (h *ProjectHandler) CreateSubProject(c *gin.Context) {
 	}
 
 	var req service.CreateSubProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -493,7 +692,11 @@ func (h *ProjectHandler) CreateSubProject(c *gin.Context) {
 
 	response, err := h.projectAppService.CreateSubProject(c.Request.Context(), parentID, req.Name, req.Description, creatorID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if isDomainErrorType(err, event.ErrProjectNameConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -502,12 +705,13 @@ func (h *ProjectHandler) CreateSubProject(c *gin.Context) {
 
 // GetProjectHierarchy 获取项目层级结构
 // @Summary 获取项目层级结构
-// @Description 获取项目的完整层级结构，包括父项目和子项目
+// @Description 获取项目的完整层级结构，包括父项目和子项目，需要能访问该项目
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Param id path string true "项目ID"
 // @Success 200 {object} service.ProjectHierarchyResponse
+// @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/projects/{id}/hierarchy [get]
@@ -518,9 +722,43 @@ func (h *ProjectHandler) GetProjectHierarchy(c *gin.Context) {
 		return
 	}
 
-	response, err := h.projectAppService.GetProjectHierarchy(c.Request.Context(), projectID)
+	response, err := h.projectAppService.GetProjectHierarchy(c.Request.Context(), projectID, c.GetString("user_id"), isAdmin(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetProjectDescendants 获取项目子树
+// @Summary 获取项目的全部后代项目
+// @Description 逐层收集项目下所有层级的子项目并平铺返回，层级深度受 max_depth 限制（最大10），需要能访问该项目
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "项目ID"
+// @Param max_depth query int false "最大层级深度（1-10，默认10）"
+// @Success 200 {object} service.ProjectDescendantsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/projects/{id}/descendants [get]
+func (h *ProjectHandler) GetProjectDescendants(c *gin.Context) {
+	projectID := c.Param("id")
+	if projectID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project ID is required"})
+		return
+	}
+
+	var req service.ProjectDescendantsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	response, err := h.projectAppService.GetProjectDescendants(c.Request.Context(), projectID, req.MaxDepth, c.GetString("user_id"), isAdmin(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
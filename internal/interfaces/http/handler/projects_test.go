@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// putProject 以管理员身份更新项目 p-1，描述上限配置为 1000 个字符
+func putProject(t *testing.T, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	project := aggregate.NewProject("p-1", "Alpha", "", valueobject.ProjectTypeMaster, "owner-1")
+	projectRepo := testutil.NewMemoryProjectRepository(*project)
+	appService := service.NewProjectAppService(nil, passthroughTransactionManager{}, projectRepo, nil).
+		WithTextLimits(valueobject.TextLimits{NameMaxLength: 200, DescriptionMaxLength: 1000})
+	h := NewProjectHandler(appService)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "admin-1")
+		c.Set("user_roles", []string{"admin"})
+		c.Next()
+	})
+	router.PUT("/projects/:id", h.UpdateProject)
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/projects/p-1", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdateProject_DescriptionLimitComesFromConfiguration(t *testing.T) {
+	// 超过旧的 500 字符绑定上限但在配置的上限内
+	description := strings.Repeat("d", 800)
+	w := putProject(t, map[string]interface{}{"id": "p-1", "name": "Alpha", "description": description})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var updated service.ProjectResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, description, updated.Description)
+
+	w = putProject(t, map[string]interface{}{"id": "p-1", "name": "Alpha", "description": strings.Repeat("d", 1001)})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"description"`)
+}
+
+// projectRouterAs 以指定用户身份注册项目路由，项目 p-1 的所有者为 owner-1，成员为 member-1
+func projectRouterAs(t *testing.T, userID string) (*gin.Engine, *testutil.MemoryProjectRepository) {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	project := aggregate.NewProject("p-1", "Alpha", "", valueobject.ProjectTypeMaster, "owner-1")
+	require.NoError(t, project.AddMember("member-1", valueobject.ProjectRoleMember, "owner-1"))
+	projectRepo := testutil.NewMemoryProjectRepository(*project)
+	h := NewProjectHandler(service.NewProjectAppService(nil, passthroughTransactionManager{}, projectRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	router.POST("/projects", h.CreateProject)
+	router.GET("/projects/:id", h.GetProject)
+	router.PUT("/projects/:id", h.UpdateProject)
+	router.GET("/projects/:id/members", h.GetProjectMembers)
+	router.GET("/projects/:id/children", h.GetSubProjects)
+	router.GET("/projects/:id/hierarchy", h.GetProjectHierarchy)
+	router.GET("/projects/:id/descendants", h.GetProjectDescendants)
+	return router, projectRepo
+}
+
+// serveJSON 以 JSON 请求体发送请求
+func serveJSON(router *gin.Engine, method, target string, body map[string]interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, target, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestProjectReadRoutes_NonMemberForbidden(t *testing.T) {
+	router, _ := projectRouterAs(t, "outsider")
+
+	for _, target := range []string{
+		"/projects/p-1",
+		"/projects/p-1/members",
+		"/projects/p-1/children",
+		"/projects/p-1/hierarchy",
+		"/projects/p-1/descendants",
+	} {
+		w := serveJSON(router, http.MethodGet, target, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code, target)
+	}
+}
+
+func TestGetProject_MemberAllowed(t *testing.T) {
+	router, _ := projectRouterAs(t, "member-1")
+
+	w := serveJSON(router, http.MethodGet, "/projects/p-1/members", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestUpdateProject_RequiresOwnerOrManager(t *testing.T) {
+	body := map[string]interface{}{"id": "p-1", "name": "Renamed"}
+
+	member, repo := projectRouterAs(t, "member-1")
+	assert.Equal(t, http.StatusForbidden, serveJSON(member, http.MethodPut, "/projects/p-1", body).Code)
+	unchanged, err := repo.FindByID(context.Background(), "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Alpha", unchanged.Name)
+
+	owner, _ := projectRouterAs(t, "owner-1")
+	assert.Equal(t, http.StatusOK, serveJSON(owner, http.MethodPut, "/projects/p-1", body).Code)
+}
+
+func TestCreateProject_OwnerIsCurrentUser(t *testing.T) {
+	router, _ := projectRouterAs(t, "creator-1")
+
+	w := serveJSON(router, http.MethodPost, "/projects", map[string]interface{}{
+		"name":         "Beta",
+		"project_type": "master",
+		"owner_id":     "someone-else",
+	})
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created service.ProjectResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "creator-1", created.OwnerID)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/service"
+)
+
+// ReminderHandler 截止日期提醒处理器
+type ReminderHandler struct {
+	reminderService *service.ReminderAppService
+}
+
+// NewReminderHandler 创建截止日期提醒处理器
+func NewReminderHandler(reminderService *service.ReminderAppService) *ReminderHandler {
+	return &ReminderHandler{reminderService: reminderService}
+}
+
+// AcknowledgeReminders 确认任务的截止日期提醒
+// @Summary 确认任务的截止日期提醒
+// @Description 当前用户确认后不再收到该任务后续梯度的截止日期提醒，重复确认保持首次确认时间；仅任务负责人和参与者可确认
+// @Tags tasks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Success 204
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/reminders/acknowledge [post]
+func (h *ReminderHandler) AcknowledgeReminders(c *gin.Context) {
+	if err := h.reminderService.Acknowledge(c.Request.Context(), c.Param("id"), c.GetString("user_id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
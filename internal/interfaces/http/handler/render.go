@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"github.com/taskflow/internal/interfaces/http/protocodec"
+)
+
+// ResponseFormatsKey 上下文中允许的二进制响应 MIME 类型列表，由响应格式中间件写入
+const ResponseFormatsKey = "response_formats"
+
+// respond 按 Accept 头协商响应编码，匹配到已启用的二进制格式时使用该编码，否则返回JSON
+// protobuf 只支持 taskflow.proto 中定义的响应，其他类型回退为JSON
+func respond(c *gin.Context, status int, obj interface{}) {
+	offered := []string{binding.MIMEJSON}
+	if formats, ok := c.Get(ResponseFormatsKey); ok {
+		mimes, _ := formats.([]string)
+		offered = append(offered, mimes...)
+	}
+	c.Header("Vary", "Accept")
+
+	switch c.NegotiateFormat(offered...) {
+	case binding.MIMEPROTOBUF:
+		if body, err := protocodec.Marshal(obj); err == nil {
+			c.Data(status, binding.MIMEPROTOBUF, body)
+			return
+		}
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		c.Render(status, render.MsgPack{Data: obj})
+		return
+	}
+	c.JSON(status, obj)
+}
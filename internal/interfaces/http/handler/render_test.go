@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/testutil"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// getProject 以项目所有者身份请求项目详情，formats 为启用的二进制响应 MIME 类型
+func getProject(t *testing.T, accept string, formats []string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	project := aggregate.NewProject("p-1", "Alpha", "", valueobject.ProjectTypeMaster, "owner-1")
+	projectRepo := testutil.NewMemoryProjectRepository(*project)
+	h := NewProjectHandler(service.NewProjectAppService(nil, passthroughTransactionManager{}, projectRepo, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("user_id", "owner-1"); c.Next() })
+	if formats != nil {
+		router.Use(func(c *gin.Context) { c.Set(ResponseFormatsKey, formats); c.Next() })
+	}
+	router.GET("/projects/:id", h.GetProject)
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/p-1", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRespond_ProtobufAcceptYieldsBinaryBody(t *testing.T) {
+	w := getProject(t, binding.MIMEPROTOBUF, []string{binding.MIMEPROTOBUF})
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, binding.MIMEPROTOBUF, w.Header().Get("Content-Type"))
+
+	// 按 taskflow.proto 的 Project 消息解码 id(1)、name(2)、owner_id(6)
+	got := map[protowire.Number]string{}
+	b := w.Body.Bytes()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		if typ == protowire.BytesType {
+			v, m := protowire.ConsumeBytes(b)
+			require.GreaterOrEqual(t, m, 0)
+			got[num] = string(v)
+			n = m
+		} else {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			require.GreaterOrEqual(t, n, 0)
+		}
+		b = b[n:]
+	}
+	assert.Equal(t, "p-1", got[1])
+	assert.Equal(t, "Alpha", got[2])
+	assert.Equal(t, "owner-1", got[6])
+}
+
+func TestRespond_DefaultsToJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		accept  string
+		formats []string
+	}{
+		{"no accept header", "", []string{binding.MIMEPROTOBUF}},
+		{"any type", "*/*", []string{binding.MIMEPROTOBUF}},
+		{"protobuf not enabled", binding.MIMEPROTOBUF, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := getProject(t, tc.accept, tc.formats)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), binding.MIMEJSON)
+			var body service.ProjectResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "p-1", body.ID)
+		})
+	}
+}
+
+func TestRespond_MsgPackAccept(t *testing.T) {
+	w := getProject(t, binding.MIMEMSGPACK, []string{binding.MIMEMSGPACK, binding.MIMEMSGPACK2})
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "msgpack")
+	assert.NotEqual(t, byte('{'), w.Body.Bytes()[0], "body is not JSON")
+}
@@ -4,8 +4,49 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
 )
 
+// SearchHandler 全局搜索处理器
+type SearchHandler struct {
+	searchService *service.SearchAppService
+}
+
+// NewSearchHandler 创建全局搜索处理器
+func NewSearchHandler(searchService *service.SearchAppService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search 全局搜索任务和项目
+// @Summary 全局搜索
+// @Description 按标题搜索任务、按名称搜索项目，返回带类型标记的统一结果列表，按相关度（完全匹配、前缀匹配、包含匹配）降序。非管理员只能搜到自己可访问的任务和项目
+// @Tags search
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string true "搜索关键字，至少2个字符"
+// @Param limit query int false "每种类型最多返回的数量，默认10，最大50"
+// @Success 200 {object} dto.SearchResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req dto.SearchRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+	req.RequesterID = c.GetString("user_id")
+	req.RequesterIsAdmin = isAdmin(c)
+
+	response, err := h.searchService.Search(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // 搜索临时处理器
 func SearchTasks(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Search tasks endpoint - to be implemented"})
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// searchAs 以指定用户请求全局搜索
+func searchAs(t *testing.T, userID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	binding.Validator = validation.NewStructValidator()
+
+	tasks := testutil.NewMemoryTaskRepository(
+		aggregate.TaskAggregate{ID: "task-1", Title: "Apollo kickoff", ProjectID: "project-1", CreatorID: "user-1"},
+		aggregate.TaskAggregate{ID: "task-2", Title: "Apollo budget", ProjectID: "project-2", CreatorID: "user-2"},
+	)
+	projects := testutil.NewMemoryProjectRepository(
+		*aggregate.NewProject("project-1", "Apollo", "", valueobject.ProjectTypeMaster, "user-1"),
+	)
+
+	router := gin.New()
+	router.GET("/search", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("user_roles", []string{"member"})
+		c.Next()
+	}, NewSearchHandler(service.NewSearchAppService(tasks, projects)).Search)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search"+query, nil))
+	return w
+}
+
+func TestSearch_ReturnsAccessibleTasksAndProjects(t *testing.T) {
+	w := searchAs(t, "user-1", "?q=Apollo")
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp dto.SearchResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, dto.SearchResultTypeProject, resp.Results[0].Type)
+	assert.Equal(t, "project-1", resp.Results[0].ID)
+	assert.Equal(t, dto.SearchResultTypeTask, resp.Results[1].Type)
+	assert.Equal(t, "task-1", resp.Results[1].ID)
+}
+
+func TestSearch_RequiresQuery(t *testing.T) {
+	w := searchAs(t, "user-1", "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	// 去掉空白后过短的关键字同样拒绝，而不是返回全部结果
+	w = searchAs(t, "user-1", "?q=+a+")
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
@@ -1,30 +1,792 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/event"
+	apperrors "github.com/taskflow/pkg/errors"
 )
 
-// 任务相关临时处理器
-func ListTasks(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "List tasks endpoint - to be implemented"})
+// TaskHandler 任务处理器
+type TaskHandler struct {
+	taskAppService *service.TaskAppService
 }
 
-func CreateTask(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Create task endpoint - to be implemented"})
+// NewTaskHandler 创建任务处理器
+func NewTaskHandler(taskAppService *service.TaskAppService) *TaskHandler {
+	return &TaskHandler{
+		taskAppService: taskAppService,
+	}
 }
 
-func GetTask(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get task endpoint - to be implemented"})
+// RequestExtension 申请任务延期
+// @Summary 申请延期
+// @Description 为任务提交延期申请，同一任务同时只能有一个待审批的申请
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param request body dto.RequestExtensionRequest true "延期申请"
+// @Success 201 {object} dto.ExtensionRequestResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/extensions [post]
+func (h *TaskHandler) RequestExtension(c *gin.Context) {
+	var req dto.RequestExtensionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	req.TaskID = c.Param("id")
+	req.RequesterID = c.GetString("user_id")
+
+	response, err := h.taskAppService.RequestExtension(c.Request.Context(), req)
+	if err != nil {
+		if domainErrorCode(err) == "EXTENSION_ALREADY_PENDING" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if isNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
 }
 
-func UpdateTask(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Update task endpoint - to be implemented"})
+// ApproveExtension 批准任务的延期申请
+// @Summary 批准延期
+// @Description 批准待审批的延期申请并将任务截止日期改为申请的日期，仅任务创建者可以批准
+// @Tags tasks
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param ext_id path string true "延期申请ID"
+// @Success 200 {object} dto.ExtensionRequestResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/extensions/{ext_id}/approve [put]
+func (h *TaskHandler) ApproveExtension(c *gin.Context) {
+	response, err := h.taskAppService.ApproveExtension(c.Request.Context(), dto.ApproveExtensionRequest{
+		TaskID:      c.Param("id"),
+		ExtensionID: c.Param("ext_id"),
+		ApproverID:  c.GetString("user_id"),
+	})
+	if err != nil {
+		c.JSON(extensionDecisionStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
-func DeleteTask(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Delete task endpoint - to be implemented"})
+// RejectExtension 拒绝任务的延期申请
+// @Summary 拒绝延期
+// @Description 拒绝待审批的延期申请，任务截止日期保持不变，仅任务创建者可以拒绝
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param ext_id path string true "延期申请ID"
+// @Param request body dto.RejectExtensionRequest false "拒绝意见"
+// @Success 200 {object} dto.ExtensionRequestResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/extensions/{ext_id}/reject [put]
+func (h *TaskHandler) RejectExtension(c *gin.Context) {
+	var req dto.RejectExtensionRequest
+	// 请求体可以为空，此时不附带拒绝意见
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+	req.TaskID = c.Param("id")
+	req.ExtensionID = c.Param("ext_id")
+	req.RejectorID = c.GetString("user_id")
+
+	response, err := h.taskAppService.RejectExtension(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(extensionDecisionStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// extensionDecisionStatus 将延期审批的领域错误映射为HTTP状态码
+func extensionDecisionStatus(err error) int {
+	switch domainErrorCode(err) {
+	case "NO_APPROVE_PERMISSION", "NO_REJECT_PERMISSION":
+		return http.StatusForbidden
+	case "EXTENSION_NOT_FOUND":
+		return http.StatusNotFound
+	case "EXTENSION_NOT_PENDING":
+		return http.StatusConflict
+	default:
+		return errorStatus(err)
+	}
+}
+
+// GetTaskExtensions 获取任务的延期申请历史
+// @Summary 获取延期申请历史
+// @Description 按申请时间返回任务的全部延期申请及其当前状态、审批人和审批意见；仅任务可见用户和管理员可访问
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/extensions [get]
+func (h *TaskHandler) GetTaskExtensions(c *gin.Context) {
+	taskID := c.Param("id")
+	if taskID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task ID is required"})
+		return
+	}
+
+	extensions, err := h.taskAppService.GetTaskExtensions(c.Request.Context(), taskID, c.GetString("user_id"), isAdmin(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"extensions": extensions,
+		"total":      len(extensions),
+	})
+}
+
+// GetWorkSubmissions 获取任务的工作提交记录
+// @Summary 获取工作提交记录
+// @Description 按提交时间倒序返回任务的全部工作提交，包含提交人、内容、附件和审核状态；仅任务可见用户和管理员可访问
+// @Tags tasks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/work-submissions [get]
+func (h *TaskHandler) GetWorkSubmissions(c *gin.Context) {
+	submissions, err := h.taskAppService.GetWorkSubmissions(c.Request.Context(), c.Param("id"), c.GetString("user_id"), isAdmin(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"submissions": submissions,
+		"total":       len(submissions),
+	})
+}
+
+// SubmitWork 提交工作
+// @Summary 提交工作
+// @Description 以当前用户身份提交任务工作，hours_spent 累加到任务实际工时；任务开放协作时项目成员提交会自动加入为参与者，其他非参与者返回403
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Param request body dto.SubmitWorkBody true "提交工作请求"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/work-submissions [post]
+// @Router /api/v1/tasks/{id}/executions/{exec_id}/work [post]
+func (h *TaskHandler) SubmitWork(c *gin.Context) {
+	var body dto.SubmitWorkBody
+	if !bindJSON(c, &body) {
+		return
+	}
+
+	err := h.taskAppService.SubmitWork(c.Request.Context(), dto.SubmitWorkRequest{
+		TaskID:      c.Param("id"),
+		SubmitterID: c.GetString("user_id"),
+		WorkContent: body.WorkContent,
+		Attachments: body.Attachments,
+		HoursSpent:  body.HoursSpent,
+	})
+	if err != nil {
+		switch {
+		case domainErrorCode(err) == "NOT_PARTICIPANT":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case domainErrorCode(err) == "INVALID_HOURS":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "work submitted successfully"})
+}
+
+// GetTaskBundle 获取任务详情包
+// @Summary 获取任务详情包
+// @Description 一次返回任务及参与者、工作提交、延期申请和事件历史，用于离线审阅或支持升级；仅任务可见用户和管理员可访问，事件历史仅管理员可见且最多返回200条
+// @Tags tasks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Success 200 {object} dto.TaskBundleResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/bundle [get]
+func (h *TaskHandler) GetTaskBundle(c *gin.Context) {
+	bundle, err := h.taskAppService.GetTaskBundle(c.Request.Context(), c.Param("id"), c.GetString("user_id"), isAdmin(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// GetActionItems 获取当前用户需要处理的事项
+// @Summary 待处理事项
+// @Description 按处理类型分组返回当前用户负责的进行中任务、待其审核的工作提交和待其审批的延期申请，按任务截止时间分页
+// @Tags tasks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码，从1开始"
+// @Param page_size query int false "每页任务数，默认20，最大100"
+// @Success 200 {object} dto.ActionItemsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/me/action-items [get]
+func (h *TaskHandler) GetActionItems(c *gin.Context) {
+	var req dto.ActionItemsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+	req.UserID = c.GetString("user_id")
+
+	response, err := h.taskAppService.GetActionItems(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteTask 删除任务
+// @Summary 删除任务
+// @Description 软删除单个任务，仅任务创建者和负责人可以删除；处于流转中（待审批、进行中、暂停）的任务需要指定force
+// @Tags tasks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Param force query bool false "强制删除流转中的任务"
+// @Success 204
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id} [delete]
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	var req dto.DeleteTaskRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+	req.TaskID = c.Param("id")
+	req.DeletedBy = c.GetString("user_id")
+
+	if err := h.taskAppService.DeleteTask(c.Request.Context(), req); err != nil {
+		status := errorStatus(err)
+		switch domainErrorCode(err) {
+		case "NO_DELETE_PERMISSION":
+			status = http.StatusForbidden
+		case "TASK_DELETE_REQUIRES_FORCE":
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// BulkDeleteTasks 批量删除任务
+// @Summary 批量删除任务
+// @Description 软删除多个任务并返回每个任务的处理结果。请求需携带确认令牌，缺少或不匹配时返回428及正确的令牌；任一任务处于流转中且未指定force时整批拒绝
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkDeleteTasksRequest true "批量删除请求"
+// @Success 200 {object} dto.BulkDeleteTasksResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 428 {object} map[string]interface{}
+// @Router /api/v1/tasks/bulk [delete]
+func (h *TaskHandler) BulkDeleteTasks(c *gin.Context) {
+	var req dto.BulkDeleteTasksRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	req.DeletedBy = c.GetString("user_id")
+
+	response, err := h.taskAppService.BulkDeleteTasks(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBulkDeleteNotConfirmed):
+			c.JSON(http.StatusPreconditionRequired, gin.H{
+				"error":              err.Error(),
+				"confirmation_token": service.BulkDeleteConfirmationToken(req.TaskIDs),
+			})
+		case domainErrorCode(err) == "TASK_DELETE_REQUIRES_FORCE":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// BulkTagTasks 批量添加和移除任务标签
+// @Summary 批量打标签
+// @Description 在一个事务中为多个任务添加和移除标签并返回每个任务的处理结果，非管理员只能修改自己创建或负责的任务，其余任务记为 forbidden；标签去除首尾空白后不能为空、重复或超过50个字符，同一标签不能同时添加和移除
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkTagTasksRequest true "批量打标签请求"
+// @Success 200 {object} dto.BulkTagTasksResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/bulk-tag [post]
+func (h *TaskHandler) BulkTagTasks(c *gin.Context) {
+	var req dto.BulkTagTasksRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	req.TaggedBy = c.GetString("user_id")
+	req.RequesterIsAdmin = isAdmin(c)
+
+	response, err := h.taskAppService.BulkTagTasks(c.Request.Context(), req)
+	if err != nil {
+		if domainErrorCode(err) == "INVALID_TAG" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListTasks 分页获取任务列表
+// @Summary 任务列表
+// @Description 按条件分页查询任务，page_size 默认20、最大100；非管理员只能看到自己创建、负责或参与的任务
+// @Tags tasks
+// @Produce json
+// @Param project_id query string false "项目ID"
+// @Param status query string false "任务状态"
+// @Param priority query string false "优先级"
+// @Param page query int false "页码，从1开始"
+// @Param page_size query int false "每页数量"
+// @Success 200 {object} dto.ListTasksResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks [get]
+func (h *TaskHandler) ListTasks(c *gin.Context) {
+	var req dto.ListTasksRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+	req.RequesterID = c.GetString("user_id")
+	req.RequesterIsAdmin = isAdmin(c)
+
+	response, err := h.taskAppService.ListTasks(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetStaleApprovedTasks 获取已审批但未开始的任务
+// @Summary 已审批未开始任务报表
+// @Description 返回审批通过超过指定时长仍未开始的任务，按审批时间升序。未指定时长时使用配置的默认值，仅经理及以上角色可访问
+// @Tags tasks
+// @Produce json
+// @Param project_id query string false "项目ID"
+// @Param min_age_hours query int false "审批通过后的最小时长（小时）"
+// @Success 200 {object} dto.StaleApprovedTasksResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/reports/approved-not-started [get]
+func (h *TaskHandler) GetStaleApprovedTasks(c *gin.Context) {
+	if !isManagerOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only managers can view task reports"})
+		return
+	}
+
+	var req dto.StaleApprovedTasksRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	response, err := h.taskAppService.GetStaleApprovedTasks(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTaskStatusDurations 获取项目任务在各状态的停留时长
+// @Summary 任务状态停留时长报表
+// @Description 按状态统计项目内任务数量，以及任务在当前状态的平均和最长停留时长，仅经理及以上角色可访问
+// @Tags tasks
+// @Produce json
+// @Param project_id query string true "项目ID"
+// @Success 200 {object} dto.TaskStatusDurationsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/reports/status-durations [get]
+func (h *TaskHandler) GetTaskStatusDurations(c *gin.Context) {
+	if !isManagerOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only managers can view task reports"})
+		return
+	}
+
+	var req dto.TaskStatusDurationsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	response, err := h.taskAppService.GetTaskStatusDurations(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetEstimateVariance 获取预估与实际工时偏差报表
+// @Summary 工时偏差报表
+// @Description 返回同时记录了预估和实际工时的任务的工时偏差，标记超出预估比例大于阈值的任务，并按项目和负责人汇总。未指定阈值时使用配置的默认值，仅经理及以上角色可访问
+// @Tags tasks
+// @Produce json
+// @Param project_id query string false "项目ID"
+// @Param responsible_id query string false "负责人ID"
+// @Param overrun_threshold_percent query number false "超支阈值（百分比）"
+// @Success 200 {object} dto.EstimateVarianceResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/reports/estimate-variance [get]
+func (h *TaskHandler) GetEstimateVariance(c *gin.Context) {
+	if !isManagerOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only managers can view task reports"})
+		return
+	}
+
+	var req dto.EstimateVarianceRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	response, err := h.taskAppService.GetEstimateVariance(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetProjectTaskStatistics 获取项目任务统计
+// @Summary 项目任务统计
+// @Description 按状态、优先级、类型统计项目内的任务数量，并返回逾期数量和完成率，统计在数据库中聚合完成，仅经理及以上角色可访问
+// @Tags tasks
+// @Produce json
+// @Param id path string true "项目ID"
+// @Success 200 {object} dto.ProjectTaskStatisticsResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/projects/{id}/tasks/statistics [get]
+func (h *TaskHandler) GetProjectTaskStatistics(c *gin.Context) {
+	if !isManagerOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only managers can view task reports"})
+		return
+	}
+
+	response, err := h.taskAppService.GetProjectTaskStatistics(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetProjectsTaskStatistics 批量获取项目任务统计
+// @Summary 批量项目任务统计
+// @Description 一次获取多个项目的任务统计（最多100个），按项目ID返回与单项目统计相同的结构，没有任务的项目返回空统计；所有项目在一次分组查询中完成统计，仅经理及以上角色可访问
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body dto.BatchProjectTaskStatisticsRequest true "项目ID列表"
+// @Success 200 {object} dto.BatchProjectTaskStatisticsResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/projects/statistics/batch [post]
+func (h *TaskHandler) GetProjectsTaskStatistics(c *gin.Context) {
+	if !isManagerOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only managers can view task reports"})
+		return
+	}
+
+	var req dto.BatchProjectTaskStatisticsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.taskAppService.GetProjectsTaskStatistics(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddTaskParticipant 添加任务参与者
+// @Summary 添加任务参与者
+// @Description 以指定角色（executor、reviewer、observer、assistant）添加参与者，未指定时为执行者；用户已是参与者时返回409且不改变其角色。只有任务创建人或负责人可以添加参与者，审核者角色只能由任务创建人授予，参与者用户不存在时返回404
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param request body dto.AddTaskParticipantBody true "添加参与者请求"
+// @Success 201 {object} dto.TaskParticipantDTO
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/participants [post]
+func (h *TaskHandler) AddTaskParticipant(c *gin.Context) {
+	var body dto.AddTaskParticipantBody
+	if !bindJSON(c, &body) {
+		return
+	}
+
+	response, err := h.taskAppService.AddTaskParticipant(c.Request.Context(), dto.AddTaskParticipantRequest{
+		TaskID:        c.Param("id"),
+		ParticipantID: body.ParticipantID,
+		Role:          body.Role,
+		AddedBy:       c.GetString("user_id"),
+	})
+	if err != nil {
+		switch {
+		case domainErrorCode(err) == "INVALID_PARTICIPANT_ROLE":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case domainErrorCode(err) == "PARTICIPANT_LIMIT_EXCEEDED", domainErrorCode(err) == "ALREADY_PARTICIPANT":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateTask 更新任务
+// @Summary 更新任务
+// @Description 更新任务的标题、描述、优先级、预估工时、开始日期和截止日期，未提供的字段保持不变；开始日期不能晚于截止日期，仅任务创建者和负责人可以更新
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Param request body dto.UpdateTaskRequest true "更新任务请求"
+// @Success 200 {object} dto.UpdateTaskResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id} [put]
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	var req dto.UpdateTaskRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	req.ID = c.Param("id")
+	req.UpdatedBy = c.GetString("user_id")
+
+	response, err := h.taskAppService.UpdateTask(c.Request.Context(), req)
+	if err != nil {
+		// 标题、描述超长按字段返回
+		if fields, ok := violationFieldErrors(err); ok {
+			apperrors.RespondWithValidationError(c, fields)
+			return
+		}
+		switch domainErrorCode(err) {
+		case "EMPTY_TITLE", "TASK_START_AFTER_DUE", "RECURRENCE_ANCHOR_REQUIRED":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ChangeTaskType 变更任务类型
+// @Summary 变更任务类型
+// @Description 变更任务类型（regular、recurring、template、urgent）。变更为重复任务时必须提供重复规则，已完成或已取消的任务不能变更为重复任务；变更为常规或紧急任务时清除重复规则
+// @Tags tasks
+// @Accept json
+// @Produce json,application/x-protobuf,application/x-msgpack
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Param request body dto.ChangeTaskTypeRequest true "变更任务类型请求"
+// @Success 200 {object} dto.TaskResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/type [put]
+func (h *TaskHandler) ChangeTaskType(c *gin.Context) {
+	var req dto.ChangeTaskTypeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	req.TaskID = c.Param("id")
+	req.ChangedBy = c.GetString("user_id")
+
+	response, err := h.taskAppService.ChangeTaskType(c.Request.Context(), req)
+	if err != nil {
+		switch domainErrorCode(err) {
+		case "INVALID_TASK_TYPE", "RECURRENCE_RULE_REQUIRED", "INVALID_RECURRENCE_RULE", "RECURRENCE_ANCHOR_REQUIRED":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "NO_MODIFY_PERMISSION":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "TASK_CLOSED":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, response)
+}
+
+// PreviewRecurrence 预览重复任务的执行时间
+// @Summary 预览重复任务执行时间
+// @Description 按重复规则计算接下来的执行时间，不保存任何修改；未提供规则或锚点日期时使用任务当前的规则和开始日期或截止日期，结束日期或最大执行次数会提前截止预览；仅任务可见用户和管理员可访问
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "任务ID"
+// @Param request body dto.PreviewRecurrenceRequest false "预览请求"
+// @Success 200 {object} dto.RecurrencePreviewResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/recurrence/preview [post]
+func (h *TaskHandler) PreviewRecurrence(c *gin.Context) {
+	var req dto.PreviewRecurrenceRequest
+	// 请求体可以为空，此时按任务当前的规则预览
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+	req.TaskID = c.Param("id")
+	req.ViewerID = c.GetString("user_id")
+	req.IsAdmin = isAdmin(c)
+
+	response, err := h.taskAppService.PreviewRecurrence(c.Request.Context(), req)
+	if err != nil {
+		switch domainErrorCode(err) {
+		case "RECURRENCE_RULE_REQUIRED", "INVALID_RECURRENCE_RULE", "RECURRENCE_ANCHOR_REQUIRED":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, response)
+}
+
+// CloneTask 复制任务
+// @Summary 复制任务
+// @Description 以当前用户为创建人复制任务的标题、描述、类型、优先级和预估工时，新任务处于草稿状态；只能复制自己可查看的任务，管理员除外；可复制到其他项目并可选复制参与者，不复制状态历史、实际工时和完成情况
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "源任务ID"
+// @Param request body dto.CloneTaskRequest false "复制选项"
+// @Success 201 {object} dto.CreateTaskResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/tasks/{id}/clone [post]
+func (h *TaskHandler) CloneTask(c *gin.Context) {
+	var req dto.CloneTaskRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+	req.TaskID = c.Param("id")
+	req.CallerID = c.GetString("user_id")
+	req.IsAdmin = isAdmin(c)
+
+	response, err := h.taskAppService.CloneTask(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case isDomainErrorType(err, event.ErrInvalidState):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// 任务相关临时处理器
+func CreateTask(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"message": "Create task endpoint - to be implemented"})
+}
+
+func GetTask(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"message": "Get task endpoint - to be implemented"})
 }
 
 func SubmitTask(c *gin.Context) {
@@ -47,10 +809,6 @@ func GetTaskParticipants(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Get task participants endpoint - to be implemented"})
 }
 
-func AddTaskParticipant(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Add task participant endpoint - to be implemented"})
-}
-
 func RemoveTaskParticipant(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Remove task participant endpoint - to be implemented"})
 }
@@ -64,7 +822,7 @@ func GetTaskExecutions(c *gin.Context) {
 }
 
 func SubmitWork(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Submit work endpoint - to be implemented"})
+	c.JSON(http.StatusNotImplemented, gin.H{"message": "Please use TaskHandler.SubmitWork instead"})
 }
 
 func ReviewWork(c *gin.Context) {
@@ -72,17 +830,9 @@ func ReviewWork(c *gin.Context) {
 }
 
 func RequestExtension(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Request extension endpoint - to be implemented"})
+	c.JSON(http.StatusNotImplemented, gin.H{"message": "Please use TaskHandler.RequestExtension instead"})
 }
 
 func GetTaskExtensions(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get task extensions endpoint - to be implemented"})
-}
-
-func ApproveExtension(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Approve extension endpoint - to be implemented"})
-}
-
-func RejectExtension(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Reject extension endpoint - to be implemented"})
+	c.JSON(http.StatusNotImplemented, gin.H{"message": "Please use TaskHandler.GetTaskExtensions instead"})
 }
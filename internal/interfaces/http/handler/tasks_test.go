@@ -0,0 +1,877 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/repository"
+	domainService "github.com/taskflow/internal/domain/service"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/messaging/memory"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// failingTaskRepository 查询任务时返回数据库错误
+type failingTaskRepository struct {
+	repository.TaskRepository
+}
+
+func (failingTaskRepository) FindByID(context.Context, valueobject.TaskID) (*aggregate.TaskAggregate, error) {
+	return nil, errors.New("connection refused")
+}
+
+// getTaskExtensions 通过路由请求任务的延期申请历史
+func getTaskExtensions(t *testing.T, repo repository.TaskRepository, callerID, taskID string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	h := NewTaskHandler(service.NewTaskAppService(nil, nil, repo, nil))
+	router := gin.New()
+	router.GET("/tasks/:id/extensions", func(c *gin.Context) {
+		c.Set("user_id", callerID)
+		h.GetTaskExtensions(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/"+taskID+"/extensions", nil))
+	return w
+}
+
+func TestGetTaskExtensions_MissingTaskReturns404(t *testing.T) {
+	w := getTaskExtensions(t, testutil.NewMemoryTaskRepository(), "user-1", "task-missing")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetTaskExtensions_RepositoryFailureReturns500(t *testing.T) {
+	w := getTaskExtensions(t, failingTaskRepository{}, "user-1", "task-1")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGetTaskExtensions_HiddenTaskReturns403(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{ID: "task-1", CreatorID: "creator-1", ResponsibleID: "responsible-1"})
+
+	assert.Equal(t, http.StatusForbidden, getTaskExtensions(t, repo, "outsider", "task-1").Code)
+	assert.Equal(t, http.StatusOK, getTaskExtensions(t, repo, "responsible-1", "task-1").Code)
+}
+
+func TestGetProjectTaskStatistics_ManagerGetsGroupedCounts(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	tasks := make([]aggregate.TaskAggregate, 0, 3)
+	for i, status := range []valueobject.TaskStatus{
+		valueobject.TaskStatusCompleted, valueobject.TaskStatusInProgress, valueobject.TaskStatusInProgress,
+	} {
+		tasks = append(tasks, aggregate.TaskAggregate{
+			ID:        valueobject.TaskID(fmt.Sprintf("task-%d", i)),
+			ProjectID: "project-1",
+			Status:    status,
+			Priority:  valueobject.TaskPriorityHigh,
+			TaskType:  valueobject.TaskTypeRegular,
+		})
+	}
+	h := NewTaskHandler(service.NewTaskAppService(nil, nil, testutil.NewMemoryTaskRepository(tasks...), nil))
+
+	for _, tc := range []struct {
+		roles []string
+		code  int
+	}{
+		{[]string{"member"}, http.StatusForbidden},
+		{[]string{"manager"}, http.StatusOK},
+	} {
+		router := gin.New()
+		router.Use(func(c *gin.Context) { c.Set("user_roles", tc.roles); c.Next() })
+		router.GET("/projects/:id/tasks/statistics", h.GetProjectTaskStatistics)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/projects/project-1/tasks/statistics", nil))
+		require.Equal(t, tc.code, w.Code, w.Body.String())
+		if tc.code != http.StatusOK {
+			continue
+		}
+
+		var resp dto.ProjectTaskStatisticsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "project-1", resp.ProjectID)
+		assert.Equal(t, 3, resp.TotalTasks)
+		assert.Equal(t, map[string]int{"completed": 1, "in_progress": 2}, resp.TasksByStatus)
+		assert.Equal(t, map[string]int{"high": 3}, resp.TasksByPriority)
+		assert.InDelta(t, 100.0/3, resp.CompletionRate, 1e-9)
+	}
+}
+
+func TestGetProjectsTaskStatistics_BatchAndCap(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	repo := testutil.NewMemoryTaskRepository(
+		aggregate.TaskAggregate{ID: "task-1", ProjectID: "project-1", Status: valueobject.TaskStatusCompleted},
+		aggregate.TaskAggregate{ID: "task-2", ProjectID: "project-1", Status: valueobject.TaskStatusInProgress},
+		aggregate.TaskAggregate{ID: "task-3", ProjectID: "project-2", Status: valueobject.TaskStatusDraft},
+	)
+	h := NewTaskHandler(service.NewTaskAppService(nil, nil, repo, nil))
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("project-%d", i)
+	}
+	tooManyBody, err := json.Marshal(dto.BatchProjectTaskStatisticsRequest{ProjectIDs: tooMany})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		roles []string
+		body  string
+		code  int
+	}{
+		{[]string{"member"}, `{"project_ids":["project-1"]}`, http.StatusForbidden},
+		{[]string{"manager"}, `{"project_ids":[]}`, http.StatusBadRequest},
+		{[]string{"manager"}, string(tooManyBody), http.StatusBadRequest},
+		{[]string{"manager"}, `{"project_ids":["project-1","project-2","project-empty","project-1"]}`, http.StatusOK},
+	} {
+		router := gin.New()
+		router.Use(func(c *gin.Context) { c.Set("user_roles", tc.roles); c.Next() })
+		router.POST("/projects/statistics/batch", h.GetProjectsTaskStatistics)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/projects/statistics/batch", strings.NewReader(tc.body)))
+		require.Equal(t, tc.code, w.Code, w.Body.String())
+		if tc.code != http.StatusOK {
+			continue
+		}
+
+		var resp dto.BatchProjectTaskStatisticsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Statistics, 3)
+		assert.Equal(t, 2, resp.Statistics["project-1"].TotalTasks)
+		assert.InDelta(t, 50.0, resp.Statistics["project-1"].CompletionRate, 1e-9)
+		assert.Equal(t, map[string]int{"draft": 1}, resp.Statistics["project-2"].TasksByStatus)
+		assert.Zero(t, resp.Statistics["project-empty"].TotalTasks)
+	}
+}
+
+func TestGetEstimateVariance_ManagerGetsFlaggedTasks(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	repo := testutil.NewMemoryTaskRepository(
+		aggregate.TaskAggregate{ID: "task-overrun", ProjectID: "project-1", EstimatedHours: 8, ActualHours: 12},
+		aggregate.TaskAggregate{ID: "task-within", ProjectID: "project-1", EstimatedHours: 8, ActualHours: 8.5},
+	)
+	h := NewTaskHandler(service.NewTaskAppService(nil, nil, repo, nil))
+
+	for _, tc := range []struct {
+		roles []string
+		query string
+		code  int
+	}{
+		{[]string{"member"}, "", http.StatusForbidden},
+		{[]string{"manager"}, "?overrun_threshold_percent=-5", http.StatusBadRequest},
+		{[]string{"manager"}, "?project_id=project-1", http.StatusOK},
+	} {
+		router := gin.New()
+		router.Use(func(c *gin.Context) { c.Set("user_roles", tc.roles); c.Next() })
+		router.GET("/tasks/reports/estimate-variance", h.GetEstimateVariance)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/reports/estimate-variance"+tc.query, nil))
+		require.Equal(t, tc.code, w.Code, w.Body.String())
+		if tc.code != http.StatusOK {
+			continue
+		}
+
+		var resp dto.EstimateVarianceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Tasks, 2)
+		assert.Equal(t, "task-overrun", resp.Tasks[0].TaskID)
+		assert.True(t, resp.Tasks[0].Overrun)
+		assert.False(t, resp.Tasks[1].Overrun)
+		assert.Equal(t, 1, resp.OverrunCount)
+	}
+}
+
+// passthroughTransactionManager 直接执行回调的事务管理器
+type passthroughTransactionManager struct{}
+
+func (passthroughTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTransactionManager) WithTransactionResult(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return fn(ctx)
+}
+
+// postTaskParticipant 以指定用户身份通过路由为任务添加参与者，用户 user-1 已存在
+func postTaskParticipant(t *testing.T, repo repository.TaskRepository, callerID, taskID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	users := testutil.NewMemoryUserRepository(aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee))
+	taskDomainService := domainService.NewTaskDomainService(repo, users, testutil.NewMemoryProjectRepository())
+	h := NewTaskHandler(service.NewTaskAppService(taskDomainService, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)))
+	router := gin.New()
+	router.POST("/tasks/:id/participants", func(c *gin.Context) {
+		c.Set("user_id", callerID)
+		h.AddTaskParticipant(c)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/tasks/"+taskID+"/participants", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddTaskParticipant_AcceptsRole(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", Status: valueobject.TaskStatusInProgress,
+	})
+
+	w := postTaskParticipant(t, repo, "creator-1", "task-1", `{"participant_id":"user-1","role":"reviewer"}`)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var participant dto.TaskParticipantDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &participant))
+	assert.Equal(t, "user-1", participant.UserID)
+	assert.Equal(t, "reviewer", participant.Role)
+	assert.Equal(t, "creator-1", participant.AddedBy)
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	role := stored.GetParticipantRole("user-1")
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ParticipantRoleReviewer, *role)
+}
+
+func TestAddTaskParticipant_ExistingParticipantReturns409(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", Status: valueobject.TaskStatusInProgress,
+	})
+	w := postTaskParticipant(t, repo, "creator-1", "task-1", `{"participant_id":"user-1"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = postTaskParticipant(t, repo, "creator-1", "task-1", `{"participant_id":"user-1","role":"reviewer"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	role := stored.GetParticipantRole("user-1")
+	require.NotNil(t, role)
+	assert.Equal(t, valueobject.ParticipantRoleExecutor, *role, "the existing role is kept")
+}
+
+func TestAddTaskParticipant_RejectsUnknownRole(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1"})
+
+	w := postTaskParticipant(t, repo, "creator-1", "task-1", `{"participant_id":"user-1","role":"owner"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAddTaskParticipant_MissingTaskReturns404(t *testing.T) {
+	w := postTaskParticipant(t, testutil.NewMemoryTaskRepository(), "creator-1", "task-missing", `{"participant_id":"user-1"}`)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddTaskParticipant_ChecksCallerAndParticipant(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "lead-1", Status: valueobject.TaskStatusInProgress,
+	})
+
+	// 与任务无关的用户不能把自己加为参与者
+	w := postTaskParticipant(t, repo, "user-1", "task-1", `{"participant_id":"user-1"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	// 负责人不能授予审核者角色
+	w = postTaskParticipant(t, repo, "lead-1", "task-1", `{"participant_id":"user-1","role":"reviewer"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	// 参与者用户不存在
+	w = postTaskParticipant(t, repo, "creator-1", "task-1", `{"participant_id":"ghost"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Empty(t, stored.Participants)
+}
+
+// putTaskType 以任务创建人身份变更任务类型
+func putTaskType(t *testing.T, repo repository.TaskRepository, taskID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)))
+	router := gin.New()
+	router.PUT("/tasks/:id/type", func(c *gin.Context) {
+		c.Set("user_id", "creator-1")
+		h.ChangeTaskType(c)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/tasks/"+taskID+"/type", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestChangeTaskType_RegularToRecurringRequiresRule(t *testing.T) {
+	due := time.Date(2024, 6, 10, 18, 0, 0, 0, time.UTC)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", TaskType: valueobject.TaskTypeRegular, CreatorID: "creator-1", Status: valueobject.TaskStatusInProgress, DueDate: &due,
+	}, aggregate.TaskAggregate{
+		ID: "task-2", TaskType: valueobject.TaskTypeRegular, CreatorID: "creator-1", Status: valueobject.TaskStatusInProgress,
+	})
+
+	w := putTaskType(t, repo, "task-1", `{"task_type":"recurring"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	// 没有开始日期和截止日期的任务缺少重复锚点
+	w = putTaskType(t, repo, "task-2", `{"task_type":"recurring","recurrence_rule":{"frequency":"weekly","interval_value":2}}`)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = putTaskType(t, repo, "task-1", `{"task_type":"recurring","recurrence_rule":{"frequency":"weekly","interval_value":2}}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response dto.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "recurring", response.TaskType)
+	require.NotNil(t, response.RecurrenceRule)
+	assert.Equal(t, "weekly", response.RecurrenceRule.Frequency)
+	assert.Equal(t, 2, response.RecurrenceRule.IntervalValue)
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TaskTypeRecurring, stored.TaskType)
+}
+
+func TestChangeTaskType_CompletedTaskCannotBecomeRecurring(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", TaskType: valueobject.TaskTypeRegular, CreatorID: "creator-1", Status: valueobject.TaskStatusCompleted,
+	})
+
+	w := putTaskType(t, repo, "task-1", `{"task_type":"recurring","recurrence_rule":{"frequency":"daily","interval_value":1}}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TaskTypeRegular, stored.TaskType)
+}
+
+// postRecurrencePreview 以指定用户身份预览任务的重复执行时间
+func postRecurrencePreview(t *testing.T, repo repository.TaskRepository, taskID, userID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)))
+	router := gin.New()
+	router.POST("/tasks/:id/recurrence/preview", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		h.PreviewRecurrence(c)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/tasks/"+taskID+"/recurrence/preview", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// previewExecutions 解析预览响应中的执行时间
+func previewExecutions(t *testing.T, w *httptest.ResponseRecorder) []time.Time {
+	t.Helper()
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response dto.RecurrencePreviewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	for i := range response.Executions {
+		response.Executions[i] = response.Executions[i].UTC()
+	}
+	return response.Executions
+}
+
+func TestPreviewRecurrence_ComputesFromAnchorWithoutSaving(t *testing.T) {
+	// 锚点在未来，预览从锚点本身开始
+	due := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", TaskType: valueobject.TaskTypeRegular, CreatorID: "creator-1", DueDate: &due,
+	})
+
+	weekly := previewExecutions(t, postRecurrencePreview(t, repo, "task-1", "creator-1",
+		`{"recurrence_rule":{"frequency":"weekly","interval_value":2},"count":3}`))
+	assert.Equal(t, []time.Time{due, due.AddDate(0, 0, 14), due.AddDate(0, 0, 28)}, weekly)
+
+	monthly := previewExecutions(t, postRecurrencePreview(t, repo, "task-1", "creator-1",
+		`{"recurrence_rule":{"frequency":"monthly","interval_value":1}}`))
+	assert.Equal(t, []time.Time{
+		due,
+		time.Date(2030, 2, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2030, 3, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2030, 4, 30, 9, 0, 0, 0, time.UTC),
+		time.Date(2030, 5, 31, 9, 0, 0, 0, time.UTC),
+	}, monthly, "defaults to five executions, clamped to the month end")
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TaskTypeRegular, stored.TaskType)
+	assert.Nil(t, stored.RecurrenceRule, "previewing must not persist the rule")
+}
+
+func TestPreviewRecurrence_CutoffTruncatesPreview(t *testing.T) {
+	start := time.Date(2030, 3, 4, 9, 0, 0, 0, time.UTC)
+	maxExecutions := 3
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", TaskType: valueobject.TaskTypeRecurring, CreatorID: "creator-1", StartDate: &start,
+		RecurrenceRule: &valueobject.RecurrenceRule{Frequency: valueobject.RecurrenceDaily, IntervalValue: 1, MaxExecutions: &maxExecutions},
+	})
+
+	// 不带请求体时使用任务当前的规则
+	assert.Len(t, previewExecutions(t, postRecurrencePreview(t, repo, "task-1", "creator-1", "")), 3)
+
+	executions := previewExecutions(t, postRecurrencePreview(t, repo, "task-1", "creator-1",
+		`{"recurrence_rule":{"frequency":"weekly","interval_value":1,"end_date":"2030-03-20T00:00:00Z"},"count":10}`))
+	assert.Equal(t, []time.Time{start, start.AddDate(0, 0, 7), start.AddDate(0, 0, 14)}, executions)
+}
+
+func TestPreviewRecurrence_RejectsInvalidRequests(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", TaskType: valueobject.TaskTypeRegular, CreatorID: "creator-1",
+	})
+	rule := `{"recurrence_rule":{"frequency":"weekly","interval_value":1}}`
+
+	assert.Equal(t, http.StatusBadRequest, postRecurrencePreview(t, repo, "task-1", "creator-1", rule).Code, "no anchor date")
+	assert.Equal(t, http.StatusBadRequest, postRecurrencePreview(t, repo, "task-1", "creator-1", "").Code, "no rule")
+	assert.Equal(t, http.StatusBadRequest, postRecurrencePreview(t, repo, "task-1", "creator-1",
+		`{"recurrence_rule":{"frequency":"weekly","interval_value":1},"anchor_date":"2030-01-01T00:00:00Z","count":51}`).Code, "count over the limit")
+	assert.Equal(t, http.StatusForbidden, postRecurrencePreview(t, repo, "task-1", "outsider",
+		`{"recurrence_rule":{"frequency":"weekly","interval_value":1},"anchor_date":"2030-01-01T00:00:00Z"}`).Code)
+	assert.Equal(t, http.StatusOK, postRecurrencePreview(t, repo, "task-1", "creator-1",
+		`{"recurrence_rule":{"frequency":"weekly","interval_value":1},"anchor_date":"2030-01-01T00:00:00Z"}`).Code, "explicit anchor date")
+}
+
+func TestPreviewRecurrence_RejectsHugeInterval(t *testing.T) {
+	due := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", TaskType: valueobject.TaskTypeRegular, CreatorID: "creator-1", DueDate: &due,
+	}, aggregate.TaskAggregate{
+		ID: "task-2", TaskType: valueobject.TaskTypeRecurring, CreatorID: "creator-1", DueDate: &due,
+		RecurrenceRule: &valueobject.RecurrenceRule{Frequency: valueobject.RecurrenceYearly, IntervalValue: 1 << 51},
+	})
+
+	w := postRecurrencePreview(t, repo, "task-1", "creator-1",
+		`{"recurrence_rule":{"frequency":"yearly","interval_value":2251799813685248},"anchor_date":"2020-01-01T00:00:00Z"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	// 已保存的规则同样在预览前校验
+	w = postRecurrencePreview(t, repo, "task-2", "creator-1", `{"anchor_date":"2020-01-01T00:00:00Z"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
+
+// postCloneTask 以指定用户身份复制任务到 project-mine，该项目由 attacker-1 和 admin-1 共同可访问
+func postCloneTask(t *testing.T, repo *testutil.MemoryTaskRepository, userID string, roles []string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	target := aggregate.NewProject("project-mine", "Mine", "", valueobject.ProjectTypeMaster, "attacker-1")
+	target.Status = valueobject.ProjectStatusActive
+	require.NoError(t, target.AddMember("admin-1", valueobject.ProjectRoleMember, "attacker-1"))
+	projects := testutil.NewMemoryProjectRepository(*target)
+	svc := service.NewTaskAppService(domainService.NewTaskDomainService(repo, nil, projects), passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(validation.NewTaskValidator(), nil))
+	h := NewTaskHandler(svc)
+	router := gin.New()
+	router.POST("/tasks/:id/clone", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("user_roles", roles)
+		h.CloneTask(c)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/tasks/source-1/clone", strings.NewReader(`{"project_id":"project-mine","include_participants":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCloneTask_RejectsCallerWhoCannotViewSource(t *testing.T) {
+	description := "confidential"
+	due := time.Now().Add(72 * time.Hour)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "source-1", Title: "Secret plan", Description: &description, DueDate: &due, TaskType: valueobject.TaskTypeRegular,
+		Priority: valueobject.TaskPriorityMedium, Status: valueobject.TaskStatusInProgress,
+		ProjectID: "project-victim", CreatorID: "victim-1", ResponsibleID: "victim-1",
+		Participants: []valueobject.TaskParticipant{{UserID: "victim-2", Role: valueobject.ParticipantRoleExecutor}},
+	})
+
+	w := postCloneTask(t, repo, "attacker-1", []string{"employee"})
+
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	count, err := repo.CountByProject(context.Background(), "project-mine")
+	require.NoError(t, err)
+	assert.Zero(t, count, "no copy must be created")
+
+	// 管理员可以复制任意任务
+	w = postCloneTask(t, repo, "admin-1", []string{"admin"})
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+// getWorkSubmissions 以指定用户身份请求任务的工作提交记录
+func getWorkSubmissions(t *testing.T, repo repository.TaskRepository, taskID, userID string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)))
+	router := gin.New()
+	router.GET("/tasks/:id/work-submissions", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		h.GetWorkSubmissions(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/"+taskID+"/work-submissions", nil))
+	return w
+}
+
+func TestGetWorkSubmissions_ListsSubmittedWorkNewestFirst(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+		Status: valueobject.TaskStatusInProgress,
+	})
+	svc := service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil))
+	for _, content := range []string{"first draft", "second draft"} {
+		require.NoError(t, svc.SubmitWork(context.Background(), dto.SubmitWorkRequest{
+			TaskID: "task-1", SubmitterID: "responsible-1", WorkContent: content, Attachments: []string{"file-1"},
+		}))
+	}
+
+	w := getWorkSubmissions(t, repo, "task-1", "creator-1")
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Submissions []dto.WorkSubmissionResponse `json:"submissions"`
+		Total       int                          `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, 2, body.Total)
+	assert.Equal(t, "second draft", body.Submissions[0].Content)
+	assert.Equal(t, "first draft", body.Submissions[1].Content)
+	assert.False(t, body.Submissions[0].SubmittedAt.Before(body.Submissions[1].SubmittedAt))
+	assert.Equal(t, "responsible-1", body.Submissions[0].AuthorID)
+	assert.Equal(t, []string{"file-1"}, body.Submissions[0].Attachments)
+	assert.Equal(t, "pending", body.Submissions[0].ReviewStatus)
+}
+
+func TestGetWorkSubmissions_ForbiddenForUsersWhoCannotViewTask(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+	})
+
+	w := getWorkSubmissions(t, repo, "task-1", "outsider-1")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGetWorkSubmissions_MissingTaskReturns404(t *testing.T) {
+	w := getWorkSubmissions(t, testutil.NewMemoryTaskRepository(), "task-missing", "creator-1")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// postWork 以指定用户身份提交任务工作
+func postWork(t *testing.T, repo repository.TaskRepository, target, userID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)))
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	router.POST("/tasks/:id/work-submissions", h.SubmitWork)
+	router.POST("/tasks/:id/executions/:exec_id/work", h.SubmitWork)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSubmitWork_LogsHoursOverHTTP(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+		Status: valueobject.TaskStatusInProgress,
+	})
+
+	w := postWork(t, repo, "/tasks/task-1/work-submissions", "responsible-1", `{"work_content":"draft","hours_spent":2.5}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = postWork(t, repo, "/tasks/task-1/executions/exec-1/work", "responsible-1", `{"work_content":"final","hours_spent":1}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	assert.InDelta(t, 3.5, stored.ActualHours, 1e-9)
+	require.Len(t, stored.WorkSubmissions, 2)
+	assert.Equal(t, valueobject.UserID("responsible-1"), stored.WorkSubmissions[0].SubmitterID)
+}
+
+func TestSubmitWork_RejectsOutsidersAndInvalidHours(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+		Status: valueobject.TaskStatusInProgress,
+	})
+
+	w := postWork(t, repo, "/tasks/task-1/work-submissions", "outsider-1", `{"work_content":"drive-by"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = postWork(t, repo, "/tasks/task-1/work-submissions", "responsible-1", `{"work_content":"draft","hours_spent":-1}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = postWork(t, repo, "/tasks/task-missing/work-submissions", "responsible-1", `{"work_content":"draft"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestApproveExtension_MovesDueDateForCreatorOnly(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	oldDueDate := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	newDueDate := oldDueDate.Add(72 * time.Hour)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+		Status: valueobject.TaskStatusInProgress, DueDate: &oldDueDate,
+		Extensions: []valueobject.ExtensionRequest{{
+			ID: "ext-1", TaskID: "task-1", RequesterID: "responsible-1", OriginalDueDate: oldDueDate,
+			RequestedDueDate: newDueDate, Status: valueobject.ExtensionStatusPending,
+		}},
+	})
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil))
+	approve := func(userID, target string) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.PUT("/tasks/:id/extensions/:ext_id/approve", h.ApproveExtension)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, target, nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, approve("responsible-1", "/tasks/task-1/extensions/ext-1/approve"))
+	assert.Equal(t, http.StatusNotFound, approve("creator-1", "/tasks/task-1/extensions/ext-missing/approve"))
+	assert.Equal(t, http.StatusOK, approve("creator-1", "/tasks/task-1/extensions/ext-1/approve"))
+	assert.Equal(t, http.StatusConflict, approve("creator-1", "/tasks/task-1/extensions/ext-1/approve"))
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored.DueDate)
+	assert.True(t, stored.DueDate.Equal(newDueDate))
+}
+
+func TestUpdateTask_ReschedulesForModifiersOnly(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	oldDueDate := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	newDueDate := oldDueDate.Add(48 * time.Hour)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1", Title: "Report",
+		Status: valueobject.TaskStatusInProgress, Priority: valueobject.TaskPriorityMedium, DueDate: &oldDueDate,
+	})
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)))
+	update := func(userID, body string) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.PUT("/tasks/:id", h.UpdateTask)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/tasks/task-1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	dueBody := fmt.Sprintf(`{"due_date":%q,"priority":"high"}`, newDueDate.Format(time.RFC3339))
+
+	assert.Equal(t, http.StatusForbidden, update("outsider-1", dueBody))
+	assert.Equal(t, http.StatusBadRequest, update("creator-1", `{"priority":"someday"}`))
+	assert.Equal(t, http.StatusBadRequest, update("creator-1", fmt.Sprintf(`{"start_date":%q}`, newDueDate.Format(time.RFC3339))))
+	assert.Equal(t, http.StatusOK, update("responsible-1", dueBody))
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored.DueDate)
+	assert.True(t, stored.DueDate.Equal(newDueDate))
+	assert.Equal(t, valueobject.TaskPriorityHigh, stored.Priority)
+	assert.Equal(t, "Report", stored.Title)
+}
+
+func TestDeleteTask_RequiresModifierAndForceForActiveTasks(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1", Title: "Report",
+		Status: valueobject.TaskStatusInProgress, Priority: valueobject.TaskPriorityMedium,
+	})
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, aggregate.NewTaskFactory(nil, nil)))
+	remove := func(userID, target string) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.DELETE("/tasks/:id", h.DeleteTask)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, target, nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, remove("outsider-1", "/tasks/task-1?force=true"))
+	assert.Equal(t, http.StatusConflict, remove("creator-1", "/tasks/task-1"))
+	assert.Equal(t, http.StatusNotFound, remove("creator-1", "/tasks/missing"))
+	assert.Equal(t, http.StatusNoContent, remove("responsible-1", "/tasks/task-1?force=true"))
+
+	_, err := repo.FindByID(context.Background(), "task-1")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestRejectExtension_KeepsDueDateAndRecordsComment(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	oldDueDate := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+		Status: valueobject.TaskStatusInProgress, DueDate: &oldDueDate,
+		Extensions: []valueobject.ExtensionRequest{{
+			ID: "ext-1", TaskID: "task-1", RequesterID: "responsible-1", OriginalDueDate: oldDueDate,
+			RequestedDueDate: oldDueDate.Add(72 * time.Hour), Status: valueobject.ExtensionStatusPending,
+		}},
+	})
+	h := NewTaskHandler(service.NewTaskAppService(nil, passthroughTransactionManager{}, repo, nil))
+	reject := func(userID, target, body string) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user_id", userID)
+			c.Next()
+		})
+		router.PUT("/tasks/:id/extensions/:ext_id/reject", h.RejectExtension)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, reject("responsible-1", "/tasks/task-1/extensions/ext-1/reject", ""))
+	assert.Equal(t, http.StatusNotFound, reject("creator-1", "/tasks/task-1/extensions/ext-missing/reject", ""))
+	assert.Equal(t, http.StatusOK, reject("creator-1", "/tasks/task-1/extensions/ext-1/reject", `{"comment":"scope is fixed"}`))
+	assert.Equal(t, http.StatusConflict, reject("creator-1", "/tasks/task-1/extensions/ext-1/reject", ""))
+
+	stored, err := repo.FindByID(context.Background(), "task-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored.DueDate)
+	assert.True(t, stored.DueDate.Equal(oldDueDate))
+	assert.Equal(t, valueobject.ExtensionStatusRejected, stored.Extensions[0].Status)
+	require.NotNil(t, stored.Extensions[0].ReviewComment)
+	assert.Equal(t, "scope is fixed", *stored.Extensions[0].ReviewComment)
+}
+
+func TestListTasks_PagesAndFiltersFromQuery(t *testing.T) {
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	tasks := make([]aggregate.TaskAggregate, 0, 26)
+	for i := 0; i < 25; i++ {
+		tasks = append(tasks, aggregate.TaskAggregate{
+			ID: valueobject.TaskID(fmt.Sprintf("task-%02d", i)), ProjectID: "project-1", CreatorID: "creator-1",
+			Status: valueobject.TaskStatusInProgress,
+		})
+	}
+	tasks = append(tasks, aggregate.TaskAggregate{ID: "other", ProjectID: "project-2", CreatorID: "creator-1", Status: valueobject.TaskStatusInProgress})
+	h := NewTaskHandler(service.NewTaskAppService(nil, nil, testutil.NewMemoryTaskRepository(tasks...), nil))
+	router := gin.New()
+	router.GET("/tasks", func(c *gin.Context) {
+		c.Set("user_id", "creator-1")
+		h.ListTasks(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks?project_id=project-1&page=2&page_size=10", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp dto.ListTasksResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Tasks, 10)
+	assert.EqualValues(t, 25, resp.Total)
+	assert.Equal(t, 3, resp.TotalPages)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks?page_size=1000", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// getTaskBundle 以指定用户身份和角色请求任务详情包
+func getTaskBundle(t *testing.T, svc *service.TaskAppService, taskID, userID string, roles ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/tasks/:id/bundle", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("user_roles", roles)
+		NewTaskHandler(svc).GetTaskBundle(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/"+taskID+"/bundle", nil))
+	return w
+}
+
+func TestGetTaskBundle_HistoryOnlyForAdmins(t *testing.T) {
+	repo := testutil.NewMemoryTaskRepository(aggregate.TaskAggregate{
+		ID: "task-1", ProjectID: "project-1", CreatorID: "creator-1", ResponsibleID: "responsible-1",
+		Status: valueobject.TaskStatusInProgress,
+	})
+	store := memory.NewInMemoryEventStore(0)
+	require.NoError(t, store.Save(event.NewTaskCompletedEvent("task-1", "responsible-1")))
+	svc := service.NewTaskAppService(nil, nil, repo, nil).WithEventStore(store)
+
+	w := getTaskBundle(t, svc, "task-1", "creator-1")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Contains(t, body, "task")
+	assert.Contains(t, body, "work_submissions")
+	assert.Contains(t, body, "extensions")
+	assert.NotContains(t, body, "history")
+
+	w = getTaskBundle(t, svc, "task-1", "admin-1", string(valueobject.UserRoleAdmin))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var bundle dto.TaskBundleResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bundle))
+	require.Len(t, bundle.History, 1)
+	assert.Equal(t, "TaskCompleted", bundle.History[0].Type)
+
+	assert.Equal(t, http.StatusForbidden, getTaskBundle(t, svc, "task-1", "outsider-1").Code)
+	assert.Equal(t, http.StatusNotFound, getTaskBundle(t, svc, "task-missing", "admin-1", string(valueobject.UserRoleAdmin)).Code)
+}
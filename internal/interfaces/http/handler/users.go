@@ -117,10 +117,14 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 	userResp, err := h.userService.GetUser(c.Request.Context(), userID)
 	if err != nil {
+		if isNotFound(err) {
+			errors.RespondWithError(c, http.StatusNotFound, "USER_NOT_FOUND", "用户不存在")
+			return
+		}
 		logger.Error("Failed to get user",
 			zap.String("user_id", userID),
 			zap.Error(err))
-		errors.RespondWithError(c, http.StatusNotFound, "USER_NOT_FOUND", "用户不存在")
+		errors.RespondWithError(c, http.StatusInternalServerError, "GET_USER_FAILED", "获取用户信息失败")
 		return
 	}
 
@@ -150,13 +154,16 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	var req service.UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		errors.RespondWithError(c, http.StatusBadRequest, "INVALID_REQUEST", "请求参数错误: "+err.Error())
+	if !bindJSON(c, &req) {
 		return
 	}
 
 	req.ID = userID
 	if err := h.userService.UpdateUserProfile(c.Request.Context(), &req); err != nil {
+		if isNotFound(err) {
+			errors.RespondWithError(c, http.StatusNotFound, "USER_NOT_FOUND", "用户不存在")
+			return
+		}
 		logger.Error("Failed to update user",
 			zap.String("user_id", userID),
 			zap.Error(err))
@@ -190,6 +197,10 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	err := h.userService.DeleteUser(c.Request.Context(), userID)
 	if err != nil {
+		if isNotFound(err) {
+			errors.RespondWithError(c, http.StatusNotFound, "USER_NOT_FOUND", "用户不存在")
+			return
+		}
 		logger.Error("Failed to delete user",
 			zap.String("user_id", userID),
 			zap.Error(err))
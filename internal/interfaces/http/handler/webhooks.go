@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/event"
+)
+
+// WebhookHandler webhook订阅管理处理器，仅管理员可访问
+type WebhookHandler struct {
+	webhookService *service.WebhookAppService
+}
+
+// NewWebhookHandler 创建webhook订阅管理处理器
+func NewWebhookHandler(webhookService *service.WebhookAppService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// requireAdmin 非管理员时写入403响应并返回 false
+func (h *WebhookHandler) requireAdmin(c *gin.Context) bool {
+	if !isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only admins can manage webhooks"})
+		return false
+	}
+	return true
+}
+
+// ListWebhooks 获取webhook订阅列表
+// @Summary 获取webhook订阅列表
+// @Description 获取全部webhook订阅，不返回签名密钥，仅管理员可访问
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} dto.WebhookResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	response, err := h.webhookService.ListWebhooks(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateWebhook 创建webhook订阅
+// @Summary 创建webhook订阅
+// @Description 订阅领域事件，匹配的事件以JSON POST推送到url，请求头 X-Taskflow-Signature 为 sha256=HMAC-SHA256(secret, body)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dto.CreateWebhookRequest true "创建webhook请求"
+// @Success 201 {object} dto.WebhookResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.webhookService.CreateWebhook(c.Request.Context(), req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetWebhook 获取webhook订阅
+// @Summary 获取webhook订阅
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	response, err := h.webhookService.GetWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateWebhook 更新webhook订阅
+// @Summary 更新webhook订阅
+// @Description 更新订阅地址和事件过滤；secret 为空时保留原密钥；active=true 重新启用被自动停用的webhook
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID"
+// @Param request body dto.UpdateWebhookRequest true "更新webhook请求"
+// @Success 200 {object} dto.WebhookResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	response, err := h.webhookService.UpdateWebhook(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook 删除webhook订阅
+// @Summary 删除webhook订阅
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries 获取webhook投递记录
+// @Summary 获取webhook投递记录
+// @Description 按投递时间倒序返回最近的投递尝试，用于排查订阅方故障
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "返回数量，默认50，最大500"
+// @Success 200 {array} dto.WebhookDeliveryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	var req dto.ListWebhookDeliveriesRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	response, err := h.webhookService.ListDeliveries(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RedeliverWebhook 重投webhook
+// @Summary 重投webhook
+// @Description 以原投递ID重新发送一次原始请求体，请求头 X-Taskflow-Redelivery 为 true，订阅方可据 X-Taskflow-Delivery 去重
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID"
+// @Param delivery_id path string true "投递ID"
+// @Success 200 {object} dto.WebhookDeliveryResponse
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	response, err := h.webhookService.RedeliverWebhook(c.Request.Context(), c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		if isDomainErrorType(err, event.ErrInvalidState) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
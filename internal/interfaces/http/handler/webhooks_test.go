@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/infrastructure/validation"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// webhookRouter 以指定角色访问webhook管理接口的路由
+func webhookRouter(t *testing.T, roles []string) (*gin.Engine, *testutil.MemoryWebhookRepository) {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+	binding.Validator = validation.NewStructValidator()
+
+	repo := testutil.NewMemoryWebhookRepository()
+	h := NewWebhookHandler(service.NewWebhookAppService(repo).WithRedeliverer(stubRedeliverer{}))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_id", "admin-1")
+		c.Set("user_roles", roles)
+		c.Next()
+	})
+	router.POST("/webhooks", h.CreateWebhook)
+	router.GET("/webhooks/:id", h.GetWebhook)
+	router.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", h.RedeliverWebhook)
+	return router, repo
+}
+
+// stubRedeliverer 不发送请求，直接返回成功的重投记录
+type stubRedeliverer struct{}
+
+func (stubRedeliverer) Redeliver(ctx context.Context, w aggregate.Webhook, original aggregate.WebhookDelivery) (aggregate.WebhookDelivery, error) {
+	delivery := original
+	delivery.ID = "attempt-redelivered"
+	delivery.Attempt = original.Attempt + 1
+	delivery.Redelivery = true
+	delivery.StatusCode = http.StatusOK
+	delivery.Success = true
+	delivery.DeliveredAt = time.Now()
+	return delivery, nil
+}
+
+func postWebhook(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateWebhook_AdminCreatesWithoutExposingSecret(t *testing.T) {
+	router, repo := webhookRouter(t, []string{"admin"})
+
+	w := postWebhook(router, `{"url":"https://example.com/hook","secret":"0123456789abcdef","event_types":["TaskCompleted"]}`)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), "0123456789abcdef")
+	var resp dto.WebhookResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Active)
+	assert.Equal(t, []string{"TaskCompleted"}, resp.EventTypes)
+	assert.Equal(t, "admin-1", resp.CreatedBy)
+
+	stored, err := repo.FindByID(context.Background(), resp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", stored.Secret)
+}
+
+func TestCreateWebhook_RejectsNonHTTPURL(t *testing.T) {
+	router, _ := webhookRouter(t, []string{"admin"})
+
+	w := postWebhook(router, `{"url":"ftp://example.com/hook","secret":"0123456789abcdef"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWebhooks_RequireAdmin(t *testing.T) {
+	router, _ := webhookRouter(t, []string{"manager"})
+
+	w := postWebhook(router, `{"url":"https://example.com/hook","secret":"0123456789abcdef"}`)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGetWebhook_MissingReturns404(t *testing.T) {
+	router, _ := webhookRouter(t, []string{"admin"})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRedeliverWebhook_ReusesDeliveryIDAndReferencesEvent(t *testing.T) {
+	router, repo := webhookRouter(t, []string{"admin"})
+	ctx := context.Background()
+	webhook, err := aggregate.NewWebhook("webhook-1", "https://example.com/hook", "0123456789abcdef", nil, "admin-1")
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, *webhook))
+	require.NoError(t, repo.SaveDelivery(ctx, aggregate.WebhookDelivery{
+		ID: "attempt-1", DeliveryID: "delivery-1", WebhookID: "webhook-1", EventID: "event-1",
+		EventType: "TaskCompleted", Attempt: 1, Payload: []byte(`{"id":"event-1"}`),
+		StatusCode: http.StatusInternalServerError, DeliveredAt: time.Now(),
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/webhook-1/deliveries/delivery-1/redeliver", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp dto.WebhookDeliveryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "delivery-1", resp.DeliveryID)
+	assert.Equal(t, "event-1", resp.EventID)
+	assert.NotEqual(t, "attempt-1", resp.ID)
+	assert.True(t, resp.Redelivery)
+	assert.Equal(t, 2, resp.Attempt)
+}
+
+func TestRedeliverWebhook_UnknownOrForeignDeliveryReturns404(t *testing.T) {
+	router, repo := webhookRouter(t, []string{"admin"})
+	ctx := context.Background()
+	for _, id := range []string{"webhook-1", "webhook-2"} {
+		webhook, err := aggregate.NewWebhook(id, "https://example.com/hook", "0123456789abcdef", nil, "admin-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, *webhook))
+	}
+	require.NoError(t, repo.SaveDelivery(ctx, aggregate.WebhookDelivery{
+		ID: "attempt-1", DeliveryID: "delivery-1", WebhookID: "webhook-2", EventID: "event-1",
+		EventType: "TaskCompleted", Attempt: 1, Payload: []byte(`{}`), DeliveredAt: time.Now(),
+	}))
+
+	for _, path := range []string{
+		"/webhooks/webhook-1/deliveries/missing/redeliver",
+		"/webhooks/webhook-1/deliveries/delivery-1/redeliver",
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
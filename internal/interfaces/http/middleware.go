@@ -6,7 +6,14 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/event"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/interfaces/http/handler"
 	"github.com/taskflow/pkg/errors"
 	"github.com/taskflow/pkg/logger"
 	"go.uber.org/zap"
@@ -37,7 +44,7 @@ func (s *Server) corsMiddleware() gin.HandlerFunc {
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Request-ID")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Api-Key, X-Request-ID")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "86400")
 
@@ -61,6 +68,34 @@ func (s *Server) requestIDMiddleware() gin.HandlerFunc {
 
 		c.Header("X-Request-ID", requestID)
 		c.Set("request_id", requestID)
+		c.Request = c.Request.WithContext(shared.WithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+// binaryResponseMIMETypes 配置中的二进制响应格式名称对应的 MIME 类型
+var binaryResponseMIMETypes = map[string][]string{
+	"protobuf": {binding.MIMEPROTOBUF},
+	"msgpack":  {binding.MIMEMSGPACK, binding.MIMEMSGPACK2},
+}
+
+// responseFormatMiddleware 响应格式中间件
+// 将配置中启用的二进制响应格式写入上下文，处理器据此与客户端的 Accept 头协商
+func (s *Server) responseFormatMiddleware() gin.HandlerFunc {
+	var mimes []string
+	for _, format := range s.config.App.BinaryResponses {
+		types, ok := binaryResponseMIMETypes[strings.ToLower(strings.TrimSpace(format))]
+		if !ok {
+			logger.Warn("Ignoring unknown binary response format", zap.String("format", format))
+			continue
+		}
+		mimes = append(mimes, types...)
+	}
+
+	return func(c *gin.Context) {
+		if len(mimes) > 0 {
+			c.Set(handler.ResponseFormatsKey, mimes)
+		}
 		c.Next()
 	}
 }
@@ -102,11 +137,16 @@ func (s *Server) securityHeadersMiddleware() gin.HandlerFunc {
 	}
 }
 
-// authMiddleware JWT认证中间件
+// authMiddleware 认证中间件
+// 优先使用 Authorization 头中的JWT；没有该头时接受 X-Api-Key 头中的用户API密钥
 func (s *Server) authMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 获取Authorization头
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && c.GetHeader(apiKeyHeader) != "" && s.apiKeyService != nil {
+			s.authenticateAPIKey(c)
+			return
+		}
 		if authHeader == "" {
 			errors.RespondWithError(c, http.StatusUnauthorized, "MISSING_AUTH_HEADER", "Authorization header is required")
 			return
@@ -144,6 +184,7 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 		c.Set("user_email", claims.Email)
 		c.Set("user_roles", claims.Roles)
 		c.Set("user_claims", claims)
+		c.Request = c.Request.WithContext(shared.WithActor(c.Request.Context(), claims.UserID))
 
 		// 记录认证成功日志
 		logger.Debug("User authenticated successfully",
@@ -152,6 +193,151 @@ func (s *Server) authMiddleware() gin.HandlerFunc {
 			zap.Strings("roles", claims.Roles),
 		)
 
+		if claims.IsImpersonation() {
+			s.serveImpersonated(c, claims.UserID, claims.ImpersonatorID)
+			return
+		}
+		c.Next()
+	}
+}
+
+// adminOnlyMiddleware 仅允许管理员访问，需挂载在认证中间件之后
+func (s *Server) adminOnlyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, role := range c.GetStringSlice("user_roles") {
+			if role == string(valueobject.UserRoleAdmin) || role == string(valueobject.UserRoleSuperAdmin) {
+				c.Next()
+				return
+			}
+		}
+		errors.RespondWithError(c, http.StatusForbidden, "ADMIN_REQUIRED", "Administrator role is required")
+	}
+}
+
+// impersonatedByHeader 模拟登录请求的响应头，值为实际操作的管理员
+const impersonatedByHeader = "X-Impersonated-By"
+
+// serveImpersonated 处理模拟登录令牌的请求，标记响应并在请求结束后写入操作日志
+// 日志写入失败不影响已完成的请求，只记录错误日志
+func (s *Server) serveImpersonated(c *gin.Context, userID, impersonatorID string) {
+	c.Set("impersonator_id", impersonatorID)
+	c.Header(impersonatedByHeader, impersonatorID)
+
+	c.Next()
+
+	if s.impersonationService == nil {
+		logger.Error("Impersonated request not audited: impersonation service unavailable",
+			zap.String("user_id", userID), zap.String("impersonator_id", impersonatorID))
+		return
+	}
+	resourceID := c.Param("id")
+	if resourceID == "" {
+		resourceID = c.Param("user_id")
+	}
+	action := dto.ImpersonatedAction{
+		UserID:         userID,
+		ImpersonatorID: impersonatorID,
+		Operation:      c.Request.Method + " " + c.FullPath(),
+		ResourceType:   routeResource(c.FullPath()),
+		ResourceID:     resourceID,
+		IPAddress:      c.ClientIP(),
+		UserAgent:      c.Request.UserAgent(),
+		ResponseStatus: c.Writer.Status(),
+	}
+	if err := s.impersonationService.RecordImpersonatedAction(c.Request.Context(), action); err != nil {
+		logger.Error("Failed to audit impersonated request",
+			zap.String("user_id", userID), zap.String("impersonator_id", impersonatorID), zap.Error(err))
+	}
+}
+
+// apiKeyHeader 携带用户API密钥的请求头
+const apiKeyHeader = "X-Api-Key"
+
+// apiRoutePrefix 接口路由前缀，API密钥作用域和操作日志中的资源取该前缀下的一级路径
+const apiRoutePrefix = "/api/v1/"
+
+// routeResource 路由在 /api/v1 下的一级路径，如 /api/v1/tasks/:id 对应 tasks；其他路由返回空
+func routeResource(fullPath string) string {
+	if !strings.HasPrefix(fullPath, apiRoutePrefix) {
+		return ""
+	}
+	resource, _, _ := strings.Cut(strings.TrimPrefix(fullPath, apiRoutePrefix), "/")
+	return resource
+}
+
+// apiKeyScopeFor 请求路由需要的API密钥作用域，GET、HEAD 请求需要 read，其余请求需要 write
+// 路由不属于 aggregate.APIKeyResources 中的资源时返回空，API密钥不能访问
+func apiKeyScopeFor(fullPath, method string) string {
+	resource := routeResource(fullPath)
+	action := aggregate.APIKeyActionWrite
+	if method == http.MethodGet || method == http.MethodHead {
+		action = aggregate.APIKeyActionRead
+	}
+	scope := aggregate.APIKeyScope(resource, action)
+	if !aggregate.IsValidAPIKeyScope(scope) {
+		return ""
+	}
+	return scope
+}
+
+// authenticateAPIKey 以API密钥认证请求，写入与JWT认证相同的用户上下文
+// 密钥必须具有路由对应资源的作用域，见 apiKeyScopeFor
+func (s *Server) authenticateAPIKey(c *gin.Context) {
+	scope := apiKeyScopeFor(c.FullPath(), c.Request.Method)
+
+	principal, err := s.apiKeyService.Authenticate(c.Request.Context(), c.GetHeader(apiKeyHeader), scope)
+	if err != nil {
+		switch {
+		case event.IsErrorType(err, event.ErrTokenInvalid):
+			errors.RespondWithError(c, http.StatusUnauthorized, "INVALID_API_KEY", "Invalid or revoked API key")
+		case event.IsErrorType(err, event.ErrUserInactive):
+			errors.RespondWithError(c, http.StatusUnauthorized, "USER_INACTIVE", "User account is not active")
+		case event.IsErrorType(err, event.ErrPermissionDenied) && scope == "":
+			errors.RespondWithError(c, http.StatusForbidden, "INSUFFICIENT_SCOPE", "This endpoint cannot be accessed with an API key")
+		case event.IsErrorType(err, event.ErrPermissionDenied):
+			errors.RespondWithError(c, http.StatusForbidden, "INSUFFICIENT_SCOPE", "API key does not have the "+scope+" scope")
+		default:
+			logger.Error("API key authentication failed", zap.Error(err))
+			errors.RespondWithError(c, http.StatusInternalServerError, "AUTHENTICATION_FAILED", "Failed to authenticate API key")
+		}
+		return
+	}
+
+	c.Set("user_id", principal.UserID)
+	c.Set("user_email", principal.Email)
+	c.Set("user_roles", principal.Roles)
+	c.Set("api_key_id", principal.KeyID)
+	c.Set("api_key_scopes", principal.Scopes)
+	c.Request = c.Request.WithContext(shared.WithActor(c.Request.Context(), principal.UserID))
+
+	logger.Debug("User authenticated with API key",
+		zap.String("user_id", principal.UserID),
+		zap.String("api_key_id", principal.KeyID),
+		zap.Strings("scopes", principal.Scopes),
+	)
+
+	c.Next()
+}
+
+// userTimezoneMiddleware 用户时区中间件
+// 将认证用户的时区写入请求上下文，响应中的时间按该时区渲染（默认UTC）
+func (s *Server) userTimezoneMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+		if userID == "" || s.userService == nil {
+			c.Next()
+			return
+		}
+
+		user, err := s.userService.GetUser(c.Request.Context(), userID)
+		if err != nil {
+			logger.Debug("Failed to load user timezone", zap.String("user_id", userID), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		c.Set("user_timezone", user.Timezone)
+		c.Request = c.Request.WithContext(shared.WithTimezone(c.Request.Context(), user.Timezone))
 		c.Next()
 	}
 }
@@ -0,0 +1,272 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"github.com/taskflow/internal/domain/aggregate"
+	authService "github.com/taskflow/internal/domain/auth/service"
+	authValueobject "github.com/taskflow/internal/domain/auth/valueobject"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+	"github.com/taskflow/internal/infrastructure/security"
+	"github.com/taskflow/internal/interfaces/http/handler"
+	"github.com/taskflow/internal/testutil"
+	"github.com/taskflow/pkg/logger"
+)
+
+// apiKeyAuthRouter 只挂载认证中间件的路由，处理器回显认证写入的用户上下文
+func apiKeyAuthRouter(t *testing.T) (*gin.Engine, *service.APIKeyAppService) {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	alice := aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleManager)
+	apiKeys := service.NewAPIKeyAppService(testutil.NewMemoryAPIKeyRepository(), testutil.NewMemoryUserRepository(alice))
+	s := &Server{apiKeyService: apiKeys}
+
+	echo := func(c *gin.Context) {
+		actor := shared.ActorFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{
+			"user_id":    c.GetString("user_id"),
+			"user_roles": c.GetStringSlice("user_roles"),
+			"actor":      actor,
+		})
+	}
+	router := gin.New()
+	v1 := router.Group("/api/v1", s.authMiddleware())
+	v1.GET("/tasks", echo)
+	v1.POST("/tasks", echo)
+	v1.GET("/projects/:id", echo)
+	v1.GET("/me", echo)
+	return router, apiKeys
+}
+
+func createAPIKey(t *testing.T, apiKeys *service.APIKeyAppService, scopes ...string) *dto.CreatedAPIKeyResponse {
+	t.Helper()
+	created, err := apiKeys.CreateAPIKey(context.Background(), "user-1", dto.CreateAPIKeyRequest{Name: "ci", Scopes: scopes})
+	require.NoError(t, err)
+	return created
+}
+
+func requestWithAPIKey(router *gin.Engine, method, key string) *httptest.ResponseRecorder {
+	return requestPathWithAPIKey(router, method, "/api/v1/tasks", key)
+}
+
+func requestPathWithAPIKey(router *gin.Engine, method, path, key string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	if key != "" {
+		req.Header.Set("X-Api-Key", key)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_ValidAPIKeyPopulatesUserContext(t *testing.T) {
+	router, apiKeys := apiKeyAuthRouter(t)
+	created := createAPIKey(t, apiKeys, "tasks:read")
+
+	w := requestWithAPIKey(router, http.MethodGet, created.Key)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"user_id":"user-1","user_roles":["manager"],"actor":"user-1"}`, w.Body.String())
+
+	keys, err := apiKeys.ListAPIKeys(context.Background(), "user-1")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+}
+
+func TestAuthMiddleware_RejectsUnknownAndRevokedAPIKeys(t *testing.T) {
+	router, apiKeys := apiKeyAuthRouter(t)
+	created := createAPIKey(t, apiKeys, "tasks:write")
+
+	w := requestWithAPIKey(router, http.MethodGet, created.Key+"x")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_API_KEY")
+
+	_, err := apiKeys.RevokeAPIKey(context.Background(), "user-1", created.ID, false)
+	require.NoError(t, err)
+	w = requestWithAPIKey(router, http.MethodGet, created.Key)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_API_KEY")
+
+	// 既没有JWT也没有API密钥时仍要求 Authorization 头
+	w = requestWithAPIKey(router, http.MethodGet, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "MISSING_AUTH_HEADER")
+}
+
+func TestAuthMiddleware_EnforcesAPIKeyScopes(t *testing.T) {
+	router, apiKeys := apiKeyAuthRouter(t)
+	readOnly := createAPIKey(t, apiKeys, "tasks:read")
+	writer := createAPIKey(t, apiKeys, "tasks:write")
+
+	w := requestWithAPIKey(router, http.MethodPost, readOnly.Key)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "INSUFFICIENT_SCOPE")
+
+	w = requestWithAPIKey(router, http.MethodPost, writer.Key)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = requestWithAPIKey(router, http.MethodGet, writer.Key)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// 任务作用域不能访问其他资源，也不能访问不在作用域范围内的接口
+	w = requestPathWithAPIKey(router, http.MethodGet, "/api/v1/projects/project-1", writer.Key)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "projects:read")
+	w = requestPathWithAPIKey(router, http.MethodGet, "/api/v1/me", writer.Key)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "INSUFFICIENT_SCOPE")
+}
+
+func TestAPIKeyScopeFor(t *testing.T) {
+	assert.Equal(t, "tasks:read", apiKeyScopeFor("/api/v1/tasks/:id", http.MethodGet))
+	assert.Equal(t, "tasks:write", apiKeyScopeFor("/api/v1/tasks/:id/approve", http.MethodPost))
+	assert.Equal(t, "projects:write", apiKeyScopeFor("/api/v1/projects/:id", http.MethodDelete))
+	assert.Equal(t, "search:read", apiKeyScopeFor("/api/v1/search", http.MethodGet))
+	assert.Empty(t, apiKeyScopeFor("/api/v1/webhooks", http.MethodGet))
+	assert.Empty(t, apiKeyScopeFor("/api/v1/me/api-keys", http.MethodPost))
+	assert.Empty(t, apiKeyScopeFor("", http.MethodGet))
+}
+
+// passthroughTransactionManager 直接执行回调的事务管理器
+type passthroughTransactionManager struct{}
+
+func (passthroughTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (passthroughTransactionManager) WithTransactionResult(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return fn(ctx)
+}
+
+// impersonationRouter 挂载认证中间件和模拟登录接口的路由，返回签发普通令牌的JWT服务和操作日志
+func impersonationRouter(t *testing.T) (*gin.Engine, authService.JWTService, *testutil.MemoryOperationLogRepository) {
+	t.Helper()
+	require.NoError(t, logger.InitLogger(&logger.Config{Level: "info", Format: "console", Output: "console"}))
+	gin.SetMode(gin.TestMode)
+
+	jwtService := security.NewJWTService(authValueobject.JWTConfig{
+		Secret:             "test-secret",
+		AccessTokenExpiry:  time.Hour,
+		RefreshTokenExpiry: 24 * time.Hour,
+		Issuer:             "taskflow-test",
+	})
+	users := testutil.NewMemoryUserRepository(
+		aggregate.NewUser("admin-1", "root", "root@example.com", "Root", "hash", valueobject.UserRoleAdmin),
+		aggregate.NewUser("user-1", "alice", "alice@example.com", "Alice", "hash", valueobject.UserRoleEmployee),
+	)
+	logs := testutil.NewMemoryOperationLogRepository()
+	impersonation := service.NewImpersonationAppService(passthroughTransactionManager{}, users, logs, jwtService)
+	s := &Server{jwtService: jwtService, impersonationService: impersonation}
+
+	router := gin.New()
+	v1 := router.Group("/api/v1", s.authMiddleware())
+	v1.POST("/admin/impersonate/:user_id", handler.NewAdminHandler(impersonation, nil, nil).Impersonate)
+	v1.PUT("/tasks/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetString("user_id")})
+	})
+	return router, jwtService, logs
+}
+
+func requestWithToken(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestImpersonation_AdminGatedAndEveryRequestAudited(t *testing.T) {
+	router, jwtService, logs := impersonationRouter(t)
+
+	employee, err := jwtService.GenerateTokens("user-1", "alice@example.com", []string{"employee"})
+	require.NoError(t, err)
+	w := requestWithToken(router, http.MethodPost, "/api/v1/admin/impersonate/user-1", employee.AccessToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	admin, err := jwtService.GenerateTokens("admin-1", "root@example.com", []string{"admin"})
+	require.NoError(t, err)
+	w = requestWithToken(router, http.MethodPost, "/api/v1/admin/impersonate/user-1", admin.AccessToken)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var issued dto.ImpersonationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+	assert.True(t, issued.Impersonating)
+
+	// 模拟令牌以被模拟用户身份访问，响应带有标记，请求写入操作日志
+	w = requestWithToken(router, http.MethodPut, "/api/v1/tasks/task-1", issued.AccessToken)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"user_id":"user-1"}`, w.Body.String())
+	assert.Equal(t, "admin-1", w.Header().Get("X-Impersonated-By"))
+
+	recorded := logs.All()
+	require.Len(t, recorded, 2)
+	assert.Equal(t, aggregate.OperationImpersonate, recorded[0].Operation)
+	action := recorded[1]
+	assert.Equal(t, valueobject.UserID("user-1"), action.UserID)
+	require.True(t, action.IsImpersonated())
+	assert.Equal(t, valueobject.UserID("admin-1"), *action.ImpersonatorID)
+	assert.Equal(t, "PUT /api/v1/tasks/:id", action.Operation)
+	assert.Equal(t, "tasks", action.ResourceType)
+	assert.Equal(t, "task-1", action.ResourceID)
+	assert.Equal(t, http.StatusOK, action.ResponseStatus)
+
+	// 普通令牌的请求不写入操作日志
+	w = requestWithToken(router, http.MethodPut, "/api/v1/tasks/task-1", admin.AccessToken)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("X-Impersonated-By"))
+	assert.Len(t, logs.All(), 2)
+}
+
+func TestImpersonation_TokenIsTimeLimited(t *testing.T) {
+	router, jwtService, _ := impersonationRouter(t)
+
+	issued, err := jwtService.GenerateImpersonationToken("user-1", "alice@example.com", []string{"employee"}, "admin-1", service.ImpersonationTokenTTL)
+	require.NoError(t, err)
+	assert.Empty(t, issued.RefreshToken, "impersonation tokens must not be refreshable")
+	claims, err := jwtService.ValidateToken(issued.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "admin-1", claims.ImpersonatorID)
+	assert.WithinDuration(t, time.Now().Add(service.ImpersonationTokenTTL), claims.ExpiresAt.Time, 5*time.Second)
+
+	expired, err := jwtService.GenerateImpersonationToken("user-1", "alice@example.com", []string{"employee"}, "admin-1", -time.Minute)
+	require.NoError(t, err)
+	w := requestWithToken(router, http.MethodPut, "/api/v1/tasks/task-1", expired.AccessToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 模拟令牌不能再发起模拟
+	w = requestWithToken(router, http.MethodPost, "/api/v1/admin/impersonate/user-1", issued.AccessToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDebugVars_RequireAdmin(t *testing.T) {
+	_, jwtService, _ := impersonationRouter(t)
+	s := &Server{jwtService: jwtService, router: gin.New()}
+	s.setupDebugRoutes()
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	employee, err := jwtService.GenerateTokens("user-1", "alice@example.com", []string{"employee"})
+	require.NoError(t, err)
+	w = requestWithToken(s.router, http.MethodGet, "/debug/vars", employee.AccessToken)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	admin, err := jwtService.GenerateTokens("admin-1", "root@example.com", []string{"admin"})
+	require.NoError(t, err)
+	w = requestWithToken(s.router, http.MethodGet, "/debug/vars", admin.AccessToken)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "memstats")
+}
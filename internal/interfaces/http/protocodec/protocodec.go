@@ -0,0 +1,212 @@
+// Package protocodec 按 taskflow.proto 定义把核心任务/项目响应编码为 protobuf
+package protocodec
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrUnsupportedType 响应类型没有对应的 protobuf 消息
+var ErrUnsupportedType = errors.New("protocodec: unsupported response type")
+
+// Marshal 把响应编码为 taskflow.proto 中对应的消息，不支持的类型返回 ErrUnsupportedType
+func Marshal(v interface{}) ([]byte, error) {
+	var e encoder
+	switch r := v.(type) {
+	case *dto.TaskResponse:
+		e.task(r)
+	case dto.TaskResponse:
+		e.task(&r)
+	case *service.ProjectResponse:
+		e.project(r)
+	case service.ProjectResponse:
+		e.project(&r)
+	case *service.ProjectListResponse:
+		e.projectList(r)
+	case service.ProjectListResponse:
+		e.projectList(&r)
+	default:
+		return nil, ErrUnsupportedType
+	}
+	return e.buf, nil
+}
+
+// encoder 按 proto3 规则编码字段：标量零值不输出，optional 字段和子消息在非空时输出
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) string(num protowire.Number, v string) {
+	if v == "" {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.BytesType)
+	e.buf = protowire.AppendString(e.buf, v)
+}
+
+func (e *encoder) optionalString(num protowire.Number, v *string) {
+	if v == nil {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.BytesType)
+	e.buf = protowire.AppendString(e.buf, *v)
+}
+
+func (e *encoder) int(num protowire.Number, v int) {
+	if v == 0 {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, uint64(int64(v)))
+}
+
+func (e *encoder) int64(num protowire.Number, v int64) {
+	if v == 0 {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, uint64(v))
+}
+
+func (e *encoder) optionalInt(num protowire.Number, v *int) {
+	if v == nil {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, uint64(int64(*v)))
+}
+
+func (e *encoder) double(num protowire.Number, v float64) {
+	if v == 0 {
+		return
+	}
+	e.buf = protowire.AppendTag(e.buf, num, protowire.Fixed64Type)
+	e.buf = protowire.AppendFixed64(e.buf, math.Float64bits(v))
+}
+
+// message 编码子消息，子消息为空时也输出以保留字段存在性
+func (e *encoder) message(num protowire.Number, fn func(*encoder)) {
+	var sub encoder
+	fn(&sub)
+	e.buf = protowire.AppendTag(e.buf, num, protowire.BytesType)
+	e.buf = protowire.AppendBytes(e.buf, sub.buf)
+}
+
+// timestamp 编码为 google.protobuf.Timestamp，零值时间不输出
+func (e *encoder) timestamp(num protowire.Number, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	e.message(num, func(sub *encoder) {
+		sub.int64(1, t.Unix())
+		sub.int(2, t.Nanosecond())
+	})
+}
+
+func (e *encoder) optionalTimestamp(num protowire.Number, t *time.Time) {
+	if t != nil {
+		e.timestamp(num, *t)
+	}
+}
+
+func (e *encoder) task(r *dto.TaskResponse) {
+	e.string(1, r.ID)
+	e.string(2, r.Title)
+	e.optionalString(3, r.Description)
+	e.string(4, r.TaskType)
+	e.string(5, r.Priority)
+	e.string(6, r.Status)
+	e.string(7, r.ProjectID)
+	e.string(8, r.CreatorID)
+	e.string(9, r.ResponsibleID)
+	e.optionalTimestamp(10, r.DueDate)
+	e.optionalTimestamp(11, r.ApprovedAt)
+	e.timestamp(12, r.StatusChangedAt)
+	e.double(13, r.TimeInStatusHours)
+	e.int(14, r.EstimatedHours)
+	e.double(15, r.ActualHours)
+	for i := range r.Participants {
+		p := &r.Participants[i]
+		e.message(16, func(sub *encoder) {
+			sub.string(1, p.UserID)
+			sub.string(2, p.Role)
+			sub.timestamp(3, p.AddedAt)
+			sub.string(4, p.AddedBy)
+		})
+	}
+	if rule := r.RecurrenceRule; rule != nil {
+		e.message(17, func(sub *encoder) {
+			sub.string(1, rule.Frequency)
+			sub.int(2, rule.IntervalValue)
+			sub.optionalTimestamp(3, rule.EndDate)
+			sub.optionalInt(4, rule.MaxExecutions)
+		})
+	}
+	e.timestamp(18, r.CreatedAt)
+	e.timestamp(19, r.UpdatedAt)
+	e.string(20, r.UpdatedBy)
+	for _, id := range r.CoResponsibleIDs {
+		e.buf = protowire.AppendTag(e.buf, 21, protowire.BytesType)
+		e.buf = protowire.AppendString(e.buf, id)
+	}
+	for _, tag := range r.Tags {
+		e.buf = protowire.AppendTag(e.buf, 22, protowire.BytesType)
+		e.buf = protowire.AppendString(e.buf, tag)
+	}
+}
+
+func (e *encoder) project(r *service.ProjectResponse) {
+	e.string(1, r.ID)
+	e.string(2, r.Name)
+	e.string(3, r.Description)
+	e.string(4, r.ProjectType)
+	e.string(5, r.Status)
+	e.string(6, r.OwnerID)
+	e.optionalString(7, r.ManagerID)
+	e.optionalString(8, r.ParentID)
+	for i := range r.Members {
+		m := &r.Members[i]
+		e.message(9, func(sub *encoder) {
+			sub.string(1, m.UserID)
+			sub.string(2, m.Role)
+			sub.timestamp(3, m.JoinedAt)
+			sub.string(4, m.AddedBy)
+		})
+	}
+	// repeated string 不跳过空字符串，保持与 JSON 数组一致
+	for _, child := range r.Children {
+		e.buf = protowire.AppendTag(e.buf, 10, protowire.BytesType)
+		e.buf = protowire.AppendString(e.buf, child)
+	}
+	e.timestamp(11, r.StartDate)
+	e.optionalTimestamp(12, r.EndDate)
+	e.timestamp(13, r.CreatedAt)
+	e.timestamp(14, r.UpdatedAt)
+	e.string(15, r.CreatedBy)
+	e.string(16, r.UpdatedBy)
+	if stats := r.Statistics; stats != nil {
+		e.message(17, func(sub *encoder) {
+			sub.int(1, stats.TotalTasks)
+			sub.int(2, stats.CompletedTasks)
+			sub.int(3, stats.PendingTasks)
+			sub.int(4, stats.TotalMembers)
+			sub.int(5, stats.Progress)
+		})
+	}
+}
+
+func (e *encoder) projectList(r *service.ProjectListResponse) {
+	for i := range r.Projects {
+		p := &r.Projects[i]
+		e.message(1, func(sub *encoder) { sub.project(p) })
+	}
+	e.int(2, r.Total)
+	e.int(3, r.Page)
+	e.int(4, r.PageSize)
+	e.int(5, r.TotalPages)
+}
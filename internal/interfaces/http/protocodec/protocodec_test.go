@@ -0,0 +1,148 @@
+package protocodec
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/application/dto"
+	"github.com/taskflow/internal/application/service"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// field 解码后的字段值，按线型只填充其中之一
+type field struct {
+	varint  uint64
+	fixed64 uint64
+	bytes   []byte
+}
+
+// decode 按字段号收集消息中的字段，repeated 字段按出现顺序保留
+func decode(t *testing.T, b []byte) map[protowire.Number][]field {
+	t.Helper()
+	fields := make(map[protowire.Number][]field)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0, "invalid tag")
+		b = b[n:]
+
+		var f field
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			f.fixed64, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			t.Fatalf("unexpected wire type %d for field %d", typ, num)
+		}
+		require.GreaterOrEqual(t, n, 0, "invalid value for field %d", num)
+		b = b[n:]
+		fields[num] = append(fields[num], f)
+	}
+	return fields
+}
+
+func decodeTimestamp(t *testing.T, b []byte) time.Time {
+	t.Helper()
+	fields := decode(t, b)
+	var seconds, nanos uint64
+	if f, ok := fields[1]; ok {
+		seconds = f[0].varint
+	}
+	if f, ok := fields[2]; ok {
+		nanos = f[0].varint
+	}
+	return time.Unix(int64(seconds), int64(nanos)).UTC()
+}
+
+func TestMarshal_TaskResponse(t *testing.T) {
+	due := time.Date(2024, 5, 1, 9, 30, 0, 500, time.UTC)
+	maxExecutions := 3
+	body, err := Marshal(&dto.TaskResponse{
+		ID:             "task-1",
+		Title:          "Quarterly report",
+		Status:         "in_progress",
+		ResponsibleID:  "alice",
+		DueDate:        &due,
+		EstimatedHours: 8,
+		ActualHours:    2.5,
+		Participants: []dto.TaskParticipantDTO{
+			{UserID: "bob", Role: "reviewer", AddedAt: due},
+			{UserID: "carol", Role: "executor"},
+		},
+		RecurrenceRule:   &dto.RecurrenceRuleDTO{Frequency: "weekly", IntervalValue: 1, MaxExecutions: &maxExecutions},
+		CoResponsibleIDs: []string{"dave", "erin"},
+		Tags:             []string{"finance", "q2"},
+	})
+	require.NoError(t, err)
+
+	fields := decode(t, body)
+	assert.Equal(t, "task-1", string(fields[1][0].bytes))
+	assert.Equal(t, "Quarterly report", string(fields[2][0].bytes))
+	assert.NotContains(t, fields, protowire.Number(3), "nil description is not emitted")
+	assert.Equal(t, "in_progress", string(fields[6][0].bytes))
+	assert.Equal(t, "alice", string(fields[9][0].bytes))
+	assert.Equal(t, due, decodeTimestamp(t, fields[10][0].bytes))
+	assert.Equal(t, uint64(8), fields[14][0].varint)
+	assert.Equal(t, 2.5, math.Float64frombits(fields[15][0].fixed64))
+
+	require.Len(t, fields[16], 2)
+	bob := decode(t, fields[16][0].bytes)
+	assert.Equal(t, "bob", string(bob[1][0].bytes))
+	assert.Equal(t, "reviewer", string(bob[2][0].bytes))
+	assert.Equal(t, due, decodeTimestamp(t, bob[3][0].bytes))
+
+	rule := decode(t, fields[17][0].bytes)
+	assert.Equal(t, "weekly", string(rule[1][0].bytes))
+	assert.Equal(t, uint64(1), rule[2][0].varint)
+	assert.Equal(t, uint64(3), rule[4][0].varint)
+
+	repeatedStrings := func(values []field) []string {
+		out := make([]string, len(values))
+		for i, v := range values {
+			out[i] = string(v.bytes)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"dave", "erin"}, repeatedStrings(fields[21]))
+	assert.Equal(t, []string{"finance", "q2"}, repeatedStrings(fields[22]))
+}
+
+func TestMarshal_ProjectList(t *testing.T) {
+	managerID := "manager-1"
+	body, err := Marshal(&service.ProjectListResponse{
+		Projects: []service.ProjectResponse{
+			{ID: "p-1", Name: "Alpha", ManagerID: &managerID, Children: []string{"p-2"}, Statistics: &service.ProjectStatisticsResponse{TotalTasks: 4}},
+			{ID: "p-2", Name: "Beta"},
+		},
+		Total:    2,
+		Page:     1,
+		PageSize: 20,
+	})
+	require.NoError(t, err)
+
+	fields := decode(t, body)
+	require.Len(t, fields[1], 2)
+	assert.Equal(t, uint64(2), fields[2][0].varint)
+	assert.Equal(t, uint64(20), fields[4][0].varint)
+	assert.NotContains(t, fields, protowire.Number(5), "zero total_pages is not emitted")
+
+	alpha := decode(t, fields[1][0].bytes)
+	assert.Equal(t, "p-1", string(alpha[1][0].bytes))
+	assert.Equal(t, "manager-1", string(alpha[7][0].bytes))
+	assert.Equal(t, "p-2", string(alpha[10][0].bytes))
+	stats := decode(t, alpha[17][0].bytes)
+	assert.Equal(t, uint64(4), stats[1][0].varint)
+
+	beta := decode(t, fields[1][1].bytes)
+	assert.NotContains(t, beta, protowire.Number(7))
+}
+
+func TestMarshal_UnsupportedType(t *testing.T) {
+	_, err := Marshal(map[string]string{"message": "ok"})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
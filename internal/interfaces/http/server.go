@@ -2,11 +2,13 @@ package http
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/swag"
@@ -14,6 +16,7 @@ import (
 	"github.com/taskflow/internal/domain/auth/service"
 	"github.com/taskflow/internal/infrastructure/config"
 	"github.com/taskflow/internal/infrastructure/http/controllers"
+	"github.com/taskflow/internal/infrastructure/validation"
 	"github.com/taskflow/internal/interfaces/http/handler"
 	"github.com/taskflow/pkg/logger"
 	"go.uber.org/zap"
@@ -23,30 +26,59 @@ import (
 
 // Server HTTP服务器
 type Server struct {
-	config      *config.Config
-	router      *gin.Engine
-	server      *http.Server
-	jwtService  service.JWTService
-	userService *userAppService.UserAppService
-	authHandler *handler.AuthHandler
+	config               *config.Config
+	router               *gin.Engine
+	server               *http.Server
+	jwtService           service.JWTService
+	userService          *userAppService.UserAppService
+	apiKeyService        *userAppService.APIKeyAppService
+	impersonationService *userAppService.ImpersonationAppService
+	authHandler          *handler.AuthHandler
+	projectHandler       *handler.ProjectHandler
+	taskHandler          *handler.TaskHandler
+	meHandler            *handler.MeHandler
+	eventHandler         *handler.EventHandler
+	webhookHandler       *handler.WebhookHandler
+	searchHandler        *handler.SearchHandler
+	approvalHandler      *handler.ApprovalHandler
+	notificationHandler  *handler.NotificationHandler
+	reminderHandler      *handler.ReminderHandler
+	apiKeyHandler        *handler.APIKeyHandler
+	adminHandler         *handler.AdminHandler
 }
 
 // NewServer 创建新的HTTP服务器
-func NewServer(cfg *config.Config, jwtService service.JWTService, userService *userAppService.UserAppService) *Server {
+func NewServer(cfg *config.Config, jwtService service.JWTService, userService *userAppService.UserAppService, projectService *userAppService.ProjectAppService, taskService *userAppService.TaskAppService, currentUserService *userAppService.CurrentUserAppService, eventService *userAppService.EventAppService, webhookService *userAppService.WebhookAppService, searchService *userAppService.SearchAppService, approvalService *userAppService.ApprovalAppService, notificationService *userAppService.NotificationAppService, reminderService *userAppService.ReminderAppService, apiKeyService *userAppService.APIKeyAppService, impersonationService *userAppService.ImpersonationAppService, permissionService *userAppService.PermissionAppService, featureFlagService *userAppService.FeatureFlagAppService) *Server {
 	// 设置Gin模式
 	if cfg.App.Mode == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// 请求绑定时同时校验 binding 和 validate 标签
+	binding.Validator = validation.NewStructValidator()
+
 	// 创建认证处理器
 	authHandler := handler.NewAuthHandler(jwtService, userService)
 
 	server := &Server{
-		config:      cfg,
-		router:      gin.New(),
-		jwtService:  jwtService,
-		userService: userService,
-		authHandler: authHandler,
+		config:               cfg,
+		router:               gin.New(),
+		jwtService:           jwtService,
+		userService:          userService,
+		apiKeyService:        apiKeyService,
+		impersonationService: impersonationService,
+		authHandler:          authHandler,
+		projectHandler:       handler.NewProjectHandler(projectService),
+		taskHandler:          handler.NewTaskHandler(taskService),
+		meHandler:            handler.NewMeHandler(currentUserService),
+		eventHandler:         handler.NewEventHandler(eventService),
+		webhookHandler:       handler.NewWebhookHandler(webhookService),
+		searchHandler:        handler.NewSearchHandler(searchService),
+		approvalHandler:      handler.NewApprovalHandler(approvalService),
+		notificationHandler:  handler.NewNotificationHandler(notificationService),
+		reminderHandler:      handler.NewReminderHandler(reminderService),
+		apiKeyHandler:        handler.NewAPIKeyHandler(apiKeyService),
+		adminHandler:         handler.NewAdminHandler(impersonationService, permissionService, featureFlagService),
 	}
 
 	// 设置中间件
@@ -91,6 +123,7 @@ func (s *Server) setupMiddleware() {
 	s.router.Use(s.corsMiddleware())
 	s.router.Use(s.requestIDMiddleware())
 	s.router.Use(s.loggingMiddleware())
+	s.router.Use(s.responseFormatMiddleware())
 
 	// 安全中间件
 	s.router.Use(s.securityHeadersMiddleware())
@@ -103,6 +136,7 @@ func (s *Server) setupRoutes() {
 	// 健康检查（无需认证，根路径）
 	s.router.GET("/health", healthController.HealthCheck)
 	s.router.GET("/version", s.versionInfo)
+	s.setupDebugRoutes()
 
 	// API版本分组
 	v1 := s.router.Group("/api/v1")
@@ -129,8 +163,48 @@ func (s *Server) setupRoutes() {
 
 		// 需要认证的接口
 		protected := v1.Group("")
-		protected.Use(s.authMiddleware()) // JWT认证中间件
+		protected.Use(s.authMiddleware())         // JWT认证中间件
+		protected.Use(s.userTimezoneMiddleware()) // 用户时区
 		{
+			// 当前登录用户
+			protected.GET("/me", s.meHandler.GetMe)
+			protected.GET("/me/notification-preferences", s.meHandler.GetNotificationPreferences)
+			protected.PUT("/me/notification-preferences", s.meHandler.UpdateNotificationPreferences)
+
+			// 当前用户的站内通知
+			notifications := protected.Group("/me/notifications")
+			{
+				notifications.GET("", s.notificationHandler.ListNotifications)
+				notifications.POST("/read-all", s.notificationHandler.MarkAllNotificationsRead)
+				notifications.POST("/:id/read", s.notificationHandler.MarkNotificationRead)
+			}
+
+			// 当前用户的API密钥
+			apiKeys := protected.Group("/me/api-keys")
+			{
+				apiKeys.GET("", s.apiKeyHandler.ListAPIKeys)
+				apiKeys.POST("", s.apiKeyHandler.CreateAPIKey)
+				apiKeys.DELETE("/:id", s.apiKeyHandler.RevokeAPIKey)
+			}
+
+			// 当前用户的待处理事项
+			protected.GET("/me/action-items", s.taskHandler.GetActionItems)
+
+			// 领域事件（供外部系统同步）
+			protected.GET("/events", s.eventHandler.ListEvents)
+
+			// 领域事件的webhook订阅管理
+			webhooks := protected.Group("/webhooks")
+			{
+				webhooks.GET("", s.webhookHandler.ListWebhooks)
+				webhooks.POST("", s.webhookHandler.CreateWebhook)
+				webhooks.GET("/:id", s.webhookHandler.GetWebhook)
+				webhooks.PUT("/:id", s.webhookHandler.UpdateWebhook)
+				webhooks.DELETE("/:id", s.webhookHandler.DeleteWebhook)
+				webhooks.GET("/:id/deliveries", s.webhookHandler.ListWebhookDeliveries)
+				webhooks.POST("/:id/deliveries/:delivery_id/redeliver", s.webhookHandler.RedeliverWebhook)
+			}
+
 			// 用户管理
 			users := protected.Group("/users")
 			{
@@ -139,33 +213,60 @@ func (s *Server) setupRoutes() {
 				users.PUT("/:id", handler.UpdateUser)
 				users.DELETE("/:id", handler.DeleteUser)
 			}
+
+			// 管理员支持工具
+			admin := protected.Group("/admin")
+			{
+				admin.POST("/impersonate/:user_id", s.adminHandler.Impersonate)
+				admin.POST("/authz/explain", s.adminHandler.ExplainPermission)
+				admin.GET("/feature-flags", s.adminHandler.ListFeatureFlags)
+			}
 			// 项目管理
 			projects := protected.Group("/projects")
 			{
-				projects.GET("", handler.ListProjects)
-				projects.POST("", handler.CreateProject)
-				projects.GET("/:id", handler.GetProject)
-				projects.PUT("/:id", handler.UpdateProject)
-				projects.DELETE("/:id", handler.DeleteProject)
+				projects.GET("", s.projectHandler.ListProjects)
+				projects.POST("", s.projectHandler.CreateProject)
+				projects.GET("/timeline", s.projectHandler.ListProjectsByDateRange)
+				projects.GET("/at-risk", s.projectHandler.ListAtRiskProjects)
+				projects.GET("/:id", s.projectHandler.GetProject)
+				projects.PUT("/:id", s.projectHandler.UpdateProject)
+				projects.DELETE("/:id", s.projectHandler.DeleteProject)
+				projects.PUT("/:id/manager", s.projectHandler.AssignManager)
+				projects.PUT("/:id/status", s.projectHandler.ChangeProjectStatus)
 
 				// 项目成员管理
-				projects.GET("/:id/members", handler.GetProjectMembers)
-				projects.POST("/:id/members", handler.AddProjectMember)
-				projects.DELETE("/:id/members/:user_id", handler.RemoveProjectMember)
+				projects.GET("/:id/members", s.projectHandler.GetProjectMembers)
+				projects.POST("/:id/members", s.projectHandler.AddProjectMember)
+				projects.DELETE("/:id/members/:user_id", s.projectHandler.RemoveProjectMember)
+				projects.PUT("/:id/members/:user_id/role", s.projectHandler.UpdateMemberRole)
+				projects.GET("/:id/assignment-suggestions", s.projectHandler.GetAssignmentSuggestions)
+				projects.POST("/:id/participants/swap", s.projectHandler.SwapProjectParticipants)
 
 				// 项目层级管理
-				projects.GET("/:id/children", handler.GetSubProjects)
-				projects.POST("/:id/children", handler.CreateSubProject)
+				projects.GET("/:id/children", s.projectHandler.GetSubProjects)
+				projects.POST("/:id/children", s.projectHandler.CreateSubProject)
+				projects.GET("/:id/hierarchy", s.projectHandler.GetProjectHierarchy)
+				projects.GET("/:id/descendants", s.projectHandler.GetProjectDescendants)
+
+				// 项目任务统计
+				projects.GET("/:id/tasks/statistics", s.taskHandler.GetProjectTaskStatistics)
+				projects.POST("/statistics/batch", s.taskHandler.GetProjectsTaskStatistics)
 			}
 
 			// 任务管理
 			tasks := protected.Group("/tasks")
 			{
-				tasks.GET("", handler.ListTasks)
+				tasks.GET("", s.taskHandler.ListTasks)
 				tasks.POST("", handler.CreateTask)
 				tasks.GET("/:id", handler.GetTask)
-				tasks.PUT("/:id", handler.UpdateTask)
-				tasks.DELETE("/:id", handler.DeleteTask)
+				tasks.GET("/:id/bundle", s.taskHandler.GetTaskBundle)
+				tasks.PUT("/:id", s.taskHandler.UpdateTask)
+				tasks.DELETE("/:id", s.taskHandler.DeleteTask)
+				tasks.DELETE("/bulk", s.taskHandler.BulkDeleteTasks)
+				tasks.POST("/bulk-tag", s.taskHandler.BulkTagTasks)
+				tasks.POST("/:id/clone", s.taskHandler.CloneTask)
+				tasks.PUT("/:id/type", s.taskHandler.ChangeTaskType)
+				tasks.POST("/:id/recurrence/preview", s.taskHandler.PreviewRecurrence)
 
 				// 任务状态管理
 				tasks.POST("/:id/submit", handler.SubmitTask)
@@ -175,20 +276,30 @@ func (s *Server) setupRoutes() {
 
 				// 任务参与者管理
 				tasks.GET("/:id/participants", handler.GetTaskParticipants)
-				tasks.POST("/:id/participants", handler.AddTaskParticipant)
+				tasks.POST("/:id/participants", s.taskHandler.AddTaskParticipant)
 				tasks.DELETE("/:id/participants/:user_id", handler.RemoveTaskParticipant)
 
 				// 任务执行管理
 				tasks.POST("/:id/executions", handler.CreateTaskExecution)
 				tasks.GET("/:id/executions", handler.GetTaskExecutions)
-				tasks.POST("/:id/executions/:exec_id/work", handler.SubmitWork)
+				tasks.POST("/:id/executions/:exec_id/work", s.taskHandler.SubmitWork)
 				tasks.POST("/:id/executions/:exec_id/review", handler.ReviewWork)
+				tasks.GET("/:id/work-submissions", s.taskHandler.GetWorkSubmissions)
+				tasks.POST("/:id/work-submissions", s.taskHandler.SubmitWork)
 
 				// 延期申请
-				tasks.POST("/:id/extensions", handler.RequestExtension)
-				tasks.GET("/:id/extensions", handler.GetTaskExtensions)
-				tasks.PUT("/extensions/:ext_id/approve", handler.ApproveExtension)
-				tasks.PUT("/extensions/:ext_id/reject", handler.RejectExtension)
+				tasks.POST("/:id/extensions", s.taskHandler.RequestExtension)
+				tasks.GET("/:id/extensions", s.taskHandler.GetTaskExtensions)
+				tasks.PUT("/:id/extensions/:ext_id/approve", s.taskHandler.ApproveExtension)
+				tasks.PUT("/:id/extensions/:ext_id/reject", s.taskHandler.RejectExtension)
+
+				// 截止日期提醒
+				tasks.POST("/:id/reminders/acknowledge", s.reminderHandler.AcknowledgeReminders)
+
+				// 任务报表
+				tasks.GET("/reports/approved-not-started", s.taskHandler.GetStaleApprovedTasks)
+				tasks.GET("/reports/status-durations", s.taskHandler.GetTaskStatusDurations)
+				tasks.GET("/reports/estimate-variance", s.taskHandler.GetEstimateVariance)
 			}
 			// 文件管理
 			files := protected.Group("/files")
@@ -213,14 +324,26 @@ func (s *Server) setupRoutes() {
 			// 搜索
 			search := protected.Group("/search")
 			{
+				search.GET("", s.searchHandler.Search)
 				search.GET("/tasks", handler.SearchTasks)
 				search.GET("/projects", handler.SearchProjects)
 				search.GET("/users", handler.SearchUsers)
 			}
+
+			// 审批
+			approvals := protected.Group("/approvals")
+			{
+				approvals.GET("/mine", s.approvalHandler.ListMyApprovals)
+			}
 		}
 	}
 }
 
+// setupDebugRoutes 注册运行时指标（含数据库连接池）接口，JSON格式，仅管理员可访问
+func (s *Server) setupDebugRoutes() {
+	s.router.GET("/debug/vars", s.authMiddleware(), s.adminOnlyMiddleware(), gin.WrapH(expvar.Handler()))
+}
+
 // setupSwagger 设置Swagger文档路由
 func (s *Server) setupSwagger() {
 	// 只在开发和测试环境启用Swagger
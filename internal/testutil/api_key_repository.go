@@ -0,0 +1,119 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// MemoryAPIKeyRepository 内存API密钥仓储，仅用于测试
+type MemoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[string]aggregate.APIKey
+}
+
+// NewMemoryAPIKeyRepository 创建内存API密钥仓储
+func NewMemoryAPIKeyRepository(keys ...aggregate.APIKey) *MemoryAPIKeyRepository {
+	r := &MemoryAPIKeyRepository{keys: make(map[string]aggregate.APIKey)}
+	for _, key := range keys {
+		r.keys[key.ID] = cloneAPIKey(key)
+	}
+	return r
+}
+
+var _ repository.APIKeyRepository = (*MemoryAPIKeyRepository)(nil)
+
+// Create 新建API密钥，ID或哈希已存在时返回 ErrAlreadyExists
+func (r *MemoryAPIKeyRepository) Create(ctx context.Context, key aggregate.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.ID == key.ID || existing.KeyHash == key.KeyHash {
+			return fmt.Errorf("api key %s: %w", key.ID, repository.ErrAlreadyExists)
+		}
+	}
+	r.keys[key.ID] = cloneAPIKey(key)
+	return nil
+}
+
+// Update 更新API密钥，不存在时返回 ErrNotFound
+func (r *MemoryAPIKeyRepository) Update(ctx context.Context, key aggregate.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[key.ID]; !ok {
+		return fmt.Errorf("api key %s: %w", key.ID, repository.ErrNotFound)
+	}
+	r.keys[key.ID] = cloneAPIKey(key)
+	return nil
+}
+
+// FindByID 根据ID查找API密钥
+func (r *MemoryAPIKeyRepository) FindByID(ctx context.Context, id string) (*aggregate.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("api key %s: %w", id, repository.ErrNotFound)
+	}
+	clone := cloneAPIKey(key)
+	return &clone, nil
+}
+
+// FindByHash 根据密钥哈希查找API密钥
+func (r *MemoryAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*aggregate.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			clone := cloneAPIKey(key)
+			return &clone, nil
+		}
+	}
+	return nil, fmt.Errorf("api key by hash: %w", repository.ErrNotFound)
+}
+
+// FindByUser 查找用户的全部API密钥，按创建时间排序
+func (r *MemoryAPIKeyRepository) FindByUser(ctx context.Context, userID valueobject.UserID) ([]aggregate.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.APIKey, 0)
+	for _, key := range r.keys {
+		if key.UserID == userID {
+			result = append(result, cloneAPIKey(key))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// cloneAPIKey 深拷贝API密钥，避免调用方修改仓储内部状态
+func cloneAPIKey(k aggregate.APIKey) aggregate.APIKey {
+	clone := k
+	if k.Scopes != nil {
+		clone.Scopes = append([]string(nil), k.Scopes...)
+	}
+	if k.LastUsedAt != nil {
+		lastUsedAt := *k.LastUsedAt
+		clone.LastUsedAt = &lastUsedAt
+	}
+	if k.RevokedAt != nil {
+		revokedAt := *k.RevokedAt
+		clone.RevokedAt = &revokedAt
+	}
+	return clone
+}
@@ -0,0 +1,402 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/taskflow/internal/domain/auth/aggregate"
+	"github.com/taskflow/internal/domain/auth/domainerror"
+	"github.com/taskflow/internal/domain/auth/repository"
+	"github.com/taskflow/internal/domain/auth/valueobject"
+)
+
+// MemoryPermissionRepository 内存权限仓储，仅用于测试
+type MemoryPermissionRepository struct {
+	mu          sync.RWMutex
+	permissions map[valueobject.PermissionID]aggregate.Permission
+}
+
+// NewMemoryPermissionRepository 创建内存权限仓储
+func NewMemoryPermissionRepository(permissions ...*aggregate.Permission) *MemoryPermissionRepository {
+	r := &MemoryPermissionRepository{permissions: make(map[valueobject.PermissionID]aggregate.Permission)}
+	for _, permission := range permissions {
+		r.permissions[permission.ID] = *permission
+	}
+	return r
+}
+
+var _ repository.PermissionRepository = (*MemoryPermissionRepository)(nil)
+
+// Save 保存权限，已存在时覆盖
+func (r *MemoryPermissionRepository) Save(ctx context.Context, permission *aggregate.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.permissions[permission.ID] = *permission
+	return nil
+}
+
+// FindByID 根据ID查找权限
+func (r *MemoryPermissionRepository) FindByID(ctx context.Context, id valueobject.PermissionID) (*aggregate.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	permission, ok := r.permissions[id]
+	if !ok {
+		return nil, domainerror.NewDomainError(domainerror.ErrPermissionNotFound, "permission not found")
+	}
+	return &permission, nil
+}
+
+// FindByResourceAndAction 根据资源和操作查找权限
+func (r *MemoryPermissionRepository) FindByResourceAndAction(ctx context.Context, resource valueobject.ResourceType, action valueobject.ActionType) (*aggregate.Permission, error) {
+	permissions, _ := r.FindAll(ctx)
+	for _, permission := range permissions {
+		if permission.Matches(resource, action) {
+			return permission, nil
+		}
+	}
+	return nil, domainerror.NewDomainError(domainerror.ErrPermissionNotFound, "permission not found")
+}
+
+// FindAll 查找所有权限，按ID排序
+func (r *MemoryPermissionRepository) FindAll(ctx context.Context) ([]*aggregate.Permission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*aggregate.Permission, 0, len(r.permissions))
+	for _, permission := range r.permissions {
+		permission := permission
+		result = append(result, &permission)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// Delete 删除权限
+func (r *MemoryPermissionRepository) Delete(ctx context.Context, id valueobject.PermissionID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.permissions, id)
+	return nil
+}
+
+// MemoryRoleRepository 内存角色仓储，角色权限从关联的权限仓储中加载
+type MemoryRoleRepository struct {
+	mu          sync.RWMutex
+	roles       map[valueobject.RoleID]aggregate.Role
+	permissions repository.PermissionRepository
+}
+
+// NewMemoryRoleRepository 创建内存角色仓储
+func NewMemoryRoleRepository(permissions repository.PermissionRepository, roles ...*aggregate.Role) *MemoryRoleRepository {
+	r := &MemoryRoleRepository{
+		roles:       make(map[valueobject.RoleID]aggregate.Role),
+		permissions: permissions,
+	}
+	for _, role := range roles {
+		r.roles[role.ID] = cloneRole(role)
+	}
+	return r
+}
+
+var _ repository.RoleRepository = (*MemoryRoleRepository)(nil)
+
+// Save 保存角色，已存在时覆盖
+func (r *MemoryRoleRepository) Save(ctx context.Context, role *aggregate.Role) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roles[role.ID] = cloneRole(role)
+	return nil
+}
+
+// FindByID 根据ID查找角色
+func (r *MemoryRoleRepository) FindByID(ctx context.Context, id valueobject.RoleID) (*aggregate.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	role, ok := r.roles[id]
+	if !ok {
+		return nil, domainerror.NewDomainError(domainerror.ErrRoleNotFound, "role not found")
+	}
+	clone := cloneRole(&role)
+	return &clone, nil
+}
+
+// FindByName 根据名称查找角色
+func (r *MemoryRoleRepository) FindByName(ctx context.Context, name string) (*aggregate.Role, error) {
+	roles, _ := r.FindAll(ctx)
+	for _, role := range roles {
+		if role.Name == name {
+			return role, nil
+		}
+	}
+	return nil, domainerror.NewDomainError(domainerror.ErrRoleNotFound, "role not found")
+}
+
+// FindAll 查找所有角色，按ID排序
+func (r *MemoryRoleRepository) FindAll(ctx context.Context) ([]*aggregate.Role, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*aggregate.Role, 0, len(r.roles))
+	for _, role := range r.roles {
+		clone := cloneRole(&role)
+		result = append(result, &clone)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// Delete 删除角色
+func (r *MemoryRoleRepository) Delete(ctx context.Context, id valueobject.RoleID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.roles, id)
+	return nil
+}
+
+// AddPermissionToRole 为角色添加权限
+func (r *MemoryRoleRepository) AddPermissionToRole(ctx context.Context, roleID valueobject.RoleID, permissionID valueobject.PermissionID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	role, ok := r.roles[roleID]
+	if !ok {
+		return domainerror.NewDomainError(domainerror.ErrRoleNotFound, "role not found")
+	}
+	if !role.HasPermission(permissionID) {
+		role.Permissions = append(role.Permissions, permissionID)
+		r.roles[roleID] = role
+	}
+	return nil
+}
+
+// RemovePermissionFromRole 移除角色的权限
+func (r *MemoryRoleRepository) RemovePermissionFromRole(ctx context.Context, roleID valueobject.RoleID, permissionID valueobject.PermissionID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	role, ok := r.roles[roleID]
+	if !ok {
+		return domainerror.NewDomainError(domainerror.ErrRoleNotFound, "role not found")
+	}
+	remaining := make([]valueobject.PermissionID, 0, len(role.Permissions))
+	for _, pid := range role.Permissions {
+		if pid != permissionID {
+			remaining = append(remaining, pid)
+		}
+	}
+	role.Permissions = remaining
+	r.roles[roleID] = role
+	return nil
+}
+
+// FindPermissionsByRole 查找角色拥有的权限，忽略权限仓储中不存在的权限
+func (r *MemoryRoleRepository) FindPermissionsByRole(ctx context.Context, roleID valueobject.RoleID) ([]*aggregate.Permission, error) {
+	role, err := r.FindByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*aggregate.Permission, 0, len(role.Permissions))
+	for _, permissionID := range role.Permissions {
+		permission, err := r.permissions.FindByID(ctx, permissionID)
+		if err != nil {
+			continue
+		}
+		result = append(result, permission)
+	}
+	return result, nil
+}
+
+// MemoryUserRoleRepository 内存用户角色关联仓储，角色从关联的角色仓储中加载
+type MemoryUserRoleRepository struct {
+	mu        sync.RWMutex
+	userRoles map[string][]valueobject.RoleID
+	roles     repository.RoleRepository
+}
+
+// NewMemoryUserRoleRepository 创建内存用户角色关联仓储
+func NewMemoryUserRoleRepository(roles repository.RoleRepository) *MemoryUserRoleRepository {
+	return &MemoryUserRoleRepository{
+		userRoles: make(map[string][]valueobject.RoleID),
+		roles:     roles,
+	}
+}
+
+var _ repository.UserRoleRepository = (*MemoryUserRoleRepository)(nil)
+
+// AssignRole 为用户分配角色，重复分配时忽略
+func (r *MemoryUserRoleRepository) AssignRole(ctx context.Context, userID string, roleID valueobject.RoleID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range r.userRoles[userID] {
+		if id == roleID {
+			return nil
+		}
+	}
+	r.userRoles[userID] = append(r.userRoles[userID], roleID)
+	return nil
+}
+
+// RevokeRole 撤销用户角色
+func (r *MemoryUserRoleRepository) RevokeRole(ctx context.Context, userID string, roleID valueobject.RoleID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remaining := make([]valueobject.RoleID, 0, len(r.userRoles[userID]))
+	for _, id := range r.userRoles[userID] {
+		if id != roleID {
+			remaining = append(remaining, id)
+		}
+	}
+	r.userRoles[userID] = remaining
+	return nil
+}
+
+// FindRolesByUser 查找用户的角色，忽略角色仓储中不存在的角色
+func (r *MemoryUserRoleRepository) FindRolesByUser(ctx context.Context, userID string) ([]*aggregate.Role, error) {
+	r.mu.RLock()
+	roleIDs := append([]valueobject.RoleID(nil), r.userRoles[userID]...)
+	r.mu.RUnlock()
+
+	result := make([]*aggregate.Role, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		role, err := r.roles.FindByID(ctx, roleID)
+		if err != nil {
+			continue
+		}
+		result = append(result, role)
+	}
+	return result, nil
+}
+
+// FindUsersByRole 查找拥有指定角色的用户，按用户ID排序
+func (r *MemoryUserRoleRepository) FindUsersByRole(ctx context.Context, roleID valueobject.RoleID) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]string, 0)
+	for userID, roleIDs := range r.userRoles {
+		for _, id := range roleIDs {
+			if id == roleID {
+				result = append(result, userID)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// HasRole 检查用户是否拥有指定角色
+func (r *MemoryUserRoleRepository) HasRole(ctx context.Context, userID string, roleID valueobject.RoleID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range r.userRoles[userID] {
+		if id == roleID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// MemoryPolicyRepository 内存策略仓储，仅用于测试
+type MemoryPolicyRepository struct {
+	mu       sync.RWMutex
+	policies map[valueobject.PolicyID]aggregate.Policy
+}
+
+// NewMemoryPolicyRepository 创建内存策略仓储
+func NewMemoryPolicyRepository(policies ...*aggregate.Policy) *MemoryPolicyRepository {
+	r := &MemoryPolicyRepository{policies: make(map[valueobject.PolicyID]aggregate.Policy)}
+	for _, policy := range policies {
+		r.policies[policy.ID] = *policy
+	}
+	return r
+}
+
+var _ repository.PolicyRepository = (*MemoryPolicyRepository)(nil)
+
+// Save 保存策略，已存在时覆盖，策略不合法时返回 ErrInvalidPolicy
+func (r *MemoryPolicyRepository) Save(ctx context.Context, policy *aggregate.Policy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.policies[policy.ID] = *policy
+	return nil
+}
+
+// FindByID 根据ID查找策略
+func (r *MemoryPolicyRepository) FindByID(ctx context.Context, id valueobject.PolicyID) (*aggregate.Policy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policy, ok := r.policies[id]
+	if !ok {
+		return nil, domainerror.NewDomainError(domainerror.ErrPolicyNotFound, "policy not found")
+	}
+	return &policy, nil
+}
+
+// FindByResourceAndAction 查找作用于指定资源和操作的策略（含未激活的策略）
+func (r *MemoryPolicyRepository) FindByResourceAndAction(ctx context.Context, resource valueobject.ResourceType, action valueobject.ActionType) ([]*aggregate.Policy, error) {
+	return r.filter(func(p aggregate.Policy) bool { return p.Resource == resource && p.Action == action }), nil
+}
+
+// FindAllActive 查找所有已激活的策略
+func (r *MemoryPolicyRepository) FindAllActive(ctx context.Context) ([]*aggregate.Policy, error) {
+	return r.filter(func(p aggregate.Policy) bool { return p.IsActive }), nil
+}
+
+// Delete 删除策略
+func (r *MemoryPolicyRepository) Delete(ctx context.Context, id valueobject.PolicyID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.policies, id)
+	return nil
+}
+
+// CountByResource 统计作用于指定资源的策略数
+func (r *MemoryPolicyRepository) CountByResource(ctx context.Context, resource valueobject.ResourceType) (int64, error) {
+	return int64(len(r.filter(func(p aggregate.Policy) bool { return p.Resource == resource }))), nil
+}
+
+// filter 按条件筛选策略，结果按优先级降序
+func (r *MemoryPolicyRepository) filter(match func(aggregate.Policy) bool) []*aggregate.Policy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*aggregate.Policy, 0)
+	for _, policy := range r.policies {
+		if match(policy) {
+			policy := policy
+			result = append(result, &policy)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Priority == result[j].Priority {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].Priority > result[j].Priority
+	})
+	return result
+}
+
+// cloneRole 复制角色的权限列表，避免调用方修改仓储中的数据
+func cloneRole(role *aggregate.Role) aggregate.Role {
+	clone := *role
+	clone.Permissions = append([]valueobject.PermissionID(nil), role.Permissions...)
+	return clone
+}
@@ -0,0 +1,91 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// FileAssociation 内存文件仓储中的资源关联记录
+type FileAssociation struct {
+	FileID       string
+	ResourceType string
+	ResourceID   string
+}
+
+// MemoryFileRepository 内存文件仓储，仅用于测试
+type MemoryFileRepository struct {
+	mu           sync.RWMutex
+	files        map[string]valueobject.FileInfo
+	associations []FileAssociation
+}
+
+// NewMemoryFileRepository 创建内存文件仓储
+func NewMemoryFileRepository(files ...valueobject.FileInfo) *MemoryFileRepository {
+	r := &MemoryFileRepository{files: make(map[string]valueobject.FileInfo)}
+	for _, file := range files {
+		r.files[file.ID] = file
+	}
+	return r
+}
+
+var _ repository.FileRepository = (*MemoryFileRepository)(nil)
+
+// FindByIDs 查找文件元数据，忽略不存在的文件
+func (r *MemoryFileRepository) FindByIDs(ctx context.Context, ids []string) ([]valueobject.FileInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	files := []valueobject.FileInfo{}
+	for _, id := range ids {
+		if file, ok := r.files[id]; ok {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// FindAssociatedFileIDs 查找已关联到资源的文件ID
+func (r *MemoryFileRepository) FindAssociatedFileIDs(ctx context.Context, resourceType, resourceID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fileIDs := []string{}
+	for _, a := range r.associations {
+		if a.ResourceType == resourceType && a.ResourceID == resourceID {
+			fileIDs = append(fileIDs, a.FileID)
+		}
+	}
+	return fileIDs, nil
+}
+
+// AssociateAttachments 将文件关联到资源，已关联的文件跳过
+func (r *MemoryFileRepository) AssociateAttachments(ctx context.Context, fileIDs []string, resourceType, resourceID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, fileID := range fileIDs {
+		if !r.isAssociated(fileID, resourceType, resourceID) {
+			r.associations = append(r.associations, FileAssociation{FileID: fileID, ResourceType: resourceType, ResourceID: resourceID})
+		}
+	}
+	return nil
+}
+
+// Associations 返回全部关联记录
+func (r *MemoryFileRepository) Associations() []FileAssociation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]FileAssociation{}, r.associations...)
+}
+
+func (r *MemoryFileRepository) isAssociated(fileID, resourceType, resourceID string) bool {
+	for _, a := range r.associations {
+		if a.FileID == fileID && a.ResourceType == resourceType && a.ResourceID == resourceID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// MemoryNotificationRepository 内存站内通知仓储，仅用于测试
+type MemoryNotificationRepository struct {
+	mu            sync.RWMutex
+	notifications []aggregate.Notification
+}
+
+// NewMemoryNotificationRepository 创建内存站内通知仓储
+func NewMemoryNotificationRepository(notifications ...aggregate.Notification) *MemoryNotificationRepository {
+	return &MemoryNotificationRepository{notifications: append([]aggregate.Notification(nil), notifications...)}
+}
+
+var _ repository.NotificationRepository = (*MemoryNotificationRepository)(nil)
+
+// Create 批量写入通知
+func (r *MemoryNotificationRepository) Create(ctx context.Context, notifications []aggregate.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.notifications = append(r.notifications, notifications...)
+	return nil
+}
+
+// FindByUser 按创建时间倒序分页查询用户的通知
+func (r *MemoryNotificationRepository) FindByUser(ctx context.Context, userID valueobject.UserID, unreadOnly bool, limit, offset int) ([]aggregate.Notification, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.Notification, 0)
+	for _, n := range r.notifications {
+		if n.UserID == userID && (!unreadOnly || !n.Read) {
+			result = append(result, n)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].ID > result[j].ID
+	})
+	return paginate(result, limit, offset), len(result), nil
+}
+
+// CountUnread 统计用户的未读通知数
+func (r *MemoryNotificationRepository) CountUnread(ctx context.Context, userID valueobject.UserID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, n := range r.notifications {
+		if n.UserID == userID && !n.Read {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MarkRead 标记用户的一条通知为已读，通知不存在或不属于该用户时返回 ErrNotFound
+func (r *MemoryNotificationRepository) MarkRead(ctx context.Context, userID valueobject.UserID, id string) (*aggregate.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.notifications {
+		n := &r.notifications[i]
+		if n.ID == id && n.UserID == userID {
+			n.MarkRead(time.Now())
+			clone := *n
+			return &clone, nil
+		}
+	}
+	return nil, fmt.Errorf("notification %s: %w", id, repository.ErrNotFound)
+}
+
+// MarkAllRead 标记用户的全部未读通知为已读
+func (r *MemoryNotificationRepository) MarkAllRead(ctx context.Context, userID valueobject.UserID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for i := range r.notifications {
+		n := &r.notifications[i]
+		if n.UserID == userID && !n.Read {
+			n.MarkRead(now)
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,76 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// MemoryOperationLogRepository 内存操作日志仓储，仅用于测试
+type MemoryOperationLogRepository struct {
+	mu   sync.RWMutex
+	logs []aggregate.OperationLog
+}
+
+// NewMemoryOperationLogRepository 创建内存操作日志仓储
+func NewMemoryOperationLogRepository() *MemoryOperationLogRepository {
+	return &MemoryOperationLogRepository{}
+}
+
+var _ repository.OperationLogRepository = (*MemoryOperationLogRepository)(nil)
+
+// Create 追加一条操作日志
+func (r *MemoryOperationLogRepository) Create(ctx context.Context, log aggregate.OperationLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+// FindByUser 查找用户最近的操作日志，按创建时间倒序
+func (r *MemoryOperationLogRepository) FindByUser(ctx context.Context, userID valueobject.UserID, limit int) ([]aggregate.OperationLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.OperationLog, 0)
+	for _, log := range r.logs {
+		if log.UserID == userID {
+			result = append(result, log)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// CountByUserSince 统计用户在 since 之后执行指定操作的次数
+func (r *MemoryOperationLogRepository) CountByUserSince(ctx context.Context, userID valueobject.UserID, operation string, since time.Time) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, log := range r.logs {
+		if log.UserID == userID && log.Operation == operation && !log.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// All 返回全部操作日志，按写入顺序
+func (r *MemoryOperationLogRepository) All() []aggregate.OperationLog {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]aggregate.OperationLog(nil), r.logs...)
+}
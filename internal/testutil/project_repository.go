@@ -0,0 +1,293 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// MemoryProjectRepository 内存项目仓储，仅用于测试
+type MemoryProjectRepository struct {
+	mu       sync.RWMutex
+	projects map[valueobject.ProjectID]aggregate.Project
+	tasks    repository.TaskRepository
+}
+
+// NewMemoryProjectRepository 创建内存项目仓储
+func NewMemoryProjectRepository(projects ...aggregate.Project) *MemoryProjectRepository {
+	r := &MemoryProjectRepository{projects: make(map[valueobject.ProjectID]aggregate.Project)}
+	for _, project := range projects {
+		r.projects[project.ID] = cloneProject(project)
+	}
+	return r
+}
+
+var _ repository.ProjectRepository = (*MemoryProjectRepository)(nil)
+
+// WithTaskRepository 设置 SyncTaskStatistics 统计使用的任务仓储
+func (r *MemoryProjectRepository) WithTaskRepository(tasks repository.TaskRepository) *MemoryProjectRepository {
+	r.tasks = tasks
+	return r
+}
+
+// Create 新建项目，ID已存在时返回 ErrAlreadyExists
+func (r *MemoryProjectRepository) Create(ctx context.Context, project aggregate.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[project.ID]; ok {
+		return fmt.Errorf("project %s: %w", project.ID, repository.ErrAlreadyExists)
+	}
+	project.CreatedBy = auditUser(ctx, project.CreatedBy, project.OwnerID)
+	project.UpdatedBy = auditUser(ctx, project.UpdatedBy, project.CreatedBy)
+	r.projects[project.ID] = cloneProject(project)
+	return nil
+}
+
+// Update 更新项目，项目不存在时返回 ErrNotFound
+func (r *MemoryProjectRepository) Update(ctx context.Context, project aggregate.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.projects[project.ID]
+	if !ok {
+		return fmt.Errorf("project %s: %w", project.ID, repository.ErrNotFound)
+	}
+	project.CreatedBy = existing.CreatedBy
+	project.UpdatedBy = auditUser(ctx, project.UpdatedBy)
+	// 任务计数只由 SyncTaskStatistics 维护
+	project.TaskCount, project.CompletedTasks = existing.TaskCount, existing.CompletedTasks
+	r.projects[project.ID] = cloneProject(project)
+	return nil
+}
+
+// FindByID 根据ID查找项目
+func (r *MemoryProjectRepository) FindByID(ctx context.Context, id valueobject.ProjectID) (*aggregate.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, ok := r.projects[id]
+	if !ok {
+		return nil, fmt.Errorf("project %s: %w", id, repository.ErrNotFound)
+	}
+	clone := cloneProject(project)
+	return &clone, nil
+}
+
+// FindByIDs 根据ID列表查找项目，忽略不存在的ID
+func (r *MemoryProjectRepository) FindByIDs(ctx context.Context, ids []valueobject.ProjectID) ([]aggregate.Project, error) {
+	wanted := make(map[valueobject.ProjectID]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return r.filter(func(p aggregate.Project) bool { return wanted[p.ID] }), nil
+}
+
+// Delete 删除项目
+func (r *MemoryProjectRepository) Delete(ctx context.Context, id valueobject.ProjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.projects, id)
+	return nil
+}
+
+// FindByOwner 查找用户拥有的项目
+func (r *MemoryProjectRepository) FindByOwner(ctx context.Context, ownerID valueobject.UserID) ([]aggregate.Project, error) {
+	return r.filter(func(p aggregate.Project) bool { return p.OwnerID == ownerID }), nil
+}
+
+// FindByManager 查找用户管理的项目
+func (r *MemoryProjectRepository) FindByManager(ctx context.Context, managerID valueobject.UserID) ([]aggregate.Project, error) {
+	return r.filter(func(p aggregate.Project) bool { return p.ManagerID != nil && *p.ManagerID == managerID }), nil
+}
+
+// FindByMember 查找用户作为成员参与的项目
+func (r *MemoryProjectRepository) FindByMember(ctx context.Context, userID valueobject.UserID) ([]aggregate.Project, error) {
+	return r.filter(func(p aggregate.Project) bool { return hasProjectMember(p, userID) }), nil
+}
+
+// FindByParent 查找子项目
+func (r *MemoryProjectRepository) FindByParent(ctx context.Context, parentID valueobject.ProjectID) ([]aggregate.Project, error) {
+	return r.filter(func(p aggregate.Project) bool { return p.ParentID != nil && *p.ParentID == parentID }), nil
+}
+
+// FindByStatus 按状态查找项目
+func (r *MemoryProjectRepository) FindByStatus(ctx context.Context, status valueobject.ProjectStatus) ([]aggregate.Project, error) {
+	return r.filter(func(p aggregate.Project) bool { return p.Status == status }), nil
+}
+
+// FindByType 按类型查找项目
+func (r *MemoryProjectRepository) FindByType(ctx context.Context, projectType valueobject.ProjectType) ([]aggregate.Project, error) {
+	return r.filter(func(p aggregate.Project) bool { return p.ProjectType == projectType }), nil
+}
+
+// FindByDateRange 查找在时间窗口内处于进行期的项目
+func (r *MemoryProjectRepository) FindByDateRange(ctx context.Context, from, to time.Time) ([]aggregate.Project, error) {
+	return r.filter(func(p aggregate.Project) bool { return p.IsActiveWithin(from, to) }), nil
+}
+
+// SearchProjects 按条件搜索项目，名称和描述与MySQL实现一样按关键字匹配
+func (r *MemoryProjectRepository) SearchProjects(ctx context.Context, criteria aggregate.ProjectSearchCriteria) ([]aggregate.Project, int, error) {
+	matched := r.filter(func(p aggregate.Project) bool {
+		switch {
+		case !matchesTerm(p.Name, criteria.Name, criteria.PrefixMatch):
+			return false
+		case !matchesTerm(p.Description, criteria.Description, criteria.PrefixMatch):
+			return false
+		case criteria.ProjectType != nil && p.ProjectType != *criteria.ProjectType:
+			return false
+		case criteria.Status != nil && p.Status != *criteria.Status:
+			return false
+		case criteria.OwnerID != nil && p.OwnerID != *criteria.OwnerID:
+			return false
+		case criteria.ManagerID != nil && (p.ManagerID == nil || *p.ManagerID != *criteria.ManagerID):
+			return false
+		case criteria.MemberID != nil && !hasProjectMember(p, *criteria.MemberID):
+			return false
+		case criteria.ParentID != nil && (p.ParentID == nil || *p.ParentID != *criteria.ParentID):
+			return false
+		case criteria.AccessibleBy != nil && !p.CanUserAccess(*criteria.AccessibleBy):
+			return false
+		}
+		return true
+	})
+	return paginate(matched, criteria.Limit, criteria.Offset), len(matched), nil
+}
+
+// FindUserAccessibleProjects 查找用户拥有、管理或参与的项目
+func (r *MemoryProjectRepository) FindUserAccessibleProjects(ctx context.Context, userID valueobject.UserID, limit, offset int) ([]aggregate.Project, int, error) {
+	matched := r.filter(func(p aggregate.Project) bool { return p.CanUserAccess(userID) })
+	return paginate(matched, limit, offset), len(matched), nil
+}
+
+// CountByOwner 统计用户拥有的项目数
+func (r *MemoryProjectRepository) CountByOwner(ctx context.Context, ownerID valueobject.UserID) (int, error) {
+	projects, _ := r.FindByOwner(ctx, ownerID)
+	return len(projects), nil
+}
+
+// CountByStatus 统计指定状态的项目数
+func (r *MemoryProjectRepository) CountByStatus(ctx context.Context, status valueobject.ProjectStatus) (int, error) {
+	projects, _ := r.FindByStatus(ctx, status)
+	return len(projects), nil
+}
+
+// GetProjectStatistics 获取项目统计信息（内存实现只统计成员和任务计数）
+func (r *MemoryProjectRepository) GetProjectStatistics(ctx context.Context, projectID valueobject.ProjectID) (*aggregate.ProjectStatistics, error) {
+	project, err := r.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &aggregate.ProjectStatistics{
+		ProjectID:      project.ID,
+		TotalTasks:     project.TaskCount,
+		CompletedTasks: project.CompletedTasks,
+		TotalMembers:   len(project.Members),
+		ActiveMembers:  len(project.Members),
+		LastActivityAt: project.UpdatedAt,
+	}
+	if stats.TotalTasks > 0 {
+		stats.CompletionRate = float64(stats.CompletedTasks) / float64(stats.TotalTasks) * 100
+	}
+	return stats, nil
+}
+
+// FindProjectsWithOverdueTasks 按任务仓储统计逾期任务，分页返回有逾期任务的项目，未设置任务仓储时返回空结果
+func (r *MemoryProjectRepository) FindProjectsWithOverdueTasks(ctx context.Context, asOf time.Time, limit, offset int) ([]aggregate.ProjectOverdueSummary, int, error) {
+	if r.tasks == nil {
+		return []aggregate.ProjectOverdueSummary{}, 0, nil
+	}
+	tasks, err := r.tasks.FindOverdueTasks(ctx, asOf)
+	if err != nil {
+		return nil, 0, err
+	}
+	counts := make(map[valueobject.ProjectID]int)
+	for _, task := range tasks {
+		counts[task.ProjectID]++
+	}
+
+	r.mu.RLock()
+	summaries := make([]aggregate.ProjectOverdueSummary, 0, len(counts))
+	for id, count := range counts {
+		if project, ok := r.projects[id]; ok {
+			summaries = append(summaries, aggregate.ProjectOverdueSummary{Project: cloneProject(project), OverdueTasks: count})
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].OverdueTasks != summaries[j].OverdueTasks {
+			return summaries[i].OverdueTasks > summaries[j].OverdueTasks
+		}
+		return summaries[i].Project.ID < summaries[j].Project.ID
+	})
+	return paginate(summaries, limit, offset), len(summaries), nil
+}
+
+// SyncTaskStatistics 按任务仓储重新统计项目的任务总数和已完成数，统计和写入在同一把锁内完成
+func (r *MemoryProjectRepository) SyncTaskStatistics(ctx context.Context, projectID valueobject.ProjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	project, ok := r.projects[projectID]
+	if !ok || r.tasks == nil {
+		return nil
+	}
+	tasks, err := r.tasks.FindByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	project.TaskCount, project.CompletedTasks = len(tasks), 0
+	for _, task := range tasks {
+		if task.Status == valueobject.TaskStatusCompleted {
+			project.CompletedTasks++
+		}
+	}
+	r.projects[projectID] = project
+	return nil
+}
+
+// filter 按条件筛选项目，结果按创建时间升序
+func (r *MemoryProjectRepository) filter(match func(aggregate.Project) bool) []aggregate.Project {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.Project, 0)
+	for _, project := range r.projects {
+		if match(project) {
+			result = append(result, cloneProject(project))
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// cloneProject 复制项目的切片字段，避免调用方修改仓储中的数据
+func cloneProject(project aggregate.Project) aggregate.Project {
+	project.Members = append([]valueobject.ProjectMember(nil), project.Members...)
+	project.Children = append([]valueobject.ProjectID(nil), project.Children...)
+	project.Events = nil
+	return project
+}
+
+// hasProjectMember 用户是否在项目成员列表中
+func hasProjectMember(project aggregate.Project, userID valueobject.UserID) bool {
+	for _, member := range project.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
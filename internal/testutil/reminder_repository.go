@@ -0,0 +1,73 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// MemoryReminderRepository 内存截止日期提醒记录仓储，仅用于测试
+type MemoryReminderRepository struct {
+	mu    sync.RWMutex
+	sent  []valueobject.TaskReminder
+	acked map[reminderAckKey]time.Time
+}
+
+type reminderAckKey struct {
+	taskID valueobject.TaskID
+	userID valueobject.UserID
+}
+
+// NewMemoryReminderRepository 创建内存截止日期提醒记录仓储
+func NewMemoryReminderRepository() *MemoryReminderRepository {
+	return &MemoryReminderRepository{acked: make(map[reminderAckKey]time.Time)}
+}
+
+var _ repository.ReminderRepository = (*MemoryReminderRepository)(nil)
+
+// RecordSent 记录已发送的提醒，相同任务、用户、截止时间和梯度已有记录时返回 false
+func (r *MemoryReminderRepository) RecordSent(ctx context.Context, reminder valueobject.TaskReminder) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, sent := range r.sent {
+		if sent.TaskID == reminder.TaskID && sent.UserID == reminder.UserID &&
+			sent.DueDate.Equal(reminder.DueDate) && sent.Offset == reminder.Offset {
+			return false, nil
+		}
+	}
+	r.sent = append(r.sent, reminder)
+	return true, nil
+}
+
+// Acknowledge 记录用户已确认任务的提醒，重复确认保持首次确认时间
+func (r *MemoryReminderRepository) Acknowledge(ctx context.Context, taskID valueobject.TaskID, userID valueobject.UserID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reminderAckKey{taskID, userID}
+	if _, ok := r.acked[key]; !ok {
+		r.acked[key] = at
+	}
+	return nil
+}
+
+// IsAcknowledged 用户是否已确认任务的提醒
+func (r *MemoryReminderRepository) IsAcknowledged(ctx context.Context, taskID valueobject.TaskID, userID valueobject.UserID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.acked[reminderAckKey{taskID, userID}]
+	return ok, nil
+}
+
+// Sent 返回已记录的提醒
+func (r *MemoryReminderRepository) Sent() []valueobject.TaskReminder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]valueobject.TaskReminder(nil), r.sent...)
+}
@@ -0,0 +1,476 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/shared"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// MemoryTaskRepository 内存任务仓储，仅用于测试
+type MemoryTaskRepository struct {
+	mu    sync.RWMutex
+	tasks map[valueobject.TaskID]aggregate.TaskAggregate
+}
+
+// NewMemoryTaskRepository 创建内存任务仓储
+func NewMemoryTaskRepository(tasks ...aggregate.TaskAggregate) *MemoryTaskRepository {
+	r := &MemoryTaskRepository{tasks: make(map[valueobject.TaskID]aggregate.TaskAggregate)}
+	for _, task := range tasks {
+		r.tasks[task.ID] = cloneTask(task)
+	}
+	return r
+}
+
+var _ repository.TaskRepository = (*MemoryTaskRepository)(nil)
+
+// auditUser 与MySQL仓储一致：优先记录上下文中的当前操作用户，未设置时依次使用候选值
+func auditUser(ctx context.Context, fallbacks ...valueobject.UserID) valueobject.UserID {
+	if actor := shared.ActorFromContext(ctx); actor != "" {
+		return valueobject.UserID(actor)
+	}
+	for _, userID := range fallbacks {
+		if userID != "" {
+			return userID
+		}
+	}
+	return ""
+}
+
+// Create 新建任务，ID已存在时返回 ErrAlreadyExists
+func (r *MemoryTaskRepository) Create(ctx context.Context, task aggregate.TaskAggregate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[task.ID]; ok {
+		return fmt.Errorf("task %s: %w", task.ID, repository.ErrAlreadyExists)
+	}
+	task.UpdatedBy = auditUser(ctx, task.UpdatedBy, task.CreatorID)
+	r.tasks[task.ID] = cloneTask(task)
+	return nil
+}
+
+// Update 更新任务，任务不存在时返回 ErrNotFound
+func (r *MemoryTaskRepository) Update(ctx context.Context, task aggregate.TaskAggregate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[task.ID]; !ok {
+		return fmt.Errorf("task %s: %w", task.ID, repository.ErrNotFound)
+	}
+	task.UpdatedBy = auditUser(ctx, task.UpdatedBy)
+	r.tasks[task.ID] = cloneTask(task)
+	return nil
+}
+
+// FindByID 根据ID查找任务
+func (r *MemoryTaskRepository) FindByID(ctx context.Context, id valueobject.TaskID) (*aggregate.TaskAggregate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("task %s: %w", id, repository.ErrNotFound)
+	}
+	clone := cloneTask(task)
+	return &clone, nil
+}
+
+// FindByIDForUpdate 内存仓储没有行锁，与 FindByID 相同
+func (r *MemoryTaskRepository) FindByIDForUpdate(ctx context.Context, id valueobject.TaskID) (*aggregate.TaskAggregate, error) {
+	return r.FindByID(ctx, id)
+}
+
+// FindByIDs 根据ID列表查找任务，忽略不存在的ID
+func (r *MemoryTaskRepository) FindByIDs(ctx context.Context, ids []valueobject.TaskID) ([]aggregate.TaskAggregate, error) {
+	wanted := make(map[valueobject.TaskID]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return r.filter(func(t aggregate.TaskAggregate) bool { return wanted[t.ID] }), nil
+}
+
+// Delete 删除任务
+func (r *MemoryTaskRepository) Delete(ctx context.Context, id valueobject.TaskID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.tasks, id)
+	return nil
+}
+
+// BatchDelete 批量删除任务
+func (r *MemoryTaskRepository) BatchDelete(ctx context.Context, ids []valueobject.TaskID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		delete(r.tasks, id)
+	}
+	return nil
+}
+
+// FindByProject 查找项目下的任务
+func (r *MemoryTaskRepository) FindByProject(ctx context.Context, projectID valueobject.ProjectID) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool { return t.ProjectID == projectID }), nil
+}
+
+// FindByCreator 查找用户创建的任务
+func (r *MemoryTaskRepository) FindByCreator(ctx context.Context, creatorID valueobject.UserID) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool { return t.CreatorID == creatorID }), nil
+}
+
+// FindByResponsible 查找用户负责或共同负责的任务
+func (r *MemoryTaskRepository) FindByResponsible(ctx context.Context, responsibleID valueobject.UserID) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool { return t.IsResponsible(responsibleID) }), nil
+}
+
+// FindByParticipant 查找用户参与的任务
+func (r *MemoryTaskRepository) FindByParticipant(ctx context.Context, participantID valueobject.UserID) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool { return t.IsParticipant(participantID) }), nil
+}
+
+// FindByStatus 按状态查找任务
+func (r *MemoryTaskRepository) FindByStatus(ctx context.Context, status valueobject.TaskStatus) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool { return t.Status == status }), nil
+}
+
+// FindByPriority 按优先级查找任务
+func (r *MemoryTaskRepository) FindByPriority(ctx context.Context, priority valueobject.TaskPriority) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool { return t.Priority == priority }), nil
+}
+
+// FindByType 按类型查找任务
+func (r *MemoryTaskRepository) FindByType(ctx context.Context, taskType valueobject.TaskType) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool { return t.TaskType == taskType }), nil
+}
+
+// SearchTasks 按条件搜索任务，标题和描述为包含匹配
+func (r *MemoryTaskRepository) SearchTasks(ctx context.Context, criteria valueobject.TaskSearchCriteria) ([]aggregate.TaskAggregate, int, error) {
+	matched := r.filter(func(t aggregate.TaskAggregate) bool {
+		switch {
+		case !matchesTerm(t.Title, criteria.Title, criteria.PrefixMatch):
+			return false
+		case !matchesTerm(stringValue(t.Description), criteria.Description, criteria.PrefixMatch):
+			return false
+		case criteria.TaskType != nil && t.TaskType != *criteria.TaskType:
+			return false
+		case criteria.Priority != nil && t.Priority != *criteria.Priority:
+			return false
+		case criteria.Status != nil && t.Status != *criteria.Status:
+			return false
+		case criteria.ProjectID != nil && t.ProjectID != *criteria.ProjectID:
+			return false
+		case criteria.CreatorID != nil && t.CreatorID != *criteria.CreatorID:
+			return false
+		case criteria.ResponsibleID != nil && t.ResponsibleID != *criteria.ResponsibleID:
+			return false
+		case criteria.ParticipantID != nil && !t.IsParticipant(*criteria.ParticipantID):
+			return false
+		case criteria.StartDate != nil && (t.StartDate == nil || t.StartDate.Before(*criteria.StartDate)):
+			return false
+		case criteria.DueDate != nil && (t.DueDate == nil || t.DueDate.After(*criteria.DueDate)):
+			return false
+		case criteria.CreatedAfter != nil && t.CreatedAt.Before(*criteria.CreatedAfter):
+			return false
+		case criteria.CreatedBefore != nil && t.CreatedAt.After(*criteria.CreatedBefore):
+			return false
+		case criteria.AccessibleBy != nil && !t.CanUserView(*criteria.AccessibleBy):
+			return false
+		}
+		return true
+	})
+	return paginate(matched, criteria.Limit, criteria.Offset), len(matched), nil
+}
+
+// FindOverdueTasks 查找截止时间早于指定时间且未完成的任务
+func (r *MemoryTaskRepository) FindOverdueTasks(ctx context.Context, asOfDate time.Time) ([]aggregate.TaskAggregate, error) {
+	return r.filter(func(t aggregate.TaskAggregate) bool {
+		return t.DueDate != nil && t.DueDate.Before(asOfDate) && !isTaskClosed(t.Status)
+	}), nil
+}
+
+// FindTasksDueWithin 查找在指定时长内到期且未完成的任务，按截止时间升序
+func (r *MemoryTaskRepository) FindTasksDueWithin(ctx context.Context, duration time.Duration) ([]aggregate.TaskAggregate, error) {
+	now := time.Now()
+	return r.FindTasksDueBetween(ctx, now, now.Add(duration))
+}
+
+// FindTasksDueBetween 查找截止时间在 [from, to] 内且未完成的任务，按截止时间升序
+func (r *MemoryTaskRepository) FindTasksDueBetween(ctx context.Context, from, to time.Time) ([]aggregate.TaskAggregate, error) {
+	tasks := r.filter(func(t aggregate.TaskAggregate) bool {
+		return t.DueDate != nil && !t.DueDate.Before(from) && !t.DueDate.After(to) && !isTaskClosed(t.Status)
+	})
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+	return tasks, nil
+}
+
+// FindApprovedNotStarted 查找审批通过时间早于 approvedBefore 且仍处于已审批状态的任务，按审批时间升序
+func (r *MemoryTaskRepository) FindApprovedNotStarted(ctx context.Context, approvedBefore time.Time, projectID *valueobject.ProjectID) ([]aggregate.TaskAggregate, error) {
+	tasks := r.filter(func(t aggregate.TaskAggregate) bool {
+		return t.Status == valueobject.TaskStatusApproved && t.ApprovedAt != nil && t.ApprovedAt.Before(approvedBefore) &&
+			(projectID == nil || t.ProjectID == *projectID)
+	})
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ApprovedAt.Before(*tasks[j].ApprovedAt) })
+	return tasks, nil
+}
+
+// FindWithEstimateAndActual 查找同时记录了预估工时和实际工时的任务，按任务ID升序
+func (r *MemoryTaskRepository) FindWithEstimateAndActual(ctx context.Context, projectID *valueobject.ProjectID, responsibleID *valueobject.UserID) ([]aggregate.TaskAggregate, error) {
+	tasks := r.filter(func(t aggregate.TaskAggregate) bool {
+		return t.EstimatedHours > 0 && t.ActualHours > 0 &&
+			(projectID == nil || t.ProjectID == *projectID) &&
+			(responsibleID == nil || t.ResponsibleID == *responsibleID)
+	})
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// FindUserAccessibleTasks 查找用户创建、负责或参与的任务
+func (r *MemoryTaskRepository) FindUserAccessibleTasks(ctx context.Context, userID valueobject.UserID, limit, offset int) ([]aggregate.TaskAggregate, int, error) {
+	matched := r.filter(func(t aggregate.TaskAggregate) bool { return t.CanUserView(userID) })
+	return paginate(matched, limit, offset), len(matched), nil
+}
+
+// FindActionRequired 分页查找用户需要处理的未结束任务，按截止时间升序，无截止时间的排在最后
+func (r *MemoryTaskRepository) FindActionRequired(ctx context.Context, userID valueobject.UserID, limit, offset int) ([]aggregate.TaskAggregate, int, error) {
+	tasks := r.filter(func(t aggregate.TaskAggregate) bool {
+		if t.Status == valueobject.TaskStatusCompleted || t.Status == valueobject.TaskStatusCancelled {
+			return false
+		}
+		return (t.IsResponsible(userID) && t.Status == valueobject.TaskStatusInProgress) ||
+			len(t.SubmissionsAwaitingReview(userID)) > 0 ||
+			(t.GetPendingExtension() != nil && t.CanUserApprove(userID))
+	})
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].DueDate == nil || tasks[j].DueDate == nil {
+			return tasks[j].DueDate == nil && tasks[i].DueDate != nil
+		}
+		return tasks[i].DueDate.Before(*tasks[j].DueDate)
+	})
+	return paginate(tasks, limit, offset), len(tasks), nil
+}
+
+// FindExtensionsByTask 查找任务的延期申请，按申请时间升序
+func (r *MemoryTaskRepository) FindExtensionsByTask(ctx context.Context, taskID valueobject.TaskID) ([]valueobject.ExtensionRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[taskID]
+	if !ok {
+		return []valueobject.ExtensionRequest{}, nil
+	}
+	extensions := append([]valueobject.ExtensionRequest{}, task.Extensions...)
+	sort.SliceStable(extensions, func(i, j int) bool {
+		return extensions[i].RequestedAt.Before(extensions[j].RequestedAt)
+	})
+	return extensions, nil
+}
+
+// FindSubmittedApprovals 查找用户提交的审批记录，按提交时间倒序、同一时间按ID升序分页
+func (r *MemoryTaskRepository) FindSubmittedApprovals(ctx context.Context, criteria valueobject.SubmittedApprovalCriteria) ([]valueobject.SubmittedApproval, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wants := func(kind valueobject.SubmittedApprovalKind) bool {
+		return criteria.Kind == "" || criteria.Kind == kind
+	}
+	approvals := make([]valueobject.SubmittedApproval, 0)
+	add := func(approval valueobject.SubmittedApproval) {
+		if criteria.Status == "" || approval.Status == criteria.Status {
+			approvals = append(approvals, approval)
+		}
+	}
+	for _, task := range r.tasks {
+		if wants(valueobject.SubmittedApprovalTaskCreation) && task.CreatorID == criteria.RequesterID {
+			var status valueobject.ApprovalStatus
+			switch {
+			case task.Status == valueobject.TaskStatusPendingApproval:
+				status = valueobject.ApprovalStatusPending
+			case task.Status == valueobject.TaskStatusRejected:
+				status = valueobject.ApprovalStatusRejected
+			case task.ApprovedAt != nil:
+				status = valueobject.ApprovalStatusApproved
+			}
+			if status != "" {
+				add(valueobject.SubmittedApproval{ID: string(task.ID), Kind: valueobject.SubmittedApprovalTaskCreation,
+					TaskID: task.ID, Status: status, SubmittedAt: task.CreatedAt})
+			}
+		}
+		for _, submission := range task.WorkSubmissions {
+			if wants(valueobject.SubmittedApprovalTaskCompletion) && submission.SubmitterID == criteria.RequesterID {
+				add(valueobject.SubmittedApproval{ID: string(submission.ID), Kind: valueobject.SubmittedApprovalTaskCompletion,
+					TaskID: task.ID, Status: valueobject.ApprovalStatus(submission.Status), SubmittedAt: submission.SubmittedAt})
+			}
+		}
+		for _, ext := range task.Extensions {
+			if wants(valueobject.SubmittedApprovalExtensionRequest) && ext.RequesterID == criteria.RequesterID {
+				add(valueobject.SubmittedApproval{ID: string(ext.ID), Kind: valueobject.SubmittedApprovalExtensionRequest,
+					TaskID: task.ID, Status: valueobject.ApprovalStatus(ext.Status), SubmittedAt: ext.RequestedAt})
+			}
+		}
+	}
+	sort.SliceStable(approvals, func(i, j int) bool {
+		if !approvals[i].SubmittedAt.Equal(approvals[j].SubmittedAt) {
+			return approvals[i].SubmittedAt.After(approvals[j].SubmittedAt)
+		}
+		return approvals[i].ID < approvals[j].ID
+	})
+
+	return paginate(approvals, criteria.Limit, criteria.Offset), len(approvals), nil
+}
+
+// FindWorkSubmissionsByTask 查找任务的工作提交，按提交时间倒序
+func (r *MemoryTaskRepository) FindWorkSubmissionsByTask(ctx context.Context, taskID valueobject.TaskID) ([]valueobject.WorkSubmission, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[taskID]
+	if !ok {
+		return []valueobject.WorkSubmission{}, nil
+	}
+	// 先按提交顺序倒排，提交时间相同时后提交的在前
+	submissions := make([]valueobject.WorkSubmission, 0, len(task.WorkSubmissions))
+	for i := len(task.WorkSubmissions) - 1; i >= 0; i-- {
+		submissions = append(submissions, task.WorkSubmissions[i])
+	}
+	sort.SliceStable(submissions, func(i, j int) bool {
+		return submissions[i].SubmittedAt.After(submissions[j].SubmittedAt)
+	})
+	return submissions, nil
+}
+
+// CountByProject 统计项目下的任务数
+func (r *MemoryTaskRepository) CountByProject(ctx context.Context, projectID valueobject.ProjectID) (int, error) {
+	tasks, _ := r.FindByProject(ctx, projectID)
+	return len(tasks), nil
+}
+
+// CountByStatus 统计指定状态的任务数
+func (r *MemoryTaskRepository) CountByStatus(ctx context.Context, status valueobject.TaskStatus) (int, error) {
+	tasks, _ := r.FindByStatus(ctx, status)
+	return len(tasks), nil
+}
+
+// CountByResponsible 统计用户负责的任务数
+func (r *MemoryTaskRepository) CountByResponsible(ctx context.Context, responsibleID valueobject.UserID) (int, error) {
+	tasks, _ := r.FindByResponsible(ctx, responsibleID)
+	return len(tasks), nil
+}
+
+// CountWorkloadByResponsible 逐条统计负责人的未结束和逾期任务
+func (r *MemoryTaskRepository) CountWorkloadByResponsible(ctx context.Context, responsibleIDs []valueobject.UserID) (map[valueobject.UserID]valueobject.TaskWorkload, error) {
+	now := time.Now()
+	result := make(map[valueobject.UserID]valueobject.TaskWorkload, len(responsibleIDs))
+	for _, id := range responsibleIDs {
+		workload := valueobject.TaskWorkload{ResponsibleID: id}
+		for _, t := range r.filter(func(t aggregate.TaskAggregate) bool { return t.IsResponsible(id) && !isTaskClosed(t.Status) }) {
+			workload.OpenTasks++
+			if t.DueDate != nil && t.DueDate.Before(now) {
+				workload.OverdueTasks++
+			}
+		}
+		result[id] = workload
+	}
+	return result, nil
+}
+
+// GetTaskStatistics 获取任务统计信息，逐条累加工作提交
+func (r *MemoryTaskRepository) GetTaskStatistics(ctx context.Context, taskID valueobject.TaskID) (*valueobject.TaskStatistics, error) {
+	task, err := r.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &valueobject.TaskStatistics{
+		TaskID:             task.ID,
+		TotalParticipants:  task.GetParticipantCount(),
+		ActiveParticipants: task.GetActiveParticipantCount(),
+	}
+	for _, submission := range task.WorkSubmissions {
+		stats.AddExecutions(submission.Status, 1, submission.SubmittedAt)
+	}
+	stats.Finalize(task.ActualHours)
+	return stats, nil
+}
+
+// GetProjectTaskStatistics 获取项目任务统计信息，逐条累加，作为SQL聚合的对照实现
+func (r *MemoryTaskRepository) GetProjectTaskStatistics(ctx context.Context, projectID valueobject.ProjectID) (*valueobject.ProjectTaskStatistics, error) {
+	tasks, _ := r.FindByProject(ctx, projectID)
+	return taskStatistics(projectID, tasks), nil
+}
+
+// GetAllTaskStatistics 统计全部项目的任务
+func (r *MemoryTaskRepository) GetAllTaskStatistics(ctx context.Context) (*valueobject.ProjectTaskStatistics, error) {
+	return taskStatistics("", r.filter(func(aggregate.TaskAggregate) bool { return true })), nil
+}
+
+// GetProjectsTaskStatistics 逐个项目统计任务
+func (r *MemoryTaskRepository) GetProjectsTaskStatistics(ctx context.Context, projectIDs []valueobject.ProjectID) (map[valueobject.ProjectID]*valueobject.ProjectTaskStatistics, error) {
+	result := make(map[valueobject.ProjectID]*valueobject.ProjectTaskStatistics, len(projectIDs))
+	for _, id := range projectIDs {
+		result[id], _ = r.GetProjectTaskStatistics(ctx, id)
+	}
+	return result, nil
+}
+
+// taskStatistics 逐条累加任务统计，逾期不含已完成和已取消的任务
+func taskStatistics(projectID valueobject.ProjectID, tasks []aggregate.TaskAggregate) *valueobject.ProjectTaskStatistics {
+	now := time.Now()
+	stats := valueobject.NewProjectTaskStatistics(projectID)
+	for _, t := range tasks {
+		overdue := 0
+		if t.DueDate != nil && t.DueDate.Before(now) &&
+			t.Status != valueobject.TaskStatusCompleted && t.Status != valueobject.TaskStatusCancelled {
+			overdue = 1
+		}
+		stats.Add(t.Status, t.Priority, t.TaskType, 1, overdue, t.ActualHours)
+	}
+	stats.Finalize()
+	return stats
+}
+
+// filter 按条件筛选任务，结果按创建时间升序
+func (r *MemoryTaskRepository) filter(match func(aggregate.TaskAggregate) bool) []aggregate.TaskAggregate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.TaskAggregate, 0)
+	for _, task := range r.tasks {
+		if match(task) {
+			result = append(result, cloneTask(task))
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// cloneTask 复制任务的切片字段，避免调用方修改仓储中的数据
+func cloneTask(task aggregate.TaskAggregate) aggregate.TaskAggregate {
+	task.Participants = append([]valueobject.TaskParticipant(nil), task.Participants...)
+	task.Extensions = append([]valueobject.ExtensionRequest(nil), task.Extensions...)
+	task.WorkSubmissions = append([]valueobject.WorkSubmission(nil), task.WorkSubmissions...)
+	for i := range task.WorkSubmissions {
+		task.WorkSubmissions[i].Decisions = append([]valueobject.ReviewDecision(nil), task.WorkSubmissions[i].Decisions...)
+	}
+	task.CoResponsibleIDs = append([]valueobject.UserID(nil), task.CoResponsibleIDs...)
+	task.Reviewers = append([]valueobject.UserID(nil), task.Reviewers...)
+	task.Tags = append([]string(nil), task.Tags...)
+	task.Events = nil
+	return task
+}
+
+// isTaskClosed 任务是否已结束
+func isTaskClosed(status valueobject.TaskStatus) bool {
+	return status == valueobject.TaskStatusCompleted || status == valueobject.TaskStatusCancelled
+}
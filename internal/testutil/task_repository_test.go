@@ -0,0 +1,49 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+func newTask(id valueobject.TaskID) aggregate.TaskAggregate {
+	dueDate := time.Now().Add(72 * time.Hour)
+	return *aggregate.NewTask(id, "Task "+string(id), "", valueobject.TaskTypeRegular,
+		valueobject.TaskPriorityMedium, "project-1", "creator-1", "responsible-1", &dueDate)
+}
+
+func TestMemoryTaskRepository_CreateAndUpdateSemantics(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryTaskRepository()
+
+	require.NoError(t, repo.Create(ctx, newTask("task-1")))
+
+	assert.ErrorIs(t, repo.Create(ctx, newTask("task-1")), repository.ErrAlreadyExists)
+	assert.ErrorIs(t, repo.Update(ctx, newTask("task-2")), repository.ErrNotFound)
+	_, err := repo.FindByID(ctx, "task-2")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestMemoryTaskRepository_ReturnsIsolatedCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryTaskRepository(newTask("task-1"))
+
+	loaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	require.NoError(t, loaded.AddParticipant("user-1", "creator-1"))
+
+	reloaded, err := repo.FindByID(ctx, "task-1")
+	require.NoError(t, err)
+	assert.False(t, reloaded.IsParticipant("user-1"), "unsaved changes must not leak into the repository")
+
+	require.NoError(t, repo.Update(ctx, *loaded))
+	participantTasks, err := repo.FindByParticipant(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, participantTasks, 1)
+}
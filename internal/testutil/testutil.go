@@ -0,0 +1,47 @@
+// Package testutil 提供领域/应用服务测试用的内存仓储实现
+// 不依赖MySQL，数据只保存在进程内存中，仅用于测试
+package testutil
+
+import (
+	"strings"
+
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// paginate 按limit/offset截取结果，limit<=0表示不限制
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// matchesTerm 按搜索关键字匹配文本，与MySQL仓储一致：关键字为空或规范化后过短时不过滤
+func matchesTerm(text string, term *string, prefix bool) bool {
+	if term == nil {
+		return true
+	}
+	normalized, ok := valueobject.NormalizeSearchTerm(*term)
+	if !ok {
+		return true
+	}
+	if prefix {
+		return strings.HasPrefix(text, normalized)
+	}
+	return strings.Contains(text, normalized)
+}
+
+// stringValue 可为空的字符串，nil 视为空字符串
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,182 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+	"github.com/taskflow/internal/domain/valueobject"
+)
+
+// MemoryUserRepository 内存用户仓储，仅用于测试
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[valueobject.UserID]aggregate.User
+}
+
+// NewMemoryUserRepository 创建内存用户仓储
+func NewMemoryUserRepository(users ...*aggregate.User) *MemoryUserRepository {
+	r := &MemoryUserRepository{users: make(map[valueobject.UserID]aggregate.User)}
+	for _, user := range users {
+		r.users[user.ID] = cloneUser(user)
+	}
+	return r
+}
+
+var _ repository.UserRepository = (*MemoryUserRepository)(nil)
+
+// Save 保存用户，已存在时覆盖
+func (r *MemoryUserRepository) Save(ctx context.Context, user *aggregate.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[user.ID] = cloneUser(user)
+	return nil
+}
+
+// FindByID 根据ID查找用户
+func (r *MemoryUserRepository) FindByID(ctx context.Context, id string) (*aggregate.User, error) {
+	users := r.filter(func(u aggregate.User) bool { return string(u.ID) == id })
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s: %w", id, repository.ErrNotFound)
+	}
+	return users[0], nil
+}
+
+// FindByIDForUpdate 内存仓储没有行锁，与 FindByID 相同
+func (r *MemoryUserRepository) FindByIDForUpdate(ctx context.Context, id string) (*aggregate.User, error) {
+	return r.FindByID(ctx, id)
+}
+
+// FindByEmail 根据邮箱查找用户
+func (r *MemoryUserRepository) FindByEmail(ctx context.Context, email string) (*aggregate.User, error) {
+	users := r.filter(func(u aggregate.User) bool { return u.Email == email })
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user with email %s: %w", email, repository.ErrNotFound)
+	}
+	return users[0], nil
+}
+
+// FindByUsername 根据用户名查找用户
+func (r *MemoryUserRepository) FindByUsername(ctx context.Context, username string) (*aggregate.User, error) {
+	users := r.filter(func(u aggregate.User) bool { return u.Username == username })
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user with username %s: %w", username, repository.ErrNotFound)
+	}
+	return users[0], nil
+}
+
+// Delete 删除用户
+func (r *MemoryUserRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, valueobject.UserID(id))
+	return nil
+}
+
+// Update 更新用户，用户不存在时返回 ErrNotFound
+func (r *MemoryUserRepository) Update(ctx context.Context, user *aggregate.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; !ok {
+		return fmt.Errorf("user %s: %w", user.ID, repository.ErrNotFound)
+	}
+	r.users[user.ID] = cloneUser(user)
+	return nil
+}
+
+// FindByRole 按角色查找用户
+func (r *MemoryUserRepository) FindByRole(ctx context.Context, role valueobject.UserRole) ([]*aggregate.User, error) {
+	return r.filter(func(u aggregate.User) bool { return u.Role == role }), nil
+}
+
+// FindByStatus 按状态查找用户
+func (r *MemoryUserRepository) FindByStatus(ctx context.Context, status valueobject.UserStatus) ([]*aggregate.User, error) {
+	return r.filter(func(u aggregate.User) bool { return u.Status == status }), nil
+}
+
+// FindByDepartment 查找部门下的用户
+func (r *MemoryUserRepository) FindByDepartment(ctx context.Context, departmentID string) ([]*aggregate.User, error) {
+	return r.filter(func(u aggregate.User) bool { return u.DepartmentID != nil && *u.DepartmentID == departmentID }), nil
+}
+
+// FindByManager 查找经理的直接下属
+func (r *MemoryUserRepository) FindByManager(ctx context.Context, managerID valueobject.UserID) ([]*aggregate.User, error) {
+	return r.filter(func(u aggregate.User) bool { return u.ManagerID != nil && *u.ManagerID == managerID }), nil
+}
+
+// SearchUsers 按条件搜索用户，用户名、邮箱和姓名为包含匹配
+func (r *MemoryUserRepository) SearchUsers(ctx context.Context, criteria valueobject.UserSearchCriteria) ([]*aggregate.User, int, error) {
+	matched := r.filter(func(u aggregate.User) bool {
+		switch {
+		case criteria.Username != nil && !strings.Contains(u.Username, *criteria.Username):
+			return false
+		case criteria.Email != nil && !strings.Contains(u.Email, *criteria.Email):
+			return false
+		case criteria.FullName != nil && !strings.Contains(u.FullName, *criteria.FullName):
+			return false
+		case criteria.Role != nil && u.Role != *criteria.Role:
+			return false
+		case criteria.Status != nil && u.Status != *criteria.Status:
+			return false
+		case criteria.DepartmentID != nil && (u.DepartmentID == nil || *u.DepartmentID != *criteria.DepartmentID):
+			return false
+		case criteria.ManagerID != nil && (u.ManagerID == nil || *u.ManagerID != *criteria.ManagerID):
+			return false
+		}
+		return true
+	})
+	return paginate(matched, criteria.Limit, criteria.Offset), len(matched), nil
+}
+
+// FindUsersByRole 按角色名分页查找用户
+func (r *MemoryUserRepository) FindUsersByRole(ctx context.Context, roleName string, limit, offset int) ([]*aggregate.User, int, error) {
+	matched := r.filter(func(u aggregate.User) bool { return string(u.Role) == roleName })
+	return paginate(matched, limit, offset), len(matched), nil
+}
+
+// CountByStatus 统计指定状态的用户数
+func (r *MemoryUserRepository) CountByStatus(ctx context.Context, status valueobject.UserStatus) (int, error) {
+	users, _ := r.FindByStatus(ctx, status)
+	return len(users), nil
+}
+
+// CountByDepartment 统计部门下的用户数
+func (r *MemoryUserRepository) CountByDepartment(ctx context.Context, department string) (int, error) {
+	users, _ := r.FindByDepartment(ctx, department)
+	return len(users), nil
+}
+
+// filter 按条件筛选用户，结果按创建时间升序
+func (r *MemoryUserRepository) filter(match func(aggregate.User) bool) []*aggregate.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*aggregate.User, 0)
+	for _, user := range r.users {
+		if match(user) {
+			clone := user
+			result = append(result, &clone)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// cloneUser 复制用户并清空未发布的领域事件
+func cloneUser(user *aggregate.User) aggregate.User {
+	clone := *user
+	clone.ClearEvents()
+	return clone
+}
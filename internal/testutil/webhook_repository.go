@@ -0,0 +1,164 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/taskflow/internal/domain/aggregate"
+	"github.com/taskflow/internal/domain/repository"
+)
+
+// MemoryWebhookRepository 内存webhook仓储，仅用于测试
+type MemoryWebhookRepository struct {
+	mu         sync.RWMutex
+	webhooks   map[string]aggregate.Webhook
+	deliveries []aggregate.WebhookDelivery
+}
+
+// NewMemoryWebhookRepository 创建内存webhook仓储
+func NewMemoryWebhookRepository(webhooks ...aggregate.Webhook) *MemoryWebhookRepository {
+	r := &MemoryWebhookRepository{webhooks: make(map[string]aggregate.Webhook)}
+	for _, webhook := range webhooks {
+		r.webhooks[webhook.ID] = cloneWebhook(webhook)
+	}
+	return r
+}
+
+var _ repository.WebhookRepository = (*MemoryWebhookRepository)(nil)
+
+// Create 新建webhook，ID已存在时返回 ErrAlreadyExists
+func (r *MemoryWebhookRepository) Create(ctx context.Context, webhook aggregate.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[webhook.ID]; ok {
+		return fmt.Errorf("webhook %s: %w", webhook.ID, repository.ErrAlreadyExists)
+	}
+	r.webhooks[webhook.ID] = cloneWebhook(webhook)
+	return nil
+}
+
+// Update 更新webhook，不存在时返回 ErrNotFound
+func (r *MemoryWebhookRepository) Update(ctx context.Context, webhook aggregate.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[webhook.ID]; !ok {
+		return fmt.Errorf("webhook %s: %w", webhook.ID, repository.ErrNotFound)
+	}
+	r.webhooks[webhook.ID] = cloneWebhook(webhook)
+	return nil
+}
+
+// Delete 删除webhook，不存在时返回 ErrNotFound
+func (r *MemoryWebhookRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return fmt.Errorf("webhook %s: %w", id, repository.ErrNotFound)
+	}
+	delete(r.webhooks, id)
+	return nil
+}
+
+// FindByID 根据ID查找webhook
+func (r *MemoryWebhookRepository) FindByID(ctx context.Context, id string) (*aggregate.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("webhook %s: %w", id, repository.ErrNotFound)
+	}
+	clone := cloneWebhook(webhook)
+	return &clone, nil
+}
+
+// FindAll 查找全部webhook，按创建时间排序
+func (r *MemoryWebhookRepository) FindAll(ctx context.Context) ([]aggregate.Webhook, error) {
+	return r.filter(func(aggregate.Webhook) bool { return true }), nil
+}
+
+// FindActive 查找启用中的webhook
+func (r *MemoryWebhookRepository) FindActive(ctx context.Context) ([]aggregate.Webhook, error) {
+	return r.filter(func(w aggregate.Webhook) bool { return w.Active }), nil
+}
+
+// SaveDelivery 保存投递记录
+func (r *MemoryWebhookRepository) SaveDelivery(ctx context.Context, delivery aggregate.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+// FindDeliveries 查找webhook的投递记录，按投递时间倒序，limit<=0表示不限制
+func (r *MemoryWebhookRepository) FindDeliveries(ctx context.Context, webhookID string, limit int) ([]aggregate.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.WebhookDelivery, 0)
+	for _, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			result = append(result, d)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].DeliveredAt.After(result[j].DeliveredAt)
+	})
+	return paginate(result, limit, 0), nil
+}
+
+// FindDeliveryAttempts 查找同一投递的全部尝试，按尝试次数升序
+func (r *MemoryWebhookRepository) FindDeliveryAttempts(ctx context.Context, deliveryID string) ([]aggregate.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.WebhookDelivery, 0)
+	for _, d := range r.deliveries {
+		if d.DeliveryID == deliveryID {
+			result = append(result, d)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("webhook delivery %s: %w", deliveryID, repository.ErrNotFound)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Attempt < result[j].Attempt })
+	return result, nil
+}
+
+func (r *MemoryWebhookRepository) filter(match func(aggregate.Webhook) bool) []aggregate.Webhook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]aggregate.Webhook, 0)
+	for _, webhook := range r.webhooks {
+		if match(webhook) {
+			result = append(result, cloneWebhook(webhook))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// cloneWebhook 深拷贝webhook，避免调用方修改仓储内部状态
+func cloneWebhook(w aggregate.Webhook) aggregate.Webhook {
+	clone := w
+	if w.EventTypes != nil {
+		clone.EventTypes = append([]string(nil), w.EventTypes...)
+	}
+	if w.DisabledAt != nil {
+		disabledAt := *w.DisabledAt
+		clone.DisabledAt = &disabledAt
+	}
+	return clone
+}
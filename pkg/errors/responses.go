@@ -46,6 +46,23 @@ func RespondWithError(c *gin.Context, statusCode int, code, message string) {
 	c.Abort()
 }
 
+// RespondWithValidationError 请求参数验证失败响应，details 为字段级错误列表
+func RespondWithValidationError(c *gin.Context, details interface{}) {
+	logger.Debug("HTTP Validation Error",
+		zap.String("path", c.Request.URL.Path),
+		zap.String("method", c.Request.Method),
+		zap.Any("details", details),
+	)
+
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error:   http.StatusText(http.StatusBadRequest),
+		Code:    "VALIDATION_FAILED",
+		Message: "请求参数验证失败",
+		Details: details,
+	})
+	c.Abort()
+}
+
 // respondWithSuccess 统一成功响应
 func RespondWithSuccess(c *gin.Context, data interface{}, message string) {
 	response := SuccessResponse{
@@ -0,0 +1,11 @@
+-- ================================================
+-- 用户时区
+-- 版本: 005
+-- 描述: 时间统一以UTC存储，响应时按用户时区渲染
+-- ================================================
+
+SET NAMES utf8mb4;
+
+-- 为用户表添加时区字段（IANA时区名，如 Asia/Shanghai）
+ALTER TABLE `users`
+ADD COLUMN `timezone` VARCHAR(64) NULL COMMENT '用户时区' AFTER `manager_id`;
@@ -0,0 +1,10 @@
+-- ================================================
+-- 任务开放协作
+-- 版本: 006
+-- 描述: 开放协作的任务允许项目成员提交工作时自动加入为参与者
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `open_contribution` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '是否开放协作' AFTER `workflow_id`;
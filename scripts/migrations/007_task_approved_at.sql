@@ -0,0 +1,15 @@
+-- ================================================
+-- 任务审批通过时间
+-- 版本: 007
+-- 描述: 记录任务最近一次审批通过的时间，用于统计已审批未开始的任务
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `approved_at` TIMESTAMP NULL DEFAULT NULL COMMENT '审批通过时间' AFTER `completed_at`,
+ADD INDEX `idx_tasks_status_approved_at` (`status`, `approved_at`);
+
+-- 已审批的历史任务以最后更新时间作为审批通过时间
+UPDATE `tasks` SET `approved_at` = `updated_at`
+WHERE `status` = 'approved' AND `approved_at` IS NULL;
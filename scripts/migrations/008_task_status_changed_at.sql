@@ -0,0 +1,15 @@
+-- ================================================
+-- 任务状态变更时间
+-- 版本: 008
+-- 描述: 记录任务进入当前状态的时间，用于统计任务在各状态的停留时长
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `status_changed_at` TIMESTAMP NULL DEFAULT NULL COMMENT '进入当前状态的时间' AFTER `status`,
+ADD INDEX `idx_tasks_project_status_changed_at` (`project_id`, `status`, `status_changed_at`);
+
+-- 历史任务以最后更新时间作为进入当前状态的时间
+UPDATE `tasks` SET `status_changed_at` = `updated_at`
+WHERE `status_changed_at` IS NULL;
@@ -0,0 +1,10 @@
+-- ================================================
+-- 领域事件按类型和时间查询的索引
+-- 版本: 009
+-- 描述: 支持外部系统按事件类型和时间窗口分页拉取事件（按 occurred_at、id 排序）
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `domain_events`
+ADD INDEX `idx_domain_events_type_occurred` (`event_type`, `occurred_at`, `id`);
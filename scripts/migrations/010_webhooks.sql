@@ -0,0 +1,37 @@
+-- ================================================
+-- Webhook订阅及投递记录
+-- 版本: 010
+-- 描述: 将领域事件以签名的HTTP POST推送给外部订阅方，并记录每次投递尝试
+-- ================================================
+
+SET NAMES utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `webhooks` (
+    `id` VARCHAR(36) PRIMARY KEY,
+    `url` VARCHAR(500) NOT NULL COMMENT '订阅方地址',
+    `secret` VARCHAR(255) NOT NULL COMMENT 'HMAC-SHA256签名密钥',
+    `event_types` JSON NOT NULL COMMENT '订阅的事件类型，空数组表示全部',
+    `active` BOOLEAN DEFAULT TRUE,
+    `failure_count` INT DEFAULT 0 COMMENT '连续投递失败次数',
+    `created_by` VARCHAR(36) NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `disabled_at` TIMESTAMP NULL DEFAULT NULL COMMENT '停用时间',
+
+    INDEX `idx_webhooks_active` (`active`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='webhook订阅表';
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+    `id` VARCHAR(36) PRIMARY KEY,
+    `webhook_id` VARCHAR(36) NOT NULL,
+    `event_id` VARCHAR(36) NOT NULL,
+    `event_type` VARCHAR(100) NOT NULL,
+    `attempt` INT NOT NULL COMMENT '同一事件的第几次尝试',
+    `status_code` INT DEFAULT 0 COMMENT '响应状态码，请求未发出时为0',
+    `success` BOOLEAN DEFAULT FALSE,
+    `error` TEXT,
+    `duration_ms` BIGINT DEFAULT 0,
+    `delivered_at` TIMESTAMP(3) NOT NULL,
+
+    INDEX `idx_webhook_deliveries_webhook_time` (`webhook_id`, `delivered_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='webhook投递记录表';
@@ -0,0 +1,17 @@
+-- ================================================
+-- Webhook投递ID去重
+-- 版本: 011
+-- 描述: 投递记录增加稳定的投递ID（同一事件的重试和重投共用），并保存原始请求体用于手动重投
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `webhook_deliveries`
+    ADD COLUMN `delivery_id` VARCHAR(36) NOT NULL DEFAULT '' COMMENT '投递ID，订阅方据此去重' AFTER `id`,
+    ADD COLUMN `redelivery` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '是否为管理员手动重投' AFTER `attempt`,
+    ADD COLUMN `payload` MEDIUMTEXT NULL COMMENT '原始请求体' AFTER `redelivery`;
+
+-- 历史记录没有投递ID，以尝试ID作为各自的投递ID
+UPDATE `webhook_deliveries` SET `delivery_id` = `id` WHERE `delivery_id` = '';
+
+CREATE INDEX `idx_webhook_deliveries_delivery_id` ON `webhook_deliveries` (`delivery_id`);
@@ -0,0 +1,10 @@
+-- ================================================
+-- 任务随项目暂停标记
+-- 版本: 012
+-- 描述: 记录任务是否因项目暂停而被级联暂停，项目恢复时只恢复这些任务
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `paused_by_project` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '是否随项目暂停' AFTER `open_contribution`;
@@ -0,0 +1,10 @@
+-- ================================================
+-- 任务参与者角色
+-- 版本: 013
+-- 描述: 记录参与者在任务中的角色（执行者、审核者、观察者、协助者），旧数据默认为执行者
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `task_participants`
+ADD COLUMN `role` VARCHAR(20) NOT NULL DEFAULT 'executor' COMMENT '参与者角色' AFTER `user_id`;
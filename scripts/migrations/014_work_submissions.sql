@@ -0,0 +1,24 @@
+-- ================================================
+-- 工作提交记录
+-- 版本: 014
+-- 描述: 持久化参与者提交的工作内容及附件，供审核者查阅并记录审核结果
+-- ================================================
+
+SET NAMES utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `work_submissions` (
+    `id` VARCHAR(36) PRIMARY KEY,
+    `task_id` VARCHAR(36) NOT NULL COMMENT '任务ID',
+    `submitter_id` VARCHAR(36) NOT NULL COMMENT '提交人ID',
+    `content` TEXT NOT NULL COMMENT '工作内容',
+    `attachments` JSON NOT NULL COMMENT '附件列表',
+    `status` ENUM('pending', 'approved', 'rejected') DEFAULT 'pending' COMMENT '审核状态',
+    `submitted_at` TIMESTAMP(3) NOT NULL COMMENT '提交时间',
+    `reviewed_at` TIMESTAMP NULL COMMENT '审核时间',
+    `reviewer_id` VARCHAR(36) DEFAULT NULL COMMENT '审核人ID',
+    `review_comment` TEXT COMMENT '审核意见',
+
+    FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE,
+
+    INDEX `idx_work_submissions_task_time` (`task_id`, `submitted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='工作提交记录表';
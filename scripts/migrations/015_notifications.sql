@@ -0,0 +1,28 @@
+-- ================================================
+-- 站内通知
+-- 版本: 015
+-- 描述: 领域事件触发时为开启站内通知的用户写入通知，用户可查看并标记已读
+-- ================================================
+
+SET NAMES utf8mb4;
+
+-- 用户站内通知开关，默认开启
+ALTER TABLE `users`
+ADD COLUMN `in_app_notifications` BOOLEAN NOT NULL DEFAULT TRUE COMMENT '是否接收站内通知' AFTER `timezone`;
+
+CREATE TABLE IF NOT EXISTS `notifications` (
+    `id` VARCHAR(36) PRIMARY KEY,
+    `user_id` VARCHAR(36) NOT NULL COMMENT '接收人ID',
+    `type` VARCHAR(100) NOT NULL COMMENT '通知类型（触发的事件类型）',
+    `title` VARCHAR(255) NOT NULL COMMENT '标题',
+    `body` TEXT NOT NULL COMMENT '内容',
+    `resource_type` VARCHAR(50) DEFAULT NULL COMMENT '关联资源类型',
+    `resource_id` VARCHAR(36) DEFAULT NULL COMMENT '关联资源ID',
+    `is_read` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '是否已读',
+    `read_at` TIMESTAMP NULL COMMENT '已读时间',
+    `created_at` TIMESTAMP(3) NOT NULL COMMENT '创建时间',
+
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+
+    INDEX `idx_notifications_user_read` (`user_id`, `is_read`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='站内通知表';
@@ -0,0 +1,10 @@
+-- ================================================
+-- 任务重复规则
+-- 版本: 016
+-- 描述: 以JSON记录重复任务的重复规则（频率、间隔、结束时间、最大执行次数），非重复任务为NULL
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `recurrence_rule` JSON NULL COMMENT '重复规则' AFTER `workflow_id`;
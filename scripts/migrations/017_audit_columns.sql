@@ -0,0 +1,19 @@
+-- ================================================
+-- 任务和项目的审计列
+-- 版本: 017
+-- 描述: 记录项目的创建人以及任务、项目的最后修改人，由仓储在写入时按当前操作用户填充；
+--       后台任务等未认证的写入保留原修改人。已有项目的创建人以所有者回填
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `updated_by` VARCHAR(36) DEFAULT NULL COMMENT '最后修改人ID' AFTER `updated_at`;
+
+ALTER TABLE `projects`
+ADD COLUMN `created_by` VARCHAR(36) DEFAULT NULL COMMENT '创建人ID' AFTER `updated_at`,
+ADD COLUMN `updated_by` VARCHAR(36) DEFAULT NULL COMMENT '最后修改人ID' AFTER `created_by`;
+
+UPDATE `projects` SET `created_by` = `owner_id`, `updated_by` = `owner_id` WHERE `created_by` IS NULL;
+
+UPDATE `tasks` SET `updated_by` = `creator_id` WHERE `updated_by` IS NULL;
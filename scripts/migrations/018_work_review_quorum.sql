@@ -0,0 +1,14 @@
+-- ================================================
+-- 多人工作审核
+-- 版本: 018
+-- 描述: 任务可指定多名工作审核人及通过所需人数，工作提交以JSON记录各审核人的意见
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `reviewers` JSON NULL COMMENT '工作审核人' AFTER `recurrence_rule`,
+ADD COLUMN `review_quorum` INT NOT NULL DEFAULT 0 COMMENT '工作通过所需的审核通过人数' AFTER `reviewers`;
+
+ALTER TABLE `work_submissions`
+ADD COLUMN `review_decisions` JSON NULL COMMENT '各审核人的审核意见' AFTER `review_comment`;
@@ -0,0 +1,27 @@
+-- ================================================
+-- 截止日期提醒
+-- 版本: 019
+-- 描述: 记录按提醒梯度已发送的截止日期提醒，避免重复提醒；用户确认后不再提醒该任务
+-- ================================================
+
+SET NAMES utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `task_reminders` (
+    `task_id` VARCHAR(36) NOT NULL COMMENT '任务ID',
+    `user_id` VARCHAR(36) NOT NULL COMMENT '接收人ID',
+    `due_date` TIMESTAMP NOT NULL COMMENT '提醒时的截止时间，截止日期变更后重新提醒',
+    `offset_minutes` INT NOT NULL COMMENT '提醒梯度，截止时间前的提前分钟数',
+    `sent_at` TIMESTAMP(3) NOT NULL COMMENT '发送时间',
+
+    PRIMARY KEY (`task_id`, `user_id`, `due_date`, `offset_minutes`),
+    FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='截止日期提醒记录表';
+
+CREATE TABLE IF NOT EXISTS `task_reminder_acks` (
+    `task_id` VARCHAR(36) NOT NULL COMMENT '任务ID',
+    `user_id` VARCHAR(36) NOT NULL COMMENT '确认人ID',
+    `acknowledged_at` TIMESTAMP(3) NOT NULL COMMENT '确认时间',
+
+    PRIMARY KEY (`task_id`, `user_id`),
+    FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='截止日期提醒确认表';
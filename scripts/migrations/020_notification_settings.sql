@@ -0,0 +1,11 @@
+-- ================================================
+-- 通知偏好设置
+-- 版本: 020
+-- 描述: 用户可设置邮件、短信、推送渠道开关、提醒与升级间隔及静默时段，通知发送前据此过滤
+-- ================================================
+
+SET NAMES utf8mb4;
+
+-- 为空时使用默认设置：开启邮件和推送，关闭短信，不设静默时段
+ALTER TABLE `users`
+ADD COLUMN `notification_settings` JSON NULL COMMENT '通知偏好设置' AFTER `in_app_notifications`;
@@ -0,0 +1,16 @@
+-- ================================================
+-- 项目任务计数
+-- 版本: 021
+-- 描述: 项目保存任务总数和已完成数，任务变化时按任务表重新统计，避免并发更新丢失计数
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `projects`
+ADD COLUMN `task_count` INT NOT NULL DEFAULT 0 COMMENT '任务总数' AFTER `end_date`,
+ADD COLUMN `completed_tasks` INT NOT NULL DEFAULT 0 COMMENT '已完成任务数' AFTER `task_count`;
+
+-- 按现有任务回填计数
+UPDATE `projects` p SET
+    `task_count` = (SELECT COUNT(*) FROM `tasks` t WHERE t.`project_id` = p.`id` AND t.`deleted_at` IS NULL),
+    `completed_tasks` = (SELECT COUNT(*) FROM `tasks` t WHERE t.`project_id` = p.`id` AND t.`status` = 'completed' AND t.`deleted_at` IS NULL);
@@ -0,0 +1,10 @@
+-- ================================================
+-- 任务共同负责人
+-- 版本: 022
+-- 描述: 任务除负责人（牵头人）外可指定多名共同负责人，以JSON数组存储
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `tasks`
+ADD COLUMN `co_responsibles` JSON NULL COMMENT '共同负责人' AFTER `assignee_id`;
@@ -0,0 +1,22 @@
+-- ================================================
+-- 用户API密钥
+-- 版本: 023
+-- 描述: 用户可创建带作用域的API密钥，以 X-Api-Key 请求头代替JWT访问接口；只保存密钥的SHA-256哈希
+-- ================================================
+
+SET NAMES utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `api_keys` (
+    `id` VARCHAR(36) PRIMARY KEY,
+    `user_id` VARCHAR(36) NOT NULL COMMENT '所属用户',
+    `name` VARCHAR(100) NOT NULL COMMENT '密钥名称',
+    `prefix` VARCHAR(20) NOT NULL COMMENT '明文密钥前缀，用于展示',
+    `key_hash` CHAR(64) NOT NULL COMMENT '明文密钥的SHA-256哈希',
+    `scopes` JSON NOT NULL COMMENT '作用域：read、write',
+    `last_used_at` TIMESTAMP NULL DEFAULT NULL COMMENT '最近使用时间',
+    `revoked_at` TIMESTAMP NULL DEFAULT NULL COMMENT '吊销时间',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE INDEX `idx_api_keys_key_hash` (`key_hash`),
+    INDEX `idx_api_keys_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户API密钥表';
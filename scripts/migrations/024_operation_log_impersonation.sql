@@ -0,0 +1,11 @@
+-- ================================================
+-- 操作日志记录模拟登录
+-- 版本: 024
+-- 描述: 管理员模拟用户期间的操作记录在被模拟用户名下，impersonator_id 记录实际操作的管理员
+-- ================================================
+
+SET NAMES utf8mb4;
+
+ALTER TABLE `operation_logs`
+    ADD COLUMN `impersonator_id` VARCHAR(36) DEFAULT NULL COMMENT '模拟登录时实际操作的管理员' AFTER `user_id`,
+    ADD INDEX `idx_operation_logs_impersonator_id` (`impersonator_id`);
@@ -0,0 +1,23 @@
+-- ================================================
+-- 统一ABAC策略条件语法
+-- 版本: 025
+-- 描述: 将 002 中使用 subject.* 和 $eq/$in 的示例策略改写为评估器支持的 user.* 属性、eq/in 运算符和 ${...} 占位符
+-- ================================================
+
+SET NAMES utf8mb4;
+
+UPDATE `permission_policies`
+SET `conditions` = JSON_OBJECT('user.id', JSON_OBJECT('eq', '${resource.id}'))
+WHERE `id` = 'policy-user-self-read';
+
+UPDATE `permission_policies`
+SET `conditions` = JSON_OBJECT('user.id', JSON_OBJECT('in', '${resource.member_ids}'))
+WHERE `id` = 'policy-project-member-read';
+
+UPDATE `permission_policies`
+SET `conditions` = JSON_OBJECT('user.id', JSON_OBJECT('eq', '${resource.responsible_id}'))
+WHERE `id` = 'policy-task-responsible-manage';
+
+UPDATE `permission_policies`
+SET `conditions` = JSON_OBJECT('user.id', JSON_OBJECT('eq', '${resource.project.owner_id}'))
+WHERE `id` = 'policy-project-owner-manage-tasks';